@@ -0,0 +1,119 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	vclusterCLIConfigEnv = "VCLUSTER_CLI_CONFIG"
+	// the CLI config file is in the same directory as the default config file
+	cliConfigFileName = "cli.yaml"
+)
+
+// CLIConfig is the struct of cli.yaml, which holds the default values of
+// some flags shared by all the subcommands. The values in the command line,
+// the environment variables and vertica_cluster.yaml take precedence over them.
+type CLIConfig struct {
+	LogPath   string `yaml:"logPath"`
+	LogFormat string `yaml:"logFormat"`
+	LogLevel  string `yaml:"logLevel"`
+	LogOutput string `yaml:"logOutput"`
+	KeyFile   string `yaml:"keyFile"`
+	CertFile  string `yaml:"certFile"`
+	IPv6      *bool  `yaml:"ipv6"`
+}
+
+// getCLIConfigPath returns the path of cli.yaml, which can be set by the
+// VCLUSTER_CLI_CONFIG environment variable and is
+// $HOME/.config/vcluster/cli.yaml otherwise
+func getCLIConfigPath() string {
+	if val := os.Getenv(vclusterCLIConfigEnv); val != "" {
+		return val
+	}
+	cfgDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cfgDir, "vcluster", cliConfigFileName)
+}
+
+// readCLIConfig reads cli.yaml. A missing file is not an error, in which
+// case it returns nil.
+func readCLIConfig(configFilePath string) (*CLIConfig, error) {
+	if configFilePath == "" {
+		return nil, nil
+	}
+	configBytes, err := os.ReadFile(configFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fail to read CLI configuration file, details: %w", err)
+	}
+
+	var cliConfig CLIConfig
+	err = yaml.Unmarshal(configBytes, &cliConfig)
+	if err != nil {
+		return nil, fmt.Errorf("fail to unmarshal CLI configuration file %s, details: %w", configFilePath, err)
+	}
+	return &cliConfig, nil
+}
+
+// loadCLIConfig applies the defaults in cli.yaml to the flags of the command
+// that are not set in the command line
+func loadCLIConfig(cmd *cobra.Command) error {
+	cliConfig, err := readCLIConfig(getCLIConfigPath())
+	if err != nil || cliConfig == nil {
+		return err
+	}
+	cliConfig.applyDefaults(cmd)
+	return nil
+}
+
+func (c *CLIConfig) applyDefaults(cmd *cobra.Command) {
+	// the flags bound to viper keys get the lowest precedence in viper
+	if c.LogPath != "" {
+		viper.SetDefault(logPathKey, c.LogPath)
+	}
+	if c.KeyFile != "" {
+		viper.SetDefault(keyFileKey, c.KeyFile)
+	}
+	if c.CertFile != "" {
+		viper.SetDefault(certFileKey, c.CertFile)
+	}
+	if c.IPv6 != nil {
+		viper.SetDefault(ipv6Key, *c.IPv6)
+	}
+
+	// the other flags are only set if they are not in the command line
+	setIfNotChanged := func(flag, value string, target *string) {
+		if value != "" && cmd.Flags().Lookup(flag) != nil && !cmd.Flags().Changed(flag) {
+			*target = value
+		}
+	}
+	setIfNotChanged(logFormatFlag, c.LogFormat, &globals.logFormat)
+	setIfNotChanged(logLevelFlag, c.LogLevel, &globals.logLevel)
+	setIfNotChanged(logOutputFlag, c.LogOutput, &globals.logOutput)
+}
@@ -17,8 +17,10 @@ package commands
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/spf13/cobra"
@@ -45,6 +47,7 @@ const (
 	dbUserKey                   = "dbUser"
 	hostsFlag                   = "hosts"
 	hostsKey                    = "hosts"
+	hostsFileFlag               = "hosts-file"
 	catalogPathFlag             = "catalog-path"
 	catalogPathKey              = "catalogPath"
 	depotPathFlag               = "depot-path"
@@ -61,17 +64,38 @@ const (
 	configParamKey              = "configParam"
 	logPathFlag                 = "log-path"
 	logPathKey                  = "logPath"
+	logFormatFlag               = "log-format"
+	logLevelFlag                = "log-level"
+	logOutputFlag               = "log-output"
+	metricsListenFlag           = "metrics-listen"
+	auditLogFlag                = "audit-log"
+	captureHTTPDirFlag          = "capture-http-dir"
+	notifyURLFlag               = "notify-url"
+	maxConcurrentHostsFlag      = "max-concurrent-hosts"
+	localExecutionFlag          = "local-execution"
+	compressionThresholdFlag    = "compression-threshold"
+	reresolveHostsFlag          = "reresolve-hosts"
+	journalFlag                 = "journal"
+	useInstanceProfileFlag      = "use-instance-profile"
+	subnetFlag                  = "subnet"
+	resumeFlag                  = "resume"
 	keyFileFlag                 = "key-file"
 	keyFileKey                  = "keyFile"
 	certFileFlag                = "cert-file"
 	certFileKey                 = "certFile"
+	caCertFileFlag              = "ca-cert-file"
+	tlsVerifyModeFlag           = "tls-verify-mode"
+	tlsServerNameFlag           = "tls-server-name"
 	passwordFlag                = "password"
 	passwordKey                 = "password"
 	passwordFileFlag            = "password-file"
 	passwordFileKey             = "passwordFile"
 	readPasswordFromPromptFlag  = "read-password-from-prompt"
+	accessTokenFlag             = "access-token"
+	tokenFileFlag               = "token-file"
 	readPasswordFromPromptKey   = "readPasswordFromPrompt"
 	configFlag                  = "config"
+	dbProfileFlag               = "db-profile"
 	configKey                   = "config"
 	verboseFlag                 = "verbose"
 	verboseKey                  = "verbose"
@@ -81,9 +105,14 @@ const (
 	addNodeFlag                 = "new-hosts"
 	sandboxFlag                 = "sandbox"
 	sandboxKey                  = "sandbox"
+	archiveNameFlag             = "archive-name"
+	namespaceFlag               = "namespace"
 	connFlag                    = "conn"
 	connKey                     = "conn"
 	stopNodeFlag                = "stop-hosts"
+	followLogsFlag              = "follow-logs"
+	fromCommunalFlag            = "from-communal"
+	yesFlag                     = "yes"
 	// VER-90436: restart -> start
 	startNodeFlag = "restart"
 	startHostFlag = "start-hosts"
@@ -150,6 +179,7 @@ const (
 	createConnectionSubCmd  = "create_connection"
 	configRecoverSubCmd     = "recover"
 	configShowSubCmd        = "show"
+	configDiffSubCmd        = "diff"
 	replicationSubCmd       = "replication"
 	startReplicationSubCmd  = "start"
 	listAllNodesSubCmd      = "list_all_nodes"
@@ -157,6 +187,8 @@ const (
 	dropDBSubCmd            = "drop_db"
 	addSCSubCmd             = "add_subcluster"
 	removeSCSubCmd          = "remove_subcluster"
+	pauseSCSubCmd           = "pause_subcluster"
+	scaleSCSubCmd           = "scale_subcluster"
 	stopSCSubCmd            = "stop_subcluster"
 	addNodeSubCmd           = "add_node"
 	startSCSubCmd           = "start_subcluster"
@@ -166,18 +198,80 @@ const (
 	reIPSubCmd              = "re_ip"
 	sandboxSubCmd           = "sandbox_subcluster"
 	unsandboxSubCmd         = "unsandbox_subcluster"
+	showSandboxesSubCmd     = "show_sandboxes"
+	setKSafetySubCmd        = "set_ksafety"
+	rebalanceShardsSubCmd   = "rebalance_shards"
+	rebalanceClusterSubCmd  = "rebalance_cluster"
+	createArchiveSubCmd     = "create_archive"
+	removeArchiveSubCmd     = "remove_archive"
+	replaceNodeSubCmd       = "replace_node"
 	scrutinizeSubCmd        = "scrutinize"
 	showRestorePointsSubCmd = "show_restore_points"
 	installPkgSubCmd        = "install_packages"
+	killVerticaSubCmd       = "kill_vertica"
+	verifyCatalogSubCmd     = "verify_catalog"
+	alterDepotSizeSubCmd    = "alter_depot_size"
+	storageLocationSubCmd   = "storage_location"
+	addLocationSubCmd       = "add"
+	retireLocationSubCmd    = "retire"
+	alterLocationUsageCmd   = "alter_usage"
+	routingSubCmd           = "routing"
+	createGroupSubCmd       = "create_group"
+	alterGroupSubCmd        = "alter_group"
+	dropGroupSubCmd         = "drop_group"
+	createRuleSubCmd        = "create_rule"
+	alterRuleSubCmd         = "alter_rule"
+	dropRuleSubCmd          = "drop_rule"
+	setConfigSubCmd         = "set_config"
+	getConfigSubCmd         = "get_config"
+	spreadEncryptionSubCmd  = "spread_encryption"
+	rotateDBPasswordSubCmd  = "rotate_db_password"
+	nmaSubCmd               = "nma"
+	startNMASubCmd          = "start"
+	stopNMASubCmd           = "stop"
+	realignControlSubCmd    = "realign_control_nodes"
+	forceRestartDBSubCmd    = "force_restart_db"
+	collectLogsSubCmd       = "collect_logs"
+	completionSubCmd        = "completion"
+	hibernateDBSubCmd       = "hibernate_db"
+	wakeDBSubCmd            = "wake_db"
+	distributeFileSubCmd    = "distribute_file"
+	pollSubsStateSubCmd     = "poll_subscription_state"
+	listSessionsSubCmd      = "list_sessions"
+	closeSessionsSubCmd     = "close_sessions"
+	manageConnSubCmd        = "manage_connections"
+	rollingUpgradeSubCmd    = "rolling_upgrade"
+	checkPrereqsSubCmd      = "check_prerequisites"
+	checkLicenseSubCmd      = "check_license_compliance"
 )
 
 // cmdGlobals holds global variables shared by multiple
 // commands
 type cmdGlobals struct {
-	verbose  bool
-	file     *os.File
-	keyFile  string
-	certFile string
+	verbose    bool
+	file       *os.File
+	keyFile    string
+	certFile   string
+	caCertFile string
+
+	// format, level and output of the logs
+	logFormat string
+	logLevel  string
+	logOutput string
+	// address on which the metrics are served, e.g., ":9090"
+	metricsListen string
+	// directory in which the HTTP requests are captured
+	captureHTTPDir string
+	// the database profile selecting the config file in the default directory
+	dbProfile string
+	// webhooks to which a summary of the command is posted when it finishes
+	notifyURLs []string
+	// maximum number of hosts to which requests are sent at the same time
+	maxConcurrentHosts int
+	// whether some NMA requests to the local host are executed without the NMA
+	localExecution bool
+	// size in bytes from which the request bodies are compressed
+	compressionThreshold int
 
 	// Global variables for targetDB are used for the replication subcommand
 	targetHosts        []string
@@ -206,11 +300,28 @@ the following administrator operations:
 - Stop a database
 - Drop a database
 - Revive an Eon database
-- Add/Remove a subcluster
+- Hibernate/Wake an Eon database
+- Add/Remove/Pause/Scale a subcluster
 - Sandbox/Unsandbox a subcluster
 - Run scrutinize on a database
 - View the state of a database
-- Install packages on a database`,
+- Install packages on a database
+
+The default values of the log, certificate and IPv6 flags can be set in
+$HOME/.config/vcluster/cli.yaml, or the file in VCLUSTER_CLI_CONFIG, e.g.:
+  logPath: /var/log/vcluster/vcluster.log
+  logFormat: json
+  ipv6: true
+The command line flags, environment variables and config file take precedence.
+
+vcluster exits with one of the following codes:
+  0  success
+  1  generic failure
+  2  invalid command line, config file or connection file
+  3  a host could not be reached
+  4  wrong password or certificates
+  5  quorum is not reached
+  6  the command succeeded on some hosts only`,
 		Version: CLIVersion,
 	}
 )
@@ -229,10 +340,14 @@ type cmdInterface interface {
 }
 
 func Execute() {
+	// flag errors are reported with the config error exit code
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return makeConfigError(err)
+	})
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Printf("Error during execution: %s\n", err)
-		os.Exit(1)
+		os.Exit(getExitCode(err))
 	}
 }
 
@@ -240,18 +355,71 @@ func Execute() {
 func initVcc(cmd *cobra.Command) vclusterops.VClusterCommands {
 	// setup logs
 	logger := vlog.Printer{ForCli: true}
-	logger.SetupOrDie(dbOptions.LogPath)
+	logger.SetupWithOptionsOrDie(vlog.LogOptions{
+		LogFile: dbOptions.LogPath,
+		Format:  globals.logFormat,
+		Level:   globals.logLevel,
+		Output:  globals.logOutput,
+	})
 
 	vcc := vclusterops.VClusterCommands{
 		VClusterCommandsLogger: vclusterops.VClusterCommandsLogger{
 			Log: logger.WithName(cmd.CalledAs()),
 		},
+		MaxConcurrentHosts:   globals.maxConcurrentHosts,
+		LocalExecution:       globals.localExecution,
+		CompressionThreshold: globals.compressionThreshold,
 	}
 	vcc.LogInfo("New VCluster command initialization")
 
+	if globals.captureHTTPDir != "" {
+		capture, err := vclusterops.MakeHTTPCapture(globals.captureHTTPDir)
+		if err != nil {
+			vcc.PrintWarning("HTTP requests will not be captured, details: %s", err)
+		} else {
+			vcc.HTTPCapture = capture
+			vcc.PrintInfo("Capturing the HTTP requests in %s", capture.Dir())
+		}
+	}
+
+	if len(globals.notifyURLs) > 0 {
+		vcc.Notifier = vclusterops.MakeNotifier()
+		for _, notifyURL := range globals.notifyURLs {
+			if err := vcc.Notifier.AddWebhook(notifyURL); err != nil {
+				vcc.PrintWarning("No notification will be sent to the webhook, details: %s", err)
+			}
+		}
+	}
+
+	if globals.metricsListen != "" {
+		vcc.Metrics = vclusterops.MakeMetricsRegistry()
+		serveMetrics(&vcc, globals.metricsListen)
+	}
+
 	return vcc
 }
 
+// serveMetrics serves the metrics of vcc at /metrics on the given address
+// until the command exits. This is useful for long-running commands, like
+// the ones that poll the database.
+func serveMetrics(vcc *vclusterops.VClusterCommands, address string) {
+	const readHeaderTimeout = 10 * time.Second
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", vcc.Metrics.Handler())
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil {
+			vcc.PrintWarning("fail to serve the metrics on %s, details: %s", address, err)
+		}
+	}()
+	vcc.LogInfo("Serving the metrics", "address", address)
+}
+
 // setDBOptionsUsingViper can set the value of flag using the relevant key in viper
 func setDBOptionsUsingViper(flag string) error {
 	switch flag {
@@ -307,7 +475,7 @@ func setTargetDBOptionsUsingViper(flag string) error {
 }
 
 // configViper configures viper to load database options using this order:
-// user input -> environment variables -> vcluster config file
+// user input -> environment variables -> vcluster config file -> CLI config file
 func configViper(cmd *cobra.Command, flagsInConfig []string) error {
 	// initialize config file
 	initConfig()
@@ -345,6 +513,11 @@ func configViper(cmd *cobra.Command, flagsInConfig []string) error {
 		return err
 	}
 
+	// Load the defaults of the flags from the CLI config file
+	if err := loadCLIConfig(cmd); err != nil {
+		return err
+	}
+
 	// Load config options from file to viper
 	if err := loadConfig(cmd); err != nil {
 		return err
@@ -402,6 +575,7 @@ func handleViperUserInput(flagsInConfig []string) error {
 	// 1. user input
 	// 2. environment variable
 	// 3. config/connection file
+	// 4. CLI config file
 	// if the flag is not set in viper, the default value of it will be used
 	for _, flag := range flagsInConfig {
 		if _, ok := flagKeyMap[flag]; !ok {
@@ -447,7 +621,7 @@ func makeBasicCobraCmd(i cmdInterface, use, short, long string, commonFlags []st
 				fmt.Println("---{VCluster begin}---")
 			}
 			flagsInConfig := filterFlagsInConfig(commonFlags)
-			return configViper(cmd, flagsInConfig)
+			return makeConfigError(configViper(cmd, flagsInConfig))
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			vcc := initVcc(cmd)
@@ -465,7 +639,7 @@ func makeBasicCobraCmd(i cmdInterface, use, short, long string, commonFlags []st
 			parseError := i.Parse(os.Args[2:], vcc.GetLog())
 			if parseError != nil {
 				vcc.LogError(parseError, "fail to parse command")
-				return parseError
+				return makeConfigError(parseError)
 			}
 			runError := i.Run(vcc)
 			if runError != nil {
@@ -509,26 +683,59 @@ func constructCmds() []*cobra.Command {
 		makeCmdStartDB(),
 		makeCmdDropDB(),
 		makeCmdReviveDB(),
+		makeCmdHibernateDB(),
+		makeCmdWakeDB(),
 		makeCmdReIP(),
 		makeCmdShowRestorePoints(),
+		makeCmdCreateArchive(),
+		makeCmdRemoveArchive(),
 		makeCmdInstallPackages(),
+		makeCmdVerifyCatalog(),
+		makeCmdAlterDepotSize(),
+		makeCmdStorageLocation(),
+		makeCmdSetKSafety(),
+		makeCmdRebalanceShards(),
+		makeCmdRebalanceCluster(),
+		makeCmdRouting(),
+		makeCmdSetConfig(),
+		makeCmdGetConfig(),
+		makeCmdSpreadEncryption(),
+		makeCmdRotateDBPassword(),
+		makeCmdNMA(),
+		makeCmdRealignControlNodes(),
+		makeCmdForceRestartDB(),
+		makeCmdListSessions(),
+		makeCmdCloseSessions(),
+		makeCmdRollingUpgrade(),
+		makeCmdCheckPrerequisites(),
+		makeCmdCheckLicenseCompliance(),
 		// sc-scope cmds
 		makeCmdAddSubcluster(),
 		makeCmdRemoveSubcluster(),
+		makeCmdPauseSubcluster(),
+		makeCmdScaleSubcluster(),
+		makeCmdManageConnections(),
+		makeCmdPollSubscriptionState(),
 		makeCmdStopSubcluster(),
 		makeCmdStartSubcluster(),
 		makeCmdSandboxSubcluster(),
 		makeCmdUnsandboxSubcluster(),
+		makeCmdShowSandboxes(),
 		// node-scope cmds
 		makeCmdRestartNodes(),
 		makeCmdAddNode(),
 		makeCmdStopNode(),
 		makeCmdRemoveNode(),
+		makeCmdReplaceNode(),
+		makeCmdKillVertica(),
 		// others
 		makeCmdScrutinize(),
+		makeCmdCollectLogs(),
+		makeCmdDistributeFile(),
 		makeCmdManageConfig(),
 		makeCmdReplication(),
 		makeCmdCreateConnection(),
+		makeCmdCompletion(),
 	}
 }
 
@@ -18,8 +18,11 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,6 +61,64 @@ func TestConfigPathDefaults(t *testing.T) {
 	assert.Contains(t, dbOptions.ConfigPath, homeConfigPathPartial)
 }
 
+func TestConfigPathDBProfile(t *testing.T) {
+	const envConfigPath = "/scratch_b/mspilchen/cfg-from-env.yaml"
+	defer func() { globals.dbProfile = "" }()
+	os.Setenv(vclusterConfigEnv, envConfigPath)
+	defer os.Setenv(vclusterConfigEnv, "")
+
+	// the profile option takes precedence over the environment variable
+	globals.dbProfile = "sales_db"
+	dbOptions.ConfigPath = ""
+	initConfigImpl("/usr/bin/vcluster", false, false)
+	assert.Contains(t, dbOptions.ConfigPath, "vcluster/vertica_cluster_sales_db.yaml")
+
+	// the profile environment variable comes after the config one
+	globals.dbProfile = ""
+	os.Setenv(vclusterDBProfileEnv, "hr_db")
+	defer os.Setenv(vclusterDBProfileEnv, "")
+	dbOptions.ConfigPath = ""
+	initConfigImpl("/usr/bin/vcluster", false, false)
+	assert.Equal(t, envConfigPath, dbOptions.ConfigPath)
+
+	os.Setenv(vclusterConfigEnv, "")
+	dbOptions.ConfigPath = ""
+	initConfigImpl("/usr/bin/vcluster", false, false)
+	assert.Contains(t, dbOptions.ConfigPath, "vcluster/vertica_cluster_hr_db.yaml")
+
+	_, err := getConfigFileName("../other")
+	assert.ErrorContains(t, err, "invalid database profile")
+}
+
+func TestCLIConfigDefaults(t *testing.T) {
+	cliConfigPath := filepath.Join(t.TempDir(), cliConfigFileName)
+
+	// a missing CLI config file is not an error
+	cliConfig, err := readCLIConfig(cliConfigPath)
+	assert.NoError(t, err)
+	assert.Nil(t, cliConfig)
+
+	err = os.WriteFile(cliConfigPath, []byte("logFormat: json\nlogLevel: debug\nipv6: true\n"), 0600)
+	assert.NoError(t, err)
+	cliConfig, err = readCLIConfig(cliConfigPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "json", cliConfig.LogFormat)
+	assert.True(t, *cliConfig.IPv6)
+
+	// the flags in the command line take precedence
+	originalGlobals := globals
+	defer func() { globals = originalGlobals }()
+	defer viper.SetDefault(ipv6Key, nil)
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVar(&globals.logFormat, logFormatFlag, "console", "")
+	cmd.Flags().StringVar(&globals.logLevel, logLevelFlag, "info", "")
+	assert.NoError(t, cmd.Flags().Parse([]string{"--" + logLevelFlag + "=warn"}))
+	cliConfig.applyDefaults(cmd)
+	assert.Equal(t, "json", globals.logFormat)
+	assert.Equal(t, "warn", globals.logLevel)
+	assert.True(t, viper.GetBool(ipv6Key))
+}
+
 type mockOperatingSystem struct {
 	mockExecutablePath    string
 	mockExecutablePathErr error
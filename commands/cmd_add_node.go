@@ -61,6 +61,10 @@ when using this option. This subcommand removes any surplus nodes from the
 catalog, provided they are down, before commencing the node addition process.
 Omitting the option will skip this node trimming process.
 
+Use the --force-cleanup-on-failure option to stop the new nodes, delete the
+directories created for them, and remove them from the catalog if the node
+addition fails, so that the next attempt starts clean.
+
 Examples:
   # Add a single host to the existing database with config file
   vcluster add_node --db-name test_db --new-hosts 10.20.30.43 \
@@ -72,7 +76,7 @@ Examples:
     --node-names v_test_db_node0001,v_test_db_node0002
 `,
 		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, dataPathFlag, depotPathFlag,
-			passwordFlag},
+			passwordFlag, subnetFlag},
 	)
 
 	// local flags
@@ -123,6 +127,36 @@ func (c *CmdAddNode) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"Comma-separated list of node names that exist in the cluster",
 	)
+	cmd.Flags().BoolVar(
+		&c.addNodeOptions.Idempotent,
+		"idempotent",
+		false,
+		"Skip the host(s) that are already in the database with the same subcluster and catalog path, instead of failing",
+	)
+	cmd.Flags().BoolVar(
+		&c.addNodeOptions.FixDirectoryOwnership,
+		"fix-directory-ownership",
+		false,
+		"Change the owner of the existing directories of the new host(s) that have a wrong owner, used with --idempotent",
+	)
+	cmd.Flags().BoolVar(
+		&c.addNodeOptions.ForceCleanupOnFailure,
+		"force-cleanup-on-failure",
+		false,
+		"Remove the new host(s) and the directories created for them on failure of command",
+	)
+	cmd.Flags().BoolVar(
+		&c.addNodeOptions.SkipPortCheck,
+		"skip-port-check",
+		false,
+		"Skip the check of port reachability between the hosts",
+	)
+	cmd.Flags().IntVar(
+		&c.addNodeOptions.ClientPort,
+		"client-port",
+		util.DefaultClientPort,
+		"The client port of the database, checked for reachability unless --skip-port-check is set",
+	)
 }
 
 func (c *CmdAddNode) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -0,0 +1,139 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+	"golang.org/x/exp/maps"
+)
+
+/* CmdAlterDepotSize
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdAlterDepotSize struct {
+	alterDepotSizeOptions *vclusterops.VAlterDepotSizeOptions
+
+	CmdBase
+}
+
+func makeCmdAlterDepotSize() *cobra.Command {
+	// CmdAlterDepotSize
+	newCmd := &CmdAlterDepotSize{}
+	opt := vclusterops.VAlterDepotSizeOptionsFactory()
+	newCmd.alterDepotSizeOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		alterDepotSizeSubCmd,
+		"Change the depot size of the nodes in an Eon database",
+		`This subcommand changes the depot size of the up nodes in an Eon Mode
+database. The depot size is either a percentage of the disk space, e.g. 40%,
+or a size in bytes with a K, M, G or T suffix, e.g. 100G.
+
+Use the --subcluster option to only change the depot size of the nodes
+in a subcluster. The depot size of down nodes is not changed.
+
+Examples:
+  # Change the depot size of all nodes with config file
+  vcluster alter_depot_size --db-name test_db --depot-size 40% \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Change the depot size of the nodes in a subcluster with user input
+  vcluster alter_depot_size --db-name test_db --depot-size 100G \
+    --subcluster sc1 --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the new depot size
+	markFlagsRequired(cmd, []string{"depot-size"})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdAlterDepotSize) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.alterDepotSizeOptions.DepotSize,
+		"depot-size",
+		"",
+		util.GetEonFlagMsg("New size of depot, e.g., 40% or 100G"),
+	)
+	cmd.Flags().StringVar(
+		&c.alterDepotSizeOptions.SCName,
+		subclusterFlag,
+		"",
+		util.GetEonFlagMsg("The name of the subcluster whose depot size must be changed."+
+			" If empty, the depot size of all nodes is changed"),
+	)
+}
+
+func (c *CmdAlterDepotSize) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.alterDepotSizeOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdAlterDepotSize) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.alterDepotSizeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.alterDepotSizeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.alterDepotSizeOptions.DatabaseOptions)
+}
+
+func (c *CmdAlterDepotSize) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.alterDepotSizeOptions
+
+	nodeDepotSizes, err := vcc.VAlterDepotSize(options)
+	if err != nil {
+		vcc.LogError(err, "fail to alter depot size", "DBName", options.DBName)
+		return err
+	}
+	nodeNames := maps.Keys(nodeDepotSizes)
+	sort.Strings(nodeNames)
+	for _, nodeName := range nodeNames {
+		vcc.PrintInfo("Depot size of node %s is %s", nodeName, nodeDepotSizes[nodeName])
+	}
+	vcc.PrintInfo("Successfully altered the depot size of database %s to %s", options.DBName, options.DepotSize)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdAlterDepotSize
+func (c *CmdAlterDepotSize) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.alterDepotSizeOptions.DatabaseOptions = *opt
+}
@@ -45,10 +45,23 @@ type CmdBase struct {
 	output                 string
 	passwordFile           string
 	readPasswordFromPrompt bool
+	// file to read the hosts from, one host per line
+	hostsFile string
+	// whether to skip the confirmation prompt of destructive commands
+	assumeYes bool
 }
 
 // ValidateParseBaseOptions will validate and parse the required base options in each command
 func (c *CmdBase) ValidateParseBaseOptions(opt *vclusterops.DatabaseOptions) error {
+	// the hosts in --hosts-file override the hosts in the config file
+	if c.hostsFile != "" {
+		hosts, err := c.readHostsFile()
+		if err != nil {
+			return err
+		}
+		opt.RawHosts = hosts
+	}
+
 	// parse raw hosts
 	if len(opt.RawHosts) > 0 {
 		err := util.ParseHostList(&opt.RawHosts)
@@ -71,6 +84,9 @@ func (c *CmdBase) setCommonFlags(cmd *cobra.Command, flags []string) {
 		return
 	}
 	setConfigFlags(cmd, flags)
+	if util.StringInArray(hostsFlag, flags) {
+		c.setHostsFileFlag(cmd)
+	}
 	if util.StringInArray(passwordFlag, flags) {
 		c.setPasswordFlags(cmd)
 	}
@@ -83,6 +99,76 @@ func (c *CmdBase) setCommonFlags(cmd *cobra.Command, flags []string) {
 		"Path location used for the debug logs",
 	)
 	markFlagsFileName(cmd, map[string][]string{logPathFlag: {"log"}})
+	cmd.Flags().StringVar(
+		&globals.logFormat,
+		logFormatFlag,
+		vlog.ConsoleFormat,
+		fmt.Sprintf("Format of the debug logs, one of %s or %s", vlog.ConsoleFormat, vlog.JSONFormat),
+	)
+	cmd.Flags().StringVar(
+		&globals.logLevel,
+		logLevelFlag,
+		vlog.InfoLevel,
+		fmt.Sprintf("Minimum level of the debug logs, one of %s, %s, %s or %s",
+			vlog.DebugLevel, vlog.InfoLevel, vlog.WarningLevel, vlog.ErrorLevel),
+	)
+	cmd.Flags().StringVar(
+		&globals.logOutput,
+		logOutputFlag,
+		vlog.FileOutput,
+		fmt.Sprintf("Where to write the debug logs, one of %s, %s or %s",
+			vlog.FileOutput, vlog.StdoutOutput, vlog.BothOutput),
+	)
+	cmd.Flags().StringVar(
+		&dbOptions.AuditLogPath,
+		auditLogFlag,
+		"",
+		fmt.Sprintf("Path of the file to which a record of the command is appended, or %q to send it to syslog",
+			vclusterops.AuditLogSyslog),
+	)
+	cmd.Flags().StringVar(
+		&globals.captureHTTPDir,
+		captureHTTPDirFlag,
+		"",
+		"Directory in which to record the HTTP requests of the command and their responses, for troubleshooting",
+	)
+	markFlagsDirName(cmd, []string{captureHTTPDirFlag})
+	cmd.Flags().StringSliceVar(
+		&globals.notifyURLs,
+		notifyURLFlag,
+		[]string{},
+		"Comma-separated list of webhook URLs to which a JSON summary of the command is posted when it finishes",
+	)
+	cmd.Flags().IntVar(
+		&globals.maxConcurrentHosts,
+		maxConcurrentHostsFlag,
+		0,
+		"Maximum number of hosts to which requests are sent at the same time, 0 for no limit",
+	)
+	cmd.Flags().BoolVar(
+		&globals.localExecution,
+		localExecutionFlag,
+		false,
+		"Prepare and delete directories and read the catalog of the local host directly, without its Node Management Agent",
+	)
+	cmd.Flags().IntVar(
+		&globals.compressionThreshold,
+		compressionThresholdFlag,
+		0,
+		"Size in bytes from which the bodies of the NMA file transfers are compressed with gzip, and gzip responses are accepted. 0 for no compression",
+	)
+	cmd.Flags().BoolVar(
+		&dbOptions.ReresolveHosts,
+		reresolveHostsFlag,
+		false,
+		"Resolve the host names again before each step and when a host is unreachable, to follow DNS-based failovers",
+	)
+	cmd.Flags().StringVar(
+		&globals.metricsListen,
+		metricsListenFlag,
+		"",
+		"Address, e.g., :9090, on which to serve the metrics of the command at /metrics in the Prometheus format",
+	)
 
 	// verbose is a flag that all the subcommands need
 	cmd.Flags().BoolVar(
@@ -110,6 +196,28 @@ func (c *CmdBase) setCommonFlags(cmd *cobra.Command, flags []string) {
 		)
 		markFlagsFileName(cmd, map[string][]string{certFileFlag: {"pem", "crt"}})
 		cmd.MarkFlagsRequiredTogether(keyFileFlag, certFileFlag)
+
+		cmd.Flags().StringVar(
+			&globals.caCertFile,
+			caCertFileFlag,
+			"",
+			"Path to the CA cert file the certificates of the hosts are verified against",
+		)
+		markFlagsFileName(cmd, map[string][]string{caCertFileFlag: {"pem", "crt"}})
+		cmd.Flags().StringVar(
+			(*string)(&dbOptions.TLSVerifyMode),
+			tlsVerifyModeFlag,
+			string(vclusterops.TLSInsecureSkipVerify),
+			fmt.Sprintf("How the certificates of the hosts are verified, one of %s, %s or %s",
+				vclusterops.TLSInsecureSkipVerify, vclusterops.TLSVerifyCA, vclusterops.TLSVerifyFull),
+		)
+		cmd.Flags().StringVar(
+			&dbOptions.TLSServerName,
+			tlsServerNameFlag,
+			"",
+			"With --"+tlsVerifyModeFlag+" "+string(vclusterops.TLSVerifyFull)+
+				", the name the certificates of the hosts must match instead of the host names or IP addresses",
+		)
 	}
 	if util.StringInArray(outputFileFlag, flags) {
 		cmd.Flags().StringVarP(
@@ -120,6 +228,24 @@ func (c *CmdBase) setCommonFlags(cmd *cobra.Command, flags []string) {
 			"Write output to this file instead of stdout",
 		)
 	}
+	if util.StringInArray(useInstanceProfileFlag, flags) {
+		cmd.Flags().BoolVar(
+			&dbOptions.UseInstanceProfile,
+			useInstanceProfileFlag,
+			false,
+			util.GetEonFlagMsg("Access the S3 communal storage with the IAM role of the hosts, "+
+				"e.g., an instance profile or IRSA, instead of AWS keys"),
+		)
+	}
+	if util.StringInArray(subnetFlag, flags) {
+		cmd.Flags().StringVar(
+			&dbOptions.Subnet,
+			subnetFlag,
+			"",
+			"Network in CIDR notation, e.g., 10.20.0.0/16, from which the addresses of the new nodes are chosen "+
+				"on hosts with several network interfaces",
+		)
+	}
 	if util.StringInArray(dbUserFlag, flags) {
 		cmd.Flags().StringVar(
 			&dbOptions.UserName,
@@ -149,13 +275,24 @@ func setConfigFlags(cmd *cobra.Command, flags []string) {
 			"",
 			"Path to the config file")
 		markFlagsFileName(cmd, map[string][]string{configFlag: {"yaml"}})
+		cmd.Flags().StringVar(
+			&globals.dbProfile,
+			dbProfileFlag,
+			"",
+			"Name of the database profile, whose config file is in the default config directory")
+		cmd.MarkFlagsMutuallyExclusive(configFlag, dbProfileFlag)
+		err := cmd.RegisterFlagCompletionFunc(dbProfileFlag, completeDBProfiles)
+		if err != nil {
+			fmt.Printf("Warning: fail to register the completion of flag %q, details: %v\n", dbProfileFlag, err)
+		}
 	}
 	if util.StringInArray(hostsFlag, flags) {
 		cmd.Flags().StringSliceVar(
 			&dbOptions.RawHosts,
 			hostsFlag,
 			[]string{},
-			"Comma-separated list of hosts in database.")
+			"Comma-separated list of hosts in database. A range like node[01-32] is expanded, "+
+				"and the hosts of overlapping ranges are only kept once.")
 	}
 	if util.StringInArray(catalogPathFlag, flags) {
 		cmd.Flags().StringVar(
@@ -193,7 +330,7 @@ func setConfigFlags(cmd *cobra.Command, flags []string) {
 			&dbOptions.IPv6,
 			ipv6Flag,
 			false,
-			"Whether the hosts are using IPv6 addresses")
+			"Whether to resolve host names to IPv6 addresses rather than IPv4 addresses")
 	}
 	if util.StringInArray(eonModeFlag, flags) {
 		cmd.Flags().BoolVar(
@@ -234,8 +371,101 @@ func (c *CmdBase) setPasswordFlags(cmd *cobra.Command) {
 		false,
 		"Prompt the user to enter the password",
 	)
+	cmd.Flags().StringVar(
+		&dbOptions.AccessToken,
+		accessTokenFlag,
+		"",
+		"Access token from an identity provider, used instead of the password",
+	)
+	cmd.Flags().StringVar(
+		&dbOptions.TokenFile,
+		tokenFileFlag,
+		"",
+		"Path to the file to read the access token from. "+
+			"The file is read again when the token is rejected, so that it can be rotated",
+	)
 	cmd.MarkFlagsMutuallyExclusive([]string{passwordFlag, passwordFileFlag,
-		readPasswordFromPromptFlag}...)
+		readPasswordFromPromptFlag, accessTokenFlag, tokenFileFlag}...)
+}
+
+// setHostsFileFlag sets the flag to read the hosts from a file, for the
+// clusters whose host list is too long for the command line
+func (c *CmdBase) setHostsFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.hostsFile,
+		hostsFileFlag,
+		"",
+		"Path to the file to read the hosts in database from, one host per line. "+
+			"If - is passed, the hosts are read from stdin",
+	)
+	cmd.MarkFlagsMutuallyExclusive(hostsFlag, hostsFileFlag)
+}
+
+// setConfirmationFlags sets the flags of the destructive commands, which ask
+// the user to confirm before they start
+func (c *CmdBase) setConfirmationFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
+		&c.assumeYes,
+		yesFlag,
+		"y",
+		false,
+		"Do not ask for confirmation before running the command",
+	)
+}
+
+// confirmOperation asks the user to confirm a destructive command. There is no
+// prompt if --yes is given or if the input is not a terminal, e.g., in scripts.
+func (c *CmdBase) confirmOperation(format string, args ...any) error {
+	if c.assumeYes || !isInputTerminalFn() {
+		return nil
+	}
+	confirmed, err := readConfirmationFromPrompt(fmt.Sprintf(format, args...))
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("the command was cancelled, use --%s to skip the confirmation", yesFlag)
+	}
+	return nil
+}
+
+// confirmOperationWithName asks the user to retype the name of what an
+// unrecoverable command removes, e.g., the database of drop_db. Unlike
+// confirmOperation, it fails if the input is not a terminal and --yes is not given.
+func (c *CmdBase) confirmOperationWithName(name, format string, args ...any) error {
+	if c.assumeYes {
+		return nil
+	}
+	if !isInputTerminalFn() {
+		return fmt.Errorf("the command must be confirmed, run it in a terminal or use --%s", yesFlag)
+	}
+	answer, err := readLineFromPrompt(fmt.Sprintf(format, args...) +
+		fmt.Sprintf(" Type %q to confirm: ", name))
+	if err != nil {
+		return err
+	}
+	if answer != name {
+		return fmt.Errorf("the command was cancelled, %q does not match %q", answer, name)
+	}
+	return nil
+}
+
+// setResumeFlags sets the flags of the commands that can be resumed
+func (c *CmdBase) setResumeFlags(cmd *cobra.Command, opt *vclusterops.ResumeOptions) {
+	cmd.Flags().StringVar(
+		&opt.JournalPath,
+		journalFlag,
+		"",
+		"Path of the file in which to record the progress of the command, to resume it if it is interrupted",
+	)
+	markFlagsFileName(cmd, map[string][]string{journalFlag: {"json"}})
+	cmd.Flags().BoolVar(
+		&opt.Resume,
+		resumeFlag,
+		false,
+		"Resume the command from the progress recorded in the file of --"+journalFlag+
+			", skipping the steps that completed",
+	)
 }
 
 // ResetUserInputOptions reset password option to nil in each command
@@ -304,6 +534,47 @@ func (c *CmdBase) passwordFileHelper(passwordFile string) (string, error) {
 	return strings.TrimSuffix(string(passwordBytes), "\n"), nil
 }
 
+// readHostsFile reads the hosts from --hosts-file, or from stdin if it is -
+func (c *CmdBase) readHostsFile() ([]string, error) {
+	var content string
+	if c.hostsFile == "-" {
+		if c.passwordFile == "-" {
+			return nil, fmt.Errorf("cannot read both the hosts and the password from stdin")
+		}
+		var err error
+		content, err = readFromStdin()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		contentBytes, err := os.ReadFile(c.hostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading hosts from file %q: %w", c.hostsFile, err)
+		}
+		content = string(contentBytes)
+	}
+
+	hosts := parseHostsFileContent(content)
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no host found in hosts file %q", c.hostsFile)
+	}
+	return hosts, nil
+}
+
+// parseHostsFileContent returns the host on each line of content. Blank
+// lines and the text after a # are ignored.
+func parseHostsFileContent(content string) []string {
+	var hosts []string
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(line)
+		if line != "" {
+			hosts = append(hosts, line)
+		}
+	}
+	return hosts
+}
+
 // usePassword returns true if at least one of the password
 // flags is passed in the cli
 func (c *CmdBase) usePassword() bool {
@@ -353,5 +624,12 @@ func (c *CmdBase) getCertFilesFromCertPaths(opt *vclusterops.DatabaseOptions) er
 		}
 		opt.Key = string(keyData)
 	}
+	if globals.caCertFile != "" {
+		caCertData, err := os.ReadFile(globals.caCertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate file, details %w", err)
+		}
+		opt.CaCert = string(caCertData)
+	}
 	return nil
 }
@@ -0,0 +1,127 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdCheckLicenseCompliance
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdCheckLicenseCompliance struct {
+	CmdBase
+	checkLicenseOptions *vclusterops.VCheckLicenseComplianceOptions
+}
+
+func makeCmdCheckLicenseCompliance() *cobra.Command {
+	// CmdCheckLicenseCompliance
+	newCmd := &CmdCheckLicenseCompliance{}
+	opt := vclusterops.VCheckLicenseComplianceOptionsFactory()
+	newCmd.checkLicenseOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		checkLicenseSubCmd,
+		"Check the compliance of a database with its license",
+		`This subcommand gets the license of a running database and the result of
+its last audit, and checks that the database is within the node and size
+limits of the license and that the license has not expired. The result is a
+report with the status of every check: PASSED, WARNING, or FAILED.
+
+The subcommand fails if any check fails. A check with a warning, e.g., a
+license that expires within 30 days or an audited size above 90% of the
+limit, does not make the database out of compliance.
+
+The audited size is the one of the last license audit of the database, it is
+not computed by this subcommand.
+
+Examples:
+  # Check the license of a database with config file
+  vcluster check_license_compliance \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Check the license of a database and write the report to a file
+  vcluster check_license_compliance --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
+    --output-file /tmp/license.json
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
+	)
+
+	return cmd
+}
+
+func (c *CmdCheckLicenseCompliance) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.checkLicenseOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdCheckLicenseCompliance) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.checkLicenseOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.checkLicenseOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.checkLicenseOptions.DatabaseOptions)
+}
+
+func (c *CmdCheckLicenseCompliance) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.checkLicenseOptions
+
+	report, err := vcc.VCheckLicenseCompliance(options)
+	if err != nil {
+		vcc.LogError(err, "fail to check the license compliance", "DBName", options.DBName)
+		return err
+	}
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+
+	if !report.Compliant {
+		return fmt.Errorf("database %s is not compliant with its license", options.DBName)
+	}
+	vcc.PrintInfo("Database %s is compliant with its license", options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdCheckLicenseCompliance
+func (c *CmdCheckLicenseCompliance) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.checkLicenseOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,68 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vmock"
+)
+
+func TestCheckLicenseComplianceRun(t *testing.T) {
+	outputFile, err := os.Create(filepath.Join(t.TempDir(), "license.json"))
+	assert.NoError(t, err)
+	defer outputFile.Close()
+	savedFile := globals.file
+	globals.file = outputFile
+	defer func() { globals.file = savedFile }()
+
+	opt := vclusterops.VCheckLicenseComplianceOptionsFactory()
+	opt.DBName = "test_db"
+	c := CmdCheckLicenseCompliance{checkLicenseOptions: &opt}
+	report := vclusterops.LicenseComplianceReport{
+		LicenseType: "Premium Edition",
+		NodeCount:   3,
+		Checks: []vclusterops.PrerequisiteCheck{
+			{Name: "license_expiration", Status: vclusterops.PrerequisiteWarning},
+		},
+		Compliant: true,
+	}
+	mock := vmock.MakeClusterCommands()
+	mock.VCheckLicenseComplianceFn = func(_ *vclusterops.VCheckLicenseComplianceOptions) (
+		vclusterops.LicenseComplianceReport, error) {
+		return report, nil
+	}
+
+	// a warning does not make the database out of compliance
+	assert.NoError(t, c.Run(mock))
+	assert.Equal(t, []any{&opt}, mock.CallsOf("VCheckLicenseCompliance"))
+	content, err := os.ReadFile(outputFile.Name())
+	assert.NoError(t, err)
+	written := vclusterops.LicenseComplianceReport{}
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, report, written)
+
+	// the command fails when a check fails
+	report.Checks[0].Status = vclusterops.PrerequisiteFailed
+	report.Compliant = false
+	err = c.Run(mock)
+	assert.EqualError(t, err, "database test_db is not compliant with its license")
+}
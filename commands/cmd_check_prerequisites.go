@@ -0,0 +1,126 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdCheckPrerequisites
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdCheckPrerequisites struct {
+	CmdBase
+	checkPrereqsOptions *vclusterops.VCheckPrerequisitesOptions
+}
+
+func makeCmdCheckPrerequisites() *cobra.Command {
+	// CmdCheckPrerequisites
+	newCmd := &CmdCheckPrerequisites{}
+	opt := vclusterops.VCheckPrerequisitesOptionsFactory()
+	newCmd.checkPrereqsOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		checkPrereqsSubCmd,
+		"Check the hardware prerequisites of the hosts",
+		`This subcommand gathers the CPU count, memory, hugepages, ulimits, and
+NTP status of each host through the NMA, and evaluates them against the
+Vertica prerequisites. The result is a report with the status of every
+check on every host: PASSED, WARNING, or FAILED.
+
+The subcommand fails if any check fails on any host. A check with a warning
+does not prevent Vertica from running, but the host is not configured as
+recommended.
+
+The same checks run before create_db, which only warns about the failed
+checks. Use the --skip-prerequisite-check option of create_db to skip them.
+
+Examples:
+  # Check the hosts of a database with config file
+  vcluster check_prerequisites --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Check the hosts of a new database and write the report to a file
+  vcluster check_prerequisites --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
+    --output-file /tmp/prerequisites.json
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, outputFileFlag},
+	)
+
+	return cmd
+}
+
+func (c *CmdCheckPrerequisites) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.checkPrereqsOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdCheckPrerequisites) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.checkPrereqsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	return c.ValidateParseBaseOptions(&c.checkPrereqsOptions.DatabaseOptions)
+}
+
+func (c *CmdCheckPrerequisites) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.checkPrereqsOptions
+
+	report, err := vcc.VCheckPrerequisites(options)
+	if err != nil {
+		vcc.LogError(err, "fail to check the prerequisites", "DBName", options.DBName)
+		return err
+	}
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+
+	if len(report.SkippedHosts) > 0 {
+		vcc.PrintWarning("Skipped hosts %v, whose NMA cannot report their resources", report.SkippedHosts)
+	}
+	if !report.Passed {
+		return fmt.Errorf("some hosts do not meet the Vertica prerequisites")
+	}
+	vcc.PrintInfo("All hosts meet the Vertica prerequisites")
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdCheckPrerequisites
+func (c *CmdCheckPrerequisites) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.checkPrereqsOptions.DatabaseOptions = *opt
+}
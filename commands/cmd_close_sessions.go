@@ -0,0 +1,131 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdCloseSessions
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdCloseSessions struct {
+	closeSessionsOptions *vclusterops.VCloseSessionsOptions
+
+	CmdBase
+}
+
+func makeCmdCloseSessions() *cobra.Command {
+	// CmdCloseSessions
+	newCmd := &CmdCloseSessions{}
+	opt := vclusterops.VCloseSessionsOptionsFactory()
+	newCmd.closeSessionsOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		closeSessionsSubCmd,
+		"Close client sessions of a database",
+		`This subcommand forcibly closes client sessions of a running database, and
+prints the sessions that were closed in JSON.
+
+The sessions to close are selected by the nodes they are connected to, with
+the --node-names or --subcluster option, by database user, with the
+--session-user option, or by ID, with the --session-ids option. The criteria
+are combined. At least one criterion is required.
+
+Use this subcommand to close the sessions that keep a subcluster from
+draining before it is stopped or removed. The list_sessions subcommand
+shows the sessions that would be closed.
+
+Examples:
+  # Close all the sessions on a subcluster with config file
+  vcluster close_sessions --subcluster sc1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Close two sessions with user input
+  vcluster close_sessions --db-name test_db --hosts 10.20.30.40 \
+    --session-ids v_test_db_node0001-12345:0x3b,v_test_db_node0002-23456:0x1a
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdCloseSessions) setLocalFlags(cmd *cobra.Command) {
+	setSessionFilterFlags(cmd, &c.closeSessionsOptions.SessionFilter)
+	cmd.Flags().StringSliceVar(
+		&c.closeSessionsOptions.SessionIDs,
+		"session-ids",
+		[]string{},
+		"Comma-separated list of IDs of the sessions to close",
+	)
+}
+
+func (c *CmdCloseSessions) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.closeSessionsOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdCloseSessions) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.closeSessionsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.closeSessionsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.closeSessionsOptions.DatabaseOptions)
+}
+
+func (c *CmdCloseSessions) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	closed, err := vcc.VCloseSessions(c.closeSessionsOptions)
+	if err != nil {
+		vcc.LogError(err, "fail to close sessions", "DBName", c.closeSessionsOptions.DBName)
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(closed, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	vcc.PrintInfo("Closed %d sessions of database %s", len(closed), c.closeSessionsOptions.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdCloseSessions
+func (c *CmdCloseSessions) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.closeSessionsOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,184 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const bytesPerMB = 1024 * 1024
+
+/* CmdCollectLogs
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdCollectLogs struct {
+	collectLogsOptions *vclusterops.VCollectLogsOptions
+	// size limit of every log collected from a node, in MB
+	logSizeLimitMB int64
+
+	CmdBase
+}
+
+func makeCmdCollectLogs() *cobra.Command {
+	// CmdCollectLogs
+	newCmd := &CmdCollectLogs{}
+	opt := vclusterops.VCollectLogsOptionsFactory()
+	newCmd.collectLogsOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		collectLogsSubCmd,
+		"Collect the logs of the nodes of a database",
+		`This subcommand collects vertica.log, the data collector (DC) tables and
+startup.log from the nodes of a database through the NMA. The database does
+not need to be up. Unlike scrutinize, it does not collect diagnostics or
+system tables, so it is much faster.
+
+If you use the --hosts option, the logs are only collected from the specified
+hosts.
+
+Use the --log-age-* options to restrict the archived vertica logs and the DC
+table records to a time range, and the --log-size-limit option to limit the
+size of every log collected from a node.
+
+The logs are bundled together in a tar file and stored in
+`+vclusterops.ScrutinizeOutputBasePath+`/VerticaCollectLogs.<timestamp>.tar.
+
+Examples:
+  # Collect the logs of all nodes in the database with config file
+  vcluster collect_logs --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Collect the vertica logs of the last 6 hours from one node
+  vcluster collect_logs --db-name test_db --hosts 10.20.30.40 \
+    --catalog-path /data --log-age-hours 6 \
+    --exclude-dc-tables --exclude-startup-log
+`,
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, configFlag, catalogPathFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdCollectLogs) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.collectLogsOptions.TarballName,
+		"tarball-name",
+		"",
+		"Name of the generated tarball. If empty an auto-generated "+
+			"name is used following the pattern VerticaCollectLogs.<timestamp>",
+	)
+	cmd.Flags().StringVar(
+		&c.collectLogsOptions.LogAgeOldestTime,
+		"log-age-oldest-time",
+		"",
+		"Timestamp of the maximum age of archived vertica log files and DC table records "+
+			"to collect, formatted as "+vclusterops.ScrutinizeHelpTimeFormatDesc,
+	)
+	cmd.Flags().StringVar(
+		&c.collectLogsOptions.LogAgeNewestTime,
+		"log-age-newest-time",
+		"",
+		"Timestamp of the minimum age of archived vertica log files and DC table records "+
+			"to collect, formatted as "+vclusterops.ScrutinizeHelpTimeFormatDesc,
+	)
+	cmd.Flags().IntVar(
+		&c.collectLogsOptions.LogAgeHours,
+		"log-age-hours",
+		vclusterops.ScrutinizeLogMaxAgeHoursDefault,
+		"Maximum age of archived vertica log files and DC table records to collect "+
+			"in hours, default "+fmt.Sprint(vclusterops.ScrutinizeLogMaxAgeHoursDefault),
+	)
+	cmd.MarkFlagsMutuallyExclusive("log-age-hours", "log-age-oldest-time")
+	cmd.MarkFlagsMutuallyExclusive("log-age-hours", "log-age-newest-time")
+	cmd.Flags().Int64Var(
+		&c.logSizeLimitMB,
+		"log-size-limit",
+		vclusterops.CollectLogsLogMaxSizeBytesDefault/bytesPerMB,
+		"Maximum size in MB of every log collected from a node",
+	)
+	cmd.Flags().BoolVar(
+		&c.collectLogsOptions.ExcludeVerticaLog,
+		"exclude-vertica-log",
+		false,
+		"Do not collect vertica.log",
+	)
+	cmd.Flags().BoolVar(
+		&c.collectLogsOptions.ExcludeDCTables,
+		"exclude-dc-tables",
+		false,
+		"Do not collect the data collector tables",
+	)
+	cmd.Flags().BoolVar(
+		&c.collectLogsOptions.ExcludeStartupLog,
+		"exclude-startup-log",
+		false,
+		"Do not collect startup.log",
+	)
+}
+
+func (c *CmdCollectLogs) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.collectLogsOptions.DatabaseOptions)
+
+	c.collectLogsOptions.LogSizeLimitBytes = c.logSizeLimitMB * bytesPerMB
+	return c.validateParse(logger)
+}
+
+func (c *CmdCollectLogs) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.collectLogsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	return c.ValidateParseBaseOptions(&c.collectLogsOptions.DatabaseOptions)
+}
+
+func (c *CmdCollectLogs) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.collectLogsOptions
+
+	tarballPath, err := vcc.VCollectLogs(options)
+	if err != nil {
+		vcc.LogError(err, "fail to collect logs", "dbName", options.DBName)
+		return err
+	}
+
+	vcc.PrintInfo("Collected the logs of database %s in %s", options.DBName, tarballPath)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdCollectLogs
+func (c *CmdCollectLogs) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.collectLogsOptions.DatabaseOptions = *opt
+}
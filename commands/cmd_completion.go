@@ -0,0 +1,103 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	bashShell = "bash"
+	zshShell  = "zsh"
+	fishShell = "fish"
+)
+
+/* CmdCompletion
+ *
+ * A subcommand generating the shell completion script of vcluster.
+ * It replaces the default completion subcommand of cobra.
+ */
+
+func makeCmdCompletion() *cobra.Command {
+	return &cobra.Command{
+		Use:   completionSubCmd + " [" + strings.Join([]string{bashShell, zshShell, fishShell}, "|") + "]",
+		Short: "Generate the shell completion script",
+		Long: `This subcommand generates the script that completes the subcommands, flags
+and some flag values of vcluster in the given shell.
+
+Examples:
+  # Load the completion in the current bash session
+  source <(vcluster completion bash)
+
+  # Load the completion in every bash session
+  vcluster completion bash > /etc/bash_completion.d/vcluster
+
+  # Load the completion in every zsh session
+  vcluster completion zsh > "${fpath[1]}/_vcluster"
+
+  # Load the completion in every fish session
+  vcluster completion fish > ~/.config/fish/completions/vcluster.fish
+`,
+		ValidArgs:             []string{bashShell, zshShell, fishShell},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeCompletionScript(cmd.Root(), args[0], os.Stdout)
+		},
+	}
+}
+
+// writeCompletionScript writes the completion script of the root command in the given shell
+func writeCompletionScript(root *cobra.Command, shell string, out io.Writer) error {
+	switch shell {
+	case bashShell:
+		return root.GenBashCompletionV2(out, true /*include descriptions*/)
+	case zshShell:
+		return root.GenZshCompletion(out)
+	case fishShell:
+		return root.GenFishCompletion(out, true /*include descriptions*/)
+	}
+	return fmt.Errorf("unsupported shell %q", shell)
+}
+
+// completeDBProfiles completes --db-profile with the profiles whose config
+// file is in one of the default config directories
+func completeDBProfiles(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	dirs := []string{"/opt/vertica/config"}
+	if cfgDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(cfgDir, "vcluster"))
+	}
+
+	ext := filepath.Ext(defConfigFileName)
+	prefix := strings.TrimSuffix(defConfigFileName, ext) + "_"
+	var profiles []string
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, prefix+"*"+ext))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			profiles = append(profiles, strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), prefix), ext))
+		}
+	}
+	return profiles, cobra.ShellCompDirectiveNoFileComp
+}
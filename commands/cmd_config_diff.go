@@ -0,0 +1,169 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdConfigDiff
+ *
+ * A subcommand comparing the YAML config file
+ * with the live topology of the database.
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdConfigDiff struct {
+	diffOptions *vclusterops.VDiffTopologyOptions
+	CmdBase
+}
+
+func makeCmdConfigDiff() *cobra.Command {
+	newCmd := &CmdConfigDiff{}
+	opt := vclusterops.VDiffTopologyOptionsFactory()
+	newCmd.diffOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		configDiffSubCmd,
+		"Compare the config file with the live topology of the database",
+		`This subcommand compares the nodes of the config file with the nodes in the
+catalog of the running database, including its sandboxes. It reports the nodes
+that were added or removed, the nodes whose IP address changed, and the nodes
+that moved to another subcluster or sandbox.
+
+Use the --description-file option to compare the database with a local copy
+of its cluster_config.json instead of the config file. The subclusters and
+sandboxes are not compared in that case.
+
+Run manage_config recover to update the config file with the live topology.
+
+Examples:
+  # Compare the config file in the default location with the database
+  vcluster manage_config diff --password testpassword
+
+  # Compare a cluster_config.json with the database
+  vcluster manage_config diff --db-name test_db --hosts 10.20.30.40 \
+    --description-file /tmp/cluster_config.json --password testpassword
+`,
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, configFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdConfigDiff) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.diffOptions.DescriptionFilePath,
+		"description-file",
+		"",
+		"Path to a local cluster_config.json to compare with the database instead of the config file",
+	)
+	markFlagsFileName(cmd, map[string][]string{"description-file": {"json"}})
+}
+
+func (c *CmdConfigDiff) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.diffOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+// all validations of the arguments should go in here
+func (c *CmdConfigDiff) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.diffOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.diffOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.diffOptions.DatabaseOptions)
+}
+
+func (c *CmdConfigDiff) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.diffOptions
+	if options.DescriptionFilePath == "" {
+		dbConfig, err := readConfig()
+		if err != nil {
+			return err
+		}
+		for _, node := range dbConfig.Nodes {
+			options.ExpectedNodes = append(options.ExpectedNodes, vclusterops.VTopologyNode{
+				Name: node.Name, Address: node.Address, Subcluster: node.Subcluster, Sandbox: node.Sandbox})
+		}
+	}
+
+	diff, err := vcc.VDiffTopology(options)
+	if err != nil {
+		vcc.LogError(err, "fail to compare the topology of the database", "dbName", options.DBName)
+		return err
+	}
+
+	if diff.IsEmpty() {
+		vcc.PrintInfo("The topology of database %s matches the expected one", options.DBName)
+		return nil
+	}
+	fmt.Print(formatTopologyDiff(&diff))
+	return nil
+}
+
+// formatTopologyDiff returns one line per difference, e.g.,
+// "~ v_test_db_node0001 address: 10.20.30.40 -> 10.20.30.50"
+func formatTopologyDiff(diff *vclusterops.VTopologyDiff) string {
+	var sb strings.Builder
+	for _, node := range diff.AddedNodes {
+		fmt.Fprintf(&sb, "+ %s %s subcluster=%q sandbox=%q\n", node.Name, node.Address, node.Subcluster, node.Sandbox)
+	}
+	for _, node := range diff.RemovedNodes {
+		fmt.Fprintf(&sb, "- %s %s subcluster=%q sandbox=%q\n", node.Name, node.Address, node.Subcluster, node.Sandbox)
+	}
+	for _, change := range diff.ReIPedNodes {
+		fmt.Fprintf(&sb, "~ %s address: %s -> %s\n", change.NodeName, change.Expected, change.Actual)
+	}
+	for _, change := range diff.SubclusterChanges {
+		fmt.Fprintf(&sb, "~ %s subcluster: %q -> %q\n", change.NodeName, change.Expected, change.Actual)
+	}
+	for _, change := range diff.SandboxChanges {
+		fmt.Fprintf(&sb, "~ %s sandbox: %q -> %q\n", change.NodeName, change.Expected, change.Actual)
+	}
+	return sb.String()
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance
+func (c *CmdConfigDiff) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.diffOptions.DatabaseOptions = *opt
+}
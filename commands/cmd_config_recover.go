@@ -20,6 +20,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
@@ -54,6 +55,13 @@ For accurate sandbox information recovery, the database needs to be running.
 If there is an existing file at the provided config file location, the recover function
 will not create a new config file unless you explicitly specify --overwrite.
 
+For an Eon Mode database, use the --from-communal option to rebuild the config
+file from the description file (cluster_config.json) in communal storage instead
+of the catalogs. This works when all the nodes are down and the NMA is
+unreachable on some hosts, as long as one host can read communal storage.
+The catalog path is then optional, but the subclusters of the nodes are
+unknown until you run manage_config show --sync on the running database.
+
 Examples:
   # Recover the config file to the default location
   vcluster manage_config recover --db-name test_db \
@@ -65,16 +73,24 @@ Examples:
 	--hosts 10.20.30.41,10.20.30.42,10.20.30.43 \
 	--catalog-path /data --depot-path /data \
 	--config /tmp/vertica_cluster.yaml --password ""
+
+  # Recover the config file from the description file in communal storage
+  vcluster manage_config recover --db-name test_db \
+	--hosts 10.20.30.41,10.20.30.42,10.20.30.43 \
+	--communal-storage-location s3://bucket/test_db --from-communal
 `,
-		[]string{dbNameFlag, hostsFlag, catalogPathFlag, depotPathFlag, ipv6Flag, configFlag, passwordFlag},
+		[]string{dbNameFlag, hostsFlag, catalogPathFlag, depotPathFlag, ipv6Flag, configFlag, passwordFlag,
+			communalStorageLocationFlag, configParamFlag},
 	)
 
-	// require db-name, hosts, catalog-path, and data-path
-	markFlagsRequired(cmd, []string{dbNameFlag, hostsFlag, catalogPathFlag})
-
 	// local flags
 	newCmd.setLocalFlags(cmd)
 
+	// require db-name, hosts, and catalog-path unless it is recovered from communal storage
+	markFlagsRequired(cmd, []string{dbNameFlag})
+	cmd.MarkFlagsOneRequired(hostsFlag, hostsFileFlag)
+	cmd.MarkFlagsOneRequired(catalogPathFlag, fromCommunalFlag)
+
 	return cmd
 }
 
@@ -92,6 +108,12 @@ func (c *CmdConfigRecover) setLocalFlags(cmd *cobra.Command) {
 		false,
 		"whether recover config file right after reviving a database",
 	)
+	cmd.Flags().BoolVar(
+		&c.recoverConfigOptions.FromCommunal,
+		fromCommunalFlag,
+		false,
+		util.GetEonFlagMsg("recover config file from the description file in communal storage"),
+	)
 }
 
 func (c *CmdConfigRecover) Parse(inputArgv []string, logger vlog.Printer) error {
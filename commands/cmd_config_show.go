@@ -32,12 +32,16 @@ import (
  * Implements ClusterCommand interface
  */
 type CmdConfigShow struct {
-	sOptions vclusterops.DatabaseOptions
+	sOptions *vclusterops.VFetchCoordinationDatabaseOptions
+	// whether to refresh the config file from the running database
+	sync bool
 	CmdBase
 }
 
 func makeCmdConfigShow() *cobra.Command {
 	newCmd := &CmdConfigShow{}
+	opt := vclusterops.VRecoverConfigOptionsFactory()
+	newCmd.sOptions = &opt
 
 	cmd := makeBasicCobraCmd(
 		newCmd,
@@ -45,27 +49,101 @@ func makeCmdConfigShow() *cobra.Command {
 		"Show the content of the config file",
 		`This subcommand prints the content of the config file.
 
+Use the --sync option to first fetch the current topology of the running
+database and rewrite the config file with it. This resolves the drift between
+the config file and the database after operations performed by other tools.
+The database name, hosts and paths are read from the existing config file
+unless they are given on the command line. The database must be running.
+
 Examples:
   # Show the cluster config file in the default location
-  vcluster config show
+  vcluster manage_config show
 
   # Show the contents of the config file at /tmp/vertica_cluster.yaml
-  vcluster config show --config /tmp/vertica_cluster.yaml
+  vcluster manage_config show --config /tmp/vertica_cluster.yaml
+
+  # Refresh the config file in the default location from the running
+  # database and show it
+  vcluster manage_config show --sync --password "PASSWORD"
 `,
-		[]string{configFlag},
+		[]string{configFlag, dbNameFlag, hostsFlag, ipv6Flag, passwordFlag},
 	)
 
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
 	return cmd
 }
 
+// setLocalFlags will set the local flags the command has
+func (c *CmdConfigShow) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&c.sync,
+		"sync",
+		false,
+		"Rewrite the config file with the current topology of the running database before showing it",
+	)
+}
+
 func (c *CmdConfigShow) Parse(inputArgv []string, logger vlog.Printer) error {
 	c.argv = inputArgv
-	logger.LogArgParse(&c.argv)
+	logger.LogMaskedArgParse(c.argv)
 
-	return nil
+	if !c.sync {
+		return nil
+	}
+	return c.validateParse(logger)
 }
 
-func (c *CmdConfigShow) Run(_ vclusterops.ClusterCommands) error {
+// all validations of the arguments should go in here
+func (c *CmdConfigShow) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	c.fillOptionsFromConfig(logger)
+
+	err := c.ValidateParseBaseOptions(&c.sOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.sOptions.DatabaseOptions)
+}
+
+// fillOptionsFromConfig uses the existing config file for the options that are
+// not given on the command line. The config file is not loaded like for the
+// other subcommands because it may be missing or out of date.
+func (c *CmdConfigShow) fillOptionsFromConfig(logger vlog.Printer) {
+	dbConfig, err := readConfig()
+	if err != nil {
+		logger.Info("cannot read the existing config file", "details", err)
+		return
+	}
+	if c.sOptions.DBName == "" {
+		c.sOptions.DBName = dbConfig.Name
+	}
+	if len(c.sOptions.RawHosts) == 0 {
+		c.sOptions.RawHosts = dbConfig.getHosts()
+	}
+	if !c.parser.Changed(ipv6Flag) {
+		c.sOptions.IPv6 = dbConfig.Ipv6
+	}
+	// keep the paths of the existing config file
+	c.sOptions.CatalogPrefix, c.sOptions.DataPrefix, c.sOptions.DepotPrefix = dbConfig.getPathPrefixes()
+}
+
+func (c *CmdConfigShow) Run(vcc vclusterops.ClusterCommands) error {
+	if c.sync {
+		vdb, err := vcc.VFetchRunningCoordinationDatabase(c.sOptions)
+		if err != nil {
+			vcc.LogError(err, "failed to sync the config file")
+			return err
+		}
+		err = writeConfig(&vdb)
+		if err != nil {
+			return fmt.Errorf("fail to write config file, details: %s", err)
+		}
+		vcc.PrintInfo("Synced config file for database %s at %s", vdb.Name, dbOptions.ConfigPath)
+	}
+
 	fileBytes, err := os.ReadFile(dbOptions.ConfigPath)
 	if err != nil {
 		return fmt.Errorf("fail to read config file, details: %w", err)
@@ -77,5 +155,5 @@ func (c *CmdConfigShow) Run(_ vclusterops.ClusterCommands) error {
 
 // SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance
 func (c *CmdConfigShow) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
-	c.sOptions = *opt
+	c.sOptions.DatabaseOptions = *opt
 }
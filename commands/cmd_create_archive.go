@@ -0,0 +1,140 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdCreateArchive
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdCreateArchive struct {
+	CmdBase
+	createArchiveOptions *vclusterops.VCreateArchiveOptions
+}
+
+func makeCmdCreateArchive() *cobra.Command {
+	// CmdCreateArchive
+	newCmd := &CmdCreateArchive{}
+	opt := vclusterops.VCreateArchiveOptionsFactory()
+	newCmd.createArchiveOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		createArchiveSubCmd,
+		"Create a restore point archive",
+		`This subcommand creates a restore point archive in an Eon Mode database.
+
+Use the --num-restore-points option to limit the number of restore points kept
+in the archive. Once the limit is reached, the oldest restore points are
+removed. The default is 0, which keeps all the restore points.
+
+Use show_restore_points to list the restore points of an archive, and
+remove_archive to remove it.
+
+Examples:
+  # Create an archive with config file
+  vcluster create_archive --db-name test_db --archive-name db1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Create an archive that keeps 5 restore points with user input
+  vcluster create_archive --db-name test_db --archive-name db1 \
+    --num-restore-points 5 --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the archive name
+	markFlagsRequired(cmd, []string{archiveNameFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdCreateArchive) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.createArchiveOptions.ArchiveName,
+		archiveNameFlag,
+		"",
+		"The name of the archive",
+	)
+	cmd.Flags().IntVar(
+		&c.createArchiveOptions.NumRestorePoints,
+		"num-restore-points",
+		0,
+		"The maximum number of restore points kept in the archive, 0 means unlimited",
+	)
+	cmd.Flags().StringVar(
+		&c.createArchiveOptions.Namespace,
+		namespaceFlag,
+		"",
+		"The namespace of the archive, if not the default namespace",
+	)
+}
+
+func (c *CmdCreateArchive) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.createArchiveOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdCreateArchive) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.createArchiveOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.createArchiveOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.createArchiveOptions.DatabaseOptions)
+}
+
+func (c *CmdCreateArchive) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.createArchiveOptions
+
+	err := vcc.VCreateArchive(options)
+	if err != nil {
+		vcc.LogError(err, "fail to create the archive", "archive", options.ArchiveName)
+		return err
+	}
+
+	vcc.PrintInfo("Successfully created archive %s in database %s", options.ArchiveName, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdCreateArchive
+func (c *CmdCreateArchive) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.createArchiveOptions.DatabaseOptions = *opt
+}
@@ -17,6 +17,7 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/vertica/vcluster/vclusterops"
@@ -34,6 +35,10 @@ import (
 
 type CmdCreateDB struct {
 	createDBOptions *vclusterops.VCreateDatabaseOptions
+	// host -> catalog, data or depot path that overrides the one of the database
+	nodeCatalogPaths map[string]string
+	nodeDataPaths    map[string]string
+	nodeDepotPaths   map[string]string
 	CmdBase
 }
 
@@ -52,7 +57,11 @@ You must specify the database name, host list, catalog path, and data path.
 
 If --config is not provided, a configuration file is created in one of the
 following locations, in order of precedence:
+- vertica_cluster_<profile>.yaml in one of the directories below if --db-profile
+  is provided
 - path set in VCLUSTER_CONFIG environment variable
+- vertica_cluster_<profile>.yaml in one of the directories below if the
+  VCLUSTER_DB_PROFILE environment variable is set
 - /opt/vertica/config/vertica_config.yaml if running vcluster from /opt/vertica/bin
 - $HOME/.config/vcluster/vertica_config.yaml
 
@@ -66,6 +75,11 @@ The data deleted with these options is unrecoverable.
 Provide the dbadmin password with the --password-file, --read-password-from-prompt,
 or --password options.
 
+Use the --journal option to record the progress of the command in a file. If
+the command is interrupted, for example by a crash of the host that runs it,
+run it again with the same options and --resume to skip the steps that
+completed.
+
 Examples:
   # Create a database and save the generated config file under custom directory
   vcluster create_db --db-name test_db \
@@ -97,9 +111,23 @@ Examples:
     --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
     --catalog-path /data --data-path /data \
     --password 12345678
+
+  # Use a different catalog and data path on a host with another disk layout
+  vcluster create_db --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
+    --catalog-path /data --data-path /data \
+    --node-catalog-path 10.20.30.42=/ssd --node-data-path 10.20.30.42=/hdd \
+    --read-password-from-prompt
+
+  # Resume an interrupted creation of a database
+  vcluster create_db --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
+    --catalog-path /data --data-path /data \
+    --read-password-from-prompt --journal /tmp/create_test_db.json --resume
 `,
 		[]string{dbNameFlag, hostsFlag, catalogPathFlag, dataPathFlag, depotPathFlag,
-			communalStorageLocationFlag, passwordFlag, configFlag, ipv6Flag, configParamFlag},
+			communalStorageLocationFlag, passwordFlag, configFlag, ipv6Flag, configParamFlag, useInstanceProfileFlag,
+			subnetFlag},
 	)
 	// local flags
 	newCmd.setLocalFlags(cmd)
@@ -139,6 +167,7 @@ func (c *CmdCreateDB) setLocalFlags(cmd *cobra.Command) {
 		"SQL file to run (as dbadmin) immediately on database creation",
 	)
 	markFlagsFileName(cmd, map[string][]string{"sql": {"sql"}})
+	c.setResumeFlags(cmd, &c.createDBOptions.ResumeOptions)
 	cmd.Flags().IntVar(
 		&c.createDBOptions.ShardCount,
 		"shard-count",
@@ -211,6 +240,42 @@ func (c *CmdCreateDB) setLocalFlags(cmd *cobra.Command) {
 		util.DefaultTimeoutSeconds,
 		"The timeout to wait for the nodes to start",
 	)
+	cmd.Flags().BoolVar(
+		&c.createDBOptions.SkipClockCheck,
+		"skip-clock-check",
+		false,
+		"Skip the check of clock skew between the hosts",
+	)
+	cmd.Flags().BoolVar(
+		&c.createDBOptions.SkipPortCheck,
+		"skip-port-check",
+		false,
+		"Skip the check of port reachability between the hosts",
+	)
+	cmd.Flags().BoolVar(
+		&c.createDBOptions.SkipPrerequisiteCheck,
+		"skip-prerequisite-check",
+		false,
+		"Skip the check of the hardware prerequisites of the hosts",
+	)
+	cmd.Flags().StringToStringVar(
+		&c.nodeCatalogPaths,
+		"node-catalog-path",
+		map[string]string{},
+		"Comma-separated list of HOST=PATH pairs, each overriding the catalog path of a host",
+	)
+	cmd.Flags().StringToStringVar(
+		&c.nodeDataPaths,
+		"node-data-path",
+		map[string]string{},
+		"Comma-separated list of HOST=PATH pairs, each overriding the data path of a host",
+	)
+	cmd.Flags().StringToStringVar(
+		&c.nodeDepotPaths,
+		"node-depot-path",
+		map[string]string{},
+		util.GetEonFlagMsg("Comma-separated list of HOST=PATH pairs, each overriding the depot path of a host"),
+	)
 }
 
 // setHiddenFlags will set the hidden flags the command has.
@@ -257,9 +322,32 @@ func (c *CmdCreateDB) validateParse(logger vlog.Printer) error {
 		return err
 	}
 
+	c.parseNodePaths()
+
 	return c.setDBPassword(&c.createDBOptions.DatabaseOptions)
 }
 
+// parseNodePaths merges the paths of --node-catalog-path, --node-data-path
+// and --node-depot-path into the node paths of the options
+func (c *CmdCreateDB) parseNodePaths() {
+	nodePaths := make(map[string]vclusterops.VNodePaths)
+	mergePaths := func(hostToPath map[string]string, setPath func(*vclusterops.VNodePaths, string)) {
+		for host, path := range hostToPath {
+			// hosts are lowercased like the ones of --hosts
+			host = strings.ToLower(strings.TrimSpace(host))
+			paths := nodePaths[host]
+			setPath(&paths, path)
+			nodePaths[host] = paths
+		}
+	}
+	mergePaths(c.nodeCatalogPaths, func(p *vclusterops.VNodePaths, path string) { p.CatalogPrefix = path })
+	mergePaths(c.nodeDataPaths, func(p *vclusterops.VNodePaths, path string) { p.DataPrefix = path })
+	mergePaths(c.nodeDepotPaths, func(p *vclusterops.VNodePaths, path string) { p.DepotPrefix = path })
+	if len(nodePaths) > 0 {
+		c.createDBOptions.NodePaths = nodePaths
+	}
+}
+
 func (c *CmdCreateDB) Run(vcc vclusterops.ClusterCommands) error {
 	vcc.V(1).Info("Called method Run()")
 	vdb, createError := vcc.VCreateDatabase(c.createDBOptions)
@@ -0,0 +1,167 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdDistributeFile
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdDistributeFile struct {
+	distributeFileOptions *vclusterops.VDistributeFileOptions
+
+	CmdBase
+}
+
+func makeCmdDistributeFile() *cobra.Command {
+	newCmd := &CmdDistributeFile{}
+	opt := vclusterops.VDistributeFileOptionsFactory()
+	newCmd.distributeFileOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		distributeFileSubCmd,
+		"Copy a local file to the hosts of a database",
+		`This subcommand copies a file of the local host, such as a license, the
+library of a UDx or a configuration override, to the same path on the hosts of
+a database through the Node Management Agent.
+
+The file is copied to all the hosts of the database unless the --target-hosts
+option is provided. The SHA-256 checksum of the file written on each host is
+compared with the checksum of the local file. The destination path, size,
+checksum and the hosts that received the file are printed in JSON.
+
+An existing file on a host is only replaced when the --overwrite option is
+provided. The file must not be larger than 64 MiB.
+
+Examples:
+  # Copy a license to all the hosts with config file
+  vcluster distribute_file --local-path /tmp/license.key \
+    --destination /opt/vertica/config/license.key \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Replace the library of a UDx on two hosts
+  vcluster distribute_file --local-path ./libudx.so \
+    --destination /home/dbadmin/udx/libudx.so --file-mode 0755 --overwrite \
+    --target-hosts 10.20.30.40,10.20.30.41 --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, configFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the file to copy and its destination
+	markFlagsRequired(cmd, []string{"local-path", "destination"})
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdDistributeFile) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.distributeFileOptions.LocalPath,
+		"local-path",
+		"",
+		"Path of the file to copy on the local host",
+	)
+	cmd.Flags().StringVar(
+		&c.distributeFileOptions.DestinationPath,
+		"destination",
+		"",
+		"Absolute path of the file on the hosts",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.distributeFileOptions.TargetHosts,
+		"target-hosts",
+		[]string{},
+		"Comma-separated list of host(s) to which the file is copied. Defaults to all the hosts of the database",
+	)
+	cmd.Flags().StringVar(
+		&c.distributeFileOptions.FileMode,
+		"file-mode",
+		"",
+		"Octal permission of the file on the hosts, e.g., 0644",
+	)
+	cmd.Flags().BoolVar(
+		&c.distributeFileOptions.Overwrite,
+		"overwrite",
+		false,
+		"Replace the file if it already exists on a host",
+	)
+}
+
+func (c *CmdDistributeFile) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.distributeFileOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdDistributeFile) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	if len(c.distributeFileOptions.TargetHosts) > 0 {
+		err := util.ParseHostList(&c.distributeFileOptions.TargetHosts)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.getCertFilesFromCertPaths(&c.distributeFileOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	return c.ValidateParseBaseOptions(&c.distributeFileOptions.DatabaseOptions)
+}
+
+func (c *CmdDistributeFile) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.distributeFileOptions
+
+	result, distributeErr := vcc.VDistributeFile(options)
+	// the hosts that received the file are printed even if some hosts failed
+	if result.DestinationPath != "" {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	}
+	if distributeErr != nil {
+		vcc.LogError(distributeErr, "failed to distribute file", "LocalPath", options.LocalPath)
+		return distributeErr
+	}
+	vcc.PrintInfo("Successfully copied %s to %s on hosts %v", options.LocalPath, options.DestinationPath, result.Hosts)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdDistributeFile
+func (c *CmdDistributeFile) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.distributeFileOptions.DatabaseOptions = *opt
+}
@@ -49,7 +49,11 @@ the dropped database with revive_db.
 The config file must be specified to retrieve host information. If --config
 is not provided, a configuration file is created in one of the following 
 locations, in order of precedence:
+- vertica_cluster_<profile>.yaml in one of the directories below if --db-profile
+  is provided
 - path set in VCLUSTER_CONFIG environment variable
+- vertica_cluster_<profile>.yaml in one of the directories below if the
+  VCLUSTER_DB_PROFILE environment variable is set
 - /opt/vertica/config/vertica_config.yaml if running vcluster from /opt/vertica/bin
 - $HOME/.config/vcluster/vertica_config.yaml
 
@@ -58,10 +62,18 @@ When the command completes, the config file is removed.
 To remove the local directories like catalog, depot, and data, use the 
 --force-delete option. The data deleted with this option is unrecoverable.
 
+The command asks you to retype the name of the database before it drops it,
+and fails if it is not run from a terminal. Use the --confirm or --yes option
+to skip the confirmation, e.g., in scripts. The command also fails if a vertica
+process is still running on any host.
+
 Examples:
   # Drop a database with config file
   vcluster drop_db --db-name test_db \
     --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Drop a database from a script, without confirmation
+  vcluster drop_db --db-name test_db --confirm
 `,
 		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, catalogPathFlag, dataPathFlag, depotPathFlag},
 	)
@@ -83,6 +95,13 @@ func (c *CmdDropDB) setLocalFlags(cmd *cobra.Command) {
 		false,
 		"Delete local directories like catalog, depot, and data.",
 	)
+	c.setConfirmationFlags(cmd)
+	cmd.Flags().BoolVar(
+		&c.assumeYes,
+		"confirm",
+		false,
+		"Same as --"+yesFlag+", drop the database without asking to retype its name",
+	)
 }
 
 func (c *CmdDropDB) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -104,7 +123,13 @@ func (c *CmdDropDB) validateParse(logger vlog.Printer) error {
 func (c *CmdDropDB) Run(vcc vclusterops.ClusterCommands) error {
 	vcc.V(1).Info("Called method Run()")
 
-	err := vcc.VDropDatabase(c.dropDBOptions)
+	err := c.confirmOperationWithName(c.dropDBOptions.DBName,
+		"Database %s and its local directories will be dropped.", c.dropDBOptions.DBName)
+	if err != nil {
+		return err
+	}
+
+	err = vcc.VDropDatabase(c.dropDBOptions)
 	if err != nil {
 		vcc.LogError(err, "failed do drop the database")
 		return err
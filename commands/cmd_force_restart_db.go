@@ -0,0 +1,149 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdForceRestartDB
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdForceRestartDB struct {
+	forceRestartOptions *vclusterops.VForceRestartDatabaseOptions
+
+	CmdBase
+}
+
+func makeCmdForceRestartDB() *cobra.Command {
+	// CmdForceRestartDB
+	newCmd := &CmdForceRestartDB{}
+	opt := vclusterops.VForceRestartDatabaseOptionsFactory()
+	newCmd.forceRestartOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		forceRestartDBSubCmd,
+		"Restart a database that lost its quorum, from the nodes with the latest catalog",
+		`This subcommand restarts a down database that lost its quorum of primary
+nodes, e.g., after several hosts were lost, when start_db cannot start it.
+Only the nodes that have the latest catalog are restarted, without waiting for
+the other nodes. Restart the other nodes with restart_node afterwards.
+
+THIS IS UNSAFE: the transactions that were only committed on the nodes that
+are not restarted are lost, and the database may be inconsistent. Only use it
+when the database cannot be started otherwise.
+
+Without the --unsafe option, the subcommand only reports the nodes that it
+would restart and the ones it would leave out.
+
+The catalogs of the hosts whose NMA is not reachable cannot be compared, so
+one of them may have a later catalog than the restarted nodes. If the NMA is
+not reachable on some hosts, the --allow-unreachable-hosts option is also
+required to restart the nodes.
+
+Examples:
+  # Report the nodes that would be restarted, with config file
+  vcluster force_restart_db --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Restart the nodes with the latest catalog, with user input
+  vcluster force_restart_db --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --catalog-path /data --unsafe
+`,
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, configFlag, catalogPathFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdForceRestartDB) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&c.forceRestartOptions.Unsafe,
+		"unsafe",
+		false,
+		"Confirm the restart of the nodes with the latest catalog, which can lose data",
+	)
+	cmd.Flags().BoolVar(
+		&c.forceRestartOptions.AllowUnreachableHosts,
+		"allow-unreachable-hosts",
+		false,
+		"Confirm the restart even if the NMA is not reachable on some hosts, whose catalogs cannot be compared",
+	)
+	cmd.Flags().IntVar(
+		&c.forceRestartOptions.StatePollingTimeout,
+		"timeout",
+		util.DefaultTimeoutSeconds,
+		"The timeout (in seconds) to wait for polling node state operation",
+	)
+}
+
+func (c *CmdForceRestartDB) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.forceRestartOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdForceRestartDB) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.forceRestartOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.forceRestartOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.forceRestartOptions.DatabaseOptions)
+}
+
+func (c *CmdForceRestartDB) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.forceRestartOptions
+
+	restartedHosts, err := vcc.VForceRestartDatabase(options)
+	if err != nil {
+		if !options.Unsafe {
+			vcc.PrintInfo("Rerun the command with --unsafe to restart the nodes")
+		}
+		vcc.LogError(err, "fail to force the restart of the database", "dbName", options.DBName)
+		return err
+	}
+
+	vcc.PrintInfo("Restarted database %s unsafely from hosts %v", options.DBName, restartedHosts)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdForceRestartDB
+func (c *CmdForceRestartDB) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.forceRestartOptions.DatabaseOptions = *opt
+}
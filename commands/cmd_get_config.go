@@ -0,0 +1,124 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdGetConfig
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdGetConfig struct {
+	getConfigOptions *vclusterops.VGetConfigurationParameterOptions
+	level            string
+
+	CmdBase
+}
+
+func makeCmdGetConfig() *cobra.Command {
+	// CmdGetConfig
+	newCmd := &CmdGetConfig{}
+	opt := vclusterops.VGetConfigurationParameterOptionsFactory()
+	newCmd.getConfigOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		getConfigSubCmd,
+		"Get the value of a configuration parameter",
+		`This subcommand prints, in JSON format, the current and the default values
+of a configuration parameter of a database.
+
+The value is the one of the whole database, or, with the --level and
+--level-name options, the one of a node or of a subcluster.
+
+Examples:
+  # Get a configuration parameter of the database with config file
+  vcluster get_config --parameter MaxClientSessions \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Get a configuration parameter of a node with user input
+  vcluster get_config --db-name test_db --hosts 10.20.30.40 \
+    --parameter MaxClientSessions --level node --level-name v_test_db_node0001
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	setConfigParameterFlags(cmd, newCmd.getConfigOptions, &newCmd.level)
+
+	// require the parameter name
+	markFlagsRequired(cmd, []string{configParameterFlag})
+
+	return cmd
+}
+
+func (c *CmdGetConfig) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.getConfigOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdGetConfig) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	c.getConfigOptions.Level = vclusterops.ConfigParameterLevel(c.level)
+
+	err := c.getCertFilesFromCertPaths(&c.getConfigOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.getConfigOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.getConfigOptions.DatabaseOptions)
+}
+
+func (c *CmdGetConfig) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.getConfigOptions
+
+	info, err := vcc.VGetConfigurationParameter(options)
+	if err != nil {
+		vcc.LogError(err, "fail to get the configuration parameter", "parameter", options.ConfigParameter)
+		return err
+	}
+	bytes, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdGetConfig
+func (c *CmdGetConfig) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.getConfigOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,127 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdHibernateDB
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdHibernateDB struct {
+	hibernateDBOptions *vclusterops.VHibernateDatabaseOptions
+
+	CmdBase
+}
+
+func makeCmdHibernateDB() *cobra.Command {
+	// CmdHibernateDB
+	newCmd := &CmdHibernateDB{}
+	opt := vclusterops.VHibernateDatabaseOptionsFactory()
+	newCmd.hibernateDBOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		hibernateDBSubCmd,
+		"Hibernate an Eon database",
+		`This subcommand suspends a running Eon Mode database, so that its hosts can be
+shut down to save costs.
+
+The catalog is synchronized to communal storage, all the nodes are stopped, and
+the database is marked as hibernated in its metadata on communal storage.
+Use wake_db to start the database on the same hosts again, or revive_db to
+start it on other hosts.
+
+A database that has sandboxes cannot be hibernated.
+
+Examples:
+  # Hibernate a database with config file using password authentication
+  vcluster hibernate_db --password testpassword \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`,
+		[]string{dbNameFlag, hostsFlag, communalStorageLocationFlag, ipv6Flag,
+			configFlag, passwordFlag, eonModeFlag, configParamFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdHibernateDB) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&c.hibernateDBOptions.DrainSeconds,
+		"drain-seconds",
+		util.DefaultDrainSeconds,
+		"Seconds to wait for user connections to close before the database is stopped",
+	)
+	c.setConfirmationFlags(cmd)
+}
+
+func (c *CmdHibernateDB) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	c.ResetUserInputOptions(&c.hibernateDBOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdHibernateDB) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()", "command", hibernateDBSubCmd)
+
+	err := c.getCertFilesFromCertPaths(&c.hibernateDBOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.hibernateDBOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.hibernateDBOptions.DatabaseOptions)
+}
+
+func (c *CmdHibernateDB) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.hibernateDBOptions
+
+	err := c.confirmOperation("Stop and hibernate database %s?", options.DBName)
+	if err != nil {
+		return err
+	}
+
+	err = vcc.VHibernateDatabase(options)
+	if err != nil {
+		vcc.LogError(err, "failed to hibernate the database")
+		return err
+	}
+
+	vcc.PrintInfo("Successfully hibernated the database %s", options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdHibernateDB
+func (c *CmdHibernateDB) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.hibernateDBOptions.DatabaseOptions = *opt
+}
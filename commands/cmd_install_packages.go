@@ -54,6 +54,12 @@ comma-separated list.
 Default packages are located in /opt/vertica/packages. During installation, the
 status for each package is returned.
 
+Use the --package option to only install some of the default packages.
+
+The packages are installed from one up host. Use the --max-host-failures
+option to try the next up hosts if the install fails on that host. The
+output tells on which hosts the install succeeded or failed.
+
 Examples:
   # Install default packages with user input
   vcluster install_packages --db-name test_db \
@@ -62,6 +68,10 @@ Examples:
   # Force (re)install default packages with config file
   vcluster install_packages --db-name test_db --force-reinstall \
     --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Install only two of the default packages
+  vcluster install_packages --db-name test_db --package ComplexTypes,DelimitedExport \
+    --config /opt/vertica/config/vertica_cluster.yaml
 `,
 		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
 	)
@@ -80,6 +90,24 @@ func (c *CmdInstallPackages) setLocalFlags(cmd *cobra.Command) {
 		false,
 		"Install the packages, even if they are already installed.",
 	)
+	cmd.Flags().BoolVar(
+		&c.installPkgOpts.ForceReinstall,
+		"force",
+		false,
+		"Same as --force-reinstall.",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.installPkgOpts.PackageNames,
+		"package",
+		[]string{},
+		"Comma-separated list of the packages to install. If omitted, all default packages are installed.",
+	)
+	cmd.Flags().IntVar(
+		&c.installPkgOpts.MaxHostFailures,
+		"max-host-failures",
+		0,
+		"Number of up hosts on which the install can fail before another up host installs the packages",
+	)
 }
 
 func (c *CmdInstallPackages) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -120,6 +148,9 @@ func (c *CmdInstallPackages) Run(vcc vclusterops.ClusterCommands) error {
 		vcc.LogError(err, "failed to install the packages")
 		return err
 	}
+	if failedPackages := status.GetPackagesWithStatus("Failure"); len(failedPackages) > 0 {
+		vcc.PrintWarning("Failed to install packages %v", failedPackages)
+	}
 
 	var bytes []byte
 	bytes, err = json.MarshalIndent(status, "", "  ")
@@ -0,0 +1,152 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const killHostsFlag = "kill-hosts"
+
+/* CmdKillVertica
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdKillVertica struct {
+	killVerticaOptions *vclusterops.VKillVerticaOptions
+	// the user must confirm that they want to kill the vertica processes
+	confirm bool
+
+	CmdBase
+}
+
+func makeCmdKillVertica() *cobra.Command {
+	newCmd := &CmdKillVertica{}
+	opt := vclusterops.VKillVerticaOptionsFactory()
+	newCmd.killVerticaOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		killVerticaSubCmd,
+		"Kill the vertica process on a list of host(s)",
+		`This subcommand sends SIGKILL to the vertica process on one or more hosts.
+
+This is a last resort for when stop_node or stop_db hangs. Any uncommitted
+work on the killed nodes is lost. You must provide the --confirm option to
+run this subcommand.
+
+The subcommand refuses to kill hosts that hold the only up copy of a shard
+in an Eon Mode database. Use the --force option to skip this check.
+
+Examples:
+  # Kill the vertica process on a host with config file
+  vcluster kill_vertica --kill-hosts 10.20.30.43 --confirm \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Kill the vertica process on hosts even if some shards lose their only up copy
+  vcluster kill_vertica --db-name test_db --kill-hosts 10.20.30.40,10.20.30.41 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --confirm --force
+`,
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, configFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require hosts to kill
+	markFlagsRequired(cmd, []string{killHostsFlag})
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdKillVertica) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(
+		&c.killVerticaOptions.HostsToKill,
+		killHostsFlag,
+		[]string{},
+		"Comma-separated list of host(s) on which to kill vertica",
+	)
+	cmd.Flags().BoolVar(
+		&c.confirm,
+		"confirm",
+		false,
+		"Confirm that the vertica process must be killed",
+	)
+	cmd.Flags().BoolVar(
+		&c.killVerticaOptions.Force,
+		"force",
+		false,
+		"Kill vertica even if the host(s) hold the only up copy of a shard",
+	)
+}
+
+func (c *CmdKillVertica) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.killVerticaOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdKillVertica) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	if !c.confirm {
+		return fmt.Errorf("killing vertica may lose uncommitted work, use --confirm to proceed")
+	}
+
+	err := util.ParseHostList(&c.killVerticaOptions.HostsToKill)
+	if err != nil {
+		return fmt.Errorf("must specify at least one valid host on which to kill vertica: %w", err)
+	}
+
+	err = c.getCertFilesFromCertPaths(&c.killVerticaOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.killVerticaOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.killVerticaOptions.DatabaseOptions)
+}
+
+func (c *CmdKillVertica) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.killVerticaOptions
+
+	err := vcc.VKillVertica(options)
+	if err != nil {
+		vcc.LogError(err, "failed to kill vertica", "Hosts", options.HostsToKill)
+		return err
+	}
+	vcc.PrintInfo("Successfully killed vertica on hosts %v", options.HostsToKill)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdKillVertica
+func (c *CmdKillVertica) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.killVerticaOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,39 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestKillVerticaParseHosts(t *testing.T) {
+	opt := vclusterops.VKillVerticaOptionsFactory()
+	c := CmdKillVertica{killVerticaOptions: &opt, confirm: true}
+
+	// the error of an invalid host range is kept
+	opt.HostsToKill = []string{"host[05-01]"}
+	err := c.validateParse(vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify at least one valid host on which to kill vertica")
+	assert.ErrorContains(t, err, "the start 5 is greater than the end 1")
+
+	opt.HostsToKill = []string{" "}
+	err = c.validateParse(vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify a host or host list")
+}
@@ -56,6 +56,19 @@ The --db-name and --catalog-path options are required only when vcluster cannot
 obtain node information from a running database and the config file is not
 provided.
 
+In an Eon Mode database, the output includes the labels of each node and of
+its subcluster in the config file, e.g.:
+  nodes:
+    - name: v_test_db_node0001
+      labels:
+        rack: r1
+  subclusters:
+    - name: default_subcluster
+      labels:
+        zone: us-east-1a
+The labels of a node override the ones of its subcluster with the same key.
+vcluster keeps the labels when it updates the config file.
+
 Examples:
   # List the status of nodes with config file where password authentication is
   # used to access the database
@@ -135,7 +148,7 @@ func (c *CmdListAllNodes) marshalNoteStates(nodeStates []vclusterops.NodeInfo) (
 	}
 
 	if isEon {
-		bytes, err = json.MarshalIndent(nodeStates, "", "  ")
+		bytes, err = json.MarshalIndent(addNodeLabels(nodeStates), "", "  ")
 		if err != nil {
 			return bytes, fmt.Errorf("fail to marshal the node state result, details %w", err)
 		}
@@ -158,3 +171,36 @@ func (c *CmdListAllNodes) marshalNoteStates(nodeStates []vclusterops.NodeInfo) (
 
 	return bytes, nil
 }
+
+// nodeInfoWithLabels is a node state with the labels of the node and its
+// subcluster in the config file
+type nodeInfoWithLabels struct {
+	vclusterops.NodeInfo
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// addNodeLabels adds the labels of the config file to the node states. The
+// labels of a node override the ones of its subcluster with the same key.
+func addNodeLabels(nodeStates []vclusterops.NodeInfo) []nodeInfoWithLabels {
+	dbConfig, err := readConfig()
+	if err != nil {
+		dbConfig = &DatabaseConfig{}
+	}
+	nodeLabels := dbConfig.getNodeLabels()
+	scLabels := dbConfig.getSubclusterLabels()
+
+	nodes := make([]nodeInfoWithLabels, 0, len(nodeStates))
+	for i := range nodeStates {
+		n := nodeInfoWithLabels{NodeInfo: nodeStates[i]}
+		for _, labels := range []map[string]string{scLabels[n.Subcluster], nodeLabels[n.Name]} {
+			for key, value := range labels {
+				if n.Labels == nil {
+					n.Labels = make(map[string]string)
+				}
+				n.Labels[key] = value
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
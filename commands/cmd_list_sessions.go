@@ -0,0 +1,145 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdListSessions
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdListSessions struct {
+	listSessionsOptions *vclusterops.VListSessionsOptions
+
+	CmdBase
+}
+
+func makeCmdListSessions() *cobra.Command {
+	// CmdListSessions
+	newCmd := &CmdListSessions{}
+	opt := vclusterops.VListSessionsOptionsFactory()
+	newCmd.listSessionsOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		listSessionsSubCmd,
+		"List the client sessions of a database",
+		`This subcommand lists the client sessions of a running database in JSON.
+
+The sessions can be filtered by the nodes they are connected to, with the
+--node-names or --subcluster option, and by database user, with the
+--session-user option. The filters are combined.
+
+Use this subcommand to find the sessions that keep a subcluster from
+draining before it is stopped or removed, and the close_sessions subcommand
+to close them.
+
+Examples:
+  # List all the sessions of a database with config file
+  vcluster list_sessions --config /opt/vertica/config/vertica_cluster.yaml
+
+  # List the sessions of a user on a subcluster with user input
+  vcluster list_sessions --db-name test_db --hosts 10.20.30.40 \
+    --subcluster sc1 --session-user app_user
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdListSessions) setLocalFlags(cmd *cobra.Command) {
+	setSessionFilterFlags(cmd, &c.listSessionsOptions.SessionFilter)
+}
+
+// setSessionFilterFlags sets the flags of the sessions commands that filter
+// the sessions
+func setSessionFilterFlags(cmd *cobra.Command, filter *vclusterops.SessionFilter) {
+	cmd.Flags().StringSliceVar(
+		&filter.NodeNames,
+		"node-names",
+		[]string{},
+		"Comma-separated list of node names whose sessions are selected",
+	)
+	cmd.Flags().StringVar(
+		&filter.SCName,
+		subclusterFlag,
+		"",
+		"Name of subcluster whose sessions are selected",
+	)
+	cmd.Flags().StringVar(
+		&filter.SessionUser,
+		"session-user",
+		"",
+		"Name of the database user whose sessions are selected",
+	)
+}
+
+func (c *CmdListSessions) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.listSessionsOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdListSessions) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.listSessionsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.listSessionsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.listSessionsOptions.DatabaseOptions)
+}
+
+func (c *CmdListSessions) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	sessions, err := vcc.VListSessions(c.listSessionsOptions)
+	if err != nil {
+		vcc.LogError(err, "fail to list sessions", "DBName", c.listSessionsOptions.DBName)
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	vcc.LogInfo("Found sessions", "count", len(sessions))
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdListSessions
+func (c *CmdListSessions) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.listSessionsOptions.DatabaseOptions = *opt
+}
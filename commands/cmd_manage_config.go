@@ -30,11 +30,13 @@ import (
 func makeCmdManageConfig() *cobra.Command {
 	cmd := makeSimpleCobraCmd(
 		manageConfigSubCmd,
-		"Display or recover the contents of the config file",
-		`This subcommand displays or recovers the contents of the config file.`)
+		"Display, recover or compare the contents of the config file",
+		`This subcommand displays or recovers the contents of the config file, or
+compares it with the live topology of the database.`)
 
 	cmd.AddCommand(makeCmdConfigShow())
 	cmd.AddCommand(makeCmdConfigRecover())
+	cmd.AddCommand(makeCmdConfigDiff())
 
 	return cmd
 }
@@ -0,0 +1,191 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func makeCmdManageConnections() *cobra.Command {
+	cmd := makeSimpleCobraCmd(
+		manageConnSubCmd,
+		"Pause, redirect or resume the client connections of a subcluster",
+		`This subcommand controls whether the subclusters of an Eon Mode database
+accept new client connections. It is used along with connection draining, e.g.,
+to steer the traffic away from a subcluster before its nodes are stopped in a
+rolling upgrade.
+
+The existing sessions are not closed. Use the list_sessions and close_sessions
+subcommands to find and close them.`)
+
+	cmd.AddCommand(makeCmdManageConnectionsAction(vclusterops.ActionPause,
+		"Stop accepting new client connections",
+		`This subcommand makes a subcluster stop accepting new client connections.
+The existing sessions can complete their work. If no subcluster is given, all
+the subclusters of the main cluster or of the given sandbox stop accepting new
+client connections.
+
+Examples:
+  # Pause the connections of a subcluster with config file
+  vcluster manage_connections pause --subcluster sc1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Pause the connections of a subcluster with user input
+  vcluster manage_connections pause --db-name test_db --subcluster sc1 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --password testpassword
+`))
+	cmd.AddCommand(makeCmdManageConnectionsAction(vclusterops.ActionRedirect,
+		"Redirect new client connections to another host",
+		`This subcommand redirects the new client connections of a subcluster to the
+host given by the --redirect-hostname option, e.g., a host of another
+subcluster or a load balancer. The existing sessions can complete their work.
+
+Examples:
+  # Redirect the new connections of a subcluster with config file
+  vcluster manage_connections redirect --subcluster sc1 \
+    --redirect-hostname 10.20.30.50 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdManageConnectionsAction(vclusterops.ActionResume,
+		"Accept new client connections again",
+		`This subcommand makes a subcluster accept new client connections again, after
+they were paused or redirected.
+
+Examples:
+  # Resume the connections of a subcluster with config file
+  vcluster manage_connections resume --subcluster sc1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+
+	return cmd
+}
+
+/* CmdManageConnections
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdManageConnections struct {
+	manageConnOptions *vclusterops.VManageConnectionDrainingOptions
+
+	CmdBase
+}
+
+func makeCmdManageConnectionsAction(action vclusterops.ConnectionDrainingAction, short, long string) *cobra.Command {
+	newCmd := &CmdManageConnections{}
+	opt := vclusterops.VManageConnectionDrainingOptionsFactory()
+	opt.Action = action
+	newCmd.manageConnOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		string(action),
+		short,
+		long,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, eonModeFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd, action)
+
+	if action == vclusterops.ActionRedirect {
+		markFlagsRequired(cmd, []string{"redirect-hostname"})
+	}
+
+	// hide eon mode flag since we expect it to come from config file, not from user input
+	hideLocalFlags(cmd, []string{eonModeFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdManageConnections) setLocalFlags(cmd *cobra.Command, action vclusterops.ConnectionDrainingAction) {
+	cmd.Flags().StringVar(
+		&c.manageConnOptions.SCName,
+		subclusterFlag,
+		"",
+		"Name of the subcluster whose connections are managed. If empty, all the subclusters are managed",
+	)
+	cmd.Flags().StringVar(
+		&c.manageConnOptions.Sandbox,
+		sandboxFlag,
+		"",
+		"Name of the sandbox of the subcluster. If empty, the main cluster is assumed",
+	)
+	if action == vclusterops.ActionRedirect {
+		cmd.Flags().StringVar(
+			&c.manageConnOptions.RedirectHostname,
+			"redirect-hostname",
+			"",
+			"The host to redirect the new client connections to",
+		)
+	}
+}
+
+func (c *CmdManageConnections) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.manageConnOptions.DatabaseOptions)
+
+	// manage_connections only works for an Eon db so we assume the user always runs this subcommand
+	// on an Eon db. When Eon mode cannot be found in config file, we set its value to true.
+	if !viper.IsSet(eonModeKey) {
+		c.manageConnOptions.IsEon = true
+	}
+	return c.validateParse(logger)
+}
+
+func (c *CmdManageConnections) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.manageConnOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.manageConnOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.manageConnOptions.DatabaseOptions)
+}
+
+func (c *CmdManageConnections) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.manageConnOptions
+
+	err := vcc.VManageConnectionDraining(options)
+	if err != nil {
+		vcc.LogError(err, "fail to manage connections", "action", options.Action)
+		return err
+	}
+	target := "all subclusters"
+	if options.SCName != "" {
+		target = "subcluster " + options.SCName
+	}
+	vcc.PrintInfo("Successfully completed connection %s on %s", options.Action, target)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdManageConnections
+func (c *CmdManageConnections) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.manageConnOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,152 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func makeCmdNMA() *cobra.Command {
+	cmd := makeSimpleCobraCmd(
+		nmaSubCmd,
+		"Start or stop the Node Management Agent",
+		`This subcommand starts or stops the Node Management Agent (NMA) on hosts.`)
+
+	cmd.AddCommand(makeCmdNMAAction(vclusterops.NMAStart, startNMASubCmd,
+		"Start the Node Management Agent",
+		`This subcommand starts the Node Management Agent (NMA) on the hosts where it
+is not running, and waits for it to be healthy. Use it when other subcommands
+fail because the NMA is not healthy on some hosts.
+
+The NMA of the host vcluster runs on is started with systemctl. The NMA of the
+other hosts is started through the Vertica HTTPS service of the host, so
+Vertica must be up on those hosts.
+
+Examples:
+  # Start the NMA on all hosts of the database with config file
+  vcluster nma start --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Start the NMA on a host through Vertica with user input
+  vcluster nma start --hosts 10.20.30.41 --password testpassword
+`))
+	cmd.AddCommand(makeCmdNMAAction(vclusterops.NMAStop, stopNMASubCmd,
+		"Stop the Node Management Agent",
+		`This subcommand stops the Node Management Agent (NMA) on the hosts.
+
+The NMA of the host vcluster runs on is stopped with systemctl. The NMA of the
+other hosts is stopped through the Vertica HTTPS service of the host, so
+Vertica must be up on those hosts.
+
+Examples:
+  # Stop the NMA on a host with user input
+  vcluster nma stop --hosts 10.20.30.41 --password testpassword
+`))
+
+	return cmd
+}
+
+/* CmdNMA
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdNMA struct {
+	manageNMAOptions *vclusterops.VManageNMAOptions
+
+	CmdBase
+}
+
+func makeCmdNMAAction(action vclusterops.NMAAction, subCmd, short, long string) *cobra.Command {
+	newCmd := &CmdNMA{}
+	opt := vclusterops.VManageNMAOptionsFactory()
+	opt.Action = action
+	newCmd.manageNMAOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		subCmd,
+		short,
+		long,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd, action)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdNMA) setLocalFlags(cmd *cobra.Command, action vclusterops.NMAAction) {
+	cmd.Flags().StringVar(
+		&c.manageNMAOptions.ServiceName,
+		"service-name",
+		c.manageNMAOptions.ServiceName,
+		"The systemd unit that runs the NMA on the hosts",
+	)
+	if action == vclusterops.NMAStart {
+		cmd.Flags().IntVar(
+			&c.manageNMAOptions.StartTimeout,
+			"timeout",
+			c.manageNMAOptions.StartTimeout,
+			"The timeout (in seconds) to wait for the NMA to be healthy",
+		)
+	}
+}
+
+func (c *CmdNMA) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.manageNMAOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdNMA) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.manageNMAOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.manageNMAOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.manageNMAOptions.DatabaseOptions)
+}
+
+func (c *CmdNMA) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.manageNMAOptions
+
+	err := vcc.VManageNMA(options)
+	if err != nil {
+		vcc.LogError(err, "fail to manage NMA", "action", options.Action)
+		return err
+	}
+	vcc.PrintInfo("Successfully completed NMA %s on hosts %v", options.Action, options.Hosts)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdNMA
+func (c *CmdNMA) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.manageNMAOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,165 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdPauseSubcluster
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdPauseSubcluster struct {
+	pauseScOptions *vclusterops.VPauseSubclusterOptions
+
+	CmdBase
+}
+
+func makeCmdPauseSubcluster() *cobra.Command {
+	// CmdPauseSubcluster
+	newCmd := &CmdPauseSubcluster{}
+	opt := vclusterops.VPauseSubclusterOptionsFactory()
+	newCmd.pauseScOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		pauseSCSubCmd,
+		"Pause a subcluster",
+		`This subcommand scales a secondary subcluster of an Eon Mode database down
+to zero nodes, to pause the compute of its workload.
+
+You must provide the subcluster name with the --subcluster option.
+
+The subcluster is stopped once its users are disconnected, and all its hosts
+are removed from the database. Unlike remove_subcluster, the subcluster is kept
+in the catalog with its settings. Use add_node with the --subcluster option to
+add hosts to the subcluster again, which rebalances its shards.
+
+You cannot pause a primary or a sandboxed subcluster.
+
+Examples:
+  # Pause a subcluster with config file
+  vcluster pause_subcluster --subcluster sc1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Pause a subcluster with user input, waiting 30 seconds for its users
+  vcluster pause_subcluster --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --subcluster sc1 \
+    --depot-path /data --drain-seconds 30
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, eonModeFlag, dataPathFlag, depotPathFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require name of subcluster to pause
+	markFlagsRequired(cmd, []string{subclusterFlag})
+
+	// hide eon mode flag since we expect it to come from config file, not from user input
+	hideLocalFlags(cmd, []string{eonModeFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdPauseSubcluster) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.pauseScOptions.SCName,
+		subclusterFlag,
+		"",
+		"Name of subcluster to be paused",
+	)
+	cmd.Flags().IntVar(
+		&c.pauseScOptions.DrainSeconds,
+		"drain-seconds",
+		util.DefaultDrainSeconds,
+		"Seconds to wait for user connections to close before the subcluster is stopped",
+	)
+	cmd.Flags().BoolVar(
+		&c.pauseScOptions.ForceDelete,
+		"force-delete",
+		true,
+		"Whether force delete directories if they are not empty",
+	)
+	c.setConfirmationFlags(cmd)
+}
+
+func (c *CmdPauseSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.pauseScOptions.DatabaseOptions)
+
+	// pause_subcluster only works for an Eon db so we assume the user always runs this subcommand
+	// on an Eon db. When Eon mode cannot be found in config file, we set its value to true.
+	if !viper.IsSet(eonModeKey) {
+		c.pauseScOptions.IsEon = true
+	}
+	return c.validateParse(logger)
+}
+
+func (c *CmdPauseSubcluster) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.pauseScOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.pauseScOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.pauseScOptions.DatabaseOptions)
+}
+
+func (c *CmdPauseSubcluster) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.pauseScOptions
+
+	err := c.confirmOperation("Stop and remove all the hosts of subcluster %s in database %s?",
+		options.SCName, options.DBName)
+	if err != nil {
+		return err
+	}
+
+	vdb, err := vcc.VPauseSubcluster(options)
+	if err != nil {
+		return err
+	}
+
+	// write db info to vcluster config file
+	err = writeConfig(&vdb)
+	if err != nil {
+		vcc.PrintWarning("fail to write config file, details: %s", err)
+	}
+	vcc.PrintInfo("Successfully paused subcluster %s in database %s",
+		options.SCName, options.DBName)
+
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdPauseSubcluster
+func (c *CmdPauseSubcluster) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.pauseScOptions.DatabaseOptions = *opt
+}
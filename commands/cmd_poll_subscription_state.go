@@ -0,0 +1,151 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdPollSubscriptionState
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdPollSubscriptionState struct {
+	pollSubscriptionStateOptions *vclusterops.VPollSubscriptionStateOptions
+
+	CmdBase
+}
+
+func makeCmdPollSubscriptionState() *cobra.Command {
+	// CmdPollSubscriptionState
+	newCmd := &CmdPollSubscriptionState{}
+	opt := vclusterops.VPollSubscriptionStateOptionsFactory()
+	newCmd.pollSubscriptionStateOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		pollSubsStateSubCmd,
+		"Wait for the shard subscriptions of a subcluster to be active",
+		`This subcommand waits for all the shard subscriptions of the up nodes of a
+subcluster in an Eon Mode database to be ACTIVE, e.g., after add_node or
+rebalance_shards.
+
+The number of ACTIVE subscriptions is reported while the subcommand waits.
+When it stops, the number of ACTIVE subscriptions and the subscriptions that
+are not ACTIVE yet are printed in JSON. The subcommand fails if some
+subscriptions are not ACTIVE after the number of seconds given by the
+--timeout option.
+
+Examples:
+  # Wait for the subscriptions of a subcluster with config file
+  vcluster poll_subscription_state --subcluster sc1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Wait for up to 10 minutes with user input
+  vcluster poll_subscription_state --db-name test_db --subcluster sc1 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --timeout 600
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, eonModeFlag, passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require name of subcluster to poll
+	markFlagsRequired(cmd, []string{subclusterFlag})
+
+	// hide eon mode flag since we expect it to come from config file, not from user input
+	hideLocalFlags(cmd, []string{eonModeFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdPollSubscriptionState) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.pollSubscriptionStateOptions.SCName,
+		subclusterFlag,
+		"",
+		"Name of subcluster whose shard subscriptions are polled",
+	)
+	cmd.Flags().IntVar(
+		&c.pollSubscriptionStateOptions.TimeoutSeconds,
+		"timeout",
+		vclusterops.StartupPollingTimeout,
+		"The timeout in seconds to wait for the subscriptions to be active",
+	)
+}
+
+func (c *CmdPollSubscriptionState) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.pollSubscriptionStateOptions.DatabaseOptions)
+
+	// poll_subscription_state only works for an Eon db so we assume the user always runs this subcommand
+	// on an Eon db. When Eon mode cannot be found in config file, we set its value to true.
+	if !viper.IsSet(eonModeKey) {
+		c.pollSubscriptionStateOptions.IsEon = true
+	}
+	return c.validateParse(logger)
+}
+
+func (c *CmdPollSubscriptionState) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.pollSubscriptionStateOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.pollSubscriptionStateOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.pollSubscriptionStateOptions.DatabaseOptions)
+}
+
+func (c *CmdPollSubscriptionState) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.pollSubscriptionStateOptions
+
+	state, pollErr := vcc.VPollSubscriptionState(options)
+	// the state of the subscriptions is printed even on timeout
+	if state.TotalCount > 0 {
+		bytes, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	}
+	if pollErr != nil {
+		return pollErr
+	}
+
+	vcc.PrintInfo("All %d shard subscriptions of subcluster %s are active", state.TotalCount, options.SCName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdPollSubscriptionState
+func (c *CmdPollSubscriptionState) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.pollSubscriptionStateOptions.DatabaseOptions = *opt
+}
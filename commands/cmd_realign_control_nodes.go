@@ -0,0 +1,126 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdRealignControlNodes
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRealignControlNodes struct {
+	realignOptions *vclusterops.VRealignControlNodesOptions
+
+	CmdBase
+}
+
+func makeCmdRealignControlNodes() *cobra.Command {
+	// CmdRealignControlNodes
+	newCmd := &CmdRealignControlNodes{}
+	opt := vclusterops.VRealignControlNodesOptionsFactory()
+	newCmd.realignOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		realignControlSubCmd,
+		"Re-balance the control nodes of a large cluster",
+		`This subcommand distributes the control nodes of a running database across
+its nodes and fault groups, and reloads spread. Use it after nodes were added
+or removed, or to change the number of control nodes with --control-set-size.
+
+The nodes use their new control nodes after they are restarted.
+
+Examples:
+  # Re-balance the control nodes with config file
+  vcluster realign_control_nodes \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Use 5 control nodes with user input
+  vcluster realign_control_nodes --db-name test_db --hosts 10.20.30.40 \
+    --control-set-size 5
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRealignControlNodes) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&c.realignOptions.ControlSetSize,
+		"control-set-size",
+		util.DefaultControlSetSize,
+		"Number of control nodes of the database, -1 to keep the current number",
+	)
+}
+
+func (c *CmdRealignControlNodes) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.realignOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdRealignControlNodes) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.realignOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.realignOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.realignOptions.DatabaseOptions)
+}
+
+func (c *CmdRealignControlNodes) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.realignOptions
+
+	controlNodes, err := vcc.VRealignControlNodes(options)
+	if err != nil {
+		vcc.LogError(err, "fail to realign the control nodes", "dbName", options.DBName)
+		return err
+	}
+	for node, controlNode := range controlNodes {
+		vcc.LogInfo("control node assignment", "node", node, "controlNode", controlNode)
+	}
+	vcc.PrintInfo("Successfully realigned the control nodes of database %s", options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRealignControlNodes
+func (c *CmdRealignControlNodes) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.realignOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,123 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdRebalanceCluster
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRebalanceCluster struct {
+	CmdBase
+	rebalanceClusterOptions *vclusterops.VRebalanceClusterOptions
+}
+
+func makeCmdRebalanceCluster() *cobra.Command {
+	// CmdRebalanceCluster
+	newCmd := &CmdRebalanceCluster{}
+	opt := vclusterops.VRebalanceClusterOptionsFactory()
+	newCmd.rebalanceClusterOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		rebalanceClusterSubCmd,
+		"Rebalance the data of an Enterprise Mode database",
+		`This subcommand rebalances the data of an Enterprise Mode database across
+its nodes. Use it after adding or removing nodes.
+
+While the rebalance runs, its progress is printed at the interval given to the
+--progress-interval option. For Eon Mode databases, use rebalance_shards
+instead.
+
+Examples:
+  # Rebalance the cluster with config file
+  vcluster rebalance_cluster --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Rebalance the cluster with user input, printing the progress every 30 seconds
+  vcluster rebalance_cluster --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --progress-interval 30
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRebalanceCluster) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&c.rebalanceClusterOptions.ProgressIntervalSeconds,
+		"progress-interval",
+		vclusterops.PollingInterval,
+		"The interval in seconds between two prints of the rebalance progress",
+	)
+}
+
+func (c *CmdRebalanceCluster) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.rebalanceClusterOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdRebalanceCluster) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.rebalanceClusterOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.rebalanceClusterOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.rebalanceClusterOptions.DatabaseOptions)
+}
+
+func (c *CmdRebalanceCluster) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.rebalanceClusterOptions
+
+	err := vcc.VRebalanceCluster(options)
+	if err != nil {
+		vcc.LogError(err, "fail to rebalance the cluster", "DBName", options.DBName)
+		return err
+	}
+
+	vcc.PrintInfo("Successfully rebalanced database %s", options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRebalanceCluster
+func (c *CmdRebalanceCluster) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.rebalanceClusterOptions.DatabaseOptions = *opt
+}
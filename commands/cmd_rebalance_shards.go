@@ -0,0 +1,130 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdRebalanceShards
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRebalanceShards struct {
+	CmdBase
+	rebalanceShardsOptions *vclusterops.VRebalanceShardsOptions
+}
+
+func makeCmdRebalanceShards() *cobra.Command {
+	// CmdRebalanceShards
+	newCmd := &CmdRebalanceShards{}
+	opt := vclusterops.VRebalanceShardsOptionsFactory()
+	newCmd.rebalanceShardsOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		rebalanceShardsSubCmd,
+		"Rebalance the shards of an Eon Mode database",
+		`This subcommand rebalances the shards of a subcluster, or of every subcluster
+of the main cluster, in an Eon Mode database. It waits until all the shard
+subscriptions of the rebalanced subclusters are active.
+
+Use this subcommand after scaling a subcluster up or down to spread the shard
+subscriptions evenly across its nodes.
+
+You can rebalance a single subcluster with the --subcluster option. Sandboxed
+subclusters cannot be rebalanced.
+
+Examples:
+  # Rebalance the shards of all subclusters with config file
+  vcluster rebalance_shards --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Rebalance the shards of a subcluster with user input
+  vcluster rebalance_shards --db-name test_db --subcluster sc1 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRebalanceShards) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.rebalanceShardsOptions.SCName,
+		subclusterFlag,
+		"",
+		"The name of the subcluster to rebalance. If empty, all subclusters of the main cluster are rebalanced",
+	)
+}
+
+func (c *CmdRebalanceShards) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.rebalanceShardsOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdRebalanceShards) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.rebalanceShardsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.rebalanceShardsOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.rebalanceShardsOptions.DatabaseOptions)
+}
+
+func (c *CmdRebalanceShards) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.rebalanceShardsOptions
+
+	err := vcc.VRebalanceShards(options)
+	if err != nil {
+		vcc.LogError(err, "fail to rebalance shards", "DBName", options.DBName)
+		return err
+	}
+
+	if options.SCName == "" {
+		vcc.PrintInfo("Successfully rebalanced the shards of database %s", options.DBName)
+	} else {
+		vcc.PrintInfo("Successfully rebalanced the shards of subcluster %s", options.SCName)
+	}
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRebalanceShards
+func (c *CmdRebalanceShards) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.rebalanceShardsOptions.DatabaseOptions = *opt
+}
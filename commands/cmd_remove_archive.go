@@ -0,0 +1,128 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdRemoveArchive
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRemoveArchive struct {
+	CmdBase
+	removeArchiveOptions *vclusterops.VRemoveArchiveOptions
+}
+
+func makeCmdRemoveArchive() *cobra.Command {
+	// CmdRemoveArchive
+	newCmd := &CmdRemoveArchive{}
+	opt := vclusterops.VRemoveArchiveOptionsFactory()
+	newCmd.removeArchiveOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		removeArchiveSubCmd,
+		"Remove a restore point archive",
+		`This subcommand removes a restore point archive, with all its restore points,
+from an Eon Mode database.
+
+Examples:
+  # Remove an archive with config file
+  vcluster remove_archive --db-name test_db --archive-name db1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Remove an archive with user input
+  vcluster remove_archive --db-name test_db --archive-name db1 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the archive name
+	markFlagsRequired(cmd, []string{archiveNameFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRemoveArchive) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.removeArchiveOptions.ArchiveName,
+		archiveNameFlag,
+		"",
+		"The name of the archive",
+	)
+	cmd.Flags().StringVar(
+		&c.removeArchiveOptions.Namespace,
+		namespaceFlag,
+		"",
+		"The namespace of the archive, if not the default namespace",
+	)
+}
+
+func (c *CmdRemoveArchive) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.removeArchiveOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdRemoveArchive) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.removeArchiveOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.removeArchiveOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.removeArchiveOptions.DatabaseOptions)
+}
+
+func (c *CmdRemoveArchive) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.removeArchiveOptions
+
+	err := vcc.VRemoveArchive(options)
+	if err != nil {
+		vcc.LogError(err, "fail to remove the archive", "archive", options.ArchiveName)
+		return err
+	}
+
+	vcc.PrintInfo("Successfully removed archive %s from database %s", options.ArchiveName, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRemoveArchive
+func (c *CmdRemoveArchive) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.removeArchiveOptions.DatabaseOptions = *opt
+}
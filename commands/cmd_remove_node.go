@@ -51,6 +51,12 @@ remove as a comma-separated list.
 
 You cannot remove nodes from a sandboxed subcluster in an Eon Mode database.
 
+Use the --verify-rebalance option to check that the data of the nodes was
+moved to other nodes before they are dropped: in an Eon Mode database, the
+nodes must not hold any shard subscription; in an Enterprise Mode database,
+the data rebalance must be complete. The command fails before dropping any
+node otherwise.
+
 Examples:
   # Remove multiple nodes from the existing database with config file
   vcluster remove_node --db-name test_db \
@@ -87,6 +93,19 @@ func (c *CmdRemoveNode) setLocalFlags(cmd *cobra.Command) {
 		true,
 		"Whether to force clean-up of existing directories if they are not empty",
 	)
+	cmd.Flags().BoolVar(
+		&c.removeNodeOptions.VerifyRebalance,
+		"verify-rebalance",
+		false,
+		"Check that the data of the host(s) was rebalanced to other hosts before dropping them",
+	)
+	cmd.Flags().BoolVar(
+		&c.removeNodeOptions.CloseSessions,
+		"close-sessions",
+		false,
+		"Close the client sessions of the host(s) before they are removed, instead of only reporting them",
+	)
+	c.setConfirmationFlags(cmd)
 }
 
 func (c *CmdRemoveNode) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -138,6 +157,11 @@ func (c *CmdRemoveNode) Run(vcc vclusterops.ClusterCommands) error {
 
 	options := c.removeNodeOptions
 
+	err := c.confirmOperation("Remove host(s) %v from database %s?", options.HostsToRemove, options.DBName)
+	if err != nil {
+		return err
+	}
+
 	vdb, err := vcc.VRemoveNode(options)
 	if err != nil {
 		return err
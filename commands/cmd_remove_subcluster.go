@@ -88,6 +88,7 @@ func (c *CmdRemoveSubcluster) setLocalFlags(cmd *cobra.Command) {
 		true,
 		"Whether force delete directories if they are not empty",
 	)
+	c.setConfirmationFlags(cmd)
 }
 
 func (c *CmdRemoveSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -128,6 +129,11 @@ func (c *CmdRemoveSubcluster) Run(vcc vclusterops.ClusterCommands) error {
 
 	options := c.removeScOptions
 
+	err := c.confirmOperation("Remove subcluster %s from database %s?", options.SCName, options.DBName)
+	if err != nil {
+		return err
+	}
+
 	vdb, err := vcc.VRemoveSubcluster(options)
 	if err != nil {
 		return err
@@ -0,0 +1,159 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdReplaceNode
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdReplaceNode struct {
+	replaceNodeOptions *vclusterops.VReplaceNodeOptions
+
+	CmdBase
+}
+
+func makeCmdReplaceNode() *cobra.Command {
+	// CmdReplaceNode
+	newCmd := &CmdReplaceNode{}
+	opt := vclusterops.VReplaceNodeOptionsFactory()
+	newCmd.replaceNodeOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		replaceNodeSubCmd,
+		"Replace a host of an existing database by a new host",
+		`This subcommand replaces a node, usually on a failed host, by a new node on
+a new host in the same subcluster.
+
+The new host is added first. In an Eon Mode database, vcluster waits for its
+shard subscriptions to be active. Then the node to replace is removed. The
+database never has fewer nodes than before, so its quorum is not at risk.
+
+If the new host cannot be added, it is cleaned up and the node to replace is
+kept.
+
+In an Enterprise Mode database, all nodes, including the one to replace, must
+be up.
+
+Examples:
+  # Replace a host with config file
+  vcluster replace_node --db-name test_db \
+    --host-to-replace 10.20.30.42 --new-host 10.20.30.43 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Replace a host with user input
+  vcluster replace_node --db-name test_db \
+    --host-to-replace 10.20.30.42 --new-host 10.20.30.43 \
+    --hosts 10.20.30.40 --data-path /data
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, catalogPathFlag, dataPathFlag, depotPathFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the host to replace and the new host
+	markFlagsRequired(cmd, []string{"host-to-replace", "new-host"})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdReplaceNode) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.replaceNodeOptions.HostToReplace,
+		"host-to-replace",
+		"",
+		"The host of the node to replace",
+	)
+	cmd.Flags().StringVar(
+		&c.replaceNodeOptions.NewHost,
+		"new-host",
+		"",
+		"The host that replaces it, in the same subcluster",
+	)
+	cmd.Flags().StringVar(
+		&c.replaceNodeOptions.DepotSize,
+		"depot-size",
+		"",
+		util.GetEonFlagMsg("Size of depot of the new node"),
+	)
+	cmd.Flags().BoolVar(
+		&c.replaceNodeOptions.ForceDelete,
+		"force-delete",
+		true,
+		"Whether to force clean-up of the directories of the replaced node if they are not empty",
+	)
+}
+
+func (c *CmdReplaceNode) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.replaceNodeOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdReplaceNode) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.replaceNodeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.replaceNodeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.replaceNodeOptions.DatabaseOptions)
+}
+
+func (c *CmdReplaceNode) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.replaceNodeOptions
+
+	vdb, err := vcc.VReplaceNode(options)
+	if err != nil {
+		return err
+	}
+
+	// write db info to vcluster config file
+	err = writeConfig(&vdb)
+	if err != nil {
+		vcc.PrintWarning("fail to write config file, details: %s", err)
+	}
+	vcc.PrintInfo("Successfully replaced host %s by host %s in database %s",
+		options.HostToReplace, options.NewHost, options.DBName)
+
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdReplaceNode
+func (c *CmdReplaceNode) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.replaceNodeOptions.DatabaseOptions = *opt
+}
@@ -57,6 +57,12 @@ to restart multiple nodes without a config file. If the IP_TO_RESTART value
 does not match the information stored in the catalog for NODE_NAME, Vertica
 updates the catalog with the IP_TO_RESTART value and restarts the node.
 
+Nodes that came back with a new IP address are re-IP'ed and restarted in the
+same call this way, and the config file is updated with their new addresses.
+
+Use the --follow-logs option to print the new lines of vertica.log of the
+restarting nodes while waiting for them to come up.
+
 Examples:
   # Restart a single node in the database with config file
   vcluster restart_node --db-name test_db \
@@ -107,6 +113,12 @@ func (c *CmdRestartNodes) setLocalFlags(cmd *cobra.Command) {
 		util.DefaultTimeoutSeconds,
 		"The timeout (in seconds) to wait for polling node state operation",
 	)
+	cmd.Flags().BoolVar(
+		&c.restartNodesOptions.FollowLogs,
+		followLogsFlag,
+		false,
+		"Print the new lines of vertica.log of the restarting nodes while waiting for them to come up",
+	)
 
 	// VER-90436: restart -> start
 	// users only input --restart or --start-hosts
@@ -172,9 +184,36 @@ func (c *CmdRestartNodes) Run(vcc vclusterops.ClusterCommands) error {
 	}
 	vcc.PrintInfo("Successfully restart hosts %s of the database %s", hostToRestart, options.DBName)
 
+	// nodes may have been re-ip'ed, update their addresses in the config file
+	dbConfig, err := readConfig()
+	if err != nil {
+		vcc.LogInfo("fail to read config file, skipping the update of node addresses", "error", err)
+		return nil
+	}
+	if c.updateConfigAddresses(dbConfig) {
+		err = dbConfig.write(options.ConfigPath)
+		if err != nil {
+			vcc.PrintWarning("fail to update config file, details: %s", err)
+		}
+	}
+
 	return nil
 }
 
+// updateConfigAddresses sets the addresses the nodes were restarted with in
+// the config object. It returns true if any address has changed.
+func (c *CmdRestartNodes) updateConfigAddresses(dbConfig *DatabaseConfig) bool {
+	updated := false
+	for _, n := range dbConfig.Nodes {
+		newAddress, ok := c.restartNodesOptions.Nodes[n.Name]
+		if ok && n.Address != newAddress {
+			n.Address = newAddress
+			updated = true
+		}
+	}
+	return updated
+}
+
 // SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRestartNodes
 func (c *CmdRestartNodes) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
 	c.restartNodesOptions.DatabaseOptions = *opt
@@ -0,0 +1,50 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestRestartNodeNewAddresses(t *testing.T) {
+	opt := vclusterops.VStartNodesOptionsFactory()
+	c := CmdRestartNodes{restartNodesOptions: &opt}
+	c.SetParser(pflag.NewFlagSet(restartNodeSubCmd, pflag.ContinueOnError))
+
+	// a node that came back with a new address is given with --restart
+	c.vnodeHostMap = map[string]string{"v_test_db_node0001": "192.0.2.1", "v_test_db_node0002": "192.0.2.12"}
+	err := c.validateParse(vlog.Printer{})
+	assert.NoError(t, err)
+	assert.Equal(t, c.vnodeHostMap, opt.Nodes)
+
+	// the config file is updated with the new addresses
+	dbConfig := MakeDatabaseConfig()
+	dbConfig.Nodes = []*NodeConfig{
+		{Name: "v_test_db_node0001", Address: "192.0.2.1"},
+		{Name: "v_test_db_node0002", Address: "192.0.2.2"},
+		{Name: "v_test_db_node0003", Address: "192.0.2.3"},
+	}
+	assert.True(t, c.updateConfigAddresses(&dbConfig))
+	assert.Equal(t, "192.0.2.1", dbConfig.Nodes[0].Address)
+	assert.Equal(t, "192.0.2.12", dbConfig.Nodes[1].Address)
+	assert.Equal(t, "192.0.2.3", dbConfig.Nodes[2].Address)
+	assert.False(t, c.updateConfigAddresses(&dbConfig))
+}
@@ -53,12 +53,21 @@ The number of hosts that you provide to the --hosts option must match the
 number of hosts in the existing database. You can omit the hosts only if
 --display-only is specified.
 
+Use the --allow-node-count-change option to revive the database on fewer hosts
+than it has nodes. The primary nodes are revived first, and enough of them must
+be revived to hold quorum. The nodes that are not revived are dropped from the
+catalog, and their shards are remapped onto the revived nodes.
+
 The name of the database must be provided.
 
 To restore a database to a restore point, you must provide the
 --restore-point-archive option, and specify the restore point with either the
 --restore-point-index or --restore-point-id option.
 
+Use the --journal option to record the progress of the command in a file. If
+the command is interrupted, run it again with the same options and --resume
+to skip the steps that completed.
+
 Examples:
   # Revive a database with user input and save the generated config file
   # under the given directory
@@ -79,7 +88,8 @@ Examples:
     --ignore-cluster-lease --restore-point-archive db --restore-point-index 1
 
 `,
-		[]string{dbNameFlag, hostsFlag, ipv6Flag, communalStorageLocationFlag, configFlag, outputFileFlag, configParamFlag},
+		[]string{dbNameFlag, hostsFlag, ipv6Flag, communalStorageLocationFlag, configFlag, outputFileFlag, configParamFlag,
+			useInstanceProfileFlag},
 	)
 
 	// local flags
@@ -138,8 +148,21 @@ func (c *CmdReviveDB) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"The identifier of the restore point in the restore archive to restore from",
 	)
+	cmd.Flags().StringVar(
+		&c.reviveDBOptions.RestorePoint.Namespace,
+		"restore-point-namespace",
+		"",
+		"The namespace of the restore archive, if not the default namespace",
+	)
+	cmd.Flags().BoolVar(
+		&c.reviveDBOptions.AllowNodeCountChange,
+		"allow-node-count-change",
+		false,
+		"Revive the database on fewer hosts than it has nodes, reviving the primary nodes first",
+	)
 	// only one of restore-point-index or restore-point-id" will be required
 	cmd.MarkFlagsMutuallyExclusive("restore-point-index", "restore-point-id")
+	c.setResumeFlags(cmd, &c.reviveDBOptions.ResumeOptions)
 }
 
 func (c *CmdReviveDB) Parse(inputArgv []string, logger vlog.Printer) error {
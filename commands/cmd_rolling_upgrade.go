@@ -0,0 +1,198 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdRollingUpgrade
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRollingUpgrade struct {
+	rollingUpgradeOptions *vclusterops.VRollingUpgradeOptions
+	// local executable that installs the new binary on the hosts of a subcluster
+	upgradeCommand string
+
+	CmdBase
+}
+
+func makeCmdRollingUpgrade() *cobra.Command {
+	// CmdRollingUpgrade
+	newCmd := &CmdRollingUpgrade{}
+	opt := vclusterops.VRollingUpgradeOptionsFactory()
+	newCmd.rollingUpgradeOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		rollingUpgradeSubCmd,
+		"Upgrade the Vertica binary of a database one subcluster at a time",
+		`This subcommand upgrades the Vertica binary of an Eon Mode database one
+subcluster at a time, so that the other subclusters keep serving clients.
+
+For each subcluster, the subcommand pauses its new client connections, stops
+it once its users are disconnected, runs the executable given by the
+--upgrade-command option, starts the subcluster, checks the version of its
+nodes, and resumes its client connections.
+
+The executable installs the new binary on the hosts of the subcluster. It is
+run on the local host with the name of the subcluster and the comma-separated
+list of its hosts as arguments, and must exit with 0 once the hosts are ready
+to be started.
+
+By default, all the subclusters are upgraded, the secondary subclusters
+first. Use the --subclusters option to choose the subclusters and their
+order. Use the --target-version option to check that the nodes run the
+expected version once upgraded.
+
+Use the --journal option to record the progress of the command in a file. If
+the command is interrupted, run it again with the same options and --resume
+to skip the subclusters that were upgraded.
+
+Examples:
+  # Upgrade all the subclusters with config file
+  vcluster rolling_upgrade --upgrade-command /opt/scripts/install_rpm.sh \
+    --target-version v24.3.0 --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Upgrade two subclusters in order with user input, recording the progress
+  vcluster rolling_upgrade --db-name test_db --hosts 10.20.30.40 \
+    --subclusters sc1,default_subcluster \
+    --upgrade-command /opt/scripts/install_rpm.sh \
+    --journal /tmp/rolling_upgrade_test_db.json
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, eonModeFlag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the executable that installs the new binary
+	markFlagsRequired(cmd, []string{"upgrade-command"})
+
+	// hide eon mode flag since we expect it to come from config file, not from user input
+	hideLocalFlags(cmd, []string{eonModeFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRollingUpgrade) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.upgradeCommand,
+		"upgrade-command",
+		"",
+		"Path of the local executable that installs the new binary on the hosts of a subcluster",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.rollingUpgradeOptions.SCNames,
+		"subclusters",
+		[]string{},
+		"Comma-separated list of the subclusters to upgrade, in order. If empty, all the subclusters are upgraded",
+	)
+	cmd.Flags().IntVar(
+		&c.rollingUpgradeOptions.DrainSeconds,
+		"drain-seconds",
+		util.DefaultDrainSeconds,
+		"Seconds to wait for user connections to close before a subcluster is stopped",
+	)
+	cmd.Flags().IntVar(
+		&c.rollingUpgradeOptions.StatePollingTimeout,
+		"timeout",
+		util.DefaultStatePollingTimeout,
+		"The timeout (in seconds) to wait for the nodes of a subcluster to come up",
+	)
+	cmd.Flags().StringVar(
+		&c.rollingUpgradeOptions.TargetVersion,
+		"target-version",
+		"",
+		"The version that the nodes must run once upgraded, e.g., v24.3.0",
+	)
+	c.setResumeFlags(cmd, &c.rollingUpgradeOptions.ResumeOptions)
+}
+
+func (c *CmdRollingUpgrade) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.rollingUpgradeOptions.DatabaseOptions)
+
+	// rolling_upgrade only works for an Eon db so we assume the user always runs this subcommand
+	// on an Eon db. When Eon mode cannot be found in config file, we set its value to true.
+	if !viper.IsSet(eonModeKey) {
+		c.rollingUpgradeOptions.IsEon = true
+	}
+	return c.validateParse(logger)
+}
+
+func (c *CmdRollingUpgrade) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.rollingUpgradeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.rollingUpgradeOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	c.rollingUpgradeOptions.UpgradeHook = c.runUpgradeCommand
+	return c.setDBPassword(&c.rollingUpgradeOptions.DatabaseOptions)
+}
+
+// runUpgradeCommand runs the upgrade command for the hosts of a subcluster,
+// with its output printed to the console
+func (c *CmdRollingUpgrade) runUpgradeCommand(scName string, hosts []string) error {
+	//nolint:gosec // the upgrade command is given by the user who runs vcluster
+	cmd := exec.Command(c.upgradeCommand, scName, strings.Join(hosts, ","))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("upgrade command %s failed for subcluster %s: %w", c.upgradeCommand, scName, err)
+	}
+	return nil
+}
+
+func (c *CmdRollingUpgrade) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.rollingUpgradeOptions
+
+	err := vcc.VRollingUpgrade(options)
+	if err != nil {
+		vcc.LogError(err, "fail to upgrade database", "DBName", options.DBName)
+		return err
+	}
+
+	vcc.PrintInfo("Successfully upgraded database %s", options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRollingUpgrade
+func (c *CmdRollingUpgrade) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.rollingUpgradeOptions.DatabaseOptions = *opt
+}
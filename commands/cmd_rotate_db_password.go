@@ -0,0 +1,203 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	newPasswordFileFlag    = "new-password-file"
+	updatePasswordFileFlag = "update-password-file"
+)
+
+/* CmdRotateDBPassword
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRotateDBPassword struct {
+	rotateDBPasswordOptions *vclusterops.VRotateDBPasswordOptions
+	newPasswordFile         string
+	updatePasswordFile      bool
+
+	CmdBase
+}
+
+func makeCmdRotateDBPassword() *cobra.Command {
+	// CmdRotateDBPassword
+	newCmd := &CmdRotateDBPassword{}
+	opt := vclusterops.VRotateDBPasswordOptionsFactory()
+	newCmd.rotateDBPasswordOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		rotateDBPasswordSubCmd,
+		"Rotate the database password",
+		`This subcommand changes the database password of the database superuser,
+or of the user given by --db-user, on a running database.
+
+The new password is read from the file given by --new-password-file. If - is
+passed, the new password is read from stdin.
+
+Use the --update-password-file option to write the new password to the file
+given by --password-file, so that the next commands keep working with the
+same options.
+
+Examples:
+  # Rotate the password and update the password file with config file
+  vcluster rotate_db_password --password-file /opt/vertica/secrets/password \
+    --new-password-file /tmp/new_password --update-password-file \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Rotate the password with user input
+  vcluster rotate_db_password --db-name test_db --hosts 10.20.30.40 \
+    --password-file /opt/vertica/secrets/password --new-password-file -
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, dbUserFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the new password
+	markFlagsRequired(cmd, []string{newPasswordFileFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRotateDBPassword) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.newPasswordFile,
+		newPasswordFileFlag,
+		"",
+		"Path to the file to read the new password from. "+
+			"If - is passed, the new password is read from stdin",
+	)
+	cmd.Flags().BoolVar(
+		&c.updatePasswordFile,
+		updatePasswordFileFlag,
+		false,
+		"Write the new password to the file given by --"+passwordFileFlag,
+	)
+}
+
+func (c *CmdRotateDBPassword) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.rotateDBPasswordOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdRotateDBPassword) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	if c.updatePasswordFile && (c.passwordFile == "" || c.passwordFile == "-") {
+		return fmt.Errorf("--%s requires --%s to be the path to a file", updatePasswordFileFlag, passwordFileFlag)
+	}
+	if c.newPasswordFile == "-" && c.passwordFile == "-" {
+		return fmt.Errorf("the current and the new passwords cannot both be read from stdin")
+	}
+
+	err := c.getCertFilesFromCertPaths(&c.rotateDBPasswordOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.rotateDBPasswordOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	err = c.setNewPassword()
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.rotateDBPasswordOptions.DatabaseOptions)
+}
+
+// setNewPassword reads the new password from --new-password-file
+func (c *CmdRotateDBPassword) setNewPassword() error {
+	var newPassword string
+	if c.newPasswordFile == "-" {
+		password, err := readFromStdin()
+		if err != nil {
+			return err
+		}
+		newPassword = strings.TrimSuffix(password, "\n")
+	} else {
+		password, err := c.passwordFileHelper(c.newPasswordFile)
+		if err != nil {
+			return err
+		}
+		newPassword = password
+	}
+	c.rotateDBPasswordOptions.NewPassword = &newPassword
+	return nil
+}
+
+func (c *CmdRotateDBPassword) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.rotateDBPasswordOptions
+
+	err := vcc.VRotateDBPassword(options)
+	if err != nil {
+		vcc.LogError(err, "fail to rotate the database password", "user", options.UserName)
+		return err
+	}
+	vcc.PrintInfo("Successfully rotated the password of user %s", options.UserName)
+
+	if c.updatePasswordFile {
+		err = writePasswordFile(c.passwordFile, *options.NewPassword)
+		if err != nil {
+			vcc.PrintWarning("The password was rotated but the password file could not be updated, details: %s", err)
+			return err
+		}
+		vcc.PrintInfo("Updated password file %s", c.passwordFile)
+	}
+	return nil
+}
+
+// writePasswordFile replaces the content of the password file with the
+// password, keeping the permissions of the file
+func writePasswordFile(passwordFile, password string) error {
+	const passwordFilePerm = 0600
+	perm := os.FileMode(passwordFilePerm)
+	if info, err := os.Stat(passwordFile); err == nil {
+		perm = info.Mode().Perm()
+	}
+	err := os.WriteFile(passwordFile, []byte(password+"\n"), perm)
+	if err != nil {
+		return fmt.Errorf("fail to write password file %q: %w", passwordFile, err)
+	}
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRotateDBPassword
+func (c *CmdRotateDBPassword) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.rotateDBPasswordOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,320 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	groupNameFlag = "group-name"
+	ruleNameFlag  = "rule-name"
+)
+
+func makeCmdRouting() *cobra.Command {
+	cmd := makeSimpleCobraCmd(
+		routingSubCmd,
+		"Manage connection load balance groups and routing rules",
+		`This subcommand creates, alters, or drops the connection load balance
+groups and the routing rules of a database.
+
+A load balance group is a set of subclusters whose nodes the client
+connections are balanced across. A routing rule sends the clients connecting
+from some addresses to a load balance group. Together, they isolate the
+workloads of the clients on different subclusters of an Eon Mode database.`)
+
+	cmd.AddCommand(makeCmdLoadBalanceGroupAction(vclusterops.RoutingCreate, createGroupSubCmd,
+		"Create a load balance group",
+		`This subcommand creates a load balance group made of one or more subclusters.
+
+Examples:
+  # Create a load balance group with config file
+  vcluster routing create_group --group-name analytics \
+    --subclusters sc_analytics1,sc_analytics2 --policy ROUNDROBIN \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdLoadBalanceGroupAction(vclusterops.RoutingAlter, alterGroupSubCmd,
+		"Alter a load balance group",
+		`This subcommand changes the subclusters, the filter, or the policy of a load
+balance group.
+
+Examples:
+  # Change the policy of a load balance group with config file
+  vcluster routing alter_group --group-name analytics --policy RANDOM \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdLoadBalanceGroupAction(vclusterops.RoutingDrop, dropGroupSubCmd,
+		"Drop a load balance group",
+		`This subcommand drops a load balance group.
+
+Examples:
+  # Drop a load balance group with config file
+  vcluster routing drop_group --group-name analytics \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdRoutingRuleAction(vclusterops.RoutingCreate, createRuleSubCmd,
+		"Create a routing rule",
+		`This subcommand creates a routing rule that sends the clients connecting
+from the given addresses to a load balance group.
+
+Examples:
+  # Route the clients of a network to a load balance group with config file
+  vcluster routing create_rule --rule-name analytics_clients \
+    --source-address 10.20.0.0/16 --group-name analytics \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdRoutingRuleAction(vclusterops.RoutingAlter, alterRuleSubCmd,
+		"Alter a routing rule",
+		`This subcommand changes the source addresses or the load balance group of a
+routing rule.
+
+Examples:
+  # Route the clients of a rule to another load balance group with config file
+  vcluster routing alter_rule --rule-name analytics_clients --group-name etl \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdRoutingRuleAction(vclusterops.RoutingDrop, dropRuleSubCmd,
+		"Drop a routing rule",
+		`This subcommand drops a routing rule.
+
+Examples:
+  # Drop a routing rule with config file
+  vcluster routing drop_rule --rule-name analytics_clients \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+
+	return cmd
+}
+
+/* CmdLoadBalanceGroup
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdLoadBalanceGroup struct {
+	loadBalanceGroupOptions *vclusterops.VLoadBalanceGroupOptions
+
+	CmdBase
+}
+
+func makeCmdLoadBalanceGroupAction(action vclusterops.RoutingAction,
+	subCmd, short, long string) *cobra.Command {
+	newCmd := &CmdLoadBalanceGroup{}
+	opt := vclusterops.VLoadBalanceGroupOptionsFactory()
+	opt.Action = action
+	newCmd.loadBalanceGroupOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		subCmd,
+		short,
+		long,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd, action)
+
+	requiredFlags := []string{groupNameFlag}
+	if action == vclusterops.RoutingCreate {
+		requiredFlags = append(requiredFlags, "subclusters")
+	}
+	markFlagsRequired(cmd, requiredFlags)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdLoadBalanceGroup) setLocalFlags(cmd *cobra.Command, action vclusterops.RoutingAction) {
+	cmd.Flags().StringVar(
+		&c.loadBalanceGroupOptions.GroupName,
+		groupNameFlag,
+		"",
+		"The name of the load balance group",
+	)
+	if action == vclusterops.RoutingDrop {
+		return
+	}
+	cmd.Flags().StringSliceVar(
+		&c.loadBalanceGroupOptions.Subclusters,
+		"subclusters",
+		[]string{},
+		"Comma-separated list of subclusters whose nodes the connections are balanced across",
+	)
+	cmd.Flags().StringVar(
+		&c.loadBalanceGroupOptions.Filter,
+		"filter",
+		"",
+		"CIDR of the node addresses the connections can be redirected to, e.g. 192.168.1.0/24",
+	)
+	cmd.Flags().StringVar(
+		&c.loadBalanceGroupOptions.Policy,
+		"policy",
+		"",
+		"The policy to pick a node, one of ROUNDROBIN, RANDOM or NONE",
+	)
+}
+
+func (c *CmdLoadBalanceGroup) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.loadBalanceGroupOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdLoadBalanceGroup) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.loadBalanceGroupOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.loadBalanceGroupOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.loadBalanceGroupOptions.DatabaseOptions)
+}
+
+func (c *CmdLoadBalanceGroup) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.loadBalanceGroupOptions
+
+	err := vcc.VAlterLoadBalanceGroup(options)
+	if err != nil {
+		vcc.LogError(err, "fail to change load balance group", "action", options.Action, "group", options.GroupName)
+		return err
+	}
+	vcc.PrintInfo("Successfully completed %s of load balance group %s in database %s",
+		options.Action, options.GroupName, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdLoadBalanceGroup
+func (c *CmdLoadBalanceGroup) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.loadBalanceGroupOptions.DatabaseOptions = *opt
+}
+
+/* CmdRoutingRule
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdRoutingRule struct {
+	routingRuleOptions *vclusterops.VRoutingRuleOptions
+
+	CmdBase
+}
+
+func makeCmdRoutingRuleAction(action vclusterops.RoutingAction,
+	subCmd, short, long string) *cobra.Command {
+	newCmd := &CmdRoutingRule{}
+	opt := vclusterops.VRoutingRuleOptionsFactory()
+	opt.Action = action
+	newCmd.routingRuleOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		subCmd,
+		short,
+		long,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd, action)
+
+	requiredFlags := []string{ruleNameFlag}
+	if action == vclusterops.RoutingCreate {
+		requiredFlags = append(requiredFlags, "source-address", groupNameFlag)
+	}
+	markFlagsRequired(cmd, requiredFlags)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdRoutingRule) setLocalFlags(cmd *cobra.Command, action vclusterops.RoutingAction) {
+	cmd.Flags().StringVar(
+		&c.routingRuleOptions.RuleName,
+		ruleNameFlag,
+		"",
+		"The name of the routing rule",
+	)
+	if action == vclusterops.RoutingDrop {
+		return
+	}
+	cmd.Flags().StringVar(
+		&c.routingRuleOptions.SourceAddress,
+		"source-address",
+		"",
+		"CIDR of the client addresses the rule applies to, e.g. 10.20.0.0/16",
+	)
+	cmd.Flags().StringVar(
+		&c.routingRuleOptions.GroupName,
+		groupNameFlag,
+		"",
+		"The name of the load balance group the clients are routed to",
+	)
+}
+
+func (c *CmdRoutingRule) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.routingRuleOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdRoutingRule) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.routingRuleOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.routingRuleOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.routingRuleOptions.DatabaseOptions)
+}
+
+func (c *CmdRoutingRule) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.routingRuleOptions
+
+	err := vcc.VAlterRoutingRule(options)
+	if err != nil {
+		vcc.LogError(err, "fail to change routing rule", "action", options.Action, "rule", options.RuleName)
+		return err
+	}
+	vcc.PrintInfo("Successfully completed %s of routing rule %s in database %s",
+		options.Action, options.RuleName, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdRoutingRule
+func (c *CmdRoutingRule) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.routingRuleOptions.DatabaseOptions = *opt
+}
@@ -59,6 +59,11 @@ sandbox. A sandbox can contain multiple subclusters.
 
 You must provide the subcluster name with the --subcluster option and the
 sandbox name with the --sandbox option.
+
+To test a change, such as a version upgrade, in the sandbox only, set
+configuration parameters in the sandbox with the --config-param-override
+option, and give the sandbox its own depot with the --create-separate-depot
+and --sandbox-depot-path options.
 		
 Examples:
   # Sandbox a subcluster with config file
@@ -68,6 +73,12 @@ Examples:
   # Sandbox a subcluster with user input
   vcluster sandbox_subcluster --subcluster sc1 --sandbox sand \
     --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --db-name test_db
+
+  # Sandbox a subcluster with its own depot and configuration parameters
+  vcluster sandbox_subcluster --subcluster sc1 --sandbox sand \
+    --config /opt/vertica/config/vertica_cluster.yaml \
+    --config-param-override MaxClientSessions=100 \
+    --create-separate-depot --sandbox-depot-path /sandbox/depot
 `,
 		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
 	)
@@ -95,6 +106,26 @@ func (c *CmdSandboxSubcluster) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"The name of the sandbox",
 	)
+	cmd.Flags().StringToStringVar(
+		&c.sbOptions.ConfigParamOverrides,
+		"config-param-override",
+		map[string]string{},
+		"Comma-separated list of NAME=VALUE pairs of configuration parameters to set in the sandbox only",
+	)
+	cmd.Flags().BoolVar(
+		&c.sbOptions.CreateSeparateDepot,
+		"create-separate-depot",
+		false,
+		"Create a separate depot for the sandbox at --sandbox-depot-path, instead of re-using the depot of the subcluster",
+	)
+	cmd.Flags().StringVar(
+		&c.sbOptions.SandboxDepotPath,
+		"sandbox-depot-path",
+		"",
+		"Path of the separate depot of the sandbox, used with --create-separate-depot",
+	)
+	markFlagsDirName(cmd, []string{"sandbox-depot-path"})
+	cmd.MarkFlagsRequiredTogether("create-separate-depot", "sandbox-depot-path")
 }
 
 func (c *CmdSandboxSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
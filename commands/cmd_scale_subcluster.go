@@ -0,0 +1,200 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdScaleSubcluster
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdScaleSubcluster struct {
+	scaleScOptions *vclusterops.VScaleSubclusterOptions
+
+	CmdBase
+}
+
+func makeCmdScaleSubcluster() *cobra.Command {
+	// CmdScaleSubcluster
+	newCmd := &CmdScaleSubcluster{}
+	opt := vclusterops.VScaleSubclusterOptionsFactory()
+	newCmd.scaleScOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		scaleSCSubCmd,
+		"Scale a subcluster to a number of nodes",
+		`This subcommand adds or removes hosts so that a subcluster of an Eon Mode
+database has the number of nodes given by the --target-node-count option.
+
+The hosts to add are taken from the --host-pool option, in order, skipping the
+hosts that are already in the database. When the subcluster is scaled down,
+its down nodes are removed first, then its nodes that are not in the host pool,
+then its most recent nodes. Running the subcommand again once the subcluster
+has the target number of nodes does nothing.
+
+The plan and the result of each of its steps are printed in JSON. Use the
+--dry-run option to print the plan without executing it.
+
+You cannot scale a sandboxed subcluster, or scale a primary subcluster to 0.
+
+Examples:
+  # Scale a subcluster to 3 nodes with config file
+  vcluster scale_subcluster --subcluster sc1 --target-node-count 3 \
+    --host-pool 10.20.30.43,10.20.30.44,10.20.30.45 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Show which hosts would be removed to scale a subcluster to 1 node
+  vcluster scale_subcluster --subcluster sc1 --target-node-count 1 --dry-run \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, eonModeFlag, dataPathFlag, depotPathFlag,
+			passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require name of subcluster to scale and its target size
+	markFlagsRequired(cmd, []string{subclusterFlag, "target-node-count"})
+
+	// hide eon mode flag since we expect it to come from config file, not from user input
+	hideLocalFlags(cmd, []string{eonModeFlag})
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdScaleSubcluster) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.scaleScOptions.SCName,
+		subclusterFlag,
+		"",
+		"Name of subcluster to be scaled",
+	)
+	cmd.Flags().IntVar(
+		&c.scaleScOptions.TargetNodeCount,
+		"target-node-count",
+		0,
+		"Number of nodes the subcluster must have",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.scaleScOptions.HostPool,
+		"host-pool",
+		[]string{},
+		"Comma-separated list of hosts that can be added to the subcluster, in order of preference",
+	)
+	cmd.Flags().StringVar(
+		&c.scaleScOptions.DepotSize,
+		"depot-size",
+		"",
+		util.GetEonFlagMsg("Size of depot of the new nodes"),
+	)
+	cmd.Flags().BoolVar(
+		&c.scaleScOptions.ForceDelete,
+		"force-delete",
+		true,
+		"Whether force delete directories of the removed nodes if they are not empty",
+	)
+	cmd.Flags().BoolVar(
+		&c.scaleScOptions.DryRun,
+		"dry-run",
+		false,
+		"Print the plan without executing it",
+	)
+}
+
+func (c *CmdScaleSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.scaleScOptions.DatabaseOptions)
+
+	// scale_subcluster only works for an Eon db so we assume the user always runs this subcommand
+	// on an Eon db. When Eon mode cannot be found in config file, we set its value to true.
+	if !viper.IsSet(eonModeKey) {
+		c.scaleScOptions.IsEon = true
+	}
+	return c.validateParse(logger)
+}
+
+func (c *CmdScaleSubcluster) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+	err := c.getCertFilesFromCertPaths(&c.scaleScOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	if len(c.scaleScOptions.HostPool) > 0 {
+		err = util.ParseHostList(&c.scaleScOptions.HostPool)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.ValidateParseBaseOptions(&c.scaleScOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.scaleScOptions.DatabaseOptions)
+}
+
+func (c *CmdScaleSubcluster) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.scaleScOptions
+
+	result, scaleErr := vcc.VScaleSubcluster(options)
+	// the plan and the steps that ran are printed even if a step failed
+	if result.Plan.SCName != "" {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	}
+
+	if result.Database != nil {
+		// write db info to vcluster config file
+		err := writeConfig(result.Database)
+		if err != nil {
+			vcc.PrintWarning("fail to write config file, details: %s", err)
+		}
+	}
+	if scaleErr != nil {
+		return scaleErr
+	}
+
+	if !options.DryRun {
+		vcc.PrintInfo("Subcluster %s in database %s has %d nodes",
+			options.SCName, options.DBName, options.TargetNodeCount)
+	}
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdScaleSubcluster
+func (c *CmdScaleSubcluster) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.scaleScOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,188 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	configParameterFlag = "parameter"
+	configLevelFlag     = "level"
+	configLevelNameFlag = "level-name"
+)
+
+/* CmdSetConfig
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdSetConfig struct {
+	setConfigOptions *vclusterops.VSetConfigurationParameterOptions
+	level            string
+
+	CmdBase
+}
+
+func makeCmdSetConfig() *cobra.Command {
+	// CmdSetConfig
+	newCmd := &CmdSetConfig{}
+	opt := vclusterops.VSetConfigurationParameterOptionsFactory()
+	newCmd.setConfigOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		setConfigSubCmd,
+		"Set or clear a configuration parameter",
+		`This subcommand sets the value of a configuration parameter of a database,
+or clears it so that the value of the higher level, or the default value,
+applies again.
+
+A configuration parameter can be set for the whole database, or, with the
+--level and --level-name options, for a node or for a subcluster.
+
+Use the --dry-run option to see the current value and the requested one
+without changing anything. The current and requested values are printed in
+JSON format.
+
+Examples:
+  # Set a configuration parameter for the database with config file
+  vcluster set_config --parameter MaxClientSessions --value 100 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # See what setting a configuration parameter for a subcluster would change
+  vcluster set_config --parameter MaxClientSessions --value 100 \
+    --level subcluster --level-name sc1 --dry-run \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Clear a configuration parameter set for a node with user input
+  vcluster set_config --db-name test_db --hosts 10.20.30.40 \
+    --parameter MaxClientSessions --clear \
+    --level node --level-name v_test_db_node0001
+`,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require the parameter name and one of its value or --clear
+	markFlagsRequired(cmd, []string{configParameterFlag})
+	cmd.MarkFlagsOneRequired("value", "clear")
+	cmd.MarkFlagsMutuallyExclusive("value", "clear")
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdSetConfig) setLocalFlags(cmd *cobra.Command) {
+	setConfigParameterFlags(cmd, &c.setConfigOptions.VGetConfigurationParameterOptions, &c.level)
+	cmd.Flags().StringVar(
+		&c.setConfigOptions.Value,
+		"value",
+		"",
+		"The value to set the configuration parameter to",
+	)
+	cmd.Flags().BoolVar(
+		&c.setConfigOptions.Clear,
+		"clear",
+		false,
+		"Clear the value set at the level instead of setting one",
+	)
+	cmd.Flags().BoolVar(
+		&c.setConfigOptions.DryRun,
+		"dry-run",
+		false,
+		"Only show the current value and the requested one, without changing anything",
+	)
+}
+
+// setConfigParameterFlags sets the flags shared by set_config and get_config
+func setConfigParameterFlags(cmd *cobra.Command, options *vclusterops.VGetConfigurationParameterOptions, level *string) {
+	cmd.Flags().StringVar(
+		&options.ConfigParameter,
+		configParameterFlag,
+		"",
+		"The name of the configuration parameter",
+	)
+	cmd.Flags().StringVar(
+		level,
+		configLevelFlag,
+		string(vclusterops.ConfigLevelDatabase),
+		"The level of the configuration parameter, one of database, node or subcluster",
+	)
+	cmd.Flags().StringVar(
+		&options.LevelName,
+		configLevelNameFlag,
+		"",
+		"The node name or the subcluster name, for the node and subcluster levels",
+	)
+}
+
+func (c *CmdSetConfig) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.setConfigOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdSetConfig) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	c.setConfigOptions.Level = vclusterops.ConfigParameterLevel(c.level)
+
+	err := c.getCertFilesFromCertPaths(&c.setConfigOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.setConfigOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.setConfigOptions.DatabaseOptions)
+}
+
+func (c *CmdSetConfig) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.setConfigOptions
+
+	change, err := vcc.VSetConfigurationParameter(options)
+	if err != nil {
+		vcc.LogError(err, "fail to set the configuration parameter", "parameter", options.ConfigParameter)
+		return err
+	}
+	bytes, err := json.MarshalIndent(change, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdSetConfig
+func (c *CmdSetConfig) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.setConfigOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,159 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	ksafetyFlag     = "ksafety"
+	showKSafetyFlag = "show"
+)
+
+/* CmdSetKSafety
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdSetKSafety struct {
+	CmdBase
+	setKSafetyOptions *vclusterops.VSetKSafetyOptions
+	// only show the current k-safety instead of setting it
+	show bool
+}
+
+func makeCmdSetKSafety() *cobra.Command {
+	// CmdSetKSafety
+	newCmd := &CmdSetKSafety{}
+	opt := vclusterops.VSetKSafetyOptionsFactory()
+	newCmd.setKSafetyOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		setKSafetySubCmd,
+		"Set the design k-safety of a database",
+		`This subcommand marks the physical design of a running database with the
+k-safety given to the --ksafety option.
+
+A k-safety of k requires at least 2k+1 nodes, or 2k+1 primary nodes in an Eon
+Mode database. The k-safety must be between 0 and 2.
+
+Use the --show option to print the design k-safety and the current fault
+tolerance of the database instead of setting it.
+
+Examples:
+  # Set the k-safety to 1 with config file
+  vcluster set_ksafety --db-name test_db --ksafety 1 \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Show the k-safety with user input
+  vcluster set_ksafety --db-name test_db --show \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// either set or show the k-safety
+	cmd.MarkFlagsOneRequired(ksafetyFlag, showKSafetyFlag)
+	cmd.MarkFlagsMutuallyExclusive(ksafetyFlag, showKSafetyFlag)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdSetKSafety) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&c.setKSafetyOptions.KSafety,
+		ksafetyFlag,
+		0,
+		"The design k-safety to set, between 0 and 2",
+	)
+	cmd.Flags().BoolVar(
+		&c.show,
+		showKSafetyFlag,
+		false,
+		"Show the design k-safety and the current fault tolerance of the database",
+	)
+}
+
+func (c *CmdSetKSafety) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.setKSafetyOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdSetKSafety) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.setKSafetyOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.setKSafetyOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.setKSafetyOptions.DatabaseOptions)
+}
+
+func (c *CmdSetKSafety) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.setKSafetyOptions
+
+	if c.show {
+		getOptions := vclusterops.VGetKSafetyOptionsFactory()
+		getOptions.DatabaseOptions = options.DatabaseOptions
+		ksafety, err := vcc.VGetKSafety(&getOptions)
+		if err != nil {
+			vcc.LogError(err, "fail to get the k-safety", "DBName", options.DBName)
+			return err
+		}
+		bytes, err := json.MarshalIndent(ksafety, "", "  ")
+		if err != nil {
+			return err
+		}
+		c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+		return nil
+	}
+
+	err := vcc.VSetKSafety(options)
+	if err != nil {
+		vcc.LogError(err, "fail to set the k-safety", "DBName", options.DBName)
+		return err
+	}
+	vcc.PrintInfo("Set the k-safety of database %s to %d", options.DBName, options.KSafety)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdSetKSafety
+func (c *CmdSetKSafety) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.setKSafetyOptions.DatabaseOptions = *opt
+}
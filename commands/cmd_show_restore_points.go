@@ -80,7 +80,7 @@ Examples:
     --end-timestamp 2024-03-04 08:32:34.176391
 `,
 		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag,
-			communalStorageLocationFlag, configParamFlag},
+			communalStorageLocationFlag, configParamFlag, useInstanceProfileFlag},
 	)
 
 	// local flags
@@ -109,6 +109,12 @@ func (c *CmdShowRestorePoints) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"Index to filter restore points with",
 	)
+	cmd.Flags().StringVar(
+		&c.showRestorePointsOptions.FilterOptions.Namespace,
+		namespaceFlag,
+		"",
+		"Namespace of the archives to filter restore points with",
+	)
 	cmd.Flags().StringVar(
 		&c.showRestorePointsOptions.FilterOptions.StartTimestamp,
 		"start-timestamp",
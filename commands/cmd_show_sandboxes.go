@@ -0,0 +1,172 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	tableOutputFormat = "table"
+	jsonOutputFormat  = "json"
+)
+
+/* CmdShowSandboxes
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdShowSandboxes struct {
+	CmdBase
+	showSandboxesOptions *vclusterops.VShowSandboxesOptions
+	outputFormat         string
+}
+
+func makeCmdShowSandboxes() *cobra.Command {
+	// CmdShowSandboxes
+	newCmd := &CmdShowSandboxes{}
+	opt := vclusterops.VShowSandboxesOptionsFactory()
+	newCmd.showSandboxesOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		showSandboxesSubCmd,
+		"Show the sandboxes of an Eon Mode database",
+		`This subcommand lists the sandboxes of an Eon Mode database with their
+subclusters, and the state and catalog version of each sandboxed node.
+
+The state of a sandboxed node is fetched from the node itself, so the hosts
+passed to the --hosts option can be in the main cluster or in a sandbox.
+
+Use the --output-format option to print the sandboxes as a table or as JSON.
+
+Examples:
+  # Show the sandboxes with config file
+  vcluster show_sandboxes --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Show the sandboxes as JSON with user input
+  vcluster show_sandboxes --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --output-format json
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdShowSandboxes) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&c.outputFormat,
+		"output-format",
+		tableOutputFormat,
+		fmt.Sprintf("Format of the output, one of %s or %s", tableOutputFormat, jsonOutputFormat),
+	)
+}
+
+func (c *CmdShowSandboxes) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.showSandboxesOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdShowSandboxes) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	if c.outputFormat != tableOutputFormat && c.outputFormat != jsonOutputFormat {
+		return fmt.Errorf("invalid output format %q, must be one of %s or %s",
+			c.outputFormat, tableOutputFormat, jsonOutputFormat)
+	}
+
+	err := c.getCertFilesFromCertPaths(&c.showSandboxesOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.showSandboxesOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.showSandboxesOptions.DatabaseOptions)
+}
+
+func (c *CmdShowSandboxes) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.showSandboxesOptions
+
+	sandboxes, err := vcc.VShowSandboxes(options)
+	if err != nil {
+		vcc.LogError(err, "fail to show the sandboxes", "DBName", options.DBName)
+		return err
+	}
+
+	output, err := c.formatSandboxes(sandboxes)
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, output, vcc.GetLog())
+	vcc.LogInfo("Sandboxes: ", "sandboxes", sandboxes)
+	return nil
+}
+
+// formatSandboxes returns the sandboxes in the requested output format
+func (c *CmdShowSandboxes) formatSandboxes(sandboxes []vclusterops.SandboxInfo) ([]byte, error) {
+	if c.outputFormat == jsonOutputFormat {
+		output, err := json.MarshalIndent(sandboxes, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("fail to marshal the sandboxes, details %w", err)
+		}
+		return output, nil
+	}
+
+	var buf bytes.Buffer
+	const padding = 2
+	w := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, "SANDBOX\tSUBCLUSTER\tNODE\tADDRESS\tSTATE\tCATALOG VERSION")
+	for _, sandbox := range sandboxes {
+		for _, node := range sandbox.Nodes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", sandbox.Name, node.Subcluster,
+				node.Name, node.Address, node.State, node.CatalogVersion)
+		}
+	}
+	err := w.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("fail to format the sandboxes, details %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdShowSandboxes
+func (c *CmdShowSandboxes) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.showSandboxesOptions.DatabaseOptions = *opt
+}
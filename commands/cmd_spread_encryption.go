@@ -0,0 +1,168 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	enableSpreadEncryptionFlag  = "enable"
+	disableSpreadEncryptionFlag = "disable"
+	rotateSpreadEncryptionFlag  = "rotate"
+)
+
+/* CmdSpreadEncryption
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdSpreadEncryption struct {
+	spreadEncryptionOptions *vclusterops.VSpreadEncryptionOptions
+	enable                  bool
+	disable                 bool
+	rotate                  bool
+
+	CmdBase
+}
+
+func makeCmdSpreadEncryption() *cobra.Command {
+	// CmdSpreadEncryption
+	newCmd := &CmdSpreadEncryption{}
+	opt := vclusterops.VSpreadEncryptionOptionsFactory()
+	newCmd.spreadEncryptionOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		spreadEncryptionSubCmd,
+		"Enable, disable or rotate spread encryption",
+		`This subcommand enables or disables the encryption of the spread
+communications of a running database, or rotates the spread encryption key.
+
+The spread daemons of all nodes must use the same key, so the database is
+stopped and started again to apply the change. All nodes must be up, and no
+subcluster can be sandboxed.
+
+You must provide exactly one of the --enable, --disable or --rotate options.
+
+Examples:
+  # Enable spread encryption with config file
+  vcluster spread_encryption --enable \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Rotate the spread encryption key with user input
+  vcluster spread_encryption --rotate --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 --catalog-path /data
+`,
+		[]string{dbNameFlag, hostsFlag, communalStorageLocationFlag, ipv6Flag,
+			configFlag, catalogPathFlag, passwordFlag, eonModeFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	// require exactly one action
+	cmd.MarkFlagsOneRequired(enableSpreadEncryptionFlag, disableSpreadEncryptionFlag, rotateSpreadEncryptionFlag)
+	cmd.MarkFlagsMutuallyExclusive(enableSpreadEncryptionFlag, disableSpreadEncryptionFlag, rotateSpreadEncryptionFlag)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdSpreadEncryption) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&c.enable,
+		enableSpreadEncryptionFlag,
+		false,
+		"Enable spread encryption",
+	)
+	cmd.Flags().BoolVar(
+		&c.disable,
+		disableSpreadEncryptionFlag,
+		false,
+		"Disable spread encryption",
+	)
+	cmd.Flags().BoolVar(
+		&c.rotate,
+		rotateSpreadEncryptionFlag,
+		false,
+		"Replace the spread encryption key with a new one",
+	)
+	cmd.Flags().IntVar(
+		&c.spreadEncryptionOptions.StatePollingTimeout,
+		"timeout",
+		util.DefaultTimeoutSeconds,
+		"The timeout (in seconds) to wait for the nodes to be up after the restart",
+	)
+}
+
+func (c *CmdSpreadEncryption) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.spreadEncryptionOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdSpreadEncryption) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	switch {
+	case c.enable:
+		c.spreadEncryptionOptions.Action = vclusterops.SpreadEncryptionEnable
+	case c.disable:
+		c.spreadEncryptionOptions.Action = vclusterops.SpreadEncryptionDisable
+	case c.rotate:
+		c.spreadEncryptionOptions.Action = vclusterops.SpreadEncryptionRotate
+	}
+
+	err := c.getCertFilesFromCertPaths(&c.spreadEncryptionOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.spreadEncryptionOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.spreadEncryptionOptions.DatabaseOptions)
+}
+
+func (c *CmdSpreadEncryption) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.spreadEncryptionOptions
+
+	err := vcc.VAlterSpreadEncryption(options)
+	if err != nil {
+		vcc.LogError(err, "fail to change spread encryption", "action", options.Action)
+		return err
+	}
+
+	vcc.PrintInfo("Successfully completed spread encryption %s for database %s", options.Action, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdSpreadEncryption
+func (c *CmdSpreadEncryption) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.spreadEncryptionOptions.DatabaseOptions = *opt
+}
@@ -55,12 +55,21 @@ has been lost.
 
 The IP address provided for each node name must match the current IP address
 in the Vertica catalog. If the IPs do not match, you must call re_ip
-before start_db.
+before start_db, or use the --auto-reip option to re-ip the nodes whose
+addresses changed before starting them.
 
 If you pass the --hosts command a subset of all nodes in the cluster, only the
 specified nodes are started. There must be a quorum of nodes for the database
 to start.
 
+Use the --restart-only-failed option to make the command safe to run again
+when some nodes failed to start: if the database is partially up, only its
+down nodes are started.
+
+Use the --follow-logs option to print the new lines of vertica.log of the
+starting nodes while waiting for them to come up, to diagnose startup failures
+without logging in to the hosts.
+
 Examples:
   # Start a database with config file using password authentication
   vcluster start_db --password testpassword \
@@ -88,6 +97,30 @@ func (c *CmdStartDB) setLocalFlags(cmd *cobra.Command) {
 		util.DefaultTimeoutSeconds,
 		"The timeout (in seconds) to wait for polling node state operation",
 	)
+	cmd.Flags().BoolVar(
+		&c.startDBOptions.SkipClockCheck,
+		"skip-clock-check",
+		false,
+		"Skip the check of clock skew between the hosts",
+	)
+	cmd.Flags().BoolVar(
+		&c.startDBOptions.AutoReIP,
+		"auto-reip",
+		false,
+		"Re-ip the nodes whose addresses in the catalog do not match the hosts before starting the database",
+	)
+	cmd.Flags().BoolVar(
+		&c.startDBOptions.RestartOnlyFailed,
+		"restart-only-failed",
+		false,
+		"If the database is partially up, only start its down nodes instead of failing",
+	)
+	cmd.Flags().BoolVar(
+		&c.startDBOptions.FollowLogs,
+		followLogsFlag,
+		false,
+		"Print the new lines of vertica.log of the starting nodes while waiting for them to come up",
+	)
 	// Update description of hosts flag locally for a detailed hint
 	cmd.Flags().Lookup(hostsFlag).Usage = "Comma-separated list of hosts in database. This is used to start sandboxed hosts"
 }
@@ -117,6 +117,12 @@ func (c *CmdStartReplication) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"The source sandbox that we will replicate from",
 	)
+	cmd.Flags().StringVar(
+		&c.startRepOptions.Namespace,
+		namespaceFlag,
+		"",
+		"The namespace of the tables to replicate, if not the default namespace",
+	)
 	cmd.Flags().StringSliceVar(
 		&c.startRepOptions.TargetHosts,
 		targetHostsFlag,
@@ -91,6 +91,19 @@ func (c *CmdStopDB) setLocalFlags(cmd *cobra.Command) {
 		false,
 		"Stop the database, but don't stop any of the sandboxes",
 	)
+	cmd.Flags().IntVar(
+		&c.stopDBOptions.ShutdownTimeout,
+		"shutdown-timeout",
+		vclusterops.StopDBTimeout,
+		"Seconds to wait for all nodes to be down after the graceful shutdown",
+	)
+	cmd.Flags().BoolVar(
+		&c.stopDBOptions.EscalateToForce,
+		"force-on-timeout",
+		false,
+		"Force the nodes that are still up after --shutdown-timeout to shut down",
+	)
+	c.setConfirmationFlags(cmd)
 }
 
 // setHiddenFlags will set the hidden flags the command has.
@@ -146,7 +159,17 @@ func (c *CmdStopDB) Run(vcc vclusterops.ClusterCommands) error {
 
 	options := c.stopDBOptions
 
-	err := vcc.VStopDatabase(options)
+	var err error
+	if options.SandboxName != "" {
+		err = c.confirmOperation("Stop sandbox %s of database %s?", options.SandboxName, options.DBName)
+	} else {
+		err = c.confirmOperation("Stop database %s?", options.DBName)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = vcc.VStopDatabase(options)
 	if err != nil {
 		vcc.LogError(err, "failed to stop the database")
 		return err
@@ -53,6 +53,10 @@ You must provide the subcluster name with the --subcluster option.
 
 All hosts in the subcluster will be stopped.
 
+Unless the subcluster is forcibly stopped, the client sessions that keep it
+from draining are reported. Use the --close-sessions option to close them
+before the subcluster is stopped.
+
 Examples:
   # Gracefully stop a subcluster with config file
   vcluster stop_subcluster --subcluster sc1 --drain-seconds 10 \
@@ -109,7 +113,14 @@ func (c *CmdStopSubcluster) setLocalFlags(cmd *cobra.Command) {
 		false,
 		"Force the subcluster to shutdown immediately even if users are connected",
 	)
+	cmd.Flags().BoolVar(
+		&c.stopSCOptions.CloseSessions,
+		"close-sessions",
+		false,
+		"Close the client sessions of the subcluster before it is stopped, instead of waiting for them to end",
+	)
 	cmd.MarkFlagsMutuallyExclusive("drain-seconds", "force")
+	cmd.MarkFlagsMutuallyExclusive("close-sessions", "force")
 }
 
 func (c *CmdStopSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
@@ -0,0 +1,179 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const (
+	locationPathFlag  = "location-path"
+	locationUsageFlag = "usage"
+)
+
+func makeCmdStorageLocation() *cobra.Command {
+	cmd := makeSimpleCobraCmd(
+		storageLocationSubCmd,
+		"Add, retire, or alter the usage of a storage location",
+		`This subcommand adds a storage location, retires one, or alters its usage.`)
+
+	cmd.AddCommand(makeCmdStorageLocationAction(vclusterops.StorageLocationAdd, addLocationSubCmd,
+		"Add a storage location",
+		`This subcommand adds a storage location to the nodes of the given hosts,
+or to all nodes if --location-hosts is not specified. The directory of the
+storage location is created on the hosts if it does not exist.
+
+Examples:
+  # Add a temp storage location to all nodes with config file
+  vcluster storage_location add --location-path /data/temp --usage TEMP \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Add a data storage location to some nodes with user input
+  vcluster storage_location add --db-name test_db --location-path /data/extra \
+    --usage DATA --location-hosts 10.20.30.40,10.20.30.41 \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42
+`))
+	cmd.AddCommand(makeCmdStorageLocationAction(vclusterops.StorageLocationRetire, retireLocationSubCmd,
+		"Retire a storage location",
+		`This subcommand retires a storage location on the nodes of the given hosts,
+or on all nodes if --location-hosts is not specified. No new data is stored
+in a retired storage location.
+
+Examples:
+  # Retire a storage location on all nodes with config file
+  vcluster storage_location retire --location-path /data/extra \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`))
+	cmd.AddCommand(makeCmdStorageLocationAction(vclusterops.StorageLocationAlterUsage, alterLocationUsageCmd,
+		"Alter the usage of a storage location",
+		`This subcommand alters the usage of a storage location on the nodes of the
+given hosts, or on all nodes if --location-hosts is not specified.
+
+Examples:
+  # Use a storage location for both data and temp files with config file
+  vcluster storage_location alter_usage --location-path /data/extra \
+    --usage DATA,TEMP --config /opt/vertica/config/vertica_cluster.yaml
+`))
+
+	return cmd
+}
+
+/* CmdStorageLocation
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdStorageLocation struct {
+	storageLocationOptions *vclusterops.VAlterStorageLocationOptions
+
+	CmdBase
+}
+
+func makeCmdStorageLocationAction(action vclusterops.StorageLocationAction,
+	subCmd, short, long string) *cobra.Command {
+	newCmd := &CmdStorageLocation{}
+	opt := vclusterops.VAlterStorageLocationOptionsFactory()
+	opt.Action = action
+	newCmd.storageLocationOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		subCmd,
+		short,
+		long,
+		[]string{dbNameFlag, configFlag, hostsFlag, ipv6Flag, passwordFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd, action)
+
+	requiredFlags := []string{locationPathFlag}
+	if action != vclusterops.StorageLocationRetire {
+		requiredFlags = append(requiredFlags, locationUsageFlag)
+	}
+	markFlagsRequired(cmd, requiredFlags)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdStorageLocation) setLocalFlags(cmd *cobra.Command, action vclusterops.StorageLocationAction) {
+	cmd.Flags().StringVar(
+		&c.storageLocationOptions.LocationPath,
+		locationPathFlag,
+		"",
+		"Absolute path of the storage location",
+	)
+	cmd.Flags().StringSliceVar(
+		&c.storageLocationOptions.LocationHosts,
+		"location-hosts",
+		[]string{},
+		"Comma-separated list of hosts whose nodes the change applies to. If empty, the change applies to all nodes",
+	)
+	if action != vclusterops.StorageLocationRetire {
+		cmd.Flags().StringVar(
+			&c.storageLocationOptions.Usage,
+			locationUsageFlag,
+			"",
+			"Usage of the storage location, one of DATA, TEMP, DATA,TEMP, DEPOT or USER",
+		)
+	}
+}
+
+func (c *CmdStorageLocation) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogArgParse(&c.argv)
+
+	// reset some options that are not included in user input
+	c.ResetUserInputOptions(&c.storageLocationOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdStorageLocation) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.storageLocationOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.storageLocationOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.storageLocationOptions.DatabaseOptions)
+}
+
+func (c *CmdStorageLocation) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.LogInfo("Called method Run()")
+
+	options := c.storageLocationOptions
+
+	err := vcc.VAlterStorageLocation(options)
+	if err != nil {
+		vcc.LogError(err, "fail to change storage location", "action", options.Action, "path", options.LocationPath)
+		return err
+	}
+	vcc.PrintInfo("Successfully completed %s of storage location %s in database %s",
+		options.Action, options.LocationPath, options.DBName)
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdStorageLocation
+func (c *CmdStorageLocation) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.storageLocationOptions.DatabaseOptions = *opt
+}
@@ -59,6 +59,10 @@ When all subclusters are removed from a sandbox, the sandbox catalog and
 metadata are deleted. To reuse the sandbox name, you must manually clean the 
 /metadata/<sandbox-name> directory in your communal storage location.
 
+By default, the sandbox catalog directories of the subcluster hosts are deleted
+after unsandboxing, so repeated sandbox and unsandbox cycles do not leak disk
+space. Use --cleanup-catalog=false to keep them, for example to inspect them.
+
 The comma-separated list of hosts passed to the --hosts option must include at
 least one up host in the main cluster.
 
@@ -93,6 +97,12 @@ func (c *CmdUnsandboxSubcluster) setLocalFlags(cmd *cobra.Command) {
 		"",
 		"The name of the subcluster to be unsandboxed",
 	)
+	cmd.Flags().BoolVar(
+		&c.usOptions.CleanupCatalog,
+		"cleanup-catalog",
+		true,
+		"Whether to delete the stale sandbox catalog directories of the subcluster hosts after unsandboxing it",
+	)
 }
 
 func (c *CmdUnsandboxSubcluster) Parse(inputArgv []string, logger vlog.Printer) error {
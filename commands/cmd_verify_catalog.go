@@ -0,0 +1,141 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdVerifyCatalog
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdVerifyCatalog struct {
+	CmdBase
+	verifyCatalogOptions *vclusterops.VVerifyCatalogOptions
+}
+
+func makeCmdVerifyCatalog() *cobra.Command {
+	// CmdVerifyCatalog
+	newCmd := &CmdVerifyCatalog{}
+	opt := vclusterops.VVerifyCatalogOptionsFactory()
+	newCmd.verifyCatalogOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		verifyCatalogSubCmd,
+		"Verify that all nodes have the latest catalog",
+		`This subcommand reads the catalog of every node and compares their global
+and spread versions. The nodes whose catalog is behind the latest one are
+reported as stale.
+
+Use the --repair option to copy vertica.conf and spread.conf from a node
+with the latest catalog to the stale nodes. The database must be down
+to repair the catalogs.
+
+Examples:
+  # Verify the catalogs with config file
+  vcluster verify_catalog --db-name test_db \
+    --config /opt/vertica/config/vertica_cluster.yaml
+
+  # Verify and repair the catalogs with user input
+  vcluster verify_catalog --db-name test_db \
+    --hosts 10.20.30.40,10.20.30.41,10.20.30.42 \
+    --catalog-path /data --repair
+`,
+		[]string{dbNameFlag, configFlag, passwordFlag, hostsFlag, ipv6Flag,
+			catalogPathFlag, outputFileFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdVerifyCatalog) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&c.verifyCatalogOptions.Repair,
+		"repair",
+		false,
+		"Sync the config files of the latest catalog to the nodes with a stale catalog",
+	)
+}
+
+func (c *CmdVerifyCatalog) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	// for some options, we do not want to use their default values,
+	// if they are not provided in cli,
+	// reset the value of those options to nil
+	c.ResetUserInputOptions(&c.verifyCatalogOptions.DatabaseOptions)
+
+	return c.validateParse(logger)
+}
+
+func (c *CmdVerifyCatalog) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()")
+
+	err := c.getCertFilesFromCertPaths(&c.verifyCatalogOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.verifyCatalogOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.verifyCatalogOptions.DatabaseOptions)
+}
+
+func (c *CmdVerifyCatalog) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.verifyCatalogOptions
+
+	report, err := vcc.VVerifyCatalog(options)
+	if err != nil {
+		vcc.LogError(err, "fail to verify the catalogs", "DBName", options.DBName)
+		return err
+	}
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	c.writeCmdOutputToFile(globals.file, bytes, vcc.GetLog())
+
+	switch {
+	case len(report.StaleNodes) == 0:
+		vcc.PrintInfo("All nodes of database %s have the latest catalog", options.DBName)
+	case report.Repaired:
+		vcc.PrintInfo("Repaired %d stale catalog(s) in database %s", len(report.StaleNodes), options.DBName)
+	default:
+		vcc.PrintWarning("Found %d stale catalog(s) in database %s, use --repair to repair them",
+			len(report.StaleNodes), options.DBName)
+	}
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdVerifyCatalog
+func (c *CmdVerifyCatalog) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.verifyCatalogOptions.DatabaseOptions = *opt
+}
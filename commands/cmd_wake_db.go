@@ -0,0 +1,124 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+/* CmdWakeDB
+ *
+ * Implements ClusterCommand interface
+ */
+type CmdWakeDB struct {
+	wakeDBOptions *vclusterops.VWakeDatabaseOptions
+
+	CmdBase
+}
+
+func makeCmdWakeDB() *cobra.Command {
+	// CmdWakeDB
+	newCmd := &CmdWakeDB{}
+	opt := vclusterops.VWakeDatabaseOptionsFactory()
+	newCmd.wakeDBOptions = &opt
+
+	cmd := makeBasicCobraCmd(
+		newCmd,
+		wakeDBSubCmd,
+		"Wake a hibernated Eon database",
+		`This subcommand starts an Eon Mode database that was hibernated by
+hibernate_db.
+
+The hosts must be the ones on which the database was hibernated, so that their
+local catalogs can be used. This is faster than reviving the database. To start
+the database on other hosts, use revive_db.
+
+Examples:
+  # Wake a database with config file using password authentication
+  vcluster wake_db --password testpassword \
+    --config /opt/vertica/config/vertica_cluster.yaml
+`,
+		[]string{dbNameFlag, hostsFlag, communalStorageLocationFlag, ipv6Flag,
+			configFlag, catalogPathFlag, passwordFlag, eonModeFlag, configParamFlag},
+	)
+
+	// local flags
+	newCmd.setLocalFlags(cmd)
+
+	return cmd
+}
+
+// setLocalFlags will set the local flags the command has
+func (c *CmdWakeDB) setLocalFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(
+		&c.wakeDBOptions.StatePollingTimeout,
+		"timeout",
+		util.DefaultTimeoutSeconds,
+		"The timeout (in seconds) to wait for polling node state operation",
+	)
+}
+
+func (c *CmdWakeDB) Parse(inputArgv []string, logger vlog.Printer) error {
+	c.argv = inputArgv
+	logger.LogMaskedArgParse(c.argv)
+
+	c.ResetUserInputOptions(&c.wakeDBOptions.DatabaseOptions)
+	return c.validateParse(logger)
+}
+
+func (c *CmdWakeDB) validateParse(logger vlog.Printer) error {
+	logger.Info("Called validateParse()", "command", wakeDBSubCmd)
+
+	err := c.getCertFilesFromCertPaths(&c.wakeDBOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = c.ValidateParseBaseOptions(&c.wakeDBOptions.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	return c.setDBPassword(&c.wakeDBOptions.DatabaseOptions)
+}
+
+func (c *CmdWakeDB) Run(vcc vclusterops.ClusterCommands) error {
+	vcc.V(1).Info("Called method Run()")
+
+	options := c.wakeDBOptions
+
+	vdb, err := vcc.VWakeDatabase(options)
+	if err != nil {
+		vcc.LogError(err, "failed to wake the database")
+		return err
+	}
+
+	vcc.PrintInfo("Successfully woke the database %s", options.DBName)
+
+	// update config file to fill nodes' subcluster information
+	err = writeConfig(vdb)
+	if err != nil {
+		vcc.PrintWarning("fail to update config file, details: %s", err)
+	}
+	return nil
+}
+
+// SetDatabaseOptions will assign a vclusterops.DatabaseOptions instance to the one in CmdWakeDB
+func (c *CmdWakeDB) SetDatabaseOptions(opt *vclusterops.DatabaseOptions) {
+	c.wakeDBOptions.DatabaseOptions = *opt
+}
@@ -0,0 +1,97 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+
+	"github.com/vertica/vcluster/rfc7807"
+	"github.com/vertica/vcluster/vclusterops"
+)
+
+// Exit codes of the vcluster CLI. Wrapper scripts can rely on them to decide
+// how to react to a failure:
+//
+//	0  the command succeeded
+//	1  the command failed for a reason not listed below
+//	2  the command line, the config file or the connection file is invalid
+//	3  a host could not be reached
+//	4  the password or the certificates were rejected
+//	5  not enough primary nodes are up to reach quorum
+//	6  the command succeeded on some hosts only
+//
+// When an error matches several categories, the first one in the list above
+// is used.
+const (
+	ExitCodeSuccess           = 0
+	ExitCodeGenericError      = 1
+	ExitCodeConfigError       = 2
+	ExitCodeConnectivityError = 3
+	ExitCodeAuthError         = 4
+	ExitCodeQuorumError       = 5
+	ExitCodePartialSuccess    = 6
+)
+
+// configError wraps the errors found while reading the command line,
+// the config file or the connection file
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string {
+	return e.err.Error()
+}
+
+func (e *configError) Unwrap() error {
+	return e.err
+}
+
+// makeConfigError wraps err into a configError. It returns nil if err is nil.
+func makeConfigError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &configError{err: err}
+}
+
+// getExitCode maps the error returned by a command to one of the exit codes
+func getExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+
+	var cfgErr *configError
+	var unreachableErr *vclusterops.HostUnreachableError
+	var credErr *vclusterops.WrongCredentialError
+	var quorumErr *vclusterops.NoQuorumError
+	var partialErr *vclusterops.PartialSuccessError
+	rfcErr := &rfc7807.VProblem{}
+	switch {
+	case errors.As(err, &cfgErr):
+		return ExitCodeConfigError
+	case errors.As(err, &unreachableErr):
+		return ExitCodeConnectivityError
+	case errors.As(err, &credErr):
+		return ExitCodeAuthError
+	case errors.As(err, &rfcErr) && rfcErr.IsInstanceOf(rfc7807.AuthenticationError):
+		return ExitCodeAuthError
+	case errors.As(err, &quorumErr):
+		return ExitCodeQuorumError
+	case errors.As(err, &partialErr):
+		return ExitCodePartialSuccess
+	}
+	return ExitCodeGenericError
+}
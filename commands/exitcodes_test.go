@@ -0,0 +1,50 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vertica/vcluster/rfc7807"
+	"github.com/vertica/vcluster/vclusterops"
+)
+
+func TestGetExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeSuccess, getExitCode(nil))
+	assert.Equal(t, ExitCodeGenericError, getExitCode(errors.New("some error")))
+	assert.Equal(t, ExitCodeConfigError, getExitCode(makeConfigError(errors.New("bad flag"))))
+
+	// typed errors are found even when they are wrapped or joined
+	unreachableErr := &vclusterops.HostUnreachableError{Host: "192.0.2.1"}
+	assert.Equal(t, ExitCodeConnectivityError, getExitCode(fmt.Errorf("fail to start db: %w", unreachableErr)))
+	credErr := &vclusterops.WrongCredentialError{Hosts: []string{"192.0.2.1"}}
+	assert.Equal(t, ExitCodeAuthError, getExitCode(errors.Join(errors.New("some error"), credErr)))
+	rfcErr := rfc7807.New(rfc7807.AuthenticationError).WithHost("192.0.2.1")
+	assert.Equal(t, ExitCodeAuthError, getExitCode(rfcErr))
+	assert.Equal(t, ExitCodeQuorumError, getExitCode(&vclusterops.NoQuorumError{}))
+	assert.Equal(t, ExitCodePartialSuccess, getExitCode(&vclusterops.PartialSuccessError{}))
+
+	// the first matching category wins
+	assert.Equal(t, ExitCodeConnectivityError, getExitCode(errors.Join(credErr, unreachableErr)))
+
+	// a wrapped config error still prints the original message
+	assert.Equal(t, "bad flag", makeConfigError(errors.New("bad flag")).Error())
+	assert.Nil(t, makeConfigError(nil))
+}
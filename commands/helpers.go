@@ -15,9 +15,12 @@ limitations under the License.
 package commands
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"golang.org/x/term"
 )
@@ -37,6 +40,35 @@ func readDBPasswordFromPrompt() (string, error) {
 	return string(passwordBytes), nil
 }
 
+// these variables are for unit test, be careful to modify them
+var (
+	isInputTerminalFn = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd()))
+	}
+	confirmationInput io.Reader = os.Stdin
+)
+
+// readConfirmationFromPrompt asks a yes or no question to the user.
+// Anything but "y" or "yes" is a no.
+func readConfirmationFromPrompt(question string) (bool, error) {
+	answer, err := readLineFromPrompt(question + " [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// readLineFromPrompt prints the prompt and returns the trimmed line the user enters
+func readLineFromPrompt(prompt string) (string, error) {
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(confirmationInput).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("error reading confirmation: %w", err)
+	}
+	return strings.TrimSpace(answer), nil
+}
+
 func readFromStdin() (string, error) {
 	data, err := io.ReadAll(os.Stdin)
 	if err != nil {
@@ -19,6 +19,8 @@ func init() {
 	// set the log path depending on executable path
 	setLogPath()
 
+	// the completion subcommand only supports the shells we document
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	allCommands := constructCmds()
 	for _, c := range allCommands {
 		rootCmd.AddCommand(c)
@@ -23,7 +23,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
 	"gopkg.in/yaml.v3"
 )
 
@@ -40,23 +43,34 @@ func simulateVClusterCli(vclusterCmd string) error {
 
 	// simulate a VCluster CLI call
 	log.Printf("Simulating VCluster CLI call %+v\n", os.Args)
-	err := rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
 
-	// reset os.Args
+	// reset os.Args, and the flags so that they are not seen as set by
+	// the next calls
 	os.Args = nil
+	resetChanged := func(flag *pflag.Flag) { flag.Changed = false }
+	if cmd != nil {
+		cmd.Flags().VisitAll(resetChanged)
+	}
+	rootCmd.PersistentFlags().VisitAll(resetChanged)
 	return err
 }
 
 func TestConfigRecover(t *testing.T) {
 	err := simulateVClusterCli("vcluster manage_config recover")
-	assert.ErrorContains(t, err, `required flag(s) "catalog-path", "db-name", "hosts" not set`)
+	assert.ErrorContains(t, err, `required flag(s) "db-name" not set`)
 
 	err = simulateVClusterCli("vcluster manage_config recover --db-name test_db")
-	assert.ErrorContains(t, err, `required flag(s) "catalog-path", "hosts" not set`)
+	assert.ErrorContains(t, err, "at least one of the flags in the group [catalog-path from-communal] is required")
+
+	// cobra checks the catalog-path group before the hosts group, so the
+	// hosts are only checked once the catalog path is given
+	err = simulateVClusterCli("vcluster manage_config recover --db-name test_db --catalog-path /data")
+	assert.ErrorContains(t, err, "at least one of the flags in the group [hosts hosts-file] is required")
 
 	err = simulateVClusterCli("vcluster manage_config recover --db-name test_db " +
 		"--hosts 192.168.1.101")
-	assert.ErrorContains(t, err, `required flag(s) "catalog-path" not set`)
+	assert.ErrorContains(t, err, "at least one of the flags in the group [catalog-path from-communal] is required")
 
 	tempConfig, _ := os.Create(tempConfigFilePath)
 	tempConfig.Close()
@@ -77,6 +91,96 @@ func TestManageConfig(t *testing.T) {
 	assert.ErrorContains(t, err, `unknown command "recover" for "vcluster manage_config show"`)
 }
 
+func TestConfigShowSyncFillOptions(t *testing.T) {
+	dbConfig := MakeDatabaseConfig()
+	dbConfig.Name = "test_db"
+	dbConfig.Ipv6 = true
+	dbConfig.Nodes = []*NodeConfig{
+		{Name: "v_test_db_node0001", Address: "192.168.1.101", CatalogPath: "/data/test_db/v_test_db_node0001_catalog",
+			DataPath: "/data/test_db/v_test_db_node0001_data"},
+		{Name: "v_test_db_node0002", Address: "192.168.1.102", CatalogPath: "/data/test_db/v_test_db_node0002_catalog",
+			DataPath: "/data/test_db/v_test_db_node0002_data"},
+	}
+	assert.NoError(t, dbConfig.write(tempConfigFilePath))
+	defer os.Remove(tempConfigFilePath)
+	originalConfigPath := dbOptions.ConfigPath
+	dbOptions.ConfigPath = tempConfigFilePath
+	defer func() { dbOptions.ConfigPath = originalConfigPath }()
+
+	opt := vclusterops.VRecoverConfigOptionsFactory()
+	c := CmdConfigShow{sOptions: &opt}
+	parser := pflag.NewFlagSet("show", pflag.ContinueOnError)
+	parser.BoolVar(&opt.IPv6, ipv6Flag, false, "")
+	c.SetParser(parser)
+
+	// the options that are not given come from the config file
+	c.fillOptionsFromConfig(vlog.Printer{})
+	assert.Equal(t, "test_db", opt.DBName)
+	assert.Equal(t, []string{"192.168.1.101", "192.168.1.102"}, opt.RawHosts)
+	assert.True(t, opt.IPv6)
+	assert.Equal(t, "/data", opt.CatalogPrefix)
+	assert.Equal(t, "/data", opt.DataPrefix)
+
+	// the options that are given are kept
+	opt = vclusterops.VRecoverConfigOptionsFactory()
+	opt.DBName = "other_db"
+	opt.RawHosts = []string{"192.168.1.103"}
+	assert.NoError(t, parser.Parse([]string{"--" + ipv6Flag + "=false"}))
+	c.fillOptionsFromConfig(vlog.Printer{})
+	assert.Equal(t, "other_db", opt.DBName)
+	assert.Equal(t, []string{"192.168.1.103"}, opt.RawHosts)
+	assert.False(t, opt.IPv6)
+}
+
+func TestConfigLabels(t *testing.T) {
+	dbConfig := MakeDatabaseConfig()
+	dbConfig.Name = "test_db"
+	dbConfig.IsEon = true
+	dbConfig.Nodes = []*NodeConfig{
+		{Name: "v_test_db_node0001", Address: "192.168.1.101", Subcluster: "default_subcluster",
+			Labels: map[string]string{"rack": "r1", "zone": "us-east-1b"}},
+		{Name: "v_test_db_node0002", Address: "192.168.1.102", Subcluster: "sc1",
+			Labels: map[string]string{"rack": "r2"}},
+	}
+	dbConfig.Subclusters = []*SubclusterConfig{
+		{Name: "default_subcluster", Labels: map[string]string{"zone": "us-east-1a"}},
+		{Name: "sc1", Labels: map[string]string{"instance-type": "r5.xlarge"}},
+	}
+	assert.NoError(t, dbConfig.validateLabels())
+	assert.NoError(t, dbConfig.write(tempConfigFilePath))
+	defer os.Remove(tempConfigFilePath)
+	originalConfigPath := dbOptions.ConfigPath
+	dbOptions.ConfigPath = tempConfigFilePath
+	defer func() { dbOptions.ConfigPath = originalConfigPath }()
+
+	// the labels are merged into the node states, the node labels first
+	nodes := addNodeLabels([]vclusterops.NodeInfo{
+		{Name: "v_test_db_node0001", Subcluster: "default_subcluster"},
+		{Name: "v_test_db_node0002", Subcluster: "sc1"},
+	})
+	assert.Equal(t, map[string]string{"rack": "r1", "zone": "us-east-1b"}, nodes[0].Labels)
+	assert.Equal(t, map[string]string{"rack": "r2", "instance-type": "r5.xlarge"}, nodes[1].Labels)
+
+	// the labels are kept when the config file is rewritten, for the nodes
+	// still in the database
+	vdb := vclusterops.VCoordinationDatabase{Name: "test_db", IsEon: true,
+		HostList: []string{"192.168.1.101", "192.168.1.103"},
+		HostNodeMap: map[string]*vclusterops.VCoordinationNode{
+			"192.168.1.101": {Name: "v_test_db_node0001", Address: "192.168.1.101", Subcluster: "default_subcluster"},
+			"192.168.1.103": {Name: "v_test_db_node0003", Address: "192.168.1.103", Subcluster: "sc1"},
+		},
+	}
+	assert.NoError(t, writeConfig(&vdb))
+	newConfig, err := readConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"rack": "r1", "zone": "us-east-1b"}, newConfig.Nodes[0].Labels)
+	assert.Nil(t, newConfig.Nodes[1].Labels)
+	assert.Equal(t, dbConfig.Subclusters, newConfig.Subclusters)
+
+	newConfig.Subclusters[1].Labels["bad key"] = "value"
+	assert.ErrorContains(t, newConfig.validateLabels(), `invalid labels of subcluster sc1: invalid label key "bad key"`)
+}
+
 func TestManageReplication(t *testing.T) {
 	// vcluster replication should succeed and show help message
 	err := simulateVClusterCli("vcluster replication")
@@ -86,6 +190,52 @@ func TestManageReplication(t *testing.T) {
 	assert.ErrorContains(t, err, `unknown command "test" for "vcluster replication start"`)
 }
 
+func TestManageConnections(t *testing.T) {
+	// vcluster manage_connections should succeed and show help message
+	err := simulateVClusterCli("vcluster manage_connections")
+	assert.NoError(t, err)
+
+	// redirect requires the host to redirect to
+	err = simulateVClusterCli("vcluster manage_connections redirect --db-name test_db --hosts 192.168.1.101")
+	assert.ErrorContains(t, err, `required flag(s) "redirect-hostname" not set`)
+
+	err = simulateVClusterCli("vcluster manage_connections pause --redirect-hostname 192.168.1.102")
+	assert.ErrorContains(t, err, `unknown flag: --redirect-hostname`)
+}
+
+func TestRollingUpgrade(t *testing.T) {
+	// the executable that installs the new binary is required
+	err := simulateVClusterCli("vcluster rolling_upgrade --db-name test_db --hosts 192.168.1.101")
+	assert.ErrorContains(t, err, `required flag(s) "upgrade-command" not set`)
+}
+
+func TestHostsFile(t *testing.T) {
+	hostsFile := t.TempDir() + "/hosts"
+
+	err := simulateVClusterCli("vcluster check_prerequisites --db-name test_db --hosts-file " + hostsFile)
+	assert.ErrorContains(t, err, "error reading hosts from file")
+
+	err = os.WriteFile(hostsFile, []byte("# no host yet\n\n"), 0600)
+	assert.NoError(t, err)
+	err = simulateVClusterCli("vcluster check_prerequisites --db-name test_db --hosts-file " + hostsFile)
+	assert.ErrorContains(t, err, "no host found in hosts file")
+
+	err = simulateVClusterCli("vcluster check_prerequisites --db-name test_db --hosts 192.168.1.101 " +
+		"--hosts-file " + hostsFile)
+	assert.ErrorContains(t, err, "[hosts hosts-file] were all set")
+}
+
+func TestParseHostsFileContent(t *testing.T) {
+	content := `# primary subcluster
+192.168.1.101
+  192.168.1.102  # trailing comment
+
+192.168.1.103
+`
+	assert.Equal(t, []string{"192.168.1.101", "192.168.1.102", "192.168.1.103"}, parseHostsFileContent(content))
+	assert.Empty(t, parseHostsFileContent("\n# comment only\n"))
+}
+
 func TestCreateConnection(t *testing.T) {
 	var tempConnFilePath = os.TempDir() + "/vertica_connection.yaml"
 	dbName := "platform_test_db"
@@ -143,3 +293,46 @@ func TestStartNode(t *testing.T) {
 	err = simulateVClusterCli("vcluster restart_node --restart node1=host1 --start-hosts host1")
 	assert.ErrorContains(t, err, "[restart start-hosts] were all set")
 }
+
+func TestConfirmOperation(t *testing.T) {
+	originalIsTerminal, originalInput := isInputTerminalFn, confirmationInput
+	defer func() { isInputTerminalFn, confirmationInput = originalIsTerminal, originalInput }()
+	c := CmdBase{}
+
+	// no prompt without a terminal, e.g., in scripts
+	isInputTerminalFn = func() bool { return false }
+	confirmationInput = strings.NewReader("n\n")
+	assert.NoError(t, c.confirmOperation("Drop database %s?", "test_db"))
+
+	isInputTerminalFn = func() bool { return true }
+	confirmationInput = strings.NewReader("Yes\n")
+	assert.NoError(t, c.confirmOperation("Drop database %s?", "test_db"))
+
+	confirmationInput = strings.NewReader("\n")
+	assert.ErrorContains(t, c.confirmOperation("Drop database %s?", "test_db"), "cancelled")
+
+	// --yes skips the prompt
+	c.assumeYes = true
+	confirmationInput = strings.NewReader("n\n")
+	assert.NoError(t, c.confirmOperation("Drop database %s?", "test_db"))
+}
+
+func TestConfirmOperationWithName(t *testing.T) {
+	originalIsTerminal, originalInput := isInputTerminalFn, confirmationInput
+	defer func() { isInputTerminalFn, confirmationInput = originalIsTerminal, originalInput }()
+	c := CmdBase{}
+
+	// the confirmation is required without a terminal
+	isInputTerminalFn = func() bool { return false }
+	assert.ErrorContains(t, c.confirmOperationWithName("test_db", "Drop database?"), "must be confirmed")
+
+	isInputTerminalFn = func() bool { return true }
+	confirmationInput = strings.NewReader("y\n")
+	assert.ErrorContains(t, c.confirmOperationWithName("test_db", "Drop database?"), "cancelled")
+	confirmationInput = strings.NewReader("test_db\n")
+	assert.NoError(t, c.confirmOperationWithName("test_db", "Drop database?"))
+
+	c.assumeYes = true
+	isInputTerminalFn = func() bool { return false }
+	assert.NoError(t, c.confirmOperationWithName("test_db", "Drop database?"))
+}
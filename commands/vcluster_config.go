@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -29,6 +30,8 @@ import (
 
 const (
 	vclusterConfigEnv = "VCLUSTER_CONFIG"
+	// the profile of the database, whose config file is in the default directory
+	vclusterDBProfileEnv = "VCLUSTER_DB_PROFILE"
 	// If no config file was provided, we will pick a default one. This is the
 	// default file name that we'll use.
 	defConfigFileName        = "vertica_cluster.yaml"
@@ -50,6 +53,8 @@ type DatabaseConfig struct {
 	CommunalStorageLocation string        `yaml:"communalStorageLocation" mapstructure:"communalStorageLocation"`
 	Ipv6                    bool          `yaml:"ipv6" mapstructure:"ipv6"`
 	FirstStartAfterRevive   bool          `yaml:"firstStartAfterRevive" mapstructure:"firstStartAfterRevive"`
+	// labels of the subclusters, the other subcluster info is in the nodes
+	Subclusters []*SubclusterConfig `yaml:"subclusters,omitempty" mapstructure:"subclusters"`
 }
 
 // NodeConfig contains node information in the database
@@ -61,6 +66,16 @@ type NodeConfig struct {
 	DataPath    string `yaml:"dataPath" mapstructure:"dataPath"`
 	DepotPath   string `yaml:"depotPath" mapstructure:"depotPath"`
 	Sandbox     string `yaml:"sandbox" mapstructure:"sandbox"` // Name of the sandbox the node belongs to
+	// arbitrary key/value metadata, e.g., zone, rack or instance type
+	Labels map[string]string `yaml:"labels,omitempty" mapstructure:"labels"`
+}
+
+// SubclusterConfig contains the labels of a subcluster. Labels are not
+// stored in the database: they are kept in the config file for schedulers
+// and operators, and preserved when vcluster rewrites the file.
+type SubclusterConfig struct {
+	Name   string            `yaml:"name" mapstructure:"name"`
+	Labels map[string]string `yaml:"labels,omitempty" mapstructure:"labels"`
 }
 
 // MakeDatabaseConfig() can create an instance of DatabaseConfig
@@ -87,11 +102,17 @@ func initConfig() {
 func initConfigImpl(vclusterExePath string, ensureOptVerticaConfigExists, ensureUserConfigDirExists bool) {
 	// We need to find the path to the config. The order of precedence is as follows:
 	// 1. Option
-	// 2. Environment variable
-	// 3. Default locations
+	// 2. Database profile option, see below
+	// 3. Environment variable
+	// 4. Database profile environment variable
+	// 5. Default locations
 	//   a. /opt/vertica/config/vertica_config.yaml if running vcluster in /opt/vertica/bin
 	//   b. $HOME/.config/vcluster/vertica_config.yaml otherwise
 	//
+	// The config file of a database profile is in the default directory, e.g.,
+	// $HOME/.config/vcluster/vertica_cluster_<profile>.yaml, so that several
+	// databases can be managed from one host without passing --config.
+	//
 	// If none of these things are true, then we run the cli without a config file.
 
 	// If option is set, nothing else to do in here
@@ -100,13 +121,17 @@ func initConfigImpl(vclusterExePath string, ensureOptVerticaConfigExists, ensure
 	}
 
 	// Check environment variable
-	if dbOptions.ConfigPath == "" {
+	profile := globals.dbProfile
+	if profile == "" {
 		val, ok := os.LookupEnv(vclusterConfigEnv)
 		if ok && val != "" {
 			dbOptions.ConfigPath = val
 			return
 		}
+		profile = os.Getenv(vclusterDBProfileEnv)
 	}
+	configFileName, err := getConfigFileName(profile)
+	cobra.CheckErr(err)
 
 	// Pick a default config file.
 
@@ -122,7 +147,7 @@ func initConfigImpl(vclusterExePath string, ensureOptVerticaConfigExists, ensure
 			}
 			cobra.CheckErr(err)
 		} else {
-			dbOptions.ConfigPath = fmt.Sprintf("%s/%s", rpmConfDir, defConfigFileName)
+			dbOptions.ConfigPath = fmt.Sprintf("%s/%s", rpmConfDir, configFileName)
 			return
 		}
 	}
@@ -142,7 +167,20 @@ func initConfigImpl(vclusterExePath string, ensureOptVerticaConfigExists, ensure
 			return
 		}
 	}
-	dbOptions.ConfigPath = fmt.Sprintf("%s/%s", path, defConfigFileName)
+	dbOptions.ConfigPath = fmt.Sprintf("%s/%s", path, configFileName)
+}
+
+// getConfigFileName returns the name of the config file of a database profile,
+// or the default name if no profile is given
+func getConfigFileName(profile string) (string, error) {
+	if profile == "" {
+		return defConfigFileName, nil
+	}
+	if strings.ContainsAny(profile, `/\`) || profile == "." || profile == ".." {
+		return "", fmt.Errorf("invalid database profile %q, it must not be a path", profile)
+	}
+	ext := filepath.Ext(defConfigFileName)
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(defConfigFileName, ext), profile, ext), nil
 }
 
 // loadConfigToViper can fill viper keys using vertica_cluster.yaml
@@ -168,6 +206,11 @@ func loadConfigToViper() error {
 		return fmt.Errorf("database %q does not match name found in the configuration file %q", dbConfig.Name, viper.GetString(dbNameKey))
 	}
 
+	err = dbConfig.validateLabels()
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %q: %w", dbOptions.ConfigPath, err)
+	}
+
 	// hosts, catalogPrefix, dataPrefix, depotPrefix are special in config file,
 	// they are the values in each node so they need extra process.
 	if !viper.IsSet(hostsKey) {
@@ -198,6 +241,11 @@ func writeConfig(vdb *vclusterops.VCoordinationDatabase) error {
 		return err
 	}
 
+	// labels are not in the database, keep the ones of the current config file
+	if oldConfig, readErr := readConfig(); readErr == nil && oldConfig.Name == dbConfig.Name {
+		dbConfig.copyLabels(oldConfig)
+	}
+
 	// update db config with the given database info
 	err = dbConfig.write(dbOptions.ConfigPath)
 	if err != nil {
@@ -323,3 +371,52 @@ func (c *DatabaseConfig) getPathPrefixes() (catalogPrefix string,
 
 	return util.GetPathPrefix(c.Nodes[0].CatalogPath), util.GetPathPrefix(c.Nodes[0].DataPath), util.GetPathPrefix(c.Nodes[0].DepotPath)
 }
+
+// validateLabels checks the labels of the nodes and subclusters
+func (c *DatabaseConfig) validateLabels() error {
+	for _, n := range c.Nodes {
+		if err := util.ValidateLabels(n.Labels); err != nil {
+			return fmt.Errorf("invalid labels of node %s: %w", n.Name, err)
+		}
+	}
+	for _, sc := range c.Subclusters {
+		if err := util.ValidateLabels(sc.Labels); err != nil {
+			return fmt.Errorf("invalid labels of subcluster %s: %w", sc.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyLabels copies the labels of another config of the same database,
+// e.g., the current config file. The labels of the nodes are copied for the
+// nodes that are still in the database. The labels of all subclusters are
+// kept, as a subcluster can have no nodes, e.g., when it is paused.
+func (c *DatabaseConfig) copyLabels(other *DatabaseConfig) {
+	nodeLabels := other.getNodeLabels()
+	for _, n := range c.Nodes {
+		n.Labels = nodeLabels[n.Name]
+	}
+	c.Subclusters = other.Subclusters
+}
+
+// getNodeLabels returns the labels of the nodes, keyed by node name
+func (c *DatabaseConfig) getNodeLabels() map[string]map[string]string {
+	labels := make(map[string]map[string]string)
+	for _, n := range c.Nodes {
+		if len(n.Labels) > 0 {
+			labels[n.Name] = n.Labels
+		}
+	}
+	return labels
+}
+
+// getSubclusterLabels returns the labels of the subclusters, keyed by subcluster name
+func (c *DatabaseConfig) getSubclusterLabels() map[string]map[string]string {
+	labels := make(map[string]map[string]string)
+	for _, sc := range c.Subclusters {
+		if len(sc.Labels) > 0 {
+			labels[sc.Name] = sc.Labels
+		}
+	}
+	return labels
+}
@@ -90,6 +90,21 @@ func GenerateErrorFromResponse(resp string) error {
 	return &prob
 }
 
+// ParseProblem will try to parse a response string as a VProblem. Unlike
+// GenerateErrorFromResponse, it can be called on any response: the second
+// return value is false if the response is not a problem, which must at least
+// have a type and a title.
+func ParseProblem(resp string) (*VProblem, bool) {
+	prob := VProblem{}
+	if err := json.Unmarshal([]byte(resp), &prob); err != nil {
+		return nil, false
+	}
+	if prob.Type == "" || prob.Title == "" {
+		return nil, false
+	}
+	return &prob, true
+}
+
 // newProblemID will generate a ProblemID struct for use with VProblem
 func newProblemID(errType, title string, status int) ProblemID {
 	return ProblemID{
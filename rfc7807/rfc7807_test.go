@@ -16,6 +16,7 @@
 package rfc7807
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -110,3 +111,20 @@ func TestJSONExtractFailure(t *testing.T) {
 	assert.False(t, ok)
 	assert.Contains(t, err.Error(), "failed to unmarshal the rfc7807 response")
 }
+
+func TestParseProblem(t *testing.T) {
+	origProblem := New(CommunalAccessError).
+		WithDetail("communal endpoint is down").
+		WithHost("pod-4")
+	b, err := json.Marshal(origProblem)
+	assert.NoError(t, err)
+	problem, ok := ParseProblem(string(b))
+	assert.True(t, ok)
+	assert.True(t, reflect.DeepEqual(origProblem, problem))
+
+	// valid json that is not a problem
+	_, ok = ParseProblem(`{"detail": "some detail"}`)
+	assert.False(t, ok)
+	_, ok = ParseProblem("not json")
+	assert.False(t, ok)
+}
@@ -0,0 +1,138 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+// AccessTokenRefresher returns a new access token from the identity
+// provider. It is called when the HTTPS service rejects the current token,
+// e.g., because it expired during a long-running operation.
+type AccessTokenRefresher func() (string, error)
+
+// bearerToken holds the access token sent in the Authorization header of
+// the requests to the HTTPS endpoints. It is shared by the requests sent
+// in parallel, so that the token is only refreshed once.
+type bearerToken struct {
+	mu        sync.Mutex
+	token     string
+	tokenFile string
+	refresher AccessTokenRefresher
+}
+
+// get returns the current token, reading it from the token file, or getting
+// it from the refresher, the first time
+func (t *bearerToken) get() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != "" {
+		return t.token, nil
+	}
+	token, err := t.fetch()
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	return t.token, nil
+}
+
+// fetch gets a token from the token file if there is one, otherwise
+// from the refresher
+func (t *bearerToken) fetch() (string, error) {
+	if t.tokenFile != "" {
+		tokenBytes, err := os.ReadFile(t.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("fail to read the access token from file %q: %w", t.tokenFile, err)
+		}
+		token := strings.TrimSpace(string(tokenBytes))
+		if token == "" {
+			return "", fmt.Errorf("the access token file %q is empty", t.tokenFile)
+		}
+		return token, nil
+	}
+	if t.refresher != nil {
+		token, err := t.refresher()
+		if err != nil {
+			return "", fmt.Errorf("fail to get a new access token: %w", err)
+		}
+		if token == "" {
+			return "", errors.New("the access token refresher returned an empty token")
+		}
+		return token, nil
+	}
+	return "", errors.New("no access token is available")
+}
+
+// refresh replaces the rejected token with a new one. The token file is read
+// again, as an agent may have rotated it. It returns false if there is no
+// new token to retry with.
+func (t *bearerToken) refresh(rejectedToken string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != rejectedToken {
+		// another request already refreshed the token
+		return true, nil
+	}
+	if t.tokenFile == "" && t.refresher == nil {
+		return false, nil
+	}
+	token, err := t.fetch()
+	if err != nil {
+		return false, err
+	}
+	if token == rejectedToken {
+		return false, nil
+	}
+	t.token = token
+	return true, nil
+}
+
+// setAuthorizationHeader sets the Authorization header of the request to the
+// current token, and returns the token
+func (t *bearerToken) setAuthorizationHeader(req *http.Request) (string, error) {
+	token, err := t.get()
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return token, nil
+}
+
+// useAccessToken returns true if access token authentication is configured
+func (opt *DatabaseOptions) useAccessToken() bool {
+	return opt.AccessToken != "" || opt.TokenFile != "" || opt.AccessTokenRefresher != nil
+}
+
+// validateAccessToken checks the access token configuration, if any
+func (opt *DatabaseOptions) validateAccessToken() error {
+	if opt.AccessToken != "" && opt.TokenFile != "" {
+		return errors.New("cannot specify both an access token and an access token file")
+	}
+	if opt.TokenFile != "" {
+		return util.ValidateAbsPath(opt.TokenFile, "access token file")
+	}
+	return nil
+}
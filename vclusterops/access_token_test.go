@@ -0,0 +1,108 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAccessToken(t *testing.T) {
+	opt := DatabaseOptions{}
+	assert.False(t, opt.useAccessToken())
+	assert.NoError(t, opt.validateAccessToken())
+
+	opt.AccessToken = "token"
+	opt.TokenFile = "/tmp/token"
+	assert.ErrorContains(t, opt.validateAccessToken(), "cannot specify both")
+
+	opt.AccessToken = ""
+	assert.True(t, opt.useAccessToken())
+	assert.NoError(t, opt.validateAccessToken())
+
+	opt.TokenFile = "token"
+	assert.Error(t, opt.validateAccessToken())
+}
+
+func TestBearerTokenRefresh(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("first\n"), 0600))
+
+	token := bearerToken{tokenFile: tokenFile}
+	req := httptest.NewRequest(GetMethod, "https://vnode1:8443/v1/nodes", http.NoBody)
+	used, err := token.setAuthorizationHeader(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", used)
+	assert.Equal(t, "Bearer first", req.Header.Get("Authorization"))
+
+	// the file has not been rotated, there is no new token to retry with
+	refreshed, err := token.refresh("first")
+	assert.NoError(t, err)
+	assert.False(t, refreshed)
+
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("second\n"), 0600))
+	refreshed, err = token.refresh("first")
+	assert.NoError(t, err)
+	assert.True(t, refreshed)
+	// a request that used the old token does not refresh it again
+	refreshed, err = token.refresh("first")
+	assert.NoError(t, err)
+	assert.True(t, refreshed)
+	used, err = token.get()
+	assert.NoError(t, err)
+	assert.Equal(t, "second", used)
+
+	// without a file and a refresher, the token cannot be refreshed
+	token = bearerToken{token: "static"}
+	refreshed, err = token.refresh("static")
+	assert.NoError(t, err)
+	assert.False(t, refreshed)
+
+	calls := 0
+	token = bearerToken{token: "old", refresher: func() (string, error) {
+		calls++
+		return "new", nil
+	}}
+	refreshed, err = token.refresh("old")
+	assert.NoError(t, err)
+	assert.True(t, refreshed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAccessTokenPrecedence(t *testing.T) {
+	opt := DatabaseOptions{AccessToken: "token", AccessTokenRefresher: func() (string, error) {
+		return "fresh", nil
+	}}
+	certs := opt.buildHTTPSCerts()
+	assert.NotNil(t, certs.token)
+
+	request := hostHTTPRequest{Method: GetMethod, Token: certs.token}
+	usePassword, err := whetherUsePassword(&request)
+	assert.NoError(t, err)
+	assert.False(t, usePassword)
+
+	// the password takes precedence over the token
+	password := "secret"
+	request.Password = &password
+	usePassword, err = whetherUsePassword(&request)
+	assert.NoError(t, err)
+	assert.True(t, usePassword)
+}
@@ -18,7 +18,6 @@ package vclusterops
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/theckman/yacspin"
@@ -29,30 +28,16 @@ type adapterPool struct {
 	logger vlog.Printer
 	// map from host to HTTPAdapter
 	connections map[string]adapter
+	// maximum number of hosts to which requests are sent at the same time,
+	// 0 means no limit
+	maxConcurrentHosts int
 }
 
-var (
-	poolInstance adapterPool
-	once         sync.Once
-)
-
-// return a new instance of an adapterPool. The adapterPool cannot be shared
-// between Go routines. Otherwise, they will clobber each other state causing
-// HTTP request errors. It is the callers responsibility to ensure it doesn't
-// get shared.
-func getPoolInstance(logger vlog.Printer) adapterPool {
-	/* if once.Do(f) is called multiple times,
-	 * only the first call will invoke f,
-	 * even if f has a different value in each invocation.
-	 * Reference: https://pkg.go.dev/sync#Once
-	 */
-	once.Do(func() {
-		poolInstance = makeAdapterPool(logger)
-	})
-
-	return poolInstance
-}
-
+// makeAdapterPool returns a new instance of an adapterPool. The adapterPool
+// cannot be shared between Go routines. Otherwise, they will clobber each
+// other state causing HTTP request errors. Each op engine run owns its pool,
+// through the dispatcher of its exec context, so that several clusters can be
+// administered at the same time with different certs.
 func makeAdapterPool(logger vlog.Printer) adapterPool {
 	newAdapterPool := adapterPool{}
 	newAdapterPool.connections = make(map[string]adapter)
@@ -61,11 +46,16 @@ func makeAdapterPool(logger vlog.Printer) adapterPool {
 }
 
 type adapterToRequest struct {
+	host    string
 	adapter adapter
 	request hostHTTPRequest
 }
 
-func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *yacspin.Spinner) error {
+// sendRequest sends the requests of httpRequest to their hosts in parallel and
+// waits for all the results. If tracer is set, a span is started for each host
+// request, as a child of the span in traceCtx.
+func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *yacspin.Spinner,
+	tracer Tracer, traceCtx context.Context) error {
 	// build a collection of adapter to request
 	// we need this step as a host may not be in the pool
 	// in that case, we should not proceed
@@ -76,7 +66,7 @@ func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *y
 		if !ok {
 			return fmt.Errorf("host %s is not found in the adapter pool", host)
 		}
-		ar := adapterToRequest{adapter: adpt, request: request}
+		ar := adapterToRequest{host: host, adapter: adpt, request: request}
 		adapterToRequestCollection = append(adapterToRequestCollection, ar)
 	}
 
@@ -94,12 +84,22 @@ func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *y
 		defer cancelCtx()
 	}
 
+	hostSpans := make(map[string]Span)
+	requestChannel := make(chan adapterToRequest, hostCount)
 	for i := 0; i < len(adapterToRequestCollection); i++ {
 		ar := adapterToRequestCollection[i]
-		// send request to the hosts
-		// each goroutine will handle one request for one host
-		request := ar.request
-		go ar.adapter.sendRequest(&request, resultChannel)
+		_, hostSpans[ar.host] = startSpan(traceCtx, tracer, httpRequest.Name+" request",
+			map[string]string{"host": ar.host, "method": ar.request.Method, "endpoint": ar.request.Endpoint})
+		requestChannel <- ar
+	}
+	close(requestChannel)
+
+	// send requests to the hosts
+	// each goroutine will handle the requests of several hosts, one at a time,
+	// so that no more than maxConcurrentHosts requests are in flight
+	workerCount := pool.getWorkerCount(hostCount)
+	for i := 0; i < workerCount; i++ {
+		go sendRequestsFromChannel(requestChannel, resultChannel)
 	}
 
 	// handle results
@@ -110,6 +110,9 @@ func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *y
 		result, ok := <-resultChannel
 		if ok {
 			httpRequest.ResultCollection[result.host] = result
+			if span, found := hostSpans[result.host]; found {
+				endSpan(span, result.err)
+			}
 		}
 	}
 	close(resultChannel)
@@ -117,6 +120,24 @@ func (pool *adapterPool) sendRequest(httpRequest *clusterHTTPRequest, spinner *y
 	return nil
 }
 
+// getWorkerCount returns the number of goroutines that send the requests
+// to hostCount hosts
+func (pool *adapterPool) getWorkerCount(hostCount int) int {
+	if pool.maxConcurrentHosts > 0 && pool.maxConcurrentHosts < hostCount {
+		return pool.maxConcurrentHosts
+	}
+	return hostCount
+}
+
+// sendRequestsFromChannel sends the requests of requestChannel one after the
+// other, until the channel is closed and drained
+func sendRequestsFromChannel(requestChannel <-chan adapterToRequest, resultChannel chan<- hostHTTPResult) {
+	for ar := range requestChannel {
+		request := ar.request
+		ar.adapter.sendRequest(&request, resultChannel)
+	}
+}
+
 // progressCheck checks whether a step (operation) has been completed.
 // Elapsed time of the step in seconds will be displayed.
 func progressCheck(ctx context.Context, name string, logger vlog.Printer, spinner *yacspin.Spinner) {
@@ -0,0 +1,85 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// mockConcurrencyAdapter records the highest number of requests
+// that are in flight at the same time
+type mockConcurrencyAdapter struct {
+	host        string
+	mu          *sync.Mutex
+	inFlight    *int
+	maxInFlight *int
+}
+
+func (a *mockConcurrencyAdapter) sendRequest(_ *hostHTTPRequest, resultChannel chan<- hostHTTPResult) {
+	a.mu.Lock()
+	*a.inFlight++
+	if *a.inFlight > *a.maxInFlight {
+		*a.maxInFlight = *a.inFlight
+	}
+	a.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	a.mu.Lock()
+	*a.inFlight--
+	a.mu.Unlock()
+	resultChannel <- hostHTTPResult{host: a.host, status: SUCCESS}
+}
+
+func (a *mockConcurrencyAdapter) generateResult(_ *http.Response) hostHTTPResult {
+	return hostHTTPResult{host: a.host, status: SUCCESS}
+}
+
+func TestSendRequestMaxConcurrentHosts(t *testing.T) {
+	const hostCount = 10
+	for _, maxConcurrentHosts := range []int{0, 1, 3} {
+		var mu sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		pool := makeAdapterPool(vlog.Printer{})
+		pool.maxConcurrentHosts = maxConcurrentHosts
+		httpRequest := clusterHTTPRequest{Name: "MockOp"}
+		httpRequest.RequestCollection = make(map[string]hostHTTPRequest)
+		for i := 0; i < hostCount; i++ {
+			host := fmt.Sprintf("192.168.1.%d", i)
+			pool.connections[host] = &mockConcurrencyAdapter{host: host, mu: &mu,
+				inFlight: &inFlight, maxInFlight: &maxInFlight}
+			httpRequest.RequestCollection[host] = hostHTTPRequest{Method: GetMethod}
+		}
+
+		err := pool.sendRequest(&httpRequest, nil, nil, context.Background())
+		assert.NoError(t, err)
+		// every host must have a result
+		assert.Len(t, httpRequest.ResultCollection, hostCount)
+		if maxConcurrentHosts > 0 {
+			assert.LessOrEqual(t, maxInFlight, maxConcurrentHosts)
+		} else {
+			assert.LessOrEqual(t, maxInFlight, hostCount)
+		}
+	}
+}
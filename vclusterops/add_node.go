@@ -17,10 +17,13 @@ package vclusterops
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
+	"golang.org/x/exp/maps"
 )
 
 // VAddNodeOptions represents the available options for VAddNode.
@@ -41,11 +44,29 @@ type VAddNodeOptions struct {
 	// If the path is set, the NMA will store the Vertica start command at the path
 	// instead of executing it. This is useful in containerized environments where
 	// you may not want to have both the NMA and Vertica server in the same container.
-	// This feature requires version 24.2.0+.
+	// This feature requires version 24.2.0+, older servers start the nodes without it.
 	StartUpConf string
 	// Names of the existing nodes in the cluster. This option can be
 	// used to remove partially added nodes from catalog.
 	ExpectedNodeNames []string
+	// Skip the port reachability check between the new hosts and the existing hosts
+	SkipPortCheck bool
+	// The client port of the database, checked for reachability with the other
+	// ports unless SkipPortCheck is set
+	ClientPort int
+	// If true, the new hosts that are already in the database are skipped
+	// instead of failing the operation, as long as their nodes are in the
+	// requested subcluster and use the requested catalog path. This allows
+	// callers to safely retry an add_node. The directories left by a previous
+	// attempt are reused if they have the correct owner and permissions.
+	Idempotent bool
+	// Change the owner of the existing directories of the new nodes that
+	// have a wrong owner. Only used with Idempotent.
+	FixDirectoryOwnership bool
+	// If true, when add_node fails midway, the directories it created are
+	// deleted and the new nodes are removed from the catalog, so the next
+	// attempt starts clean
+	ForceCleanupOnFailure bool
 }
 
 func VAddNodeOptionsFactory() VAddNodeOptions {
@@ -60,6 +81,7 @@ func (options *VAddNodeOptions) setDefaultValues() {
 	options.DatabaseOptions.setDefaultValues()
 
 	options.SkipRebalanceShards = new(bool)
+	options.ClientPort = util.DefaultClientPort
 }
 
 func (options *VAddNodeOptions) validateEonOptions() error {
@@ -78,6 +100,10 @@ func (options *VAddNodeOptions) validateRequiredOptions(logger vlog.Printer) err
 }
 
 func (options *VAddNodeOptions) validateExtraOptions() error {
+	if options.FixDirectoryOwnership && !options.Idempotent {
+		return fmt.Errorf("fixing directory ownership is only supported in idempotent mode")
+	}
+
 	// data prefix
 	if options.DataPrefix != "" {
 		return util.ValidateRequiredAbsPath(options.DataPrefix, "data path")
@@ -136,10 +162,17 @@ func (options *VAddNodeOptions) validateAnalyzeOptions(logger vlog.Printer) erro
 
 // VAddNode adds one or more nodes to an existing database.
 // It returns a VCoordinationDatabase that contains catalog information and any error encountered.
-func (vcc VClusterCommands) VAddNode(options *VAddNodeOptions) (VCoordinationDatabase, error) {
+func (vcc VClusterCommands) VAddNode(options *VAddNodeOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandAddNode, &options.DatabaseOptions, options, time.Now(), &err)
 	vdb := makeVCoordinationDatabase()
 
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return vdb, err
+	}
+
+	// on hosts with several network interfaces, use their addresses in the given subnet
+	options.NewHosts, err = vcc.selectSubnetAddresses(&options.DatabaseOptions, options.NewHosts)
 	if err != nil {
 		return vdb, err
 	}
@@ -174,6 +207,18 @@ func (vcc VClusterCommands) VAddNode(options *VAddNodeOptions) (VCoordinationDat
 		return vdb, err
 	}
 
+	// skip the hosts that were added by a previous attempt
+	if options.Idempotent {
+		err = options.skipExistingHosts(&vdb, vcc.Log)
+		if err != nil {
+			return vdb, err
+		}
+		if len(options.NewHosts) == 0 {
+			vcc.Log.PrintInfo("All hosts are already in database %s, nothing to add", vdb.Name)
+			return vdb, nil
+		}
+	}
+
 	// add_node is aborted if requirements are not met.
 	// Here we check whether the nodes being added already exist
 	err = checkAddNodeRequirements(&vdb, options.NewHosts)
@@ -181,6 +226,10 @@ func (vcc VClusterCommands) VAddNode(options *VAddNodeOptions) (VCoordinationDat
 		return vdb, err
 	}
 
+	// the nodes in the catalog before adding the new ones, which
+	// are the ones to keep if we need to clean up after a failure
+	existingNodeNames := maps.Keys(vdb.genNodeNameToHostMap())
+
 	err = vdb.addHosts(options.NewHosts, options.SCName)
 	if err != nil {
 		return vdb, err
@@ -191,14 +240,42 @@ func (vcc VClusterCommands) VAddNode(options *VAddNodeOptions) (VCoordinationDat
 		return vdb, fmt.Errorf("fail to produce add node instructions, %w", err)
 	}
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	if runError := clusterOpEngine.run(vcc.Log); runError != nil {
-		return vdb, fmt.Errorf("fail to complete add node operation, %w", runError)
+		runError = fmt.Errorf("fail to complete add node operation, %w", runError)
+		if options.ForceCleanupOnFailure {
+			cleanupError := vcc.cleanupAddNodeFailure(options, existingNodeNames, instructions, &certs)
+			runError = joinCleanupError(runError, cleanupError)
+		}
+		return vdb, runError
 	}
 	return vdb, nil
 }
 
+// cleanupAddNodeFailure stops the new nodes, deletes the directories created for
+// them and removes them from the catalog, after a failed add_node
+func (vcc VClusterCommands) cleanupAddNodeFailure(options *VAddNodeOptions, existingNodeNames []string,
+	instructions []clusterOp, certs *httpsCerts) error {
+	err := vcc.cleanupAfterFailure(options.NewHosts, instructions, certs)
+	if err != nil {
+		return err
+	}
+
+	// get the catalog as it is after the failure, and trim the nodes
+	// that were not there before add_node
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the database to remove the new nodes from the catalog, %w", err)
+	}
+	if len(vdb.HostNodeMap) == len(existingNodeNames) {
+		return nil
+	}
+	options.ExpectedNodeNames = existingNodeNames
+	return vcc.trimNodesInCatalog(&vdb, options)
+}
+
 // checkAddNodeRequirements returns an error if at least one of the nodes
 // to add already exists in db.
 func checkAddNodeRequirements(vdb *VCoordinationDatabase, hostsToAdd []string) error {
@@ -210,6 +287,39 @@ func checkAddNodeRequirements(vdb *VCoordinationDatabase, hostsToAdd []string) e
 	return nil
 }
 
+// skipExistingHosts removes from NewHosts the hosts that already exist in the
+// database. It returns an error if an existing node does not match the requested
+// configuration, because it cannot be the result of a previous add_node attempt.
+func (options *VAddNodeOptions) skipExistingHosts(vdb *VCoordinationDatabase, logger vlog.Printer) error {
+	var hostsToAdd []string
+	var existingHosts []string
+	for _, host := range options.NewHosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			hostsToAdd = append(hostsToAdd, host)
+			continue
+		}
+		if options.SCName != "" && vnode.Subcluster != options.SCName {
+			return fmt.Errorf("host %s already exists in subcluster %s, not in the requested subcluster %s",
+				host, vnode.Subcluster, options.SCName)
+		}
+		if options.CatalogPrefix != "" {
+			catalogPath := filepath.Join(options.CatalogPrefix, vdb.Name)
+			if !strings.HasPrefix(vnode.CatalogPath, catalogPath+"/") {
+				return fmt.Errorf("host %s already exists with catalog path %s, which is not under the requested catalog path %s",
+					host, vnode.CatalogPath, options.CatalogPrefix)
+			}
+		}
+		existingHosts = append(existingHosts, host)
+	}
+
+	if len(existingHosts) > 0 {
+		logger.PrintInfo("Hosts %v are already in database %s, skip adding them", existingHosts, vdb.Name)
+	}
+	options.NewHosts = hostsToAdd
+	return nil
+}
+
 // completeVDBSetting sets some VCoordinationDatabase fields we cannot get yet
 // from the https endpoints. We set those fields from options.
 func (options *VAddNodeOptions) completeVDBSetting(vdb *VCoordinationDatabase) error {
@@ -300,8 +410,8 @@ func (vcc VClusterCommands) trimNodesInCatalog(vdb *VCoordinationDatabase,
 		instructions = append(instructions, &httpsDropNodeOp)
 	}
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	err := clusterOpEngine.run(vcc.Log)
 	if err != nil {
 		vcc.Log.Error(err, "fail to trim nodes from catalog, %v")
@@ -321,12 +431,15 @@ func (vcc VClusterCommands) trimNodesInCatalog(vdb *VCoordinationDatabase,
 // The generated instructions will later perform the following operations necessary
 // for a successful add_node:
 //   - Check NMA connectivity
+//   - Check NMA version compatibility
+//   - Check port reachability between hosts
 //   - If we have subcluster in the input, check if the subcluster exists. If not, we stop.
 //     If we do not have a subcluster in the input, fetch the current default subcluster name
 //   - Check NMA versions
 //   - Prepare directories
 //   - Get network profiles
 //   - Create the new node
+//   - Realign control nodes (large cluster only)
 //   - Reload spread
 //   - Transfer config files to the new node
 //   - Start the new node
@@ -345,7 +458,13 @@ func (vcc VClusterCommands) produceAddNodeInstructions(vdb *VCoordinationDatabas
 	password := options.Password
 
 	nmaHealthOp := makeNMAHealthOp(vdb.HostList)
-	instructions = append(instructions, &nmaHealthOp)
+	nmaVersionOp := makeNMAVersionOp(vdb.HostList)
+	instructions = append(instructions, &nmaHealthOp, &nmaVersionOp)
+
+	if !options.SkipPortCheck {
+		nmaCheckPortsOp := makeNMACheckPortsOp(vdb.HostList, options.ClientPort)
+		instructions = append(instructions, &nmaCheckPortsOp)
+	}
 
 	if vdb.IsEon {
 		httpsFindSubclusterOp, e := makeHTTPSFindSubclusterOp(
@@ -364,8 +483,9 @@ func (vcc VClusterCommands) produceAddNodeInstructions(vdb *VCoordinationDatabas
 	// this is a copy of the original HostNodeMap that only
 	// contains the hosts to add.
 	newHostNodeMap := vdb.copyHostNodeMap(options.NewHosts)
-	nmaPrepareDirectoriesOp, err := makeNMAPrepareDirectoriesOp(newHostNodeMap,
-		options.ForceRemoval /*force cleanup*/, false /*for db revive*/)
+	nmaPrepareDirectoriesOp, err := makeNMAPrepareDirectoriesOpWithReuse(newHostNodeMap,
+		options.ForceRemoval /*force cleanup*/, false, /*for db revive*/
+		options.Idempotent /*reuse existing*/, options.FixDirectoryOwnership)
 	if err != nil {
 		return instructions, err
 	}
@@ -387,6 +507,18 @@ func (vcc VClusterCommands) produceAddNodeInstructions(vdb *VCoordinationDatabas
 		&nmaPrepareDirectoriesOp,
 		&nmaNetworkProfileOp,
 		&httpsCreateNodeOp,
+	)
+	// in a large cluster, the new nodes need control nodes, which are
+	// assigned before spread is reloaded
+	if vdb.isLargeCluster(allExistingHosts) {
+		httpsRealignControlNodesOp, e := makeHTTPSRealignControlNodesOp(initiatorHost, usePassword,
+			username, password, util.DefaultControlSetSize)
+		if e != nil {
+			return instructions, e
+		}
+		instructions = append(instructions, &httpsRealignControlNodesOp)
+	}
+	instructions = append(instructions,
 		&httpsReloadSpreadOp,
 		&httpsRestartUpCommandOp,
 	)
@@ -0,0 +1,101 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestSkipExistingHosts(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = "test_db"
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.0.2.1"] = &VCoordinationNode{
+		Name:        "v_test_db_node0001",
+		Address:     "192.0.2.1",
+		CatalogPath: "/catalog/test_db/v_test_db_node0001_catalog/Catalog",
+		Subcluster:  "sc1",
+	}
+
+	options := VAddNodeOptionsFactory()
+	options.NewHosts = []string{"192.0.2.1", "192.0.2.2"}
+	options.SCName = "sc1"
+	options.CatalogPrefix = "/catalog"
+	err := options.skipExistingHosts(&vdb, vlog.Printer{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.2"}, options.NewHosts)
+
+	// existing node in another subcluster
+	options.NewHosts = []string{"192.0.2.1"}
+	options.SCName = "sc2"
+	err = options.skipExistingHosts(&vdb, vlog.Printer{})
+	assert.ErrorContains(t, err, "not in the requested subcluster sc2")
+
+	// existing node with another catalog path
+	options.SCName = "sc1"
+	options.CatalogPrefix = "/other"
+	err = options.skipExistingHosts(&vdb, vlog.Printer{})
+	assert.ErrorContains(t, err, "not under the requested catalog path /other")
+}
+
+func TestAddNodeAddressFamily(t *testing.T) {
+	options := VAddNodeOptionsFactory()
+	options.RawHosts = []string{"2001:db8::1", "2001:db8::2"}
+
+	// the new hosts can have another family than the existing hosts
+	options.NewHosts = []string{"192.168.1.103", "2001:db8::3"}
+	assert.NoError(t, options.analyzeOptions())
+	assert.Equal(t, []string{"192.168.1.103", "2001:db8::3"}, options.NewHosts)
+	assert.Equal(t, []string{"2001:db8::1", "2001:db8::2"}, options.Hosts)
+}
+
+func TestAddNodeCheckPortsOp(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = "test_db"
+	vdb.HostList = []string{"192.0.2.1", "192.0.2.2"}
+	vdb.HostNodeMap = makeVHostNodeMap()
+	for i, host := range vdb.HostList {
+		vdb.HostNodeMap[host] = &VCoordinationNode{
+			Name:    fmt.Sprintf("v_test_db_node%04d", i+1),
+			Address: host,
+		}
+	}
+
+	options := VAddNodeOptionsFactory()
+	options.Initiator = "192.0.2.1"
+	options.NewHosts = []string{"192.0.2.2"}
+	options.ClientPort = 15433
+	vcc := VClusterCommands{}
+	instructions, err := vcc.produceAddNodeInstructions(&vdb, &options)
+	assert.NoError(t, err)
+
+	// the configured client port is checked, not the default one
+	var checkPortsOp *nmaCheckPortsOp
+	for _, instruction := range instructions {
+		if op, ok := instruction.(*nmaCheckPortsOp); ok {
+			checkPortsOp = op
+		}
+	}
+	if assert.NotNil(t, checkPortsOp) {
+		assert.Contains(t, checkPortsOp.ports, 15433)
+		assert.NotContains(t, checkPortsOp.ports, util.DefaultClientPort)
+	}
+}
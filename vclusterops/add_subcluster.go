@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -187,7 +188,8 @@ func (options *VAddSubclusterOptions) validateAnalyzeOptions(logger vlog.Printer
 // It returns any error encountered.
 //
 //nolint:dupl
-func (vcc VClusterCommands) VAddSubcluster(options *VAddSubclusterOptions) error {
+func (vcc VClusterCommands) VAddSubcluster(options *VAddSubclusterOptions) (err error) {
+	defer vcc.audit(commandAddSubcluster, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Validate Options
 	 *   - Produce Instructions
@@ -196,7 +198,7 @@ func (vcc VClusterCommands) VAddSubcluster(options *VAddSubclusterOptions) error
 	 */
 
 	// validate and analyze all options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -207,8 +209,8 @@ func (vcc VClusterCommands) VAddSubcluster(options *VAddSubclusterOptions) error
 	}
 
 	// Create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
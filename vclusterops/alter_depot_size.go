@@ -0,0 +1,157 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VAlterDepotSizeOptions struct {
+	DatabaseOptions
+	// The new depot size, e.g., 40% or 100G
+	DepotSize string
+	// Only change the depot size of the nodes in this subcluster.
+	// If empty, the depot size of all nodes is changed.
+	SCName string
+}
+
+func VAlterDepotSizeOptionsFactory() VAlterDepotSizeOptions {
+	options := VAlterDepotSizeOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VAlterDepotSizeOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VAlterDepotSizeOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandAlterDepotSize, logger)
+	if err != nil {
+		return err
+	}
+	if options.DepotSize == "" {
+		return fmt.Errorf("must specify a depot size")
+	}
+	validDepotSize, err := validateDepotSize(options.DepotSize)
+	if !validDepotSize {
+		return err
+	}
+	return nil
+}
+
+func (options *VAlterDepotSizeOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (options *VAlterDepotSizeOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VAlterDepotSize changes the depot size of the up nodes of an Eon database,
+// or of a subcluster if options.SCName is set. It returns the depot size of
+// each altered node, as reported by the database.
+func (vcc VClusterCommands) VAlterDepotSize(options *VAlterDepotSizeOptions) (_ map[string]string, err error) {
+	defer vcc.audit(commandAlterDepotSize, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes to alter from the running database
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !vdb.IsEon {
+		return nil, fmt.Errorf("depot size can only be changed in an Eon database")
+	}
+
+	hosts, downNodes, err := options.getHostsToAlter(&vdb)
+	if err != nil {
+		return nil, err
+	}
+	if len(downNodes) > 0 {
+		vcc.Log.PrintWarning("The depot size of the down nodes %v will not be changed", downNodes)
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("cannot find any up node to alter the depot size of")
+	}
+
+	nodeDepotSizes := make(map[string]string)
+	httpsAlterDepotSizeOp, err := makeHTTPSAlterDepotSizeOp(vdb.HostNodeMap, hosts, options.DepotSize,
+		options.usePassword, options.UserName, options.Password, nodeDepotSizes)
+	if err != nil {
+		return nil, err
+	}
+	instructions := []clusterOp{&httpsAlterDepotSizeOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nodeDepotSizes, fmt.Errorf("fail to alter depot size: %w", err)
+	}
+	return nodeDepotSizes, nil
+}
+
+// getHostsToAlter returns the sorted hosts of the up nodes whose depot size
+// must be changed, and the names of the down nodes that are skipped
+func (options *VAlterDepotSizeOptions) getHostsToAlter(vdb *VCoordinationDatabase) (hosts, downNodes []string, err error) {
+	scFound := false
+	for host, vnode := range vdb.HostNodeMap {
+		if options.SCName != "" && vnode.Subcluster != options.SCName {
+			continue
+		}
+		scFound = true
+		if vnode.State == util.NodeUpState {
+			hosts = append(hosts, host)
+		} else {
+			downNodes = append(downNodes, vnode.Name)
+		}
+	}
+	if options.SCName != "" && !scFound {
+		return nil, nil, fmt.Errorf("cannot find subcluster %s in database %s", options.SCName, options.DBName)
+	}
+	sort.Strings(hosts)
+	sort.Strings(downNodes)
+	return hosts, downNodes, nil
+}
@@ -0,0 +1,53 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestGetHostsToAlterDepotSize(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.0.2.1"] = &VCoordinationNode{Name: "v_test_db_node0001", Subcluster: "sc1",
+		State: util.NodeUpState}
+	vdb.HostNodeMap["192.0.2.2"] = &VCoordinationNode{Name: "v_test_db_node0002", Subcluster: "sc1",
+		State: util.NodeDownState}
+	vdb.HostNodeMap["192.0.2.3"] = &VCoordinationNode{Name: "v_test_db_node0003", Subcluster: "sc2",
+		State: util.NodeUpState}
+
+	// all nodes
+	options := VAlterDepotSizeOptionsFactory()
+	hosts, downNodes, err := options.getHostsToAlter(&vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.3"}, hosts)
+	assert.Equal(t, []string{"v_test_db_node0002"}, downNodes)
+
+	// nodes of a subcluster
+	options.SCName = "sc2"
+	hosts, downNodes, err = options.getHostsToAlter(&vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.3"}, hosts)
+	assert.Empty(t, downNodes)
+
+	// unknown subcluster
+	options.SCName = "sc3"
+	_, _, err = options.getHostsToAlter(&vdb)
+	assert.ErrorContains(t, err, "cannot find subcluster sc3")
+}
@@ -0,0 +1,187 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type NodeType string
+
+const (
+	NodeTypePermanent NodeType = "permanent"
+	NodeTypeEphemeral NodeType = "ephemeral"
+	NodeTypeStandby   NodeType = "standby"
+)
+
+func (n NodeType) IsValid() bool {
+	switch n {
+	case NodeTypePermanent, NodeTypeEphemeral, NodeTypeStandby:
+		return true
+	}
+	return false
+}
+
+type VAlterNodeTypeOptions struct {
+	DatabaseOptions
+	// Hosts of the nodes to change the type of
+	HostsToAlter []string
+	// The new type of the nodes
+	NodeType NodeType
+}
+
+func VAlterNodeTypeOptionsFactory() VAlterNodeTypeOptions {
+	options := VAlterNodeTypeOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VAlterNodeTypeOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandAlterNodeType, logger)
+	if err != nil {
+		return err
+	}
+	if len(options.HostsToAlter) == 0 {
+		return fmt.Errorf("must specify at least one host to alter")
+	}
+	if !options.NodeType.IsValid() {
+		return fmt.Errorf("invalid node type %q: must be '%s', '%s' or '%s'", options.NodeType,
+			NodeTypePermanent, NodeTypeEphemeral, NodeTypeStandby)
+	}
+	return nil
+}
+
+// analyzeOptions will modify some options based on what is chosen
+func (options *VAlterNodeTypeOptions) analyzeOptions() (err error) {
+	options.HostsToAlter, err = util.ResolveRawHostsToAddresses(options.HostsToAlter, options.IPv6)
+	if err != nil {
+		return err
+	}
+	if len(options.RawHosts) > 0 {
+		// resolve RawHosts to be IP addresses
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (options *VAlterNodeTypeOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VAlterNodeType changes the type of one or more nodes to permanent, ephemeral
+// or standby. Changing a node to ephemeral or standby removes it from the
+// quorum, so the operation is refused if the database would lose its quorum.
+func (vcc VClusterCommands) VAlterNodeType(options *VAlterNodeTypeOptions) (err error) {
+	defer vcc.audit(commandAlterNodeType, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	err = checkAlterNodeTypeRequirements(&vdb, options)
+	if err != nil {
+		return err
+	}
+
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, options.HostsToAlter)
+	if err != nil {
+		return err
+	}
+
+	var instructions []clusterOp
+	for _, host := range options.HostsToAlter {
+		vnode, e := vdb.HostNodeMap.getNode(host)
+		if e != nil {
+			return e
+		}
+		httpsAlterNodeTypeOp, e := makeHTTPSAlterNodeTypeOp(vnode.Name, options.NodeType,
+			[]string{initiator}, options.usePassword, options.UserName, options.Password)
+		if e != nil {
+			return e
+		}
+		instructions = append(instructions, &httpsAlterNodeTypeOp)
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to change the type of nodes %v to %s: %w", options.HostsToAlter, options.NodeType, err)
+	}
+	return nil
+}
+
+// checkAlterNodeTypeRequirements validates the nodes to alter exist and are
+// not sandboxed. When the nodes leave the quorum, i.e., they become ephemeral
+// or standby, the other up primary nodes must still be more than half of the
+// primary nodes.
+func checkAlterNodeTypeRequirements(vdb *VCoordinationDatabase, options *VAlterNodeTypeOptions) error {
+	if vdb.IsEon && options.NodeType == NodeTypeStandby {
+		return errors.New("standby nodes are only supported in Enterprise mode")
+	}
+
+	for _, host := range options.HostsToAlter {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			return fmt.Errorf("host %s does not belong to database %s", host, options.DBName)
+		}
+		if vnode.Sandbox != "" {
+			return fmt.Errorf("node %s (%s) is sandboxed and its type cannot be changed", vnode.Name, host)
+		}
+	}
+
+	if options.NodeType == NodeTypePermanent {
+		return nil
+	}
+
+	primaryCount := 0
+	remainingUpPrimaryCount := 0
+	for host, vnode := range vdb.HostNodeMap {
+		if !vnode.IsPrimary || vnode.Sandbox != "" {
+			continue
+		}
+		primaryCount++
+		if vnode.State == util.NodeUpState && !util.StringInArray(host, options.HostsToAlter) {
+			remainingUpPrimaryCount++
+		}
+	}
+	if remainingUpPrimaryCount*2 <= primaryCount {
+		return fmt.Errorf("changing the type of nodes %v to %s would leave %d up primary nodes out of %d, "+
+			"the database would lose its quorum", options.HostsToAlter, options.NodeType,
+			remainingUpPrimaryCount, primaryCount)
+	}
+	return nil
+}
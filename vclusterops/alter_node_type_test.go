@@ -0,0 +1,65 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestCheckAlterNodeTypeRequirements(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	nodes := []VCoordinationNode{
+		{Name: "v_db_node0001", Address: "192.168.1.101", IsPrimary: true, State: util.NodeUpState},
+		{Name: "v_db_node0002", Address: "192.168.1.102", IsPrimary: true, State: util.NodeUpState},
+		{Name: "v_db_node0003", Address: "192.168.1.103", IsPrimary: true, State: util.NodeDownState},
+		{Name: "v_db_node0004", Address: "192.168.1.104", Sandbox: "sand1", State: util.NodeUpState},
+	}
+	for i := range nodes {
+		vdb.HostNodeMap[nodes[i].Address] = &nodes[i]
+	}
+	options := VAlterNodeTypeOptionsFactory()
+	options.DBName = "test_db"
+
+	// the down node can leave the quorum
+	options.HostsToAlter = []string{"192.168.1.103"}
+	options.NodeType = NodeTypeEphemeral
+	assert.NoError(t, checkAlterNodeTypeRequirements(&vdb, &options))
+
+	// an up node cannot: only one of three primary nodes would be up
+	options.HostsToAlter = []string{"192.168.1.102"}
+	err := checkAlterNodeTypeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "would lose its quorum")
+
+	// making a node permanent has no impact on the quorum
+	options.NodeType = NodeTypePermanent
+	assert.NoError(t, checkAlterNodeTypeRequirements(&vdb, &options))
+
+	// unknown and sandboxed hosts are rejected
+	options.HostsToAlter = []string{"192.168.1.105"}
+	assert.ErrorContains(t, checkAlterNodeTypeRequirements(&vdb, &options), "does not belong to database")
+	options.HostsToAlter = []string{"192.168.1.104"}
+	assert.ErrorContains(t, checkAlterNodeTypeRequirements(&vdb, &options), "is sandboxed")
+
+	// standby nodes do not exist in Eon mode
+	vdb.IsEon = true
+	options.NodeType = NodeTypeStandby
+	options.HostsToAlter = []string{"192.168.1.103"}
+	assert.ErrorContains(t, checkAlterNodeTypeRequirements(&vdb, &options), "only supported in Enterprise mode")
+}
@@ -0,0 +1,222 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// StorageLocationAction is the change VAlterStorageLocation makes to a storage location
+type StorageLocationAction string
+
+const (
+	StorageLocationAdd        StorageLocationAction = "add"
+	StorageLocationRetire     StorageLocationAction = "retire"
+	StorageLocationAlterUsage StorageLocationAction = "alter_usage"
+)
+
+// the usages a storage location can have
+var storageLocationUsages = []string{"DATA", "TEMP", "DATA,TEMP", "DEPOT", "USER"}
+
+type VAlterStorageLocationOptions struct {
+	DatabaseOptions
+	Action StorageLocationAction
+	// Absolute path of the storage location
+	LocationPath string
+	// Usage of the storage location, one of DATA, TEMP, DATA,TEMP, DEPOT or USER.
+	// Required to add a location or alter its usage.
+	Usage string
+	// The hosts whose nodes the change applies to.
+	// If empty, the change applies to all nodes.
+	LocationHosts []string
+}
+
+func VAlterStorageLocationOptionsFactory() VAlterStorageLocationOptions {
+	options := VAlterStorageLocationOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VAlterStorageLocationOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VAlterStorageLocationOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandStorageLocation, logger)
+	if err != nil {
+		return err
+	}
+
+	err = util.ValidateRequiredAbsPath(options.LocationPath, "storage location path")
+	if err != nil {
+		return err
+	}
+
+	switch options.Action {
+	case StorageLocationAdd, StorageLocationAlterUsage:
+		options.Usage = strings.ToUpper(strings.ReplaceAll(options.Usage, " ", ""))
+		if !util.StringInArray(options.Usage, storageLocationUsages) {
+			return fmt.Errorf("invalid storage location usage %q, must be one of %v", options.Usage, storageLocationUsages)
+		}
+	case StorageLocationRetire:
+		if options.Usage != "" {
+			return fmt.Errorf("usage cannot be specified to retire a storage location")
+		}
+	default:
+		return fmt.Errorf("invalid storage location action %q", options.Action)
+	}
+	return nil
+}
+
+func (options *VAlterStorageLocationOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	if len(options.LocationHosts) > 0 {
+		options.LocationHosts, err = util.ResolveRawHostsToAddresses(options.LocationHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	options.LocationPath = util.GetCleanPath(options.LocationPath)
+	return nil
+}
+
+func (options *VAlterStorageLocationOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VAlterStorageLocation adds a storage location, retires one, or alters its
+// usage on the nodes of options.LocationHosts, or on all nodes if empty.
+// When a location is added, its directory is created on the hosts first.
+func (vcc VClusterCommands) VAlterStorageLocation(options *VAlterStorageLocationOptions) (err error) {
+	defer vcc.audit(commandStorageLocation, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	hosts, err := options.getLocationHosts(&vdb)
+	if err != nil {
+		return err
+	}
+
+	instructions, err := vcc.produceAlterStorageLocationInstructions(options, &vdb, hosts)
+	if err != nil {
+		return fmt.Errorf("fail to produce instructions, %w", err)
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to %s storage location %s: %w", options.Action, options.LocationPath, err)
+	}
+	return nil
+}
+
+// produceAlterStorageLocationInstructions will build a list of instructions to execute for
+// the storage_location command.
+//
+// The generated instructions will later perform the following operations:
+//   - Check NMA connectivity
+//   - Create the storage location directory (add only)
+//   - Call the https storage location endpoint on each node
+func (vcc VClusterCommands) produceAlterStorageLocationInstructions(options *VAlterStorageLocationOptions,
+	vdb *VCoordinationDatabase, hosts []string) ([]clusterOp, error) {
+	var instructions []clusterOp
+
+	if options.Action == StorageLocationAdd {
+		nmaHealthOp := makeNMAHealthOp(hosts)
+		nmaPrepareStorageLocationOp, err := makeNMAPrepareStorageLocationOp(hosts, options.LocationPath)
+		if err != nil {
+			return instructions, err
+		}
+		instructions = append(instructions, &nmaHealthOp, &nmaPrepareStorageLocationOp)
+	}
+
+	httpsStorageLocationOp, err := makeHTTPSStorageLocationOp(hosts, vdb.HostNodeMap, options.Action,
+		options.LocationPath, options.Usage, options.usePassword, options.UserName, options.Password)
+	if err != nil {
+		return instructions, err
+	}
+	instructions = append(instructions, &httpsStorageLocationOp)
+	return instructions, nil
+}
+
+// getLocationHosts returns the sorted hosts the storage location change applies to.
+// All of them must be up nodes of the database.
+func (options *VAlterStorageLocationOptions) getLocationHosts(vdb *VCoordinationDatabase) ([]string, error) {
+	hosts := options.LocationHosts
+	if len(hosts) == 0 {
+		hosts = vdb.HostList
+	}
+
+	var missingHosts, downHosts []string
+	for _, host := range hosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			missingHosts = append(missingHosts, host)
+			continue
+		}
+		if vnode.State != util.NodeUpState {
+			downHosts = append(downHosts, host)
+		}
+	}
+	if len(missingHosts) > 0 {
+		return nil, &NodeNotFoundError{
+			Detail: fmt.Sprintf("hosts %v are not in database %s", missingHosts, options.DBName),
+			Nodes:  missingHosts,
+		}
+	}
+	if len(downHosts) > 0 {
+		return nil, fmt.Errorf("the nodes on hosts %v are down, the storage location can only be changed on up nodes", downHosts)
+	}
+
+	sortedHosts := make([]string, len(hosts))
+	copy(sortedHosts, hosts)
+	sort.Strings(sortedHosts)
+	return sortedHosts, nil
+}
@@ -0,0 +1,79 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateAlterStorageLocationOptions(t *testing.T) {
+	options := VAlterStorageLocationOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.0.2.1"}
+	options.Action = StorageLocationAdd
+	options.LocationPath = "/data/temp"
+	options.Usage = "data, temp"
+	err := options.validateParseOptions(vlog.Printer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "DATA,TEMP", options.Usage)
+
+	// invalid usage
+	options.Usage = "CACHE"
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, `invalid storage location usage "CACHE"`)
+
+	// no usage to retire a location
+	options.Action = StorageLocationRetire
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "usage cannot be specified")
+	options.Usage = ""
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.NoError(t, err)
+
+	// relative path
+	options.LocationPath = "data/temp"
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.Error(t, err)
+}
+
+func TestGetStorageLocationHosts(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostList = []string{"192.0.2.2", "192.0.2.1"}
+	vdb.HostNodeMap["192.0.2.1"] = &VCoordinationNode{Name: "v_test_db_node0001", State: util.NodeUpState}
+	vdb.HostNodeMap["192.0.2.2"] = &VCoordinationNode{Name: "v_test_db_node0002", State: util.NodeUpState}
+
+	// all hosts by default
+	options := VAlterStorageLocationOptionsFactory()
+	hosts, err := options.getLocationHosts(&vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, hosts)
+
+	// unknown host
+	options.LocationHosts = []string{"192.0.2.3"}
+	_, err = options.getLocationHosts(&vdb)
+	assert.ErrorContains(t, err, "hosts [192.0.2.3] are not in database")
+
+	// down host
+	vdb.HostNodeMap["192.0.2.2"].State = util.NodeDownState
+	options.LocationHosts = []string{"192.0.2.2"}
+	_, err = options.getLocationHosts(&vdb)
+	assert.ErrorContains(t, err, "can only be changed on up nodes")
+}
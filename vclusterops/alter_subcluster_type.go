@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -73,7 +74,7 @@ func (options *VAlterSubclusterTypeOptions) validateParseOptions(logger vlog.Pri
 	}
 
 	// need to provide a password or certs
-	if options.Password == nil && (options.Cert == "" || options.Key == "") {
+	if options.Password == nil && !options.hasHTTPSCredentials() {
 		return fmt.Errorf("must provide a password or certs")
 	}
 
@@ -112,7 +113,8 @@ func (options *VAlterSubclusterTypeOptions) validateAnalyzeOptions(logger vlog.P
 }
 
 // VAlterSubclusterType can promote/demote subcluster to different types
-func (vcc VClusterCommands) VAlterSubclusterType(options *VAlterSubclusterTypeOptions) error {
+func (vcc VClusterCommands) VAlterSubclusterType(options *VAlterSubclusterTypeOptions) (err error) {
+	defer vcc.audit(commandAlterSubclusterType, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -120,7 +122,7 @@ func (vcc VClusterCommands) VAlterSubclusterType(options *VAlterSubclusterTypeOp
 	 */
 
 	// validate and analyze options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -139,8 +141,8 @@ func (vcc VClusterCommands) VAlterSubclusterType(options *VAlterSubclusterTypeOp
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -0,0 +1,187 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VCreateArchiveOptions struct {
+	DatabaseOptions
+	// Name of the restore point archive to create
+	ArchiveName string
+	// Maximum number of restore points kept in the archive, the oldest ones
+	// are removed first. 0 means unlimited.
+	NumRestorePoints int
+	// Namespace of the archive, empty for the default namespace
+	Namespace string
+}
+
+type VRemoveArchiveOptions struct {
+	DatabaseOptions
+	// Name of the restore point archive to remove
+	ArchiveName string
+	// Namespace of the archive, empty for the default namespace
+	Namespace string
+}
+
+func VCreateArchiveOptionsFactory() VCreateArchiveOptions {
+	options := VCreateArchiveOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func VRemoveArchiveOptionsFactory() VRemoveArchiveOptions {
+	options := VRemoveArchiveOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func validateArchiveName(archiveName, namespace string) error {
+	if archiveName == "" {
+		return fmt.Errorf("must specify an archive name")
+	}
+	if namespace != "" {
+		err := util.ValidateNamespaceName(namespace)
+		if err != nil {
+			return err
+		}
+	}
+	return util.ValidateName(archiveName, "archive")
+}
+
+func (options *VCreateArchiveOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandCreateArchive, logger)
+	if err != nil {
+		return err
+	}
+	if options.NumRestorePoints < 0 {
+		return fmt.Errorf("the number of restore points of an archive cannot be negative, got %d",
+			options.NumRestorePoints)
+	}
+	return validateArchiveName(options.ArchiveName, options.Namespace)
+}
+
+func (options *VRemoveArchiveOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRemoveArchive, logger)
+	if err != nil {
+		return err
+	}
+	return validateArchiveName(options.ArchiveName, options.Namespace)
+}
+
+// resolveRawHosts resolves RawHosts to be IP addresses
+func resolveRawHosts(options *DatabaseOptions) (err error) {
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VCreateArchive creates a restore point archive in an Eon database
+func (vcc VClusterCommands) VCreateArchive(options *VCreateArchiveOptions) (err error) {
+	defer vcc.audit(commandCreateArchive, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	initiator, err := vcc.getArchiveInitiator(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	httpsCreateArchiveOp, err := makeHTTPSCreateArchiveOp(initiator, options.usePassword,
+		options.UserName, options.Password, options.ArchiveName, options.Namespace, options.NumRestorePoints)
+	if err != nil {
+		return err
+	}
+
+	return vcc.runSingleOp(&httpsCreateArchiveOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to create archive %s", options.ArchiveName))
+}
+
+// VRemoveArchive removes a restore point archive, with all its restore
+// points, from an Eon database
+func (vcc VClusterCommands) VRemoveArchive(options *VRemoveArchiveOptions) (err error) {
+	defer vcc.audit(commandRemoveArchive, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	initiator, err := vcc.getArchiveInitiator(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	httpsDropArchiveOp, err := makeHTTPSDropArchiveOp(initiator, options.usePassword,
+		options.UserName, options.Password, options.ArchiveName, options.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return vcc.runSingleOp(&httpsDropArchiveOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to remove archive %s", options.ArchiveName))
+}
+
+// getArchiveInitiator returns an up primary host of the main cluster of an
+// Eon database, on which the archive requests are sent
+func (vcc VClusterCommands) getArchiveInitiator(options *DatabaseOptions) ([]string, error) {
+	vdb := makeVCoordinationDatabase()
+	err := vcc.getVDBFromRunningDB(&vdb, options)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if !vdb.IsEon {
+		return nil, fmt.Errorf("database %s is not an Eon database, archives only exist in Eon mode", options.DBName)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return []string{initiator}, nil
+}
+
+// runSingleOp runs an op that needs no other op, errMsg describes its failure
+func (vcc VClusterCommands) runSingleOp(op clusterOp, options *DatabaseOptions, errMsg string) error {
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{op}, &certs)
+	err := clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateArchiveOptions(t *testing.T) {
+	createOptions := VCreateArchiveOptionsFactory()
+	createOptions.DBName = "test_db"
+	createOptions.RawHosts = []string{"192.168.1.101"}
+
+	// the archive name is required
+	assert.ErrorContains(t, createOptions.validateParseOptions(vlog.Printer{}), "must specify an archive name")
+	createOptions.ArchiveName = "db/1"
+	assert.ErrorContains(t, createOptions.validateParseOptions(vlog.Printer{}), "invalid character in archive name")
+	createOptions.ArchiveName = "db1"
+	assert.NoError(t, createOptions.validateParseOptions(vlog.Printer{}))
+	createOptions.Namespace = "ns.1"
+	assert.ErrorContains(t, createOptions.validateParseOptions(vlog.Printer{}), "invalid character in namespace name")
+	createOptions.Namespace = "ns1"
+	assert.NoError(t, createOptions.validateParseOptions(vlog.Printer{}))
+	createOptions.NumRestorePoints = -1
+	assert.Error(t, createOptions.validateParseOptions(vlog.Printer{}))
+
+	removeOptions := VRemoveArchiveOptionsFactory()
+	removeOptions.DBName = "test_db"
+	removeOptions.RawHosts = []string{"192.168.1.101"}
+	assert.Error(t, removeOptions.validateParseOptions(vlog.Printer{}))
+	removeOptions.ArchiveName = "db1"
+	assert.NoError(t, removeOptions.validateParseOptions(vlog.Printer{}))
+}
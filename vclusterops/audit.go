@@ -0,0 +1,169 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditLogSyslog can be set in DatabaseOptions.AuditLogPath to send the audit
+// records to the local syslog daemon instead of a file
+const AuditLogSyslog = "syslog"
+
+const (
+	auditLogFilePerm = 0600
+	auditMaskedValue = "******"
+)
+
+// auditLogMutex serializes the writes to the audit log of the commands
+// that run concurrently in the same process
+var auditLogMutex sync.Mutex
+
+// auditRecord is one line of the audit log
+type auditRecord struct {
+	Time     string         `json:"time"`
+	Command  string         `json:"command"`
+	OSUser   string         `json:"os_user"`
+	DBUser   string         `json:"db_user,omitempty"`
+	DBName   string         `json:"db_name,omitempty"`
+	Hosts    []string       `json:"hosts"`
+	Options  map[string]any `json:"options"`
+	Result   string         `json:"result"`
+	Error    string         `json:"error,omitempty"`
+	Duration string         `json:"duration"`
+}
+
+// audit appends a record of a V* API invocation to the audit log, if
+// options.AuditLogPath is set, and notifies the subscribers of vcc.Notifier.
+// It is meant to be deferred at the start of the V* functions, with a pointer
+// to their returned error. A failure to write the audit log is logged, but
+// does not fail the command.
+func (vcc VClusterCommands) audit(command string, options *DatabaseOptions, allOptions any,
+	start time.Time, err *error) {
+	if vcc.Notifier != nil {
+		var cmdErr error
+		if err != nil {
+			cmdErr = *err
+		}
+		summary := makeOperationSummary(command, options, start, cmdErr)
+		vcc.Notifier.notify(vcc.Log, &summary)
+	}
+	if options.AuditLogPath == "" {
+		return
+	}
+
+	record := auditRecord{
+		Time:     start.UTC().Format(time.RFC3339),
+		Command:  command,
+		DBUser:   options.UserName,
+		DBName:   options.DBName,
+		Hosts:    options.Hosts,
+		Options:  maskAuditOptions(allOptions),
+		Result:   SuccessResult,
+		Duration: time.Since(start).String(),
+	}
+	if len(record.Hosts) == 0 {
+		record.Hosts = options.RawHosts
+	}
+	if osUser, e := user.Current(); e == nil {
+		record.OSUser = osUser.Username
+	}
+	if err != nil && *err != nil {
+		record.Result = FailureResult
+		record.Error = (*err).Error()
+	}
+
+	if e := writeAuditRecord(options.AuditLogPath, &record); e != nil {
+		vcc.Log.PrintWarning("fail to write the audit log %s, details: %s", options.AuditLogPath, e)
+	}
+}
+
+// writeAuditRecord appends a record to the audit log file, or sends it to syslog
+func writeAuditRecord(auditLogPath string, record *auditRecord) error {
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("fail to marshal the audit record, details: %w", err)
+	}
+
+	auditLogMutex.Lock()
+	defer auditLogMutex.Unlock()
+
+	if auditLogPath == AuditLogSyslog {
+		writer, e := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "vcluster")
+		if e != nil {
+			return e
+		}
+		defer writer.Close()
+		return writer.Info(string(recordBytes))
+	}
+
+	// the audit log is append-only
+	file, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogFilePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(recordBytes, '\n'))
+	return err
+}
+
+// maskAuditOptions converts the options of a command to a map, in which the
+// values of the sensitive options, like passwords or communal storage
+// credentials, are masked
+func maskAuditOptions(allOptions any) map[string]any {
+	optionsBytes, err := json.Marshal(allOptions)
+	if err != nil {
+		return nil
+	}
+	optionsMap := make(map[string]any)
+	err = json.Unmarshal(optionsBytes, &optionsMap)
+	if err != nil {
+		return nil
+	}
+	maskSensitiveValues(optionsMap)
+	return optionsMap
+}
+
+func maskSensitiveValues(m map[string]any) {
+	for k, v := range m {
+		if isSensitiveOptionName(k) && v != nil {
+			m[k] = auditMaskedValue
+			continue
+		}
+		if nested, ok := v.(map[string]any); ok {
+			maskSensitiveValues(nested)
+		}
+	}
+}
+
+// isSensitiveOptionName returns true if the option, or the configuration
+// parameter, with the given name may contain a secret
+func isSensitiveOptionName(name string) bool {
+	lowerName := strings.ToLower(name)
+	for _, pattern := range []string{"password", "auth", "token", "secret", "credential", "key", "cert"} {
+		if strings.Contains(lowerName, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+	password := "secret-password"
+	options := VAddNodeOptionsFactory()
+	options.DBName = "test_db"
+	options.UserName = "dbadmin"
+	options.Password = &password
+	options.RawHosts = []string{"vnode1"}
+	options.NewHosts = []string{"192.0.2.4"}
+	options.ConfigurationParameters = map[string]string{"awsauth": "id:key", "awsregion": "us-east-1"}
+	options.AuditLogPath = auditLogPath
+
+	vcc := VClusterCommands{}
+	vcc.audit(commandAddNode, &options.DatabaseOptions, &options, time.Now(), nil)
+	runErr := errors.New("fail to add node")
+	vcc.audit(commandAddNode, &options.DatabaseOptions, &options, time.Now(), &runErr)
+
+	// the records are appended
+	content, err := os.ReadFile(auditLogPath)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	assert.NotContains(t, string(content), password)
+	assert.NotContains(t, string(content), "id:key")
+
+	var record auditRecord
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, commandAddNode, record.Command)
+	assert.Equal(t, "test_db", record.DBName)
+	assert.Equal(t, []string{"vnode1"}, record.Hosts)
+	assert.Equal(t, SuccessResult, record.Result)
+	assert.Equal(t, auditMaskedValue, record.Options["Password"])
+	assert.Equal(t, []any{"192.0.2.4"}, record.Options["NewHosts"])
+	configParams, ok := record.Options["ConfigurationParameters"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, auditMaskedValue, configParams["awsauth"])
+	assert.Equal(t, "us-east-1", configParams["awsregion"])
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, FailureResult, record.Result)
+	assert.Equal(t, "fail to add node", record.Error)
+}
@@ -0,0 +1,114 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// VCatalogTruncationVersionOptions represents the available options of
+// VSyncCatalog and VGetCatalogTruncationVersion.
+type VCatalogTruncationVersionOptions struct {
+	DatabaseOptions
+}
+
+func VCatalogTruncationVersionOptionsFactory() VCatalogTruncationVersionOptions {
+	options := VCatalogTruncationVersionOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VCatalogTruncationVersionOptions) validateParseOptions(commandName string, logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandName, logger)
+	if err != nil {
+		return err
+	}
+	if !options.IsEon {
+		return fmt.Errorf("the catalog is only synchronized with the communal storage in Eon mode")
+	}
+	return nil
+}
+
+// VSyncCatalog synchronizes the catalog of a running Eon database with the
+// communal storage. It returns the catalog truncation version after the sync,
+// the catalog version up to which the database can be revived.
+func (vcc VClusterCommands) VSyncCatalog(options *VCatalogTruncationVersionOptions) (_ int64, err error) {
+	defer vcc.audit(commandSyncCatalog, &options.DatabaseOptions, options, time.Now(), &err)
+
+	initiator, err := vcc.getCatalogTruncationVersionInitiator(commandSyncCatalog, options)
+	if err != nil {
+		return 0, err
+	}
+	httpsSyncCatalogOp, err := makeHTTPSSyncCatalogOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, ManualSyncCat)
+	if err != nil {
+		return 0, err
+	}
+	err = vcc.runSingleOp(&httpsSyncCatalogOp, &options.DatabaseOptions, "fail to sync the catalog")
+	if err != nil {
+		return 0, err
+	}
+	return httpsSyncCatalogOp.truncationVersion, nil
+}
+
+// VGetCatalogTruncationVersion returns the catalog truncation version of a
+// running Eon database, without synchronizing its catalog. Backup tooling can
+// use it to confirm that the catalog was synchronized past a given version.
+func (vcc VClusterCommands) VGetCatalogTruncationVersion(options *VCatalogTruncationVersionOptions) (_ int64, err error) {
+	defer vcc.audit(commandGetCatalogTruncationVersion, &options.DatabaseOptions, options, time.Now(), &err)
+
+	initiator, err := vcc.getCatalogTruncationVersionInitiator(commandGetCatalogTruncationVersion, options)
+	if err != nil {
+		return 0, err
+	}
+	httpsGetCatalogTruncationVersionOp, err := makeHTTPSGetCatalogTruncationVersionOp([]string{initiator},
+		options.usePassword, options.UserName, options.Password)
+	if err != nil {
+		return 0, err
+	}
+	err = vcc.runSingleOp(&httpsGetCatalogTruncationVersionOp, &options.DatabaseOptions,
+		"fail to get the catalog truncation version")
+	if err != nil {
+		return 0, err
+	}
+	return httpsGetCatalogTruncationVersionOp.truncationVersion, nil
+}
+
+// getCatalogTruncationVersionInitiator validates the options and returns an
+// up primary host of the database to send the requests to
+func (vcc VClusterCommands) getCatalogTruncationVersionInitiator(commandName string,
+	options *VCatalogTruncationVersionOptions) (string, error) {
+	err := options.validateParseOptions(commandName, vcc.Log)
+	if err != nil {
+		return "", err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return "", err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return "", err
+	}
+	return getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+}
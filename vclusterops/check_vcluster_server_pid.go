@@ -0,0 +1,104 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VCheckVClusterServerPidOptions struct {
+	DatabaseOptions
+}
+
+func VCheckVClusterServerPidOptionsFactory() VCheckVClusterServerPidOptions {
+	options := VCheckVClusterServerPidOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VCheckVClusterServerPidOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VCheckVClusterServerPidOptions) validateParseOptions(logger vlog.Printer) error {
+	return options.validateBaseOptions(commandCheckProcesses, logger)
+}
+
+func (options *VCheckVClusterServerPidOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VCheckVClusterServerPidOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VCheckVClusterServerPid inspects the vertica and NMA processes on each host.
+// It returns, for each host, whether the processes are running with their
+// pid and uptime.
+func (vcc VClusterCommands) VCheckVClusterServerPid(options *VCheckVClusterServerPidOptions) (_ []HostProcesses, err error) {
+	defer vcc.audit(commandCheckProcesses, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	hostProcesses := make(map[string]*HostProcesses, len(options.Hosts))
+	nmaHealthOp := makeNMAHealthOp(options.Hosts)
+	nmaCheckProcessesOp := makeNMACheckProcessesOp(options.Hosts, false, /*failIfVerticaRunning*/
+		false /*ignoreNotFound*/, hostProcesses)
+	instructions := []clusterOp{&nmaHealthOp, &nmaCheckProcessesOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to check processes on hosts %v: %w", options.Hosts, err)
+	}
+
+	processes := make([]HostProcesses, 0, len(hostProcesses))
+	for _, p := range hostProcesses {
+		processes = append(processes, *p)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Host < processes[j].Host
+	})
+	return processes, nil
+}
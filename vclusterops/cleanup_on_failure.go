@@ -0,0 +1,77 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// getCreatedDirectories returns, for each host, the directories created by the
+// nmaPrepareDirectoriesOp instructions that have run
+func getCreatedDirectories(instructions []clusterOp) map[string][]string {
+	hostDirectories := make(map[string][]string)
+	for _, instruction := range instructions {
+		op, ok := instruction.(*nmaPrepareDirectoriesOp)
+		if !ok {
+			continue
+		}
+		for host, dirs := range op.createdDirectories {
+			hostDirectories[host] = append(hostDirectories[host], dirs...)
+		}
+	}
+	for _, dirs := range hostDirectories {
+		sort.Strings(dirs)
+	}
+	return hostDirectories
+}
+
+// cleanupAfterFailure is called when an instruction chain that creates nodes fails
+// midway. It kills the vertica processes that may have been started on the given
+// hosts and deletes the directories created by the chain, so the next attempt
+// starts clean. Directories that existed before the chain ran are not deleted.
+func (vcc VClusterCommands) cleanupAfterFailure(hosts []string, instructions []clusterOp, certs *httpsCerts) error {
+	hostDirectories := getCreatedDirectories(instructions)
+	if len(hostDirectories) == 0 {
+		vcc.Log.PrintInfo("No directories were created, nothing to clean up")
+		return nil
+	}
+
+	vcc.Log.PrintInfo("Cleaning up the directories created on hosts %v", hosts)
+	nmaKillVerticaOp := makeNMAKillVerticaOp(hosts)
+	nmaDeleteDirectoriesOp, err := makeNMADeleteCreatedDirectoriesOp(hostDirectories)
+	if err != nil {
+		return err
+	}
+	cleanupInstructions := []clusterOp{&nmaKillVerticaOp, &nmaDeleteDirectoriesOp}
+
+	clusterOpEngine := vcc.makeClusterOpEngine(cleanupInstructions, certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to clean up after the failure: %w", err)
+	}
+	return nil
+}
+
+// joinCleanupError adds the error of a cleanup, if any, to the error that
+// triggered the cleanup
+func joinCleanupError(runError, cleanupError error) error {
+	if cleanupError == nil {
+		return runError
+	}
+	return errors.Join(runError, cleanupError)
+}
@@ -21,6 +21,7 @@
 package vclusterops
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/theckman/yacspin"
+	"github.com/vertica/vcluster/rfc7807"
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
 )
@@ -36,8 +38,7 @@ import (
 /* Op and host http result status
  */
 
-// resultStatus is the data type for the status of
-// ClusterOpResult and hostHTTPResult
+// resultStatus is the data type for the status of hostHTTPResult
 type resultStatus int
 
 var wrongCredentialErrMsg = []string{"Wrong password", "Wrong certificate"}
@@ -74,6 +75,7 @@ const (
 	SuccessCode        = 200
 	MultipleChoiceCode = 300
 	UnauthorizedCode   = 401
+	NotFoundCode       = 404
 	InternalErrorCode  = 500
 )
 
@@ -85,6 +87,11 @@ type hostHTTPResult struct {
 	host       string
 	content    string
 	err        error // This is set if the http response with a status code that is not 2XX
+	// This is set if the failed http response carries an RFC 7807 problem.
+	// err is then the same problem.
+	problem *rfc7807.VProblem
+	// time taken by the host to answer the request
+	duration time.Duration
 }
 
 type httpsResponseStatus struct {
@@ -123,6 +130,33 @@ func (hostResult *hostHTTPResult) isPasswordAndCertificateError(logger vlog.Prin
 	return false
 }
 
+// getProblem returns the RFC 7807 problem of a failed response,
+// or nil if the response did not carry one
+func (hostResult *hostHTTPResult) getProblem() *rfc7807.VProblem {
+	return hostResult.problem
+}
+
+// isProblemInstanceOf returns true if the response carries an RFC 7807
+// problem of the given type
+func (hostResult *hostHTTPResult) isProblemInstanceOf(id rfc7807.ProblemID) bool {
+	return hostResult.problem != nil && hostResult.problem.IsInstanceOf(id)
+}
+
+// getErrorDetail returns a readable message for a failed response:
+// the detail of the RFC 7807 problem if there is one, the raw content otherwise
+func (hostResult *hostHTTPResult) getErrorDetail() string {
+	if hostResult.problem != nil {
+		return fmt.Sprintf("%s: %s", hostResult.problem.Title, hostResult.problem.Detail)
+	}
+	return hostResult.content
+}
+
+// isNotFound returns true if the endpoint does not exist on the host,
+// which usually means that the service is too old
+func (hostResult *hostHTTPResult) isNotFound() bool {
+	return hostResult.statusCode == NotFoundCode
+}
+
 func (hostResult *hostHTTPResult) isInternalError() bool {
 	return hostResult.statusCode == InternalErrorCode
 }
@@ -157,6 +191,29 @@ func (hostResult *hostHTTPResult) isTimeout() bool {
 	return false
 }
 
+// isUnreachable returns true if the request could not reach the host,
+// e.g., the connection was refused or timed out
+func (hostResult *hostHTTPResult) isUnreachable() bool {
+	var netErr net.Error
+	return hostResult.isException() && errors.As(hostResult.err, &netErr)
+}
+
+// isConnectionFailure returns true if the request failed before it was sent
+// to the host, i.e., the host name could not be resolved or the connection
+// could not be established. Unlike a timeout, such a request is safe to send
+// again, because the host cannot have run it.
+func (hostResult *hostHTTPResult) isConnectionFailure() bool {
+	if !hostResult.isException() {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(hostResult.err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(hostResult.err, &opErr) && opErr.Op == "dial"
+}
+
 func (hostResult *hostHTTPResult) isEOF() bool {
 	return hostResult.status == EOF
 }
@@ -196,6 +253,12 @@ type clusterOp interface {
 	setupBasicInfo()
 	loadCertsIfNeeded(certs *httpsCerts, findCertsInOptions bool) error
 	isSkipExecute() bool
+	checkMinServerVersion(serverVersion string) error
+	getLegacyOp() clusterOp
+	isSkippedOnOldServer() bool
+	getHosts() []string
+	setCheckpointed()
+	isCheckpointed() bool
 }
 
 /* Cluster ops basic fields and functions
@@ -211,6 +274,21 @@ type opBase struct {
 	clusterHTTPRequest clusterHTTPRequest
 	skipExecute        bool // This can be set during prepare if we determine no work is needed
 	spinner            *yacspin.Spinner
+	// optional, the minimum Vertica version that the op requires, e.g., "24.2.0".
+	// If the server is older, the op is replaced by legacyOp if set, skipped if
+	// skipOnOldServer is true, or fails otherwise.
+	minServerVersion string
+	legacyOp         clusterOp
+	skipOnOldServer  bool
+	// whether the completion of the op is recorded in the journal of the
+	// engine, if any, so that a resumed run skips it
+	checkpointed bool
+	// optional, the fields that the responses of the hosts must have
+	responseSchema responseSchema
+	// whether the op skips the hosts whose service could not be reached by a
+	// previous op. The skipped hosts get a failed result, so only the ops
+	// that tolerate unreachable hosts should set it.
+	skipQuarantinedHosts bool
 }
 
 type opResponseMap map[string]string
@@ -219,12 +297,20 @@ func (op *opBase) getName() string {
 	return op.name
 }
 
+// setLogger sets the logger of the op. Every message logged by the op
+// carries the op name, so that the logs can be filtered by op.
 func (op *opBase) setLogger(logger vlog.Printer) {
-	op.logger = logger.WithName(op.name)
+	opLogger := logger.WithName(op.name)
+	op.logger = opLogger.WithValues("op", op.name)
 }
 
 func (op *opBase) parseAndCheckResponse(host, responseContent string, responseObj any) error {
-	err := util.GetJSONLogErrors(responseContent, &responseObj, op.name, op.logger)
+	err := op.responseSchema.validate(host, responseContent)
+	if err != nil {
+		op.logger.Error(err, "invalid response on host, detail", "host", host)
+		return fmt.Errorf("[%s] %w", op.name, err)
+	}
+	err = util.GetJSONLogErrors(responseContent, &responseObj, op.name, op.logger)
 	if err != nil {
 		op.logger.Error(err, "fail to parse response on host, detail", "host", host)
 		return err
@@ -255,6 +341,55 @@ func (op *opBase) setupBasicInfo() {
 	op.setVersionToSemVar()
 }
 
+// checkMinServerVersion returns an error if the op declared a minimum Vertica
+// version that is higher than the given server version. The hotfix number of
+// the server version, e.g., 0 in 24.3.0-0, is ignored.
+func (op *opBase) checkMinServerVersion(serverVersion string) error {
+	if op.minServerVersion == "" {
+		return nil
+	}
+	serverVer, err := parseServerVersion(serverVersion)
+	if err != nil {
+		return fmt.Errorf("[%s] %w", op.name, err)
+	}
+	minVer := semVer{Ver: op.minServerVersion}
+	cmp, err := serverVer.compareCore(&minVer)
+	if err != nil {
+		return fmt.Errorf("[%s] %w", op.name, err)
+	}
+	if cmp < 0 {
+		return fmt.Errorf("[%s] requires Vertica version %s or higher, but the server version is %s",
+			op.name, op.minServerVersion, serverVersion)
+	}
+	return nil
+}
+
+// getLegacyOp returns the op that replaces this op on servers that
+// are older than its minimum version, if any
+func (op *opBase) getLegacyOp() clusterOp {
+	return op.legacyOp
+}
+
+// isSkippedOnOldServer returns true if this op can be skipped on servers
+// that are older than its minimum version
+func (op *opBase) isSkippedOnOldServer() bool {
+	return op.skipOnOldServer
+}
+
+func (op *opBase) getHosts() []string {
+	return op.hosts
+}
+
+// setCheckpointed marks the op as one that a resumed run can skip once it has
+// completed, e.g., because it changes the cluster and would fail if run again
+func (op *opBase) setCheckpointed() {
+	op.checkpointed = true
+}
+
+func (op *opBase) isCheckpointed() bool {
+	return op.checkpointed
+}
+
 // setupSpinner sets up the progress spinner
 func (op *opBase) setupSpinner() {
 	if op.logger.ForCli {
@@ -336,30 +471,38 @@ func (op *opBase) logResponse(host string, result hostHTTPResult) {
 	if result.err != nil {
 		op.logger.PrintError("[%s] result from host %s summary %s, details: %+v",
 			op.name, host, result.status.getStatusString(), result.err)
+		op.logger.Info("Request failed", "host", host, "status", result.status.getStatusString(),
+			"statusCode", result.statusCode, "duration", result.duration)
 	} else {
-		op.logger.Log.Info("Request succeeded",
-			"op name", op.name, "host", host, "details", result)
+		op.logger.Info("Request succeeded", "host", host, "status", result.status.getStatusString(),
+			"statusCode", result.statusCode, "duration", result.duration, "details", result)
 	}
 }
 
 func (op *opBase) logPrepare() {
-	op.logger.Info("Prepare() called", "name", op.name)
+	op.logger.Info("Prepare() called")
 }
 
 func (op *opBase) logExecute() {
-	op.logger.Info("Execute() called", "name", op.name)
+	op.logger.Info("Execute() called")
 }
 
 func (op *opBase) logFinalize() {
-	op.logger.Info("Finalize() called", "name", op.name)
+	op.logger.Info("Finalize() called")
 }
 
 func (op *opBase) runExecute(execContext *opEngineExecContext) error {
+	var excludedHosts map[string]error
+	if op.skipQuarantinedHosts {
+		excludedHosts = execContext.excludeQuarantinedHosts(op.logger, &op.clusterHTTPRequest)
+	}
 	err := execContext.dispatcher.sendRequest(&op.clusterHTTPRequest, op.spinner)
 	if err != nil {
 		op.logger.Error(err, "Fail to dispatch request, detail", "dispatch request", op.clusterHTTPRequest)
 		return err
 	}
+	execContext.quarantineUnreachableHosts(op.logger, &op.clusterHTTPRequest)
+	addQuarantinedResults(&op.clusterHTTPRequest, excludedHosts)
 	return nil
 }
 
@@ -380,10 +523,21 @@ func (op *opBase) loadCertsIfNeeded(certs *httpsCerts, findCertsInOptions bool)
 
 	for host := range op.clusterHTTPRequest.RequestCollection {
 		request := op.clusterHTTPRequest.RequestCollection[host]
-		request.UseCertsInOptions = true
-		request.Certs.key = certs.key
-		request.Certs.cert = certs.cert
+		if certs.hasClientCert() {
+			request.UseCertsInOptions = true
+			request.Certs.key = certs.key
+			request.Certs.cert = certs.cert
+			request.Certs.tlsCert = certs.tlsCert
+		}
 		request.Certs.caCert = certs.caCert
+		request.Certs.caCertPool = certs.caCertPool
+		request.TLSVerify = certs.tlsVerify
+		// the NMA endpoints do not support access tokens and Kerberos,
+		// they keep using the certificates
+		if !request.IsNMACommand {
+			request.Token = certs.token
+			request.Kerberos = certs.kerberos
+		}
 		op.clusterHTTPRequest.RequestCollection[host] = request
 	}
 	return nil
@@ -481,7 +635,18 @@ func (opb *opHTTPSBase) validateAndSetUsernameAndPassword(opName string, useHTTP
 	return nil
 }
 
-type ClusterCommands interface {
+// ClusterCommandsAPIVersion is the latest version of the ClusterCommands
+// interface. A released version of the interface is never changed: the
+// commands added after it go into a new version that embeds it, and this
+// constant is set to the new version, so that the implementations outside
+// of this package, e.g., mocks, can tell which version they implement.
+const ClusterCommandsAPIVersion = 2
+
+// ClusterCommandsV1 is version 1 of the interface of the commands, with the
+// context support. Callers that want to be tested without a real cluster
+// should depend on a versioned interface rather than on VClusterCommands,
+// and use the vmock package in their unit tests.
+type ClusterCommandsV1 interface {
 	GetLog() vlog.Printer
 	V(int) logr.Logger
 	LogInfo(msg string, keysAndValues ...any)
@@ -499,13 +664,19 @@ type ClusterCommands interface {
 	VInstallPackages(options *VInstallPackagesOptions) (*InstallPackageStatus, error)
 	VReIP(options *VReIPOptions) error
 	VRemoveNode(options *VRemoveNodeOptions) (VCoordinationDatabase, error)
+	VReplaceNode(options *VReplaceNodeOptions) (VCoordinationDatabase, error)
 	VRemoveSubcluster(removeScOpt *VRemoveScOptions) (VCoordinationDatabase, error)
 	VReviveDatabase(options *VReviveDatabaseOptions) (dbInfo string, vdbPtr *VCoordinationDatabase, err error)
+	VDescribeCommunalDatabase(options *VReviveDatabaseOptions) (*DatabaseDescription, error)
 	VSandbox(options *VSandboxOptions) error
+	VShowSandboxes(options *VShowSandboxesOptions) ([]SandboxInfo, error)
 	VScrutinize(options *VScrutinizeOptions) error
 	VShowRestorePoints(options *VShowRestorePointsOptions) (restorePoints []RestorePoint, err error)
+	VCreateArchive(options *VCreateArchiveOptions) error
+	VRemoveArchive(options *VRemoveArchiveOptions) error
 	VStartDatabase(options *VStartDatabaseOptions) (vdbPtr *VCoordinationDatabase, err error)
 	VStartNodes(options *VStartNodesOptions) error
+	VRestartNode(options *VRestartNodeOptions) error
 	VStartSubcluster(startScOpt *VStartScOptions) error
 	VStopDatabase(options *VStopDatabaseOptions) error
 	VReplicateDatabase(options *VReplicationDatabaseOptions) error
@@ -513,8 +684,70 @@ type ClusterCommands interface {
 	VUnsandbox(options *VUnsandboxOptions) error
 	VStopSubcluster(options *VStopSubclusterOptions) error
 	VAlterSubclusterType(options *VAlterSubclusterTypeOptions) error
+	VAlterNodeType(options *VAlterNodeTypeOptions) error
+	VAlterLoadBalanceGroup(options *VLoadBalanceGroupOptions) error
+	VAlterRoutingRule(options *VRoutingRuleOptions) error
+	VGetConfigurationParameter(options *VGetConfigurationParameterOptions) (ConfigParameterInfo, error)
+	VSetConfigurationParameter(options *VSetConfigurationParameterOptions) (ConfigParameterChange, error)
+	VAlterSpreadEncryption(options *VSpreadEncryptionOptions) error
+	VRotateDBPassword(options *VRotateDBPasswordOptions) error
+	VManageNMA(options *VManageNMAOptions) error
+	VRealignControlNodes(options *VRealignControlNodesOptions) (map[string]string, error)
+	VSendCustomRequest(options *VCustomRequestOptions) (map[string]CustomRequestResult, error)
+	VForceRestartDatabase(options *VForceRestartDatabaseOptions) ([]string, error)
+	VSyncCatalog(options *VCatalogTruncationVersionOptions) (int64, error)
+	VGetCatalogTruncationVersion(options *VCatalogTruncationVersionOptions) (int64, error)
+	VCollectLogs(options *VCollectLogsOptions) (string, error)
+	VDiffTopology(options *VDiffTopologyOptions) (VTopologyDiff, error)
+	VFetchRunningCoordinationDatabase(options *VFetchCoordinationDatabaseOptions) (VCoordinationDatabase, error)
+	VHibernateDatabase(options *VHibernateDatabaseOptions) error
+	VWakeDatabase(options *VWakeDatabaseOptions) (*VCoordinationDatabase, error)
+	VPauseSubcluster(options *VPauseSubclusterOptions) (VCoordinationDatabase, error)
+	VScaleSubcluster(options *VScaleSubclusterOptions) (VScaleSubclusterResult, error)
+	VFetchDatabaseInfo(options *VFetchDatabaseInfoOptions) (VDatabaseInfo, error)
+	VListSubclusters(options *VListSubclustersOptions) ([]SubclusterDetails, error)
 	VRenameSubcluster(options *VRenameSubclusterOptions) error
 	VFetchNodesDetails(options *VFetchNodesDetailsOptions) (NodesDetails, error)
+	VCheckVClusterServerPid(options *VCheckVClusterServerPidOptions) ([]HostProcesses, error)
+	VKillVertica(options *VKillVerticaOptions) error
+	VVerifyCatalog(options *VVerifyCatalogOptions) (CatalogVerificationReport, error)
+	VAlterDepotSize(options *VAlterDepotSizeOptions) (map[string]string, error)
+	VAlterStorageLocation(options *VAlterStorageLocationOptions) error
+	VSetKSafety(options *VSetKSafetyOptions) error
+	VGetKSafety(options *VGetKSafetyOptions) (KSafetyInfo, error)
+	VRebalanceShards(options *VRebalanceShardsOptions) error
+	VRebalanceCluster(options *VRebalanceClusterOptions) error
+	VManageConnectionDraining(options *VManageConnectionDrainingOptions) error
+
+	APIVersion() int
+	WithContext(ctx context.Context) ClusterCommandsV1
+}
+
+// ClusterCommandsV2 is version 2 of the interface of the commands. It adds
+// the file distribution, the subscription polling, the session management,
+// the rolling upgrade, and the prerequisite and license checks to version 1.
+// WithContext still returns a ClusterCommandsV1; the value that it returns
+// implements ClusterCommandsV2 when the receiver does.
+type ClusterCommandsV2 interface {
+	ClusterCommandsV1
+
+	VDistributeFile(options *VDistributeFileOptions) (VDistributeFileResult, error)
+	VPollSubscriptionState(options *VPollSubscriptionStateOptions) (VSubscriptionState, error)
+	VListSessions(options *VListSessionsOptions) ([]SessionInfo, error)
+	VCloseSessions(options *VCloseSessionsOptions) ([]SessionInfo, error)
+	VRollingUpgrade(options *VRollingUpgradeOptions) error
+	VCheckPrerequisites(options *VCheckPrerequisitesOptions) (PrerequisiteReport, error)
+	VCheckLicenseCompliance(options *VCheckLicenseComplianceOptions) (LicenseComplianceReport, error)
+}
+
+// check at compile time that VClusterCommands implements all the versions
+var _ ClusterCommandsV1 = VClusterCommands{}
+var _ ClusterCommandsV2 = VClusterCommands{}
+
+// ClusterCommands is the interface of all the commands of VClusterCommands,
+// i.e., the latest version of the interface
+type ClusterCommands interface {
+	ClusterCommandsV2
 }
 
 type VClusterCommandsLogger struct {
@@ -553,4 +786,56 @@ func (vcc VClusterCommandsLogger) PrintError(msg string, v ...any) {
 // (e.g. create db, add node, etc.).
 type VClusterCommands struct {
 	VClusterCommandsLogger
+	// NodesDetailsCache is an optional cache for VFetchNodesDetails.
+	// It is shared by all the copies of this VClusterCommands.
+	NodesDetailsCache *NodesDetailsCache
+	// Tracer is an optional hook to trace the ops run by the commands
+	// and the requests they send to the hosts.
+	Tracer Tracer
+	// Metrics is an optional registry of metrics about the ops run by the
+	// commands. It is shared by all the copies of this VClusterCommands.
+	Metrics *MetricsRegistry
+	// HTTPCapture is an optional recorder of the requests sent to the
+	// hosts and their responses, for troubleshooting.
+	HTTPCapture *HTTPCapture
+	// MaxConcurrentHosts is the maximum number of hosts to which an op sends
+	// its requests at the same time. The requests to the other hosts wait
+	// for a previous request to complete. 0 means no limit.
+	MaxConcurrentHosts int
+	// LocalExecution executes the directory and catalog requests to the NMA
+	// of the local host directly through the OS, so that they do not need
+	// a running NMA, e.g., to bootstrap a single-node database.
+	LocalExecution bool
+	// CompressionThreshold is the size in bytes from which the body of an
+	// NMA request that transfers files, e.g., the catalog and config files
+	// that are transferred between hosts, is compressed with gzip. Once it is
+	// set, the responses of these requests are also requested in gzip. If the
+	// NMA rejects a compressed body, the request is sent again uncompressed.
+	// Other requests, including the ones to the Vertica HTTPS service, are
+	// never compressed. 0 means no compression.
+	CompressionThreshold int
+	// PollingPolicy controls the waits between the polls of the ops that
+	// wait for a state, e.g., the nodes to be up. DefaultPollingPolicy()
+	// is used if it is nil.
+	PollingPolicy *PollingPolicy
+	// Notifier is an optional hook that calls callbacks and webhooks
+	// with a summary of every operation that finishes.
+	Notifier *Notifier
+	// the context of the commands, set by WithContext
+	ctx context.Context
+}
+
+// WithContext returns a copy of vcc whose commands use ctx. Once ctx is done,
+// a command stops before its next op and returns the error of ctx. The op
+// that is running when ctx is done completes, so that the hosts are not left
+// in the middle of a request. ctx is also the parent of the trace spans.
+func (vcc VClusterCommands) WithContext(ctx context.Context) ClusterCommandsV1 {
+	vcc.ctx = ctx
+	return vcc
+}
+
+// APIVersion returns the version of the ClusterCommands interface that
+// VClusterCommands implements
+func (vcc VClusterCommands) APIVersion() int {
+	return ClusterCommandsAPIVersion
 }
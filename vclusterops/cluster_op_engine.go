@@ -16,7 +16,9 @@
 package vclusterops
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/vlog"
 )
@@ -25,6 +27,25 @@ type VClusterOpEngine struct {
 	instructions []clusterOp
 	certs        *httpsCerts
 	execContext  *opEngineExecContext
+	// optional, to trace the ops and their requests
+	tracer Tracer
+	// optional, to collect metrics about the ops and their requests
+	metrics *MetricsRegistry
+	// optional, to record the requests and their responses
+	httpCapture *HTTPCapture
+	// maximum number of hosts to which requests are sent at the same time
+	maxConcurrentHosts int
+	// whether some NMA requests to the local host are executed locally
+	localExecution bool
+	// size from which the request bodies are compressed, 0 for no compression
+	compressionThreshold int
+	// optional, how often the polling ops send their requests
+	pollingPolicy *PollingPolicy
+	// optional, to record the checkpointed ops that completed, so that
+	// the run can be resumed after a crash
+	journal *opJournal
+	// optional, to stop the run before the next op once it is done
+	ctx context.Context
 }
 
 func makeClusterOpEngine(instructions []clusterOp, certs *httpsCerts) VClusterOpEngine {
@@ -34,12 +55,40 @@ func makeClusterOpEngine(instructions []clusterOp, certs *httpsCerts) VClusterOp
 	return newClusterOpEngine
 }
 
+// makeClusterOpEngine creates an engine that uses the tracer, the metrics,
+// the HTTP capture, the host fan-out limit, the local execution mode, the compression
+// threshold, and the polling policy of vcc, if any
+func (vcc VClusterCommands) makeClusterOpEngine(instructions []clusterOp, certs *httpsCerts) VClusterOpEngine {
+	opEngine := makeClusterOpEngine(instructions, certs)
+	opEngine.tracer = vcc.Tracer
+	opEngine.metrics = vcc.Metrics
+	opEngine.httpCapture = vcc.HTTPCapture
+	opEngine.maxConcurrentHosts = vcc.MaxConcurrentHosts
+	opEngine.localExecution = vcc.LocalExecution
+	opEngine.compressionThreshold = vcc.CompressionThreshold
+	opEngine.pollingPolicy = vcc.PollingPolicy
+	opEngine.ctx = vcc.ctx
+	return opEngine
+}
+
 func (opEngine *VClusterOpEngine) shouldGetCertsFromOptions() bool {
-	return (opEngine.certs.key != "" && opEngine.certs.cert != "")
+	return opEngine.certs.hasClientCert() ||
+		opEngine.certs.token != nil || opEngine.certs.kerberos != nil ||
+		opEngine.certs.tlsVerify.verifiesServer()
 }
 
 func (opEngine *VClusterOpEngine) run(logger vlog.Printer) error {
 	execContext := makeOpEngineExecContext(logger)
+	execContext.dispatcher.tracer = opEngine.tracer
+	execContext.dispatcher.metrics = opEngine.metrics
+	execContext.dispatcher.httpCapture = opEngine.httpCapture
+	execContext.dispatcher.pool.maxConcurrentHosts = opEngine.maxConcurrentHosts
+	execContext.dispatcher.localExecution = opEngine.localExecution
+	execContext.dispatcher.compressionThreshold = opEngine.compressionThreshold
+	if opEngine.pollingPolicy != nil {
+		execContext.pollingPolicy = opEngine.pollingPolicy.withDefaults()
+	}
+	execContext.dispatcher.resolver = opEngine.certs.resolver
 	opEngine.execContext = &execContext
 
 	return opEngine.runWithExecContext(logger, &execContext)
@@ -48,26 +97,104 @@ func (opEngine *VClusterOpEngine) run(logger vlog.Printer) error {
 func (opEngine *VClusterOpEngine) runWithExecContext(logger vlog.Printer, execContext *opEngineExecContext) error {
 	findCertsInOptions := opEngine.shouldGetCertsFromOptions()
 
-	for _, op := range opEngine.instructions {
-		err := opEngine.runInstruction(logger, execContext, op, findCertsInOptions)
+	for i, op := range opEngine.instructions {
+		if err := opEngine.checkContext(op); err != nil {
+			return err
+		}
+		if opEngine.journal.isCompleted(i, op) {
+			logger.PrintInfo("[%s] completed in a previous run, skipping it", op.getName())
+			continue
+		}
+		// the host names may resolve to other addresses since the previous op
+		execContext.dispatcher.resolver.refresh(logger)
+		opToRun, err := negotiateServerVersion(logger, execContext, op)
+		if err != nil {
+			return err
+		}
+		if opToRun == nil {
+			continue
+		}
+		err = opEngine.runInstruction(logger, execContext, opToRun, findCertsInOptions)
+		if err != nil {
+			return err
+		}
+		err = opEngine.journal.recordCompleted(i, op)
 		if err != nil {
 			return err
 		}
 	}
 
+	// the run is complete, there is nothing left to resume
+	return opEngine.journal.remove()
+}
+
+// getContext returns the context of the run, or an empty context if none was given
+func (opEngine *VClusterOpEngine) getContext() context.Context {
+	if opEngine.ctx == nil {
+		return context.Background()
+	}
+	return opEngine.ctx
+}
+
+// checkContext returns an error if the context of the run is done
+func (opEngine *VClusterOpEngine) checkContext(op clusterOp) error {
+	if err := opEngine.getContext().Err(); err != nil {
+		return fmt.Errorf("stopped before %s: %w", op.getName(), err)
+	}
 	return nil
 }
 
+// negotiateServerVersion returns the op to run in place of the given op. If the
+// server version is known and the server is too old for the op, the legacy
+// version of the op is returned instead, or nil if the op can be skipped.
+func negotiateServerVersion(logger vlog.Printer, execContext *opEngineExecContext, op clusterOp) (clusterOp, error) {
+	if execContext.serverVersion == "" {
+		return op, nil
+	}
+	err := op.checkMinServerVersion(execContext.serverVersion)
+	if err == nil {
+		return op, nil
+	}
+	if legacyOp := op.getLegacyOp(); legacyOp != nil {
+		logger.PrintWarning("%s, running %s instead", err, legacyOp.getName())
+		return negotiateServerVersion(logger, execContext, legacyOp)
+	}
+	if op.isSkippedOnOldServer() {
+		logger.PrintWarning("%s, skipping it", err)
+		return nil, nil
+	}
+	return nil, err
+}
+
 func (opEngine *VClusterOpEngine) runInstruction(
 	logger vlog.Printer, execContext *opEngineExecContext,
-	op clusterOp, findCertsInOptions bool) error {
+	op clusterOp, findCertsInOptions bool) (err error) {
 	op.setLogger(logger)
 	op.setupBasicInfo()
 	op.setupSpinner()
 	defer op.cleanupSpinner()
 
+	// log the outcome and duration of the op, so the logs tell
+	// which op of a command failed and how long each op took
+	start := time.Now()
+	var span Span
+	execContext.dispatcher.traceCtx, span = startSpan(opEngine.getContext(), opEngine.tracer,
+		op.getName(), map[string]string{"op": op.getName()})
+	defer func() {
+		status := SuccessResult
+		if err != nil {
+			status = FailureResult
+		}
+		logger.Info("Op completed", "op", op.getName(), "status", status,
+			"duration", time.Since(start))
+		endSpan(span, err)
+		if opEngine.metrics != nil {
+			opEngine.metrics.recordOp(op.getName(), err != nil)
+		}
+	}()
+
 	op.logPrepare()
-	err := op.prepare(execContext)
+	err = op.prepare(execContext)
 	if err != nil {
 		return fmt.Errorf("prepare %s failed, details: %w", op.getName(), err)
 	}
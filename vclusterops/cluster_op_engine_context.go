@@ -39,11 +39,27 @@ type opEngineExecContext struct {
 
 	// hosts on which the wrong authentication occurred
 	hostsWithWrongAuth []string
+
+	// the lowest Vertica version of the cluster, set by nmaVerticaVersionOp.
+	// It is used to skip or substitute the ops that the server does not support.
+	serverVersion string
+	// the version of the NMAs, set by nmaVersionOp
+	nmaVersion string
+
+	// services of the hosts that could not be reached by an op, with the
+	// error of the request. The later ops that opt in exclude them while a
+	// majority of their hosts can still be reached.
+	quarantinedHosts map[quarantinedService]error
+
+	// how often the polling ops send their requests
+	pollingPolicy PollingPolicy
 }
 
 func makeOpEngineExecContext(logger vlog.Printer) opEngineExecContext {
 	newOpEngineExecContext := opEngineExecContext{}
 	newOpEngineExecContext.dispatcher = makeHTTPRequestDispatcher(logger)
+	newOpEngineExecContext.quarantinedHosts = make(map[quarantinedService]error)
+	newOpEngineExecContext.pollingPolicy = DefaultPollingPolicy()
 
 	return newOpEngineExecContext
 }
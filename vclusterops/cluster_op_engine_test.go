@@ -16,6 +16,7 @@
 package vclusterops
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -84,3 +85,137 @@ func TestSkipExecuteOp(t *testing.T) {
 	assert.False(t, opWithSkipEnabled.calledExecute)
 	assert.True(t, opWithSkipEnabled.calledFinalize)
 }
+
+type mockSpan struct {
+	name  string
+	err   error
+	ended bool
+}
+
+func (s *mockSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *mockSpan) End() {
+	s.ended = true
+}
+
+type mockTracer struct {
+	spans []*mockSpan
+}
+
+func (t *mockTracer) StartSpan(ctx context.Context, name string, _ map[string]string) (context.Context, Span) {
+	span := &mockSpan{name: name}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type failingMockOp struct {
+	mockOp
+}
+
+func (m *failingMockOp) execute(_ *opEngineExecContext) error {
+	return fmt.Errorf("mock failure")
+}
+
+func TestTraceOps(t *testing.T) {
+	tracer := mockTracer{}
+	vcc := VClusterCommands{Tracer: &tracer}
+	succeedingOp := makeMockOp(false)
+	failingOp := failingMockOp{mockOp: makeMockOp(false)}
+	failingOp.name = "failing"
+	certs := httpsCerts{}
+	opEngn := vcc.makeClusterOpEngine([]clusterOp{&succeedingOp, &failingOp}, &certs)
+	err := opEngn.run(vlog.Printer{})
+	assert.ErrorContains(t, err, "mock failure")
+
+	// one span per op, ended with the outcome of the op
+	assert.Len(t, tracer.spans, 2)
+	assert.Equal(t, succeedingOp.name, tracer.spans[0].name)
+	assert.True(t, tracer.spans[0].ended)
+	assert.NoError(t, tracer.spans[0].err)
+	assert.Equal(t, "failing", tracer.spans[1].name)
+	assert.True(t, tracer.spans[1].ended)
+	assert.ErrorContains(t, tracer.spans[1].err, "mock failure")
+}
+
+func TestTraceRunClusterOpEngine(t *testing.T) {
+	// the ops of scrutinize and collect_logs are traced like the others
+	tracer := mockTracer{}
+	vcc := VClusterCommands{Tracer: &tracer}
+	op := makeMockOp(false)
+	options := DatabaseOptions{}
+	assert.NoError(t, vcc.runClusterOpEngine(&options, []clusterOp{&op}))
+	assert.Len(t, tracer.spans, 1)
+	assert.Equal(t, op.name, tracer.spans[0].name)
+}
+
+func TestNegotiateServerVersion(t *testing.T) {
+	makeVersionedOp := func(name, minVersion string) *mockOp {
+		op := makeMockOp(true)
+		op.name = name
+		op.minServerVersion = minVersion
+		return &op
+	}
+	newOp := makeVersionedOp("new", "24.3.0")
+	legacyOp := makeVersionedOp("legacy", "")
+	newOp.legacyOp = legacyOp
+	requiredOp := makeVersionedOp("required", "24.3.0")
+	optionalOp := makeVersionedOp("optional", "24.3.0")
+	optionalOp.skipOnOldServer = true
+
+	// unknown server version, nothing is checked
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	op, err := negotiateServerVersion(vlog.Printer{}, &execContext, requiredOp)
+	assert.NoError(t, err)
+	assert.Equal(t, requiredOp, op)
+
+	// recent server
+	execContext.serverVersion = "Vertica Analytic Database v24.3.0-1"
+	op, err = negotiateServerVersion(vlog.Printer{}, &execContext, newOp)
+	assert.NoError(t, err)
+	assert.Equal(t, newOp, op)
+
+	// old server: the legacy op is substituted, and the required op fails
+	// with a clear error
+	execContext.serverVersion = "Vertica Analytic Database v24.2.0"
+	op, err = negotiateServerVersion(vlog.Printer{}, &execContext, newOp)
+	assert.NoError(t, err)
+	assert.Equal(t, legacyOp, op)
+	_, err = negotiateServerVersion(vlog.Printer{}, &execContext, requiredOp)
+	assert.ErrorContains(t, err, "[required] requires Vertica version 24.3.0 or higher")
+	// and the optional op is skipped
+	op, err = negotiateServerVersion(vlog.Printer{}, &execContext, optionalOp)
+	assert.NoError(t, err)
+	assert.Nil(t, op)
+
+	// the engine runs the legacy op in place of the new one, and does not
+	// run the optional op
+	certs := httpsCerts{}
+	opEngn := makeClusterOpEngine([]clusterOp{newOp, optionalOp}, &certs)
+	err = opEngn.runWithExecContext(vlog.Printer{}, &execContext)
+	assert.NoError(t, err)
+	assert.False(t, newOp.calledPrepare)
+	assert.True(t, legacyOp.calledPrepare)
+	assert.False(t, optionalOp.calledPrepare)
+}
+
+func TestRunWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	vcc := VClusterCommands{}.WithContext(ctx).(VClusterCommands)
+	firstOp := makeMockOp(false)
+	secondOp := makeMockOp(false)
+	certs := httpsCerts{}
+	opEngn := vcc.makeClusterOpEngine([]clusterOp{&firstOp, &secondOp}, &certs)
+	assert.NoError(t, opEngn.run(vlog.Printer{}))
+	assert.True(t, secondOp.calledExecute)
+
+	// once the context is done, no op runs
+	cancel()
+	firstOp = makeMockOp(false)
+	opEngn = vcc.makeClusterOpEngine([]clusterOp{&firstOp}, &certs)
+	err := opEngn.run(vlog.Printer{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "stopped before "+firstOp.name)
+	assert.False(t, firstOp.calledPrepare)
+}
@@ -16,6 +16,8 @@
 package vclusterops
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +56,31 @@ func TestHasQuorum(t *testing.T) {
 	succeed = op.hasQuorum(hostCount, primaryNodeCount)
 	assert.Equal(t, succeed, false)
 }
+
+// every command of VClusterCommands must be in the ClusterCommands interface,
+// so that the callers can replace it with a mock
+func TestClusterCommandsCompleteness(t *testing.T) {
+	iface := reflect.TypeOf((*ClusterCommands)(nil)).Elem()
+	vccType := reflect.TypeOf(VClusterCommands{})
+	for i := 0; i < vccType.NumMethod(); i++ {
+		name := vccType.Method(i).Name
+		if !strings.HasPrefix(name, "V") || name == "V" {
+			continue
+		}
+		_, ok := iface.MethodByName(name)
+		assert.True(t, ok, "method %s is missing in the ClusterCommands interface", name)
+	}
+}
+
+// a released version of the interface must not change, so the new commands
+// must go into a new version
+func TestClusterCommandsV1IsFrozen(t *testing.T) {
+	const v1MethodCount = 72
+	v1 := reflect.TypeOf((*ClusterCommandsV1)(nil)).Elem()
+	assert.Equal(t, v1MethodCount, v1.NumMethod())
+	_, ok := v1.MethodByName("VDistributeFile")
+	assert.False(t, ok)
+
+	v2 := reflect.TypeOf((*ClusterCommandsV2)(nil)).Elem()
+	assert.Equal(t, v1MethodCount+7, v2.NumMethod())
+}
@@ -0,0 +1,192 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// CollectLogsLogMaxSizeBytesDefault is the default size limit of the logs
+// collected from every node, per log type
+const CollectLogsLogMaxSizeBytesDefault = 1024 * 1024 * 1024 // 1GB
+
+type VCollectLogsOptions struct {
+	DatabaseOptions
+	ID                string // generated: "VerticaCollectLogs.yyyymmddhhmmss"
+	TarballName       string // final tarball name
+	ExcludeVerticaLog bool
+	ExcludeDCTables   bool
+	ExcludeStartupLog bool
+	LogAgeOldestTime  string
+	LogAgeNewestTime  string
+	LogAgeHours       int   // max log age from input
+	LogSizeLimitBytes int64 // max size of every log collected from a node
+
+	timeFormats    []util.TimeFormat // generated by factory
+	logAgeMaxHours int               // calculated from exported log age options
+	logAgeMinHours int               // calculated from exported log age options
+}
+
+func VCollectLogsOptionsFactory() VCollectLogsOptions {
+	options := VCollectLogsOptions{}
+	options.setDefaultValues()
+	return options
+}
+
+func (options *VCollectLogsOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+
+	const idPrefix = "VerticaCollectLogs."
+	const timeFmt = "20060102150405" // using fixed reference time from pkg 'time'
+	options.ID = idPrefix + time.Now().Format(timeFmt)
+	options.LogSizeLimitBytes = CollectLogsLogMaxSizeBytesDefault
+
+	// same formats as scrutinize, described by ScrutinizeHelpTimeFormatDesc
+	scrutinizeOptions := VScrutinizeOptionsFactory()
+	options.timeFormats = scrutinizeOptions.timeFormats
+}
+
+func (options *VCollectLogsOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandCollectLogs, logger)
+	if err != nil {
+		return err
+	}
+	if options.ExcludeVerticaLog && options.ExcludeDCTables && options.ExcludeStartupLog {
+		return fmt.Errorf("all the log types are excluded, there is nothing to collect")
+	}
+	if options.LogSizeLimitBytes <= 0 {
+		return fmt.Errorf("the log size limit must be a positive number of bytes, got %d", options.LogSizeLimitBytes)
+	}
+	return options.validateCatalogPath()
+}
+
+func (options *VCollectLogsOptions) analyzeOptions(logger vlog.Printer) (err error) {
+	// we analyze host names when it is set in user input, otherwise we use hosts in yaml config
+	if len(options.RawHosts) > 0 {
+		// resolve RawHosts to be IP addresses
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+		logger.V(1).Info("Resolved host list to IPs", "Hosts", options.Hosts)
+	}
+
+	options.logAgeMaxHours, options.logAgeMinHours, err = getLogAgeRange(options.LogAgeOldestTime,
+		options.LogAgeNewestTime, options.LogAgeHours, options.timeFormats, logger)
+	return err
+}
+
+func (options *VCollectLogsOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions(logger)
+}
+
+// VCollectLogs collects vertica.log, the DC tables and startup.log from the
+// nodes on the given hosts through the NMA, without the diagnostics and
+// system tables that scrutinize also collects. The database does not need
+// to be up. The logs are bundled together in a tarball in
+// ScrutinizeOutputBasePath, whose path is returned.
+func (vcc VClusterCommands) VCollectLogs(options *VCollectLogsOptions) (tarballPath string, err error) {
+	defer vcc.audit(commandCollectLogs, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return "", err
+	}
+	if options.TarballName == "" {
+		options.TarballName = options.ID
+	}
+
+	// only collect logs from hosts with a healthy NMA
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBForScrutinize(&options.DatabaseOptions, &vdb)
+	if err != nil {
+		return "", fmt.Errorf("fail to retrieve the nodes to collect logs from: %w", err)
+	}
+	options.Hosts = vdb.HostList
+
+	instructions, err := vcc.produceCollectLogsInstructions(options, &vdb)
+	if err != nil {
+		return "", fmt.Errorf("fail to produce instructions, %w", err)
+	}
+	err = vcc.runClusterOpEngine(&options.DatabaseOptions, instructions)
+	if err != nil {
+		return "", fmt.Errorf("fail to collect logs: %w", err)
+	}
+
+	if err = tarAndRemoveDirectory(options.TarballName, options.ID, vcc.Log); err != nil {
+		return "", fmt.Errorf("fail to create the final tarball: %w", err)
+	}
+	return ScrutinizeOutputBasePath + "/" + options.TarballName + ".tar", nil
+}
+
+// produceCollectLogsInstructions will build a list of instructions to execute for
+// the collect logs operation.
+//
+// The generated instructions will later perform the following operations:
+//   - Stage vertica logs on all nodes, if not excluded
+//   - Stage DC tables on all nodes, if not excluded
+//   - Stage startup.log on all nodes, if not excluded
+//   - Tar and retrieve the staged logs from all nodes
+func (vcc VClusterCommands) produceCollectLogsInstructions(options *VCollectLogsOptions,
+	vdb *VCoordinationDatabase) (instructions []clusterOp, err error) {
+	hostNodeNameMap, hostCatPathMap, err := getNodeInfoForScrutinize(options.Hosts, vdb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process retrieved node info, details %w", err)
+	}
+
+	if !options.ExcludeVerticaLog {
+		stageVerticaLogsOp, e := makeNMAStageVerticaLogsOp(options.ID, options.Hosts, hostNodeNameMap,
+			hostCatPathMap, options.LogSizeLimitBytes, options.logAgeMaxHours, options.logAgeMinHours)
+		if e != nil {
+			return nil, e
+		}
+		instructions = append(instructions, &stageVerticaLogsOp)
+	}
+
+	if !options.ExcludeDCTables {
+		stageDCTablesOp, e := makeNMAStageDCTablesOp(options.ID, options.Hosts, hostNodeNameMap, hostCatPathMap)
+		if e != nil {
+			return nil, e
+		}
+		stageDCTablesOp.setTimeRange(options.logAgeMaxHours, options.logAgeMinHours)
+		instructions = append(instructions, &stageDCTablesOp)
+	}
+
+	if !options.ExcludeStartupLog {
+		stageStartupLogOp, e := makeNMAStageStartupLogOp(options.ID, scrutinizeBatchNormal, options.Hosts,
+			hostNodeNameMap, hostCatPathMap, options.LogSizeLimitBytes)
+		if e != nil {
+			return nil, e
+		}
+		instructions = append(instructions, &stageStartupLogOp)
+	}
+
+	getTarballOp, err := makeNMAGetScrutinizeTarOp(options.ID, scrutinizeBatchNormal,
+		options.Hosts, hostNodeNameMap)
+	if err != nil {
+		return nil, err
+	}
+	instructions = append(instructions, &getTarballOp)
+
+	return instructions, nil
+}
@@ -0,0 +1,72 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateCollectLogsOptions(t *testing.T) {
+	options := VCollectLogsOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+	options.CatalogPrefix = "/data"
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	options.LogSizeLimitBytes = 0
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "log size limit")
+
+	options.LogSizeLimitBytes = CollectLogsLogMaxSizeBytesDefault
+	options.ExcludeVerticaLog = true
+	options.ExcludeDCTables = true
+	options.ExcludeStartupLog = true
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "nothing to collect")
+}
+
+func TestProduceCollectLogsInstructions(t *testing.T) {
+	options := VCollectLogsOptionsFactory()
+	options.ID = "VerticaCollectLogs.test"
+	options.Hosts = []string{"192.168.1.101"}
+	options.LogSizeLimitBytes = 1024
+	options.logAgeMaxHours = 6
+	options.ExcludeStartupLog = true
+	t.Cleanup(func() { os.RemoveAll(scrutinizeRemoteOutputPath + "/" + options.ID) })
+
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{"192.168.1.101": &VCoordinationNode{
+		Name: "v_test_db_node0001", CatalogPath: "/data/test_db/v_test_db_node0001_catalog"}}
+
+	vcc := VClusterCommands{}
+	instructions, err := vcc.produceCollectLogsInstructions(&options, &vdb)
+	assert.NoError(t, err)
+	assert.Len(t, instructions, 3)
+	assert.IsType(t, &nmaStageVerticaLogsOp{}, instructions[0])
+	assert.IsType(t, &nmaGetScrutinizeTarOp{}, instructions[2])
+
+	// the DC tables are restricted to the same time range as the logs
+	stageDCTablesOp, ok := instructions[1].(*nmaStageDCTablesOp)
+	assert.True(t, ok)
+	assert.NoError(t, stageDCTablesOp.setupRequestBody(options.Hosts))
+	var data stageDCTablesRequestData
+	assert.NoError(t, json.Unmarshal([]byte(stageDCTablesOp.hostRequestBodyMap["192.168.1.101"]), &data))
+	assert.Equal(t, 6, data.LogAgeMaxHours)
+	assert.Equal(t, "/data/test_db/v_test_db_node0001_catalog", data.CatalogPath)
+}
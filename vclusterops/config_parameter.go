@@ -0,0 +1,272 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+const configParameterEndpoint = "configuration/parameters/"
+
+// ConfigParameterLevel is the level a configuration parameter is set at
+type ConfigParameterLevel string
+
+const (
+	ConfigLevelDatabase   ConfigParameterLevel = "database"
+	ConfigLevelNode       ConfigParameterLevel = "node"
+	ConfigLevelSubcluster ConfigParameterLevel = "subcluster"
+)
+
+func (l ConfigParameterLevel) IsValid() bool {
+	switch l {
+	case ConfigLevelDatabase, ConfigLevelNode, ConfigLevelSubcluster:
+		return true
+	}
+	return false
+}
+
+// getConfigParameterLevelParams returns the query parameters that select the
+// level of a configuration parameter
+func getConfigParameterLevelParams(level ConfigParameterLevel, levelName string) map[string]string {
+	queryParams := map[string]string{"level": string(level)}
+	if levelName != "" {
+		queryParams["level_name"] = levelName
+	}
+	return queryParams
+}
+
+type VGetConfigurationParameterOptions struct {
+	DatabaseOptions
+	// Name of the configuration parameter
+	ConfigParameter string
+	// The level to get the value at, database by default
+	Level ConfigParameterLevel
+	// The node or subcluster name, for the node and subcluster levels
+	LevelName string
+}
+
+type VSetConfigurationParameterOptions struct {
+	VGetConfigurationParameterOptions
+	// The value to set the configuration parameter to
+	Value string
+	// Whether to clear the value set at the level instead of setting one
+	Clear bool
+	// Whether to only get the current value, without changing it
+	DryRun bool
+}
+
+// ConfigParameterChange is the change VSetConfigurationParameter made,
+// or would make in a dry-run, to a configuration parameter
+type ConfigParameterChange struct {
+	Name           string               `json:"parameter_name"`
+	Level          ConfigParameterLevel `json:"level"`
+	LevelName      string               `json:"level_name,omitempty"`
+	CurrentValue   string               `json:"current_value"`
+	DefaultValue   string               `json:"default_value"`
+	RequestedValue string               `json:"requested_value"`
+	Cleared        bool                 `json:"cleared"`
+	Applied        bool                 `json:"applied"`
+}
+
+func VGetConfigurationParameterOptionsFactory() VGetConfigurationParameterOptions {
+	options := VGetConfigurationParameterOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VGetConfigurationParameterOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.Level = ConfigLevelDatabase
+}
+
+func VSetConfigurationParameterOptionsFactory() VSetConfigurationParameterOptions {
+	options := VSetConfigurationParameterOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VGetConfigurationParameterOptions) validateParseOptions(commandName string, logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandName, logger)
+	if err != nil {
+		return err
+	}
+	if options.ConfigParameter == "" {
+		return fmt.Errorf("must specify a configuration parameter")
+	}
+	err = util.ValidateName(options.ConfigParameter, "configuration parameter")
+	if err != nil {
+		return err
+	}
+	if !options.Level.IsValid() {
+		return fmt.Errorf("invalid configuration parameter level %q: must be '%s', '%s' or '%s'", options.Level,
+			ConfigLevelDatabase, ConfigLevelNode, ConfigLevelSubcluster)
+	}
+	if options.Level == ConfigLevelDatabase && options.LevelName != "" {
+		return fmt.Errorf("a level name cannot be specified at the database level")
+	}
+	if options.Level != ConfigLevelDatabase && options.LevelName == "" {
+		return fmt.Errorf("must specify the %s name at the %s level", options.Level, options.Level)
+	}
+	return nil
+}
+
+func (options *VSetConfigurationParameterOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.VGetConfigurationParameterOptions.validateParseOptions(commandSetConfigParameter, logger)
+	if err != nil {
+		return err
+	}
+	if options.Clear && options.Value != "" {
+		return fmt.Errorf("a value cannot be specified to clear a configuration parameter")
+	}
+	return nil
+}
+
+// checkLevelName checks that the node or the subcluster of the level exists
+func (options *VGetConfigurationParameterOptions) checkLevelName(vdb *VCoordinationDatabase) error {
+	switch options.Level {
+	case ConfigLevelNode:
+		if _, ok := vdb.genNodeNameToHostMap()[options.LevelName]; !ok {
+			return fmt.Errorf("node %s does not exist in database %s", options.LevelName, options.DBName)
+		}
+	case ConfigLevelSubcluster:
+		if !vdb.IsEon {
+			return fmt.Errorf("the subcluster level only exists in Eon mode")
+		}
+		if !util.StringInArray(options.LevelName, vdb.getSCNames()) {
+			return fmt.Errorf("subcluster %s does not exist in database %s", options.LevelName, options.DBName)
+		}
+	case ConfigLevelDatabase:
+	}
+	return nil
+}
+
+// getConfigParameterInitiator returns an up primary host to send the requests
+// to, once it checked the level of the configuration parameter exists
+func (vcc VClusterCommands) getConfigParameterInitiator(options *VGetConfigurationParameterOptions) ([]string, error) {
+	err := resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	err = options.checkLevelName(&vdb)
+	if err != nil {
+		return nil, err
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return []string{initiator}, nil
+}
+
+// VGetConfigurationParameter gets the value of a configuration parameter at
+// the database, node or subcluster level
+func (vcc VClusterCommands) VGetConfigurationParameter(options *VGetConfigurationParameterOptions) (
+	info ConfigParameterInfo, err error) {
+	defer vcc.audit(commandGetConfigParameter, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(commandGetConfigParameter, vcc.Log)
+	if err != nil {
+		return info, err
+	}
+	initiator, err := vcc.getConfigParameterInitiator(options)
+	if err != nil {
+		return info, err
+	}
+
+	httpsGetConfigParameterOp, err := makeHTTPSGetConfigParameterOp(initiator, options.usePassword,
+		options.UserName, options.Password, options.ConfigParameter, options.Level, options.LevelName, &info)
+	if err != nil {
+		return info, err
+	}
+	err = vcc.runSingleOp(&httpsGetConfigParameterOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to get configuration parameter %s", options.ConfigParameter))
+	return info, err
+}
+
+// VSetConfigurationParameter sets or clears the value of a configuration
+// parameter at the database, node or subcluster level. It returns the value
+// before the change and the requested one. In a dry-run, nothing is changed.
+func (vcc VClusterCommands) VSetConfigurationParameter(options *VSetConfigurationParameterOptions) (
+	change ConfigParameterChange, err error) {
+	defer vcc.audit(commandSetConfigParameter, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return change, err
+	}
+	initiator, err := vcc.getConfigParameterInitiator(&options.VGetConfigurationParameterOptions)
+	if err != nil {
+		return change, err
+	}
+
+	info := ConfigParameterInfo{}
+	httpsGetConfigParameterOp, err := makeHTTPSGetConfigParameterOp(initiator, options.usePassword,
+		options.UserName, options.Password, options.ConfigParameter, options.Level, options.LevelName, &info)
+	if err != nil {
+		return change, err
+	}
+	instructions := []clusterOp{&httpsGetConfigParameterOp}
+	if !options.DryRun {
+		httpsSetConfigParameterOp, e := makeHTTPSSetConfigParameterOp(initiator, options.usePassword,
+			options.UserName, options.Password, options.ConfigParameter, options.Value, options.Clear,
+			options.Level, options.LevelName)
+		if e != nil {
+			return change, e
+		}
+		instructions = append(instructions, &httpsSetConfigParameterOp)
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return change, fmt.Errorf("fail to set configuration parameter %s: %w", options.ConfigParameter, err)
+	}
+
+	change = options.buildConfigParameterChange(&info)
+	return change, nil
+}
+
+func (options *VSetConfigurationParameterOptions) buildConfigParameterChange(info *ConfigParameterInfo) ConfigParameterChange {
+	change := ConfigParameterChange{
+		Name:           options.ConfigParameter,
+		Level:          options.Level,
+		LevelName:      options.LevelName,
+		CurrentValue:   info.CurrentValue,
+		DefaultValue:   info.DefaultValue,
+		RequestedValue: options.Value,
+		Cleared:        options.Clear,
+		Applied:        !options.DryRun,
+	}
+	if options.Clear {
+		// without a value at this level, the default value applies
+		change.RequestedValue = info.DefaultValue
+	}
+	return change
+}
@@ -0,0 +1,92 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateConfigParameterOptions(t *testing.T) {
+	options := VSetConfigurationParameterOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+	assert.Equal(t, ConfigLevelDatabase, options.Level)
+
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must specify a configuration parameter")
+	options.ConfigParameter = "MaxClientSessions"
+	options.Value = "100"
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	// the node and subcluster levels need a name
+	options.Level = ConfigLevelNode
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must specify the node name")
+	options.LevelName = "v_test_db_node0001"
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+	options.Level = ConfigLevelDatabase
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "cannot be specified at the database level")
+	options.Level = "cluster"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "invalid configuration parameter level")
+
+	// no value to clear a parameter
+	options.Level = ConfigLevelDatabase
+	options.LevelName = ""
+	options.Clear = true
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+}
+
+func TestCheckConfigParameterLevelName(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.168.1.101"] = &VCoordinationNode{Name: "v_test_db_node0001", Subcluster: "sc1"}
+	options := VGetConfigurationParameterOptionsFactory()
+	options.DBName = "test_db"
+
+	options.Level = ConfigLevelNode
+	options.LevelName = "v_test_db_node0001"
+	assert.NoError(t, options.checkLevelName(&vdb))
+	options.LevelName = "v_test_db_node0002"
+	assert.ErrorContains(t, options.checkLevelName(&vdb), "does not exist")
+
+	options.Level = ConfigLevelSubcluster
+	options.LevelName = "sc1"
+	assert.ErrorContains(t, options.checkLevelName(&vdb), "only exists in Eon mode")
+	vdb.IsEon = true
+	assert.NoError(t, options.checkLevelName(&vdb))
+}
+
+func TestBuildConfigParameterChange(t *testing.T) {
+	options := VSetConfigurationParameterOptionsFactory()
+	options.ConfigParameter = "MaxClientSessions"
+	options.Value = "100"
+	options.DryRun = true
+	info := ConfigParameterInfo{Name: "MaxClientSessions", CurrentValue: "60", DefaultValue: "50"}
+
+	change := options.buildConfigParameterChange(&info)
+	assert.Equal(t, "60", change.CurrentValue)
+	assert.Equal(t, "100", change.RequestedValue)
+	assert.False(t, change.Applied)
+
+	// a cleared parameter gets its default value back
+	options.Value = ""
+	options.Clear = true
+	options.DryRun = false
+	change = options.buildConfigParameterChange(&info)
+	assert.Equal(t, "50", change.RequestedValue)
+	assert.True(t, change.Applied)
+}
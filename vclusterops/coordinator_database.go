@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set/v2"
@@ -48,6 +49,8 @@ type VCoordinationDatabase struct {
 	AwsIDKey                string
 	AwsSecretKey            string
 	NumShards               int
+	// names of the segment shards, read from the description file by revive_db
+	ShardNames []string
 
 	// authentication
 	LicensePathOnNode string
@@ -65,6 +68,33 @@ func makeVHostNodeMap() vHostNodeMap {
 	return make(vHostNodeMap)
 }
 
+// getNode returns the node of a host, or a *NodeNotFoundError if the host is
+// not in the map, so that a bad topology is reported instead of crashing
+func (hostNodeMap vHostNodeMap) getNode(host string) (*VCoordinationNode, error) {
+	vnode, ok := hostNodeMap[host]
+	if !ok || vnode == nil {
+		return nil, &NodeNotFoundError{
+			Detail: fmt.Sprintf("host %s is not in the database", host),
+			Nodes:  []string{host},
+		}
+	}
+	return vnode, nil
+}
+
+// getNodeNames returns the names of the nodes of the hosts, or a
+// *NodeNotFoundError for the first host that is not in the map
+func (hostNodeMap vHostNodeMap) getNodeNames(hosts []string) ([]string, error) {
+	nodeNames := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		vnode, err := hostNodeMap.getNode(host)
+		if err != nil {
+			return nil, err
+		}
+		nodeNames = append(nodeNames, vnode.Name)
+	}
+	return nodeNames, nil
+}
+
 func makeVCoordinationDatabase() VCoordinationDatabase {
 	return VCoordinationDatabase{}
 }
@@ -185,6 +215,7 @@ func (vdb *VCoordinationDatabase) copy(targetHosts []string) VCoordinationDataba
 		AwsIDKey:                vdb.AwsIDKey,
 		AwsSecretKey:            vdb.AwsSecretKey,
 		NumShards:               vdb.NumShards,
+		ShardNames:              util.CopySlice(vdb.ShardNames),
 		LicensePathOnNode:       vdb.LicensePathOnNode,
 		Ipv6:                    vdb.Ipv6,
 		PrimaryUpNodes:          util.CopySlice(vdb.PrimaryUpNodes),
@@ -267,6 +298,38 @@ func (vdb *VCoordinationDatabase) hasAtLeastOneDownNode() bool {
 	return false
 }
 
+// Nodes returns copies of the nodes of the database, sorted by name, for the
+// callers outside of this package
+func (vdb *VCoordinationDatabase) Nodes() []VCoordinationNode {
+	nodes := make([]VCoordinationNode, 0, len(vdb.HostNodeMap))
+	for _, vnode := range vdb.HostNodeMap {
+		nodes = append(nodes, *vnode)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	return nodes
+}
+
+// NodeByHost returns a copy of the node of a host, and false if the host is
+// not in the database
+func (vdb *VCoordinationDatabase) NodeByHost(host string) (VCoordinationNode, bool) {
+	vnode, ok := vdb.HostNodeMap[host]
+	if !ok || vnode == nil {
+		return VCoordinationNode{}, false
+	}
+	return *vnode, true
+}
+
+// hasUpNodes returns true if at least one of the given hosts has an up node
+func (vdb *VCoordinationDatabase) hasUpNodes(hosts []string) bool {
+	for _, host := range hosts {
+		if vnode, ok := vdb.HostNodeMap[host]; ok && vnode.State == util.NodeUpState {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GenDataPath builds and returns the data path
 func (vdb *VCoordinationDatabase) GenDataPath(nodeName string) string {
 	dataSuffix := fmt.Sprintf("%s_data", nodeName)
@@ -314,6 +377,19 @@ func (vdb *VCoordinationDatabase) filterPrimaryNodes() {
 	vdb.HostList = maps.Keys(vdb.HostNodeMap)
 }
 
+// getDownNodes returns the node name to host map of the nodes on the given
+// hosts that are not UP. The hosts that are not in the database are ignored.
+func (vdb *VCoordinationDatabase) getDownNodes(hosts []string) map[string]string {
+	downNodes := make(map[string]string)
+	for _, host := range hosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if ok && vnode.State != util.NodeUpState {
+			downNodes[vnode.Name] = host
+		}
+	}
+	return downNodes
+}
+
 // VCoordinationNode represents node information from the database catalog.
 type VCoordinationNode struct {
 	Name    string `json:"name"`
@@ -335,6 +411,8 @@ type VCoordinationNode struct {
 	Sandbox       string
 	Version       string
 	IsControlNode bool
+	// empty string if the node state was not fetched from the node itself
+	CatalogVersion string
 }
 
 func makeVCoordinationNode() VCoordinationNode {
@@ -357,20 +435,18 @@ func (vnode *VCoordinationNode) setFromBasicDBOptions(
 		vnode.Port = options.ClientPort
 		nodeNameSuffix := i + 1
 		vnode.Name = fmt.Sprintf("v_%s_node%04d", dbNameInNode, nodeNameSuffix)
+		catalogPrefix, dataPrefix, depotPrefix := options.getNodePrefixes(host)
 		catalogSuffix := fmt.Sprintf("%s_catalog", vnode.Name)
-		vnode.CatalogPath = filepath.Join(options.CatalogPrefix, dbName, catalogSuffix)
+		vnode.CatalogPath = filepath.Join(catalogPrefix, dbName, catalogSuffix)
 		dataSuffix := fmt.Sprintf("%s_data", vnode.Name)
-		dataPath := filepath.Join(options.DataPrefix, dbName, dataSuffix)
+		dataPath := filepath.Join(dataPrefix, dbName, dataSuffix)
 		vnode.StorageLocations = append(vnode.StorageLocations, dataPath)
-		if options.DepotPrefix != "" {
+		if depotPrefix != "" {
 			depotSuffix := fmt.Sprintf("%s_depot", vnode.Name)
-			vnode.DepotPath = filepath.Join(options.DepotPrefix, dbName, depotSuffix)
-		}
-		if options.IPv6 {
-			vnode.ControlAddressFamily = util.IPv6ControlAddressFamily
-		} else {
-			vnode.ControlAddressFamily = util.DefaultControlAddressFamily
+			vnode.DepotPath = filepath.Join(depotPrefix, dbName, depotSuffix)
 		}
+		// the family is detected per host, so that IPv4 and IPv6 hosts can be mixed
+		vnode.ControlAddressFamily = util.GetControlAddressFamily(host)
 
 		return nil
 	}
@@ -389,9 +465,5 @@ func (vnode *VCoordinationNode) setNode(vdb *VCoordinationDatabase, address, nam
 	if vdb.DepotPrefix != "" {
 		vnode.DepotPath = vdb.GenDepotPath(vnode.Name)
 	}
-	if vdb.Ipv6 {
-		vnode.ControlAddressFamily = util.IPv6ControlAddressFamily
-	} else {
-		vnode.ControlAddressFamily = util.DefaultControlAddressFamily
-	}
+	vnode.ControlAddressFamily = util.GetControlAddressFamily(address)
 }
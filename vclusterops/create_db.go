@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -41,16 +42,24 @@ type VCreateDatabaseOptions struct {
 	DepotSize                string // depot size with two supported formats: % and KMGT, e.g., 50% or 10G
 	GetAwsCredentialsFromEnv bool   // whether get AWS credentials from environmental variables
 	// part 3: optional info
-	ForceCleanupOnFailure     bool // whether force remove existing directories on failure
+	ForceCleanupOnFailure     bool // whether to remove the directories created by create_db on failure
 	ForceRemovalAtCreation    bool // whether force remove existing directories before creating the database
 	SkipPackageInstall        bool // whether skip package installation
 	TimeoutNodeStartupSeconds int  // timeout in seconds for polling node start up state
+	SkipClockCheck            bool // whether skip the clock skew check across hosts
+	SkipPortCheck             bool // whether skip the port reachability check across hosts
+	SkipPrerequisiteCheck     bool // whether skip the hardware prerequisite check of the hosts
+	// host -> prefixes that override the catalog, data and depot prefixes
+	// for that host, e.g., for hosts with a different disk layout
+	NodePaths map[string]VNodePaths
+	// where to record the progress of create_db, to resume it if interrupted
+	ResumeOptions
 
 	/* part 3: new params originally in installer generated admintools.conf, now in create db op */
 
 	Broadcast          bool // configure Spread to use UDP broadcast traffic between nodes on the same subnet
 	P2p                bool // configure Spread to use point-to-point communication between all Vertica nodes
-	LargeCluster       int  // whether enables a large cluster layout
+	LargeCluster       int  // number of control nodes, fewer than the nodes in a large cluster layout
 	ClientPort         int  // for internal QA test only, do not abuse
 	SpreadLogging      bool // whether enable spread logging
 	SpreadLoggingLevel int  // spread logging level
@@ -62,7 +71,7 @@ type VCreateDatabaseOptions struct {
 	// If the path is set, the NMA will store the Vertica start command at the path
 	// instead of executing it. This is useful in containerized environments where
 	// you may not want to have both the NMA and Vertica server in the same container.
-	// This feature requires version 24.2.0+.
+	// This feature requires version 24.2.0+, older servers start the nodes without it.
 	StartUpConf string
 
 	/* hidden options (which cache information only) */
@@ -71,6 +80,14 @@ type VCreateDatabaseOptions struct {
 	bootstrapHost []string
 }
 
+// VNodePaths are the catalog, data and depot prefixes of one host, which
+// override the prefixes of the database. An empty prefix is not overridden.
+type VNodePaths struct {
+	CatalogPrefix string
+	DataPrefix    string
+	DepotPrefix   string
+}
+
 func VCreateDatabaseOptionsFactory() VCreateDatabaseOptions {
 	options := VCreateDatabaseOptions{}
 	// set default values to the params
@@ -215,6 +232,12 @@ func (options *VCreateDatabaseOptions) validateEonOptions() error {
 	if options.GetAwsCredentialsFromEnv && options.CommunalStorageLocation == "" {
 		return fmt.Errorf("AWS credentials are only used in Eon mode")
 	}
+	if options.UseInstanceProfile && options.CommunalStorageLocation == "" {
+		return fmt.Errorf("the instance profile is only used in Eon mode")
+	}
+	if options.GetAwsCredentialsFromEnv && options.UseInstanceProfile {
+		return fmt.Errorf("cannot get AWS credentials from environment variables when the instance profile is used")
+	}
 	if options.DepotSize != "" {
 		if options.DepotPrefix == "" {
 			return fmt.Errorf("when depot size is given, depot path cannot be empty")
@@ -235,9 +258,86 @@ func (options *VCreateDatabaseOptions) validateExtraOptions() error {
 	if options.LargeCluster != util.DefaultLargeCluster && (options.LargeCluster < 1 || options.LargeCluster > util.MaxLargeCluster) {
 		return fmt.Errorf("must specify a valid large cluster value in range [1, 120]")
 	}
+	err := options.validateNodePaths()
+	if err != nil {
+		return err
+	}
+	return options.validateResumeOptions()
+}
+
+func (options *VCreateDatabaseOptions) validateNodePaths() error {
+	for host, paths := range options.NodePaths {
+		if !util.StringInArray(host, options.RawHosts) {
+			return fmt.Errorf("host %s of the node paths is not in the hosts of the database", host)
+		}
+		if paths.CatalogPrefix != "" {
+			if err := util.ValidateAbsPath(paths.CatalogPrefix, "catalog path of host "+host); err != nil {
+				return err
+			}
+		}
+		if paths.DataPrefix != "" {
+			if err := util.ValidateAbsPath(paths.DataPrefix, "data path of host "+host); err != nil {
+				return err
+			}
+		}
+		if paths.DepotPrefix != "" {
+			if options.DepotPrefix == "" {
+				return fmt.Errorf("cannot override the depot path of host %s when the database has no depot", host)
+			}
+			if err := util.ValidateAbsPath(paths.DepotPrefix, "depot path of host "+host); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// getNodePrefixes returns the catalog, data and depot prefixes of a host,
+// which are the ones of the database unless they are overridden
+func (options *VCreateDatabaseOptions) getNodePrefixes(host string) (catalogPrefix, dataPrefix, depotPrefix string) {
+	catalogPrefix, dataPrefix, depotPrefix = options.CatalogPrefix, options.DataPrefix, options.DepotPrefix
+	paths, ok := options.NodePaths[host]
+	if !ok {
+		return catalogPrefix, dataPrefix, depotPrefix
+	}
+	if paths.CatalogPrefix != "" {
+		catalogPrefix = paths.CatalogPrefix
+	}
+	if paths.DataPrefix != "" {
+		dataPrefix = paths.DataPrefix
+	}
+	if paths.DepotPrefix != "" {
+		depotPrefix = paths.DepotPrefix
+	}
+	return catalogPrefix, dataPrefix, depotPrefix
+}
+
+// groupHostsByPrefixes splits the hosts into groups of consecutive hosts
+// that have the same catalog and data prefixes
+func (options *VCreateDatabaseOptions) groupHostsByPrefixes(hosts []string) [][]string {
+	var groups [][]string
+	lastCatalogPrefix, lastDataPrefix := "", ""
+	for _, host := range hosts {
+		catalogPrefix, dataPrefix, _ := options.getNodePrefixes(host)
+		if len(groups) == 0 || catalogPrefix != lastCatalogPrefix || dataPrefix != lastDataPrefix {
+			groups = append(groups, []string{})
+			lastCatalogPrefix, lastDataPrefix = catalogPrefix, dataPrefix
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], host)
+	}
+	return groups
+}
+
+// hasNodeDepotPaths returns true if the depot prefix of some host is overridden
+func (options *VCreateDatabaseOptions) hasNodeDepotPaths() bool {
+	for _, paths := range options.NodePaths {
+		if paths.DepotPrefix != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (options *VCreateDatabaseOptions) validateParseOptions(logger vlog.Printer) error {
 	// batch 1: validate required parameters without default values
 	err := options.validateRequiredOptions(logger)
@@ -273,6 +373,28 @@ func (options *VCreateDatabaseOptions) analyzeOptions() error {
 	options.DataPrefix = util.GetCleanPath(options.DataPrefix)
 	options.DepotPrefix = util.GetCleanPath(options.DepotPrefix)
 
+	return options.analyzeNodePaths()
+}
+
+// analyzeNodePaths resolves the hosts of the node paths to IP addresses
+// and cleans the paths
+func (options *VCreateDatabaseOptions) analyzeNodePaths() error {
+	if len(options.NodePaths) == 0 {
+		return nil
+	}
+	nodePaths := make(map[string]VNodePaths, len(options.NodePaths))
+	for host, paths := range options.NodePaths {
+		addresses, err := util.ResolveRawHostsToAddresses([]string{host}, options.IPv6)
+		if err != nil {
+			return err
+		}
+		nodePaths[addresses[0]] = VNodePaths{
+			CatalogPrefix: util.GetCleanPath(paths.CatalogPrefix),
+			DataPrefix:    util.GetCleanPath(paths.DataPrefix),
+			DepotPrefix:   util.GetCleanPath(paths.DepotPrefix),
+		}
+	}
+	options.NodePaths = nodePaths
 	return nil
 }
 
@@ -283,7 +405,8 @@ func (options *VCreateDatabaseOptions) validateAnalyzeOptions(logger vlog.Printe
 	return options.analyzeOptions()
 }
 
-func (vcc VClusterCommands) VCreateDatabase(options *VCreateDatabaseOptions) (VCoordinationDatabase, error) {
+func (vcc VClusterCommands) VCreateDatabase(options *VCreateDatabaseOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandCreateDB, &options.DatabaseOptions, options, time.Now(), &err)
 	vcc.Log.Info("starting VCreateDatabase")
 
 	/*
@@ -293,7 +416,12 @@ func (vcc VClusterCommands) VCreateDatabase(options *VCreateDatabaseOptions) (VC
 	 */
 	// Analyze to produce vdb info, for later create db use and for cache db info
 	vdb := makeVCoordinationDatabase()
-	err := vdb.setFromCreateDBOptions(options, vcc.Log)
+	// on hosts with several network interfaces, use their addresses in the given subnet
+	err = vcc.useSubnetAddresses(options)
+	if err != nil {
+		return vdb, err
+	}
+	err = vdb.setFromCreateDBOptions(options, vcc.Log)
 	if err != nil {
 		return vdb, err
 	}
@@ -305,13 +433,25 @@ func (vcc VClusterCommands) VCreateDatabase(options *VCreateDatabaseOptions) (VC
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	clusterOpEngine.journal, err = options.makeJournal(commandCreateDB, options.DBName)
+	if err != nil {
+		return vdb, err
+	}
 
 	// Give the instructions to the VClusterOpEngine to run
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
 		vcc.Log.Error(err, "fail to create database")
+		if options.ForceCleanupOnFailure {
+			cleanupErr := vcc.cleanupAfterFailure(vdb.HostList, instructions, &certs)
+			// the cleanup undoes the progress recorded in the journal
+			if cleanupErr == nil {
+				cleanupErr = clusterOpEngine.journal.remove()
+			}
+			err = joinCleanupError(err, cleanupErr)
+		}
 		return vdb, err
 	}
 	return vdb, nil
@@ -323,6 +463,9 @@ func (vcc VClusterCommands) VCreateDatabase(options *VCreateDatabaseOptions) (VC
 // The generated instructions will later perform the following operations necessary
 // for a successful create_db:
 //   - Check NMA connectivity
+//   - Check NMA version compatibility
+//   - Check clock skew between hosts
+//   - Check port reachability between hosts
 //   - Check to see if any dbs running
 //   - Check NMA versions
 //   - Prepare directories
@@ -374,6 +517,28 @@ func (vcc VClusterCommands) produceCreateDBBootstrapInstructions(
 	initiator := getInitiator(hosts)
 
 	nmaHealthOp := makeNMAHealthOp(hosts)
+	nmaVersionOp := makeNMAVersionOp(hosts)
+	instructions = append(instructions, &nmaHealthOp, &nmaVersionOp)
+
+	// spread and the catalog are sensitive to clock skew, so we refuse to create
+	// a database on hosts whose clocks are too far apart
+	if !options.SkipClockCheck {
+		nmaClockSkewOp := makeNMAClockSkewOp(hosts, util.DefaultMaxClockSkewSeconds, true /*failOnSkew*/)
+		instructions = append(instructions, &nmaClockSkewOp)
+	}
+
+	if !options.SkipPortCheck && len(hosts) > 1 {
+		nmaCheckPortsOp := makeNMACheckPortsOp(hosts, options.ClientPort)
+		instructions = append(instructions, &nmaCheckPortsOp)
+	}
+
+	// hosts below the hardware prerequisites can still run a database, e.g.,
+	// for testing, so we only warn about them, and about the hosts that
+	// cannot be checked
+	if !options.SkipPrerequisiteCheck {
+		nmaCheckPrerequisitesOp := makeNMACheckPrerequisitesOpForCreateDB(hosts, &PrerequisiteReport{})
+		instructions = append(instructions, &nmaCheckPrerequisitesOp)
+	}
 
 	// require to have the same vertica version
 	nmaVerticaVersionOp := makeNMACheckVerticaVersionOp(hosts, true, vdb.IsEon)
@@ -412,8 +577,12 @@ func (vcc VClusterCommands) produceCreateDBBootstrapInstructions(
 		return instructions, err
 	}
 
+	// a resumed run skips the ops that changed the cluster in a previous run,
+	// and the check of running databases as the bootstrap node may be up
+	checkDBRunningOp.setCheckpointed()
+	nmaPrepareDirectoriesOp.setCheckpointed()
+	nmaBootstrapCatalogOp.setCheckpointed()
 	instructions = append(instructions,
-		&nmaHealthOp,
 		&nmaVerticaVersionOp,
 		&checkDBRunningOp,
 		&nmaPrepareDirectoriesOp,
@@ -429,6 +598,7 @@ func (vcc VClusterCommands) produceCreateDBBootstrapInstructions(
 	}
 
 	nmaStartNodeOp := makeNMAStartNodeOp(bootstrapHost, options.StartUpConf)
+	nmaStartNodeOp.setCheckpointed()
 
 	httpsPollBootstrapNodeStateOp, err := makeHTTPSPollNodeStateOpWithTimeoutAndCommand(bootstrapHost, true, /* useHTTPPassword */
 		options.UserName, options.Password, options.TimeoutNodeStartupSeconds, CreateDBCmd)
@@ -455,12 +625,32 @@ func (vcc VClusterCommands) produceCreateDBWorkerNodesInstructions(
 
 	newNodeHosts := util.SliceDiff(hosts, bootstrapHost)
 	if len(hosts) > 1 {
-		httpsCreateNodeOp, err := makeHTTPSCreateNodeOp(newNodeHosts, bootstrapHost,
-			true /* use password auth */, options.UserName, options.Password, vdb, "")
+		// the nodes are created in the order of the hosts, so that they get
+		// the node names computed by vcluster, but the hosts with different
+		// prefixes cannot be created by the same request
+		for _, hostGroup := range options.groupHostsByPrefixes(newNodeHosts) {
+			catalogPrefix, dataPrefix, _ := options.getNodePrefixes(hostGroup[0])
+			httpsCreateNodeOp, err := makeHTTPSCreateNodeOpWithPrefixes(hostGroup, bootstrapHost,
+				true /* use password auth */, options.UserName, options.Password, vdb.Name,
+				catalogPrefix, dataPrefix, "")
+			if err != nil {
+				return instructions, err
+			}
+			httpsCreateNodeOp.setCheckpointed()
+			instructions = append(instructions, &httpsCreateNodeOp)
+		}
+	}
+
+	// with fewer control nodes than nodes, the control nodes are distributed
+	// across the nodes and fault groups before spread is reloaded
+	if options.LargeCluster != util.DefaultLargeCluster && len(hosts) > options.LargeCluster {
+		httpsRealignControlNodesOp, err := makeHTTPSRealignControlNodesOp(bootstrapHost,
+			true /* use password auth */, options.UserName, options.Password, util.DefaultControlSetSize)
 		if err != nil {
 			return instructions, err
 		}
-		instructions = append(instructions, &httpsCreateNodeOp)
+		httpsRealignControlNodesOp.setCheckpointed()
+		instructions = append(instructions, &httpsRealignControlNodesOp)
 	}
 
 	httpsReloadSpreadOp, err := makeHTTPSReloadSpreadOpWithInitiator(bootstrapHost,
@@ -468,6 +658,7 @@ func (vcc VClusterCommands) produceCreateDBWorkerNodesInstructions(
 	if err != nil {
 		return instructions, err
 	}
+	httpsReloadSpreadOp.setCheckpointed()
 	instructions = append(instructions, &httpsReloadSpreadOp)
 
 	if len(hosts) > 1 {
@@ -491,6 +682,7 @@ func (vcc VClusterCommands) produceCreateDBWorkerNodesInstructions(
 			vdb.HostList,
 			vdb /*db configurations retrieved from a running db*/)
 		nmaStartNewNodesOp := makeNMAStartNodeOpWithVDB(newNodeHosts, options.StartUpConf, vdb)
+		nmaStartNewNodesOp.setCheckpointed()
 		instructions = append(instructions, &nmaStartNewNodesOp)
 	}
 
@@ -515,11 +707,20 @@ func (vcc VClusterCommands) produceAdditionalCreateDBInstructions(vdb *VCoordina
 		instructions = append(instructions, &httpsPollNodeStateOp)
 	}
 
-	if vdb.UseDepot {
+	if vdb.UseDepot && options.hasNodeDepotPaths() {
+		// the depot of each node is created at its own path
+		httpsCreateNodesDepotOp, err := makeHTTPSCreateNodesDepotOp(vdb, hosts, true, username, options.Password)
+		if err != nil {
+			return instructions, err
+		}
+		httpsCreateNodesDepotOp.setCheckpointed()
+		instructions = append(instructions, &httpsCreateNodesDepotOp)
+	} else if vdb.UseDepot {
 		httpsCreateDepotOp, err := makeHTTPSCreateClusterDepotOp(vdb, bootstrapHost, true, username, options.Password)
 		if err != nil {
 			return instructions, err
 		}
+		httpsCreateDepotOp.setCheckpointed()
 		instructions = append(instructions, &httpsCreateDepotOp)
 	}
 
@@ -529,6 +730,7 @@ func (vcc VClusterCommands) produceAdditionalCreateDBInstructions(vdb *VCoordina
 		if err != nil {
 			return instructions, err
 		}
+		httpsMarkDesignKSafeOp.setCheckpointed()
 		instructions = append(instructions, &httpsMarkDesignKSafeOp)
 	}
 
@@ -538,6 +740,7 @@ func (vcc VClusterCommands) produceAdditionalCreateDBInstructions(vdb *VCoordina
 		if err != nil {
 			return instructions, err
 		}
+		httpsInstallPackagesOp.setCheckpointed()
 		instructions = append(instructions, &httpsInstallPackagesOp)
 	}
 
@@ -546,6 +749,7 @@ func (vcc VClusterCommands) produceAdditionalCreateDBInstructions(vdb *VCoordina
 		if err != nil {
 			return instructions, err
 		}
+		httpsSyncCatalogOp.setCheckpointed()
 		instructions = append(instructions, &httpsSyncCatalogOp)
 	}
 	return instructions, nil
@@ -554,5 +758,6 @@ func (vcc VClusterCommands) produceAdditionalCreateDBInstructions(vdb *VCoordina
 func (vcc VClusterCommands) addEnableSpreadEncryptionOp(keyType string) clusterOp {
 	vcc.Log.Info("adding instruction to set key for spread encryption")
 	op := makeNMASpreadSecurityOp(vcc.Log, keyType)
+	op.setCheckpointed()
 	return &op
 }
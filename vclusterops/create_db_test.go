@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
 const defaultPath = "/data"
@@ -51,3 +53,54 @@ func TestValidateDepotSize(t *testing.T) {
 	assert.Equal(t, res, true)
 	assert.Nil(t, err)
 }
+
+func TestNodePaths(t *testing.T) {
+	options := VCreateDatabaseOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101", "192.168.1.102", "192.168.1.103"}
+	options.Hosts = options.RawHosts
+	options.CatalogPrefix = defaultPath
+	options.DataPrefix = defaultPath
+
+	options.NodePaths = map[string]VNodePaths{"192.168.1.104": {CatalogPrefix: "/ssd"}}
+	assert.ErrorContains(t, options.validateNodePaths(), "not in the hosts of the database")
+	options.NodePaths = map[string]VNodePaths{"192.168.1.102": {CatalogPrefix: "ssd"}}
+	assert.ErrorContains(t, options.validateNodePaths(), "must specify an absolute catalog path")
+	options.NodePaths = map[string]VNodePaths{"192.168.1.102": {DepotPrefix: "/ssd"}}
+	assert.ErrorContains(t, options.validateNodePaths(), "has no depot")
+
+	options.NodePaths = map[string]VNodePaths{"192.168.1.102": {CatalogPrefix: "/ssd", DataPrefix: "/hdd/"}}
+	assert.NoError(t, options.validateNodePaths())
+	assert.NoError(t, options.analyzeNodePaths())
+
+	vnode := VCoordinationNode{}
+	assert.NoError(t, vnode.setFromBasicDBOptions(&options, "192.168.1.102"))
+	assert.Equal(t, "/ssd/test_db/v_test_db_node0002_catalog", vnode.CatalogPath)
+	assert.Equal(t, []string{"/hdd/test_db/v_test_db_node0002_data"}, vnode.StorageLocations)
+
+	vnode = VCoordinationNode{}
+	assert.NoError(t, vnode.setFromBasicDBOptions(&options, "192.168.1.103"))
+	assert.Equal(t, "/data/test_db/v_test_db_node0003_catalog", vnode.CatalogPath)
+
+	// the hosts with other prefixes are created by separate requests, in order
+	assert.Equal(t, [][]string{{"192.168.1.102"}, {"192.168.1.103"}},
+		options.groupHostsByPrefixes([]string{"192.168.1.102", "192.168.1.103"}))
+}
+
+func TestMixedAddressFamilies(t *testing.T) {
+	options := VCreateDatabaseOptionsFactory()
+	options.DBName = "test_db"
+	options.CatalogPrefix = "/data"
+	options.DataPrefix = "/data"
+
+	// a host list can mix IPv4 and IPv6 addresses
+	options.RawHosts = []string{"192.168.1.101", "2001:db8::2"}
+	assert.NoError(t, options.analyzeOptions())
+	assert.Equal(t, []string{"192.168.1.101", "2001:db8::2"}, options.Hosts)
+
+	// the family is detected per host
+	vdb := makeVCoordinationDatabase()
+	assert.NoError(t, vdb.setFromCreateDBOptions(&options, vlog.Printer{}))
+	assert.Equal(t, util.DefaultControlAddressFamily, vdb.HostNodeMap["192.168.1.101"].ControlAddressFamily)
+	assert.Equal(t, util.IPv6ControlAddressFamily, vdb.HostNodeMap["2001:db8::2"].ControlAddressFamily)
+}
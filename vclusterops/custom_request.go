@@ -0,0 +1,107 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VCustomRequestOptions struct {
+	DatabaseOptions
+	// whether the request is sent to the NMA instead of the Vertica HTTPS service
+	IsNMA bool
+	// one of GET, PUT, POST and DELETE
+	Method string
+	// the path of the endpoint without its version, e.g., "nodes" or "health"
+	Endpoint string
+	// optional query parameters of the request
+	QueryParams map[string]string
+	// optional body of the request, which is not audited
+	// because it may contain secrets
+	Body string `json:"-"`
+}
+
+// CustomRequestResult is the response of a host to a custom request
+type CustomRequestResult struct {
+	// 0 if no response was received
+	StatusCode int
+	Content    string
+	// set if the request failed on the host
+	Err      error
+	Duration time.Duration
+}
+
+func VCustomRequestOptionsFactory() VCustomRequestOptions {
+	options := VCustomRequestOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+	options.Method = GetMethod
+
+	return options
+}
+
+func (options *VCustomRequestOptions) validateParseOptions(logger vlog.Printer) error {
+	logger.Info("Validating options", "command", commandCustomRequest)
+	// the endpoints may not need a database, only the hosts are needed
+	if len(options.RawHosts) == 0 {
+		return errors.New("must specify a host or host list")
+	}
+	options.Method = strings.ToUpper(options.Method)
+	switch options.Method {
+	case GetMethod, PutMethod, PostMethod, DeleteMethod:
+	default:
+		return fmt.Errorf("invalid HTTP method %q: must be one of %s, %s, %s and %s",
+			options.Method, GetMethod, PutMethod, PostMethod, DeleteMethod)
+	}
+	options.Endpoint = strings.TrimPrefix(options.Endpoint, "/")
+	if options.Endpoint == "" {
+		return errors.New("must specify an endpoint")
+	}
+	return options.setUsePassword(logger)
+}
+
+// VSendCustomRequest sends a request to an endpoint of the NMA or of the
+// Vertica HTTPS service of every host, e.g., an endpoint that vclusterops does
+// not wrap yet. The request uses the same certificates and authentication as
+// the other commands. It returns the result of each host, even if the request
+// failed on some hosts, in which case the error joins the errors of these
+// hosts.
+func (vcc VClusterCommands) VSendCustomRequest(options *VCustomRequestOptions) (_ map[string]CustomRequestResult, err error) {
+	defer vcc.audit(commandCustomRequest, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	customRequestOp, err := makeCustomRequestOp(options.Hosts, options.usePassword,
+		options.UserName, options.Password, options)
+	if err != nil {
+		return nil, err
+	}
+	err = vcc.runSingleOp(&customRequestOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to send request %s %s", options.Method, options.Endpoint))
+	return customRequestOp.hostResults, err
+}
@@ -0,0 +1,128 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type customRequestOp struct {
+	opBase
+	opHTTPSBase
+	isNMA       bool
+	method      string
+	endpoint    string
+	queryParams map[string]string
+	body        string
+	// host -> result of the request on that host
+	hostResults map[string]CustomRequestResult
+}
+
+// makeCustomRequestOp will create an op that sends the same request to the
+// NMA or the HTTPS service of every host, and records the result of each host
+// without interpreting it
+func makeCustomRequestOp(hosts []string, useHTTPPassword bool, userName string, httpsPassword *string,
+	options *VCustomRequestOptions) (customRequestOp, error) {
+	op := customRequestOp{}
+	op.name = "CustomRequestOp"
+	op.description = fmt.Sprintf("Send request %s %s", options.Method, options.Endpoint)
+	op.hosts = hosts
+	op.isNMA = options.IsNMA
+	op.method = options.Method
+	op.endpoint = options.Endpoint
+	op.queryParams = options.QueryParams
+	op.body = options.Body
+	op.hostResults = make(map[string]CustomRequestResult)
+
+	// the NMA endpoints do not use the database password
+	if op.isNMA {
+		return op, nil
+	}
+	op.useHTTPPassword = useHTTPPassword
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *customRequestOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = op.method
+		if op.isNMA {
+			httpRequest.buildNMAEndpoint(op.endpoint)
+		} else {
+			httpRequest.buildHTTPSEndpoint(op.endpoint)
+			if op.useHTTPPassword {
+				httpRequest.Password = op.httpsPassword
+				httpRequest.Username = op.userName
+			}
+		}
+		httpRequest.QueryParams = op.queryParams
+		httpRequest.RequestData = op.body
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *customRequestOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *customRequestOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *customRequestOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *customRequestOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		hostResult := CustomRequestResult{
+			StatusCode: result.statusCode,
+			Content:    result.content,
+			Duration:   result.duration,
+		}
+		if result.isUnauthorizedRequest() {
+			result.err = errors.Join(result.err, makeWrongCredentialError(op.name, host))
+		}
+		if !result.isPassing() {
+			hostResult.Err = result.err
+			allErrs = errors.Join(allErrs, result.err)
+		}
+		op.hostResults[host] = hostResult
+	}
+
+	return allErrs
+}
@@ -0,0 +1,70 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateCustomRequestOptions(t *testing.T) {
+	logger := vlog.Printer{}
+	options := VCustomRequestOptionsFactory()
+	assert.ErrorContains(t, options.validateParseOptions(logger), "must specify a host")
+
+	options.RawHosts = []string{"192.0.2.1"}
+	assert.ErrorContains(t, options.validateParseOptions(logger), "must specify an endpoint")
+
+	options.Endpoint = "/nodes"
+	options.Method = "patch"
+	assert.ErrorContains(t, options.validateParseOptions(logger), "invalid HTTP method \"PATCH\"")
+
+	options.Method = "post"
+	assert.NoError(t, options.validateParseOptions(logger))
+	assert.Equal(t, PostMethod, options.Method)
+	assert.Equal(t, "nodes", options.Endpoint)
+}
+
+func TestCustomRequestOp(t *testing.T) {
+	options := VCustomRequestOptionsFactory()
+	options.IsNMA = true
+	options.Endpoint = "health"
+	hosts := []string{"192.0.2.1", "192.0.2.2"}
+	op, err := makeCustomRequestOp(hosts, false, "", nil, &options)
+	assert.NoError(t, err)
+
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	request := op.clusterHTTPRequest.RequestCollection["192.0.2.1"]
+	assert.True(t, request.IsNMACommand)
+	assert.Equal(t, NMACurVersion+"health", request.Endpoint)
+	assert.Equal(t, GetMethod, request.Method)
+
+	// the results of all hosts are kept, even if some hosts failed
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: `{"healthy": "true"}`},
+		"192.0.2.2": {host: "192.0.2.2", status: EXCEPTION, err: errors.New("connection refused")},
+	}
+	err = op.processResult(nil)
+	assert.ErrorContains(t, err, "connection refused")
+	assert.Len(t, op.hostResults, 2)
+	assert.Equal(t, `{"healthy": "true"}`, op.hostResults["192.0.2.1"].Content)
+	assert.NoError(t, op.hostResults["192.0.2.1"].Err)
+	assert.Error(t, op.hostResults["192.0.2.2"].Err)
+}
@@ -0,0 +1,60 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DatabaseDescription is the description of a database that is stored in
+// cluster_config.json on communal storage
+type DatabaseDescription struct {
+	ClusterLeaseExpiration string               `json:"ClusterLeaseExpiration"`
+	Nodes                  []NodeDescription    `json:"Node"`
+	Shards                 []ShardDescription   `json:"Shard"`
+	StorageLocations       []StorageDescription `json:"StorageLocation"`
+}
+
+type NodeDescription struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	CatalogPath string `json:"catalogPath"`
+	IsPrimary   bool   `json:"isPrimary"`
+}
+
+type ShardDescription struct {
+	Name      string `json:"name"`
+	ShardType string `json:"shardType"`
+}
+
+type StorageDescription struct {
+	// the name of a node's storage location contains the node name,
+	// e.g., "__location_1_v_test_db_node0001"
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Usage int    `json:"usage"`
+}
+
+// parseDatabaseDescription parses the content of cluster_config.json
+func parseDatabaseDescription(content string) (*DatabaseDescription, error) {
+	desc := DatabaseDescription{}
+	err := json.Unmarshal([]byte(content), &desc)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse the description of the database: %w", err)
+	}
+	return &desc, nil
+}
@@ -0,0 +1,64 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDatabaseDescription(t *testing.T) {
+	content := `{
+		"ClusterLeaseExpiration": "2024-01-01 10:00:00.000000",
+		"Node": [
+			{"name": "v_test_db_node0001", "address": "192.168.1.101",
+			 "catalogPath": "/data/test_db/v_test_db_node0001_catalog/Catalog", "isPrimary": true},
+			{"name": "v_test_db_node0002", "address": "192.168.1.102",
+			 "catalogPath": "/data/test_db/v_test_db_node0002_catalog/Catalog", "isPrimary": false}
+		],
+		"Shard": [
+			{"name": "replica", "shardType": "Replica"},
+			{"name": "segment0001", "shardType": "Segment"}
+		],
+		"StorageLocation": [
+			{"name": "__location_0_v_test_db_node0001", "path": "/data/test_db/v_test_db_node0001_data", "usage": 1},
+			{"name": "__location_1_v_test_db_node0001", "path": "/depot/test_db/v_test_db_node0001_depot", "usage": 5}
+		]
+	}`
+
+	desc, err := parseDatabaseDescription(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-01 10:00:00.000000", desc.ClusterLeaseExpiration)
+	assert.Len(t, desc.Nodes, 2)
+	assert.True(t, desc.Nodes[0].IsPrimary)
+	assert.Equal(t, "192.168.1.102", desc.Nodes[1].Address)
+	assert.Equal(t, []ShardDescription{{Name: "replica", ShardType: "Replica"},
+		{Name: "segment0001", ShardType: "Segment"}}, desc.Shards)
+	assert.Equal(t, depotStorageType, desc.StorageLocations[1].Usage)
+
+	// revive_db keeps the segment shards to remap them
+	vdb := makeVCoordinationDatabase()
+	op := nmaDownloadFileOp{vdb: &vdb}
+	err = op.buildVDBFromClusterConfig(*desc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"segment0001"}, vdb.ShardNames)
+	assert.Equal(t, 1, vdb.NumShards)
+	assert.Equal(t, "/data/test_db/v_test_db_node0001_catalog", vdb.HostNodeMap["192.168.1.101"].CatalogPath)
+
+	_, err = parseDatabaseDescription("not json")
+	assert.Error(t, err)
+}
@@ -0,0 +1,186 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the NMA rejects request bodies larger than this, and the content grows by
+// a third once it is base64 encoded
+const maxDistributeFileSize = 64 * 1024 * 1024
+
+type VDistributeFileOptions struct {
+	DatabaseOptions
+	// path of the file on the host that runs vcluster
+	LocalPath string
+	// absolute path of the file on the target hosts
+	DestinationPath string
+	// hosts to which the file is pushed. If empty, the file is pushed to
+	// all the hosts of the database.
+	TargetHosts []string
+	// octal permission of the file on the target hosts, e.g., "0644".
+	// If empty, the NMA default applies.
+	FileMode string
+	// replace the file if it already exists on a target host
+	Overwrite bool
+}
+
+// VDistributeFileResult describes the file that was pushed to the hosts
+type VDistributeFileResult struct {
+	DestinationPath string `json:"destination_path"`
+	Size            int64  `json:"size"`
+	// hex-encoded SHA-256 checksum of the file, verified on every host
+	Checksum string `json:"checksum"`
+	// hosts that have the file, sorted
+	Hosts []string `json:"hosts"`
+}
+
+func VDistributeFileOptionsFactory() VDistributeFileOptions {
+	options := VDistributeFileOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VDistributeFileOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VDistributeFileOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandDistributeFile, logger)
+	if err != nil {
+		return err
+	}
+	if options.LocalPath == "" {
+		return fmt.Errorf("must specify the local path of the file to distribute")
+	}
+	if !filepath.IsAbs(options.DestinationPath) {
+		return fmt.Errorf("must specify an absolute destination path, got %q", options.DestinationPath)
+	}
+	if options.FileMode != "" {
+		mode, err := strconv.ParseUint(options.FileMode, 8, 32)
+		if err != nil || mode > uint64(os.ModePerm) {
+			return fmt.Errorf("invalid file mode %q, must be an octal permission like 0644", options.FileMode)
+		}
+	}
+	return nil
+}
+
+func (options *VDistributeFileOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(options.TargetHosts) == 0 {
+		options.TargetHosts = options.Hosts
+	} else {
+		options.TargetHosts, err = util.ResolveRawHostsToAddresses(options.TargetHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	if len(options.TargetHosts) == 0 {
+		return fmt.Errorf("must specify at least one host to which the file is distributed")
+	}
+	return nil
+}
+
+func (options *VDistributeFileOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VDistributeFile pushes a local file, e.g., a license, the library of a UDx
+// or a configuration override, to the same path on the target hosts through
+// the NMA. The checksum of the file written on each host is compared with the
+// checksum of the local file, and a mismatch is reported as a failure.
+func (vcc VClusterCommands) VDistributeFile(options *VDistributeFileOptions) (result VDistributeFileResult, err error) {
+	defer vcc.audit(commandDistributeFile, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Read the local file
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return result, err
+	}
+
+	content, err := readFileToDistribute(options.LocalPath)
+	if err != nil {
+		return result, err
+	}
+
+	nmaHealthOp := makeNMAHealthOp(options.TargetHosts)
+	nmaUploadFileOp := makeNMAUploadFileOp(options.TargetHosts, options.DestinationPath, content,
+		options.FileMode, options.Overwrite)
+	instructions := []clusterOp{&nmaHealthOp, &nmaUploadFileOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	runErr := clusterOpEngine.run(vcc.Log)
+
+	result.DestinationPath = options.DestinationPath
+	result.Size = int64(len(content))
+	result.Checksum = nmaUploadFileOp.checksum
+	for host := range nmaUploadFileOp.hostChecksums {
+		result.Hosts = append(result.Hosts, host)
+	}
+	sort.Strings(result.Hosts)
+	if runErr != nil {
+		return result, fmt.Errorf("fail to distribute %s to hosts %v: %w", options.LocalPath, options.TargetHosts, runErr)
+	}
+	return result, nil
+}
+
+// readFileToDistribute reads a regular file that is small enough to be
+// sent in the body of an NMA request
+func readFileToDistribute(localPath string) ([]byte, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("fail to access %s: %w", localPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", localPath)
+	}
+	if info.Size() > maxDistributeFileSize {
+		return nil, fmt.Errorf("%s is %d bytes, larger than the limit of %d bytes", localPath, info.Size(), maxDistributeFileSize)
+	}
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read %s: %w", localPath, err)
+	}
+	return content, nil
+}
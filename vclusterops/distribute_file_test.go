@@ -0,0 +1,106 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateDistributeFileOptions(t *testing.T) {
+	options := VDistributeFileOptionsFactory()
+	options.DBName = dbName
+	options.RawHosts = []string{"192.0.2.1", "192.0.2.2"}
+	options.LocalPath = "/tmp/license.key"
+	options.DestinationPath = "/opt/vertica/config/license.key"
+	assert.NoError(t, options.validateAnalyzeOptions(vlog.Printer{}))
+	// the file goes to all the hosts by default
+	assert.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, options.TargetHosts)
+
+	options.DestinationPath = "config/license.key"
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "absolute destination path")
+
+	options.DestinationPath = "/opt/vertica/config/license.key"
+	options.FileMode = "0644"
+	assert.NoError(t, options.validateAnalyzeOptions(vlog.Printer{}))
+	options.FileMode = "rw-r--r--"
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "invalid file mode")
+}
+
+func TestReadFileToDistribute(t *testing.T) {
+	dir := t.TempDir()
+	_, err := readFileToDistribute(dir)
+	assert.ErrorContains(t, err, "not a regular file")
+
+	path := filepath.Join(dir, "license.key")
+	assert.NoError(t, os.WriteFile(path, []byte("license"), 0o600))
+	content, err := readFileToDistribute(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("license"), content)
+}
+
+func TestUploadFileOpChecksum(t *testing.T) {
+	content := []byte("test")
+	hosts := []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}
+	op := makeNMAUploadFileOp(hosts, "/opt/vertica/config/license.key", content, "0600", false)
+	op.logger = vlog.Printer{}
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", op.checksum)
+
+	// the content is sent base64 encoded
+	assert.NoError(t, op.setupRequestBody())
+	data := uploadFileRequestData{}
+	assert.NoError(t, json.Unmarshal([]byte(op.hostRequestBodyMap["192.0.2.1"]), &data))
+	decoded, err := base64.StdEncoding.DecodeString(data.FileContent)
+	assert.NoError(t, err)
+	assert.Equal(t, content, decoded)
+	assert.Equal(t, "0600", data.FileMode)
+
+	makeResponse := func(checksum string) string {
+		return fmt.Sprintf(`{"destination_file_path": "/opt/vertica/config/license.key", "size": 4, "checksum": %q}`, checksum)
+	}
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: makeResponse(op.checksum)},
+		"192.0.2.2": {host: "192.0.2.2", status: SUCCESS, statusCode: SuccessCode, content: makeResponse("bad")},
+		"192.0.2.3": {host: "192.0.2.3", status: SUCCESS, statusCode: SuccessCode, content: makeResponse(op.checksum)},
+	}
+	err = op.processResult(nil)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.ErrorContains(t, err, "192.0.2.2")
+	assert.Equal(t, map[string]string{"192.0.2.1": op.checksum, "192.0.2.3": op.checksum}, op.hostChecksums)
+}
+
+func TestUploadCommunalFileOp(t *testing.T) {
+	hosts := []string{"192.0.2.1", "192.0.2.2"}
+	parameters := map[string]string{"awsauth": "key:secret"}
+	op := makeNMAUploadCommunalFileOp(hosts, "s3://bucket/db/metadata/test_db/hibernation.json", "{}", parameters)
+
+	// the file is written once, by the NMA of the initiator, with the
+	// same request and checksum check as any other upload
+	assert.Equal(t, []string{getInitiator(hosts)}, op.hosts)
+	assert.Equal(t, computeChecksum([]byte("{}")), op.checksum)
+	assert.NoError(t, op.setupRequestBody())
+	data := uploadFileRequestData{}
+	assert.NoError(t, json.Unmarshal([]byte(op.hostRequestBodyMap[op.hosts[0]]), &data))
+	assert.Equal(t, parameters, data.Parameters)
+	assert.True(t, data.Overwrite)
+}
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
@@ -70,7 +71,8 @@ func (options *VDropDatabaseOptions) validateAnalyzeOptions() error {
 	return options.analyzeOptions()
 }
 
-func (vcc VClusterCommands) VDropDatabase(options *VDropDatabaseOptions) error {
+func (vcc VClusterCommands) VDropDatabase(options *VDropDatabaseOptions) (err error) {
+	defer vcc.audit(commandDropDB, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -80,7 +82,7 @@ func (vcc VClusterCommands) VDropDatabase(options *VDropDatabaseOptions) error {
 	// Analyze to produce vdb info for drop db use
 	vdb := makeVCoordinationDatabase()
 
-	err := options.validateAnalyzeOptions()
+	err = options.validateAnalyzeOptions()
 	if err != nil {
 		return err
 	}
@@ -97,8 +99,8 @@ func (vcc VClusterCommands) VDropDatabase(options *VDropDatabaseOptions) error {
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -116,6 +118,7 @@ func (vcc VClusterCommands) VDropDatabase(options *VDropDatabaseOptions) error {
 // for a successful drop_db:
 //   - Check NMA connectivity
 //   - Check to see if any dbs running
+//   - Check that no vertica process runs on any host
 //   - Delete directories
 func (vcc VClusterCommands) produceDropDBInstructions(vdb *VCoordinationDatabase, options *VDropDatabaseOptions) ([]clusterOp, error) {
 	var instructions []clusterOp
@@ -140,6 +143,12 @@ func (vcc VClusterCommands) produceDropDBInstructions(vdb *VCoordinationDatabase
 		return instructions, err
 	}
 
+	// the HTTPS service may not be up yet on a node that is starting or is
+	// down already on a node that is stopping, so we also check the processes
+	nmaCheckProcessesOp := makeNMACheckProcessesOp(hosts, true, /*failIfVerticaRunning*/
+		true /*ignoreNotFound*/, make(map[string]*HostProcesses))
+	nmaCheckProcessesOp.action = "dropping"
+
 	nmaDeleteDirectoriesOp, err := makeNMADeleteDirectoriesOp(vdb, options.ForceDelete)
 	if err != nil {
 		return instructions, err
@@ -148,6 +157,7 @@ func (vcc VClusterCommands) produceDropDBInstructions(vdb *VCoordinationDatabase
 	instructions = append(instructions,
 		&nmaHealthOp,
 		&checkDBRunningOp,
+		&nmaCheckProcessesOp,
 		&nmaDeleteDirectoriesOp,
 	)
 
@@ -0,0 +1,134 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import "fmt"
+
+// The errors below are returned, possibly wrapped, by the vclusterops APIs.
+// Callers can use errors.As to check for one of them and react to a specific
+// kind of failure, e.g.:
+//
+//	var credErr *WrongCredentialError
+//	if errors.As(err, &credErr) {
+//		// ask the user for a new password
+//	}
+
+// NoQuorumError is an error to indicate that not enough primary nodes
+// took part in an operation to reach quorum.
+type NoQuorumError struct {
+	Detail string
+}
+
+func (e *NoQuorumError) Error() string {
+	return e.Detail
+}
+
+// NodeNotFoundError is an error to indicate that one or more of the
+// given hosts or nodes are not part of the database.
+type NodeNotFoundError struct {
+	Detail string
+	// the hosts or node names that cannot be found
+	Nodes []string
+}
+
+func (e *NodeNotFoundError) Error() string {
+	return e.Detail
+}
+
+// WrongCredentialError is an error to indicate that the https service
+// rejected the password or the certificate on some hosts.
+type WrongCredentialError struct {
+	Detail string
+	Hosts  []string
+}
+
+func (e *WrongCredentialError) Error() string {
+	return e.Detail
+}
+
+// makeWrongCredentialError builds the error returned by an op when the https
+// service rejects the credentials on the given host
+func makeWrongCredentialError(opName, host string) *WrongCredentialError {
+	return &WrongCredentialError{
+		Detail: fmt.Sprintf("[%s] wrong password/certificate for https service on host %s", opName, host),
+		Hosts:  []string{host},
+	}
+}
+
+// VersionMismatchError is an error to indicate that the hosts do not
+// all run the same Vertica version.
+type VersionMismatchError struct {
+	Detail string
+	// the two versions that were found different
+	Versions []string
+}
+
+func (e *VersionMismatchError) Error() string {
+	return e.Detail
+}
+
+// HostUnreachableError is an error to indicate that a host could not be
+// reached, most likely because it is down.
+type HostUnreachableError struct {
+	Detail string
+	Host   string
+}
+
+func (e *HostUnreachableError) Error() string {
+	return e.Detail
+}
+
+// makeHostUnreachableError builds the error returned by an op when a
+// request to the given host timed out
+func makeHostUnreachableError(opName, host string) *HostUnreachableError {
+	return &HostUnreachableError{
+		Detail: fmt.Sprintf("[%s] cannot connect to host %s, please check if the host is still alive", opName, host),
+		Host:   host,
+	}
+}
+
+// PartialSuccessError is an error to indicate that an operation
+// succeeded on some hosts but failed on the others.
+type PartialSuccessError struct {
+	Detail  string
+	Summary HostResultSummary
+}
+
+func (e *PartialSuccessError) Error() string {
+	return e.Detail
+}
+
+// the actual type of a field of ResponseSchemaError that is not in the response
+const responseFieldMissing = "missing"
+
+// ResponseSchemaError is an error to indicate that the response of a host
+// misses a field that vclusterops needs, or that the field has a wrong type.
+type ResponseSchemaError struct {
+	Host string
+	// the path of the field, e.g., "node_list[0].address"
+	Field string
+	// the JSON types, or "missing" as actual type if the field is not in the response
+	Expected string
+	Actual   string
+}
+
+func (e *ResponseSchemaError) Error() string {
+	if e.Actual == responseFieldMissing {
+		return fmt.Sprintf("the response of host %s does not contain the field %q", e.Host, e.Field)
+	}
+	return fmt.Sprintf("the field %q in the response of host %s is a(n) %s, expected a(n) %s",
+		e.Field, e.Host, e.Actual, e.Expected)
+}
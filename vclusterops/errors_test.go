@@ -0,0 +1,82 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedErrors(t *testing.T) {
+	// a wrapped error can still be matched with errors.As
+	err := fmt.Errorf("fail to start database: %w", makeWrongCredentialError("HTTPSPollNodeStateOp", "192.0.2.1"))
+	var credErr *WrongCredentialError
+	assert.True(t, errors.As(err, &credErr))
+	assert.Equal(t, []string{"192.0.2.1"}, credErr.Hosts)
+	assert.Equal(t, "[HTTPSPollNodeStateOp] wrong password/certificate for https service on host 192.0.2.1", credErr.Error())
+
+	// an error joined with other errors
+	err = errors.Join(errors.New("some other error"), makeHostUnreachableError("HTTPSPollNodeStateOp", "192.0.2.2"))
+	var unreachableErr *HostUnreachableError
+	assert.True(t, errors.As(err, &unreachableErr))
+	assert.Equal(t, "192.0.2.2", unreachableErr.Host)
+	assert.False(t, errors.As(err, &credErr))
+
+	// the re-ip quorum error is also a generic quorum error
+	err = &ReIPNoClusterQuorumError{Detail: "[HTTPSReIPOp] 1 up nodes are not enough for re-ip"}
+	var quorumErr *NoQuorumError
+	assert.True(t, errors.As(err, &quorumErr))
+	assert.Equal(t, err.Error(), quorumErr.Error())
+}
+
+func TestStopNodeRequirementsNodeNotFound(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.0.2.1"] = &VCoordinationNode{Address: "192.0.2.1"}
+
+	assert.NoError(t, checkStopNodeRequirements(&vdb, []string{"192.0.2.1"}))
+
+	err := checkStopNodeRequirements(&vdb, []string{"192.0.2.3"})
+	var notFoundErr *NodeNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, []string{"192.0.2.3"}, notFoundErr.Nodes)
+}
+
+func TestOpConstructorsNodeNotFound(t *testing.T) {
+	hostNodeMap := makeVHostNodeMap()
+	hostNodeMap["192.0.2.1"] = &VCoordinationNode{Address: "192.0.2.1", Name: "v_test_db_node0001"}
+
+	op, err := makeHTTPSSpreadRemoveNodeOp([]string{"192.0.2.1"}, []string{"192.0.2.1"}, false, "", nil, hostNodeMap)
+	assert.NoError(t, err)
+	assert.Equal(t, "v_test_db_node0001", op.RequestParams["remove-target"])
+
+	// a host that is not in the database is an error instead of a crash
+	_, err = makeHTTPSSpreadRemoveNodeOp([]string{"192.0.2.3"}, []string{"192.0.2.1"}, false, "", nil, hostNodeMap)
+	var notFoundErr *NodeNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, []string{"192.0.2.3"}, notFoundErr.Nodes)
+
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = hostNodeMap
+	depotOp, err := makeHTTPSCreateNodesDepotOp(&vdb, []string{"192.0.2.3"}, false, "", nil)
+	assert.NoError(t, err)
+	depotOp.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	err = depotOp.setupClusterHTTPRequest(depotOp.hosts)
+	assert.True(t, errors.As(err, &notFoundErr))
+}
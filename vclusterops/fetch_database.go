@@ -17,6 +17,8 @@ package vclusterops
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -26,6 +28,9 @@ type VFetchCoordinationDatabaseOptions struct {
 	DatabaseOptions
 	Overwrite   bool // overwrite existing config file at the same location
 	AfterRevive bool // whether recover config right after revive_db
+	// whether to rebuild the config from the description file on communal storage
+	// instead of the catalogs, e.g., when the NMA is unreachable on some hosts
+	FromCommunal bool
 
 	// hidden option
 	readOnly bool // this should be only used if we don't want to update the config file
@@ -39,7 +44,16 @@ func VRecoverConfigOptionsFactory() VFetchCoordinationDatabaseOptions {
 }
 
 func (options *VFetchCoordinationDatabaseOptions) validateParseOptions(logger vlog.Printer) error {
-	return options.validateBaseOptions(commandConfigRecover, logger)
+	if !options.FromCommunal {
+		return options.validateBaseOptions(commandConfigRecover, logger)
+	}
+
+	// the paths are read from the description file, so they are not required
+	err := options.validateBaseOptions(commandConfigRecoverFromCommunal, logger)
+	if err != nil {
+		return err
+	}
+	return util.ValidateCommunalStorageLocation(options.CommunalStorageLocation)
 }
 
 func (options *VFetchCoordinationDatabaseOptions) analyzeOptions() error {
@@ -73,7 +87,8 @@ func (options *VFetchCoordinationDatabaseOptions) validateAnalyzeOptions(logger
 	return options.analyzeOptions()
 }
 
-func (vcc VClusterCommands) VFetchCoordinationDatabase(options *VFetchCoordinationDatabaseOptions) (VCoordinationDatabase, error) {
+func (vcc VClusterCommands) VFetchCoordinationDatabase(options *VFetchCoordinationDatabaseOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandConfigRecover, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -82,11 +97,15 @@ func (vcc VClusterCommands) VFetchCoordinationDatabase(options *VFetchCoordinati
 
 	var vdb VCoordinationDatabase
 
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return vdb, err
 	}
 
+	if options.FromCommunal {
+		return vcc.fetchCoordinationDatabaseFromCommunal(options)
+	}
+
 	// pre-fill vdb from the user input
 	vdb.Name = options.DBName
 	vdb.HostList = options.Hosts
@@ -101,8 +120,8 @@ func (vcc VClusterCommands) VFetchCoordinationDatabase(options *VFetchCoordinati
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -135,6 +154,91 @@ func (vcc VClusterCommands) VFetchCoordinationDatabase(options *VFetchCoordinati
 	return vdb, runError
 }
 
+// fetchCoordinationDatabaseFromCommunal builds the database from cluster_config.json
+// on communal storage, which is downloaded by the NMA of any reachable host.
+// This works when all the nodes are down and the NMA is unreachable on some
+// hosts, but the description file does not have the subclusters and sandboxes
+// of the nodes, and its addresses are the ones of the last sync of the catalog.
+func (vcc VClusterCommands) fetchCoordinationDatabaseFromCommunal(
+	options *VFetchCoordinationDatabaseOptions) (vdb VCoordinationDatabase, err error) {
+	certs := options.buildHTTPSCerts()
+
+	// step 1: find the hosts on which the NMA is reachable
+	healthyVDB := makeVCoordinationDatabase()
+	nmaGetHealthyNodesOp := makeNMAGetHealthyNodesOp(options.Hosts, &healthyVDB)
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&nmaGetHealthyNodesOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return vdb, fmt.Errorf("fail to find a host to read communal storage from: %w", err)
+	}
+
+	// step 2: download cluster_config.json with one of them
+	vdb = makeVCoordinationDatabase()
+	nmaDownloadFileOp, err := makeNMADownloadFileOp(healthyVDB.HostList, options.getCurrConfigFilePath(),
+		currConfigFileDestPath, catalogPath, options.getCommunalStorageParameters(), &vdb)
+	if err != nil {
+		return vdb, err
+	}
+	clusterOpEngine = vcc.makeClusterOpEngine([]clusterOp{&nmaDownloadFileOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return vdb, fmt.Errorf("fail to read %s from communal storage: %w", descriptionFileName, err)
+	}
+
+	vdb.Name = options.DBName
+	vdb.IsEon = true
+	vdb.CommunalStorageLocation = options.CommunalStorageLocation
+	vdb.Ipv6 = options.IPv6
+	vdb.CatalogPrefix = options.CatalogPrefix
+	vdb.DataPrefix = options.DataPrefix
+	vdb.DepotPrefix = options.DepotPrefix
+
+	for _, host := range options.Hosts {
+		if _, ok := vdb.HostNodeMap[host]; !ok {
+			vcc.Log.PrintWarning("Host %s is not in %s, the addresses of the nodes may be out of date",
+				host, descriptionFileName)
+		}
+	}
+	vcc.Log.PrintWarning("The subclusters of the nodes are not in %s, run manage_config show --sync "+
+		"once the database is up to add them to the config file", descriptionFileName)
+
+	return vdb, nil
+}
+
+// VFetchRunningCoordinationDatabase fetches the current topology of a running
+// database, including its sandboxes, from the HTTPS service of its up nodes,
+// e.g., to refresh the config file after other tools changed the database.
+// Unlike VFetchCoordinationDatabase, it does not need the NMA or the catalog
+// path, but it fails if the database is down. The path prefixes of the options
+// are kept in the returned database.
+func (vcc VClusterCommands) VFetchRunningCoordinationDatabase(
+	options *VFetchCoordinationDatabaseOptions) (vdb VCoordinationDatabase, err error) {
+	defer vcc.audit(commandConfigSync, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateBaseOptions(commandConfigSync, vcc.Log)
+	if err != nil {
+		return vdb, err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return vdb, err
+	}
+
+	vdb = makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return vdb, fmt.Errorf("fail to fetch the topology of running database %s: %w", options.DBName, err)
+	}
+	vdb.Name = options.DBName
+	vdb.Ipv6 = options.IPv6
+	vdb.CatalogPrefix = options.CatalogPrefix
+	vdb.DataPrefix = options.DataPrefix
+	vdb.DepotPrefix = options.DepotPrefix
+	// keep the order of the hosts stable across the refreshes
+	sort.Strings(vdb.HostList)
+	return vdb, nil
+}
+
 // produceRecoverConfigInstructions will build a list of instructions to execute for
 // the recover config operation.
 
@@ -0,0 +1,109 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VFetchDatabaseInfoOptions struct {
+	DatabaseOptions
+}
+
+// VDatabaseInfo is the complete description of a running database
+type VDatabaseInfo struct {
+	// the database and its nodes, with their states, paths, subclusters and
+	// sandboxes. Use its Nodes() method to list the nodes.
+	Database VCoordinationDatabase
+	// the subclusters with their nodes and shard coverage, sorted by name.
+	// It is empty for an Enterprise database.
+	Subclusters []SubclusterDetails
+	// the names of the sandboxes, sorted
+	Sandboxes []string
+}
+
+func VFetchDatabaseInfoOptionsFactory() VFetchDatabaseInfoOptions {
+	options := VFetchDatabaseInfoOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VFetchDatabaseInfoOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VFetchDatabaseInfoOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandFetchDatabaseInfo, logger)
+	if err != nil {
+		return err
+	}
+	return resolveRawHosts(&options.DatabaseOptions)
+}
+
+// VFetchDatabaseInfo returns the complete description of a running database:
+// its nodes with their states and paths, its subclusters with their shard
+// coverage and its sandboxes. It only uses the HTTPS service of the up nodes,
+// so it is meant for tools that need the topology of a database without
+// parsing the responses of the endpoints themselves.
+func (vcc VClusterCommands) VFetchDatabaseInfo(options *VFetchDatabaseInfoOptions) (info VDatabaseInfo, err error) {
+	defer vcc.audit(commandFetchDatabaseInfo, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return info, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return info, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	vdb.Name = options.DBName
+	vdb.Ipv6 = options.IPv6
+	sort.Strings(vdb.HostList)
+	info.Sandboxes = vdb.getSandboxNames()
+
+	if vdb.IsEon {
+		info.Subclusters, err = vcc.fetchSubclusterDetails(&vdb, &options.DatabaseOptions)
+		if err != nil {
+			return info, err
+		}
+		if len(info.Subclusters) > 0 {
+			vdb.NumShards = info.Subclusters[0].TotalShardCount
+		}
+	}
+	info.Database = vdb
+	return info, nil
+}
+
+// getSandboxNames returns the sorted names of the sandboxes of the nodes
+func (vdb *VCoordinationDatabase) getSandboxNames() []string {
+	sandboxes := []string{}
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != util.MainClusterSandbox && !util.StringInArray(vnode.Sandbox, sandboxes) {
+			sandboxes = append(sandboxes, vnode.Sandbox)
+		}
+	}
+	sort.Strings(sandboxes)
+	return sandboxes
+}
@@ -0,0 +1,61 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchDatabaseInfoOptions(t *testing.T) {
+	options := VFetchDatabaseInfoOptionsFactory()
+	vcc := VClusterCommands{}
+
+	_, err := vcc.VFetchDatabaseInfo(&options)
+	assert.ErrorContains(t, err, "must specify a database name")
+
+	options.DBName = dbName
+	_, err = vcc.VFetchDatabaseInfo(&options)
+	assert.ErrorContains(t, err, "must specify a host or host list")
+}
+
+func TestDatabaseAccessors(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.168.1.102": &VCoordinationNode{Name: "v_test_db_node0002", Address: "192.168.1.102", Sandbox: "sand2"},
+		"192.168.1.101": &VCoordinationNode{Name: "v_test_db_node0001", Address: "192.168.1.101"},
+		"192.168.1.103": &VCoordinationNode{Name: "v_test_db_node0003", Address: "192.168.1.103", Sandbox: "sand1"},
+		"192.168.1.104": &VCoordinationNode{Name: "v_test_db_node0004", Address: "192.168.1.104", Sandbox: "sand1"},
+	}
+
+	nodes := vdb.Nodes()
+	assert.Len(t, nodes, 4)
+	assert.Equal(t, "v_test_db_node0001", nodes[0].Name)
+	assert.Equal(t, "v_test_db_node0004", nodes[3].Name)
+
+	// the nodes are copies
+	nodes[0].State = "UP"
+	assert.Empty(t, vdb.HostNodeMap["192.168.1.101"].State)
+
+	vnode, ok := vdb.NodeByHost("192.168.1.103")
+	assert.True(t, ok)
+	assert.Equal(t, "sand1", vnode.Sandbox)
+	_, ok = vdb.NodeByHost("192.168.1.105")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"sand1", "sand2"}, vdb.getSandboxNames())
+}
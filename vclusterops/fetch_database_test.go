@@ -0,0 +1,42 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestRecoverConfigFromCommunalOptions(t *testing.T) {
+	options := VRecoverConfigOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101", "192.168.1.102"}
+
+	// the catalog path is required to recover from the catalogs
+	err := options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "catalog")
+
+	// but not from communal storage, which must be given instead
+	options.FromCommunal = true
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify a communal storage location")
+
+	options.CommunalStorageLocation = "s3://bucket/test_db"
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
@@ -54,14 +55,15 @@ func (options *VFetchNodeStateOptions) validateAnalyzeOptions(vcc VClusterComman
 
 // VFetchNodeState returns the node state (e.g., up or down) for each node in the cluster and any
 // error encountered.
-func (vcc VClusterCommands) VFetchNodeState(options *VFetchNodeStateOptions) ([]NodeInfo, error) {
+func (vcc VClusterCommands) VFetchNodeState(options *VFetchNodeStateOptions) (_ []NodeInfo, err error) {
+	defer vcc.audit(commandFetchNodeState, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
 	 *   - Give the instructions to the VClusterOpEngine to run
 	 */
 
-	err := options.validateAnalyzeOptions(vcc)
+	err = options.validateAnalyzeOptions(vcc)
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +83,8 @@ func (vcc VClusterCommands) VFetchNodeState(options *VFetchNodeStateOptions) ([]
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -106,8 +108,10 @@ func (vcc VClusterCommands) VFetchNodeState(options *VFetchNodeStateOptions) ([]
 
 	// error out in case of wrong certificate or password
 	if len(clusterOpEngine.execContext.hostsWithWrongAuth) > 0 {
-		return nodeStates,
-			fmt.Errorf("wrong certificate or password on hosts %v", clusterOpEngine.execContext.hostsWithWrongAuth)
+		return nodeStates, &WrongCredentialError{
+			Detail: fmt.Sprintf("wrong certificate or password on hosts %v", clusterOpEngine.execContext.hostsWithWrongAuth),
+			Hosts:  clusterOpEngine.execContext.hostsWithWrongAuth,
+		}
 	}
 
 	// if failed to get node info from a running database,
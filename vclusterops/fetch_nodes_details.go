@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -62,6 +63,11 @@ type StorageLocations struct {
 type NodeDetails struct {
 	NodeState
 	StorageLocations
+	// labels of the node and of its subcluster, e.g., zone or instance
+	// type. They are not stored in the database but given by the caller in
+	// VFetchNodesDetailsOptions.
+	Labels           map[string]string `json:"labels,omitempty"`
+	SubclusterLabels map[string]string `json:"subcluster_labels,omitempty"`
 }
 
 type NodesDetails []NodeDetails
@@ -70,6 +76,15 @@ type hostNodeDetailsMap map[string]*NodeDetails
 
 type VFetchNodesDetailsOptions struct {
 	DatabaseOptions
+	// CatalogVersion is the catalog version known by the caller. This is
+	// only used when VClusterCommands has a NodesDetailsCache: the cached
+	// details are reused only if they were fetched for the same version.
+	CatalogVersion string
+	// NodeLabels are the labels of the nodes, keyed by node name, and
+	// SubclusterLabels are the labels of the subclusters, keyed by
+	// subcluster name. They are added to the returned node details.
+	NodeLabels       map[string]map[string]string
+	SubclusterLabels map[string]map[string]string
 }
 
 func VFetchNodesDetailsOptionsFactory() VFetchNodesDetailsOptions {
@@ -90,6 +105,13 @@ func (options *VFetchNodesDetailsOptions) validateParseOptions(logger vlog.Print
 		return err
 	}
 
+	for _, labelsMap := range []map[string]map[string]string{options.NodeLabels, options.SubclusterLabels} {
+		for name, labels := range labelsMap {
+			if err := util.ValidateLabels(labels); err != nil {
+				return fmt.Errorf("invalid labels of %s: %w", name, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -114,6 +136,7 @@ func (options *VFetchNodesDetailsOptions) validateAnalyzeOptions(logger vlog.Pri
 
 // VFetchNodesDetails can return nodes' details including node state and storage locations for the provided hosts
 func (vcc VClusterCommands) VFetchNodesDetails(options *VFetchNodesDetailsOptions) (nodesDetails NodesDetails, err error) {
+	defer vcc.audit(commandFetchNodesDetails, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Validate Options
 	 *   - Produce Instructions
@@ -126,26 +149,57 @@ func (vcc VClusterCommands) VFetchNodesDetails(options *VFetchNodesDetailsOption
 		return nodesDetails, err
 	}
 
-	hostsWithNodeDetails := make(hostNodeDetailsMap, len(options.Hosts))
+	// only send requests to the hosts that are not in the cache
+	hostsToFetch := options.Hosts
+	if vcc.NodesDetailsCache != nil {
+		hostsToFetch = []string{}
+		for _, host := range options.Hosts {
+			if nodeDetails, ok := vcc.NodesDetailsCache.get(host, options.CatalogVersion); ok {
+				nodesDetails = append(nodesDetails, nodeDetails)
+			} else {
+				hostsToFetch = append(hostsToFetch, host)
+			}
+		}
+		vcc.Log.Info("nodes details cache lookup", "cachedHostCount", len(nodesDetails),
+			"hostsToFetch", hostsToFetch)
+		if len(hostsToFetch) == 0 {
+			return options.addLabels(nodesDetails), nil
+		}
+	}
+
+	hostsWithNodeDetails := make(hostNodeDetailsMap, len(hostsToFetch))
 
-	instructions, err := vcc.produceFetchNodesDetailsInstructions(options, hostsWithNodeDetails)
+	instructions, err := vcc.produceFetchNodesDetailsInstructions(options, hostsToFetch, hostsWithNodeDetails)
 	if err != nil {
-		return nodesDetails, fmt.Errorf("fail to produce instructions: %w", err)
+		return nil, fmt.Errorf("fail to produce instructions: %w", err)
 	}
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
-		return nodesDetails, fmt.Errorf("failed to fetch node details on hosts %v: %w", options.Hosts, err)
+		return nil, fmt.Errorf("failed to fetch node details on hosts %v: %w", hostsToFetch, err)
 	}
 
-	for _, nodeDetails := range hostsWithNodeDetails {
+	for host, nodeDetails := range hostsWithNodeDetails {
+		if vcc.NodesDetailsCache != nil {
+			vcc.NodesDetailsCache.put(host, options.CatalogVersion, nodeDetails)
+		}
 		nodesDetails = append(nodesDetails, *nodeDetails)
 	}
 
-	return nodesDetails, nil
+	return options.addLabels(nodesDetails), nil
+}
+
+// addLabels sets the labels of the nodes and their subclusters. The cached
+// node details are copies, so they are not changed.
+func (options *VFetchNodesDetailsOptions) addLabels(nodesDetails NodesDetails) NodesDetails {
+	for i := range nodesDetails {
+		nodesDetails[i].Labels = options.NodeLabels[nodesDetails[i].Name]
+		nodesDetails[i].SubclusterLabels = options.SubclusterLabels[nodesDetails[i].SubclusterName]
+	}
+	return nodesDetails
 }
 
 // produceFetchNodesDetails will build a list of instructions to execute for
@@ -155,7 +209,7 @@ func (vcc VClusterCommands) VFetchNodesDetails(options *VFetchNodesDetailsOption
 //   - Get nodes' state by calling /v1/node
 //   - Get nodes' storage locations by calling /v1/node/storage-locations
 func (vcc *VClusterCommands) produceFetchNodesDetailsInstructions(options *VFetchNodesDetailsOptions,
-	hostsWithNodeDetails hostNodeDetailsMap) ([]clusterOp, error) {
+	hosts []string, hostsWithNodeDetails hostNodeDetailsMap) ([]clusterOp, error) {
 	var instructions []clusterOp
 
 	// when password is specified, we will use username/password to call https endpoints
@@ -164,13 +218,13 @@ func (vcc *VClusterCommands) produceFetchNodesDetailsInstructions(options *VFetc
 		return instructions, err
 	}
 
-	httpsGetNodeStateOp, err := makeHTTPSGetLocalNodeStateOp(options.DBName, options.Hosts,
+	httpsGetNodeStateOp, err := makeHTTPSGetLocalNodeStateOp(options.DBName, hosts,
 		options.usePassword, options.UserName, options.Password, hostsWithNodeDetails)
 	if err != nil {
 		return instructions, err
 	}
 
-	httpsGetStorageLocationsOp, err := makeHTTPSGetStorageLocsOp(options.Hosts, options.usePassword,
+	httpsGetStorageLocationsOp, err := makeHTTPSGetStorageLocsOp(hosts, options.usePassword,
 		options.UserName, options.Password, hostsWithNodeDetails)
 	if err != nil {
 		return instructions, err
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
 func TestRequiredOptions(t *testing.T) {
@@ -36,3 +37,29 @@ func TestRequiredOptions(t *testing.T) {
 	assert.Empty(t, nodesDetails)
 	assert.ErrorContains(t, err, `must specify a host or host list`)
 }
+
+func TestNodesDetailsLabels(t *testing.T) {
+	options := VFetchNodesDetailsOptionsFactory()
+	options.DBName = "testDB"
+	options.RawHosts = []string{"192.168.1.101"}
+	options.NodeLabels = map[string]map[string]string{
+		"v_testdb_node0001": {"rack": "r1"},
+	}
+	options.SubclusterLabels = map[string]map[string]string{
+		"sc1": {"instance-type": "r5.xlarge"},
+	}
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	nodesDetails := NodesDetails{
+		{NodeState: NodeState{Name: "v_testdb_node0001", SubclusterName: "default_subcluster"}},
+		{NodeState: NodeState{Name: "v_testdb_node0002", SubclusterName: "sc1"}},
+	}
+	nodesDetails = options.addLabels(nodesDetails)
+	assert.Equal(t, map[string]string{"rack": "r1"}, nodesDetails[0].Labels)
+	assert.Nil(t, nodesDetails[0].SubclusterLabels)
+	assert.Nil(t, nodesDetails[1].Labels)
+	assert.Equal(t, map[string]string{"instance-type": "r5.xlarge"}, nodesDetails[1].SubclusterLabels)
+
+	options.SubclusterLabels["sc1"]["bad key"] = "value"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), `invalid labels of sc1: invalid label key "bad key"`)
+}
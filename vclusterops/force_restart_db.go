@@ -0,0 +1,232 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the argument with which vertica starts a node without waiting for a quorum
+// of primary nodes and without checking the transaction level of the other
+// nodes, like admintools start_db --unsafe does
+const unsafeStartArg = "--unsafe"
+
+// VForceRestartDatabaseOptions represents the available options when you
+// force a restart of a database that lost its quorum with VForceRestartDatabase.
+type VForceRestartDatabaseOptions struct {
+	DatabaseOptions
+	// timeout for polling the states of the restarted nodes
+	StatePollingTimeout int
+	// If the path is set, the NMA will store the Vertica start command at the path
+	// instead of executing it
+	StartUpConf string
+	// must be true to restart the nodes. Otherwise, the nodes that would be
+	// restarted are only reported.
+	Unsafe bool
+	// must be true to restart the nodes when the NMA of some hosts is not
+	// reachable, since the catalogs of those hosts cannot be compared
+	AllowUnreachableHosts bool
+}
+
+func VForceRestartDatabaseOptionsFactory() VForceRestartDatabaseOptions {
+	options := VForceRestartDatabaseOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+	options.StatePollingTimeout = util.DefaultStatePollingTimeout
+
+	return options
+}
+
+func (options *VForceRestartDatabaseOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandForceRestartDB, logger)
+	if err != nil {
+		return err
+	}
+	return options.validateCatalogPath()
+}
+
+// VForceRestartDatabase restarts a database that lost its quorum of primary
+// nodes, e.g., after several hosts were lost, from the nodes that have the
+// latest catalog, without waiting for the other nodes. This is unsafe: the
+// transactions that were only committed on the other nodes are lost, and the
+// database may be inconsistent. The nodes are only restarted if options.Unsafe
+// is set, otherwise an error reports the nodes that would be restarted.
+// It returns the hosts that were restarted.
+func (vcc VClusterCommands) VForceRestartDatabase(options *VForceRestartDatabaseOptions) (_ []string, err error) {
+	defer vcc.audit(commandForceRestartDB, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	err = options.setUsePassword(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	// the NMA is likely down on some hosts when the quorum is lost, so only
+	// the hosts with a healthy NMA are checked and restarted
+	certs := options.buildHTTPSCerts()
+	healthyVDB := makeVCoordinationDatabase()
+	nmaGetHealthyNodesOp := makeNMAGetHealthyNodesOp(options.Hosts, &healthyVDB)
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&nmaGetHealthyNodesOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to find the hosts with a healthy NMA: %w", err)
+	}
+
+	// find the hosts with the latest catalog
+	vdb := makeVCoordinationDatabase()
+	preInstructions, err := vcc.produceForceRestartDBPreCheck(options, &vdb, healthyVDB.HostList)
+	if err != nil {
+		return nil, fmt.Errorf("fail to produce instructions: %w", err)
+	}
+	clusterOpEngine = vcc.makeClusterOpEngine(preInstructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to find the nodes with the latest catalog: %w", err)
+	}
+	hostsToRestart := append([]string{}, clusterOpEngine.execContext.hostsWithLatestCatalog...)
+	sort.Strings(hostsToRestart)
+	if len(hostsToRestart) == 0 {
+		return nil, fmt.Errorf("no host with the latest catalog of database %s was found", options.DBName)
+	}
+
+	err = vcc.checkForceRestartHosts(options, &vdb, healthyVDB.HostList, hostsToRestart)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions, err := vcc.produceForceRestartDBInstructions(options, &vdb, hostsToRestart)
+	if err != nil {
+		return nil, fmt.Errorf("fail to produce instructions: %w", err)
+	}
+	clusterOpEngine = vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to restart database %s: %w", options.DBName, err)
+	}
+
+	vcc.Log.PrintWarning("Database %s was restarted unsafely from hosts %v, restart the other nodes with restart_node",
+		options.DBName, hostsToRestart)
+	return hostsToRestart, nil
+}
+
+// checkForceRestartHosts warns about the hosts that will not be restarted,
+// including the hosts whose catalog could not be compared because their NMA
+// is not reachable, and checks that the user confirmed the restart
+func (vcc VClusterCommands) checkForceRestartHosts(options *VForceRestartDatabaseOptions,
+	vdb *VCoordinationDatabase, healthyHosts, hostsToRestart []string) error {
+	var hostsLeftOut []string
+	for host := range vdb.HostNodeMap {
+		if !util.StringInArray(host, hostsToRestart) {
+			hostsLeftOut = append(hostsLeftOut, host)
+		}
+	}
+	sort.Strings(hostsLeftOut)
+	unreachableHosts := util.SliceDiff(options.Hosts, healthyHosts)
+	sort.Strings(unreachableHosts)
+
+	vcc.Log.PrintWarning("UNSAFE RESTART: database %s will be restarted from the hosts with the latest catalog %v, "+
+		"without a quorum of primary nodes", options.DBName, hostsToRestart)
+	if len(hostsLeftOut) > 0 {
+		vcc.Log.PrintWarning("UNSAFE RESTART: the hosts %v are not restarted, the transactions that were only "+
+			"committed on them will be lost", hostsLeftOut)
+	}
+	if len(unreachableHosts) > 0 {
+		vcc.Log.PrintWarning("UNSAFE RESTART: the NMA is not reachable on hosts %v, their catalogs could not be "+
+			"compared and may be later than the catalog of the restarted hosts", unreachableHosts)
+	}
+	if !options.Unsafe {
+		return fmt.Errorf("the unsafe restart of database %s must be confirmed: "+
+			"the restart can lose data and leave the database inconsistent", options.DBName)
+	}
+	if len(unreachableHosts) > 0 && !options.AllowUnreachableHosts {
+		return fmt.Errorf("the catalogs of hosts %v could not be compared, start the NMA on them or "+
+			"confirm the restart of database %s without them", unreachableHosts, options.DBName)
+	}
+	return nil
+}
+
+// produceForceRestartDBPreCheck will build a list of instructions that find
+// the hosts with the latest catalog among the hosts with a healthy NMA:
+//   - Check that the database is not running on any host
+//   - Check that no vertica process runs
+//   - Get nodes' information by calling the NMA /nodes endpoint
+//   - Read the catalog of every node
+func (vcc VClusterCommands) produceForceRestartDBPreCheck(options *VForceRestartDatabaseOptions,
+	vdb *VCoordinationDatabase, healthyHosts []string) ([]clusterOp, error) {
+	checkDBRunningOp, err := makeHTTPSCheckRunningDBOp(options.Hosts,
+		options.usePassword, options.UserName, options.Password, StartDB)
+	if err != nil {
+		return nil, err
+	}
+	nmaCheckProcessesOp := makeNMACheckProcessesOp(healthyHosts, true, /*failIfVerticaRunning*/
+		true /*ignoreNotFound*/, make(map[string]*HostProcesses))
+	// some hosts are likely broken when the quorum is lost
+	nmaGetNodesInfoOp := makeNMAGetNodesInfoOp(healthyHosts, options.DBName, options.CatalogPrefix,
+		true /* ignore internal errors */, vdb)
+	nmaReadCatalogEditorOp, err := makeNMAReadCatalogEditorOp(vdb)
+	if err != nil {
+		return nil, err
+	}
+
+	return []clusterOp{&checkDBRunningOp, &nmaCheckProcessesOp,
+		&nmaGetNodesInfoOp, &nmaReadCatalogEditorOp}, nil
+}
+
+// produceForceRestartDBInstructions will build a list of instructions that
+// restart the hosts with the latest catalog:
+//   - Read the catalog of the hosts to restart, to get their start commands
+//   - Sync the confs between the hosts to restart
+//   - Start the nodes unsafely
+//   - Poll node startup
+func (vcc VClusterCommands) produceForceRestartDBInstructions(options *VForceRestartDatabaseOptions,
+	vdb *VCoordinationDatabase, hostsToRestart []string) ([]clusterOp, error) {
+	var instructions []clusterOp
+
+	nmaReadCatalogEditorOp, err := makeNMAReadCatalogEditorOpWithInitiator(hostsToRestart, vdb)
+	if err != nil {
+		return instructions, err
+	}
+	instructions = append(instructions, &nmaReadCatalogEditorOp)
+
+	produceTransferConfigOps(
+		&instructions,
+		nil, /*source hosts for transferring configuration files*/
+		hostsToRestart,
+		nil /*db configurations retrieved from a running db*/)
+
+	nmaStartNodeOp := makeNMAStartNodeOp(hostsToRestart, options.StartUpConf)
+	nmaStartNodeOp.extraStartArgs = []string{unsafeStartArg}
+	httpsPollNodeStateOp, err := makeHTTPSPollNodeStateOpWithTimeoutAndCommand(hostsToRestart,
+		options.usePassword, options.UserName, options.Password, options.StatePollingTimeout, StartDBCmd)
+	if err != nil {
+		return instructions, err
+	}
+	instructions = append(instructions, &nmaStartNodeOp, &httpsPollNodeStateOp)
+
+	return instructions, nil
+}
@@ -0,0 +1,76 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForceRestartDBPreCheckHosts(t *testing.T) {
+	options := VForceRestartDatabaseOptionsFactory()
+	options.DBName = dbName
+	options.Hosts = []string{"192.168.1.101", "192.168.1.102", "192.168.1.103"}
+	options.UserName = "dbadmin"
+	healthyHosts := []string{"192.168.1.101", "192.168.1.103"}
+
+	vcc := VClusterCommands{}
+	vdb := makeVCoordinationDatabase()
+	instructions, err := vcc.produceForceRestartDBPreCheck(&options, &vdb, healthyHosts)
+	assert.NoError(t, err)
+
+	// the database must not run on any host, but the NMA is only
+	// called on the hosts where it is healthy
+	assert.Equal(t, options.Hosts, instructions[0].getHosts())
+	for _, op := range instructions[1:3] {
+		assert.Equal(t, healthyHosts, op.getHosts(), op.getName())
+	}
+}
+
+func TestForceRestartDBUnreachableHosts(t *testing.T) {
+	options := VForceRestartDatabaseOptionsFactory()
+	options.DBName = dbName
+	options.Hosts = []string{"192.168.1.101", "192.168.1.102", "192.168.1.103"}
+	options.Unsafe = true
+	healthyHosts := []string{"192.168.1.101", "192.168.1.103"}
+	hostsToRestart := []string{"192.168.1.101"}
+
+	vcc := VClusterCommands{}
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	for _, host := range healthyHosts {
+		vdb.HostNodeMap[host] = &VCoordinationNode{Address: host}
+	}
+
+	// the NMA of 192.168.1.102 is down, so its catalog was not compared
+	err := vcc.checkForceRestartHosts(&options, &vdb, healthyHosts, hostsToRestart)
+	assert.ErrorContains(t, err, "the catalogs of hosts [192.168.1.102] could not be compared")
+
+	options.AllowUnreachableHosts = true
+	err = vcc.checkForceRestartHosts(&options, &vdb, healthyHosts, hostsToRestart)
+	assert.NoError(t, err)
+
+	// the NMA is reachable on all the hosts
+	options.AllowUnreachableHosts = false
+	err = vcc.checkForceRestartHosts(&options, &vdb, options.Hosts, hostsToRestart)
+	assert.NoError(t, err)
+
+	// the restart must be confirmed
+	options.Unsafe = false
+	err = vcc.checkForceRestartHosts(&options, &vdb, options.Hosts, hostsToRestart)
+	assert.ErrorContains(t, err, "must be confirmed")
+}
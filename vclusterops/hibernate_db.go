@@ -0,0 +1,279 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// the hibernation marker is in the metadata folder of the database on communal storage
+	hibernationMarkerFileName  = "hibernation.json"
+	hibernationMarkerDestPath  = "/tmp/hibernation.json"
+	hibernationStateHibernated = "hibernated"
+	hibernationStateAwake      = "awake"
+)
+
+// hibernationMarker records the hosts on which an Eon database was hibernated,
+// so that it can be woken up on them without reviving it
+type hibernationMarker struct {
+	State string `json:"state"`
+	// the time of the last state change, in RFC 3339 format
+	Time                     string           `json:"time"`
+	CatalogTruncationVersion int64            `json:"catalog_truncation_version"`
+	Nodes                    []hibernatedNode `json:"nodes"`
+}
+
+type hibernatedNode struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	CatalogPath string `json:"catalog_path"`
+	Subcluster  string `json:"subcluster"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+func makeHibernationMarker(vdb *VCoordinationDatabase, truncationVersion int64) hibernationMarker {
+	marker := hibernationMarker{
+		State:                    hibernationStateHibernated,
+		Time:                     time.Now().UTC().Format(time.RFC3339),
+		CatalogTruncationVersion: truncationVersion,
+	}
+	for _, host := range vdb.HostList {
+		vnode := vdb.HostNodeMap[host]
+		marker.Nodes = append(marker.Nodes, hibernatedNode{Name: vnode.Name, Address: vnode.Address,
+			CatalogPath: vnode.CatalogPath, Subcluster: vnode.Subcluster, IsPrimary: vnode.IsPrimary})
+	}
+	return marker
+}
+
+// getHosts returns the sorted hosts of the hibernated nodes
+func (marker *hibernationMarker) getHosts() []string {
+	hosts := make([]string, 0, len(marker.Nodes))
+	for _, node := range marker.Nodes {
+		hosts = append(hosts, node.Address)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// hasSameHosts returns true if the given hosts are the ones of the hibernated
+// nodes, in which case their local catalogs can be used to wake the database
+func (marker *hibernationMarker) hasSameHosts(hosts []string) bool {
+	sortedHosts := slices.Clone(hosts)
+	sort.Strings(sortedHosts)
+	return slices.Equal(marker.getHosts(), sortedHosts)
+}
+
+// getCatalogPrefix returns the catalog prefix of the hibernated nodes
+func (marker *hibernationMarker) getCatalogPrefix() string {
+	if len(marker.Nodes) == 0 {
+		return ""
+	}
+	return util.GetPathPrefix(marker.Nodes[0].CatalogPath)
+}
+
+type VHibernateDatabaseOptions struct {
+	DatabaseOptions
+	// seconds to wait for the user connections to close before the database is stopped
+	DrainSeconds int
+}
+
+func VHibernateDatabaseOptionsFactory() VHibernateDatabaseOptions {
+	options := VHibernateDatabaseOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+	options.DrainSeconds = util.DefaultDrainSeconds
+
+	return options
+}
+
+type VWakeDatabaseOptions struct {
+	DatabaseOptions
+	// timeout for polling the states of the nodes while the database starts
+	StatePollingTimeout int
+}
+
+func VWakeDatabaseOptionsFactory() VWakeDatabaseOptions {
+	options := VWakeDatabaseOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+	options.StatePollingTimeout = util.DefaultStatePollingTimeout
+
+	return options
+}
+
+// validateHibernationOptions validates the options shared by hibernate and wake
+func (opt *DatabaseOptions) validateHibernationOptions(commandName string, logger vlog.Printer) error {
+	err := opt.validateBaseOptions(commandName, logger)
+	if err != nil {
+		return err
+	}
+	if !opt.IsEon {
+		return fmt.Errorf("only an Eon database can be hibernated")
+	}
+	err = util.ValidateCommunalStorageLocation(opt.CommunalStorageLocation)
+	if err != nil {
+		return err
+	}
+	return resolveRawHosts(opt)
+}
+
+// VHibernateDatabase suspends a running Eon database: it syncs the catalog to
+// communal storage, stops all the nodes and marks the database as hibernated
+// in its metadata on communal storage. The hosts can then be shut down to save
+// costs, and VWakeDatabase starts the database on them again.
+func (vcc VClusterCommands) VHibernateDatabase(options *VHibernateDatabaseOptions) (err error) {
+	defer vcc.audit(commandHibernateDB, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateHibernationOptions(commandHibernateDB, vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != "" {
+			return fmt.Errorf("cannot hibernate database %s because node %s is in sandbox %s, "+
+				"unsandbox its subcluster first", options.DBName, vnode.Name, vnode.Sandbox)
+		}
+	}
+
+	// step 1: sync the catalog, so that no transaction is lost
+	syncOptions := VCatalogTruncationVersionOptionsFactory()
+	syncOptions.DatabaseOptions = options.DatabaseOptions
+	truncationVersion, err := vcc.VSyncCatalog(&syncOptions)
+	if err != nil {
+		return fmt.Errorf("fail to sync the catalog before hibernating database %s: %w", options.DBName, err)
+	}
+
+	// step 2: stop all the nodes
+	stopOptions := VStopDatabaseOptionsFactory()
+	stopOptions.DatabaseOptions = options.DatabaseOptions
+	stopOptions.RawHosts = vdb.HostList
+	stopOptions.DrainSeconds = &options.DrainSeconds
+	err = vcc.VStopDatabase(&stopOptions)
+	if err != nil {
+		return fmt.Errorf("fail to stop database %s to hibernate it: %w", options.DBName, err)
+	}
+
+	// step 3: mark the database as hibernated on communal storage
+	marker := makeHibernationMarker(&vdb, truncationVersion)
+	err = vcc.writeHibernationMarker(&options.DatabaseOptions, vdb.HostList, &marker)
+	if err != nil {
+		return fmt.Errorf("database %s is stopped but could not be marked as hibernated: %w", options.DBName, err)
+	}
+
+	vcc.Log.PrintInfo("Hibernated database %s at catalog truncation version %d", options.DBName, truncationVersion)
+	return nil
+}
+
+// VWakeDatabase starts an Eon database that was hibernated by VHibernateDatabase.
+// This is a fast path of reviving the database on the same hosts: the local
+// catalogs are still on the hosts, so the database only needs to be started.
+// To start the database on other hosts, use VReviveDatabase.
+func (vcc VClusterCommands) VWakeDatabase(options *VWakeDatabaseOptions) (vdbPtr *VCoordinationDatabase, err error) {
+	defer vcc.audit(commandWakeDB, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateHibernationOptions(commandWakeDB, vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	marker, err := vcc.readHibernationMarker(&options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+	if marker.State != hibernationStateHibernated {
+		return nil, fmt.Errorf("database %s is not hibernated, its hibernation state is %q", options.DBName, marker.State)
+	}
+	if !marker.hasSameHosts(options.Hosts) {
+		return nil, fmt.Errorf("database %s was hibernated on hosts %v, use revive_db to start it on other hosts",
+			options.DBName, marker.getHosts())
+	}
+
+	startOptions := VStartDatabaseOptionsFactory()
+	startOptions.DatabaseOptions = options.DatabaseOptions
+	startOptions.StatePollingTimeout = options.StatePollingTimeout
+	if startOptions.CatalogPrefix == "" {
+		startOptions.CatalogPrefix = marker.getCatalogPrefix()
+	}
+	vdbPtr, err = vcc.VStartDatabase(&startOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fail to wake database %s: %w", options.DBName, err)
+	}
+
+	// the database is up, so failing to update the marker is not fatal
+	marker.State = hibernationStateAwake
+	marker.Time = time.Now().UTC().Format(time.RFC3339)
+	err = vcc.writeHibernationMarker(&options.DatabaseOptions, options.Hosts, marker)
+	if err != nil {
+		vcc.Log.PrintWarning("Database %s is up but could not be marked as awake: %v", options.DBName, err)
+	}
+
+	vcc.Log.PrintInfo("Woke database %s", options.DBName)
+	return vdbPtr, nil
+}
+
+func (opt *DatabaseOptions) getHibernationMarkerPath() string {
+	return opt.joinCommunalStorageLocation(descriptionFileMetadataFolder, opt.DBName, hibernationMarkerFileName)
+}
+
+func (vcc VClusterCommands) writeHibernationMarker(options *DatabaseOptions, hosts []string,
+	marker *hibernationMarker) error {
+	content, err := json.Marshal(marker)
+	if err != nil {
+		return fmt.Errorf("fail to marshal the hibernation marker, details: %w", err)
+	}
+	nmaUploadCommunalFileOp := makeNMAUploadCommunalFileOp(hosts, options.getHibernationMarkerPath(),
+		string(content), options.getCommunalStorageParameters())
+	return vcc.runSingleOp(&nmaUploadCommunalFileOp, options, "fail to write the hibernation marker")
+}
+
+func (vcc VClusterCommands) readHibernationMarker(options *DatabaseOptions) (*hibernationMarker, error) {
+	var content string
+	nmaHealthOp := makeNMAHealthOp(options.Hosts)
+	nmaDownloadFileContentOp, err := makeNMADownloadFileContentOp(options.Hosts, options.getHibernationMarkerPath(),
+		hibernationMarkerDestPath, options.getCommunalStorageParameters(), &content)
+	if err != nil {
+		return nil, err
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&nmaHealthOp, &nmaDownloadFileContentOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the hibernation marker of database %s, it may not be hibernated: %w",
+			options.DBName, err)
+	}
+
+	marker := hibernationMarker{}
+	err = json.Unmarshal([]byte(content), &marker)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse the hibernation marker of database %s: %w", options.DBName, err)
+	}
+	return &marker, nil
+}
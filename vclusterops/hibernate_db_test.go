@@ -0,0 +1,64 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestHibernationMarker(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostList = []string{"192.168.1.102", "192.168.1.101"}
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.168.1.101": {Name: "v_test_db_node0001", Address: "192.168.1.101",
+			CatalogPath: "/data/test_db/v_test_db_node0001_catalog", IsPrimary: true},
+		"192.168.1.102": {Name: "v_test_db_node0002", Address: "192.168.1.102",
+			CatalogPath: "/data/test_db/v_test_db_node0002_catalog"},
+	}
+
+	marker := makeHibernationMarker(&vdb, 42)
+	assert.Equal(t, hibernationStateHibernated, marker.State)
+	assert.Equal(t, int64(42), marker.CatalogTruncationVersion)
+	assert.Equal(t, []string{"192.168.1.101", "192.168.1.102"}, marker.getHosts())
+	assert.Equal(t, "/data", marker.getCatalogPrefix())
+
+	// the order of the hosts does not matter
+	assert.True(t, marker.hasSameHosts([]string{"192.168.1.101", "192.168.1.102"}))
+	assert.True(t, marker.hasSameHosts([]string{"192.168.1.102", "192.168.1.101"}))
+	assert.False(t, marker.hasSameHosts([]string{"192.168.1.101", "192.168.1.103"}))
+	assert.False(t, marker.hasSameHosts([]string{"192.168.1.101"}))
+}
+
+func TestHibernationOptions(t *testing.T) {
+	options := VHibernateDatabaseOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+
+	err := options.validateHibernationOptions(commandHibernateDB, vlog.Printer{})
+	assert.ErrorContains(t, err, "only an Eon database can be hibernated")
+
+	options.IsEon = true
+	err = options.validateHibernationOptions(commandHibernateDB, vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify a communal storage location")
+
+	options.CommunalStorageLocation = "s3://bucket/test_db"
+	err = options.validateHibernationOptions(commandHibernateDB, vlog.Printer{})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://bucket/test_db/metadata/test_db/hibernation.json", options.getHibernationMarkerPath())
+}
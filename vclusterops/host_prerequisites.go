@@ -0,0 +1,268 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// status of a prerequisite check
+const (
+	PrerequisitePassed  = "PASSED"
+	PrerequisiteWarning = "WARNING"
+	PrerequisiteFailed  = "FAILED"
+)
+
+// the names of the prerequisite checks
+const (
+	checkMemoryPerCPU         = "memory_per_cpu"
+	checkOpenFilesLimit       = "open_files_limit"
+	checkUserProcessesLimit   = "user_processes_limit"
+	checkFileSizeLimit        = "file_size_limit"
+	checkTransparentHugepages = "transparent_hugepages"
+	checkNTPSynchronized      = "ntp_synchronized"
+)
+
+const (
+	bytesPerGiB = 1024 * 1024 * 1024
+	// Vertica needs at least 1GB of memory per logical CPU, and 4GB per
+	// logical CPU are recommended
+	minMemoryPerCPUBytes         = 1 * bytesPerGiB
+	recommendedMemoryPerCPUBytes = 4 * bytesPerGiB
+	minOpenFilesLimit            = 65536
+	minUserProcessesLimit        = 1024
+	// the NMA reports an unlimited ulimit as -1
+	unlimitedUlimit                 = -1
+	recommendedTransparentHugepages = "always"
+)
+
+type VCheckPrerequisitesOptions struct {
+	DatabaseOptions
+}
+
+// PrerequisiteCheck is the result of the evaluation of one prerequisite on a host
+type PrerequisiteCheck struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Actual   string `json:"actual"`
+	Expected string `json:"expected"`
+}
+
+// HostPrerequisites describes the resources of a host and whether they meet
+// the Vertica prerequisites
+type HostPrerequisites struct {
+	Host                 string              `json:"host"`
+	CPUCount             int                 `json:"cpu_count"`
+	MemoryBytes          int64               `json:"memory_bytes"`
+	TransparentHugepages string              `json:"transparent_hugepages"`
+	HugepagesTotal       int64               `json:"hugepages_total"`
+	OpenFilesLimit       int64               `json:"open_files_limit"`
+	UserProcessesLimit   int64               `json:"user_processes_limit"`
+	FileSizeLimit        int64               `json:"file_size_limit"`
+	NTPSynchronized      bool                `json:"ntp_synchronized"`
+	Checks               []PrerequisiteCheck `json:"checks"`
+}
+
+// PrerequisiteReport is the result of VCheckPrerequisites
+type PrerequisiteReport struct {
+	Hosts []HostPrerequisites `json:"hosts"`
+	// hosts whose NMA cannot report their resources
+	SkippedHosts []string `json:"skipped_hosts"`
+	// whether no check failed on any host. The checks with a warning
+	// do not prevent Vertica from running.
+	Passed bool `json:"passed"`
+}
+
+func VCheckPrerequisitesOptionsFactory() VCheckPrerequisitesOptions {
+	options := VCheckPrerequisitesOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VCheckPrerequisitesOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VCheckPrerequisitesOptions) validateParseOptions(logger vlog.Printer) error {
+	return options.validateBaseOptions(commandCheckPrerequisites, logger)
+}
+
+func (options *VCheckPrerequisitesOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VCheckPrerequisitesOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VCheckPrerequisites gathers the CPU count, memory, hugepages, ulimits and
+// NTP status of each host through the NMA, and evaluates them against the
+// Vertica prerequisites. A failed prerequisite is reported, not returned
+// as an error.
+func (vcc VClusterCommands) VCheckPrerequisites(options *VCheckPrerequisitesOptions) (_ PrerequisiteReport, err error) {
+	defer vcc.audit(commandCheckPrerequisites, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	var report PrerequisiteReport
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return report, err
+	}
+
+	nmaHealthOp := makeNMAHealthOp(options.Hosts)
+	nmaCheckPrerequisitesOp := makeNMACheckPrerequisitesOp(options.Hosts, &report)
+	instructions := []clusterOp{&nmaHealthOp, &nmaCheckPrerequisitesOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return report, fmt.Errorf("fail to check prerequisites on hosts %v: %w", options.Hosts, err)
+	}
+
+	return report, nil
+}
+
+// evaluateHostPrerequisites compares the resources of a host with the
+// Vertica prerequisites
+func evaluateHostPrerequisites(host string, resources *nmaHostResources) HostPrerequisites {
+	hostPrereqs := HostPrerequisites{
+		Host:                 host,
+		CPUCount:             resources.CPUCount,
+		MemoryBytes:          resources.MemoryBytes,
+		TransparentHugepages: resources.TransparentHugepages,
+		HugepagesTotal:       resources.HugepagesTotal,
+		OpenFilesLimit:       resources.Ulimits.OpenFiles,
+		UserProcessesLimit:   resources.Ulimits.UserProcesses,
+		FileSizeLimit:        resources.Ulimits.FileSize,
+		NTPSynchronized:      resources.NTPSynchronized,
+	}
+
+	var memoryPerCPU int64
+	if resources.CPUCount > 0 {
+		memoryPerCPU = resources.MemoryBytes / int64(resources.CPUCount)
+	}
+	memoryStatus := PrerequisitePassed
+	switch {
+	case memoryPerCPU < minMemoryPerCPUBytes:
+		memoryStatus = PrerequisiteFailed
+	case memoryPerCPU < recommendedMemoryPerCPUBytes:
+		memoryStatus = PrerequisiteWarning
+	}
+	hostPrereqs.addCheck(checkMemoryPerCPU, memoryStatus,
+		fmt.Sprintf("%.1fGiB", float64(memoryPerCPU)/bytesPerGiB),
+		fmt.Sprintf(">= %dGiB", recommendedMemoryPerCPUBytes/bytesPerGiB))
+
+	hostPrereqs.addLimitCheck(checkOpenFilesLimit, resources.Ulimits.OpenFiles, minOpenFilesLimit)
+	hostPrereqs.addLimitCheck(checkUserProcessesLimit, resources.Ulimits.UserProcesses, minUserProcessesLimit)
+	fileSizeStatus := PrerequisitePassed
+	if resources.Ulimits.FileSize != unlimitedUlimit {
+		fileSizeStatus = PrerequisiteFailed
+	}
+	hostPrereqs.addCheck(checkFileSizeLimit, fileSizeStatus, formatUlimit(resources.Ulimits.FileSize),
+		formatUlimit(unlimitedUlimit))
+
+	hugepagesStatus := PrerequisitePassed
+	if resources.TransparentHugepages != recommendedTransparentHugepages {
+		hugepagesStatus = PrerequisiteWarning
+	}
+	hostPrereqs.addCheck(checkTransparentHugepages, hugepagesStatus, resources.TransparentHugepages,
+		recommendedTransparentHugepages)
+
+	// an unsynchronized clock is only a warning, because the clock skew
+	// check of create_db and start_db catches the actual skew
+	ntpStatus := PrerequisitePassed
+	if !resources.NTPSynchronized {
+		ntpStatus = PrerequisiteWarning
+	}
+	hostPrereqs.addCheck(checkNTPSynchronized, ntpStatus, fmt.Sprint(resources.NTPSynchronized), "true")
+
+	return hostPrereqs
+}
+
+func (hostPrereqs *HostPrerequisites) addCheck(name, status, actual, expected string) {
+	hostPrereqs.Checks = append(hostPrereqs.Checks, PrerequisiteCheck{
+		Name:     name,
+		Status:   status,
+		Actual:   actual,
+		Expected: expected,
+	})
+}
+
+// addLimitCheck adds the check of a ulimit that must be unlimited or at
+// least minLimit
+func (hostPrereqs *HostPrerequisites) addLimitCheck(name string, limit, minLimit int64) {
+	status := PrerequisitePassed
+	if limit != unlimitedUlimit && limit < minLimit {
+		status = PrerequisiteFailed
+	}
+	hostPrereqs.addCheck(name, status, formatUlimit(limit), fmt.Sprintf(">= %d", minLimit))
+}
+
+// failedChecks returns the names of the failed checks of the host
+func (hostPrereqs *HostPrerequisites) failedChecks() []string {
+	var names []string
+	for _, check := range hostPrereqs.Checks {
+		if check.Status == PrerequisiteFailed {
+			names = append(names, check.Name)
+		}
+	}
+	return names
+}
+
+// finish sorts the hosts of the report and sets whether all the checks passed
+func (report *PrerequisiteReport) finish() {
+	sort.Slice(report.Hosts, func(i, j int) bool {
+		return report.Hosts[i].Host < report.Hosts[j].Host
+	})
+	sort.Strings(report.SkippedHosts)
+	report.Passed = true
+	for i := range report.Hosts {
+		if len(report.Hosts[i].failedChecks()) > 0 {
+			report.Passed = false
+		}
+	}
+}
+
+func formatUlimit(limit int64) string {
+	if limit == unlimitedUlimit {
+		return "unlimited"
+	}
+	return fmt.Sprint(limit)
+}
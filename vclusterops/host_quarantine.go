@@ -0,0 +1,106 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// quarantinedService is a service of a host that could not be reached: the
+// NMA or the Vertica HTTPS service. A host whose Vertica is down usually still
+// has a running NMA, so each service is quarantined separately.
+type quarantinedService struct {
+	host  string
+	isNMA bool
+}
+
+// quarantineUnreachableHosts records the services of the hosts that could not
+// be reached, so that the later ops do not wait for them again
+func (execContext *opEngineExecContext) quarantineUnreachableHosts(logger vlog.Printer,
+	httpRequest *clusterHTTPRequest) {
+	for host, result := range httpRequest.ResultCollection {
+		if !result.isUnreachable() {
+			continue
+		}
+		request, ok := httpRequest.RequestCollection[host]
+		if !ok {
+			continue
+		}
+		service := quarantinedService{host: host, isNMA: request.IsNMACommand}
+		if _, found := execContext.quarantinedHosts[service]; found {
+			continue
+		}
+		logger.Info("Quarantining unreachable host", "host", host, "nma", service.isNMA, "error", result.err)
+		execContext.quarantinedHosts[service] = result.err
+	}
+}
+
+// excludeQuarantinedHosts removes from httpRequest the requests to the
+// services that were quarantined, and returns the error that quarantined each
+// excluded host. The requests are kept if excluding the hosts would leave no
+// more than half of the hosts of the op, as the op would not hold quorum
+// anyway: it will then report the unreachable hosts itself.
+func (execContext *opEngineExecContext) excludeQuarantinedHosts(logger vlog.Printer,
+	httpRequest *clusterHTTPRequest) map[string]error {
+	excludedHosts := make(map[string]error)
+	for host, request := range httpRequest.RequestCollection {
+		service := quarantinedService{host: host, isNMA: request.IsNMACommand}
+		if quarantineErr, found := execContext.quarantinedHosts[service]; found {
+			excludedHosts[host] = quarantineErr
+		}
+	}
+	if len(excludedHosts) == 0 {
+		return nil
+	}
+
+	hostCount := len(httpRequest.RequestCollection)
+	remainingCount := hostCount - len(excludedHosts)
+	if remainingCount*2 <= hostCount {
+		logger.Info("Not excluding the quarantined hosts as too few hosts would remain",
+			"op", httpRequest.Name, "quarantinedHosts", excludedHosts)
+		return nil
+	}
+
+	hosts := make([]string, 0, len(excludedHosts))
+	for host := range excludedHosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		logger.PrintWarning("[%s] skipping host %s as it could not be reached by a previous step: %v",
+			httpRequest.Name, host, excludedHosts[host])
+		delete(httpRequest.RequestCollection, host)
+	}
+	return excludedHosts
+}
+
+// addQuarantinedResults adds a failed result for each host that was excluded
+// from httpRequest, so that the op reports the host as unreachable instead
+// of silently ignoring it. The result wraps the error that quarantined the
+// host, so it is still recognized as unreachable.
+func addQuarantinedResults(httpRequest *clusterHTTPRequest, excludedHosts map[string]error) {
+	for host, quarantineErr := range excludedHosts {
+		httpRequest.ResultCollection[host] = hostHTTPResult{
+			host:   host,
+			status: EXCEPTION,
+			err: fmt.Errorf("[%s] skipped host %s as it could not be reached by a previous step: %w",
+				httpRequest.Name, host, quarantineErr),
+		}
+	}
+}
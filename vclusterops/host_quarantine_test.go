@@ -0,0 +1,84 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestHostQuarantine(t *testing.T) {
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	connErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	// only the hosts that could not be reached are quarantined, for the
+	// service to which the request was sent
+	httpsRequest := clusterHTTPRequest{Name: "MockHTTPSOp", RequestCollection: map[string]hostHTTPRequest{
+		"192.168.1.101": {}, "192.168.1.102": {}, "192.168.1.103": {},
+	}}
+	httpsRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.168.1.101": {host: "192.168.1.101", status: SUCCESS},
+		"192.168.1.102": {host: "192.168.1.102", status: EXCEPTION, err: connErr},
+		"192.168.1.103": {host: "192.168.1.103", status: FAILURE, statusCode: InternalErrorCode,
+			err: errors.New("internal error")},
+	}
+	execContext.quarantineUnreachableHosts(vlog.Printer{}, &httpsRequest)
+	assert.Len(t, execContext.quarantinedHosts, 1)
+	assert.Contains(t, execContext.quarantinedHosts, quarantinedService{host: "192.168.1.102"})
+
+	// the quarantined host is excluded while a majority of hosts remains
+	httpRequest := clusterHTTPRequest{Name: "MockOp", RequestCollection: map[string]hostHTTPRequest{
+		"192.168.1.101": {}, "192.168.1.102": {}, "192.168.1.103": {},
+	}}
+	excludedHosts := execContext.excludeQuarantinedHosts(vlog.Printer{}, &httpRequest)
+	assert.Len(t, httpRequest.RequestCollection, 2)
+	assert.NotContains(t, httpRequest.RequestCollection, "192.168.1.102")
+
+	// the excluded host is reported as unreachable, not dropped
+	httpRequest.ResultCollection = map[string]hostHTTPResult{}
+	addQuarantinedResults(&httpRequest, excludedHosts)
+	result := httpRequest.ResultCollection["192.168.1.102"]
+	assert.False(t, result.isPassing())
+	assert.True(t, result.isUnreachable())
+	assert.ErrorContains(t, result.err, "could not be reached by a previous step")
+
+	// the NMA of a host whose HTTPS service is unreachable is not excluded
+	nmaRequest := clusterHTTPRequest{Name: "MockNMAOp", RequestCollection: map[string]hostHTTPRequest{
+		"192.168.1.101": {IsNMACommand: true}, "192.168.1.102": {IsNMACommand: true},
+		"192.168.1.103": {IsNMACommand: true},
+	}}
+	assert.Empty(t, execContext.excludeQuarantinedHosts(vlog.Printer{}, &nmaRequest))
+	assert.Len(t, nmaRequest.RequestCollection, 3)
+
+	// the quarantined host is kept when too few hosts would remain
+	httpRequest = clusterHTTPRequest{Name: "MockOp", RequestCollection: map[string]hostHTTPRequest{
+		"192.168.1.101": {}, "192.168.1.102": {},
+	}}
+	assert.Empty(t, execContext.excludeQuarantinedHosts(vlog.Printer{}, &httpRequest))
+	assert.Len(t, httpRequest.RequestCollection, 2)
+}
+
+func TestQuarantineOptIn(t *testing.T) {
+	// only the ops that tolerate unreachable hosts skip the quarantined hosts
+	healthyNodesOp := makeNMAGetHealthyNodesOp([]string{"192.168.1.101"}, &VCoordinationDatabase{})
+	assert.True(t, healthyNodesOp.skipQuarantinedHosts)
+	versionOp := makeNMAVerticaVersionOpWithTargetHosts(true, []string{"192.168.1.101"})
+	assert.False(t, versionOp.skipQuarantinedHosts)
+}
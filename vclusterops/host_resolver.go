@@ -0,0 +1,130 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"net"
+	"sort"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// this variable is for unit test, be careful to modify it.
+var resolveHostFn = util.ResolveToOneIP
+
+// hostResolver resolves the host names of RawHosts again during a command,
+// so that the requests follow a host name that starts pointing at another
+// address, e.g., after a DNS-based failover. The ops keep addressing the
+// hosts by the addresses resolved when the command started: the resolver
+// only changes the address to which their requests are sent.
+type hostResolver struct {
+	ipv6 bool
+	// host names that were not resolved yet when the resolver was made
+	unresolvedNames []string
+	// address resolved when the command started -> host name
+	hostNames map[string]string
+	// address resolved when the command started -> current address,
+	// only for the host names that now resolve to another address
+	aliases map[string]string
+}
+
+// makeHostResolver pairs the host names in rawHosts with the addresses they
+// were resolved to in hosts. If hosts is not resolved yet, the host names are
+// resolved on the first refresh. IP addresses are never re-resolved.
+func makeHostResolver(rawHosts, hosts []string, ipv6 bool) *hostResolver {
+	resolver := hostResolver{ipv6: ipv6}
+	resolver.hostNames = make(map[string]string)
+	resolver.aliases = make(map[string]string)
+	for i, rawHost := range rawHosts {
+		if net.ParseIP(rawHost) != nil {
+			continue
+		}
+		if len(hosts) == len(rawHosts) {
+			resolver.hostNames[hosts[i]] = rawHost
+		} else {
+			resolver.unresolvedNames = append(resolver.unresolvedNames, rawHost)
+		}
+	}
+	return &resolver
+}
+
+// getAddress returns the address to which the requests to host are sent
+func (resolver *hostResolver) getAddress(host string) string {
+	if resolver == nil {
+		return host
+	}
+	if address, ok := resolver.aliases[host]; ok {
+		return address
+	}
+	return host
+}
+
+// refresh resolves all the host names again
+func (resolver *hostResolver) refresh(logger vlog.Printer) {
+	if resolver == nil {
+		return
+	}
+	for _, name := range resolver.unresolvedNames {
+		address, err := resolveHostFn(name, resolver.ipv6)
+		if err != nil {
+			logger.Info("fail to resolve host name, it will not be re-resolved", "host", name, "details", err.Error())
+			continue
+		}
+		resolver.hostNames[address] = name
+	}
+	resolver.unresolvedNames = nil
+
+	hosts := make([]string, 0, len(resolver.hostNames))
+	for host := range resolver.hostNames {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	resolver.refreshHosts(logger, hosts)
+}
+
+// refreshHosts resolves the host names of the given hosts again and returns
+// the hosts whose address changed. A host name that cannot be resolved keeps
+// its current address.
+func (resolver *hostResolver) refreshHosts(logger vlog.Printer, hosts []string) (movedHosts []string) {
+	if resolver == nil {
+		return nil
+	}
+	for _, host := range hosts {
+		name, ok := resolver.hostNames[host]
+		if !ok {
+			continue
+		}
+		address, err := resolveHostFn(name, resolver.ipv6)
+		if err != nil {
+			logger.Info("fail to re-resolve host name, keeping its current address",
+				"host", name, "address", resolver.getAddress(host), "details", err.Error())
+			continue
+		}
+		currentAddress := resolver.getAddress(host)
+		if address == currentAddress {
+			continue
+		}
+		logger.PrintInfo("Host %s now resolves to %s instead of %s", name, address, currentAddress)
+		if address == host {
+			delete(resolver.aliases, host)
+		} else {
+			resolver.aliases[host] = address
+		}
+		movedHosts = append(movedHosts, host)
+	}
+	return movedHosts
+}
@@ -0,0 +1,140 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func mockResolveHosts(t *testing.T, addresses map[string]string) {
+	originalFn := resolveHostFn
+	t.Cleanup(func() { resolveHostFn = originalFn })
+	resolveHostFn = func(hostname string, _ bool) (string, error) {
+		if address, ok := addresses[hostname]; ok {
+			return address, nil
+		}
+		return "", errors.New("no such host")
+	}
+}
+
+func TestHostResolverRefresh(t *testing.T) {
+	addresses := map[string]string{"vnode1": "192.0.2.1", "vnode2": "192.0.2.2"}
+	mockResolveHosts(t, addresses)
+
+	resolver := makeHostResolver([]string{"vnode1", "vnode2", "192.0.2.3"},
+		[]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, false)
+	assert.Equal(t, map[string]string{"192.0.2.1": "vnode1", "192.0.2.2": "vnode2"}, resolver.hostNames)
+
+	resolver.refresh(vlog.Printer{})
+	assert.Equal(t, "192.0.2.1", resolver.getAddress("192.0.2.1"))
+
+	// vnode1 fails over to another address, vnode2 cannot be resolved
+	addresses["vnode1"] = "192.0.2.11"
+	delete(addresses, "vnode2")
+	resolver.refresh(vlog.Printer{})
+	assert.Equal(t, "192.0.2.11", resolver.getAddress("192.0.2.1"))
+	assert.Equal(t, "192.0.2.2", resolver.getAddress("192.0.2.2"))
+	assert.Equal(t, "192.0.2.3", resolver.getAddress("192.0.2.3"))
+
+	// and back to its original address
+	addresses["vnode1"] = "192.0.2.1"
+	movedHosts := resolver.refreshHosts(vlog.Printer{}, []string{"192.0.2.1"})
+	assert.Equal(t, []string{"192.0.2.1"}, movedHosts)
+	assert.Equal(t, "192.0.2.1", resolver.getAddress("192.0.2.1"))
+
+	// the host names are resolved on the first refresh if the hosts are not resolved yet
+	resolver = makeHostResolver([]string{"vnode1"}, nil, false)
+	resolver.refresh(vlog.Printer{})
+	assert.Equal(t, map[string]string{"192.0.2.1": "vnode1"}, resolver.hostNames)
+
+	// a nil resolver keeps the addresses
+	resolver = nil
+	assert.Equal(t, "192.0.2.1", resolver.getAddress("192.0.2.1"))
+}
+
+// mockMovedHostAdapter cannot reach its host until the host name
+// resolves to another address
+type mockMovedHostAdapter struct {
+	host     string
+	resolver *hostResolver
+	requests *int
+	// the error of a request to the old address, a refused connection by default
+	err error
+}
+
+func (a *mockMovedHostAdapter) sendRequest(_ *hostHTTPRequest, resultChannel chan<- hostHTTPResult) {
+	*a.requests++
+	if a.resolver.getAddress(a.host) == a.host {
+		err := a.err
+		if err == nil {
+			err = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		resultChannel <- hostHTTPResult{host: a.host, status: EXCEPTION, err: err}
+		return
+	}
+	resultChannel <- hostHTTPResult{host: a.host, status: SUCCESS, statusCode: http.StatusOK}
+}
+
+func (a *mockMovedHostAdapter) generateResult(_ *http.Response) hostHTTPResult {
+	return hostHTTPResult{host: a.host, status: SUCCESS}
+}
+
+func TestResendToMovedHosts(t *testing.T) {
+	addresses := map[string]string{"vnode1": "192.0.2.1"}
+	mockResolveHosts(t, addresses)
+
+	dispatcher := makeHTTPRequestDispatcher(vlog.Printer{})
+	dispatcher.resolver = makeHostResolver([]string{"vnode1"}, []string{"192.0.2.1"}, false)
+	requests := 0
+	dispatcher.pool.connections["192.0.2.1"] = &mockMovedHostAdapter{host: "192.0.2.1",
+		resolver: dispatcher.resolver, requests: &requests}
+	httpRequest := clusterHTTPRequest{Name: "MockOp"}
+	httpRequest.RequestCollection = map[string]hostHTTPRequest{"192.0.2.1": {Method: GetMethod}}
+
+	// the host name still resolves to the unreachable address
+	assert.NoError(t, dispatcher.sendRequest(&httpRequest, nil))
+	result := httpRequest.ResultCollection["192.0.2.1"]
+	assert.True(t, result.isUnreachable())
+	assert.Equal(t, 1, requests)
+
+	// the request is resent to the new address, and keyed by the original one
+	addresses["vnode1"] = "192.0.2.11"
+	assert.NoError(t, dispatcher.sendRequest(&httpRequest, nil))
+	result = httpRequest.ResultCollection["192.0.2.1"]
+	assert.True(t, result.isPassing())
+	assert.Equal(t, 3, requests)
+
+	// a request that timed out may have run on the old address, so it is
+	// not resent even if the host name resolves to another address
+	addresses["vnode1"] = "192.0.2.1"
+	dispatcher.resolver = makeHostResolver([]string{"vnode1"}, []string{"192.0.2.1"}, false)
+	requests = 0
+	dispatcher.pool.connections["192.0.2.1"] = &mockMovedHostAdapter{host: "192.0.2.1",
+		resolver: dispatcher.resolver, requests: &requests,
+		err: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}}
+	addresses["vnode1"] = "192.0.2.11"
+	assert.NoError(t, dispatcher.sendRequest(&httpRequest, nil))
+	result = httpRequest.ResultCollection["192.0.2.1"]
+	assert.True(t, result.isTimeout())
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,89 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// HostResultSummary reports the outcome of an operation on each host
+// it was sent to.
+type HostResultSummary struct {
+	HostsSucceeded []string `json:"hosts_succeeded"`
+	// host -> reason of the failure
+	HostsFailed map[string]string `json:"hosts_failed"`
+}
+
+func makeHostResultSummary() HostResultSummary {
+	return HostResultSummary{
+		HostsFailed: make(map[string]string),
+	}
+}
+
+func (s *HostResultSummary) recordSuccess(host string) {
+	s.HostsSucceeded = append(s.HostsSucceeded, host)
+}
+
+func (s *HostResultSummary) recordFailure(host string, err error) {
+	if s.HostsFailed == nil {
+		s.HostsFailed = make(map[string]string)
+	}
+	s.HostsFailed[host] = err.Error()
+}
+
+// getFailedHosts returns the sorted list of hosts that failed
+func (s *HostResultSummary) getFailedHosts() []string {
+	hosts := make([]string, 0, len(s.HostsFailed))
+	for host := range s.HostsFailed {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// checkHostFailures decides whether an op should fail based on its per-host
+// results. It returns nil if at most maxHostFailures hosts failed. Otherwise,
+// it returns a PartialSuccessError if some hosts succeeded, or allErrs if no
+// host succeeded.
+func (s *HostResultSummary) checkHostFailures(opName string, maxHostFailures int, allErrs error,
+	logger vlog.Printer) error {
+	if len(s.HostsFailed) == 0 {
+		return allErrs
+	}
+	if len(s.HostsFailed) <= maxHostFailures {
+		logger.PrintWarning("[%s] failed on hosts %v, which is tolerated. Details: %v",
+			opName, s.getFailedHosts(), allErrs)
+		return nil
+	}
+	if len(s.HostsSucceeded) == 0 {
+		return allErrs
+	}
+
+	var reasons []string
+	for _, host := range s.getFailedHosts() {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", host, s.HostsFailed[host]))
+	}
+	return errors.Join(allErrs, &PartialSuccessError{
+		Detail: fmt.Sprintf("[%s] succeeded on %d host(s) but failed on %d host(s), more than the %d tolerated failure(s):\n%s",
+			opName, len(s.HostsSucceeded), len(s.HostsFailed), maxHostFailures, strings.Join(reasons, "\n")),
+		Summary: *s,
+	})
+}
@@ -0,0 +1,52 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestCheckHostFailures(t *testing.T) {
+	failure := errors.New("spread is not running")
+
+	// no failure
+	summary := makeHostResultSummary()
+	summary.recordSuccess("192.0.2.1")
+	assert.NoError(t, summary.checkHostFailures("TestOp", 0, nil, vlog.Printer{}))
+
+	// one failure is tolerated
+	summary.recordFailure("192.0.2.2", failure)
+	assert.NoError(t, summary.checkHostFailures("TestOp", 1, failure, vlog.Printer{}))
+
+	// one failure is not tolerated
+	err := summary.checkHostFailures("TestOp", 0, failure, vlog.Printer{})
+	var partialErr *PartialSuccessError
+	assert.True(t, errors.As(err, &partialErr))
+	assert.Equal(t, []string{"192.0.2.1"}, partialErr.Summary.HostsSucceeded)
+	assert.Equal(t, map[string]string{"192.0.2.2": "spread is not running"}, partialErr.Summary.HostsFailed)
+	assert.ErrorContains(t, err, "192.0.2.2: spread is not running")
+
+	// all hosts failed
+	summary = makeHostResultSummary()
+	summary.recordFailure("192.0.2.1", failure)
+	err = summary.checkHostFailures("TestOp", 0, failure, vlog.Printer{})
+	assert.Equal(t, failure, err)
+	assert.False(t, errors.As(err, &partialErr))
+}
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"bytes"
+	"compress/gzip"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -26,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/vertica/vcluster/rfc7807"
@@ -37,6 +39,12 @@ type httpAdapter struct {
 	opBase
 	host            string
 	respBodyHandler responseBodyHandler
+	// optional, gives the address to which the requests to host are sent
+	// when the host names are re-resolved during the command
+	resolver *hostResolver
+	// optional, the size in bytes from which the request bodies are
+	// compressed with gzip. When it is set, gzip responses are accepted.
+	compressionThreshold int
 }
 
 func makeHTTPAdapter(logger vlog.Printer) httpAdapter {
@@ -47,15 +55,16 @@ func makeHTTPAdapter(logger vlog.Printer) httpAdapter {
 	return newHTTPAdapter
 }
 
-// makeHTTPDownloadAdapter creates an HTTP adapter which will
-// download a response body to a file via streaming read and
-// buffered write, rather than copying the body to memory.
-func makeHTTPDownloadAdapter(logger vlog.Printer,
-	destFilePath string) httpAdapter {
+// makeHTTPStreamAdapter creates an HTTP adapter which will
+// stream a successful response body to the given writer, e.g., a
+// file or an archive opened by the op, rather than copying the
+// body to memory. The writer is not closed by the adapter.
+func makeHTTPStreamAdapter(logger vlog.Printer,
+	writer io.Writer) httpAdapter {
 	newHTTPAdapter := makeHTTPAdapter(logger)
-	newHTTPAdapter.respBodyHandler = &responseBodyDownloader{
-		logger,
-		destFilePath,
+	newHTTPAdapter.respBodyHandler = &responseBodyStreamer{
+		logger: logger,
+		writer: writer,
 	}
 	return newHTTPAdapter
 }
@@ -67,10 +76,10 @@ type responseBodyHandler interface {
 // empty struct for default behavior of reading response body into memory
 type responseBodyReader struct{}
 
-// for downloading response body to file instead of reading into memory
-type responseBodyDownloader struct {
-	logger       vlog.Printer
-	destFilePath string
+// for streaming response body to a writer instead of reading into memory
+type responseBodyStreamer struct {
+	logger vlog.Printer
+	writer io.Writer
 }
 
 const (
@@ -78,6 +87,7 @@ const (
 	nmaPort               = 5554
 	httpsPort             = 8443
 	defaultRequestTimeout = 300 // seconds
+	gzipEncoding          = "gzip"
 )
 
 type certificatePaths struct {
@@ -98,9 +108,8 @@ func (adapter *httpAdapter) sendRequest(request *hostHTTPRequest, resultChannel
 		port = httpsPort
 	}
 
-	requestURL := fmt.Sprintf("https://%s:%d/%s%s",
-		adapter.host,
-		port,
+	requestURL := fmt.Sprintf("https://%s/%s%s",
+		util.JoinHostPort(adapter.resolver.getAddress(adapter.host), port),
 		request.Endpoint,
 		queryParams)
 	adapter.logger.Info("Request URL", "URL", requestURL)
@@ -111,58 +120,175 @@ func (adapter *httpAdapter) sendRequest(request *hostHTTPRequest, resultChannel
 		resultChannel <- adapter.makeExceptionResult(err)
 		return
 	}
+	// whether use an access token or Kerberos (for HTTPS endpoints only, when no password is given)
+	useToken := !usePassword && !request.IsNMACommand && request.Token != nil
+	useKerberos := !usePassword && !useToken && !request.IsNMACommand && request.Kerberos != nil
 
-	// HTTP client
-	client, err := adapter.setupHTTPClient(request, usePassword, resultChannel)
+	// HTTP client, which does not need client certificates
+	// when the password, an access token or Kerberos is used
+	client, err := adapter.setupHTTPClient(request, usePassword || useToken || useKerberos, resultChannel)
 	if err != nil {
 		resultChannel <- adapter.makeExceptionResult(err)
 		return
 	}
 
-	// set up request body
-	var requestBody io.Reader
-	if request.RequestData == "" {
-		requestBody = http.NoBody
-	} else {
-		requestBody = bytes.NewBuffer([]byte(request.RequestData))
-	}
-
-	// build HTTP request
-	req, err := http.NewRequest(request.Method, requestURL, requestBody)
+	compress := adapter.shouldCompress(request)
+	req, usedToken, err := adapter.buildRequest(request, requestURL, usePassword, useToken, useKerberos, compress)
 	if err != nil {
-		err = fmt.Errorf("fail to build request %v on host %s, details %w",
-			request.Endpoint, adapter.host, err)
 		resultChannel <- adapter.makeExceptionResult(err)
 		return
 	}
-	// close the connection after sending the request (for clients)
-	req.Close = true
-
-	// set username and password
-	// which is only used for HTTPS endpoints
-	if usePassword {
-		req.SetBasicAuth(request.Username, *request.Password)
-	}
 
 	// send HTTP request
+	start := time.Now()
 	resp, err := client.Do(req)
+	// an NMA that does not accept a compressed body rejects the request,
+	// in which case the request is sent again once without compression
+	if err == nil && compress && isCompressionRejected(resp) {
+		adapter.logger.Info("compressed request body rejected, sending it uncompressed",
+			"host", adapter.host, "endpoint", request.Endpoint, "statusCode", resp.StatusCode)
+		resp.Body.Close()
+		compress = false
+		req, usedToken, err = adapter.buildRequest(request, requestURL, usePassword, useToken, useKerberos, compress)
+		if err != nil {
+			resultChannel <- adapter.makeExceptionResult(err)
+			return
+		}
+		resp, err = client.Do(req)
+	}
+	// the access token may have expired during a long-running operation,
+	// in which case the request is sent again once with a new token
+	if err == nil && useToken && resp.StatusCode == http.StatusUnauthorized {
+		refreshed, refreshErr := request.Token.refresh(usedToken)
+		if refreshErr != nil {
+			adapter.logger.Info("fail to refresh the access token", "host", adapter.host, "details", refreshErr.Error())
+		} else if refreshed {
+			resp.Body.Close()
+			req, _, err = adapter.buildRequest(request, requestURL, usePassword, useToken, useKerberos, compress)
+			if err != nil {
+				resultChannel <- adapter.makeExceptionResult(err)
+				return
+			}
+			resp, err = client.Do(req)
+		}
+	}
 	if err != nil {
 		err = fmt.Errorf("fail to send request %v on host %s, details %w",
 			request.Endpoint, adapter.host, err)
+		var result hostHTTPResult
 		if errors.Is(err, io.EOF) {
-			resultChannel <- adapter.makeEOFResult(err)
+			result = adapter.makeEOFResult(err)
 		} else {
-			resultChannel <- adapter.makeExceptionResult(err)
+			result = adapter.makeExceptionResult(err)
 		}
+		result.duration = time.Since(start)
+		resultChannel <- result
 		return
 	}
 	defer resp.Body.Close()
 
 	// generate and return the result
-	resultChannel <- adapter.generateResult(resp)
+	result := adapter.generateResult(resp)
+	result.duration = time.Since(start)
+	resultChannel <- result
+}
+
+// shouldCompress returns true if the body of the request is compressed,
+// which is only done for the NMA requests that opt in
+func (adapter *httpAdapter) shouldCompress(request *hostHTTPRequest) bool {
+	return adapter.acceptsCompression(request) && len(request.RequestData) >= adapter.compressionThreshold
+}
+
+// acceptsCompression returns true if a compressed response is accepted
+func (adapter *httpAdapter) acceptsCompression(request *hostHTTPRequest) bool {
+	return adapter.compressionThreshold > 0 && request.IsNMACommand && request.Compressible
+}
+
+// the size of the body of a 400 response that is read to find out whether the
+// compressed body of a request was rejected
+const maxRejectionBodySize = 4096
+
+// isCompressionRejected returns true if the server did not accept the
+// compressed body of a request: either it answered 415, or it answered 400
+// with an error that says that the content encoding is not supported. The
+// body of a 400 response is left readable for the result of the request.
+func isCompressionRejected(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnsupportedMediaType:
+		return true
+	case http.StatusBadRequest:
+		if resp.Body == nil {
+			return false
+		}
+		peeked, err := io.ReadAll(io.LimitReader(resp.Body, maxRejectionBodySize))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(peeked), resp.Body), resp.Body}
+		if err != nil {
+			return false
+		}
+		message := strings.ToLower(string(peeked))
+		return strings.Contains(message, "encoding") &&
+			(strings.Contains(message, "unsupported") || strings.Contains(message, "not supported"))
+	default:
+		return false
+	}
+}
+
+// buildRequest builds the HTTP request and sets its authentication. It
+// returns the access token that is used, if any.
+func (adapter *httpAdapter) buildRequest(request *hostHTTPRequest, requestURL string,
+	usePassword, useToken, useKerberos, compressBody bool) (req *http.Request, usedToken string, err error) {
+	// set up request body
+	var requestBody io.Reader
+	if request.RequestData == "" {
+		requestBody = http.NoBody
+	} else if compressBody {
+		requestBody, err = compressRequestData(request.RequestData)
+		if err != nil {
+			return nil, "", fmt.Errorf("fail to compress request %v on host %s, details %w",
+				request.Endpoint, adapter.host, err)
+		}
+	} else {
+		requestBody = bytes.NewBuffer([]byte(request.RequestData))
+	}
+
+	// build HTTP request
+	req, err = http.NewRequest(request.Method, requestURL, requestBody)
+	if err != nil {
+		return nil, "", fmt.Errorf("fail to build request %v on host %s, details %w",
+			request.Endpoint, adapter.host, err)
+	}
+	// close the connection after sending the request (for clients)
+	req.Close = true
+	if adapter.acceptsCompression(request) {
+		// the response is decompressed in generateResult, as setting this
+		// header disables the transparent decompression of the transport
+		req.Header.Set("Accept-Encoding", gzipEncoding)
+		if compressBody {
+			req.Header.Set("Content-Encoding", gzipEncoding)
+		}
+	}
+
+	// set username and password, access token or Kerberos token
+	// which are only used for HTTPS endpoints
+	switch {
+	case usePassword:
+		req.SetBasicAuth(request.Username, *request.Password)
+	case useToken:
+		usedToken, err = request.Token.setAuthorizationHeader(req)
+	case useKerberos:
+		err = request.Kerberos.setNegotiateHeader(req, adapter.host)
+	}
+	return req, usedToken, err
 }
 
 func (adapter *httpAdapter) generateResult(resp *http.Response) hostHTTPResult {
+	err := decompressResponseBody(resp)
+	if err != nil {
+		return adapter.makeExceptionResult(err)
+	}
 	bodyString, err := adapter.respBodyHandler.processResponseBody(resp)
 	if err != nil {
 		return adapter.makeExceptionResult(err)
@@ -177,30 +303,20 @@ func (*responseBodyReader) processResponseBody(resp *http.Response) (bodyString
 	return readResponseBody(resp)
 }
 
-func (downloader *responseBodyDownloader) processResponseBody(resp *http.Response) (bodyString string, err error) {
+func (streamer *responseBodyStreamer) processResponseBody(resp *http.Response) (bodyString string, err error) {
 	if isSuccess(resp) {
-		bytesWritten, err := downloader.downloadFile(resp)
+		bytesWritten, err := io.Copy(streamer.writer, resp.Body)
 		if err != nil {
-			err = fmt.Errorf("fail to stream the response body to file %s: %w", downloader.destFilePath, err)
+			err = fmt.Errorf("fail to stream the response body: %w", err)
 		} else {
-			downloader.logger.Info("File downloaded", "File", downloader.destFilePath, "Bytes", bytesWritten)
+			streamer.logger.Info("Response body streamed", "Bytes", bytesWritten)
 		}
 		return "", err
 	}
-	// in case of error, we get an RFC7807 error, not a file
+	// in case of error, we get an RFC7807 error, not the streamed content
 	return readResponseBody(resp)
 }
 
-// downloadFile uses buffered read/writes to download the http response body to a file
-func (downloader *responseBodyDownloader) downloadFile(resp *http.Response) (bytesWritten int64, err error) {
-	file, err := os.Create(downloader.destFilePath)
-	if err != nil {
-		return 0, err
-	}
-	defer file.Close()
-	return io.Copy(file, resp.Body)
-}
-
 // readResponseBody attempts to read the entire contents of the http response into bodyString
 func readResponseBody(resp *http.Response) (bodyString string, err error) {
 	bodyBytes, err := io.ReadAll(resp.Body)
@@ -213,6 +329,46 @@ func readResponseBody(resp *http.Response) (bodyString string, err error) {
 	return bodyString, nil
 }
 
+// compressRequestData returns the gzip-compressed request data
+func compressRequestData(requestData string) (io.Reader, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(requestData)); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// decompressResponseBody replaces the body of a gzip-encoded response with
+// its decompressed content, which is read as the body is read, so that a
+// downloaded file is decompressed while it is streamed to disk
+func decompressResponseBody(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), gzipEncoding) {
+		return nil
+	}
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("fail to decompress the response body: %w", err)
+	}
+	resp.Body = &gzipResponseBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.ContentLength = -1
+	return nil
+}
+
+// gzipResponseBody closes both the gzip reader and the original body
+type gzipResponseBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipResponseBody) Close() error {
+	return errors.Join(b.Reader.Close(), b.body.Close())
+}
+
 func isSuccess(resp *http.Response) bool {
 	return resp.StatusCode >= 200 && resp.StatusCode < 300
 }
@@ -243,13 +399,18 @@ func (adapter *httpAdapter) makeExceptionResult(err error) hostHTTPResult {
 // makeFailResult is a factory method for hostHTTPResult when an error response
 // is received from a REST endpoint.
 func (adapter *httpAdapter) makeFailResult(header http.Header, respBody string, statusCode int) hostHTTPResult {
-	return hostHTTPResult{
+	result := hostHTTPResult{
 		host:       adapter.host,
 		status:     FAILURE,
 		statusCode: statusCode,
 		content:    respBody,
 		err:        adapter.extractErrorFromResponse(header, respBody, statusCode),
 	}
+	problem := &rfc7807.VProblem{}
+	if errors.As(result.err, &problem) {
+		result.problem = problem
+	}
+	return result
 }
 
 // makeEOFResult is a factory method for hostHTTPSResult when an EOF response
@@ -267,11 +428,27 @@ func (adapter *httpAdapter) makeEOFResult(err error) hostHTTPResult {
 // object to create.
 func (adapter *httpAdapter) extractErrorFromResponse(header http.Header, respBody string, statusCode int) error {
 	if header.Get("Content-Type") == rfc7807.ContentType {
-		return rfc7807.GenerateErrorFromResponse(respBody)
+		err := rfc7807.GenerateErrorFromResponse(respBody)
+		adapter.setProblemHost(err)
+		return err
+	}
+	// some endpoints return an RFC 7807 problem without setting the content type
+	if problem, ok := rfc7807.ParseProblem(respBody); ok {
+		adapter.setProblemHost(problem)
+		return problem
 	}
 	return fmt.Errorf("status code %d returned from host %s: %s", statusCode, adapter.host, respBody)
 }
 
+// setProblemHost fills the host of an RFC 7807 problem when the server did not
+// set it, so that the error tells which host it comes from
+func (adapter *httpAdapter) setProblemHost(err error) {
+	problem := &rfc7807.VProblem{}
+	if errors.As(err, &problem) && problem.Host == "" {
+		problem.Host = adapter.host
+	}
+}
+
 func whetherUsePassword(request *hostHTTPRequest) (bool, error) {
 	if request.IsNMACommand {
 		return false, nil
@@ -282,6 +459,11 @@ func whetherUsePassword(request *hostHTTPRequest) (bool, error) {
 		return true, nil
 	}
 
+	// in case that an access token or Kerberos is configured
+	if request.Token != nil || request.Kerberos != nil {
+		return false, nil
+	}
+
 	// otherwise, use certs
 	// a. use certs in options
 	if request.UseCertsInOptions {
@@ -339,6 +521,26 @@ func (adapter *httpAdapter) buildCertsFromMemory(key, cert, caCert string) (tls.
 	return certificate, caCertPool, nil
 }
 
+// buildCertsFromOptions returns the TLS client certificate and the CAs given in
+// the options, either parsed or as PEM
+func (adapter *httpAdapter) buildCertsFromOptions(certs *httpsCerts) (tls.Certificate, *x509.CertPool, error) {
+	if certs.tlsCert == nil {
+		cert, caCertPool, err := adapter.buildCertsFromMemory(certs.key, certs.cert, certs.caCert)
+		if err != nil {
+			return cert, nil, err
+		}
+		if certs.caCertPool != nil {
+			caCertPool = certs.caCertPool
+		}
+		return cert, caCertPool, nil
+	}
+	if certs.caCertPool != nil || certs.caCert == "" {
+		return *certs.tlsCert, certs.caCertPool, nil
+	}
+	caCertPool, err := buildCAPool(certs.caCert)
+	return *certs.tlsCert, caCertPool, err
+}
+
 func (adapter *httpAdapter) setupHTTPClient(
 	request *hostHTTPRequest,
 	usePassword bool,
@@ -353,45 +555,42 @@ func (adapter *httpAdapter) setupHTTPClient(
 		requestTimeout = time.Duration(0) // a Timeout of zero means no timeout.
 	}
 
+	tlsConfig := &tls.Config{}
 	if usePassword {
-		// TODO: we have to use `InsecureSkipVerify: true` here,
-		//       as password is used
-		//nolint:gosec
-		client = &http.Client{
-			Timeout: time.Second * requestTimeout,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				},
-			},
+		// no client certificate is sent, the CA is only needed to verify the server
+		if request.TLSVerify.verifiesServer() {
+			caCertPool := request.Certs.caCertPool
+			if caCertPool == nil {
+				var err error
+				caCertPool, err = buildCAPool(request.Certs.caCert)
+				if err != nil {
+					return client, err
+				}
+			}
+			tlsConfig.RootCAs = caCertPool
 		}
 	} else {
 		var cert tls.Certificate
 		var caCertPool *x509.CertPool
 		var err error
 		if request.UseCertsInOptions {
-			cert, caCertPool, err = adapter.buildCertsFromMemory(request.Certs.key, request.Certs.cert, request.Certs.caCert)
+			cert, caCertPool, err = adapter.buildCertsFromOptions(&request.Certs)
 		} else {
 			cert, caCertPool, err = adapter.buildCertsFromFile()
 		}
 		if err != nil {
 			return client, err
 		}
-		// for both http and nma, we have to use `InsecureSkipVerify: true` here
-		// because the certs are self signed at this time
-		// TODO: update the InsecureSkipVerify once we start to use non-self-signed certs
-
-		//nolint:gosec
-		client = &http.Client{
-			Timeout: time.Second * requestTimeout,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					Certificates:       []tls.Certificate{cert},
-					RootCAs:            caCertPool,
-					InsecureSkipVerify: true,
-				},
-			},
-		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		tlsConfig.RootCAs = caCertPool
+	}
+	setupTLSVerification(tlsConfig, request.TLSVerify)
+
+	client = &http.Client{
+		Timeout: time.Second * requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
 	}
 	return client, nil
 }
@@ -16,6 +16,8 @@
 package vclusterops
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"io"
@@ -27,6 +29,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vertica/vcluster/rfc7807"
+	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
 func TestBuildQueryParams(t *testing.T) {
@@ -169,8 +172,36 @@ func TestHandleRFC7807Response(t *testing.T) {
 	assert.Equal(t, detail, problem.Detail)
 }
 
+func TestHandleRFC7807ResponseWithoutContentType(t *testing.T) {
+	adapter := httpAdapter{host: "192.0.2.1", respBodyHandler: &responseBodyReader{}}
+	detail := "Local node has not joined cluster yet"
+	rfcErr := rfc7807.New(rfc7807.AuthenticationError).
+		WithDetail(detail)
+	b, err := json.Marshal(rfcErr)
+	assert.Equal(t, err, nil)
+	mockBodyReader := MockReadCloser{
+		body: b,
+	}
+	mockResp := &http.Response{
+		StatusCode: rfcErr.Status,
+		Header:     http.Header{},
+		Body:       &mockBodyReader,
+	}
+	result := adapter.generateResult(mockResp)
+	assert.Equal(t, result.status, FAILURE)
+	problem := &rfc7807.VProblem{}
+	ok := errors.As(result.err, &problem)
+	assert.True(t, ok)
+	assert.True(t, result.isProblemInstanceOf(rfc7807.AuthenticationError))
+	assert.Equal(t, detail, result.getProblem().Detail)
+	// the host is filled by the adapter when the server does not set it
+	assert.Equal(t, "192.0.2.1", result.getProblem().Host)
+	assert.Contains(t, result.getErrorDetail(), detail)
+}
+
 func TestHandleFileDownloadErrorResponse(t *testing.T) {
-	adapter := httpAdapter{respBodyHandler: &responseBodyDownloader{destFilePath: "/never/use/me"}}
+	var buf bytes.Buffer
+	adapter := httpAdapter{respBodyHandler: &responseBodyStreamer{writer: &buf}}
 	detail := "Something went horribly wrong and this is not a file"
 	rfcErr := rfc7807.New(rfc7807.GenericHTTPInternalServerError).
 		WithDetail(detail)
@@ -193,6 +224,8 @@ func TestHandleFileDownloadErrorResponse(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, 500, problem.Status)
 	assert.Equal(t, detail, problem.Detail)
+	// the error is not streamed as if it were the file
+	assert.Empty(t, buf.String())
 }
 
 func TestHandleGenericErrorResponse(t *testing.T) {
@@ -214,3 +247,126 @@ func TestHandleGenericErrorResponse(t *testing.T) {
 	assert.False(t, ok)
 	assert.Contains(t, result.err.Error(), errorMessage)
 }
+
+func TestCompressRequestBody(t *testing.T) {
+	const url = "https://192.0.2.1:5554/v1/config/vertica"
+	adapter := httpAdapter{host: "192.0.2.1", compressionThreshold: 10}
+	request := hostHTTPRequest{Method: PostMethod, RequestData: `{"content": "a large config file"}`}
+	request.buildNMAEndpoint("config/vertica")
+
+	// only the NMA requests that opt in are compressed
+	assert.False(t, adapter.shouldCompress(&request))
+	req, _, err := adapter.buildRequest(&request, url, false, false, false, adapter.shouldCompress(&request))
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Content-Encoding"))
+	assert.Empty(t, req.Header.Get("Accept-Encoding"))
+	httpsRequest := hostHTTPRequest{Method: PostMethod, RequestData: request.RequestData, Compressible: true}
+	httpsRequest.buildHTTPSEndpoint("config/vertica")
+	assert.False(t, adapter.shouldCompress(&httpsRequest))
+
+	request.Compressible = true
+	assert.True(t, adapter.shouldCompress(&request))
+	req, _, err = adapter.buildRequest(&request, url, false, false, false, adapter.shouldCompress(&request))
+	assert.NoError(t, err)
+	assert.Equal(t, gzipEncoding, req.Header.Get("Content-Encoding"))
+	assert.Equal(t, gzipEncoding, req.Header.Get("Accept-Encoding"))
+	reader, err := gzip.NewReader(req.Body)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, request.RequestData, string(body))
+
+	// the fallback request after a rejection is not compressed
+	assert.True(t, isCompressionRejected(&http.Response{StatusCode: http.StatusUnsupportedMediaType}))
+	assert.False(t, isCompressionRejected(&http.Response{StatusCode: http.StatusInternalServerError}))
+	rejection := `{"title": "Bad Request", "detail": "Unsupported Content-Encoding: gzip"}`
+	resp := &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewBufferString(rejection))}
+	assert.True(t, isCompressionRejected(resp))
+	// any other bad request is not sent again, and its body is kept
+	badRequest := `{"title": "Bad Request", "detail": "the catalog path is not valid"}`
+	resp = &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(bytes.NewBufferString(badRequest))}
+	assert.False(t, isCompressionRejected(resp))
+	body, err = io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, badRequest, string(body))
+	req, _, err = adapter.buildRequest(&request, url, false, false, false, false)
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Content-Encoding"))
+	body, err = io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, request.RequestData, string(body))
+
+	// a body smaller than the threshold is not compressed
+	request.RequestData = `{}`
+	assert.False(t, adapter.shouldCompress(&request))
+	req, _, err = adapter.buildRequest(&request, url, false, false, false, adapter.shouldCompress(&request))
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Content-Encoding"))
+	assert.Equal(t, gzipEncoding, req.Header.Get("Accept-Encoding"))
+
+	// without threshold, nothing changes
+	adapter.compressionThreshold = 0
+	req, _, err = adapter.buildRequest(&request, url, false, false, false, adapter.shouldCompress(&request))
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Accept-Encoding"))
+}
+
+func TestHandleGzipResponse(t *testing.T) {
+	adapter := httpAdapter{respBodyHandler: &responseBodyReader{}, compressionThreshold: 10}
+	compressed, err := compressRequestData("success!")
+	assert.NoError(t, err)
+	body, err := io.ReadAll(compressed)
+	assert.NoError(t, err)
+	mockResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{gzipEncoding}},
+		Body:       &MockReadCloser{body: body},
+	}
+	result := adapter.generateResult(mockResp)
+	assert.Equal(t, SUCCESS, result.status)
+	assert.Equal(t, "success!", result.content)
+
+	// the file of a download is decompressed as well
+	destFilePath := path.Join(t.TempDir(), "vertica.log")
+	file, err := os.Create(destFilePath)
+	assert.NoError(t, err)
+	adapter.respBodyHandler = &responseBodyStreamer{writer: file}
+	mockResp = &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Encoding": []string{gzipEncoding}},
+		Body:       &MockReadCloser{body: body},
+	}
+	result = adapter.generateResult(mockResp)
+	assert.Equal(t, SUCCESS, result.status)
+	assert.NoError(t, file.Close())
+	content, err := os.ReadFile(destFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "success!", string(content))
+}
+
+func TestHandleStreamedResponse(t *testing.T) {
+	var buf bytes.Buffer
+	adapter := makeHTTPStreamAdapter(vlog.Printer{}, &buf)
+	mockResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       &MockReadCloser{body: []byte("a large log file")},
+	}
+	result := adapter.generateResult(mockResp)
+	assert.Equal(t, SUCCESS, result.status)
+	// the body is in the writer, not in the result
+	assert.Empty(t, result.content)
+	assert.Equal(t, "a large log file", buf.String())
+
+	// an error is read into the result instead of being streamed
+	buf.Reset()
+	mockResp = &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Header:     http.Header{},
+		Body:       &MockReadCloser{body: []byte("generic error!")},
+	}
+	result = adapter.generateResult(mockResp)
+	assert.Equal(t, FAILURE, result.status)
+	assert.Empty(t, buf.String())
+	assert.ErrorContains(t, result.err, "generic error!")
+}
@@ -0,0 +1,144 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	httpCaptureDirPerm  = 0700
+	httpCaptureFilePerm = 0600
+)
+
+// HTTPCapture records every request sent to the NMA and HTTPS service, with
+// its response, as a JSON file in a capture directory. Support can then see
+// exactly which calls a failing operation made. The secrets in the query
+// params and the request and response bodies are masked. Set it in
+// VClusterCommands.HTTPCapture to turn on the capture.
+type HTTPCapture struct {
+	mu  sync.Mutex
+	dir string
+	// sequence number of the next capture file
+	seq int
+}
+
+// httpCaptureRecord is the content of a capture file
+type httpCaptureRecord struct {
+	Op           string            `json:"op"`
+	Host         string            `json:"host"`
+	Method       string            `json:"method"`
+	Endpoint     string            `json:"endpoint"`
+	QueryParams  map[string]string `json:"query_params,omitempty"`
+	RequestBody  any               `json:"request_body,omitempty"`
+	Status       string            `json:"status"`
+	StatusCode   int               `json:"status_code"`
+	ResponseBody any               `json:"response_body,omitempty"`
+	Error        string            `json:"error,omitempty"`
+	Duration     string            `json:"duration"`
+}
+
+// MakeHTTPCapture creates a capture directory for this run under parentDir,
+// named after the current time and process
+func MakeHTTPCapture(parentDir string) (*HTTPCapture, error) {
+	dir := filepath.Join(parentDir,
+		fmt.Sprintf("vcluster-capture-%s-%d", time.Now().UTC().Format("20060102T150405"), os.Getpid()))
+	err := os.MkdirAll(dir, httpCaptureDirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create the HTTP capture directory %s: %w", dir, err)
+	}
+	return &HTTPCapture{dir: dir}, nil
+}
+
+// Dir returns the capture directory of this run
+func (c *HTTPCapture) Dir() string {
+	return c.dir
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// record writes a capture file for each host request of httpRequest
+func (c *HTTPCapture) record(httpRequest *clusterHTTPRequest) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for host, request := range httpRequest.RequestCollection {
+		result := httpRequest.ResultCollection[host]
+		record := httpCaptureRecord{
+			Op:           httpRequest.Name,
+			Host:         host,
+			Method:       request.Method,
+			Endpoint:     request.Endpoint,
+			QueryParams:  maskQueryParams(request.QueryParams),
+			RequestBody:  maskCapturedBody(request.RequestData),
+			Status:       result.status.getStatusString(),
+			StatusCode:   result.statusCode,
+			ResponseBody: maskCapturedBody(result.content),
+			Duration:     result.duration.String(),
+		}
+		if result.err != nil {
+			record.Error = result.err.Error()
+		}
+		recordBytes, err := json.MarshalIndent(record, "", "  ")
+		if err != nil {
+			return fmt.Errorf("fail to marshal the captured request to %s: %w", host, err)
+		}
+
+		c.seq++
+		fileName := unsafeFileNameChars.ReplaceAllString(
+			fmt.Sprintf("%04d_%s_%s.json", c.seq, httpRequest.Name, host), "_")
+		err = os.WriteFile(filepath.Join(c.dir, fileName), recordBytes, httpCaptureFilePerm)
+		if err != nil {
+			return fmt.Errorf("fail to write the captured request to %s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+func maskQueryParams(queryParams map[string]string) map[string]string {
+	if len(queryParams) == 0 {
+		return nil
+	}
+	masked := make(map[string]string, len(queryParams))
+	for k, v := range queryParams {
+		if isSensitiveOptionName(k) {
+			v = auditMaskedValue
+		}
+		masked[k] = v
+	}
+	return masked
+}
+
+// maskCapturedBody returns a JSON body, with its secrets masked, as an object
+// so that it is readable in the capture file. A body that is not a JSON object
+// is returned as it is.
+func maskCapturedBody(body string) any {
+	if body == "" {
+		return nil
+	}
+	bodyMap := make(map[string]any)
+	if err := json.Unmarshal([]byte(body), &bodyMap); err != nil {
+		return body
+	}
+	maskSensitiveValues(bodyMap)
+	return bodyMap
+}
@@ -0,0 +1,56 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPCapture(t *testing.T) {
+	capture, err := MakeHTTPCapture(t.TempDir())
+	assert.NoError(t, err)
+
+	httpRequest := clusterHTTPRequest{Name: "NMABootstrapCatalogOp"}
+	request := hostHTTPRequest{Method: PostMethod,
+		QueryParams: map[string]string{"auth-token": "s3cr3t", "node": "v_db_node0001"},
+		RequestData: `{"db_name": "test_db", "parameters": {"awsauth": "id:key"}}`}
+	request.buildNMAEndpoint("catalog/bootstrap")
+	httpRequest.RequestCollection = map[string]hostHTTPRequest{"192.0.2.1": request}
+	httpRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {status: SUCCESS, statusCode: SuccessCode, content: "done", duration: time.Second},
+	}
+	err = capture.record(&httpRequest)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(capture.Dir(), "0001_NMABootstrapCatalogOp_192.0.2.1.json"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+	assert.NotContains(t, string(content), "id:key")
+	var record httpCaptureRecord
+	assert.NoError(t, json.Unmarshal(content, &record))
+	assert.Equal(t, "v1/catalog/bootstrap", record.Endpoint)
+	assert.Equal(t, "v_db_node0001", record.QueryParams["node"])
+	assert.Equal(t, auditMaskedValue, record.QueryParams["auth-token"])
+	assert.Equal(t, "test_db", record.RequestBody.(map[string]any)["db_name"])
+	assert.Equal(t, "done", record.ResponseBody)
+	assert.Equal(t, "1s", record.Duration)
+}
@@ -15,6 +15,11 @@
 
 package vclusterops
 
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
 type hostHTTPRequest struct {
 	Method       string
 	Endpoint     string
@@ -29,12 +34,31 @@ type hostHTTPRequest struct {
 	// optional, for calling NMA/Vertica HTTPS endpoints. If Username/Password is set, that takes precedence over this for HTTPS calls.
 	UseCertsInOptions bool
 	Certs             httpsCerts
+	// optional, for calling Vertica HTTPS endpoints with an access token. If Username/Password is set, that takes precedence.
+	Token *bearerToken
+	// optional, how the certificate of the server is verified, not verified by default
+	TLSVerify tlsVerifyConfig
+	// optional, for calling Vertica HTTPS endpoints with Kerberos. If Username/Password or Token is set, that takes precedence.
+	Kerberos *kerberosAuth
+	// optional, for NMA endpoints that transfer files only, whether the body of the
+	// request and of the response can be compressed with gzip
+	Compressible bool
 }
 
 type httpsCerts struct {
 	key    string
 	cert   string
 	caCert string
+	// optional, used instead of key, cert and caCert
+	tlsCert    *tls.Certificate
+	caCertPool *x509.CertPool
+	// optional, used instead of the certificates for the Vertica HTTPS endpoints
+	token    *bearerToken
+	kerberos *kerberosAuth
+	// how the certificates of the servers are verified
+	tlsVerify tlsVerifyConfig
+	// optional, to re-resolve the host names of the hosts during the run
+	resolver *hostResolver
 }
 
 func (req *hostHTTPRequest) buildNMAEndpoint(url string) {
@@ -16,6 +16,10 @@
 package vclusterops
 
 import (
+	"context"
+	"io"
+	"sort"
+
 	"github.com/theckman/yacspin"
 	"github.com/vertica/vcluster/vclusterops/vlog"
 )
@@ -23,41 +27,124 @@ import (
 type requestDispatcher struct {
 	opBase
 	pool adapterPool
+	// optional, to trace the requests sent to the hosts
+	tracer Tracer
+	// context of the span of the op that sends the requests
+	traceCtx context.Context
+	// optional, to record the latency of the requests sent to the hosts
+	metrics *MetricsRegistry
+	// optional, to record the requests sent to the hosts and their responses
+	httpCapture *HTTPCapture
+	// whether the directory and catalog requests to the NMA of the local
+	// host are executed locally
+	localExecution bool
+	// optional, to send the requests to the addresses the host names
+	// resolve to when they change during the command
+	resolver *hostResolver
+	// size from which the request bodies are compressed, 0 for no compression
+	compressionThreshold int
 }
 
 func makeHTTPRequestDispatcher(logger vlog.Printer) requestDispatcher {
 	newHTTPRequestDispatcher := requestDispatcher{}
 	newHTTPRequestDispatcher.name = "HTTPRequestDispatcher"
 	newHTTPRequestDispatcher.logger = logger.WithName(newHTTPRequestDispatcher.name)
+	newHTTPRequestDispatcher.traceCtx = context.Background()
+	newHTTPRequestDispatcher.pool = makeAdapterPool(newHTTPRequestDispatcher.logger)
 
 	return newHTTPRequestDispatcher
 }
 
 // set up the pool connection for each host
 func (dispatcher *requestDispatcher) setup(hosts []string) {
-	dispatcher.pool = getPoolInstance(dispatcher.logger)
-
 	dispatcher.pool.connections = make(map[string]adapter)
 	for _, host := range hosts {
+		if dispatcher.localExecution && isLocalExecutionHost(host) {
+			adapter := makeLocalNMAAdapter(dispatcher.logger, host)
+			adapter.compressionThreshold = dispatcher.compressionThreshold
+			dispatcher.pool.connections[host] = &adapter
+			continue
+		}
 		adapter := makeHTTPAdapter(dispatcher.logger)
 		adapter.host = host
+		adapter.resolver = dispatcher.resolver
+		adapter.compressionThreshold = dispatcher.compressionThreshold
 		dispatcher.pool.connections[host] = &adapter
 	}
 }
 
-// set up the pool connection for each host to download a file
-func (dispatcher *requestDispatcher) setupForDownload(hosts []string,
-	hostToFilePathsMap map[string]string) {
-	dispatcher.pool = getPoolInstance(dispatcher.logger)
-
+// set up the pool connection for each host to stream the response body
+// to the writer of the host, so that large responses, e.g., a log file of
+// several GB, are not held in memory. The responses of the hosts without
+// a writer are read into memory as usual.
+func (dispatcher *requestDispatcher) setupForStreaming(hosts []string,
+	hostToWriterMap map[string]io.Writer) {
+	dispatcher.pool.connections = make(map[string]adapter)
 	for _, host := range hosts {
-		adapter := makeHTTPDownloadAdapter(dispatcher.logger, hostToFilePathsMap[host])
+		var adapter httpAdapter
+		if writer, ok := hostToWriterMap[host]; ok {
+			adapter = makeHTTPStreamAdapter(dispatcher.logger, writer)
+		} else {
+			adapter = makeHTTPAdapter(dispatcher.logger)
+		}
 		adapter.host = host
+		adapter.resolver = dispatcher.resolver
+		adapter.compressionThreshold = dispatcher.compressionThreshold
 		dispatcher.pool.connections[host] = &adapter
 	}
 }
 
 func (dispatcher *requestDispatcher) sendRequest(httpRequest *clusterHTTPRequest, spinner *yacspin.Spinner) error {
 	dispatcher.logger.Info("HTTP request dispatcher's sendRequest is called")
-	return dispatcher.pool.sendRequest(httpRequest, spinner)
+	err := dispatcher.pool.sendRequest(httpRequest, spinner, dispatcher.tracer, dispatcher.traceCtx)
+	if err == nil && dispatcher.resolver != nil {
+		err = dispatcher.resendToMovedHosts(httpRequest, spinner)
+	}
+	if dispatcher.metrics != nil {
+		for host, result := range httpRequest.ResultCollection {
+			dispatcher.metrics.recordRequest(host, result.duration)
+		}
+	}
+	if dispatcher.httpCapture != nil {
+		// a failure to capture the requests does not fail the op
+		if captureErr := dispatcher.httpCapture.record(httpRequest); captureErr != nil {
+			dispatcher.logger.PrintWarning("fail to capture the HTTP requests, details: %s", captureErr)
+		}
+	}
+	return err
+}
+
+// resendToMovedHosts resolves the host names of the unreachable hosts again
+// and, for the ones that now resolve to another address, resends their
+// requests to the new address. Only the requests that failed to connect are
+// resent, so they are not executed twice. A request that timed out may have
+// run on the old address, so it is not resent.
+func (dispatcher *requestDispatcher) resendToMovedHosts(httpRequest *clusterHTTPRequest, spinner *yacspin.Spinner) error {
+	var unreachableHosts []string
+	for host, result := range httpRequest.ResultCollection {
+		if result.isConnectionFailure() {
+			unreachableHosts = append(unreachableHosts, host)
+		}
+	}
+	sort.Strings(unreachableHosts)
+	movedHosts := dispatcher.resolver.refreshHosts(dispatcher.logger, unreachableHosts)
+	if len(movedHosts) == 0 {
+		return nil
+	}
+
+	dispatcher.logger.Info("Resend the requests to the hosts whose host names resolve to another address",
+		"hosts", movedHosts)
+	retryRequest := clusterHTTPRequest{Name: httpRequest.Name, SemVar: httpRequest.SemVar}
+	retryRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	for _, host := range movedHosts {
+		retryRequest.RequestCollection[host] = httpRequest.RequestCollection[host]
+	}
+	err := dispatcher.pool.sendRequest(&retryRequest, spinner, dispatcher.tracer, dispatcher.traceCtx)
+	if err != nil {
+		return err
+	}
+	for host, result := range retryRequest.ResultCollection {
+		httpRequest.ResultCollection[host] = result
+	}
+	return nil
 }
@@ -0,0 +1,62 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestDispatchersDoNotSharePool(t *testing.T) {
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+
+	dispatcher1 := makeHTTPRequestDispatcher(vlog.Printer{})
+	dispatcher1.setup(hosts)
+	dispatcher2 := makeHTTPRequestDispatcher(vlog.Printer{})
+	dispatcher2.setup(hosts[:1])
+
+	// setting up the second dispatcher must not change the
+	// connections of the first one
+	assert.Len(t, dispatcher1.pool.connections, 2)
+	assert.Len(t, dispatcher2.pool.connections, 1)
+	assert.NotSame(t, dispatcher1.pool.connections[hosts[0]], dispatcher2.pool.connections[hosts[0]])
+
+	// a new setup replaces the connections of the previous op
+	dispatcher1.setup([]string{"192.168.1.103"})
+	assert.Len(t, dispatcher1.pool.connections, 1)
+	assert.Contains(t, dispatcher1.pool.connections, "192.168.1.103")
+}
+
+func TestDispatcherSetupForStreaming(t *testing.T) {
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+	var buf bytes.Buffer
+
+	dispatcher := makeHTTPRequestDispatcher(vlog.Printer{})
+	dispatcher.setupForStreaming(hosts, map[string]io.Writer{"192.168.1.101": &buf})
+	assert.Len(t, dispatcher.pool.connections, 2)
+
+	// only the host with a writer streams its response
+	streamAdapter, ok := dispatcher.pool.connections["192.168.1.101"].(*httpAdapter)
+	assert.True(t, ok)
+	assert.IsType(t, &responseBodyStreamer{}, streamAdapter.respBodyHandler)
+	readAdapter, ok := dispatcher.pool.connections["192.168.1.102"].(*httpAdapter)
+	assert.True(t, ok)
+	assert.IsType(t, &responseBodyReader{}, readAdapter.respBodyHandler)
+}
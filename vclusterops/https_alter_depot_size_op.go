@@ -0,0 +1,135 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsAlterDepotSizeOp struct {
+	opBase
+	opHTTPSBase
+	hostNodeMap vHostNodeMap
+	depotSize   string
+	// node name -> depot size after the change, filled in once the op completes
+	nodeDepotSizes map[string]string
+}
+
+type alterDepotSizeResponse struct {
+	Node          string `json:"node"`
+	DepotLocation string `json:"depot_location"`
+	DepotSize     string `json:"depot_size"`
+}
+
+// makeHTTPSAlterDepotSizeOp will make an op that calls the vertica-http service
+// to change the depot size of the nodes on the given hosts
+func makeHTTPSAlterDepotSizeOp(hostNodeMap vHostNodeMap, hosts []string, depotSize string,
+	useHTTPPassword bool, userName string, httpsPassword *string, nodeDepotSizes map[string]string,
+) (httpsAlterDepotSizeOp, error) {
+	op := httpsAlterDepotSizeOp{}
+	op.name = "HTTPSAlterDepotSizeOp"
+	op.description = "Alter depot size"
+	op.hosts = hosts
+	op.hostNodeMap = hostNodeMap
+	op.depotSize = depotSize
+	op.nodeDepotSizes = nodeDepotSizes
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsAlterDepotSizeOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PutMethod
+		node, ok := op.hostNodeMap[host]
+		if !ok {
+			return fmt.Errorf("[%s] cannot find the node of host %s", op.name, host)
+		}
+		httpRequest.buildHTTPSEndpoint("nodes/" + node.Name + "/depot")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = map[string]string{"size": op.depotSize}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsAlterDepotSizeOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsAlterDepotSizeOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsAlterDepotSizeOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			// not break here because we want to log all the failed nodes
+			continue
+		}
+
+		/* decode the json-format response
+		The successful response object will be a dictionary like below:
+		{
+			"node": "v_test_db_node0001",
+			"depot_location": "/depot/test_db/v_test_db_node0001_depot",
+			"depot_size": "40%"
+		}
+		*/
+		resp := alterDepotSizeResponse{}
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			err = fmt.Errorf(`[%s] fail to parse result on host %s, details: %w`, op.name, host, err)
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		op.nodeDepotSizes[resp.Node] = resp.DepotSize
+	}
+	return allErrs
+}
+
+func (op *httpsAlterDepotSizeOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
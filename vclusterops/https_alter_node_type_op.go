@@ -21,22 +21,25 @@ import (
 	"github.com/vertica/vcluster/vclusterops/util"
 )
 
-type httpsMarkEphemeralNodeOp struct {
+type httpsAlterNodeTypeOp struct {
 	opBase
 	opHTTPSBase
 	targetNodeName string
+	nodeType       NodeType
 }
 
-func makeHTTPSMarkEphemeralNodeOp(nodeName string,
+// makeHTTPSAlterNodeTypeOp will make an op that changes the type of a node
+func makeHTTPSAlterNodeTypeOp(nodeName string, nodeType NodeType,
 	initiatorHost []string,
 	useHTTPPassword bool,
 	userName string,
-	httpsPassword *string) (httpsMarkEphemeralNodeOp, error) {
-	op := httpsMarkEphemeralNodeOp{}
-	op.name = "HTTPSMarkEphemeralNodeOp"
-	op.description = "Change node type to ephemeral"
+	httpsPassword *string) (httpsAlterNodeTypeOp, error) {
+	op := httpsAlterNodeTypeOp{}
+	op.name = "HTTPSAlterNodeTypeOp"
+	op.description = "Change node type to " + string(nodeType)
 	op.hosts = initiatorHost
 	op.targetNodeName = nodeName
+	op.nodeType = nodeType
 	op.useHTTPPassword = useHTTPPassword
 	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
 	if err != nil {
@@ -47,11 +50,20 @@ func makeHTTPSMarkEphemeralNodeOp(nodeName string,
 	return op, nil
 }
 
-func (op *httpsMarkEphemeralNodeOp) setupClusterHTTPRequest(hosts []string) error {
+func makeHTTPSMarkEphemeralNodeOp(nodeName string,
+	initiatorHost []string,
+	useHTTPPassword bool,
+	userName string,
+	httpsPassword *string) (httpsAlterNodeTypeOp, error) {
+	return makeHTTPSAlterNodeTypeOp(nodeName, NodeTypeEphemeral, initiatorHost,
+		useHTTPPassword, userName, httpsPassword)
+}
+
+func (op *httpsAlterNodeTypeOp) setupClusterHTTPRequest(hosts []string) error {
 	for _, host := range hosts {
 		httpRequest := hostHTTPRequest{}
 		httpRequest.Method = PostMethod
-		httpRequest.buildHTTPSEndpoint("nodes/" + op.targetNodeName + "/ephemeral")
+		httpRequest.buildHTTPSEndpoint("nodes/" + op.targetNodeName + "/" + string(op.nodeType))
 		if op.useHTTPPassword {
 			httpRequest.Password = op.httpsPassword
 			httpRequest.Username = op.userName
@@ -61,12 +73,12 @@ func (op *httpsMarkEphemeralNodeOp) setupClusterHTTPRequest(hosts []string) erro
 	return nil
 }
 
-func (op *httpsMarkEphemeralNodeOp) prepare(execContext *opEngineExecContext) error {
+func (op *httpsAlterNodeTypeOp) prepare(execContext *opEngineExecContext) error {
 	execContext.dispatcher.setup(op.hosts)
 	return op.setupClusterHTTPRequest(op.hosts)
 }
 
-func (op *httpsMarkEphemeralNodeOp) execute(execContext *opEngineExecContext) error {
+func (op *httpsAlterNodeTypeOp) execute(execContext *opEngineExecContext) error {
 	if err := op.runExecute(execContext); err != nil {
 		return err
 	}
@@ -74,7 +86,7 @@ func (op *httpsMarkEphemeralNodeOp) execute(execContext *opEngineExecContext) er
 	return op.processResult(execContext)
 }
 
-func (op *httpsMarkEphemeralNodeOp) processResult(_ *opEngineExecContext) error {
+func (op *httpsAlterNodeTypeOp) processResult(_ *opEngineExecContext) error {
 	var allErrs error
 
 	for host, result := range op.clusterHTTPRequest.ResultCollection {
@@ -88,6 +100,6 @@ func (op *httpsMarkEphemeralNodeOp) processResult(_ *opEngineExecContext) error
 	return allErrs
 }
 
-func (op *httpsMarkEphemeralNodeOp) finalize(_ *opEngineExecContext) error {
+func (op *httpsAlterNodeTypeOp) finalize(_ *opEngineExecContext) error {
 	return nil
 }
@@ -0,0 +1,76 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import "errors"
+
+// SetCertsFromPEM sets the TLS key, certificate and CA certificate from PEM
+// bytes, e.g., the content of a secret, instead of files. The CA certificate
+// is optional.
+func (opt *DatabaseOptions) SetCertsFromPEM(key, cert, caCert []byte) error {
+	if len(key) == 0 || len(cert) == 0 {
+		return errors.New("must provide both the PEM key and the PEM certificate")
+	}
+	opt.Key = string(key)
+	opt.Cert = string(cert)
+	opt.CaCert = string(caCert)
+	return nil
+}
+
+// hasClientCert returns true if a TLS client certificate is given,
+// either parsed or as PEM
+func (opt *DatabaseOptions) hasClientCert() bool {
+	return opt.TLSCertificate != nil || (opt.Key != "" && opt.Cert != "")
+}
+
+// hasHTTPSCredentials returns true if the HTTPS endpoints can be called
+// without a password
+func (opt *DatabaseOptions) hasHTTPSCredentials() bool {
+	return opt.hasClientCert() || opt.useAccessToken() || opt.useKerberos()
+}
+
+// buildHTTPSCerts returns the TLS certificates, and the access token and the
+// Kerberos configuration if any, that the requests to the hosts use when no
+// password is given
+func (opt *DatabaseOptions) buildHTTPSCerts() httpsCerts {
+	certs := httpsCerts{key: opt.Key, cert: opt.Cert, caCert: opt.CaCert}
+	certs.tlsCert = opt.TLSCertificate
+	certs.caCertPool = opt.CACertPool
+	certs.tlsVerify = tlsVerifyConfig{mode: opt.TLSVerifyMode, serverName: opt.TLSServerName}
+	if opt.useAccessToken() {
+		certs.token = &bearerToken{
+			token:     opt.AccessToken,
+			tokenFile: opt.TokenFile,
+			refresher: opt.AccessTokenRefresher,
+		}
+	}
+	if opt.useKerberos() {
+		certs.kerberos = &kerberosAuth{
+			serviceName:   opt.KerberosServiceName,
+			keytab:        opt.KerberosKeytab,
+			generateToken: opt.KerberosTokenGenerator,
+		}
+	}
+	if opt.ReresolveHosts {
+		certs.resolver = makeHostResolver(opt.RawHosts, opt.Hosts, opt.IPv6)
+	}
+	return certs
+}
+
+// hasClientCert returns true if the certs hold a TLS client certificate
+func (certs *httpsCerts) hasClientCert() bool {
+	return certs.tlsCert != nil || (certs.key != "" && certs.cert != "")
+}
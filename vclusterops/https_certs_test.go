@@ -0,0 +1,67 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCertsFromPEM(t *testing.T) {
+	opt := DatabaseOptions{}
+	assert.False(t, opt.hasClientCert())
+	assert.Error(t, opt.SetCertsFromPEM(nil, []byte("cert"), nil))
+
+	assert.NoError(t, opt.SetCertsFromPEM([]byte("key"), []byte("cert"), nil))
+	assert.True(t, opt.hasClientCert())
+	assert.True(t, opt.hasHTTPSCredentials())
+	assert.Empty(t, opt.CaCert)
+}
+
+func TestBuildCertsFromParsedCertificate(t *testing.T) {
+	adapter := httpAdapter{}
+	certPaths, err := getCertFilePathsMock()
+	assert.NoError(t, err)
+	tlsCert, err := tls.LoadX509KeyPair(certPaths.certFile, certPaths.keyFile)
+	assert.NoError(t, err)
+	caCert, err := os.ReadFile(certPaths.caFile)
+	assert.NoError(t, err)
+
+	opt := DatabaseOptions{TLSCertificate: &tlsCert}
+	assert.True(t, opt.hasClientCert())
+	certs := opt.buildHTTPSCerts()
+	assert.True(t, certs.hasClientCert())
+
+	// the CA given as PEM
+	certs.caCert = string(caCert)
+	cert, caCertPool, err := adapter.buildCertsFromOptions(&certs)
+	assert.NoError(t, err)
+	assert.Equal(t, tlsCert.Certificate, cert.Certificate)
+	expectedPool := x509.NewCertPool()
+	expectedPool.AppendCertsFromPEM(caCert)
+	assert.True(t, expectedPool.Equal(caCertPool))
+
+	// the parsed CA takes precedence
+	parsedPool := x509.NewCertPool()
+	certs.caCertPool = parsedPool
+	_, caCertPool, err = adapter.buildCertsFromOptions(&certs)
+	assert.NoError(t, err)
+	assert.Same(t, parsedPool, caCertPool)
+}
@@ -0,0 +1,137 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsChangeDBPasswordOp struct {
+	opBase
+	opHTTPSBase
+	// the user whose password changes, which is also the user sending the request
+	targetUserName string
+	newPassword    *string
+}
+
+type changePasswordRequestData struct {
+	Password string `json:"password"`
+}
+
+// makeHTTPSChangeDBPasswordOp will create an op that changes the database
+// password of a user. The request is authenticated with the current password,
+// or with the TLS certificate if no password is used.
+func makeHTTPSChangeDBPasswordOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword, newPassword *string) (httpsChangeDBPasswordOp, error) {
+	op := httpsChangeDBPasswordOp{}
+	op.name = "HTTPSChangeDBPasswordOp"
+	op.description = "Change database password"
+	op.hosts = hosts
+	op.targetUserName = userName
+	op.newPassword = newPassword
+	op.useHTTPPassword = useHTTPPassword
+
+	if userName == "" {
+		return op, fmt.Errorf("[%s] the name of the user whose password changes is empty", op.name)
+	}
+	if newPassword == nil {
+		return op, fmt.Errorf("[%s] the new password is not set", op.name)
+	}
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsChangeDBPasswordOp) setupRequestBody() (string, error) {
+	dataBytes, err := json.Marshal(changePasswordRequestData{Password: *op.newPassword})
+	if err != nil {
+		return "", fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	return string(dataBytes), nil
+}
+
+func (op *httpsChangeDBPasswordOp) setupClusterHTTPRequest(hosts []string) error {
+	requestData, err := op.setupRequestBody()
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PutMethod
+		httpRequest.buildHTTPSEndpoint("users/" + op.targetUserName + "/password")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.RequestData = requestData
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsChangeDBPasswordOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsChangeDBPasswordOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsChangeDBPasswordOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsChangeDBPasswordOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "ALTER USER"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
@@ -36,6 +36,7 @@ const (
 	ReviveDB
 	StopSC
 	ReIP
+	RepairCatalog
 
 	checkDBRunningOpName    = "HTTPSCheckDBRunningOp"
 	checkDBRunningOpDesc    = "Verify database is running"
@@ -58,6 +59,8 @@ func (op opType) String() string {
 		return "Stop Subcluster"
 	case ReIP:
 		return "Re-ip Hosts"
+	case RepairCatalog:
+		return "Repair Catalog"
 	}
 	return "unknown operation"
 }
@@ -81,6 +84,8 @@ type httpsCheckRunningDBOp struct {
 	opType      opType
 	sandbox     string // check if DB is running on specified sandbox
 	mainCluster bool   // check if DB is running on the main cluster.
+	// time in seconds to wait for the DB to be down, StopDBTimeout is used if not set
+	pollingTimeout int
 }
 
 func makeHTTPSCheckRunningDBOp(hosts []string,
@@ -170,6 +175,8 @@ func (op *httpsCheckRunningDBOp) generateHintMessage(host, dbName string) (msg s
 		msg = fmt.Sprintf("%s, please stop the HTTPS service before dropping the existing database.", generalMsg)
 	case ReIP:
 		msg = fmt.Sprintf("%s, please consider using restart_node to re-ip nodes for the running database.", generalMsg)
+	case RepairCatalog:
+		msg = fmt.Sprintf("%s, please stop the database before repairing the catalogs.", generalMsg)
 	case StopDB, StartDB, ReviveDB, StopSC:
 		msg = fmt.Sprintf("%s.", generalMsg)
 	}
@@ -243,10 +250,8 @@ func (op *httpsCheckRunningDBOp) isDBRunningOnHost(host string,
 	} else {
 		// check whether the node is starting and hasn't pulled the latest catalog yet
 		// setting status for logging purpose
-		rfcError := &rfc7807.VProblem{}
-		if ok := errors.As(result.err, &rfcError); ok &&
-			rfcError.ProblemID == rfc7807.AuthenticationError &&
-			strings.Contains(rfcError.Detail, "Local node has not joined cluster yet") {
+		if result.isProblemInstanceOf(rfc7807.AuthenticationError) &&
+			strings.Contains(result.getProblem().Detail, "Local node has not joined cluster yet") {
 			status = startingStatus
 		}
 	}
@@ -312,7 +317,7 @@ func (op *httpsCheckRunningDBOp) processResult(_ *opEngineExecContext) error {
 		if err != nil {
 			err = fmt.Errorf(`[%s] fail to parse result on host %s, details: %w`, op.name, host, err)
 			allErrs = errors.Join(allErrs, err)
-			msg = result.content
+			msg = result.getErrorDetail()
 			continue
 		}
 
@@ -371,6 +376,10 @@ func (op *httpsCheckRunningDBOp) handleDBRunning(allErrs error, msg string, upHo
 		const reIPMsg = "aborting re-ip hosts"
 		op.logger.PrintInfo(reIPMsg)
 		op.updateSpinnerMessage(reIPMsg)
+	case RepairCatalog:
+		const repairCatalogMsg = "aborting catalog repair"
+		op.logger.PrintInfo(repairCatalogMsg)
+		op.updateSpinnerMessage(repairCatalogMsg)
 	}
 
 	// when db is running, append an error to allErrs for stopping VClusterOpEngine
@@ -420,7 +429,7 @@ func (op *httpsCheckRunningDBOp) checkProcessedResult(sandboxedHosts map[string]
 func (op *httpsCheckRunningDBOp) execute(execContext *opEngineExecContext) error {
 	op.logger.Info("Execute() called", "opType", op.opType)
 	switch op.opType {
-	case CreateDB, StartDB, ReviveDB, ReIP, DropDB:
+	case CreateDB, StartDB, ReviveDB, ReIP, DropDB, RepairCatalog:
 		return op.checkDBConnection(execContext)
 	case StopDB, StopSC:
 		return op.pollForDBDown(execContext)
@@ -433,7 +442,11 @@ func (op *httpsCheckRunningDBOp) pollForDBDown(execContext *opEngineExecContext)
 	// start the polling
 	startTime := time.Now()
 	// for tests
-	timeoutSecondStr := util.GetEnv("NODE_STATE_POLLING_TIMEOUT", strconv.Itoa(StopDBTimeout))
+	pollingTimeout := StopDBTimeout
+	if op.pollingTimeout > 0 {
+		pollingTimeout = op.pollingTimeout
+	}
+	timeoutSecondStr := util.GetEnv("NODE_STATE_POLLING_TIMEOUT", strconv.Itoa(pollingTimeout))
 	timeoutSecond, err := strconv.Atoi(timeoutSecondStr)
 	if err != nil {
 		return fmt.Errorf("invalid timeout value %s: %w", timeoutSecondStr, err)
@@ -474,7 +487,7 @@ func (op *httpsCheckRunningDBOp) pollForDBDown(execContext *opEngineExecContext)
 	}
 	msg := fmt.Sprintf("the %s is still up after %s seconds", target, timeoutSecondStr)
 	op.logger.PrintWarning(msg)
-	return errors.New(msg)
+	return &DBIsRunningError{Detail: msg}
 }
 
 func (op *httpsCheckRunningDBOp) checkDBConnection(execContext *opEngineExecContext) error {
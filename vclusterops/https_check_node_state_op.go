@@ -88,7 +88,7 @@ func (op *httpsCheckNodeStateOp) processResult(execContext *opEngineExecContext)
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			op.logger.PrintError("[%s] unauthorized request: %s", op.name, result.content)
+			op.logger.PrintError("[%s] unauthorized request: %s", op.name, result.getErrorDetail())
 			execContext.hostsWithWrongAuth = append(execContext.hostsWithWrongAuth, host)
 			// return here because we assume that
 			// we will get the same error across other nodes
@@ -99,7 +99,7 @@ func (op *httpsCheckNodeStateOp) processResult(execContext *opEngineExecContext)
 		if !result.isPassing() {
 			// for any error, we continue to the next node
 			if result.isInternalError() {
-				op.logger.PrintError("[%s] internal error of the /nodes endpoint: %s", op.name, result.content)
+				op.logger.PrintError("[%s] internal error of the /nodes endpoint: %s", op.name, result.getErrorDetail())
 				// At internal error originated from the server, so its a
 				// response, just not a successful one.
 				respondingNodeCount++
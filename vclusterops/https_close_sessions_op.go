@@ -0,0 +1,136 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type httpsCloseSessionsOp struct {
+	opBase
+	opHTTPSBase
+	sessionIDs []string
+	closedIDs  *[]string // Filled in once the op completes
+}
+
+type closeSessionsRequestData struct {
+	SessionIDs []string `json:"session_ids"`
+}
+
+type closeSessionsResponse struct {
+	ClosedSessions []string `json:"closed_sessions"`
+}
+
+// makeHTTPSCloseSessionsOp will create an op that closes the given client
+// sessions from one of the hosts. A session can be closed from any node,
+// not only from the node that it is connected to.
+func makeHTTPSCloseSessionsOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, sessionIDs []string, closedIDs *[]string) (httpsCloseSessionsOp, error) {
+	op := httpsCloseSessionsOp{}
+	op.name = "HTTPSCloseSessionsOp"
+	op.description = "Close client sessions"
+	op.hosts = hosts
+	op.sessionIDs = sessionIDs
+	op.closedIDs = closedIDs
+	op.responseSchema = responseSchema{{path: "closed_sessions", typ: jsonArray}}
+
+	err := op.validateAndSetUsernameAndPassword(op.name,
+		useHTTPPassword, userName, httpsPassword)
+
+	return op, err
+}
+
+func (op *httpsCloseSessionsOp) setupRequestBody() (string, error) {
+	dataBytes, err := json.Marshal(closeSessionsRequestData{SessionIDs: op.sessionIDs})
+	if err != nil {
+		return "", fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	return string(dataBytes), nil
+}
+
+func (op *httpsCloseSessionsOp) setupClusterHTTPRequest(hosts []string) error {
+	requestData, err := op.setupRequestBody()
+	if err != nil {
+		return err
+	}
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildHTTPSEndpoint("sessions/close")
+		httpRequest.RequestData = requestData
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsCloseSessionsOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsCloseSessionsOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsCloseSessionsOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsCloseSessionsOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like
+		// {"closed_sessions": ["v_test_db_node0001-12345:0x3b"]}
+		// the sessions that ended before they could be closed are not in the list
+		resp := closeSessionsResponse{}
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		if len(resp.ClosedSessions) < len(op.sessionIDs) {
+			op.logger.Info("some sessions ended before they could be closed",
+				"requested", len(op.sessionIDs), "closed", len(resp.ClosedSessions))
+		}
+		*op.closedIDs = resp.ClosedSessions
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
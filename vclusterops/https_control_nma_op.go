@@ -0,0 +1,112 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsControlNMAOp struct {
+	opBase
+	opHTTPSBase
+	action      NMAAction
+	serviceName string
+}
+
+// makeHTTPSControlNMAOp will create an op that asks the Vertica HTTPS service
+// of each host to start or stop the systemd unit of the NMA on the host. It
+// can only reach the hosts where Vertica is up, and is used when the NMA
+// cannot be reached itself.
+func makeHTTPSControlNMAOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, action NMAAction, serviceName string) (httpsControlNMAOp, error) {
+	op := httpsControlNMAOp{}
+	op.name = "HTTPSControlNMAOp"
+	op.description = fmt.Sprintf("%s NMA through the Vertica HTTPS service", action)
+	op.hosts = hosts
+	op.action = action
+	op.serviceName = serviceName
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsControlNMAOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildHTTPSEndpoint("node-management-agent/" + string(op.action))
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = map[string]string{"service": op.serviceName}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsControlNMAOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsControlNMAOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsControlNMAOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsControlNMAOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to %s NMA on host %s: %w", op.name, op.action, host, result.err))
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "NMA started"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,124 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsCreateArchiveOp struct {
+	opBase
+	opHTTPSBase
+	archiveName string
+	// maximum number of restore points kept in the archive, 0 means unlimited
+	numRestorePoints int
+	// namespace of the archive, empty for the default namespace
+	namespace string
+}
+
+// makeHTTPSCreateArchiveOp will make an op that creates a restore point archive.
+// The oldest restore points are removed once the archive holds more than
+// numRestorePoints restore points.
+func makeHTTPSCreateArchiveOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, archiveName, namespace string, numRestorePoints int) (httpsCreateArchiveOp, error) {
+	op := httpsCreateArchiveOp{}
+	op.name = "HTTPSCreateArchiveOp"
+	op.description = "Create restore point archive"
+	op.hosts = hosts
+	op.archiveName = archiveName
+	op.numRestorePoints = numRestorePoints
+	op.namespace = namespace
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsCreateArchiveOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildHTTPSEndpoint("archives/" + op.archiveName)
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = make(map[string]string)
+		if op.numRestorePoints > 0 {
+			httpRequest.QueryParams["limit"] = strconv.Itoa(op.numRestorePoints)
+		}
+		if op.namespace != "" {
+			httpRequest.QueryParams["namespace"] = op.namespace
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsCreateArchiveOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsCreateArchiveOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsCreateArchiveOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsCreateArchiveOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "archive db1 created"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
@@ -31,14 +31,24 @@ type httpsCreateNodeOp struct {
 func makeHTTPSCreateNodeOp(newNodeHosts []string, bootstrapHost []string,
 	useHTTPPassword bool, userName string, httpsPassword *string,
 	vdb *VCoordinationDatabase, scName string) (httpsCreateNodeOp, error) {
+	return makeHTTPSCreateNodeOpWithPrefixes(newNodeHosts, bootstrapHost, useHTTPPassword, userName,
+		httpsPassword, vdb.Name, vdb.CatalogPrefix, vdb.DataPrefix, scName)
+}
+
+// makeHTTPSCreateNodeOpWithPrefixes will make an op that creates the nodes of
+// the new hosts with the given catalog and data prefixes
+func makeHTTPSCreateNodeOpWithPrefixes(newNodeHosts []string, bootstrapHost []string,
+	useHTTPPassword bool, userName string, httpsPassword *string,
+	dbName, catalogPrefix, dataPrefix, scName string) (httpsCreateNodeOp, error) {
 	op := httpsCreateNodeOp{}
 	op.name = "HTTPSCreateNodeOp"
 	op.description = "Create node in catalog"
+	op.responseSchema = responseSchema{{path: "created_nodes", typ: jsonArray}}
 	op.hosts = bootstrapHost
 	op.RequestParams = make(map[string]string)
 	// HTTPS create node endpoint requires passing everything before node name
-	op.RequestParams["catalog-prefix"] = vdb.CatalogPrefix + "/" + vdb.Name
-	op.RequestParams["data-prefix"] = vdb.DataPrefix + "/" + vdb.Name
+	op.RequestParams["catalog-prefix"] = catalogPrefix + "/" + dbName
+	op.RequestParams["data-prefix"] = dataPrefix + "/" + dbName
 	op.RequestParams["hosts"] = util.ArrayToString(newNodeHosts, ",")
 	if scName != "" {
 		op.RequestParams["subcluster"] = scName
@@ -115,15 +125,8 @@ func (op *httpsCreateNodeOp) processResult(_ *opEngineExecContext) error {
 			//                    {'name': 'v_running_db_node0003', 'catalog_path': '/data/v_running_db_node0003_catalog'}]}
 			var responseObj httpsCreateNodeResponse
 			err := op.parseAndCheckResponse(host, result.content, &responseObj)
-
 			if err != nil {
 				allErrs = errors.Join(allErrs, err)
-				continue
-			}
-			_, ok := responseObj["created_nodes"]
-			if !ok {
-				err = fmt.Errorf(`[%s] response does not contain field "created_nodes"`, op.name)
-				allErrs = errors.Join(allErrs, err)
 			}
 		} else {
 			allErrs = errors.Join(allErrs, result.err)
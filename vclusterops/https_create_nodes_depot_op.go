@@ -55,7 +55,10 @@ func (op *httpsCreateNodesDepotOp) setupClusterHTTPRequest(hosts []string) error
 	for _, host := range hosts {
 		httpRequest := hostHTTPRequest{}
 		httpRequest.Method = PostMethod
-		node := op.HostNodeMap[host]
+		node, err := op.HostNodeMap.getNode(host)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", op.name, err)
+		}
 		httpRequest.buildHTTPSEndpoint("nodes/" + node.Name + "/depot")
 		if op.useHTTPPassword {
 			httpRequest.Password = op.httpsPassword
@@ -115,9 +118,14 @@ func (op *httpsCreateNodesDepotOp) processResult(_ *opEngineExecContext) error {
 		}
 
 		// verify if the node name and the depot location are correct
-		if resp["node"] != op.HostNodeMap[host].Name || resp["depot_location"] != op.HostNodeMap[host].DepotPath {
+		node, err := op.HostNodeMap.getNode(host)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] %w", op.name, err))
+			continue
+		}
+		if resp["node"] != node.Name || resp["depot_location"] != node.DepotPath {
 			err := fmt.Errorf(`[%s] should create depot %s on node %s, but created depot %s on node %s from host %s`,
-				op.name, op.HostNodeMap[host].DepotPath, op.HostNodeMap[host].Name, resp["depot_location"], resp["node"], host)
+				op.name, node.DepotPath, node.Name, resp["depot_location"], resp["node"], host)
 			allErrs = errors.Join(allErrs, err)
 			// not break here because we want to log all the failed nodes
 		}
@@ -0,0 +1,115 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsDropArchiveOp struct {
+	opBase
+	opHTTPSBase
+	archiveName string
+	// namespace of the archive, empty for the default namespace
+	namespace string
+}
+
+// makeHTTPSDropArchiveOp will make an op that drops a restore point archive
+// and all its restore points.
+func makeHTTPSDropArchiveOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, archiveName, namespace string) (httpsDropArchiveOp, error) {
+	op := httpsDropArchiveOp{}
+	op.name = "HTTPSDropArchiveOp"
+	op.description = "Drop restore point archive"
+	op.hosts = hosts
+	op.archiveName = archiveName
+	op.namespace = namespace
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsDropArchiveOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = DeleteMethod
+		httpRequest.buildHTTPSEndpoint("archives/" + op.archiveName)
+		if op.namespace != "" {
+			httpRequest.QueryParams = map[string]string{"namespace": op.namespace}
+		}
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsDropArchiveOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsDropArchiveOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsDropArchiveOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsDropArchiveOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "archive db1 dropped"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
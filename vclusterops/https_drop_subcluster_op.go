@@ -17,7 +17,6 @@ package vclusterops
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
@@ -87,8 +86,7 @@ func (op *httpsDropSubclusterOp) processResult(_ *opEngineExecContext) error {
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			return fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return makeWrongCredentialError(op.name, host)
 		}
 
 		if result.isPassing() {
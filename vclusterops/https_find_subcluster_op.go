@@ -18,11 +18,14 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+
+	"github.com/vertica/vcluster/rfc7807"
 )
 
 const (
 	AddNodeCmd CommandType = iota
 	RemoveSubclusterCmd
+	ScaleSubclusterCmd
 )
 
 type httpsFindSubclusterOp struct {
@@ -86,9 +89,10 @@ func (op *httpsFindSubclusterOp) execute(execContext *opEngineExecContext) error
 
 // the following struct will store a subcluster's information for this op
 type subclusterInfo struct {
-	SCName    string `json:"subcluster_name"`
-	IsDefault bool   `json:"is_default"`
-	Sandbox   string `json:"sandbox"`
+	SCName      string `json:"subcluster_name"`
+	IsDefault   bool   `json:"is_default"`
+	IsSecondary bool   `json:"is_secondary"`
+	Sandbox     string `json:"sandbox"`
 }
 
 type scResp struct {
@@ -142,7 +146,7 @@ func (op *httpsFindSubclusterOp) processResult(execContext *opEngineExecContext)
 		}
 
 		// process subclusters
-		if err := op.processSubclusters(subclusterResp, execContext); err != nil {
+		if err := op.processSubclusters(host, subclusterResp, execContext); err != nil {
 			allErrs = errors.Join(allErrs, err)
 			return allErrs
 		}
@@ -153,7 +157,8 @@ func (op *httpsFindSubclusterOp) processResult(execContext *opEngineExecContext)
 	return allErrs
 }
 
-func (op *httpsFindSubclusterOp) processSubclusters(subclusterResp scResp, execContext *opEngineExecContext) error {
+func (op *httpsFindSubclusterOp) processSubclusters(host string, subclusterResp scResp,
+	execContext *opEngineExecContext) error {
 	// 1. when subcluster name is given, look for the name in the database
 	//    error out if not found
 	// 2. look for the default subcluster, error out if not found
@@ -184,7 +189,9 @@ func (op *httpsFindSubclusterOp) processSubclusters(subclusterResp scResp, execC
 
 	if op.scName != "" && !op.ignoreNotFound {
 		if !foundNamedSc {
-			return fmt.Errorf(`[%s] subcluster '%s' does not exist in the database`, op.name, op.scName)
+			return rfc7807.New(rfc7807.SubclusterNotFound).
+				WithDetail(fmt.Sprintf(`[%s] subcluster '%s' does not exist in the database`, op.name, op.scName)).
+				WithHost(host)
 		}
 	}
 
@@ -198,6 +205,8 @@ func (op *httpsFindSubclusterOp) processSubclusters(subclusterResp scResp, execC
 			return fmt.Errorf(`[%s] cannot add node into a sandboxed subcluster`, op.name)
 		case RemoveSubclusterCmd:
 			return fmt.Errorf(`[%s] cannot remove a sandboxed subcluster, must unsandbox the subcluster first`, op.name)
+		case ScaleSubclusterCmd:
+			return fmt.Errorf(`[%s] cannot scale a sandboxed subcluster`, op.name)
 		default:
 			return fmt.Errorf(`[%s] sandbox handling in the operation is not implemented`, op.name)
 		}
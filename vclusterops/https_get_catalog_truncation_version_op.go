@@ -0,0 +1,125 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetCatalogTruncationVersionOp struct {
+	opBase
+	opHTTPSBase
+	truncationVersion int64
+}
+
+func makeHTTPSGetCatalogTruncationVersionOp(hosts []string, useHTTPPassword bool,
+	userName string, httpsPassword *string) (httpsGetCatalogTruncationVersionOp, error) {
+	op := httpsGetCatalogTruncationVersionOp{}
+	op.name = "HTTPSGetCatalogTruncationVersionOp"
+	op.description = "Get the catalog truncation version"
+	op.responseSchema = responseSchema{{path: "truncation_version", typ: jsonString}}
+	op.hosts = hosts
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetCatalogTruncationVersionOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("cluster/catalog/truncation-version")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetCatalogTruncationVersionOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetCatalogTruncationVersionOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetCatalogTruncationVersionOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// the response object will be a dictionary, e.g.,
+		// {"truncation_version": "18"}
+		resp, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		op.truncationVersion, err = parseTruncationVersion(resp["truncation_version"])
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] %w", op.name, err))
+			continue
+		}
+
+		// good response from one node is enough for us
+		return nil
+	}
+	return appendHTTPSFailureError(allErrs)
+}
+
+func (op *httpsGetCatalogTruncationVersionOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+// parseTruncationVersion parses a catalog truncation version, which the
+// HTTPS endpoints return as a string
+func parseTruncationVersion(version string) (int64, error) {
+	truncationVersion, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid catalog truncation version %q, details: %w", version, err)
+	}
+	return truncationVersion, nil
+}
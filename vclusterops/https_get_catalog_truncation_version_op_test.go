@@ -0,0 +1,54 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestParseTruncationVersion(t *testing.T) {
+	version, err := parseTruncationVersion("18")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(18), version)
+
+	_, err = parseTruncationVersion("")
+	assert.ErrorContains(t, err, "invalid catalog truncation version")
+	_, err = parseTruncationVersion("v18")
+	assert.ErrorContains(t, err, "invalid catalog truncation version")
+}
+
+func TestCatalogTruncationVersionResult(t *testing.T) {
+	password := "password"
+	op, err := makeHTTPSGetCatalogTruncationVersionOp([]string{"192.168.1.101"}, true, "dbadmin", &password)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+	op.setupBasicInfo()
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.168.1.101": {host: "192.168.1.101", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"truncation_version": "42"}`},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Equal(t, int64(42), op.truncationVersion)
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.168.1.101": {host: "192.168.1.101", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"truncation_version": "unknown"}`},
+	}
+	assert.ErrorContains(t, op.processResult(nil), "invalid catalog truncation version")
+}
@@ -94,8 +94,7 @@ func (op *httpsGetClusterInfoOp) processResult(_ *opEngineExecContext) error {
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			return fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return makeWrongCredentialError(op.name, host)
 		}
 
 		if result.isPassing() {
@@ -0,0 +1,129 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetConfigParameterOp struct {
+	opBase
+	opHTTPSBase
+	parameter string
+	level     ConfigParameterLevel
+	levelName string
+	info      *ConfigParameterInfo // Filled in once the op completes
+}
+
+// ConfigParameterInfo is the value of a configuration parameter at a level
+type ConfigParameterInfo struct {
+	Name         string `json:"parameter_name"`
+	CurrentValue string `json:"current_value"`
+	DefaultValue string `json:"default_value"`
+	// the level the current value is set at, which can be a higher level
+	// than the requested one if the value is inherited
+	Level string `json:"level"`
+}
+
+// makeHTTPSGetConfigParameterOp will create an op that gets the value of a
+// configuration parameter at a level. levelName is the node or the
+// subcluster name, empty at the database level.
+func makeHTTPSGetConfigParameterOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, parameter string, level ConfigParameterLevel, levelName string,
+	info *ConfigParameterInfo) (httpsGetConfigParameterOp, error) {
+	op := httpsGetConfigParameterOp{}
+	op.name = "HTTPSGetConfigParameterOp"
+	op.description = "Get configuration parameter"
+	op.hosts = hosts
+	op.parameter = parameter
+	op.level = level
+	op.levelName = levelName
+	op.info = info
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetConfigParameterOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint(configParameterEndpoint + op.parameter)
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = getConfigParameterLevelParams(op.level, op.levelName)
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetConfigParameterOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetConfigParameterOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetConfigParameterOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetConfigParameterOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The response object will be a dictionary, an example:
+		// {"parameter_name": "MaxClientSessions", "current_value": "100",
+		//  "default_value": "50", "level": "DATABASE"}
+		err := op.parseAndCheckResponse(host, result.content, op.info)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
@@ -0,0 +1,109 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetKSafetyOp struct {
+	opBase
+	opHTTPSBase
+	ksafety *KSafetyInfo // Filled in once the op completes
+}
+
+// makeHTTPSGetKSafetyOp will create an op that gets the design k-safety and
+// the current fault tolerance of the database
+func makeHTTPSGetKSafetyOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, ksafety *KSafetyInfo) (httpsGetKSafetyOp, error) {
+	op := httpsGetKSafetyOp{}
+	op.name = "HTTPSGetKSafetyOp"
+	op.description = "Get k-safety"
+	op.hosts = hosts
+	op.ksafety = ksafety
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetKSafetyOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("cluster/k-safety")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetKSafetyOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetKSafetyOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetKSafetyOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetKSafetyOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The response object will be a dictionary, an example:
+		// {"designed_ksafety": 1, "current_fault_tolerance": 1}
+		err := op.parseAndCheckResponse(host, result.content, op.ksafety)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
@@ -0,0 +1,133 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetLicenseOp struct {
+	opBase
+	opHTTPSBase
+	license *licenseInfo // Filled in once the op completes
+}
+
+// licenseInfo is the response of the license endpoint, with the limits of
+// the license of the database and the result of its last audit
+type licenseInfo struct {
+	LicenseType string `json:"license_type"`
+	// "Perpetual" or a date like 2025-12-31
+	ExpirationDate string `json:"expiration_date"`
+	// 0 means that the license has no limit
+	SizeLimitBytes   int64  `json:"size_limit_bytes"`
+	NodeLimit        int    `json:"node_limit"`
+	AuditedSizeBytes int64  `json:"audited_size_bytes"`
+	AuditTime        string `json:"audit_time"`
+}
+
+// makeHTTPSGetLicenseOp will create an op that gets the license of the
+// database and the result of its last audit from one of the hosts
+func makeHTTPSGetLicenseOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, license *licenseInfo) (httpsGetLicenseOp, error) {
+	op := httpsGetLicenseOp{}
+	op.name = "HTTPSGetLicenseOp"
+	op.description = "Get license of database"
+	op.hosts = hosts
+	op.license = license
+	op.useHTTPPassword = useHTTPPassword
+	op.responseSchema = responseSchema{
+		{path: "license_type", typ: jsonString},
+		{path: "expiration_date", typ: jsonString},
+		{path: "size_limit_bytes", typ: jsonNumber},
+		{path: "node_limit", typ: jsonNumber},
+		{path: "audited_size_bytes", typ: jsonNumber},
+	}
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetLicenseOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("license")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetLicenseOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetLicenseOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetLicenseOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetLicenseOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like
+		// {"license_type": "Premium Edition", "expiration_date": "2025-12-31",
+		//  "size_limit_bytes": 1099511627776, "node_limit": 0,
+		//  "audited_size_bytes": 53687091200, "audit_time": "2024-05-01 10:00:00.000000-04"}
+		license := licenseInfo{}
+		err := op.parseAndCheckResponse(host, result.content, &license)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		*op.license = license
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
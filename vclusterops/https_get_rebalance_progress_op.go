@@ -0,0 +1,118 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetRebalanceProgressOp struct {
+	opBase
+	opHTTPSBase
+	progress *RebalanceProgress // Filled in once the op completes
+}
+
+// RebalanceProgress is the progress of a cluster rebalance, computed by the
+// server from the rebalance status system tables
+type RebalanceProgress struct {
+	PercentComplete float64 `json:"percent_complete"`
+	// number of projections rebalanced so far and to rebalance in total
+	RebalancedProjections int `json:"rebalanced_projections"`
+	TotalProjections      int `json:"total_projections"`
+}
+
+// makeHTTPSGetRebalanceProgressOp will create an op that gets the progress of
+// the running cluster rebalance
+func makeHTTPSGetRebalanceProgressOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, progress *RebalanceProgress) (httpsGetRebalanceProgressOp, error) {
+	op := httpsGetRebalanceProgressOp{}
+	op.name = "HTTPSGetRebalanceProgressOp"
+	op.description = "Get rebalance progress"
+	op.hosts = hosts
+	op.progress = progress
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetRebalanceProgressOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("cluster/rebalance/progress")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetRebalanceProgressOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetRebalanceProgressOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetRebalanceProgressOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetRebalanceProgressOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The response object will be a dictionary, an example:
+		// {"percent_complete": 42.5, "rebalanced_projections": 17, "total_projections": 40}
+		err := op.parseAndCheckResponse(host, result.content, op.progress)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
@@ -0,0 +1,125 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetSessionsOp struct {
+	opBase
+	opHTTPSBase
+	sessions *[]SessionInfo // Filled in once the op completes
+}
+
+type sessionList struct {
+	SessionList []SessionInfo `json:"session_list"`
+}
+
+// makeHTTPSGetSessionsOp will create an op that gets the client sessions of
+// all the nodes of the database from one of the hosts
+func makeHTTPSGetSessionsOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, sessions *[]SessionInfo) (httpsGetSessionsOp, error) {
+	op := httpsGetSessionsOp{}
+	op.name = "HTTPSGetSessionsOp"
+	op.description = "Get client sessions"
+	op.hosts = hosts
+	op.sessions = sessions
+	op.useHTTPPassword = useHTTPPassword
+	op.responseSchema = responseSchema{
+		{path: "session_list", typ: jsonArray},
+		{path: "session_list[].session_id", typ: jsonString},
+		{path: "session_list[].node_name", typ: jsonString},
+	}
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetSessionsOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("sessions")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetSessionsOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetSessionsOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetSessionsOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetSessionsOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like
+		// {"session_list": [{"session_id": "v_test_db_node0001-12345:0x3b",
+		//   "node_name": "v_test_db_node0001", "user_name": "dbadmin",
+		//   "client_hostname": "192.168.1.10:50123",
+		//   "login_timestamp": "2024-05-01 10:00:00.000000-04",
+		//   "statement_start": "2024-05-01 10:01:00.000000-04",
+		//   "current_statement": "SELECT 1;"}]}
+		sessions := sessionList{}
+		err := op.parseAndCheckResponse(host, result.content, &sessions)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		*op.sessions = sessions.SessionList
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
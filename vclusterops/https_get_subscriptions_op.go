@@ -0,0 +1,115 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsGetSubscriptionsOp struct {
+	opBase
+	opHTTPSBase
+	subscriptions *[]subscriptionInfo // Filled in once the op completes
+}
+
+// makeHTTPSGetSubscriptionsOp will create an op that gets the shard subscriptions
+// of all nodes. If hosts is empty, an up host found by a previous op is used.
+func makeHTTPSGetSubscriptionsOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, subscriptions *[]subscriptionInfo) (httpsGetSubscriptionsOp, error) {
+	op := httpsGetSubscriptionsOp{}
+	op.name = "HTTPSGetSubscriptionsOp"
+	op.description = "Get shard subscriptions"
+	op.hosts = hosts
+	op.subscriptions = subscriptions
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsGetSubscriptionsOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildHTTPSEndpoint("subscriptions")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsGetSubscriptionsOp) prepare(execContext *opEngineExecContext) error {
+	if len(op.hosts) == 0 {
+		if len(execContext.upHosts) == 0 {
+			return fmt.Errorf(`[%s] Cannot find any up hosts in OpEngineExecContext`, op.name)
+		}
+		op.hosts = []string{getInitiator(execContext.upHosts)}
+	}
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsGetSubscriptionsOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsGetSubscriptionsOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsGetSubscriptionsOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		subscriptions := subscriptionList{}
+		err := op.parseAndCheckResponse(host, result.content, &subscriptions)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		*op.subscriptions = subscriptions.SubscriptionList
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
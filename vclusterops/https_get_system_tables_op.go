@@ -17,7 +17,6 @@ package vclusterops
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -104,8 +103,7 @@ func (op *httpsGetSystemTablesOp) processResult(execContext *opEngineExecContext
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			return fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return makeWrongCredentialError(op.name, host)
 		}
 
 		if result.isPassing() {
@@ -61,6 +61,8 @@ func makeHTTPSGetUpNodesOp(dbName string, hosts []string,
 	op.cmdType = cmdType
 	op.sandbox = ""
 	op.mainCluster = false
+	// an unreachable host is reported as down
+	op.skipQuarantinedHosts = true
 
 	if useHTTPPassword {
 		err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
@@ -28,7 +29,11 @@ type httpsInstallPackagesOp struct {
 	opHTTPSBase
 	verbose        bool // Include verbose output about package install status
 	forceReinstall bool
+	packageNames   []string             // Install only these packages if not empty
 	status         InstallPackageStatus // Filled in once the op completes
+	// number of hosts on which the install can fail before another host
+	// installs the packages successfully
+	maxHostFailures int
 }
 
 func makeHTTPSInstallPackagesOp(hosts []string, useHTTPPassword bool,
@@ -40,6 +45,7 @@ func makeHTTPSInstallPackagesOp(hosts []string, useHTTPPassword bool,
 	op.hosts = hosts
 	op.verbose = verbose
 	op.forceReinstall = forceReinstall
+	op.status.Summary = makeHostResultSummary()
 
 	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
 	if err != nil {
@@ -63,6 +69,9 @@ func (op *httpsInstallPackagesOp) setupClusterHTTPRequest(hosts []string) error
 		httpRequest.QueryParams = map[string]string{
 			"force-install": strconv.FormatBool(op.forceReinstall),
 		}
+		if len(op.packageNames) > 0 {
+			httpRequest.QueryParams["packages"] = strings.Join(op.packageNames, ",")
+		}
 		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
 	}
 
@@ -75,20 +84,46 @@ func (op *httpsInstallPackagesOp) prepare(execContext *opEngineExecContext) erro
 		if len(execContext.upHosts) == 0 {
 			return fmt.Errorf(`[%s] Cannot find any up hosts in OpEngineExecContext`, op.name)
 		}
-		// use first up host to execute https post request
-		op.hosts = []string{execContext.upHosts[0]}
+		// use the first up host to execute https post request, and the
+		// next ones if it fails, as many as the tolerated failures
+		hostCount := op.maxHostFailures + 1
+		if hostCount > len(execContext.upHosts) {
+			hostCount = len(execContext.upHosts)
+		}
+		op.hosts = execContext.upHosts[:hostCount]
 	}
 	execContext.dispatcher.setup(op.hosts)
 
 	return op.setupClusterHTTPRequest(op.hosts)
 }
 
+// execute sends the install request to one host at a time, as any host
+// installs the packages for the whole database. The next host is tried if
+// the install fails, until it succeeds or more than maxHostFailures hosts
+// failed.
 func (op *httpsInstallPackagesOp) execute(execContext *opEngineExecContext) error {
-	if err := op.runExecute(execContext); err != nil {
-		return err
+	requests := op.clusterHTTPRequest.RequestCollection
+	var allErrs error
+	for _, host := range op.hosts {
+		op.clusterHTTPRequest.RequestCollection = map[string]hostHTTPRequest{host: requests[host]}
+		if err := op.runExecute(execContext); err != nil {
+			return err
+		}
+		err := op.processResult(execContext)
+		if err == nil {
+			break
+		}
+		allErrs = errors.Join(allErrs, err)
+		if len(op.status.Summary.HostsFailed) > op.maxHostFailures {
+			break
+		}
 	}
+	op.clusterHTTPRequest.RequestCollection = requests
 
-	return op.processResult(execContext)
+	if len(op.status.Summary.HostsSucceeded) == 0 {
+		return allErrs
+	}
+	return op.status.Summary.checkHostFailures(op.name, op.maxHostFailures, allErrs, op.logger)
 }
 
 func (op *httpsInstallPackagesOp) finalize(_ *opEngineExecContext) error {
@@ -117,6 +152,31 @@ structs, will look like this:
 // InstallPackageStatus provides status for each package install attempted.
 type InstallPackageStatus struct {
 	Packages []PackageStatus `json:"packages"`
+	// Summary tells on which hosts the install succeeded or failed
+	Summary HostResultSummary `json:"host_summary"`
+}
+
+// getPackageStatus returns the status of the given package, or nil if the
+// package is not in the status list. Package names are case insensitive.
+func (s *InstallPackageStatus) getPackageStatus(name string) *PackageStatus {
+	for i := range s.Packages {
+		if strings.EqualFold(s.Packages[i].PackageName, name) {
+			return &s.Packages[i]
+		}
+	}
+	return nil
+}
+
+// GetPackagesWithStatus returns the names of the packages whose install
+// status is the given one, e.g., "Failure"
+func (s *InstallPackageStatus) GetPackagesWithStatus(installStatus string) []string {
+	var names []string
+	for _, pkg := range s.Packages {
+		if strings.EqualFold(pkg.InstallStatus, installStatus) {
+			names = append(names, pkg.PackageName)
+		}
+	}
+	return names
 }
 
 // PackageStatus has install status for a single package.
@@ -136,18 +196,23 @@ func (op *httpsInstallPackagesOp) processResult(_ *opEngineExecContext) error {
 
 		if !result.isPassing() {
 			allErrs = errors.Join(allErrs, result.err)
+			op.status.Summary.recordFailure(host, result.err)
 			continue
 		}
 
 		err := op.parseAndCheckResponse(host, result.content, &op.status)
 		if err != nil {
 			allErrs = errors.Join(allErrs, err)
+			op.status.Summary.recordFailure(host, err)
 			continue
 		}
 
 		if len(op.status.Packages) == 0 {
 			err = fmt.Errorf(`[%s] response does not have status for any packages`, op.name)
 			allErrs = errors.Join(allErrs, err)
+			op.status.Summary.recordFailure(host, err)
+		} else {
+			op.status.Summary.recordSuccess(host)
 		}
 
 		// Only print out status if verbose output was requested. Otherwise,
@@ -0,0 +1,84 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// mockResultAdapter returns the same result to every request
+type mockResultAdapter struct {
+	result   hostHTTPResult
+	requests *int
+}
+
+func (a *mockResultAdapter) sendRequest(_ *hostHTTPRequest, resultChannel chan<- hostHTTPResult) {
+	*a.requests++
+	resultChannel <- a.result
+}
+
+func (a *mockResultAdapter) generateResult(_ *http.Response) hostHTTPResult {
+	return a.result
+}
+
+func runMockInstallPackages(t *testing.T, maxHostFailures int, results map[string]hostHTTPResult) (
+	httpsInstallPackagesOp, int, error) {
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	execContext.upHosts = []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}
+	op, err := makeHTTPSInstallPackagesOp(nil, false, "", nil, false, false)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+	op.maxHostFailures = maxHostFailures
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.prepare(&execContext))
+
+	requests := 0
+	for host, result := range results {
+		execContext.dispatcher.pool.connections[host] = &mockResultAdapter{result: result, requests: &requests}
+	}
+	err = op.execute(&execContext)
+	return op, requests, err
+}
+
+func TestInstallPackagesHostFailures(t *testing.T) {
+	failed := hostHTTPResult{status: FAILURE, statusCode: InternalErrorCode, err: errors.New("disk full")}
+	installed := hostHTTPResult{status: SUCCESS, statusCode: SuccessCode,
+		content: `{"packages": [{"package_name": "ComplexTypes", "install_status": "Success"}]}`}
+	results := map[string]hostHTTPResult{"192.0.2.1": failed, "192.0.2.2": installed, "192.0.2.3": installed}
+	for host, result := range results {
+		result.host = host
+		results[host] = result
+	}
+
+	// without tolerance, the first failure fails the op
+	op, requests, err := runMockInstallPackages(t, 0, results)
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, []string{"192.0.2.1"}, op.status.Summary.getFailedHosts())
+
+	// with tolerance, the next up host installs the packages
+	op, requests, err = runMockInstallPackages(t, 1, results)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []string{"192.0.2.2"}, op.status.Summary.HostsSucceeded)
+	assert.Equal(t, map[string]string{"192.0.2.1": "disk full"}, op.status.Summary.HostsFailed)
+	assert.Equal(t, []string{"ComplexTypes"}, op.status.GetPackagesWithStatus("Success"))
+}
@@ -23,8 +23,7 @@ import (
 	"github.com/vertica/vcluster/vclusterops/util"
 )
 
-const zeroSafeRspStr = "Marked design 0-safe"
-const oneSafeRspStr = "Marked design 1-safe"
+const markDesignKSafeRspFormat = "Marked design %d-safe"
 
 type httpsMarkDesignKSafeOp struct {
 	opBase
@@ -43,10 +42,11 @@ func makeHTTPSMarkDesignKSafeOp(
 	op := httpsMarkDesignKSafeOp{}
 	op.name = "HTTPSMarkDesignKsafeOp"
 	op.description = "Set k-safety"
+	op.responseSchema = responseSchema{{path: "detail", typ: jsonString}}
 	op.hosts = hosts
 	op.useHTTPPassword = useHTTPPassword
 
-	// set ksafeValue.  Should be 1 or 0.
+	// set ksafeValue.  Should be between 0 and maxKSafety.
 	// store directly for later response verification
 	op.ksafeValue = ksafeValue
 	op.RequestParams = make(map[string]string)
@@ -92,8 +92,7 @@ func (op *httpsMarkDesignKSafeOp) execute(execContext *opEngineExecContext) erro
 	return op.processResult(execContext)
 }
 
-// markDesignKSafeRsp will be either
-// {"detail": "Marked design 0-safe"} OR
+// markDesignKSafeRsp will be like
 // {"detail": "Marked design 1-safe"}
 type markDesignKSafeRsp struct {
 	Detail string `json:"detail"`
@@ -123,11 +122,8 @@ func (op *httpsMarkDesignKSafeOp) processResult(_ *opEngineExecContext) error {
 
 		// retrieve and verify the mark ksafety response
 		var ksafeValue int
-		if markDesignKSafeResponse.Detail == zeroSafeRspStr {
-			ksafeValue = 0
-		} else if markDesignKSafeResponse.Detail == oneSafeRspStr {
-			ksafeValue = 1
-		} else {
+		_, err = fmt.Sscanf(markDesignKSafeResponse.Detail, markDesignKSafeRspFormat, &ksafeValue)
+		if err != nil {
 			err = fmt.Errorf(`[%s] fail to parse the ksafety value information, detail: %s`,
 				op.name, markDesignKSafeResponse.Detail)
 			allErrs = errors.Join(allErrs, err)
@@ -27,6 +27,11 @@ import (
 // 30 seconds is long enough for normal http request.
 // If this timeout is reached, it might imply that the target IP is unreachable
 const defaultHTTPSRequestTimeoutSeconds = 30
+
+// The server holds a GET /v1/nodes/{node}/watch call for up to this many
+// seconds, and answers as soon as the node reaches the expected state
+const defaultNodeStateWatchTimeoutSeconds = 20
+
 const (
 	StartDBCmd CmdType = iota
 	StartNodeCmd
@@ -58,6 +63,19 @@ type httpsPollNodeStateOp struct {
 	cmdType            CmdType
 	// poll for nodes down: Set to true if nodes need to be polled to be down
 	checkDown bool
+	// if set, prints the new lines of vertica.log of the nodes that are not up yet
+	logFollower *verticaLogFollower
+	notUpHosts  []string
+	// whether the op watches the states of the nodes, instead of polling
+	// them on an interval, once their HTTPS service answers
+	watchEnabled bool
+	// set when the HTTPS service does not support watching node states,
+	// the op falls back to interval polling
+	watchUnsupported bool
+	// the hosts whose request is a watch request
+	watchedHosts map[string]bool
+	// how long the server holds a watch request, in seconds
+	watchTimeout int
 }
 
 func makeHTTPSPollNodeStateOpHelper(hosts []string,
@@ -69,6 +87,9 @@ func makeHTTPSPollNodeStateOpHelper(hosts []string,
 	op.useHTTPPassword = useHTTPPassword
 	op.httpRequestTimeout = defaultHTTPSRequestTimeoutSeconds
 	op.checkDown = false // setting default to poll nodes UP
+	op.watchEnabled = true
+	op.watchedHosts = make(map[string]bool)
+	op.watchTimeout = defaultNodeStateWatchTimeoutSeconds
 	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
 	if err != nil {
 		return op, err
@@ -104,6 +125,7 @@ func makeHTTPSPollNodeStateDownOp(hosts []string,
 	}
 	op.timeout = timeoutSecond
 	op.checkDown = true
+	op.watchEnabled = false
 	op.description = fmt.Sprintf("Wait for %d node(s) to go DOWN", len(hosts))
 	return op, nil
 }
@@ -123,6 +145,13 @@ func makeHTTPSPollNodeStateOp(hosts []string,
 	return op, nil
 }
 
+// followVerticaLogs makes the op print the new lines of vertica.log of the
+// nodes that are not up yet while it polls their states
+func (op *httpsPollNodeStateOp) followVerticaLogs(vdb *VCoordinationDatabase) {
+	op.logFollower = makeVerticaLogFollower(vdb)
+	op.notUpHosts = op.hosts
+}
+
 func (op *httpsPollNodeStateOp) getPollingTimeout() int {
 	return util.Max(op.timeout, 0)
 }
@@ -144,6 +173,75 @@ func (op *httpsPollNodeStateOp) setupClusterHTTPRequest(hosts []string) error {
 	return nil
 }
 
+// watchHost turns the request of a host into a watch request, which the
+// server answers like GET /v1/nodes/{node} once the node is up or the watch
+// timeout is reached. The request is updated in place to keep the
+// certificates loaded in it.
+func (op *httpsPollNodeStateOp) watchHost(host string) {
+	httpRequest, ok := op.clusterHTTPRequest.RequestCollection[host]
+	if !ok {
+		return
+	}
+	httpRequest.buildHTTPSEndpoint("nodes/" + host + "/watch")
+	httpRequest.QueryParams = map[string]string{
+		"state":   util.NodeUpState,
+		"timeout": strconv.Itoa(op.watchTimeout),
+	}
+	httpRequest.Timeout = op.httpRequestTimeout + op.watchTimeout
+	op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	op.watchedHosts[host] = true
+}
+
+// stopWatching turns the watch requests back into interval polling requests
+func (op *httpsPollNodeStateOp) stopWatching() {
+	for host := range op.watchedHosts {
+		httpRequest := op.clusterHTTPRequest.RequestCollection[host]
+		httpRequest.buildHTTPSEndpoint("nodes/" + host)
+		httpRequest.QueryParams = nil
+		httpRequest.Timeout = op.httpRequestTimeout
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+	op.watchedHosts = make(map[string]bool)
+}
+
+// updateWatchRequests watches the hosts whose HTTPS service answered, and
+// falls back to interval polling for all the hosts when the HTTPS service
+// does not know the watch endpoint
+func (op *httpsPollNodeStateOp) updateWatchRequests() {
+	if !op.watchEnabled || op.watchUnsupported {
+		return
+	}
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		if op.watchedHosts[host] && result.isNotFound() {
+			op.logger.Info("the HTTPS service does not support watching node states, falling back to polling",
+				"host", host)
+			op.watchUnsupported = true
+			op.stopWatching()
+			return
+		}
+	}
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		if result.isPassing() && !op.watchedHosts[host] {
+			op.watchHost(host)
+		}
+	}
+}
+
+// isWatching returns true if the last poll only sent watch requests and
+// they all got an answer, so the server already waited for the states to
+// change and the next poll can be sent without waiting
+func (op *httpsPollNodeStateOp) isWatching() bool {
+	if len(op.clusterHTTPRequest.ResultCollection) == 0 {
+		return false
+	}
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		if !op.watchedHosts[host] || !result.isPassing() {
+			return false
+		}
+	}
+	return true
+}
+
 func (op *httpsPollNodeStateOp) prepare(execContext *opEngineExecContext) error {
 	execContext.dispatcher.setup(op.hosts)
 
@@ -158,6 +256,13 @@ func (op *httpsPollNodeStateOp) execute(execContext *opEngineExecContext) error
 	return op.processResult(execContext)
 }
 
+func (op *httpsPollNodeStateOp) runExecute(execContext *opEngineExecContext) error {
+	if op.logFollower != nil {
+		op.logFollower.follow(&op.opBase, execContext, op.notUpHosts)
+	}
+	return op.opBase.runExecute(execContext)
+}
+
 func (op *httpsPollNodeStateOp) finalize(_ *opEngineExecContext) error {
 	return nil
 }
@@ -180,14 +285,16 @@ func (op *httpsPollNodeStateOp) shouldStopPolling() (bool, error) {
 	if op.checkDown {
 		return op.shouldStopPollingForDown()
 	}
+	defer op.updateWatchRequests()
 	upNodeCount := 0
+	var notUpHosts []string
 
 	for host, result := range op.clusterHTTPRequest.ResultCollection {
 		op.currentHost = host
 
 		// when we get timeout error, we know that the host is unreachable/dead
 		if result.isTimeout() {
-			return true, fmt.Errorf("[%s] cannot connect to host %s, please check if the host is still alive", op.name, host)
+			return true, makeHostUnreachableError(op.name, host)
 		}
 
 		// VER-88185 vcluster start_db - password related issues
@@ -199,11 +306,13 @@ func (op *httpsPollNodeStateOp) shouldStopPolling() (bool, error) {
 			case StartDBCmd, StartNodeCmd:
 				op.logger.PrintError("[%s] The credentials are incorrect. 'Catalog Sync' will not be executed.",
 					op.name)
-				return false, fmt.Errorf("[%s] wrong password/certificate for https service on host %s, but the nodes' startup have been in progress."+
-					"Please use vsql to check the nodes' status and manually run sync_catalog vsql command 'select sync_catalog()'", op.name, host)
+				return false, &WrongCredentialError{
+					Detail: fmt.Sprintf("[%s] wrong password/certificate for https service on host %s, but the nodes' startup have been in progress."+
+						"Please use vsql to check the nodes' status and manually run sync_catalog vsql command 'select sync_catalog()'", op.name, host),
+					Hosts: []string{host},
+				}
 			case CreateDBCmd:
-				return true, fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-					op.name, host)
+				return true, makeWrongCredentialError(op.name, host)
 			}
 		}
 		if result.isPassing() {
@@ -222,6 +331,7 @@ func (op *httpsPollNodeStateOp) shouldStopPolling() (bool, error) {
 				nodeInfo := nodesInformation.NodeList[0]
 				if nodeInfo.State == util.NodeUpState {
 					upNodeCount++
+					continue
 				}
 			} else {
 				// if HTTPS endpoint cannot function well on any of the hosts, we do not want to retry polling
@@ -230,7 +340,9 @@ func (op *httpsPollNodeStateOp) shouldStopPolling() (bool, error) {
 					op.name, len(nodesInformation.NodeList), host)
 			}
 		}
+		notUpHosts = append(notUpHosts, host)
 	}
+	op.notUpHosts = notUpHosts
 
 	if upNodeCount < len(op.hosts) {
 		op.logger.PrintInfo("[%s] %d host(s) up", op.name, upNodeCount)
@@ -256,15 +368,14 @@ func (op *httpsPollNodeStateOp) shouldStopPollingForDown() (bool, error) {
 
 		// when we get timeout error, we know that the host is unreachable/dead
 		if result.isTimeout() {
-			return true, fmt.Errorf("[%s] cannot connect to host %s, please check if the host is still alive", op.name, host)
+			return true, makeHostUnreachableError(op.name, host)
 		}
 
 		// We don't need to wait until timeout to determine if all nodes are down or not.
 		// If we find the wrong password for the HTTPS service on any hosts, we should fail immediately.
 		// We also need to let user know to wait until all nodes are down
 		if result.isPasswordAndCertificateError(op.logger) {
-			return true, fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return true, makeWrongCredentialError(op.name, host)
 		}
 		if !result.isPassing() {
 			allErrs = errors.Join(allErrs, result.err)
@@ -16,9 +16,11 @@
 package vclusterops
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
@@ -55,3 +57,103 @@ func TestTimeoutErrorCase(t *testing.T) {
 	// no polling is done, directly error out
 	assert.ErrorContains(t, err, "reached polling timeout of 0 seconds")
 }
+
+func TestFollowVerticaLogsOfNotUpHosts(t *testing.T) {
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+	password := "testPwd"
+	op, err := makeHTTPSPollNodeStateOp(hosts, true, "testUser", &password)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.168.1.101": &VCoordinationNode{Name: "v_test_db_node0001", CatalogPath: "/data/v_test_db_node0001_catalog"},
+		"192.168.1.102": &VCoordinationNode{Name: "v_test_db_node0002", CatalogPath: "/data/v_test_db_node0002_catalog"},
+	}
+	op.followVerticaLogs(&vdb)
+	assert.Equal(t, hosts, op.notUpHosts)
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.168.1.101": {host: "192.168.1.101", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"node_list": [{"name": "v_test_db_node0001", "state": "UP"}]}`},
+		"192.168.1.102": {host: "192.168.1.102", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"node_list": [{"name": "v_test_db_node0002", "state": "INITIALIZING"}]}`},
+	}
+	stop, err := op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.False(t, stop)
+	// only the log of the node that is not up yet is still followed
+	assert.Equal(t, []string{"192.168.1.102"}, op.notUpHosts)
+
+	// the offsets of the next reads are the ones returned by the NMA
+	op.logFollower.printResults(&op.opBase, map[string]hostHTTPResult{
+		"192.168.1.102": {host: "192.168.1.102", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"offset": 2048, "lines": ["Startup: loading the catalog"]}`},
+	})
+	assert.Equal(t, map[string]int64{"192.168.1.102": 2048}, op.logFollower.offsets)
+}
+
+func TestWatchNodeStates(t *testing.T) {
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+	password := "testPwd"
+	op, err := makeHTTPSPollNodeStateOp(hosts, true, "testUser", &password)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(hosts))
+
+	initializing := hostHTTPResult{status: SUCCESS, statusCode: SuccessCode,
+		content: `{"node_list": [{"name": "v_test_db_node0001", "state": "INITIALIZING"}]}`}
+	refused := hostHTTPResult{status: EXCEPTION, err: errors.New("connection refused")}
+	notFound := hostHTTPResult{status: FAILURE, statusCode: NotFoundCode, err: errors.New("not found")}
+
+	// a host is watched once its HTTPS service answers
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{"192.168.1.101": initializing, "192.168.1.102": refused}
+	stop, err := op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.False(t, stop)
+	request := op.clusterHTTPRequest.RequestCollection["192.168.1.101"]
+	assert.Equal(t, HTTPCurVersion+"nodes/192.168.1.101/watch", request.Endpoint)
+	assert.Equal(t, map[string]string{"state": util.NodeUpState, "timeout": "20"}, request.QueryParams)
+	assert.Equal(t, HTTPCurVersion+"nodes/192.168.1.102", op.clusterHTTPRequest.RequestCollection["192.168.1.102"].Endpoint)
+
+	// the next poll waits as long as a host is not watched
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{"192.168.1.101": initializing, "192.168.1.102": initializing}
+	assert.False(t, op.isWatching())
+	_, err = op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.True(t, op.isWatching())
+
+	// an older HTTPS service does not know the watch endpoint
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{"192.168.1.101": notFound, "192.168.1.102": initializing}
+	_, err = op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.True(t, op.watchUnsupported)
+	assert.False(t, op.isWatching())
+	request = op.clusterHTTPRequest.RequestCollection["192.168.1.101"]
+	assert.Equal(t, HTTPCurVersion+"nodes/192.168.1.101", request.Endpoint)
+	assert.Empty(t, request.QueryParams)
+	assert.Equal(t, defaultHTTPSRequestTimeoutSeconds, request.Timeout)
+
+	// the hosts are not watched again
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{"192.168.1.101": initializing, "192.168.1.102": initializing}
+	_, err = op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.Empty(t, op.watchedHosts)
+}
+
+func TestWatchNotUsedForDownNodes(t *testing.T) {
+	password := "testPwd"
+	op, err := makeHTTPSPollNodeStateDownOp([]string{"192.168.1.101"}, true, "testUser", &password)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.168.1.101": {status: SUCCESS, statusCode: SuccessCode, content: `{"node_list": []}`},
+	}
+	_, err = op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.Empty(t, op.watchedHosts)
+	assert.False(t, op.isWatching())
+}
@@ -26,6 +26,9 @@ type httpsPollSubscriptionStateOp struct {
 	opHTTPSBase
 	timeout     int
 	nodesToPoll *[]string
+	// the subscriptions of the nodes to poll when the polling stopped, set
+	// if the subscriptions were received at least once
+	lastSubscriptions []subscriptionInfo
 }
 
 func makeHTTPSPollSubscriptionStateOp(hosts []string,
@@ -136,8 +139,7 @@ func (op *httpsPollSubscriptionStateOp) shouldStopPolling() (bool, error) {
 		op.logResponse(host, result)
 
 		if result.isPasswordAndCertificateError(op.logger) {
-			return true, fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return true, makeWrongCredentialError(op.name, host)
 		}
 
 		if result.isPassing() {
@@ -148,7 +150,11 @@ func (op *httpsPollSubscriptionStateOp) shouldStopPolling() (bool, error) {
 				return true, err
 			}
 
+			op.lastSubscriptions = filterSubsOfNodes(&subscriptList, op.nodesToPoll)
 			if containsInactiveSub(&subscriptList, op.nodesToPoll) {
+				activeCount, totalCount := countActiveSubs(&subscriptList, op.nodesToPoll)
+				op.logger.PrintInfo("[%s] %d of %d subscriptions are ACTIVE", op.name, activeCount, totalCount)
+				op.updateSpinnerMessage("%d of %d subscriptions are ACTIVE", activeCount, totalCount)
 				return false, nil
 			}
 
@@ -173,3 +179,29 @@ func containsInactiveSub(subscriptList *subscriptionList, nodesToPoll *[]string)
 	// all subs of all nodes in nodesToPoll are active
 	return len(*nodesToPoll) != len(nodesToPollWithActiveSubs)
 }
+
+// countActiveSubs returns the number of active subscriptions and the total
+// number of subscriptions of the nodes in nodesToPoll
+func countActiveSubs(subscriptList *subscriptionList, nodesToPoll *[]string) (activeCount, totalCount int) {
+	for _, s := range subscriptList.SubscriptionList {
+		if !util.StringInArray(s.Nodename, *nodesToPoll) {
+			continue
+		}
+		totalCount++
+		if s.SubscriptionState == "ACTIVE" {
+			activeCount++
+		}
+	}
+	return activeCount, totalCount
+}
+
+// filterSubsOfNodes returns the subscriptions of the nodes in nodesToPoll
+func filterSubsOfNodes(subscriptList *subscriptionList, nodesToPoll *[]string) []subscriptionInfo {
+	var subs []subscriptionInfo
+	for _, s := range subscriptList.SubscriptionList {
+		if util.StringInArray(s.Nodename, *nodesToPoll) {
+			subs = append(subs, s)
+		}
+	}
+	return subs
+}
@@ -34,6 +34,11 @@ func (e *ReIPNoClusterQuorumError) Error() string {
 	return e.Detail
 }
 
+// Unwrap lets callers match this error with the more generic NoQuorumError
+func (e *ReIPNoClusterQuorumError) Unwrap() error {
+	return &NoQuorumError{Detail: e.Detail}
+}
+
 type httpsReIPOp struct {
 	opBase
 	opHTTPSBase
@@ -48,6 +53,7 @@ func makeHTTPSReIPOp(nodeNamesToReIP, hostToReIP []string,
 	op := httpsReIPOp{}
 	op.name = "HTTPSReIpOp"
 	op.description = "Change host IPs in the catalog"
+	op.responseSchema = responseSchema{{path: "detail", typ: jsonString}}
 	op.useHTTPPassword = useHTTPPassword
 	op.nodeNamesToReIP = nodeNamesToReIP
 	op.hostToReIP = hostToReIP
@@ -136,8 +142,7 @@ func (op *httpsReIPOp) processResult(_ *opEngineExecContext) error {
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			return fmt.Errorf("[%s] wrong password/certificate for https service on host %s",
-				op.name, host)
+			return makeWrongCredentialError(op.name, host)
 		}
 
 		if !result.isPassing() {
@@ -156,13 +161,7 @@ func (op *httpsReIPOp) processResult(_ *opEngineExecContext) error {
 		}
 
 		// verify if the response content is correct
-		v, ok := reIPRsp["detail"]
-		if !ok {
-			err = fmt.Errorf(`[%s] response does not contain field "detail"`, op.name)
-			allErrs = errors.Join(allErrs, err)
-			break
-		}
-		if v != "" {
+		if reIPRsp["detail"] != "" {
 			err = fmt.Errorf(`[%s] response detail should be '' but got '%s'`, op.name, reIPRsp["detail"])
 			allErrs = errors.Join(allErrs, err)
 			break
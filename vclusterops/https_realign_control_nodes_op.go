@@ -0,0 +1,124 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsRealignControlNodesOp struct {
+	opBase
+	opHTTPSBase
+	controlSetSize int
+	// node name -> name of the control node of that node, after realignment
+	controlNodes map[string]string
+}
+
+// makeHTTPSRealignControlNodesOp will make an op that distributes the control
+// nodes of a large cluster among the nodes. The database spreads the control
+// nodes across the fault groups, if any, so that losing a fault group does
+// not lose too many control nodes. If controlSetSize is not
+// util.DefaultControlSetSize, the number of control nodes is changed first.
+// The new assignment takes effect after spread is reloaded.
+func makeHTTPSRealignControlNodesOp(hosts []string, useHTTPPassword bool,
+	userName string, httpsPassword *string, controlSetSize int) (httpsRealignControlNodesOp, error) {
+	op := httpsRealignControlNodesOp{}
+	op.name = "HTTPSRealignControlNodesOp"
+	op.description = "Distribute control nodes"
+	op.responseSchema = responseSchema{{path: "control_nodes", typ: jsonObject}}
+	op.hosts = hosts
+	op.controlSetSize = controlSetSize
+	err := op.validateAndSetUsernameAndPassword(op.name, useHTTPPassword, userName, httpsPassword)
+
+	return op, err
+}
+
+func (op *httpsRealignControlNodesOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildHTTPSEndpoint("cluster/control-nodes/realign")
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		if op.controlSetSize != util.DefaultControlSetSize {
+			httpRequest.QueryParams = map[string]string{"control-set-size": strconv.Itoa(op.controlSetSize)}
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsRealignControlNodesOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsRealignControlNodesOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+type realignControlNodesRsp struct {
+	ControlNodes map[string]string `json:"control_nodes"`
+}
+
+func (op *httpsRealignControlNodesOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// the response object will be a dictionary, an example:
+		// {"control_nodes": {"v_test_db_node0001": "v_test_db_node0001",
+		//                    "v_test_db_node0002": "v_test_db_node0001"}}
+		resp := realignControlNodesRsp{}
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w",
+				op.name, host, err))
+			continue
+		}
+		op.controlNodes = resp.ControlNodes
+		op.logger.Info("realigned control nodes", "controlNodes", op.controlNodes)
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
+
+func (op *httpsRealignControlNodesOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
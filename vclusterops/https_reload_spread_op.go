@@ -25,6 +25,9 @@ import (
 type httpsReloadSpreadOp struct {
 	opBase
 	opHTTPSBase
+	// number of hosts on which the reload can fail without failing the op
+	maxHostFailures int
+	summary         HostResultSummary
 }
 
 func makeHTTPSReloadSpreadOpWithInitiator(initHosts []string,
@@ -42,6 +45,7 @@ func makeHTTPSReloadSpreadOpWithInitiator(initHosts []string,
 	}
 	op.userName = userName
 	op.httpsPassword = httpsPassword
+	op.summary = makeHostResultSummary()
 	return op, nil
 }
 
@@ -91,6 +95,7 @@ func (op *httpsReloadSpreadOp) processResult(_ *opEngineExecContext) error {
 
 		if !result.isPassing() {
 			allErrs = errors.Join(allErrs, result.err)
+			op.summary.recordFailure(host, result.err)
 			continue
 		}
 
@@ -101,6 +106,7 @@ func (op *httpsReloadSpreadOp) processResult(_ *opEngineExecContext) error {
 		if err != nil {
 			err = fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err)
 			allErrs = errors.Join(allErrs, err)
+			op.summary.recordFailure(host, err)
 			continue
 		}
 
@@ -108,10 +114,13 @@ func (op *httpsReloadSpreadOp) processResult(_ *opEngineExecContext) error {
 		if reloadSpreadRsp["detail"] != "Reloaded" {
 			err = fmt.Errorf(`[%s] response detail should be 'Reloaded' but got '%s'`, op.name, reloadSpreadRsp["detail"])
 			allErrs = errors.Join(allErrs, err)
+			op.summary.recordFailure(host, err)
+			continue
 		}
+		op.summary.recordSuccess(host)
 	}
 
-	return allErrs
+	return op.summary.checkHostFailures(op.name, op.maxHostFailures, allErrs, op.logger)
 }
 
 func (op *httpsReloadSpreadOp) finalize(_ *opEngineExecContext) error {
@@ -0,0 +1,134 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+// the endpoints of the routing objects
+const (
+	loadBalanceGroupEndpoint = "load-balance-groups/"
+	routingRuleEndpoint      = "routing-rules/"
+)
+
+type httpsRoutingOp struct {
+	opBase
+	opHTTPSBase
+	action      RoutingAction
+	endpoint    string
+	queryParams map[string]string
+}
+
+// makeHTTPSRoutingOp will make an op that creates, alters or drops a
+// connection load balance group or a routing rule. The endpoint is the one of
+// the object to change, e.g., "load-balance-groups/group1", and the query
+// parameters hold its new settings.
+func makeHTTPSRoutingOp(hosts []string, useHTTPPassword bool, userName string, httpsPassword *string,
+	action RoutingAction, endpoint string, queryParams map[string]string) (httpsRoutingOp, error) {
+	op := httpsRoutingOp{}
+	op.name = "HTTPSRoutingOp"
+	op.description = fmt.Sprintf("Routing %s of %s", action, endpoint)
+	op.hosts = hosts
+	op.action = action
+	op.endpoint = endpoint
+	op.queryParams = queryParams
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsRoutingOp) getMethod() string {
+	switch op.action {
+	case RoutingAlter:
+		return PutMethod
+	case RoutingDrop:
+		return DeleteMethod
+	}
+	return PostMethod
+}
+
+func (op *httpsRoutingOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = op.getMethod()
+		httpRequest.buildHTTPSEndpoint(op.endpoint)
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		if len(op.queryParams) > 0 {
+			httpRequest.QueryParams = op.queryParams
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsRoutingOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsRoutingOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsRoutingOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsRoutingOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "CREATE LOAD BALANCE GROUP"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
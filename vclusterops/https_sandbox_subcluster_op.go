@@ -16,6 +16,7 @@
 package vclusterops
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -29,6 +30,16 @@ type httpsSandboxingOp struct {
 	hostRequestBodyMap map[string]string
 	scName             string
 	sandboxName        string
+	// optional, the configuration parameters to set in the sandbox only
+	configParamOverrides map[string]string
+	// optional, the path of the separate depot of the sandbox. If empty,
+	// the sandbox re-uses the depot locations of the subcluster.
+	depotPath string
+}
+
+type sandboxRequestData struct {
+	ConfigParameters map[string]string `json:"config_parameters,omitempty"`
+	DepotPath        string            `json:"depot_path,omitempty"`
 }
 
 // This op is used to sandbox the given subcluster `scName` as `sandboxName`
@@ -65,6 +76,11 @@ func (op *httpsSandboxingOp) setupClusterHTTPRequest(hosts []string) error {
 			httpRequest.Username = op.userName
 		}
 		httpRequest.QueryParams = op.hostRequestBodyMap
+		requestData, err := op.setupRequestData()
+		if err != nil {
+			return err
+		}
+		httpRequest.RequestData = requestData
 		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
 	}
 
@@ -78,6 +94,23 @@ func (op *httpsSandboxingOp) setupRequestBody() error {
 	return nil
 }
 
+// setupRequestData returns the body of the sandbox request, which is only
+// sent when the sandbox has configuration parameter overrides or its own depot
+func (op *httpsSandboxingOp) setupRequestData() (string, error) {
+	if len(op.configParamOverrides) == 0 && op.depotPath == "" {
+		return "", nil
+	}
+	data := sandboxRequestData{
+		ConfigParameters: op.configParamOverrides,
+		DepotPath:        op.depotPath,
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	return string(dataBytes), nil
+}
+
 func (op *httpsSandboxingOp) prepare(execContext *opEngineExecContext) error {
 	if len(execContext.upHostsToSandboxes) == 0 {
 		return fmt.Errorf(`[%s] Cannot find any up hosts in OpEngineExecContext`, op.name)
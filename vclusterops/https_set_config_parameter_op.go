@@ -0,0 +1,129 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsSetConfigParameterOp struct {
+	opBase
+	opHTTPSBase
+	parameter string
+	value     string
+	clear     bool
+	level     ConfigParameterLevel
+	levelName string
+}
+
+// makeHTTPSSetConfigParameterOp will create an op that sets the value of a
+// configuration parameter at a level, or clears it so the value of the higher
+// level or the default value applies again
+func makeHTTPSSetConfigParameterOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, parameter, value string, clear bool, level ConfigParameterLevel,
+	levelName string) (httpsSetConfigParameterOp, error) {
+	op := httpsSetConfigParameterOp{}
+	op.name = "HTTPSSetConfigParameterOp"
+	op.description = "Set configuration parameter"
+	if clear {
+		op.description = "Clear configuration parameter"
+	}
+	op.hosts = hosts
+	op.parameter = parameter
+	op.value = value
+	op.clear = clear
+	op.level = level
+	op.levelName = levelName
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsSetConfigParameterOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PutMethod
+		if op.clear {
+			httpRequest.Method = DeleteMethod
+		}
+		httpRequest.buildHTTPSEndpoint(configParameterEndpoint + op.parameter)
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = getConfigParameterLevelParams(op.level, op.levelName)
+		if !op.clear {
+			httpRequest.QueryParams["value"] = op.value
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsSetConfigParameterOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsSetConfigParameterOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsSetConfigParameterOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsSetConfigParameterOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// The successful response object will be a dictionary:
+		// {"detail": "ALTER DATABASE"}
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return nil
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
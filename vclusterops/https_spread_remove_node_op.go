@@ -43,9 +43,9 @@ func makeHTTPSSpreadRemoveNodeOp(hostsToRemove []string, initiatorHost []string,
 	op.userName = userName
 	op.httpsPassword = httpsPassword
 	op.RequestParams = make(map[string]string)
-	var nodeNames []string
-	for _, host := range hostsToRemove {
-		nodeNames = append(nodeNames, hostNodeMap[host].Name)
+	nodeNames, err := hostNodeMap.getNodeNames(hostsToRemove)
+	if err != nil {
+		return op, fmt.Errorf("[%s] %w", op.name, err)
 	}
 	op.RequestParams["remove-target"] = util.ArrayToString(nodeNames, ",")
 	return op, nil
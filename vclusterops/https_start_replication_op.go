@@ -34,12 +34,13 @@ type httpsStartReplicationOp struct {
 	targetUserName     string
 	targetPassword     *string
 	tlsConfig          string
+	namespace          string
 }
 
 func makeHTTPSStartReplicationOp(dbName string, sourceHosts []string,
 	sourceUseHTTPPassword bool, sourceUserName string,
 	sourceHTTPPassword *string, targetUseHTTPPassword bool, targetDB, targetUserName, targetHosts string,
-	targetHTTPSPassword *string, tlsConfig, sandbox string) (httpsStartReplicationOp, error) {
+	targetHTTPSPassword *string, tlsConfig, sandbox, namespace string) (httpsStartReplicationOp, error) {
 	op := httpsStartReplicationOp{}
 	op.name = "HTTPSStartReplicationOp"
 	op.description = "Start database replication"
@@ -50,6 +51,7 @@ func makeHTTPSStartReplicationOp(dbName string, sourceHosts []string,
 	op.targetHosts = targetHosts
 	op.tlsConfig = tlsConfig
 	op.sandbox = sandbox
+	op.namespace = namespace
 
 	if sourceUseHTTPPassword {
 		err := util.ValidateUsernameAndPassword(op.name, sourceUseHTTPPassword, sourceUserName)
@@ -77,6 +79,7 @@ type replicateRequestData struct {
 	TargetUserName string  `json:"user,omitempty"`
 	TargetPassword *string `json:"password,omitempty"`
 	TLSConfig      string  `json:"tls_config,omitempty"`
+	Namespace      string  `json:"namespace,omitempty"`
 }
 
 func (op *httpsStartReplicationOp) setupRequestBody(hosts []string) error {
@@ -89,6 +92,7 @@ func (op *httpsStartReplicationOp) setupRequestBody(hosts []string) error {
 		replicateData.TargetUserName = op.targetUserName
 		replicateData.TargetPassword = op.targetPassword
 		replicateData.TLSConfig = op.tlsConfig
+		replicateData.Namespace = op.namespace
 
 		dataBytes, err := json.Marshal(replicateData)
 		if err != nil {
@@ -0,0 +1,138 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type httpsStorageLocationOp struct {
+	opBase
+	opHTTPSBase
+	action       StorageLocationAction
+	locationPath string
+	usage        string
+	hostNodeMap  vHostNodeMap
+}
+
+// makeHTTPSStorageLocationOp will make an op that calls the vertica-http service
+// to add, retire, or alter the usage of a storage location on the nodes of the
+// given hosts. Each host is asked to act on its own node.
+func makeHTTPSStorageLocationOp(hosts []string, hostNodeMap vHostNodeMap,
+	action StorageLocationAction, locationPath, usage string,
+	useHTTPPassword bool, userName string, httpsPassword *string) (httpsStorageLocationOp, error) {
+	op := httpsStorageLocationOp{}
+	op.name = "HTTPSStorageLocationOp"
+	op.description = fmt.Sprintf("Storage location %s", action)
+	op.hosts = hosts
+	op.hostNodeMap = hostNodeMap
+	op.action = action
+	op.locationPath = locationPath
+	op.usage = usage
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsStorageLocationOp) getEndpoint() string {
+	switch op.action {
+	case StorageLocationRetire:
+		return "storage-locations/retire"
+	case StorageLocationAlterUsage:
+		return "storage-locations/usage"
+	}
+	return "storage-locations"
+}
+
+func (op *httpsStorageLocationOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		node, ok := op.hostNodeMap[host]
+		if !ok {
+			return fmt.Errorf("[%s] cannot find the node of host %s", op.name, host)
+		}
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildHTTPSEndpoint(op.getEndpoint())
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		httpRequest.QueryParams = map[string]string{"path": op.locationPath, "node": node.Name}
+		if op.usage != "" {
+			httpRequest.QueryParams["usage"] = op.usage
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsStorageLocationOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsStorageLocationOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsStorageLocationOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			// not break here because we want to log all the failed nodes
+			continue
+		}
+
+		/* decode the json-format response
+		The successful response object will be a dictionary like below:
+		{
+			"detail": ""
+		}
+		*/
+		_, err := op.parseAndCheckMapResponse(host, result.content)
+		if err != nil {
+			err = fmt.Errorf(`[%s] fail to parse result on host %s, details: %w`, op.name, host, err)
+			allErrs = errors.Join(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+func (op *httpsStorageLocationOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
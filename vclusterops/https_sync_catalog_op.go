@@ -33,12 +33,15 @@ const (
 	AddNodeSyncCat
 	StartNodeSyncCat
 	RemoveNodeSyncCat
+	ManualSyncCat
 )
 
 type httpsSyncCatalogOp struct {
 	opBase
 	opHTTPSBase
 	cmdType SyncCatCmdType
+	// the catalog truncation version after the sync
+	truncationVersion int64
 }
 
 func makeHTTPSSyncCatalogOp(hosts []string, useHTTPPassword bool,
@@ -46,6 +49,7 @@ func makeHTTPSSyncCatalogOp(hosts []string, useHTTPPassword bool,
 	op := httpsSyncCatalogOp{}
 	op.name = "HTTPSSyncCatalogOp"
 	op.description = "Synchronize catalog with communal storage"
+	op.responseSchema = responseSchema{{path: "new_truncation_version", typ: jsonString}}
 	op.hosts = hosts
 	op.cmdType = cmdType
 	op.useHTTPPassword = useHTTPPassword
@@ -128,13 +132,12 @@ func (op *httpsSyncCatalogOp) processResult(_ *opEngineExecContext) error {
 				allErrs = errors.Join(allErrs, err)
 				continue
 			}
-			version, ok := syncCatalogRsp["new_truncation_version"]
-			if !ok {
-				err = fmt.Errorf(`[%s] response does not contain field "new_truncation_version"`, op.name)
-				allErrs = errors.Join(allErrs, err)
-				continue
+			op.logger.PrintInfo(`[%s] the_latest_truncation_catalog_version: %s"`, op.name,
+				syncCatalogRsp["new_truncation_version"])
+			op.truncationVersion, err = parseTruncationVersion(syncCatalogRsp["new_truncation_version"])
+			if err != nil {
+				return fmt.Errorf("[%s] %w", op.name, err)
 			}
-			op.logger.PrintInfo(`[%s] the_latest_truncation_catalog_version: %s"`, op.name, version)
 
 			// good response from one node is enough for us
 			return nil
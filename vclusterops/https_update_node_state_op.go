@@ -88,7 +88,7 @@ func (op *httpsUpdateNodeStateOp) processResult(execContext *opEngineExecContext
 		op.logResponse(host, result)
 
 		if result.isUnauthorizedRequest() {
-			op.logger.PrintError("[%s] unauthorized request: %s", op.name, result.content)
+			op.logger.PrintError("[%s] unauthorized request: %s", op.name, result.getErrorDetail())
 			execContext.hostsWithWrongAuth = append(execContext.hostsWithWrongAuth, host)
 			// return here because we assume that
 			// we will get the same error across other nodes
@@ -124,6 +124,7 @@ func (op *httpsUpdateNodeStateOp) processResult(execContext *opEngineExecContext
 				return fmt.Errorf("cannot find host %s in vdb", host)
 			}
 			vnode.State = nodeInfo.State
+			vnode.CatalogVersion = nodeInfo.CatalogVersion
 		} else {
 			// if the result format is wrong on any of the hosts, we should throw an error
 			return fmt.Errorf("[%s] expect one node's information, but got %d nodes' information"+
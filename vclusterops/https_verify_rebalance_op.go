@@ -0,0 +1,158 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+// the state of a subscription that is being dropped by a rebalance
+const subscriptionRemovingState = "REMOVING"
+
+type httpsVerifyRebalanceOp struct {
+	opBase
+	opHTTPSBase
+	isEon         bool
+	nodesToRemove []string
+}
+
+// makeHTTPSVerifyRebalanceOp will create an op that checks the data of the nodes
+// to remove has been moved to other nodes. In Eon mode, the nodes to remove must
+// not hold any shard subscription other than the ones being removed. In Enterprise
+// mode, the cluster rebalance must be complete.
+func makeHTTPSVerifyRebalanceOp(hosts []string, useHTTPPassword bool, userName string,
+	httpsPassword *string, isEon bool, nodesToRemove []string) (httpsVerifyRebalanceOp, error) {
+	op := httpsVerifyRebalanceOp{}
+	op.name = "HTTPSVerifyRebalanceOp"
+	op.description = "Verify data was rebalanced away from the nodes to remove"
+	op.hosts = hosts
+	op.isEon = isEon
+	op.nodesToRemove = nodesToRemove
+	op.useHTTPPassword = useHTTPPassword
+
+	err := util.ValidateUsernameAndPassword(op.name, useHTTPPassword, userName)
+	if err != nil {
+		return op, err
+	}
+	op.userName = userName
+	op.httpsPassword = httpsPassword
+	return op, nil
+}
+
+func (op *httpsVerifyRebalanceOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		if op.isEon {
+			httpRequest.buildHTTPSEndpoint("subscriptions")
+		} else {
+			httpRequest.buildHTTPSEndpoint("cluster/rebalance/progress")
+		}
+		if op.useHTTPPassword {
+			httpRequest.Password = op.httpsPassword
+			httpRequest.Username = op.userName
+		}
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *httpsVerifyRebalanceOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *httpsVerifyRebalanceOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *httpsVerifyRebalanceOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *httpsVerifyRebalanceOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isUnauthorizedRequest() {
+			return makeWrongCredentialError(op.name, host)
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		if op.isEon {
+			subscriptions := subscriptionList{}
+			err := op.parseAndCheckResponse(host, result.content, &subscriptions)
+			if err != nil {
+				allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+				continue
+			}
+			return op.checkSubscriptions(subscriptions.SubscriptionList)
+		}
+
+		progress := RebalanceProgress{}
+		err := op.parseAndCheckResponse(host, result.content, &progress)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err))
+			continue
+		}
+		return op.checkRebalanceProgress(&progress)
+	}
+
+	return appendHTTPSFailureError(allErrs)
+}
+
+// checkSubscriptions returns an error if a node to remove still holds a shard
+// subscription that was not handed over to another node
+func (op *httpsVerifyRebalanceOp) checkSubscriptions(subscriptions []subscriptionInfo) error {
+	var remaining []string
+	for _, s := range subscriptions {
+		if !util.StringInArray(s.Nodename, op.nodesToRemove) || s.SubscriptionState == subscriptionRemovingState {
+			continue
+		}
+		remaining = append(remaining, fmt.Sprintf("%s:%s(%s)", s.Nodename, s.ShardName, s.SubscriptionState))
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+	sort.Strings(remaining)
+	return fmt.Errorf("[%s] shard subscriptions were not rebalanced away from the nodes to remove: %v",
+		op.name, remaining)
+}
+
+// checkRebalanceProgress returns an error if the cluster rebalance did not
+// move all the projections
+func (op *httpsVerifyRebalanceOp) checkRebalanceProgress(progress *RebalanceProgress) error {
+	if progress.RebalancedProjections < progress.TotalProjections {
+		return fmt.Errorf("[%s] data rebalance is not complete, %d of %d projections rebalanced (%.1f%%)",
+			op.name, progress.RebalancedProjections, progress.TotalProjections, progress.PercentComplete)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRebalanceCheckSubscriptions(t *testing.T) {
+	op, err := makeHTTPSVerifyRebalanceOp([]string{"host1"}, false, "", nil, true,
+		[]string{"v_db_node0003"})
+	assert.NoError(t, err)
+
+	subscriptions := []subscriptionInfo{
+		{Nodename: "v_db_node0001", ShardName: "segment0001", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0002", ShardName: "segment0002", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0003", ShardName: "segment0001", SubscriptionState: subscriptionRemovingState},
+	}
+	assert.NoError(t, op.checkSubscriptions(subscriptions))
+
+	// the node to remove still holds a subscription
+	subscriptions = append(subscriptions,
+		subscriptionInfo{Nodename: "v_db_node0003", ShardName: "segment0002", SubscriptionState: "ACTIVE"})
+	err = op.checkSubscriptions(subscriptions)
+	assert.ErrorContains(t, err, "v_db_node0003:segment0002(ACTIVE)")
+}
+
+func TestVerifyRebalanceCheckProgress(t *testing.T) {
+	op, err := makeHTTPSVerifyRebalanceOp([]string{"host1"}, false, "", nil, false,
+		[]string{"v_db_node0003"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, op.checkRebalanceProgress(&RebalanceProgress{PercentComplete: 100,
+		RebalancedProjections: 40, TotalProjections: 40}))
+
+	err = op.checkRebalanceProgress(&RebalanceProgress{PercentComplete: 42.5,
+		RebalancedProjections: 17, TotalProjections: 40})
+	assert.ErrorContains(t, err, "17 of 40 projections rebalanced")
+}
@@ -17,6 +17,8 @@ package vclusterops
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -28,6 +30,12 @@ type VInstallPackagesOptions struct {
 
 	// If true, the packages will be reinstalled even if they are already installed.
 	ForceReinstall bool
+	// Names of the packages to install. If empty, all of the default packages
+	// are installed.
+	PackageNames []string
+	// Number of up hosts on which the install can fail before another up
+	// host installs the packages, without failing the operation
+	MaxHostFailures int
 }
 
 func VInstallPackagesOptionsFactory() VInstallPackagesOptions {
@@ -42,6 +50,15 @@ func (options *VInstallPackagesOptions) validateParseOptions(logger vlog.Printer
 		return err
 	}
 
+	for _, name := range options.PackageNames {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("package names cannot be empty")
+		}
+	}
+	if options.MaxHostFailures < 0 {
+		return fmt.Errorf("the number of tolerated host failures cannot be negative")
+	}
+
 	return nil
 }
 
@@ -66,7 +83,8 @@ func (options *VInstallPackagesOptions) validateAnalyzeOptions(logger vlog.Print
 	return options.analyzeOptions()
 }
 
-func (vcc VClusterCommands) VInstallPackages(options *VInstallPackagesOptions) (*InstallPackageStatus, error) {
+func (vcc VClusterCommands) VInstallPackages(options *VInstallPackagesOptions) (_ *InstallPackageStatus, err error) {
+	defer vcc.audit(commandInstallPackages, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -74,7 +92,7 @@ func (vcc VClusterCommands) VInstallPackages(options *VInstallPackagesOptions) (
 	 */
 
 	// validate and analyze all options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +106,7 @@ func (vcc VClusterCommands) VInstallPackages(options *VInstallPackagesOptions) (
 
 	// Create a VClusterOpEngine. No need for certs since this operation doesn't
 	// talk to the NMA.
-	clusterOpEngine := makeClusterOpEngine(instructions, &httpsCerts{})
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &httpsCerts{})
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -99,6 +117,19 @@ func (vcc VClusterCommands) VInstallPackages(options *VInstallPackagesOptions) (
 		return nil, fmt.Errorf("did not flow back the install package status")
 	}
 
+	// make sure that we got a status for each of the requested packages
+	if len(options.PackageNames) > 0 {
+		var missingPackages []string
+		for _, name := range options.PackageNames {
+			if status.getPackageStatus(name) == nil {
+				missingPackages = append(missingPackages, name)
+			}
+		}
+		if len(missingPackages) > 0 {
+			return status, fmt.Errorf("packages %v are not found among the default packages", missingPackages)
+		}
+	}
+
 	return status, nil
 }
 
@@ -132,6 +163,8 @@ func (vcc *VClusterCommands) produceInstallPackagesInstructions(opts *VInstallPa
 	if err != nil {
 		return nil, nil, err
 	}
+	installOp.packageNames = opts.PackageNames
+	installOp.maxHostFailures = opts.MaxHostFailures
 
 	instructions := []clusterOp{
 		&httpsGetUpNodesOp,
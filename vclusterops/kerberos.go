@@ -0,0 +1,86 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+// SPNEGOTokenGenerator returns the initial GSS-API token (RFC 4559) that
+// authenticates the client to the Kerberos service principal, using the
+// credentials in the keytab. vclusterops does not link a Kerberos library,
+// so the caller provides the generator, e.g., one built on gokrb5.
+type SPNEGOTokenGenerator func(keytab, servicePrincipal string) ([]byte, error)
+
+const negotiateAuthScheme = "Negotiate"
+
+// kerberosAuth holds what is needed to authenticate to the
+// HTTPS endpoints with Kerberos through SPNEGO
+type kerberosAuth struct {
+	serviceName   string
+	keytab        string
+	generateToken SPNEGOTokenGenerator
+}
+
+// servicePrincipal returns the name of the service principal of the HTTPS
+// service on the host, e.g., vertica/10.20.30.40. The realm is left to the
+// token generator.
+func (auth *kerberosAuth) servicePrincipal(host string) string {
+	return auth.serviceName + "/" + host
+}
+
+// setNegotiateHeader sets the Authorization header of a request to the host
+// with a new SPNEGO token
+func (auth *kerberosAuth) setNegotiateHeader(req *http.Request, host string) error {
+	principal := auth.servicePrincipal(host)
+	token, err := auth.generateToken(auth.keytab, principal)
+	if err != nil {
+		return fmt.Errorf("fail to generate SPNEGO token for service principal %s: %w", principal, err)
+	}
+	if len(token) == 0 {
+		return fmt.Errorf("empty SPNEGO token generated for service principal %s", principal)
+	}
+	req.Header.Set("Authorization", negotiateAuthScheme+" "+base64.StdEncoding.EncodeToString(token))
+	return nil
+}
+
+// useKerberos returns true if Kerberos authentication is configured
+func (opt *DatabaseOptions) useKerberos() bool {
+	return opt.KerberosServiceName != ""
+}
+
+// validateKerberos checks the Kerberos configuration, if any
+func (opt *DatabaseOptions) validateKerberos() error {
+	if !opt.useKerberos() {
+		if opt.KerberosKeytab != "" {
+			return errors.New("a Kerberos keytab is given without a Kerberos service name")
+		}
+		return nil
+	}
+	err := util.ValidateAbsPath(opt.KerberosKeytab, "Kerberos keytab")
+	if err != nil {
+		return err
+	}
+	if opt.KerberosTokenGenerator == nil {
+		return errors.New("must provide a SPNEGO token generator for Kerberos authentication")
+	}
+	return nil
+}
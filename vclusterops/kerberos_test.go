@@ -0,0 +1,85 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateKerberos(t *testing.T) {
+	opt := DatabaseOptions{}
+	assert.NoError(t, opt.validateKerberos())
+
+	opt.KerberosKeytab = "/etc/krb5.keytab"
+	assert.ErrorContains(t, opt.validateKerberos(), "without a Kerberos service name")
+
+	opt.KerberosServiceName = "vertica"
+	assert.ErrorContains(t, opt.validateKerberos(), "must provide a SPNEGO token generator")
+
+	opt.KerberosTokenGenerator = func(_, _ string) ([]byte, error) { return []byte("token"), nil }
+	assert.NoError(t, opt.validateKerberos())
+
+	opt.KerberosKeytab = "krb5.keytab"
+	assert.Error(t, opt.validateKerberos())
+}
+
+func TestSetNegotiateHeader(t *testing.T) {
+	var gotKeytab, gotPrincipal string
+	auth := kerberosAuth{
+		serviceName: "vertica",
+		keytab:      "/etc/krb5.keytab",
+		generateToken: func(keytab, principal string) ([]byte, error) {
+			gotKeytab, gotPrincipal = keytab, principal
+			return []byte("token"), nil
+		},
+	}
+	req, err := http.NewRequest(GetMethod, "https://vnode1:8443/v1/nodes", http.NoBody)
+	assert.NoError(t, err)
+	assert.NoError(t, auth.setNegotiateHeader(req, "vnode1"))
+	assert.Equal(t, "/etc/krb5.keytab", gotKeytab)
+	assert.Equal(t, "vertica/vnode1", gotPrincipal)
+	assert.Equal(t, "Negotiate "+base64.StdEncoding.EncodeToString([]byte("token")), req.Header.Get("Authorization"))
+
+	auth.generateToken = func(_, _ string) ([]byte, error) { return nil, errors.New("no ticket") }
+	assert.ErrorContains(t, auth.setNegotiateHeader(req, "vnode1"), "no ticket")
+}
+
+func TestLoadKerberosIfNeeded(t *testing.T) {
+	opt := DatabaseOptions{KerberosServiceName: "vertica", KerberosKeytab: "/etc/krb5.keytab"}
+	certs := opt.buildHTTPSCerts()
+	assert.NotNil(t, certs.kerberos)
+
+	op := opBase{}
+	op.clusterHTTPRequest.RequestCollection = map[string]hostHTTPRequest{
+		"vnode1": {IsNMACommand: false},
+		"vnode2": {IsNMACommand: true},
+	}
+	assert.NoError(t, op.loadCertsIfNeeded(&certs, true))
+	https := op.clusterHTTPRequest.RequestCollection["vnode1"]
+	nma := op.clusterHTTPRequest.RequestCollection["vnode2"]
+	assert.Equal(t, certs.kerberos, https.Kerberos)
+	assert.False(t, https.UseCertsInOptions)
+	assert.Nil(t, nma.Kerberos)
+
+	usePassword, err := whetherUsePassword(&https)
+	assert.NoError(t, err)
+	assert.False(t, usePassword)
+}
@@ -0,0 +1,204 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VKillVerticaOptions struct {
+	DatabaseOptions
+	// Hosts on which the vertica process will be killed
+	HostsToKill []string
+	// Kill the vertica processes even if a shard would be left without any up subscriber
+	Force bool
+}
+
+func VKillVerticaOptionsFactory() VKillVerticaOptions {
+	options := VKillVerticaOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VKillVerticaOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VKillVerticaOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandKillVertica, logger)
+	if err != nil {
+		return err
+	}
+	if len(options.HostsToKill) == 0 {
+		return fmt.Errorf("must specify at least one host on which to kill vertica")
+	}
+	return nil
+}
+
+func (options *VKillVerticaOptions) analyzeOptions() (err error) {
+	options.HostsToKill, err = util.ResolveRawHostsToAddresses(options.HostsToKill, options.IPv6)
+	if err != nil {
+		return err
+	}
+
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VKillVerticaOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VKillVertica sends SIGKILL to the vertica process of the given hosts through the NMA.
+// It is a last resort for when a graceful stop hangs. Unless options.Force is set,
+// it refuses to kill hosts that hold the only up copy of a shard.
+func (vcc VClusterCommands) VKillVertica(options *VKillVerticaOptions) (err error) {
+	defer vcc.audit(commandKillVertica, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Check that every shard keeps an up subscriber
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	if options.Force {
+		vcc.Log.PrintWarning("Skipping the shard coverage check, vertica will be killed on hosts %v", options.HostsToKill)
+	} else {
+		err = vcc.checkShardCoverageBeforeKill(options)
+		if err != nil {
+			return err
+		}
+	}
+
+	nmaHealthOp := makeNMAHealthOp(options.HostsToKill)
+	nmaKillVerticaOp := makeNMAKillVerticaOp(options.HostsToKill)
+	instructions := []clusterOp{&nmaHealthOp, &nmaKillVerticaOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to kill vertica on hosts %v: %w", options.HostsToKill, err)
+	}
+	return nil
+}
+
+// checkShardCoverageBeforeKill makes sure that each shard still has an up
+// subscriber once the vertica processes of the given hosts are killed
+func (vcc VClusterCommands) checkShardCoverageBeforeKill(options *VKillVerticaOptions) error {
+	vdb := makeVCoordinationDatabase()
+	err := vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the database topology before killing vertica, use force to skip this check: %w", err)
+	}
+	// shard subscriptions only exist in Eon mode
+	if !vdb.IsEon {
+		return nil
+	}
+
+	nodesToKill := make(map[string]struct{})
+	upNodes := make(map[string]struct{})
+	var upHosts []string
+	for host, vnode := range vdb.HostNodeMap {
+		if vnode.State == util.NodeUpState {
+			upNodes[vnode.Name] = struct{}{}
+			upHosts = append(upHosts, host)
+		}
+		if util.StringInArray(host, options.HostsToKill) {
+			nodesToKill[vnode.Name] = struct{}{}
+		}
+	}
+	if len(upHosts) == 0 {
+		return fmt.Errorf("no up host to check the shard coverage before killing vertica, " +
+			"use force to kill vertica anyway")
+	}
+
+	var subscriptions []subscriptionInfo
+	httpsGetSubscriptionsOp, err := makeHTTPSGetSubscriptionsOp(upHosts, options.usePassword,
+		options.UserName, options.Password, &subscriptions)
+	if err != nil {
+		return err
+	}
+	instructions := []clusterOp{&httpsGetSubscriptionsOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to get shard subscriptions before killing vertica, use force to skip this check: %w", err)
+	}
+
+	shards := findShardsWithoutUpCopy(subscriptions, upNodes, nodesToKill)
+	if len(shards) > 0 {
+		return fmt.Errorf("hosts %v hold the only up copy of shards %v, use force to kill vertica anyway",
+			options.HostsToKill, shards)
+	}
+	return nil
+}
+
+// findShardsWithoutUpCopy returns the sorted names of the shards that will not
+// have any active subscriber on an up node once nodesToKill are killed
+func findShardsWithoutUpCopy(subscriptions []subscriptionInfo,
+	upNodes, nodesToKill map[string]struct{}) []string {
+	shardToUpCopies := make(map[string]int)
+	for _, s := range subscriptions {
+		if _, ok := shardToUpCopies[s.ShardName]; !ok {
+			shardToUpCopies[s.ShardName] = 0
+		}
+		if s.SubscriptionState != "ACTIVE" {
+			continue
+		}
+		if _, ok := upNodes[s.Nodename]; !ok {
+			continue
+		}
+		if _, ok := nodesToKill[s.Nodename]; ok {
+			continue
+		}
+		shardToUpCopies[s.ShardName]++
+	}
+
+	var shards []string
+	for shard, count := range shardToUpCopies {
+		if count == 0 {
+			shards = append(shards, shard)
+		}
+	}
+	sort.Strings(shards)
+	return shards
+}
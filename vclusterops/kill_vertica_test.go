@@ -0,0 +1,52 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindShardsWithoutUpCopy(t *testing.T) {
+	subscriptions := []subscriptionInfo{
+		{Nodename: "v_db_node0001", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0002", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0003", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0001", ShardName: "segment0001", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0002", ShardName: "segment0001", SubscriptionState: "PENDING"},
+		{Nodename: "v_db_node0002", ShardName: "segment0002", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0003", ShardName: "segment0002", SubscriptionState: "ACTIVE"},
+	}
+	upNodes := map[string]struct{}{
+		"v_db_node0001": {},
+		"v_db_node0002": {},
+		"v_db_node0003": {},
+	}
+
+	// killing node0002 leaves an active copy of every shard
+	shards := findShardsWithoutUpCopy(subscriptions, upNodes, map[string]struct{}{"v_db_node0002": {}})
+	assert.Empty(t, shards)
+
+	// node0001 holds the only active copy of segment0001
+	shards = findShardsWithoutUpCopy(subscriptions, upNodes, map[string]struct{}{"v_db_node0001": {}})
+	assert.Equal(t, []string{"segment0001"}, shards)
+
+	// a subscriber on a down node does not count as an up copy
+	delete(upNodes, "v_db_node0003")
+	shards = findShardsWithoutUpCopy(subscriptions, upNodes, map[string]struct{}{"v_db_node0002": {}})
+	assert.Equal(t, []string{"segment0002"}, shards)
+}
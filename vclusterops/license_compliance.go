@@ -0,0 +1,218 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the names of the license compliance checks
+const (
+	checkLicenseNodeCount  = "license_node_count"
+	checkLicenseSize       = "license_size"
+	checkLicenseExpiration = "license_expiration"
+)
+
+const (
+	perpetualLicense     = "Perpetual"
+	licenseDateLayout    = "2006-01-02"
+	unlimitedLicense     = "unlimited"
+	licenseExpiryWarning = 30 * 24 * time.Hour
+	// a warning is reported when the audited size reaches this percentage
+	// of the size limit of the license
+	licenseSizeWarningPercent = 90
+)
+
+type VCheckLicenseComplianceOptions struct {
+	DatabaseOptions
+}
+
+// LicenseComplianceReport is the result of VCheckLicenseCompliance. The
+// checks have the same statuses as the prerequisite checks.
+type LicenseComplianceReport struct {
+	LicenseType      string              `json:"license_type"`
+	ExpirationDate   string              `json:"expiration_date"`
+	AuditedSizeBytes int64               `json:"audited_size_bytes"`
+	AuditTime        string              `json:"audit_time"`
+	NodeCount        int                 `json:"node_count"`
+	Checks           []PrerequisiteCheck `json:"checks"`
+	// whether no check failed. The checks with a warning, e.g., a license
+	// that expires soon, do not make the database out of compliance.
+	Compliant bool `json:"compliant"`
+}
+
+func VCheckLicenseComplianceOptionsFactory() VCheckLicenseComplianceOptions {
+	options := VCheckLicenseComplianceOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VCheckLicenseComplianceOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VCheckLicenseComplianceOptions) validateParseOptions(logger vlog.Printer) error {
+	return options.validateBaseOptions(commandCheckLicenseCompliance, logger)
+}
+
+func (options *VCheckLicenseComplianceOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VCheckLicenseComplianceOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VCheckLicenseCompliance gets the license of a running database and the
+// result of its last audit, and checks that the database is within the node
+// and size limits of the license and that the license has not expired. A
+// license out of compliance is reported, not returned as an error.
+func (vcc VClusterCommands) VCheckLicenseCompliance(options *VCheckLicenseComplianceOptions) (_ LicenseComplianceReport, err error) {
+	defer vcc.audit(commandCheckLicenseCompliance, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Get the license from an up node
+	 *   - Evaluate the license against the database
+	 */
+
+	var report LicenseComplianceReport
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return report, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return report, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return report, err
+	}
+
+	var license licenseInfo
+	httpsGetLicenseOp, err := makeHTTPSGetLicenseOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, &license)
+	if err != nil {
+		return report, err
+	}
+	instructions := []clusterOp{&httpsGetLicenseOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return report, fmt.Errorf("fail to get the license of database %s: %w", options.DBName, err)
+	}
+
+	return evaluateLicenseCompliance(&license, len(vdb.HostNodeMap), time.Now())
+}
+
+// evaluateLicenseCompliance compares the limits of the license with the
+// nodes and the audited size of the database at the given time
+func evaluateLicenseCompliance(license *licenseInfo, nodeCount int, now time.Time) (LicenseComplianceReport, error) {
+	report := LicenseComplianceReport{
+		LicenseType:      license.LicenseType,
+		ExpirationDate:   license.ExpirationDate,
+		AuditedSizeBytes: license.AuditedSizeBytes,
+		AuditTime:        license.AuditTime,
+		NodeCount:        nodeCount,
+	}
+
+	nodeStatus := PrerequisitePassed
+	nodeLimit := unlimitedLicense
+	if license.NodeLimit > 0 {
+		nodeLimit = fmt.Sprintf("<= %d", license.NodeLimit)
+		if nodeCount > license.NodeLimit {
+			nodeStatus = PrerequisiteFailed
+		}
+	}
+	report.addCheck(checkLicenseNodeCount, nodeStatus, fmt.Sprint(nodeCount), nodeLimit)
+
+	sizeStatus := PrerequisitePassed
+	sizeLimit := unlimitedLicense
+	if license.SizeLimitBytes > 0 {
+		sizeLimit = fmt.Sprintf("<= %.1fGiB", float64(license.SizeLimitBytes)/bytesPerGiB)
+		switch {
+		case license.AuditedSizeBytes > license.SizeLimitBytes:
+			sizeStatus = PrerequisiteFailed
+		case license.AuditedSizeBytes*100 >= license.SizeLimitBytes*licenseSizeWarningPercent:
+			sizeStatus = PrerequisiteWarning
+		}
+	}
+	report.addCheck(checkLicenseSize, sizeStatus,
+		fmt.Sprintf("%.1fGiB", float64(license.AuditedSizeBytes)/bytesPerGiB), sizeLimit)
+
+	expirationStatus := PrerequisitePassed
+	if license.ExpirationDate != perpetualLicense {
+		expiration, err := time.Parse(licenseDateLayout, license.ExpirationDate)
+		if err != nil {
+			return report, fmt.Errorf("invalid expiration date %q of the license: %w", license.ExpirationDate, err)
+		}
+		// the license is valid until the end of its expiration date
+		expiration = expiration.AddDate(0, 0, 1)
+		switch {
+		case !now.Before(expiration):
+			expirationStatus = PrerequisiteFailed
+		case expiration.Sub(now) <= licenseExpiryWarning:
+			expirationStatus = PrerequisiteWarning
+		}
+	}
+	report.addCheck(checkLicenseExpiration, expirationStatus, license.ExpirationDate,
+		fmt.Sprintf("after %s", now.Format(licenseDateLayout)))
+
+	report.Compliant = len(report.failedChecks()) == 0
+	return report, nil
+}
+
+func (report *LicenseComplianceReport) addCheck(name, status, actual, expected string) {
+	report.Checks = append(report.Checks, PrerequisiteCheck{
+		Name:     name,
+		Status:   status,
+		Actual:   actual,
+		Expected: expected,
+	})
+}
+
+// failedChecks returns the names of the failed checks of the report
+func (report *LicenseComplianceReport) failedChecks() []string {
+	var names []string
+	for _, check := range report.Checks {
+		if check.Status == PrerequisiteFailed {
+			names = append(names, check.Name)
+		}
+	}
+	return names
+}
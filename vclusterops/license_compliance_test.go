@@ -0,0 +1,103 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestEvaluateLicenseCompliance(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	license := licenseInfo{LicenseType: "Premium Edition", ExpirationDate: perpetualLicense,
+		SizeLimitBytes: 100 * bytesPerGiB, NodeLimit: 3, AuditedSizeBytes: 50 * bytesPerGiB}
+	statuses := func(report *LicenseComplianceReport) map[string]string {
+		result := make(map[string]string)
+		for _, check := range report.Checks {
+			result[check.Name] = check.Status
+		}
+		return result
+	}
+
+	report, err := evaluateLicenseCompliance(&license, 3, now)
+	assert.NoError(t, err)
+	assert.True(t, report.Compliant)
+	assert.Equal(t, map[string]string{
+		checkLicenseNodeCount:  PrerequisitePassed,
+		checkLicenseSize:       PrerequisitePassed,
+		checkLicenseExpiration: PrerequisitePassed,
+	}, statuses(&report))
+
+	// too many nodes, close to the size limit, and about to expire
+	license.AuditedSizeBytes = 95 * bytesPerGiB
+	license.ExpirationDate = "2024-05-20"
+	report, err = evaluateLicenseCompliance(&license, 4, now)
+	assert.NoError(t, err)
+	assert.False(t, report.Compliant)
+	assert.Equal(t, []string{checkLicenseNodeCount}, report.failedChecks())
+	assert.Equal(t, map[string]string{
+		checkLicenseNodeCount:  PrerequisiteFailed,
+		checkLicenseSize:       PrerequisiteWarning,
+		checkLicenseExpiration: PrerequisiteWarning,
+	}, statuses(&report))
+
+	// over the size limit and expired. A license without node limit
+	// accepts any number of nodes
+	license.NodeLimit = 0
+	license.AuditedSizeBytes = 101 * bytesPerGiB
+	license.ExpirationDate = "2024-04-30"
+	report, err = evaluateLicenseCompliance(&license, 4, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{checkLicenseSize, checkLicenseExpiration}, report.failedChecks())
+
+	// the license is valid until the end of its expiration date
+	license.AuditedSizeBytes = 0
+	license.ExpirationDate = "2024-05-01"
+	report, err = evaluateLicenseCompliance(&license, 4, now)
+	assert.NoError(t, err)
+	assert.True(t, report.Compliant)
+
+	license.ExpirationDate = "05/01/2024"
+	_, err = evaluateLicenseCompliance(&license, 4, now)
+	assert.ErrorContains(t, err, "invalid expiration date")
+}
+
+func TestGetLicenseOp(t *testing.T) {
+	var license licenseInfo
+	op, err := makeHTTPSGetLicenseOp([]string{"192.0.2.1"}, false, "dbadmin", nil, &license)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"license_type": "Premium Edition", "expiration_date": "2025-12-31",
+				"size_limit_bytes": 1099511627776, "node_limit": 0,
+				"audited_size_bytes": 53687091200, "audit_time": "2024-05-01 10:00:00.000000-04"}`},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Equal(t, "2025-12-31", license.ExpirationDate)
+	assert.Equal(t, int64(1099511627776), license.SizeLimitBytes)
+
+	// a response without the limits is an error
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"license_type": "Premium Edition"}`},
+	}
+	assert.Error(t, op.processResult(nil))
+}
@@ -0,0 +1,214 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VListSubclustersOptions struct {
+	DatabaseOptions
+}
+
+// SubclusterDetails describes a subcluster, its nodes and its shard coverage
+type SubclusterDetails struct {
+	Name      string   `json:"name"`
+	IsPrimary bool     `json:"is_primary"`
+	IsDefault bool     `json:"is_default"`
+	Sandbox   string   `json:"sandbox"`
+	Nodes     []string `json:"nodes"`
+	NodeCount int      `json:"node_count"`
+	UpCount   int      `json:"up_count"`
+	DownCount int      `json:"down_count"`
+	// number of shards with an active subscription on a node of the subcluster
+	CoveredShardCount int `json:"covered_shard_count"`
+	// number of shards in the database
+	TotalShardCount int `json:"total_shard_count"`
+	// shards without an active subscription on a node of the subcluster
+	UncoveredShards []string `json:"uncovered_shards"`
+}
+
+func VListSubclustersOptionsFactory() VListSubclustersOptions {
+	options := VListSubclustersOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VListSubclustersOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VListSubclustersOptions) validateParseOptions(logger vlog.Printer) error {
+	return options.validateBaseOptions(commandListSubclusters, logger)
+}
+
+func (options *VListSubclustersOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VListSubclustersOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VListSubclusters returns the subclusters of an Eon database with their type,
+// their nodes and node states, and how many shards they subscribe to.
+func (vcc VClusterCommands) VListSubclusters(options *VListSubclustersOptions) (subclusters []SubclusterDetails, err error) {
+	defer vcc.audit(commandListSubclusters, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Get the subclusters and the shard subscriptions
+	 *   - Aggregate them per subcluster
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if !vdb.IsEon {
+		return nil, fmt.Errorf("database %s is not an Eon database, it has no subclusters", options.DBName)
+	}
+
+	return vcc.fetchSubclusterDetails(&vdb, &options.DatabaseOptions)
+}
+
+// fetchSubclusterDetails gets the subclusters and the shard subscriptions
+// from an up host of the main cluster, and aggregates them with the nodes of
+// the vdb
+func (vcc VClusterCommands) fetchSubclusterDetails(vdb *VCoordinationDatabase,
+	options *DatabaseOptions) ([]SubclusterDetails, error) {
+	var upHosts []string
+	for host, vnode := range vdb.HostNodeMap {
+		if vnode.State == util.NodeUpState && vnode.Sandbox == util.MainClusterSandbox {
+			upHosts = append(upHosts, host)
+		}
+	}
+	if len(upHosts) == 0 {
+		return nil, fmt.Errorf("cannot find any up host in the main cluster of database %s", options.DBName)
+	}
+
+	var scInfoList []subclusterInfo
+	httpsGetSubclustersOp, err := makeHTTPSGetSubclustersOp(upHosts, options.usePassword,
+		options.UserName, options.Password, &scInfoList)
+	if err != nil {
+		return nil, err
+	}
+	var subscriptions []subscriptionInfo
+	httpsGetSubscriptionsOp, err := makeHTTPSGetSubscriptionsOp(upHosts, options.usePassword,
+		options.UserName, options.Password, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+	instructions := []clusterOp{&httpsGetSubclustersOp, &httpsGetSubscriptionsOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to list the subclusters: %w", err)
+	}
+
+	return buildSubclusterDetails(scInfoList, vdb, subscriptions), nil
+}
+
+// buildSubclusterDetails aggregates the nodes and the shard subscriptions of
+// the vdb per subcluster. The subclusters are sorted by name.
+func buildSubclusterDetails(scInfoList []subclusterInfo, vdb *VCoordinationDatabase,
+	subscriptions []subscriptionInfo) []SubclusterDetails {
+	nodeToSubcluster := make(map[string]string)
+	scToDetails := make(map[string]*SubclusterDetails)
+	for _, scInfo := range scInfoList {
+		scToDetails[scInfo.SCName] = &SubclusterDetails{
+			Name:      scInfo.SCName,
+			IsPrimary: !scInfo.IsSecondary,
+			IsDefault: scInfo.IsDefault,
+			Sandbox:   scInfo.Sandbox,
+			Nodes:     []string{},
+		}
+	}
+	for _, vnode := range vdb.HostNodeMap {
+		nodeToSubcluster[vnode.Name] = vnode.Subcluster
+		details, ok := scToDetails[vnode.Subcluster]
+		if !ok {
+			continue
+		}
+		details.Nodes = append(details.Nodes, vnode.Name)
+		details.NodeCount++
+		if vnode.State == util.NodeUpState {
+			details.UpCount++
+		} else {
+			details.DownCount++
+		}
+	}
+
+	// subcluster -> shards with an active subscription in the subcluster
+	allShards := make(map[string]struct{})
+	scToShards := make(map[string]map[string]struct{})
+	for _, s := range subscriptions {
+		allShards[s.ShardName] = struct{}{}
+		if s.SubscriptionState != "ACTIVE" {
+			continue
+		}
+		scName := nodeToSubcluster[s.Nodename]
+		if _, ok := scToShards[scName]; !ok {
+			scToShards[scName] = make(map[string]struct{})
+		}
+		scToShards[scName][s.ShardName] = struct{}{}
+	}
+
+	subclusters := make([]SubclusterDetails, 0, len(scToDetails))
+	for scName, details := range scToDetails {
+		details.TotalShardCount = len(allShards)
+		details.CoveredShardCount = len(scToShards[scName])
+		details.UncoveredShards = []string{}
+		for shard := range allShards {
+			if _, ok := scToShards[scName][shard]; !ok {
+				details.UncoveredShards = append(details.UncoveredShards, shard)
+			}
+		}
+		sort.Strings(details.UncoveredShards)
+		sort.Strings(details.Nodes)
+		subclusters = append(subclusters, *details)
+	}
+	sort.Slice(subclusters, func(i, j int) bool {
+		return subclusters[i].Name < subclusters[j].Name
+	})
+	return subclusters
+}
@@ -0,0 +1,69 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestBuildSubclusterDetails(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	nodes := []VCoordinationNode{
+		{Name: "v_db_node0001", Address: "192.168.1.101", Subcluster: "sc1", State: util.NodeUpState},
+		{Name: "v_db_node0002", Address: "192.168.1.102", Subcluster: "sc1", State: util.NodeDownState},
+		{Name: "v_db_node0003", Address: "192.168.1.103", Subcluster: "sc2", State: util.NodeUpState},
+	}
+	for i := range nodes {
+		vdb.HostNodeMap[nodes[i].Address] = &nodes[i]
+	}
+	scInfoList := []subclusterInfo{
+		{SCName: "sc2", IsSecondary: true},
+		{SCName: "sc1", IsDefault: true},
+	}
+	subscriptions := []subscriptionInfo{
+		{Nodename: "v_db_node0001", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0001", ShardName: "segment0001", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0002", ShardName: "segment0002", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0003", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0003", ShardName: "segment0001", SubscriptionState: "PENDING"},
+	}
+
+	subclusters := buildSubclusterDetails(scInfoList, &vdb, subscriptions)
+	assert.Len(t, subclusters, 2)
+
+	sc1 := subclusters[0]
+	assert.Equal(t, "sc1", sc1.Name)
+	assert.True(t, sc1.IsPrimary)
+	assert.True(t, sc1.IsDefault)
+	assert.Equal(t, []string{"v_db_node0001", "v_db_node0002"}, sc1.Nodes)
+	assert.Equal(t, 2, sc1.NodeCount)
+	assert.Equal(t, 1, sc1.UpCount)
+	assert.Equal(t, 1, sc1.DownCount)
+	assert.Equal(t, 3, sc1.CoveredShardCount)
+	assert.Equal(t, 3, sc1.TotalShardCount)
+	assert.Empty(t, sc1.UncoveredShards)
+
+	// a pending subscription does not cover its shard
+	sc2 := subclusters[1]
+	assert.Equal(t, "sc2", sc2.Name)
+	assert.False(t, sc2.IsPrimary)
+	assert.Equal(t, 1, sc2.CoveredShardCount)
+	assert.Equal(t, []string{"segment0001", "segment0002"}, sc2.UncoveredShards)
+}
@@ -0,0 +1,209 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the NMA endpoints that can be executed locally, without the NMA
+const (
+	localPrepareDirectoriesEndpoint = "directories/prepare"
+	localDeleteDirectoriesEndpoint  = "directories/delete"
+	localReadCatalogEndpoint        = "catalog/database"
+)
+
+const (
+	localVerticaBinary = "/opt/vertica/bin/vertica"
+	localDirPermission = 0o700
+	dirStatusDeleted   = "deleted"
+)
+
+// this variable is for unit test, be careful to modify it.
+// It runs the catalog editor of the local vertica binary, which is what the
+// NMA catalog/database endpoint does, and returns the database in JSON.
+var readLocalCatalogFn = func(catalogPath string) ([]byte, error) {
+	return runLocalCommandFn(localVerticaBinary, "--read-catalog-json", "-D", catalogPath)
+}
+
+// localNMAAdapter executes some NMA requests to the local host directly
+// through the OS, so that they do not need a running NMA, e.g., to bootstrap
+// a single-node database. The other requests are sent to the NMA.
+type localNMAAdapter struct {
+	httpAdapter
+}
+
+func makeLocalNMAAdapter(logger vlog.Printer, host string) localNMAAdapter {
+	newLocalAdapter := localNMAAdapter{}
+	newLocalAdapter.httpAdapter = makeHTTPAdapter(logger)
+	newLocalAdapter.name = "LocalNMAAdapter"
+	newLocalAdapter.logger = logger.WithName(newLocalAdapter.name)
+	newLocalAdapter.host = host
+	return newLocalAdapter
+}
+
+// isLocalExecutionHost returns true if the requests to the host can be
+// executed locally. Host names are not resolved, so only IP addresses of
+// the local machine qualify.
+func isLocalExecutionHost(host string) bool {
+	isLocal, err := util.IsLocalHost(host)
+	return err == nil && isLocal
+}
+
+func (adapter *localNMAAdapter) sendRequest(request *hostHTTPRequest, resultChannel chan<- hostHTTPResult) {
+	if !request.IsNMACommand {
+		adapter.httpAdapter.sendRequest(request, resultChannel)
+		return
+	}
+
+	var handler func(*hostHTTPRequest) (any, error)
+	switch strings.TrimPrefix(request.Endpoint, NMACurVersion) {
+	case localPrepareDirectoriesEndpoint:
+		handler = adapter.prepareDirectories
+	case localDeleteDirectoriesEndpoint:
+		handler = adapter.deleteDirectories
+	case localReadCatalogEndpoint:
+		handler = adapter.readCatalog
+	default:
+		adapter.httpAdapter.sendRequest(request, resultChannel)
+		return
+	}
+
+	adapter.logger.Info("Execute NMA request locally", "endpoint", request.Endpoint)
+	start := time.Now()
+	result := adapter.executeLocally(request, handler)
+	result.duration = time.Since(start)
+	resultChannel <- result
+}
+
+func (adapter *localNMAAdapter) executeLocally(request *hostHTTPRequest,
+	handler func(*hostHTTPRequest) (any, error)) hostHTTPResult {
+	resp, err := handler(request)
+	if err != nil {
+		return hostHTTPResult{
+			host:       adapter.host,
+			status:     FAILURE,
+			statusCode: http.StatusInternalServerError,
+			err: fmt.Errorf("fail to execute request %s locally on host %s, details: %w",
+				request.Endpoint, adapter.host, err),
+		}
+	}
+	content, err := json.Marshal(resp)
+	if err != nil {
+		return adapter.makeExceptionResult(fmt.Errorf("fail to marshal the response of request %s to JSON string, details: %w",
+			request.Endpoint, err))
+	}
+	return adapter.makeSuccessResult(string(content), http.StatusOK)
+}
+
+// prepareDirectories creates the directories of a node, like the NMA
+// directories/prepare endpoint does. The directories are owned by the user
+// that runs vcluster.
+func (adapter *localNMAAdapter) prepareDirectories(request *hostHTTPRequest) (any, error) {
+	var data prepareDirectoriesRequestData
+	if err := json.Unmarshal([]byte(request.RequestData), &data); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal the request data, details: %w", err)
+	}
+
+	dirs := []string{}
+	for _, dir := range append([]string{data.CatalogPath, data.DepotPath}, data.StorageLocations...) {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	dirs = append(dirs, data.UserStorageLocations...)
+
+	resp := make(map[string]string)
+	for _, dir := range dirs {
+		status, err := prepareLocalDirectory(dir, &data)
+		if err != nil {
+			return nil, err
+		}
+		resp[dir] = status
+	}
+	return resp, nil
+}
+
+func prepareLocalDirectory(dir string, data *prepareDirectoriesRequestData) (string, error) {
+	_, err := os.Stat(dir)
+	switch {
+	case err == nil && data.ReuseExisting:
+		return dirStatusExists, nil
+	case err == nil && data.ForceCleanup:
+		if err = os.RemoveAll(dir); err != nil {
+			return "", fmt.Errorf("fail to clean up directory %s, details: %w", dir, err)
+		}
+	case err == nil && !data.ForRevive:
+		return "", fmt.Errorf("directory %s already exists", dir)
+	case err == nil:
+		return dirStatusExists, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return "", fmt.Errorf("fail to check directory %s, details: %w", dir, err)
+	}
+
+	if err = os.MkdirAll(dir, localDirPermission); err != nil {
+		return "", fmt.Errorf("fail to create directory %s, details: %w", dir, err)
+	}
+	return dirStatusCreated, nil
+}
+
+// deleteDirectories deletes the given directories, like the NMA
+// directories/delete endpoint does. Without force delete, only empty
+// directories can be deleted.
+func (adapter *localNMAAdapter) deleteDirectories(request *hostHTTPRequest) (any, error) {
+	var params deleteDirParams
+	if err := json.Unmarshal([]byte(request.RequestData), &params); err != nil {
+		return nil, fmt.Errorf("fail to unmarshal the request data, details: %w", err)
+	}
+
+	resp := make(map[string]string)
+	for _, dir := range params.Directories {
+		var err error
+		if params.ForceDelete {
+			err = os.RemoveAll(dir)
+		} else {
+			err = os.Remove(dir)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("fail to delete directory %s, details: %w", dir, err)
+		}
+		resp[dir] = dirStatusDeleted
+	}
+	return resp, nil
+}
+
+// readCatalog reads the catalog of the local node with the catalog editor
+func (adapter *localNMAAdapter) readCatalog(request *hostHTTPRequest) (any, error) {
+	catalogPath := request.QueryParams["catalog_path"]
+	if catalogPath == "" {
+		return nil, fmt.Errorf("missing the catalog path")
+	}
+	output, err := readLocalCatalogFn(catalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the catalog in %s, details: %w, output: %s",
+			catalogPath, err, strings.TrimSpace(string(output)))
+	}
+	return json.RawMessage(output), nil
+}
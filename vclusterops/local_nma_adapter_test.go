@@ -0,0 +1,119 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func sendLocalRequest(t *testing.T, request *hostHTTPRequest) hostHTTPResult {
+	adapter := makeLocalNMAAdapter(vlog.Printer{}, "127.0.0.1")
+	resultChannel := make(chan hostHTTPResult, 1)
+	adapter.sendRequest(request, resultChannel)
+	result := <-resultChannel
+	assert.Equal(t, "127.0.0.1", result.host)
+	return result
+}
+
+func TestLocalPrepareAndDeleteDirectories(t *testing.T) {
+	baseDir := t.TempDir()
+	catalogPath := filepath.Join(baseDir, "v_test_db_node0001_catalog")
+	dataPath := filepath.Join(baseDir, "v_test_db_node0001_data")
+
+	data := prepareDirectoriesRequestData{CatalogPath: catalogPath, StorageLocations: []string{dataPath}}
+	dataBytes, err := json.Marshal(data)
+	assert.NoError(t, err)
+	request := hostHTTPRequest{Method: PostMethod, RequestData: string(dataBytes)}
+	request.buildNMAEndpoint("directories/prepare")
+
+	result := sendLocalRequest(t, &request)
+	assert.True(t, result.isPassing())
+	resp := map[string]string{}
+	assert.NoError(t, json.Unmarshal([]byte(result.content), &resp))
+	assert.Equal(t, map[string]string{catalogPath: dirStatusCreated, dataPath: dirStatusCreated}, resp)
+	assert.DirExists(t, catalogPath)
+
+	// existing directories are an error unless they are reused or cleaned up
+	result = sendLocalRequest(t, &request)
+	assert.False(t, result.isPassing())
+	assert.ErrorContains(t, result.err, "already exists")
+
+	data.ReuseExisting = true
+	dataBytes, err = json.Marshal(data)
+	assert.NoError(t, err)
+	request.RequestData = string(dataBytes)
+	result = sendLocalRequest(t, &request)
+	assert.True(t, result.isPassing())
+	assert.Contains(t, result.content, dirStatusExists)
+
+	// a non-empty directory needs force delete
+	assert.NoError(t, os.WriteFile(filepath.Join(catalogPath, "vertica.log"), []byte{}, 0o600))
+	params := deleteDirParams{Directories: []string{catalogPath, dataPath}}
+	dataBytes, err = json.Marshal(params)
+	assert.NoError(t, err)
+	request = hostHTTPRequest{Method: PostMethod, RequestData: string(dataBytes)}
+	request.buildNMAEndpoint("directories/delete")
+	result = sendLocalRequest(t, &request)
+	assert.False(t, result.isPassing())
+
+	params.ForceDelete = true
+	dataBytes, err = json.Marshal(params)
+	assert.NoError(t, err)
+	request.RequestData = string(dataBytes)
+	result = sendLocalRequest(t, &request)
+	assert.True(t, result.isPassing())
+	assert.NoDirExists(t, catalogPath)
+	assert.NoDirExists(t, dataPath)
+}
+
+func TestLocalReadCatalog(t *testing.T) {
+	originalFn := readLocalCatalogFn
+	defer func() { readLocalCatalogFn = originalFn }()
+
+	var gotPath string
+	readLocalCatalogFn = func(catalogPath string) ([]byte, error) {
+		gotPath = catalogPath
+		return []byte(`{"name": "test_db", "versions": {"global": 10}}`), nil
+	}
+	request := hostHTTPRequest{Method: GetMethod,
+		QueryParams: map[string]string{"catalog_path": "/data/test_db/v_test_db_node0001_catalog"}}
+	request.buildNMAEndpoint("catalog/database")
+	result := sendLocalRequest(t, &request)
+	assert.True(t, result.isPassing())
+	assert.Equal(t, "/data/test_db/v_test_db_node0001_catalog", gotPath)
+
+	var vdb nmaVDatabase
+	assert.NoError(t, json.Unmarshal([]byte(result.content), &vdb))
+	assert.Equal(t, "test_db", vdb.Name)
+}
+
+func TestLocalExecutionDispatcherSetup(t *testing.T) {
+	dispatcher := makeHTTPRequestDispatcher(vlog.Printer{})
+	dispatcher.setup([]string{"127.0.0.1"})
+	assert.IsType(t, &httpAdapter{}, dispatcher.pool.connections["127.0.0.1"])
+
+	dispatcher.localExecution = true
+	dispatcher.setup([]string{"127.0.0.1", "192.0.2.1", "vnode1"})
+	assert.IsType(t, &localNMAAdapter{}, dispatcher.pool.connections["127.0.0.1"])
+	assert.IsType(t, &httpAdapter{}, dispatcher.pool.connections["192.0.2.1"])
+	assert.IsType(t, &httpAdapter{}, dispatcher.pool.connections["vnode1"])
+}
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -122,9 +123,10 @@ func (opt *VManageConnectionDrainingOptions) validateAnalyzeOptions(log vlog.Pri
 }
 
 //nolint:dupl
-func (vcc VClusterCommands) VManageConnectionDraining(options *VManageConnectionDrainingOptions) error {
+func (vcc VClusterCommands) VManageConnectionDraining(options *VManageConnectionDrainingOptions) (err error) {
+	defer vcc.audit(commandManageConnections, &options.DatabaseOptions, options, time.Now(), &err)
 	// validate and analyze all options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -136,8 +138,8 @@ func (vcc VClusterCommands) VManageConnectionDraining(options *VManageConnection
 	}
 
 	// Create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -0,0 +1,150 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/maps"
+)
+
+// default buckets of the request latency histogram, in seconds
+var defaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// MetricsRegistry collects metrics about the ops run by vclusterops: the number
+// of ops run and failed by op name, and the latency of the requests sent to each
+// host. Set it in VClusterCommands.Metrics to turn on the collection. The metrics
+// can be exposed in the Prometheus text format with WritePrometheus or Handler.
+// It is safe for concurrent use, and can be shared by several VClusterCommands.
+type MetricsRegistry struct {
+	mu          sync.Mutex
+	opsRun      map[string]uint64
+	opsFailed   map[string]uint64
+	hostLatency map[string]*latencyHistogram
+	buckets     []float64
+}
+
+// latencyHistogram is a cumulative histogram of the request latencies
+type latencyHistogram struct {
+	// bucketCounts[i] is the count of observations <= buckets[i]
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+}
+
+// MakeMetricsRegistry creates an empty metrics registry
+func MakeMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{
+		opsRun:      make(map[string]uint64),
+		opsFailed:   make(map[string]uint64),
+		hostLatency: make(map[string]*latencyHistogram),
+		buckets:     defaultLatencyBuckets,
+	}
+}
+
+// recordOp counts an op run, and whether it failed
+func (m *MetricsRegistry) recordOp(opName string, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.opsRun[opName]++
+	if failed {
+		m.opsFailed[opName]++
+	}
+}
+
+// recordRequest adds the latency of a request sent to a host
+func (m *MetricsRegistry) recordRequest(host string, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.hostLatency[host]
+	if !ok {
+		h = &latencyHistogram{bucketCounts: make([]uint64, len(m.buckets))}
+		m.hostLatency[host] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.bucketCounts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+func (m *MetricsRegistry) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	// write formats a line, and keeps the first error
+	write := func(format string, v ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, v...)
+		}
+	}
+
+	write("# HELP vcluster_ops_total Number of ops run, by op name.\n")
+	write("# TYPE vcluster_ops_total counter\n")
+	for _, opName := range sortedKeys(m.opsRun) {
+		write("vcluster_ops_total{op=%q} %d\n", opName, m.opsRun[opName])
+	}
+
+	write("# HELP vcluster_op_failures_total Number of ops that failed, by op name.\n")
+	write("# TYPE vcluster_op_failures_total counter\n")
+	for _, opName := range sortedKeys(m.opsFailed) {
+		write("vcluster_op_failures_total{op=%q} %d\n", opName, m.opsFailed[opName])
+	}
+
+	write("# HELP vcluster_http_request_duration_seconds Latency of the requests sent to the hosts.\n")
+	write("# TYPE vcluster_http_request_duration_seconds histogram\n")
+	for _, host := range sortedKeys(m.hostLatency) {
+		h := m.hostLatency[host]
+		for i, bound := range m.buckets {
+			write("vcluster_http_request_duration_seconds_bucket{host=%q,le=\"%g\"} %d\n",
+				host, bound, h.bucketCounts[i])
+		}
+		write("vcluster_http_request_duration_seconds_bucket{host=%q,le=\"+Inf\"} %d\n", host, h.count)
+		write("vcluster_http_request_duration_seconds_sum{host=%q} %g\n", host, h.sum)
+		write("vcluster_http_request_duration_seconds_count{host=%q} %d\n", host, h.count)
+	}
+
+	return err
+}
+
+// Handler returns an http.Handler that serves the metrics to a Prometheus scraper
+func (m *MetricsRegistry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		err := m.WritePrometheus(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := maps.Keys(m)
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	metrics := MakeMetricsRegistry()
+	metrics.recordOp("NMAHealthOp", false)
+	metrics.recordOp("NMAHealthOp", true)
+	metrics.recordOp("HTTPSGetUpNodesOp", false)
+	metrics.recordRequest("192.0.2.1", 200*time.Millisecond)
+	metrics.recordRequest("192.0.2.1", 3*time.Second)
+
+	var out bytes.Buffer
+	err := metrics.WritePrometheus(&out)
+	assert.NoError(t, err)
+	text := out.String()
+	assert.Contains(t, text, `vcluster_ops_total{op="HTTPSGetUpNodesOp"} 1`)
+	assert.Contains(t, text, `vcluster_ops_total{op="NMAHealthOp"} 2`)
+	assert.Contains(t, text, `vcluster_op_failures_total{op="NMAHealthOp"} 1`)
+	assert.NotContains(t, text, `vcluster_op_failures_total{op="HTTPSGetUpNodesOp"}`)
+	// the buckets are cumulative
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_bucket{host="192.0.2.1",le="0.1"} 0`)
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_bucket{host="192.0.2.1",le="0.25"} 1`)
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_bucket{host="192.0.2.1",le="5"} 2`)
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_bucket{host="192.0.2.1",le="+Inf"} 2`)
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_sum{host="192.0.2.1"} 3.2`)
+	assert.Contains(t, text, `vcluster_http_request_duration_seconds_count{host="192.0.2.1"} 2`)
+}
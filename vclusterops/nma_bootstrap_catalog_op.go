@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+
+	"github.com/vertica/vcluster/vclusterops/util"
 )
 
 type nmaBootstrapCatalogOp struct {
@@ -57,6 +59,7 @@ func makeNMABootstrapCatalogOp(
 	op := nmaBootstrapCatalogOp{}
 	op.name = "NMABootstrapCatalogOp"
 	op.description = "Bootstrap catalog"
+	op.responseSchema = responseSchema{{path: "bootstrap_catalog_return_code", typ: jsonString}}
 	// usually, only one node need bootstrap catalog
 	op.hosts = bootstrapHosts
 
@@ -86,7 +89,7 @@ func (op *nmaBootstrapCatalogOp) setupRequestBody(vdb *VCoordinationDatabase, op
 
 		// client port: spread port will be computed based on client port
 		bootstrapData.PortNumber = vnode.Port
-		bootstrapData.Parameters = options.ConfigurationParameters
+		bootstrapData.Parameters = options.getCommunalStorageParameters()
 
 		// need to read network_profile info in execContext
 		// see execContext in nmaBootstrapCatalogOp:prepare()
@@ -102,7 +105,7 @@ func (op *nmaBootstrapCatalogOp) setupRequestBody(vdb *VCoordinationDatabase, op
 		}
 		bootstrapData.SpreadLogging = options.SpreadLogging
 		bootstrapData.SpreadLoggingLevel = options.SpreadLoggingLevel
-		bootstrapData.Ipv6 = options.IPv6
+		bootstrapData.Ipv6 = util.IsIPv6(host)
 		bootstrapData.SuperuserName = options.UserName
 		bootstrapData.DBPassword = *options.Password
 
@@ -202,12 +205,7 @@ func (op *nmaBootstrapCatalogOp) processResult(_ *opEngineExecContext) error {
 				continue
 			}
 
-			code, ok := responseMap["bootstrap_catalog_return_code"]
-			if !ok {
-				err = fmt.Errorf(`[%s] response does not contain the field "bootstrap_catalog_return_code"`, op.name)
-				allErrs = errors.Join(allErrs, err)
-				continue
-			}
+			code := responseMap["bootstrap_catalog_return_code"]
 			if code != "0" {
 				err = fmt.Errorf(`[%s] bootstrap_catalog_return_code should be 0 but got %s`, op.name, code)
 				allErrs = errors.Join(allErrs, err)
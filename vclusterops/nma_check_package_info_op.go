@@ -0,0 +1,163 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"golang.org/x/exp/maps"
+)
+
+// packageInfoMinServerVersion is the oldest Vertica version that the package
+// check is run against. The NMA of older servers has no package-info endpoint,
+// so the check is skipped instead of failing with a 404 on every host.
+const packageInfoMinServerVersion = "24.3.0"
+
+type nmaCheckPackageInfoOp struct {
+	opBase
+	hostPackages map[string]packageInfo
+}
+
+// packageInfo describes the Vertica binary installed on a host
+type packageInfo struct {
+	BinaryPath     string `json:"binary_path"`
+	PackageVersion string `json:"package_version"`
+}
+
+// makeNMACheckPackageInfoOp will create an op that checks that all the hosts
+// run the same Vertica binary, from the same path and package version, e.g.,
+// before the database is started after an upgrade. The op is skipped on
+// servers older than packageInfoMinServerVersion, and the hosts whose NMA
+// does not have the package-info endpoint are skipped.
+func makeNMACheckPackageInfoOp(hosts []string) nmaCheckPackageInfoOp {
+	op := nmaCheckPackageInfoOp{}
+	op.name = "NMACheckPackageInfoOp"
+	op.description = "Check Vertica package of hosts"
+	op.hosts = hosts
+	op.hostPackages = make(map[string]packageInfo)
+	op.minServerVersion = packageInfoMinServerVersion
+	op.skipOnOldServer = true
+	op.responseSchema = responseSchema{
+		{path: "binary_path", typ: jsonString},
+		{path: "package_version", typ: jsonString},
+	}
+	return op
+}
+
+func (op *nmaCheckPackageInfoOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildNMAEndpoint("vertica/package-info")
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaCheckPackageInfoOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaCheckPackageInfoOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaCheckPackageInfoOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaCheckPackageInfoOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isNotFound() {
+			op.logger.Info("NMA does not support package inspection, skip the host", "host", host)
+			continue
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like:
+		// {"binary_path": "/opt/vertica/bin/vertica", "package_version": "24.3.0-1"}
+		info := packageInfo{}
+		err := op.parseAndCheckResponse(host, result.content, &info)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		op.hostPackages[host] = info
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	return op.checkSamePackage()
+}
+
+// checkSamePackage returns an error with the package of every host if the
+// hosts do not all run the same binary
+func (op *nmaCheckPackageInfoOp) checkSamePackage() error {
+	packages := make(map[packageInfo]bool)
+	versions := make(map[string]bool)
+	for _, info := range op.hostPackages {
+		packages[info] = true
+		versions[info.PackageVersion] = true
+	}
+	if len(packages) <= 1 {
+		return nil
+	}
+
+	mismatchedVersions := maps.Keys(versions)
+	sort.Strings(mismatchedVersions)
+	return &VersionMismatchError{
+		Detail: fmt.Sprintf("[%s] hosts do not run the same Vertica binary:\n%s",
+			op.name, op.buildPackageTable()),
+		Versions: mismatchedVersions,
+	}
+}
+
+// buildPackageTable returns the binary path and package version of every
+// host, one host per line
+func (op *nmaCheckPackageInfoOp) buildPackageTable() string {
+	hosts := maps.Keys(op.hostPackages)
+	sort.Strings(hosts)
+
+	var buf bytes.Buffer
+	const padding = 2
+	w := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(w, "  HOST\tBINARY PATH\tPACKAGE VERSION")
+	for _, host := range hosts {
+		info := op.hostPackages[host]
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", host, info.BinaryPath, info.PackageVersion)
+	}
+	// writing to a buffer cannot fail
+	_ = w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,66 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestCheckPackageInfo(t *testing.T) {
+	op := makeNMACheckPackageInfoOp([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"})
+	op.setLogger(vlog.Printer{})
+
+	upgraded := `{"binary_path": "/opt/vertica/bin/vertica", "package_version": "24.3.0-1"}`
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: upgraded},
+		"192.0.2.2": {host: "192.0.2.2", status: SUCCESS, statusCode: SuccessCode, content: upgraded},
+		// an old NMA without the endpoint is skipped
+		"192.0.2.3": {host: "192.0.2.3", status: FAILURE, statusCode: NotFoundCode, err: errors.New("not found")},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Len(t, op.hostPackages, 2)
+
+	op.clusterHTTPRequest.ResultCollection["192.0.2.3"] = hostHTTPResult{host: "192.0.2.3", status: SUCCESS,
+		statusCode: SuccessCode, content: `{"binary_path": "/opt/vertica/bin/vertica", "package_version": "24.2.0-0"}`}
+	err := op.processResult(nil)
+	var mismatchErr *VersionMismatchError
+	assert.True(t, errors.As(err, &mismatchErr))
+	assert.Equal(t, []string{"24.2.0-0", "24.3.0-1"}, mismatchErr.Versions)
+	// the error has the package of every host
+	assert.ErrorContains(t, err, "HOST       BINARY PATH               PACKAGE VERSION")
+	assert.ErrorContains(t, err, "192.0.2.1  /opt/vertica/bin/vertica  24.3.0-1")
+	assert.ErrorContains(t, err, "192.0.2.3  /opt/vertica/bin/vertica  24.2.0-0")
+}
+
+func TestCheckPackageInfoOnOldServer(t *testing.T) {
+	op := makeNMACheckPackageInfoOp([]string{"192.0.2.1"})
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+
+	// the servers without the package-info endpoint are not checked
+	execContext.serverVersion = "Vertica Analytic Database v24.2.0-1"
+	opToRun, err := negotiateServerVersion(vlog.Printer{}, &execContext, &op)
+	assert.NoError(t, err)
+	assert.Nil(t, opToRun)
+
+	execContext.serverVersion = "Vertica Analytic Database v24.3.0-0"
+	opToRun, err = negotiateServerVersion(vlog.Printer{}, &execContext, &op)
+	assert.NoError(t, err)
+	assert.Equal(t, &op, opToRun)
+}
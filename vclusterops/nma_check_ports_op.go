@@ -0,0 +1,177 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type nmaCheckPortsOp struct {
+	opBase
+	ports []int
+	// source host -> target host -> unreachable ports
+	unreachableMatrix map[string]map[string][]int
+}
+
+type portCheckTarget struct {
+	Host  string `json:"host"`
+	Ports []int  `json:"ports"`
+}
+
+type portCheckRequestData struct {
+	Targets []portCheckTarget `json:"targets"`
+}
+
+type portCheckResult struct {
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	Reachable bool   `json:"reachable"`
+	Error     string `json:"error,omitempty"`
+}
+
+type portCheckResponse struct {
+	Results []portCheckResult `json:"results"`
+}
+
+// makeNMACheckPortsOp will create an op that asks the NMA of every host to
+// open a connection to the given ports of every other host. A port on which
+// nothing is listening yet is still considered reachable by the NMA: this op
+// is only meant to detect firewalls and routing problems between hosts.
+func makeNMACheckPortsOp(hosts []string, clientPort int) nmaCheckPortsOp {
+	op := nmaCheckPortsOp{}
+	op.name = "NMACheckPortsOp"
+	op.description = "Check port reachability between hosts"
+	op.hosts = hosts
+	op.ports = []int{util.DefaultSpreadPort, clientPort, httpsPort, nmaPort}
+	op.unreachableMatrix = make(map[string]map[string][]int)
+	return op
+}
+
+func (op *nmaCheckPortsOp) setupRequestBody(host string) (string, error) {
+	data := portCheckRequestData{}
+	for _, target := range op.hosts {
+		if target == host {
+			continue
+		}
+		data.Targets = append(data.Targets, portCheckTarget{Host: target, Ports: op.ports})
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	return string(dataBytes), nil
+}
+
+func (op *nmaCheckPortsOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildNMAEndpoint("network/ports/check")
+		requestData, err := op.setupRequestBody(host)
+		if err != nil {
+			return err
+		}
+		httpRequest.RequestData = requestData
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaCheckPortsOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaCheckPortsOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaCheckPortsOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaCheckPortsOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		var resp portCheckResponse
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		op.recordUnreachablePorts(host, resp.Results)
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	if len(op.unreachableMatrix) > 0 {
+		return fmt.Errorf("[%s] some ports are not reachable between hosts:\n%s",
+			op.name, op.buildConnectivityMatrix())
+	}
+	return nil
+}
+
+func (op *nmaCheckPortsOp) recordUnreachablePorts(source string, results []portCheckResult) {
+	for _, r := range results {
+		if r.Reachable {
+			continue
+		}
+		op.logger.Info("port is not reachable", "source", source, "target", r.Host,
+			"port", r.Port, "error", r.Error)
+		if _, ok := op.unreachableMatrix[source]; !ok {
+			op.unreachableMatrix[source] = make(map[string][]int)
+		}
+		op.unreachableMatrix[source][r.Host] = append(op.unreachableMatrix[source][r.Host], r.Port)
+	}
+}
+
+// buildConnectivityMatrix returns one line per pair of hosts that
+// cannot reach each other, e.g., "192.168.1.101 -> 192.168.1.102: 5433,8443"
+func (op *nmaCheckPortsOp) buildConnectivityMatrix() string {
+	var lines []string
+	for source, targets := range op.unreachableMatrix {
+		for target, ports := range targets {
+			sort.Ints(ports)
+			portStrs := make([]string, len(ports))
+			for i, port := range ports {
+				portStrs[i] = fmt.Sprint(port)
+			}
+			lines = append(lines, fmt.Sprintf("  %s -> %s: %s", source, target, strings.Join(portStrs, ",")))
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,55 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestCheckPortsRequestBody(t *testing.T) {
+	hosts := []string{"host1", "host2", "host3"}
+	op := makeNMACheckPortsOp(hosts, 5433)
+
+	body, err := op.setupRequestBody("host1")
+	assert.NoError(t, err)
+	data := portCheckRequestData{}
+	err = json.Unmarshal([]byte(body), &data)
+	assert.NoError(t, err)
+	// a host does not check its own ports
+	assert.Len(t, data.Targets, 2)
+	assert.Equal(t, "host2", data.Targets[0].Host)
+	assert.Equal(t, []int{4803, 5433, 8443, 5554}, data.Targets[0].Ports)
+}
+
+func TestCheckPortsConnectivityMatrix(t *testing.T) {
+	op := makeNMACheckPortsOp([]string{"host1", "host2"}, 5433)
+	op.logger = vlog.Printer{}
+
+	op.recordUnreachablePorts("host1", []portCheckResult{
+		{Host: "host2", Port: 5433, Reachable: true},
+		{Host: "host2", Port: 8443, Reachable: false},
+		{Host: "host2", Port: 4803, Reachable: false},
+	})
+	op.recordUnreachablePorts("host2", []portCheckResult{
+		{Host: "host1", Port: 5433, Reachable: true},
+	})
+	assert.Len(t, op.unreachableMatrix, 1)
+	assert.Equal(t, "  host1 -> host2: 4803,8443", op.buildConnectivityMatrix())
+}
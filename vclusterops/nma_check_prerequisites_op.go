@@ -0,0 +1,176 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type nmaCheckPrerequisitesOp struct {
+	opBase
+	report *PrerequisiteReport
+	// if set, a host whose resources cannot be checked is skipped with a
+	// warning instead of failing the op
+	warnOnError bool
+}
+
+// nmaHostResources is the response of the NMA hardware/resources endpoint
+type nmaHostResources struct {
+	CPUCount             int        `json:"cpu_count"`
+	MemoryBytes          int64      `json:"memory_bytes"`
+	TransparentHugepages string     `json:"transparent_hugepages"`
+	HugepagesTotal       int64      `json:"hugepages_total"`
+	Ulimits              nmaUlimits `json:"ulimits"`
+	NTPSynchronized      bool       `json:"ntp_synchronized"`
+}
+
+type nmaUlimits struct {
+	OpenFiles     int64 `json:"nofile"`
+	UserProcesses int64 `json:"nproc"`
+	FileSize      int64 `json:"fsize"`
+}
+
+// makeNMACheckPrerequisitesOp will create an op that gathers the resources
+// of every host and evaluates them against the Vertica prerequisites into
+// report. The failed prerequisites are printed as warnings, not returned as
+// errors. The hosts whose NMA does not have the hardware/resources endpoint
+// are skipped.
+func makeNMACheckPrerequisitesOp(hosts []string, report *PrerequisiteReport) nmaCheckPrerequisitesOp {
+	op := nmaCheckPrerequisitesOp{}
+	op.name = "NMACheckPrerequisitesOp"
+	op.description = "Check hardware prerequisites of hosts"
+	op.hosts = hosts
+	op.report = report
+	op.responseSchema = responseSchema{
+		{path: "cpu_count", typ: jsonNumber},
+		{path: "memory_bytes", typ: jsonNumber},
+		{path: "transparent_hugepages", typ: jsonString},
+		{path: "hugepages_total", typ: jsonNumber},
+		{path: "ulimits.nofile", typ: jsonNumber},
+		{path: "ulimits.nproc", typ: jsonNumber},
+		{path: "ulimits.fsize", typ: jsonNumber},
+		{path: "ntp_synchronized", typ: jsonBool},
+	}
+	return op
+}
+
+// makeNMACheckPrerequisitesOpForCreateDB will create an op that checks the
+// prerequisites of the hosts of a new database. The check is advisory, so
+// any failure to check a host is only a warning.
+func makeNMACheckPrerequisitesOpForCreateDB(hosts []string, report *PrerequisiteReport) nmaCheckPrerequisitesOp {
+	op := makeNMACheckPrerequisitesOp(hosts, report)
+	op.warnOnError = true
+	return op
+}
+
+func (op *nmaCheckPrerequisitesOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildNMAEndpoint("hardware/resources")
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaCheckPrerequisitesOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaCheckPrerequisitesOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaCheckPrerequisitesOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaCheckPrerequisitesOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isNotFound() {
+			op.logger.Info("NMA does not support resource inspection, skip the host", "host", host)
+			op.report.SkippedHosts = append(op.report.SkippedHosts, host)
+			continue
+		}
+		if !result.isPassing() {
+			allErrs = op.joinHostError(allErrs, host, result.err)
+			continue
+		}
+
+		// a successful response looks like:
+		// {"cpu_count": 16, "memory_bytes": 68719476736, "transparent_hugepages": "always",
+		//  "hugepages_total": 0, "ulimits": {"nofile": 65536, "nproc": 4096, "fsize": -1},
+		//  "ntp_synchronized": true}
+		resources := nmaHostResources{}
+		err := op.parseAndCheckResponse(host, result.content, &resources)
+		if err != nil {
+			allErrs = op.joinHostError(allErrs, host, err)
+			continue
+		}
+		op.report.Hosts = append(op.report.Hosts, evaluateHostPrerequisites(host, &resources))
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	op.report.finish()
+	op.warnFailedPrerequisites()
+	return nil
+}
+
+// joinHostError adds the error of a host whose resources cannot be checked
+// to allErrs, or skips the host with a warning if the op only warns
+func (op *nmaCheckPrerequisitesOp) joinHostError(allErrs error, host string, err error) error {
+	if !op.warnOnError {
+		return errors.Join(allErrs, err)
+	}
+	op.logger.PrintWarning("[%s] fail to check the prerequisites of host %s, skip it: %v", op.name, host, err)
+	op.report.SkippedHosts = append(op.report.SkippedHosts, host)
+	return allErrs
+}
+
+// warnFailedPrerequisites prints a warning with the failed prerequisites of
+// every host
+func (op *nmaCheckPrerequisitesOp) warnFailedPrerequisites() {
+	if op.report.Passed {
+		return
+	}
+
+	var lines []string
+	for i := range op.report.Hosts {
+		failedChecks := op.report.Hosts[i].failedChecks()
+		if len(failedChecks) > 0 {
+			lines = append(lines, fmt.Sprintf("  %s: %s", op.report.Hosts[i].Host,
+				util.ArrayToString(failedChecks, ", ")))
+		}
+	}
+	op.logger.PrintWarning("[%s] some hosts do not meet the Vertica prerequisites:\n%s",
+		op.name, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,110 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestCheckPrerequisites(t *testing.T) {
+	report := PrerequisiteReport{}
+	op := makeNMACheckPrerequisitesOp([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, &report)
+	op.setLogger(vlog.Printer{})
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"cpu_count": 16, "memory_bytes": 68719476736, "transparent_hugepages": "always",
+				"hugepages_total": 0, "ulimits": {"nofile": 65536, "nproc": -1, "fsize": -1},
+				"ntp_synchronized": true}`},
+		// 2GB per CPU, low limits and no NTP
+		"192.0.2.2": {host: "192.0.2.2", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"cpu_count": 4, "memory_bytes": 8589934592, "transparent_hugepages": "never",
+				"hugepages_total": 0, "ulimits": {"nofile": 1024, "nproc": 4096, "fsize": 1048576},
+				"ntp_synchronized": false}`},
+		// an old NMA without the endpoint is skipped
+		"192.0.2.3": {host: "192.0.2.3", status: FAILURE, statusCode: NotFoundCode, err: errors.New("not found")},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.False(t, report.Passed)
+	assert.Equal(t, []string{"192.0.2.3"}, report.SkippedHosts)
+	assert.Len(t, report.Hosts, 2)
+
+	assert.Equal(t, "192.0.2.1", report.Hosts[0].Host)
+	assert.Equal(t, 16, report.Hosts[0].CPUCount)
+	assert.Empty(t, report.Hosts[0].failedChecks())
+	for _, check := range report.Hosts[0].Checks {
+		assert.Equal(t, PrerequisitePassed, check.Status, check.Name)
+	}
+
+	statuses := make(map[string]string)
+	for _, check := range report.Hosts[1].Checks {
+		statuses[check.Name] = check.Status
+	}
+	assert.Equal(t, map[string]string{
+		checkMemoryPerCPU:         PrerequisiteWarning,
+		checkOpenFilesLimit:       PrerequisiteFailed,
+		checkUserProcessesLimit:   PrerequisitePassed,
+		checkFileSizeLimit:        PrerequisiteFailed,
+		checkTransparentHugepages: PrerequisiteWarning,
+		checkNTPSynchronized:      PrerequisiteWarning,
+	}, statuses)
+
+	// a response with a missing field is an error
+	report = PrerequisiteReport{}
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"cpu_count": 16, "memory_bytes": 68719476736}`},
+	}
+	assert.Error(t, op.processResult(nil))
+}
+
+func TestCheckPrerequisitesForCreateDB(t *testing.T) {
+	report := PrerequisiteReport{}
+	op := makeNMACheckPrerequisitesOpForCreateDB([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, &report)
+	op.setLogger(vlog.Printer{})
+
+	// the hosts that cannot be checked are skipped instead of failing create_db
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"cpu_count": 16, "memory_bytes": 68719476736, "transparent_hugepages": "always",
+				"hugepages_total": 0, "ulimits": {"nofile": 65536, "nproc": -1, "fsize": -1},
+				"ntp_synchronized": true}`},
+		"192.0.2.2": {host: "192.0.2.2", status: FAILURE, statusCode: InternalErrorCode, err: errors.New("internal error")},
+		"192.0.2.3": {host: "192.0.2.3", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"cpu_count": 16, "memory_bytes": 68719476736}`},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.True(t, report.Passed)
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.3"}, report.SkippedHosts)
+	assert.Len(t, report.Hosts, 1)
+}
+
+func TestEvaluateMemoryPerCPU(t *testing.T) {
+	resources := nmaHostResources{CPUCount: 8, MemoryBytes: 4 * bytesPerGiB, TransparentHugepages: "always",
+		Ulimits: nmaUlimits{OpenFiles: -1, UserProcesses: -1, FileSize: -1}, NTPSynchronized: true}
+	hostPrereqs := evaluateHostPrerequisites("192.0.2.1", &resources)
+	assert.Equal(t, []string{checkMemoryPerCPU}, hostPrereqs.failedChecks())
+	assert.Equal(t, "0.5GiB", hostPrereqs.Checks[0].Actual)
+
+	// a host that reports no CPU cannot meet the memory prerequisite
+	resources.CPUCount = 0
+	hostPrereqs = evaluateHostPrerequisites("192.0.2.1", &resources)
+	assert.Equal(t, []string{checkMemoryPerCPU}, hostPrereqs.failedChecks())
+}
@@ -0,0 +1,149 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type nmaCheckProcessesOp struct {
+	opBase
+	// if true, the op fails when a vertica process is running on any host
+	failIfVerticaRunning bool
+	// if true, the hosts whose NMA does not have the process endpoint are
+	// skipped instead of failing the op
+	ignoreNotFound bool
+	hostProcesses  map[string]*HostProcesses
+	// the action that cannot be done while vertica runs, used in the error
+	action string
+}
+
+// ProcessInfo describes a process found on a host
+type ProcessInfo struct {
+	Running       bool  `json:"running"`
+	PID           int   `json:"pid"`
+	UptimeSeconds int64 `json:"uptime_seconds"`
+}
+
+// HostProcesses describes the vertica and NMA processes found on a host
+type HostProcesses struct {
+	Host    string      `json:"host"`
+	Vertica ProcessInfo `json:"vertica"`
+	NMA     ProcessInfo `json:"nma"`
+}
+
+func makeNMACheckProcessesOp(hosts []string, failIfVerticaRunning, ignoreNotFound bool,
+	hostProcesses map[string]*HostProcesses) nmaCheckProcessesOp {
+	op := nmaCheckProcessesOp{}
+	op.name = "NMACheckProcessesOp"
+	op.description = "Check vertica processes"
+	op.hosts = hosts
+	op.failIfVerticaRunning = failIfVerticaRunning
+	op.ignoreNotFound = ignoreNotFound
+	op.hostProcesses = hostProcesses
+	op.action = "starting"
+	return op
+}
+
+func (op *nmaCheckProcessesOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildNMAEndpoint("processes")
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaCheckProcessesOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaCheckProcessesOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaCheckProcessesOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaCheckProcessesOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isNotFound() && op.ignoreNotFound {
+			op.logger.Info("NMA does not support process inspection, skip the host", "host", host)
+			continue
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like:
+		// {
+		//   "vertica": {"running": true, "pid": 12345, "uptime_seconds": 3600},
+		//   "nma": {"running": true, "pid": 2345, "uptime_seconds": 7200}
+		// }
+		processes := HostProcesses{}
+		err := op.parseAndCheckResponse(host, result.content, &processes)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		processes.Host = host
+		op.hostProcesses[host] = &processes
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	if op.failIfVerticaRunning {
+		return op.checkVerticaNotRunning()
+	}
+	return nil
+}
+
+// checkVerticaNotRunning returns an error that lists the hosts where
+// a vertica process is running
+func (op *nmaCheckProcessesOp) checkVerticaNotRunning() error {
+	var runningHosts []string
+	for host, processes := range op.hostProcesses {
+		if processes.Vertica.Running {
+			runningHosts = append(runningHosts, fmt.Sprintf("%s (pid %d, up for %ds)",
+				host, processes.Vertica.PID, processes.Vertica.UptimeSeconds))
+		}
+	}
+	if len(runningHosts) == 0 {
+		return nil
+	}
+
+	sort.Strings(runningHosts)
+	return &DBIsRunningError{
+		Detail: fmt.Sprintf("[%s] vertica is already running on hosts %v, stop it before %s the database",
+			op.name, runningHosts, op.action),
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVerticaNotRunning(t *testing.T) {
+	hostProcesses := map[string]*HostProcesses{
+		"192.0.2.1": {Host: "192.0.2.1", NMA: ProcessInfo{Running: true, PID: 100, UptimeSeconds: 60}},
+		"192.0.2.2": {Host: "192.0.2.2", NMA: ProcessInfo{Running: true, PID: 101, UptimeSeconds: 60}},
+	}
+	op := makeNMACheckProcessesOp([]string{"192.0.2.1", "192.0.2.2"}, true, true, hostProcesses)
+	assert.NoError(t, op.checkVerticaNotRunning())
+
+	hostProcesses["192.0.2.2"].Vertica = ProcessInfo{Running: true, PID: 2000, UptimeSeconds: 30}
+	err := op.checkVerticaNotRunning()
+	var runningErr *DBIsRunningError
+	assert.True(t, errors.As(err, &runningErr))
+	assert.ErrorContains(t, err, "192.0.2.2 (pid 2000, up for 30s)")
+	assert.NotContains(t, err.Error(), "192.0.2.1")
+}
+
+func TestDropDBChecksVerticaProcesses(t *testing.T) {
+	options := VDropDatabaseOptionsFactory()
+	options.DBName = "test_db"
+	vdb := makeVCoordinationDatabase()
+	vdb.HostList = []string{"192.0.2.1", "192.0.2.2"}
+
+	vcc := VClusterCommands{}
+	instructions, err := vcc.produceDropDBInstructions(&vdb, &options)
+	assert.NoError(t, err)
+	var checkProcessesOp *nmaCheckProcessesOp
+	for _, instruction := range instructions {
+		if op, ok := instruction.(*nmaCheckProcessesOp); ok {
+			checkProcessesOp = op
+		}
+	}
+	assert.NotNil(t, checkProcessesOp)
+	assert.Equal(t, vdb.HostList, checkProcessesOp.hosts)
+
+	checkProcessesOp.hostProcesses["192.0.2.1"] = &HostProcesses{Host: "192.0.2.1",
+		Vertica: ProcessInfo{Running: true, PID: 2000, UptimeSeconds: 30}}
+	assert.ErrorContains(t, checkProcessesOp.checkVerticaNotRunning(), "stop it before dropping the database")
+}
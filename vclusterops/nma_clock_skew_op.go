@@ -0,0 +1,146 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+type nmaClockSkewOp struct {
+	opBase
+	maxSkew     time.Duration
+	failOnSkew  bool // if false, only a warning is printed when skew is detected
+	hostToClock map[string]time.Time
+}
+
+type nmaClockResponse struct {
+	Time string `json:"time"`
+}
+
+// makeNMAClockSkewOp will create an op that compares the current time of each
+// host against the time of the first host (the initiator). If failOnSkew is
+// false, an excessive skew will only print a warning.
+func makeNMAClockSkewOp(hosts []string, maxSkewSeconds int, failOnSkew bool) nmaClockSkewOp {
+	op := nmaClockSkewOp{}
+	op.name = "NMAClockSkewOp"
+	op.description = "Check clock skew between hosts"
+	op.hosts = hosts
+	op.maxSkew = time.Duration(maxSkewSeconds) * time.Second
+	op.failOnSkew = failOnSkew
+	op.hostToClock = make(map[string]time.Time)
+	return op
+}
+
+func (op *nmaClockSkewOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildNMAEndpoint("time")
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaClockSkewOp) prepare(execContext *opEngineExecContext) error {
+	if len(op.hosts) == 0 {
+		return fmt.Errorf("[%s] cannot check clock skew with an empty host list", op.name)
+	}
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaClockSkewOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaClockSkewOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaClockSkewOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		var resp nmaClockResponse
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		hostTime, err := time.Parse(time.RFC3339Nano, resp.Time)
+		if err != nil {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] fail to parse time %q returned from host %s: %w",
+				op.name, resp.Time, host, err))
+			continue
+		}
+		op.hostToClock[host] = hostTime
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	return op.checkClockSkew()
+}
+
+// checkClockSkew compares the clock of each host with the clock of the initiator
+func (op *nmaClockSkewOp) checkClockSkew() error {
+	initiator := getInitiator(op.hosts)
+	initiatorTime, ok := op.hostToClock[initiator]
+	if !ok {
+		return fmt.Errorf("[%s] cannot find the time of the initiator host %s", op.name, initiator)
+	}
+
+	var skewedHosts []string
+	for host, hostTime := range op.hostToClock {
+		skew := hostTime.Sub(initiatorTime)
+		if skew < 0 {
+			skew = -skew
+		}
+		op.logger.Info("clock skew against initiator", "host", host, "initiator", initiator, "skew", skew.String())
+		if skew > op.maxSkew {
+			skewedHosts = append(skewedHosts, fmt.Sprintf("%s(%s)", host, skew.Round(time.Millisecond)))
+		}
+	}
+	if len(skewedHosts) == 0 {
+		return nil
+	}
+
+	sort.Strings(skewedHosts)
+	msg := fmt.Sprintf("[%s] clock skew against initiator %s exceeds %s on hosts: %s",
+		op.name, initiator, op.maxSkew, util.ArrayToString(skewedHosts, ", "))
+	if op.failOnSkew {
+		return errors.New(msg)
+	}
+	op.logger.PrintWarning(msg)
+	return nil
+}
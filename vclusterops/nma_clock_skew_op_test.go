@@ -0,0 +1,51 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	hosts := []string{"host1", "host2", "host3"}
+	now := time.Now()
+
+	// all clocks are within the threshold
+	op := makeNMAClockSkewOp(hosts, 5, true)
+	op.logger = vlog.Printer{}
+	op.hostToClock["host1"] = now
+	op.hostToClock["host2"] = now.Add(2 * time.Second)
+	op.hostToClock["host3"] = now.Add(-3 * time.Second)
+	assert.NoError(t, op.checkClockSkew())
+
+	// one clock is out of the threshold
+	op.hostToClock["host3"] = now.Add(-10 * time.Second)
+	err := op.checkClockSkew()
+	assert.ErrorContains(t, err, "host3")
+	assert.NotContains(t, err.Error(), "host2")
+
+	// only a warning is printed when failOnSkew is false
+	op.failOnSkew = false
+	assert.NoError(t, op.checkClockSkew())
+
+	// the time of the initiator must be known
+	delete(op.hostToClock, "host1")
+	assert.Error(t, op.checkClockSkew())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 )
 
 const (
@@ -41,6 +42,29 @@ func makeNMADeleteDirectoriesOp(
 
 	return op, nil
 }
+
+// makeNMADeleteCreatedDirectoriesOp creates an op that deletes the given
+// directories of each host, e.g., the ones created by a failed operation
+func makeNMADeleteCreatedDirectoriesOp(hostDirectories map[string][]string) (nmaDeleteDirectoriesOp, error) {
+	op := nmaDeleteDirectoriesOp{}
+	op.name = delDirOpName
+	op.description = "Delete directories created by the failed operation"
+	op.forceDelete = true
+	op.hostRequestBodyMap = make(map[string]string)
+	for host, dirs := range hostDirectories {
+		p := deleteDirParams{Directories: dirs, ForceDelete: true}
+		dataBytes, err := json.Marshal(p)
+		if err != nil {
+			return op, fmt.Errorf("[%s] fail to marshal request data to JSON string, detail: %w", op.name, err)
+		}
+		op.hostRequestBodyMap[host] = string(dataBytes)
+		op.hosts = append(op.hosts, host)
+	}
+	sort.Strings(op.hosts)
+
+	return op, nil
+}
+
 func makeNMADeleteDirsSandboxOp(
 	forceDelete bool,
 	sandbox bool,
@@ -32,7 +32,20 @@ type nmaDownloadConfigOp struct {
 	catalogPathMap map[string]string
 	endpoint       string
 	fileContent    *string
+	// the checksum of the file on the source host, as computed by its NMA,
+	// against which the downloaded and the uploaded copies are verified.
+	// It is left empty if the NMA does not return it.
+	sourceChecksum *string
 	vdb            *VCoordinationDatabase
+	// the host from which the file was downloaded
+	sourceHost string
+}
+
+// sourceChecksumResponse is the response of the NMA with the size and the
+// checksum of a config file on its host
+type sourceChecksumResponse struct {
+	Size     int    `json:"size"`
+	Checksum string `json:"checksum"`
 }
 
 func makeNMADownloadConfigOp(
@@ -40,6 +53,7 @@ func makeNMADownloadConfigOp(
 	sourceConfigHost []string,
 	endpoint string,
 	fileContent *string,
+	sourceChecksum *string,
 	vdb *VCoordinationDatabase,
 ) nmaDownloadConfigOp {
 	op := nmaDownloadConfigOp{}
@@ -52,6 +66,7 @@ func makeNMADownloadConfigOp(
 		op.description = "Get contents of spread.conf"
 	}
 	op.fileContent = fileContent
+	op.sourceChecksum = sourceChecksum
 	op.vdb = vdb
 
 	return op
@@ -62,6 +77,7 @@ func (op *nmaDownloadConfigOp) setupClusterHTTPRequest(hosts []string) error {
 		httpRequest := hostHTTPRequest{}
 		httpRequest.Method = GetMethod
 		httpRequest.buildNMAEndpoint(op.endpoint)
+		httpRequest.Compressible = true
 
 		catalogPath, ok := op.catalogPathMap[host]
 		if !ok {
@@ -134,11 +150,79 @@ func (op *nmaDownloadConfigOp) prepare(execContext *opEngineExecContext) error {
 }
 
 func (op *nmaDownloadConfigOp) execute(execContext *opEngineExecContext) error {
+	for attempt := 1; ; attempt++ {
+		if err := op.runExecute(execContext); err != nil {
+			return err
+		}
+		if err := op.processResult(execContext); err != nil {
+			return err
+		}
+
+		// only download the file again from the same host if its copy does
+		// not match the file on that host, the requests keep the certificates
+		// that were loaded in them
+		downloadRequest := op.clusterHTTPRequest.RequestCollection[op.sourceHost]
+		matched, err := op.verifySourceChecksum(execContext, downloadRequest)
+		if err != nil || matched {
+			return err
+		}
+		if attempt == maxConfigTransferAttempts {
+			return fmt.Errorf("[%s] the config file downloaded from host %s does not match the source after %d attempts",
+				op.name, op.sourceHost, attempt)
+		}
+		op.logger.PrintWarning("[%s] the config file downloaded from host %s does not match the source, "+
+			"downloading it again", op.name, op.sourceHost)
+		op.clusterHTTPRequest.RequestCollection = map[string]hostHTTPRequest{op.sourceHost: downloadRequest}
+	}
+}
+
+// verifySourceChecksum gets the checksum of the file from the NMA of the source
+// host, and returns true if the downloaded content matches it. The checksum
+// is optional: if the NMA does not return it, the download is not verified
+// and the uploaded copies are compared with the checksum of the downloaded
+// content instead.
+func (op *nmaDownloadConfigOp) verifySourceChecksum(execContext *opEngineExecContext,
+	downloadRequest hostHTTPRequest) (bool, error) {
+	*op.sourceChecksum = ""
+	checksumRequest := downloadRequest
+	checksumRequest.buildNMAEndpoint(op.endpoint + "/checksum")
+	checksumRequest.Compressible = false
+	op.clusterHTTPRequest.RequestCollection = map[string]hostHTTPRequest{op.sourceHost: checksumRequest}
 	if err := op.runExecute(execContext); err != nil {
-		return err
+		return false, err
+	}
+
+	result, ok := op.clusterHTTPRequest.ResultCollection[op.sourceHost]
+	if !ok {
+		return false, fmt.Errorf("[%s] no checksum result from host %s", op.name, op.sourceHost)
+	}
+	if result.isNotFound() {
+		op.logger.Info("the NMA does not return the checksum of the config file, skipping its verification",
+			"host", op.sourceHost)
+		return true, nil
+	}
+	if !result.isPassing() {
+		op.logger.PrintWarning("[%s] fail to get the checksum of the config file from host %s, "+
+			"skipping its verification: %v", op.name, op.sourceHost, result.err)
+		return true, nil
+	}
+	// e.g., {"size": 1024, "checksum": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}
+	response := sourceChecksumResponse{}
+	if err := op.parseAndCheckResponse(op.sourceHost, result.content, &response); err != nil {
+		op.logger.PrintWarning("[%s] fail to parse the checksum of the config file from host %s, "+
+			"skipping its verification: %v", op.name, op.sourceHost, err)
+		return true, nil
 	}
 
-	return op.processResult(execContext)
+	size, checksum := len(*op.fileContent), computeChecksum([]byte(*op.fileContent))
+	if size != response.Size || checksum != response.Checksum {
+		op.logger.Info("downloaded config file verification failed", "host", op.sourceHost,
+			"expected size", response.Size, "size", size, "expected checksum", response.Checksum, "checksum", checksum)
+		return false, nil
+	}
+	*op.sourceChecksum = response.Checksum
+	op.logger.Info("downloaded config file verified", "host", op.sourceHost, "size", size, "checksum", checksum)
+	return true, nil
 }
 
 func (op *nmaDownloadConfigOp) finalize(_ *opEngineExecContext) error {
@@ -152,8 +236,9 @@ func (op *nmaDownloadConfigOp) processResult(_ *opEngineExecContext) error {
 		op.logger.Info("Download config file result",
 			"op name", op.name, "host", host, "status", result.status.getStatusString())
 		if result.isPassing() {
-			// The content of config file will be stored as content of the response
+			// The content of config file will be stored as content of the response.
 			*op.fileContent = result.content
+			op.sourceHost = host
 			return nil
 		}
 		allErrs = errors.Join(allErrs, result.err)
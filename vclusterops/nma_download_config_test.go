@@ -0,0 +1,116 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// mockConfigSourceAdapter returns the next content of the file to the download
+// requests, and the checksum of the file to the checksum requests
+type mockConfigSourceAdapter struct {
+	host           string
+	contents       []string
+	checksumResult hostHTTPResult
+	downloads      int
+}
+
+func (a *mockConfigSourceAdapter) sendRequest(request *hostHTTPRequest, resultChannel chan<- hostHTTPResult) {
+	if strings.HasSuffix(request.Endpoint, "/checksum") {
+		resultChannel <- a.checksumResult
+		return
+	}
+	content := a.contents[a.downloads]
+	a.downloads++
+	resultChannel <- hostHTTPResult{host: a.host, status: SUCCESS, statusCode: SuccessCode, content: content}
+}
+
+func (a *mockConfigSourceAdapter) generateResult(_ *http.Response) hostHTTPResult {
+	return hostHTTPResult{}
+}
+
+func runMockDownloadConfig(t *testing.T, adapter *mockConfigSourceAdapter) (content, checksum string, err error) {
+	host := adapter.host
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap[host] = &VCoordinationNode{Address: host, IsPrimary: true, State: util.NodeUpState,
+		CatalogPath: "/data/test_db/v_test_db_node0001_catalog/Catalog"}
+	op := makeNMADownloadConfigOp("NMADownloadVerticaConfigOp", nil, verticaConf, &content, &checksum, &vdb)
+	op.setLogger(vlog.Printer{})
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	assert.NoError(t, op.prepare(&execContext))
+	execContext.dispatcher.pool.connections[host] = adapter
+	err = op.execute(&execContext)
+	return content, checksum, err
+}
+
+func TestDownloadConfigSourceChecksum(t *testing.T) {
+	const host = "192.0.2.1"
+	source := "vertica.conf content"
+	checksumResult := hostHTTPResult{host: host, status: SUCCESS, statusCode: SuccessCode,
+		content: fmt.Sprintf(`{"size": %d, "checksum": %q}`, len(source), computeChecksum([]byte(source)))}
+
+	// a corrupted download is downloaded again, and the checksum of the
+	// source host is kept for the upload
+	adapter := &mockConfigSourceAdapter{host: host, contents: []string{"vertica.conf cont", source},
+		checksumResult: checksumResult}
+	content, checksum, err := runMockDownloadConfig(t, adapter)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, adapter.downloads)
+	assert.Equal(t, source, content)
+	assert.Equal(t, computeChecksum([]byte(source)), checksum)
+
+	// the download fails if it never matches the source
+	adapter = &mockConfigSourceAdapter{host: host, contents: []string{"a", "b", "c"}, checksumResult: checksumResult}
+	_, _, err = runMockDownloadConfig(t, adapter)
+	assert.ErrorContains(t, err, "does not match the source after 3 attempts")
+
+	// an older NMA does not return the checksum of the source
+	adapter = &mockConfigSourceAdapter{host: host, contents: []string{source},
+		checksumResult: hostHTTPResult{host: host, status: FAILURE, statusCode: http.StatusNotFound}}
+	content, checksum, err = runMockDownloadConfig(t, adapter)
+	assert.NoError(t, err)
+	assert.Equal(t, source, content)
+	assert.Empty(t, checksum)
+
+	// the transfer does not fail if the optional checksum request fails
+	adapter = &mockConfigSourceAdapter{host: host, contents: []string{source},
+		checksumResult: hostHTTPResult{host: host, status: FAILURE, statusCode: http.StatusInternalServerError,
+			err: errors.New("internal error")}}
+	content, checksum, err = runMockDownloadConfig(t, adapter)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, adapter.downloads)
+	assert.Equal(t, source, content)
+	assert.Empty(t, checksum)
+
+	// nor if its response cannot be parsed
+	adapter = &mockConfigSourceAdapter{host: host, contents: []string{source},
+		checksumResult: hostHTTPResult{host: host, status: SUCCESS, statusCode: SuccessCode, content: "not json"}}
+	content, checksum, err = runMockDownloadConfig(t, adapter)
+	assert.NoError(t, err)
+	assert.Equal(t, source, content)
+	assert.Empty(t, checksum)
+}
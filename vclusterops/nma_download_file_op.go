@@ -37,6 +37,7 @@ const (
 	userStorageType        = 4
 	depotStorageType       = 5
 	catalogSuffix          = "Catalog"
+	segmentShardType       = "Segment"
 	expirationStringLayout = "2006-01-02 15:04:05.999999"
 )
 
@@ -51,6 +52,11 @@ type nmaDownloadFileOp struct {
 	ignoreClusterLease bool
 	forRevive          bool
 	leaseCheckOption   leaseCheckOption
+	// whether revive_db accepts fewer new nodes than old nodes
+	allowFewerNodes bool
+	// if set, the raw content of the file is saved in it instead of being
+	// parsed as a description file
+	fileContent *string
 }
 
 type downloadFileRequestData struct {
@@ -160,6 +166,20 @@ func makeNMADownloadFileOpForRestoreLeaseCheck(newNodes []string, sourceFilePath
 	return op, nil
 }
 
+// makeNMADownloadFileContentOp will create an op that downloads any file from
+// communal storage and saves its content in fileContent
+func makeNMADownloadFileContentOp(hosts []string, sourceFilePath, destinationFilePath string,
+	configurationParameters map[string]string, fileContent *string) (nmaDownloadFileOp, error) {
+	op, err := makeNMADownloadFileOp(hosts, sourceFilePath, destinationFilePath,
+		catalogPath, configurationParameters, nil /*vdb*/)
+	if err != nil {
+		return op, err
+	}
+	op.fileContent = fileContent
+
+	return op, nil
+}
+
 func (op *nmaDownloadFileOp) setupClusterHTTPRequest(hosts []string) error {
 	for _, host := range hosts {
 		httpRequest := hostHTTPRequest{}
@@ -195,21 +215,6 @@ type downloadResponse struct {
 	FileContent string `json:"file_content"`
 }
 
-type fileContent struct {
-	ClusterLeaseExpiration string `json:"ClusterLeaseExpiration"`
-	NodeList               []struct {
-		Name        string `json:"name"`
-		Address     string `json:"address"`
-		CatalogPath string `json:"catalogPath"`
-		IsPrimary   bool   `json:"isPrimary"`
-	} `json:"Node"`
-	StorageLocations []struct {
-		Name  string `json:"name"`
-		Path  string `json:"path"`
-		Usage int    `json:"usage"`
-	} `json:"StorageLocation"`
-}
-
 func (op *nmaDownloadFileOp) processResult(execContext *opEngineExecContext) error {
 	var allErrs error
 
@@ -232,6 +237,11 @@ func (op *nmaDownloadFileOp) processResult(execContext *opEngineExecContext) err
 				break
 			}
 
+			if op.fileContent != nil {
+				*op.fileContent = response.FileContent
+				return nil
+			}
+
 			// for --display-only, we only need the file content
 			if op.displayOnly && op.forRevive {
 				execContext.dbInfo = response.FileContent
@@ -239,7 +249,7 @@ func (op *nmaDownloadFileOp) processResult(execContext *opEngineExecContext) err
 			}
 
 			// file content in the response is a string, we need to unmarshal it again
-			descFileContent := fileContent{}
+			descFileContent := DatabaseDescription{}
 			err = op.parseAndCheckResponse(host, response.FileContent, &descFileContent)
 			if err != nil {
 				allErrs = errors.Join(allErrs, err)
@@ -259,12 +269,12 @@ func (op *nmaDownloadFileOp) processResult(execContext *opEngineExecContext) err
 					return nil
 				}
 
-				if len(descFileContent.NodeList) != len(op.newNodes) {
+				if op.isNodeCountMismatch(len(descFileContent.Nodes)) {
 					err := &ReviveDBNodeCountMismatchError{
 						ReviveDBStep:  op.name,
 						FailureHost:   host,
 						NumOfNewNodes: len(op.newNodes),
-						NumOfOldNodes: len(descFileContent.NodeList),
+						NumOfOldNodes: len(descFileContent.Nodes),
 					}
 					allErrs = errors.Join(allErrs, err)
 					break
@@ -282,10 +292,19 @@ func (op *nmaDownloadFileOp) processResult(execContext *opEngineExecContext) err
 	return appendHTTPSFailureError(allErrs)
 }
 
+// isNodeCountMismatch returns true if the new nodes of revive_db cannot
+// replace the given number of old nodes
+func (op *nmaDownloadFileOp) isNodeCountMismatch(oldNodeCount int) bool {
+	if op.allowFewerNodes {
+		return len(op.newNodes) > oldNodeCount
+	}
+	return len(op.newNodes) != oldNodeCount
+}
+
 // buildVDBFromClusterConfig can build a vdb using cluster_config.json
-func (op *nmaDownloadFileOp) buildVDBFromClusterConfig(descFileContent fileContent) error {
+func (op *nmaDownloadFileOp) buildVDBFromClusterConfig(descFileContent DatabaseDescription) error {
 	op.vdb.HostNodeMap = makeVHostNodeMap()
-	for _, node := range descFileContent.NodeList {
+	for _, node := range descFileContent.Nodes {
 		vNode := makeVCoordinationNode()
 		vNode.Name = node.Name
 		vNode.Address = node.Address
@@ -323,6 +342,16 @@ func (op *nmaDownloadFileOp) buildVDBFromClusterConfig(descFileContent fileConte
 		}
 	}
 
+	// the segment shards are kept to remap them when revive_db
+	// revives fewer nodes than the database has
+	op.vdb.ShardNames = nil
+	for _, shard := range descFileContent.Shards {
+		if shard.ShardType == segmentShardType {
+			op.vdb.ShardNames = append(op.vdb.ShardNames, shard.Name)
+		}
+	}
+	op.vdb.NumShards = len(op.vdb.ShardNames)
+
 	return nil
 }
 
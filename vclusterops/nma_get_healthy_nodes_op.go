@@ -35,6 +35,8 @@ func makeNMAGetHealthyNodesOp(hosts []string,
 	op.description = "Get healthy nodes"
 	op.hosts = hosts
 	op.vdb = vdb
+	// an unreachable host is reported as unhealthy
+	op.skipQuarantinedHosts = true
 	return op
 }
 
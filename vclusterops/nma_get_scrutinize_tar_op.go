@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/vertica/vcluster/vclusterops/util"
@@ -26,6 +27,8 @@ import (
 type nmaGetScrutinizeTarOp struct {
 	scrutinizeOpBase
 	useInitiator bool
+	// the tarball file of each host, that the response body is streamed to
+	hostTarballMap map[string]*os.File
 }
 
 func makeNMAGetScrutinizeTarOp(
@@ -99,24 +102,71 @@ func (op *nmaGetScrutinizeTarOp) prepare(execContext *opEngineExecContext) error
 		op.hosts = []string{host}
 	}
 
-	hostToFilePathsMap := map[string]string{}
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+// openTarballs creates the tarball file of each host under the output directory
+func (op *nmaGetScrutinizeTarOp) openTarballs() (map[string]io.Writer, error) {
+	op.hostTarballMap = make(map[string]*os.File)
+	hostToWriterMap := make(map[string]io.Writer)
 	for _, host := range op.hosts {
-		hostToFilePathsMap[host] = fmt.Sprintf("%s/%s/%s-%s.tgz",
+		filePath := fmt.Sprintf("%s/%s/%s-%s.tgz",
 			scrutinizeRemoteOutputPath,
 			op.id,
 			op.hostNodeNameMap[host],
 			op.batch)
+		file, err := os.Create(filePath)
+		if err != nil {
+			op.closeTarballs(op.hosts)
+			return nil, fmt.Errorf("fail to create the tarball file of host %s: %w", host, err)
+		}
+		op.hostTarballMap[host] = file
+		hostToWriterMap[host] = file
 	}
-	execContext.dispatcher.setupForDownload(op.hosts, hostToFilePathsMap)
+	return hostToWriterMap, nil
+}
 
-	return op.setupClusterHTTPRequest(op.hosts)
+// closeTarballs closes the tarball files, and removes the ones of the given
+// hosts, which did not return a tarball
+func (op *nmaGetScrutinizeTarOp) closeTarballs(failedHosts []string) {
+	for _, file := range op.hostTarballMap {
+		if err := file.Close(); err != nil {
+			op.logger.Error(err, "fail to close the tarball file", "File", file.Name())
+		}
+	}
+	for _, host := range failedHosts {
+		if file, ok := op.hostTarballMap[host]; ok {
+			if err := os.Remove(file.Name()); err != nil {
+				op.logger.Error(err, "fail to remove the tarball file", "File", file.Name())
+			}
+		}
+	}
+	op.hostTarballMap = nil
 }
 
 func (op *nmaGetScrutinizeTarOp) execute(execContext *opEngineExecContext) error {
+	// the tarballs can be several GB, so they are streamed to files
+	// instead of being read into memory. The files are only opened
+	// right before the requests are sent, so that they are always closed.
+	hostToWriterMap, err := op.openTarballs()
+	if err != nil {
+		return err
+	}
+	execContext.dispatcher.setupForStreaming(op.hosts, hostToWriterMap)
+
 	if err := op.runExecute(execContext); err != nil {
+		op.closeTarballs(op.hosts)
 		return err
 	}
 
+	var failedHosts []string
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		if !result.isPassing() {
+			failedHosts = append(failedHosts, host)
+		}
+	}
+	op.closeTarballs(failedHosts)
+
 	return op.processResult(execContext)
 }
 
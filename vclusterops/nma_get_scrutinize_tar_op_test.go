@@ -0,0 +1,55 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrutinizeTarballsAreStreamedToFiles(t *testing.T) {
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+	hostNodeNameMap := map[string]string{
+		"192.168.1.101": "v_test_db_node0001",
+		"192.168.1.102": "v_test_db_node0002",
+	}
+	id := "VerticaScrutinize.TestTarballs"
+	op, err := makeNMAGetScrutinizeTarOp(id, scrutinizeBatchNormal, hosts, hostNodeNameMap)
+	assert.NoError(t, err)
+	outputDir := fmt.Sprintf("%s/%s", scrutinizeRemoteOutputPath, id)
+	defer os.RemoveAll(outputDir)
+
+	hostToWriterMap, err := op.openTarballs()
+	assert.NoError(t, err)
+	assert.Len(t, hostToWriterMap, 2)
+	for _, host := range hosts {
+		_, err = io.WriteString(hostToWriterMap[host], "tarball of "+host)
+		assert.NoError(t, err)
+	}
+
+	// the tarball of a host that failed is removed
+	op.closeTarballs([]string{"192.168.1.102"})
+	content, err := os.ReadFile(outputDir + "/v_test_db_node0001-normal.tgz")
+	assert.NoError(t, err)
+	assert.Equal(t, "tarball of 192.168.1.101", string(content))
+	_, err = os.Stat(outputDir + "/v_test_db_node0002-normal.tgz")
+	assert.True(t, os.IsNotExist(err))
+	assert.Nil(t, op.hostTarballMap)
+}
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"errors"
+	"fmt"
 )
 
 type nmaHealthOp struct {
@@ -72,7 +73,9 @@ func (op *nmaHealthOp) processResult(_ *opEngineExecContext) error {
 				return errors.Join(allErrs, err)
 			}
 		} else {
-			allErrs = errors.Join(allErrs, result.err)
+			// the NMA may be dead, in which case VManageNMA can start it
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] NMA is not healthy on host %s, "+
+				"make sure that it is running: %w", op.name, host, result.err))
 		}
 	}
 
@@ -0,0 +1,97 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+)
+
+type nmaKillVerticaOp struct {
+	opBase
+}
+
+type nmaKillVerticaResponse struct {
+	Killed bool `json:"killed"`
+	PID    int  `json:"pid"`
+}
+
+// makeNMAKillVerticaOp will create an op that asks the NMA to send SIGKILL
+// to the vertica process of each host
+func makeNMAKillVerticaOp(hosts []string) nmaKillVerticaOp {
+	op := nmaKillVerticaOp{}
+	op.name = "NMAKillVerticaOp"
+	op.description = "Kill vertica processes"
+	op.hosts = hosts
+	return op
+}
+
+func (op *nmaKillVerticaOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildNMAEndpoint("processes/vertica/kill")
+		httpRequest.RequestData = `{"signal": "SIGKILL"}`
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaKillVerticaOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaKillVerticaOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaKillVerticaOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaKillVerticaOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like {"killed": true, "pid": 12345}
+		// killed is false if no vertica process was running on the host
+		resp := nmaKillVerticaResponse{}
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		if resp.Killed {
+			op.logger.PrintInfo("[%s] killed vertica process %d on host %s", op.name, resp.PID, host)
+		} else {
+			op.logger.PrintInfo("[%s] no vertica process is running on host %s", op.name, host)
+		}
+	}
+
+	return allErrs
+}
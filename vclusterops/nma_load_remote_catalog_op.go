@@ -30,6 +30,10 @@ type nmaLoadRemoteCatalogOp struct {
 	timeout                 uint
 	primaryNodeCount        uint
 	restorePoint            *RestorePointPolicy
+	// the nodes to drop from the catalog, and the shards that the remaining
+	// nodes subscribe to, when the database is revived on fewer nodes
+	droppedNodes     []string
+	shardAssignments map[string][]string
 }
 
 type loadRemoteCatalogRequestData struct {
@@ -46,6 +50,9 @@ type loadRemoteCatalogRequestData struct {
 	RestorePointArchive string              `json:"restore_point_archive,omitempty"`
 	RestorePointIndex   int                 `json:"restore_point_index,omitempty"`
 	RestorePointID      string              `json:"restore_point_id,omitempty"`
+	Namespace           string              `json:"namespace,omitempty"`
+	DroppedNodes        []string            `json:"dropped_nodes,omitempty"`
+	ShardAssignments    map[string][]string `json:"shard_assignments,omitempty"`
 }
 
 func makeNMALoadRemoteCatalogOp(oldHosts []string, configurationParameters map[string]string,
@@ -105,6 +112,11 @@ func (op *nmaLoadRemoteCatalogOp) setupRequestBody(execContext *opEngineExecCont
 			requestData.RestorePointArchive = op.restorePoint.Archive
 			requestData.RestorePointIndex = op.restorePoint.Index
 			requestData.RestorePointID = op.restorePoint.ID
+			requestData.Namespace = op.restorePoint.Namespace
+		}
+		if len(op.droppedNodes) > 0 {
+			requestData.DroppedNodes = op.droppedNodes
+			requestData.ShardAssignments = op.shardAssignments
 		}
 
 		dataBytes, err := json.Marshal(requestData)
@@ -175,7 +187,7 @@ func (op *nmaLoadRemoteCatalogOp) processResult(_ *opEngineExecContext) error {
 				continue
 			}
 
-			if op.vdb.HostNodeMap[host].IsPrimary {
+			if vnode, ok := op.vdb.HostNodeMap[host]; ok && vnode.IsPrimary {
 				successPrimaryNodeCount++
 			}
 			continue
@@ -187,7 +199,9 @@ func (op *nmaLoadRemoteCatalogOp) processResult(_ *opEngineExecContext) error {
 
 	// quorum check
 	if !op.hasQuorum(successPrimaryNodeCount, op.primaryNodeCount) {
-		err := fmt.Errorf("[%s] fail to load catalog on enough primary nodes. Success count: %d", op.name, successPrimaryNodeCount)
+		err := &NoQuorumError{
+			Detail: fmt.Sprintf("[%s] fail to load catalog on enough primary nodes. Success count: %d", op.name, successPrimaryNodeCount),
+		}
 		op.logger.Error(err, "fail to load catalog, detail")
 		allErrs = errors.Join(allErrs, err)
 		return allErrs
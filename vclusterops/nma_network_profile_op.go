@@ -18,12 +18,15 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+	"net"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
 
 type nmaNetworkProfileOp struct {
 	opBase
+	// optional, the network in which the addresses of the profiles must be
+	subnet *net.IPNet
 }
 
 func makeNMANetworkProfileOp(hosts []string) nmaNetworkProfileOp {
@@ -34,12 +37,25 @@ func makeNMANetworkProfileOp(hosts []string) nmaNetworkProfileOp {
 	return op
 }
 
+// makeNMANetworkProfileOpForSubnet will create an op that gets the network
+// profile of the interface of each host that is in the given network, for
+// hosts with several network interfaces
+func makeNMANetworkProfileOpForSubnet(hosts []string, subnet *net.IPNet) nmaNetworkProfileOp {
+	op := makeNMANetworkProfileOp(hosts)
+	op.description = fmt.Sprintf("Get network profile of cluster in subnet %s", subnet)
+	op.subnet = subnet
+	return op
+}
+
 func (op *nmaNetworkProfileOp) setupClusterHTTPRequest(hosts []string) error {
 	for _, host := range hosts {
 		httpRequest := hostHTTPRequest{}
 		httpRequest.Method = GetMethod
 		httpRequest.buildNMAEndpoint("network-profiles")
 		httpRequest.QueryParams = map[string]string{"broadcast-hint": host}
+		if op.subnet != nil {
+			httpRequest.QueryParams["subnet"] = op.subnet.String()
+		}
 
 		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
 	}
@@ -117,6 +133,15 @@ func (op *nmaNetworkProfileOp) parseResponse(host, resultContent string) (networ
 
 	// check whether any field is empty
 	err = util.CheckMissingFields(responseObj)
+	if err != nil {
+		return responseObj, err
+	}
+
+	// an NMA that does not know the subnet returns the profile of the hint
+	if op.subnet != nil && !op.subnet.Contains(net.ParseIP(responseObj.Address)) {
+		return responseObj, fmt.Errorf("host %s has no network interface in subnet %s, the address of its profile is %s",
+			host, op.subnet, responseObj.Address)
+	}
 
-	return responseObj, err
+	return responseObj, nil
 }
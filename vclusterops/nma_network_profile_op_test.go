@@ -0,0 +1,57 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestNetworkProfileInSubnet(t *testing.T) {
+	_, subnet, err := net.ParseCIDR("10.20.0.0/16")
+	assert.NoError(t, err)
+	op := makeNMANetworkProfileOpForSubnet([]string{"192.168.1.101"}, subnet)
+	op.setLogger(vlog.Printer{})
+	op.setupBasicInfo()
+
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	request := op.clusterHTTPRequest.RequestCollection["192.168.1.101"]
+	assert.Equal(t, map[string]string{"broadcast-hint": "192.168.1.101", "subnet": "10.20.0.0/16"}, request.QueryParams)
+
+	profile, err := op.parseResponse("192.168.1.101", `{"name": "eth1", "address": "10.20.1.101",
+		"subnet": "10.20.0.0/16", "netmask": "255.255.0.0", "broadcast": "10.20.255.255"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.20.1.101", profile.Address)
+
+	// the profile of an interface in another network is rejected
+	_, err = op.parseResponse("192.168.1.101", `{"name": "eth0", "address": "192.168.1.101",
+		"subnet": "192.168.0.0/16", "netmask": "255.255.0.0", "broadcast": "192.168.255.255"}`)
+	assert.ErrorContains(t, err, "has no network interface in subnet 10.20.0.0/16")
+}
+
+func TestValidateSubnet(t *testing.T) {
+	opt := DatabaseOptions{}
+	assert.NoError(t, opt.validateSubnet())
+	opt.Subnet = "10.20.0.0/16"
+	assert.NoError(t, opt.validateSubnet())
+	opt.Subnet = "fd00::/64"
+	assert.NoError(t, opt.validateSubnet())
+	opt.Subnet = "10.20.0.0"
+	assert.ErrorContains(t, opt.validateSubnet(), "CIDR notation")
+}
@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"golang.org/x/exp/maps"
 )
@@ -28,25 +30,56 @@ type nmaPrepareDirectoriesOp struct {
 	hostRequestBodyMap map[string]string
 	forceCleanup       bool
 	forRevive          bool
+	// succeed if the directories already exist with the correct
+	// owner and permissions, instead of failing
+	reuseExisting bool
+	// change the owner of existing directories that have a wrong owner
+	fixOwnership bool
+	// host -> directories created by this op, used to clean up after a failure
+	createdDirectories map[string][]string
 }
 
+// statuses of a directory in the response of the NMA directories/prepare endpoint
+const (
+	dirStatusCreated    = "created"
+	dirStatusExists     = "exists"
+	dirStatusOwnerFixed = "owner_fixed"
+	// followed by the current owner and permissions, e.g., "wrong_owner root:root 0755"
+	dirStatusWrongOwner = "wrong_owner"
+)
+
 type prepareDirectoriesRequestData struct {
-	CatalogPath          string   `json:"catalog_path"`
+	CatalogPath          string   `json:"catalog_path,omitempty"`
 	DepotPath            string   `json:"depot_path,omitempty"`
 	StorageLocations     []string `json:"storage_locations,omitempty"`
 	UserStorageLocations []string `json:"user_storage_locations,omitempty"`
 	ForceCleanup         bool     `json:"force_cleanup"`
 	ForRevive            bool     `json:"for_revive"`
 	IgnoreParent         bool     `json:"ignore_parent"`
+	ReuseExisting        bool     `json:"reuse_existing,omitempty"`
+	FixOwnership         bool     `json:"fix_ownership,omitempty"`
 }
 
 func makeNMAPrepareDirectoriesOp(hostNodeMap vHostNodeMap,
 	forceCleanup, forRevive bool) (nmaPrepareDirectoriesOp, error) {
+	return makeNMAPrepareDirectoriesOpWithReuse(hostNodeMap, forceCleanup, forRevive,
+		false /*reuse existing*/, false /*fix ownership*/)
+}
+
+// makeNMAPrepareDirectoriesOpWithReuse creates an op that can reuse the directories
+// left by a previous attempt, as long as they have the correct owner and
+// permissions. If fixOwnership is true, the NMA changes the owner of the
+// existing directories that have a wrong owner; otherwise they are reported.
+func makeNMAPrepareDirectoriesOpWithReuse(hostNodeMap vHostNodeMap,
+	forceCleanup, forRevive, reuseExisting, fixOwnership bool) (nmaPrepareDirectoriesOp, error) {
 	op := nmaPrepareDirectoriesOp{}
 	op.name = "NMAPrepareDirectoriesOp"
 	op.description = "Create necessary directories on Vertica hosts"
 	op.forceCleanup = forceCleanup
 	op.forRevive = forRevive
+	op.reuseExisting = reuseExisting
+	op.fixOwnership = fixOwnership
+	op.createdDirectories = make(map[string][]string)
 
 	err := op.setupRequestBody(hostNodeMap)
 	if err != nil {
@@ -58,6 +91,30 @@ func makeNMAPrepareDirectoriesOp(hostNodeMap vHostNodeMap,
 	return op, nil
 }
 
+// makeNMAPrepareStorageLocationOp creates an op that only creates the directory
+// of a new user storage location on the given hosts. The catalog, data and depot
+// directories of the existing nodes are left untouched.
+func makeNMAPrepareStorageLocationOp(hosts []string, locationPath string) (nmaPrepareDirectoriesOp, error) {
+	op := nmaPrepareDirectoriesOp{}
+	op.name = "NMAPrepareStorageLocationOp"
+	op.description = "Create storage location directory on Vertica hosts"
+	op.hosts = hosts
+	op.hostRequestBodyMap = make(map[string]string)
+	op.createdDirectories = make(map[string][]string)
+
+	prepareDirData := prepareDirectoriesRequestData{}
+	prepareDirData.UserStorageLocations = []string{locationPath}
+	dataBytes, err := json.Marshal(prepareDirData)
+	if err != nil {
+		return op, fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	for _, host := range hosts {
+		op.hostRequestBodyMap[host] = string(dataBytes)
+	}
+
+	return op, nil
+}
+
 func (op *nmaPrepareDirectoriesOp) setupRequestBody(hostNodeMap vHostNodeMap) error {
 	op.hostRequestBodyMap = make(map[string]string)
 
@@ -70,6 +127,8 @@ func (op *nmaPrepareDirectoriesOp) setupRequestBody(hostNodeMap vHostNodeMap) er
 		prepareDirData.ForceCleanup = op.forceCleanup
 		prepareDirData.ForRevive = op.forRevive
 		prepareDirData.IgnoreParent = false
+		prepareDirData.ReuseExisting = op.reuseExisting
+		prepareDirData.FixOwnership = op.fixOwnership
 
 		dataBytes, err := json.Marshal(prepareDirData)
 		if err != nil {
@@ -125,10 +184,14 @@ func (op *nmaPrepareDirectoriesOp) processResult(_ *opEngineExecContext) error {
 			//  '/data/good/v_good_node0003_data': 'created',
 			//  '/data/good/v_good_node0003_depot': 'created',
 			//  '/opt/vertica/config/logrotate': 'created'}
-			_, err := op.parseAndCheckMapResponse(host, result.content)
+			// when existing directories are reused, the status can also be
+			// 'exists', 'owner_fixed', or 'wrong_owner <owner> <permissions>'
+			resp, err := op.parseAndCheckMapResponse(host, result.content)
 			if err != nil {
 				allErrs = errors.Join(allErrs, err)
+				continue
 			}
+			allErrs = errors.Join(allErrs, op.checkDirectoryStatuses(host, resp))
 		} else {
 			allErrs = errors.Join(allErrs, result.err)
 		}
@@ -136,3 +199,29 @@ func (op *nmaPrepareDirectoriesOp) processResult(_ *opEngineExecContext) error {
 
 	return allErrs
 }
+
+// checkDirectoryStatuses records the directories that were created, logs the ones
+// that were reused or fixed, and returns an error listing the directories that
+// have a wrong owner
+func (op *nmaPrepareDirectoriesOp) checkDirectoryStatuses(host string, resp map[string]string) error {
+	var wrongOwners []string
+	for dir, status := range resp {
+		switch {
+		case status == dirStatusCreated:
+			op.createdDirectories[host] = append(op.createdDirectories[host], dir)
+		case status == dirStatusExists:
+			op.logger.Info("reusing existing directory", "host", host, "directory", dir)
+		case status == dirStatusOwnerFixed:
+			op.logger.PrintInfo("[%s] fixed the owner of directory %s on host %s", op.name, dir, host)
+		case strings.HasPrefix(status, dirStatusWrongOwner):
+			detail := strings.TrimSpace(strings.TrimPrefix(status, dirStatusWrongOwner))
+			wrongOwners = append(wrongOwners, fmt.Sprintf("%s (%s)", dir, detail))
+		}
+	}
+	if len(wrongOwners) == 0 {
+		return nil
+	}
+	sort.Strings(wrongOwners)
+	return fmt.Errorf("[%s] directories with a wrong owner on host %s: %s, fix their ownership and retry",
+		op.name, host, strings.Join(wrongOwners, ", "))
+}
@@ -0,0 +1,71 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckDirectoryStatuses(t *testing.T) {
+	hostNodeMap := makeVHostNodeMap()
+	hostNodeMap["192.0.2.1"] = &VCoordinationNode{CatalogPath: "/data/test_db/v_test_db_node0001_catalog/Catalog"}
+	op, err := makeNMAPrepareDirectoriesOpWithReuse(hostNodeMap, false, false, true, false)
+	assert.NoError(t, err)
+	assert.Contains(t, op.hostRequestBodyMap["192.0.2.1"], `"reuse_existing":true`)
+
+	// created, reused, and fixed directories are fine
+	err = op.checkDirectoryStatuses("192.0.2.1", map[string]string{
+		"/data/test_db/v_test_db_node0001_catalog": dirStatusExists,
+		"/data/test_db/v_test_db_node0001_data":    dirStatusCreated,
+		"/data/test_db/v_test_db_node0001_depot":   dirStatusOwnerFixed,
+	})
+	assert.NoError(t, err)
+
+	// directories with a wrong owner are reported
+	err = op.checkDirectoryStatuses("192.0.2.1", map[string]string{
+		"/data/test_db/v_test_db_node0001_catalog": dirStatusExists,
+		"/data/test_db/v_test_db_node0001_data":    "wrong_owner root:root 0700",
+	})
+	assert.ErrorContains(t, err, "/data/test_db/v_test_db_node0001_data (root:root 0700)")
+}
+
+func TestGetCreatedDirectories(t *testing.T) {
+	hostNodeMap := makeVHostNodeMap()
+	hostNodeMap["192.0.2.1"] = &VCoordinationNode{CatalogPath: "/data/test_db/v_test_db_node0001_catalog/Catalog"}
+	op, err := makeNMAPrepareDirectoriesOpWithReuse(hostNodeMap, false, false, true, false)
+	assert.NoError(t, err)
+
+	// only the directories created by the op are returned
+	err = op.checkDirectoryStatuses("192.0.2.1", map[string]string{
+		"/data/test_db/v_test_db_node0001_catalog": dirStatusExists,
+		"/data/test_db/v_test_db_node0001_data":    dirStatusCreated,
+		"/data/test_db/v_test_db_node0001_depot":   dirStatusCreated,
+	})
+	assert.NoError(t, err)
+	nmaHealthOp := makeNMAHealthOp([]string{"192.0.2.1"})
+	hostDirectories := getCreatedDirectories([]clusterOp{&nmaHealthOp, &op})
+	assert.Equal(t, map[string][]string{
+		"192.0.2.1": {"/data/test_db/v_test_db_node0001_data", "/data/test_db/v_test_db_node0001_depot"},
+	}, hostDirectories)
+
+	// the delete op only targets the hosts with created directories
+	deleteOp, err := makeNMADeleteCreatedDirectoriesOp(hostDirectories)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1"}, deleteOp.hosts)
+	assert.Contains(t, deleteOp.hostRequestBodyMap["192.0.2.1"], `"force_delete":true`)
+}
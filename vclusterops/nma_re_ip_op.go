@@ -146,8 +146,15 @@ func (op *nmaReIPOp) trimReIPList(execContext *opEngineExecContext) error {
 	if len(nodesToTrim) > 0 {
 		// throw an error if not automatically trim the re-ip list
 		if !op.trimReIPData {
-			return fmt.Errorf("[%s] the following nodes from the re-ip list do not exist in the catalog: %+v",
-				op.name, nodesToTrim)
+			nodeNames := make([]string, 0, len(nodesToTrim))
+			for nodeName := range nodesToTrim {
+				nodeNames = append(nodeNames, nodeName)
+			}
+			return &NodeNotFoundError{
+				Detail: fmt.Sprintf("[%s] the following nodes from the re-ip list do not exist in the catalog: %+v",
+					op.name, nodesToTrim),
+				Nodes: nodeNames,
+			}
 		}
 
 		// otherwise, trim the re-ip list
@@ -218,7 +225,9 @@ func (op *nmaReIPOp) prepare(execContext *opEngineExecContext) error {
 
 	// quorum check
 	if !op.hasQuorum(uint(len(op.hosts)), op.primaryNodeCount) {
-		return fmt.Errorf("failed quorum check, not enough primaries exist with: %d", len(op.hosts))
+		return &NoQuorumError{
+			Detail: fmt.Sprintf("failed quorum check, not enough primaries exist with: %d", len(op.hosts)),
+		}
 	}
 
 	// update re-ip list
@@ -288,7 +297,9 @@ func (op *nmaReIPOp) processResult(_ *opEngineExecContext) error {
 	// quorum check
 	if !op.hasQuorum(successCount, op.primaryNodeCount) {
 		// VER-88054 rollback the commits
-		err := fmt.Errorf("failed quroum check for re-ip update. Success count: %d", successCount)
+		err := &NoQuorumError{
+			Detail: fmt.Sprintf("failed quroum check for re-ip update. Success count: %d", successCount),
+		}
 		allErrs = errors.Join(allErrs, err)
 	}
 
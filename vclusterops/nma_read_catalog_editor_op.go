@@ -31,6 +31,8 @@ type nmaReadCatalogEditorOp struct {
 	catalogPathMap map[string]string
 
 	firstStartAfterRevive bool // used for start_db only
+
+	hostToVersions map[string]nmaVersions // if not nil, filled with the catalog versions of each host
 }
 
 // makeNMAReadCatalogEditorOpWithInitiator creates an op to read catalog editor info.
@@ -210,6 +212,9 @@ func (op *nmaReadCatalogEditorOp) processResult(execContext *opEngineExecContext
 			}
 			nmaVDB.HostNodeMap = hostNodeMap
 			nmaVDB.PrimaryNodeCount = primaryNodeCount
+			if op.hostToVersions != nil {
+				op.hostToVersions[host] = nmaVDB.Versions
+			}
 
 			// find hosts with latest catalog version
 			globalVersion, err := nmaVDB.Versions.Global.Int64()
@@ -234,10 +239,8 @@ func (op *nmaReadCatalogEditorOp) processResult(execContext *opEngineExecContext
 			// - we may send request to a secondary node right after revive
 			// - users may delete the catalog files
 			if !op.firstStartAfterRevive {
-				rfcError := &rfc7807.VProblem{}
-				if ok := errors.As(result.err, &rfcError); ok &&
-					(rfcError.ProblemID == rfc7807.CECatalogContentDirEmptyError ||
-						rfcError.ProblemID == rfc7807.CECatalogContentDirNotExistError) {
+				if result.isProblemInstanceOf(rfc7807.CECatalogContentDirEmptyError) ||
+					result.isProblemInstanceOf(rfc7807.CECatalogContentDirNotExistError) {
 					continue
 				}
 			}
@@ -0,0 +1,206 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// NMAAction is the change VManageNMA makes to the NMA service of the hosts
+type NMAAction string
+
+const (
+	NMAStart NMAAction = "start"
+	NMAStop  NMAAction = "stop"
+)
+
+const (
+	// the systemd unit that runs the NMA
+	defaultNMAServiceName = "node_management_agent"
+	// the time to wait for the NMA to be healthy after it is started
+	defaultNMAStartTimeoutSeconds = 60
+	nmaHealthPollingInterval      = 2 * time.Second
+)
+
+// a systemd unit name, so that it can safely be given to systemctl
+var nmaServiceNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.@-]+$`)
+
+// this variable is for unit test, be careful to modify it
+var runLocalCommandFn = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+type VManageNMAOptions struct {
+	DatabaseOptions
+	Action NMAAction
+	// the systemd unit that runs the NMA on the hosts
+	ServiceName string
+	// timeout, in seconds, to wait for the NMA to be healthy after it is started
+	StartTimeout int
+}
+
+func VManageNMAOptionsFactory() VManageNMAOptions {
+	options := VManageNMAOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VManageNMAOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.ServiceName = defaultNMAServiceName
+	options.StartTimeout = defaultNMAStartTimeoutSeconds
+}
+
+func (options *VManageNMAOptions) validateParseOptions(logger vlog.Printer) error {
+	logger.Info("Validating options", "command", commandManageNMA)
+	// the database does not need to exist, only the hosts are needed
+	if len(options.RawHosts) == 0 {
+		return errors.New("must specify a host or host list")
+	}
+	if options.Action != NMAStart && options.Action != NMAStop {
+		return fmt.Errorf("invalid NMA action %q: must be '%s' or '%s'", options.Action, NMAStart, NMAStop)
+	}
+	if !nmaServiceNameRegexp.MatchString(options.ServiceName) {
+		return fmt.Errorf("invalid NMA service name %q", options.ServiceName)
+	}
+	if options.StartTimeout < 0 {
+		return fmt.Errorf("the NMA start timeout cannot be negative, got %d", options.StartTimeout)
+	}
+	return options.setUsePassword(logger)
+}
+
+// VManageNMA starts or stops the Node Management Agent on the hosts, so that
+// vcluster can recover from dead NMAs. The NMA service of the host vcluster
+// runs on is controlled with systemctl. The other hosts are asked through
+// their Vertica HTTPS service, which must be up. When starting, the hosts
+// whose NMA is already healthy are skipped, and the NMA of the other hosts
+// must become healthy before the timeout.
+func (vcc VClusterCommands) VManageNMA(options *VManageNMAOptions) (err error) {
+	defer vcc.audit(commandManageNMA, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	hosts := options.Hosts
+	if options.Action == NMAStart {
+		healthyHosts := vcc.getNMAHealthyHosts(hosts, &options.DatabaseOptions)
+		hosts = util.SliceDiff(hosts, healthyHosts)
+		if len(hosts) == 0 {
+			vcc.Log.PrintInfo("NMA is already running on all hosts")
+			return nil
+		}
+	}
+
+	localHosts, remoteHosts, err := splitLocalHosts(hosts)
+	if err != nil {
+		return err
+	}
+	// all the local addresses are the same host
+	if len(localHosts) > 0 {
+		err = controlLocalNMA(options.Action, options.ServiceName)
+		if err != nil {
+			return fmt.Errorf("fail to %s NMA on host %s: %w", options.Action, localHosts[0], err)
+		}
+	}
+	if len(remoteHosts) > 0 {
+		httpsControlNMAOp, e := makeHTTPSControlNMAOp(remoteHosts, options.usePassword, options.UserName,
+			options.Password, options.Action, options.ServiceName)
+		if e != nil {
+			return e
+		}
+		err = vcc.runSingleOp(&httpsControlNMAOp, &options.DatabaseOptions,
+			fmt.Sprintf("fail to %s NMA on hosts %v", options.Action, remoteHosts))
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Action == NMAStart {
+		return vcc.waitForNMAHealthy(hosts, options)
+	}
+	return nil
+}
+
+// splitLocalHosts separates the addresses of the host vcluster runs on
+// from the addresses of the other hosts
+func splitLocalHosts(hosts []string) (localHosts, remoteHosts []string, err error) {
+	for _, host := range hosts {
+		local, err := util.IsLocalHost(host)
+		if err != nil {
+			return nil, nil, err
+		}
+		if local {
+			localHosts = append(localHosts, host)
+		} else {
+			remoteHosts = append(remoteHosts, host)
+		}
+	}
+	return localHosts, remoteHosts, nil
+}
+
+// controlLocalNMA starts or stops the NMA service of the host vcluster runs on
+func controlLocalNMA(action NMAAction, serviceName string) error {
+	output, err := runLocalCommandFn("systemctl", string(action), serviceName)
+	if err != nil {
+		return fmt.Errorf("systemctl %s %s failed: %w, output: %s", action, serviceName, err, output)
+	}
+	return nil
+}
+
+// getNMAHealthyHosts returns the hosts whose NMA is healthy
+func (vcc VClusterCommands) getNMAHealthyHosts(hosts []string, options *DatabaseOptions) []string {
+	vdb := makeVCoordinationDatabase()
+	nmaGetHealthyNodesOp := makeNMAGetHealthyNodesOp(hosts, &vdb)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&nmaGetHealthyNodesOp}, &certs)
+	// the op fails if no NMA is healthy, which is not an error here
+	err := clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		vcc.Log.Info("no healthy NMA found", "hosts", hosts, "details", err.Error())
+	}
+	return vdb.HostList
+}
+
+// waitForNMAHealthy polls the NMA of the hosts until all are healthy
+func (vcc VClusterCommands) waitForNMAHealthy(hosts []string, options *VManageNMAOptions) error {
+	deadline := time.Now().Add(time.Duration(options.StartTimeout) * time.Second)
+	for {
+		unhealthyHosts := util.SliceDiff(hosts, vcc.getNMAHealthyHosts(hosts, &options.DatabaseOptions))
+		if len(unhealthyHosts) == 0 {
+			vcc.Log.PrintInfo("NMA is running on hosts %v", hosts)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("NMA is still not healthy on hosts %v after %d seconds", unhealthyHosts, options.StartTimeout)
+		}
+		time.Sleep(nmaHealthPollingInterval)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateManageNMAOptions(t *testing.T) {
+	logger := vlog.Printer{}
+	options := VManageNMAOptionsFactory()
+	assert.ErrorContains(t, options.validateParseOptions(logger), "must specify a host")
+
+	options.RawHosts = []string{"vnode1"}
+	assert.ErrorContains(t, options.validateParseOptions(logger), "invalid NMA action")
+
+	options.Action = NMAStart
+	assert.NoError(t, options.validateParseOptions(logger))
+
+	options.ServiceName = "nma; reboot"
+	assert.ErrorContains(t, options.validateParseOptions(logger), "invalid NMA service name")
+}
+
+func TestControlLocalNMA(t *testing.T) {
+	originalFn := runLocalCommandFn
+	defer func() { runLocalCommandFn = originalFn }()
+
+	var gotArgs []string
+	runLocalCommandFn = func(name string, args ...string) ([]byte, error) {
+		gotArgs = append([]string{name}, args...)
+		return nil, nil
+	}
+	assert.NoError(t, controlLocalNMA(NMAStart, defaultNMAServiceName))
+	assert.Equal(t, []string{"systemctl", "start", defaultNMAServiceName}, gotArgs)
+
+	runLocalCommandFn = func(_ string, _ ...string) ([]byte, error) {
+		return []byte("Unit node_management_agent.service not found."), errors.New("exit status 5")
+	}
+	assert.ErrorContains(t, controlLocalNMA(NMAStop, defaultNMAServiceName), "not found")
+}
+
+func TestSplitLocalHosts(t *testing.T) {
+	localHosts, remoteHosts, err := splitLocalHosts([]string{"127.0.0.1", "192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1"}, localHosts)
+	assert.Equal(t, []string{"192.0.2.1"}, remoteHosts)
+}
+
+func TestControlNMARequest(t *testing.T) {
+	password := "password"
+	op, err := makeHTTPSControlNMAOp([]string{"192.0.2.1"}, true, "dbadmin", &password, NMAStart, defaultNMAServiceName)
+	assert.NoError(t, err)
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	request := op.clusterHTTPRequest.RequestCollection["192.0.2.1"]
+	assert.Equal(t, PostMethod, request.Method)
+	assert.Contains(t, request.Endpoint, "node-management-agent/start")
+	assert.Equal(t, defaultNMAServiceName, request.QueryParams["service"])
+}
@@ -44,6 +44,8 @@ type ShowRestorePointFilterOptions struct {
 	ArchiveID string
 	// Only list restore points with given index
 	ArchiveIndex string
+	// Only list restore points of archives in the given namespace
+	Namespace string
 }
 
 type showRestorePointsRequestData struct {
@@ -55,6 +57,7 @@ type showRestorePointsRequestData struct {
 	EndTimestamp     string            `json:"end_timestamp,omitempty"`
 	ArchiveID        string            `json:"archive_id,omitempty"`
 	ArchiveIndex     string            `json:"archive_index,omitempty"`
+	Namespace        string            `json:"namespace,omitempty"`
 }
 
 // This op is used to show restore points in a database
@@ -95,6 +98,7 @@ func (op *nmaShowRestorePointsOp) setupRequestBody() (map[string]string, error)
 		requestData.EndTimestamp = op.filterOptions.EndTimestamp
 		requestData.ArchiveID = op.filterOptions.ArchiveID
 		requestData.ArchiveIndex = op.filterOptions.ArchiveIndex
+		requestData.Namespace = op.filterOptions.Namespace
 
 		dataBytes, err := json.Marshal(requestData)
 		if err != nil {
@@ -78,4 +78,16 @@ func TestShowRestorePointsRequestBody(t *testing.T) {
 	assert.Contains(t, hostReq, `"archive_index":"`+archiveIndex+`"`)
 	assert.NotContains(t, hostReq, `"start_timestamp"`)
 	assert.NotContains(t, hostReq, `"end_timestamp"`)
+	assert.NotContains(t, hostReq, `"namespace"`)
+
+	op = makeNMAShowRestorePointsOpWithFilterOptions(vlog.Printer{}, []string{hostName},
+		dbName, communalLocation, nil, &ShowRestorePointFilterOptions{
+			ArchiveName: archiveName,
+			Namespace:   "ns1",
+		})
+
+	requestBody, err = op.setupRequestBody()
+	assert.NoError(t, err)
+	hostReq = requestBody[hostName]
+	assert.Contains(t, hostReq, `"namespace":"ns1"`)
 }
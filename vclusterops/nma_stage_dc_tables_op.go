@@ -22,10 +22,14 @@ import (
 
 type nmaStageDCTablesOp struct {
 	scrutinizeOpBase
+	logAgeMaxHours int // The maximum age of DC records in hours to export, 0 for no limit
+	logAgeMinHours int // The minimum age of DC records in hours to export
 }
 
 type stageDCTablesRequestData struct {
-	CatalogPath string `json:"catalog_path"`
+	CatalogPath    string `json:"catalog_path"`
+	LogAgeMaxHours int    `json:"log_max_age_hours,omitempty"`
+	LogAgeMinHours int    `json:"log_min_age_hours,omitempty"`
 }
 
 type stageDCTablesResponseData struct {
@@ -56,11 +60,19 @@ func makeNMAStageDCTablesOp(
 	return op, err
 }
 
+// setTimeRange restricts the exported DC records to the given age range
+func (op *nmaStageDCTablesOp) setTimeRange(logAgeMaxHours, logAgeMinHours int) {
+	op.logAgeMaxHours = logAgeMaxHours
+	op.logAgeMinHours = logAgeMinHours
+}
+
 func (op *nmaStageDCTablesOp) setupRequestBody(hosts []string) error {
 	op.hostRequestBodyMap = make(map[string]string, len(hosts))
 	for _, host := range hosts {
 		stageDCTablesData := stageDCTablesRequestData{}
 		stageDCTablesData.CatalogPath = op.hostCatPathMap[host]
+		stageDCTablesData.LogAgeMaxHours = op.logAgeMaxHours
+		stageDCTablesData.LogAgeMinHours = op.logAgeMinHours
 
 		dataBytes, err := json.Marshal(stageDCTablesData)
 		if err != nil {
@@ -0,0 +1,109 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// nmaStageStartupLogOp stages the tail of startup.log, which records the
+// progress of the node startups, up to the given size
+type nmaStageStartupLogOp struct {
+	scrutinizeOpBase
+	logSizeLimitBytes int64
+}
+
+type stageStartupLogRequestData struct {
+	CatalogPath       string `json:"catalog_path"`
+	LogSizeLimitBytes int64  `json:"log_size_limit_bytes"`
+}
+
+type stageStartupLogResponseData struct {
+	Name      string `json:"name"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+func makeNMAStageStartupLogOp(
+	id, batch string,
+	hosts []string,
+	hostNodeNameMap, hostCatPathMap map[string]string,
+	logSizeLimitBytes int64) (nmaStageStartupLogOp, error) {
+	// base members
+	op := nmaStageStartupLogOp{}
+	op.name = "NMAStageStartupLogOp"
+	op.description = "Stage startup.log"
+	op.hosts = hosts
+	// scrutinize members
+	op.id = id
+	op.batch = batch
+	op.hostNodeNameMap = hostNodeNameMap
+	op.hostCatPathMap = hostCatPathMap
+	op.httpMethod = PostMethod
+	op.urlSuffix = "/startup.log"
+
+	// custom members
+	op.logSizeLimitBytes = logSizeLimitBytes
+
+	// the caller is responsible for making sure hosts and maps match up exactly
+	err := validateHostMaps(hosts, hostNodeNameMap, hostCatPathMap)
+	return op, err
+}
+
+func (op *nmaStageStartupLogOp) setupRequestBody(hosts []string) error {
+	op.hostRequestBodyMap = make(map[string]string, len(hosts))
+	for _, host := range hosts {
+		stageStartupLogData := stageStartupLogRequestData{}
+		stageStartupLogData.CatalogPath = op.hostCatPathMap[host]
+		stageStartupLogData.LogSizeLimitBytes = op.logSizeLimitBytes
+
+		dataBytes, err := json.Marshal(stageStartupLogData)
+		if err != nil {
+			return fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+		}
+
+		op.hostRequestBodyMap[host] = string(dataBytes)
+	}
+
+	return nil
+}
+
+func (op *nmaStageStartupLogOp) prepare(execContext *opEngineExecContext) error {
+	err := op.setupRequestBody(op.hosts)
+	if err != nil {
+		return err
+	}
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaStageStartupLogOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaStageStartupLogOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaStageStartupLogOp) processResult(_ *opEngineExecContext) error {
+	fileList := make([]stageStartupLogResponseData, 0)
+	return processStagedItemsResult(&op.scrutinizeOpBase, fileList)
+}
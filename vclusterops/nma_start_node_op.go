@@ -21,12 +21,20 @@ import (
 	"fmt"
 )
 
+// startupConfMinServerVersion is the first Vertica version whose NMA can
+// store the start command of a node at a path instead of executing it, as
+// documented on the StartUpConf option of create_db, start_db, start_node
+// and add_node
+const startupConfMinServerVersion = "24.2.0"
+
 type nmaStartNodeOp struct {
 	opBase
 	startupConf        string
 	hostRequestBodyMap map[string]string
 	vdb                *VCoordinationDatabase
 	sandbox            bool
+	// optional, arguments appended to the start commands of the nodes
+	extraStartArgs []string
 }
 
 type startNodeRequestData struct {
@@ -41,10 +49,26 @@ func makeNMAStartNodeOp(
 	op.description = fmt.Sprintf("Start %d node(s)", len(hosts))
 	op.hosts = hosts
 	op.startupConf = startupConf
+	if startupConf != "" {
+		op.minServerVersion = startupConfMinServerVersion
+	}
 	op.sandbox = false
 	return op
 }
 
+// getLegacyOp returns the op that starts the nodes without the startup conf,
+// on the servers that are too old to store the start command at a path
+func (op *nmaStartNodeOp) getLegacyOp() clusterOp {
+	if op.startupConf == "" {
+		return nil
+	}
+	legacyOp := *op
+	legacyOp.name = "NMAStartNodeOpWithoutStartupConf"
+	legacyOp.startupConf = ""
+	legacyOp.minServerVersion = ""
+	return &legacyOp
+}
+
 func makeNMAStartNodeOpAfterUnsandbox(startupConf string) nmaStartNodeOp {
 	startNodeOp := makeNMAStartNodeOp([]string{}, startupConf)
 	startNodeOp.sandbox = true
@@ -111,6 +135,9 @@ func (op *nmaStartNodeOp) updateRequestBody(execContext *opEngineExecContext) er
 }
 
 func (op *nmaStartNodeOp) updateHostRequestBodyMapFromNodeStartCommand(host string, hostStartCommand []string) error {
+	if len(op.extraStartArgs) > 0 {
+		hostStartCommand = append(append([]string{}, hostStartCommand...), op.extraStartArgs...)
+	}
 	startNodeData := startNodeRequestData{
 		StartCommand: hostStartCommand,
 		StartupConf:  op.startupConf,
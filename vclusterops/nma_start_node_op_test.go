@@ -44,3 +44,59 @@ func TestStartNodeOp(t *testing.T) {
 	assert.Equal(t, len(startNodeData.StartCommand), len(startCmd))
 	assert.Equal(t, startNodeData.StartupConf, startupConf)
 }
+
+func TestStartNodeOpExtraStartArgs(t *testing.T) {
+	vl := vlog.Printer{}
+	hosts := []string{"host1"}
+	op := makeNMAStartNodeOp(hosts, "")
+	op.extraStartArgs = []string{unsafeStartArg}
+	op.skipExecute = true
+	certs := httpsCerts{}
+	clusterOpEngine := makeClusterOpEngine([]clusterOp{&op}, &certs)
+
+	execContext := makeOpEngineExecContext(vl)
+	execContext.nmaVDatabase.HostNodeMap = make(map[string]*nmaVNode)
+	startCmd := []string{"/opt/vertica/bin/vertica", "-D", "/data/practice_db/v_practice_db_node0001_catalog"}
+	execContext.nmaVDatabase.HostNodeMap[hosts[0]] = &nmaVNode{StartCommand: startCmd}
+
+	err := clusterOpEngine.runWithExecContext(vl, &execContext)
+	assert.NoError(t, err)
+	startNodeData := startNodeRequestData{}
+	err = json.Unmarshal([]byte(op.clusterHTTPRequest.RequestCollection[hosts[0]].RequestData), &startNodeData)
+	assert.NoError(t, err)
+	assert.Equal(t, append(startCmd, unsafeStartArg), startNodeData.StartCommand)
+	// the start command of the catalog is not modified
+	assert.Len(t, execContext.nmaVDatabase.HostNodeMap[hosts[0]].StartCommand, len(startCmd))
+}
+
+func TestStartNodeOpStartupConfOnOldServer(t *testing.T) {
+	vl := vlog.Printer{}
+	hosts := []string{"host1"}
+	execContext := makeOpEngineExecContext(vl)
+	execContext.serverVersion = "Vertica Analytic Database v24.1.0-2"
+
+	// the startup conf is not supported by the server, the nodes are
+	// started without it
+	op := makeNMAStartNodeOp(hosts, "/tmp/startup.json")
+	opToRun, err := negotiateServerVersion(vl, &execContext, &op)
+	assert.NoError(t, err)
+	legacyOp, ok := opToRun.(*nmaStartNodeOp)
+	assert.True(t, ok)
+	assert.Empty(t, legacyOp.startupConf)
+	assert.Equal(t, hosts, legacyOp.hosts)
+	// the op itself is not modified
+	assert.Equal(t, "/tmp/startup.json", op.startupConf)
+
+	// without the startup conf, the op runs on the same server
+	op = makeNMAStartNodeOp(hosts, "")
+	opToRun, err = negotiateServerVersion(vl, &execContext, &op)
+	assert.NoError(t, err)
+	assert.Equal(t, &op, opToRun)
+
+	// a recent server accepts the startup conf
+	execContext.serverVersion = "Vertica Analytic Database v24.2.0-0"
+	op = makeNMAStartNodeOp(hosts, "/tmp/startup.json")
+	opToRun, err = negotiateServerVersion(vl, &execContext, &op)
+	assert.NoError(t, err)
+	assert.Equal(t, &op, opToRun)
+}
@@ -19,24 +19,46 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
 
 type nmaUploadConfigOp struct {
 	opBase
-	catalogPathMap     map[string]string
-	endpoint           string
-	fileContent        *string
+	catalogPathMap map[string]string
+	endpoint       string
+	fileContent    *string
+	// the checksum of the file on the source host, see nmaDownloadConfigOp
+	sourceChecksum     *string
 	hostRequestBodyMap map[string]string
 	sourceConfigHost   []string
 	destHosts          []string
 	vdb                *VCoordinationDatabase
+	// hosts on which the size or the checksum of the written file does not
+	// match the content that was sent, the upload is attempted again on them
+	mismatchedHosts []string
 }
 
+// the number of times the download or the upload of a config file is
+// attempted on a host whose copy does not match the source
+const maxConfigTransferAttempts = 3
+
 type uploadConfigRequestData struct {
 	CatalogPath string `json:"catalog_path"`
 	Content     string `json:"content"`
+	// the SHA-256 checksum of the content, the NMA can use it to reject a
+	// corrupted request
+	Checksum string `json:"checksum"`
+}
+
+// uploadConfigResponse is the response of the NMA to the upload of a config
+// file. The size and checksum of the written file are missing from the
+// responses of older NMA versions.
+type uploadConfigResponse struct {
+	Destination string `json:"destination"`
+	Size        *int   `json:"size"`
+	Checksum    string `json:"checksum"`
 }
 
 // makeNMAUploadConfigOp sets up the input parameters from the user for the upload operation.
@@ -54,6 +76,7 @@ func makeNMAUploadConfigOp(
 	targetHosts []string, // list of hosts that need to be synchronized
 	endpoint string,
 	fileContent *string,
+	sourceChecksum *string,
 	vdb *VCoordinationDatabase,
 ) nmaUploadConfigOp {
 	op := nmaUploadConfigOp{}
@@ -64,7 +87,13 @@ func makeNMAUploadConfigOp(
 	} else if op.endpoint == spreadConf {
 		op.description = "Send contents of spread.conf to nodes"
 	}
+	op.responseSchema = responseSchema{
+		{path: "destination", typ: jsonString},
+		{path: "size", typ: jsonNumber, optional: true},
+		{path: "checksum", typ: jsonString, optional: true},
+	}
 	op.fileContent = fileContent
+	op.sourceChecksum = sourceChecksum
 	op.catalogPathMap = make(map[string]string)
 	op.sourceConfigHost = sourceConfigHost
 	op.destHosts = targetHosts
@@ -80,6 +109,7 @@ func (op *nmaUploadConfigOp) setupRequestBody(hosts []string) error {
 		uploadConfigData := uploadConfigRequestData{}
 		uploadConfigData.CatalogPath = op.catalogPathMap[host]
 		uploadConfigData.Content = *op.fileContent
+		uploadConfigData.Checksum = op.expectedChecksum()
 
 		dataBytes, err := json.Marshal(uploadConfigData)
 		if err != nil {
@@ -92,11 +122,22 @@ func (op *nmaUploadConfigOp) setupRequestBody(hosts []string) error {
 	return nil
 }
 
+// expectedChecksum returns the checksum that the uploaded copies must match:
+// the checksum of the file on the source host, or the checksum of the
+// downloaded content if the NMA of the source host does not return it
+func (op *nmaUploadConfigOp) expectedChecksum() string {
+	if op.sourceChecksum != nil && *op.sourceChecksum != "" {
+		return *op.sourceChecksum
+	}
+	return computeChecksum([]byte(*op.fileContent))
+}
+
 func (op *nmaUploadConfigOp) setupClusterHTTPRequest(hosts []string) error {
 	for _, host := range hosts {
 		httpRequest := hostHTTPRequest{}
 		httpRequest.Method = PostMethod
 		httpRequest.buildNMAEndpoint(op.endpoint)
+		httpRequest.Compressible = true
 		httpRequest.RequestData = op.hostRequestBodyMap[host]
 		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
 	}
@@ -166,40 +207,77 @@ func (op *nmaUploadConfigOp) prepare(execContext *opEngineExecContext) error {
 }
 
 func (op *nmaUploadConfigOp) execute(execContext *opEngineExecContext) error {
-	if err := op.runExecute(execContext); err != nil {
-		return err
-	}
+	for attempt := 1; ; attempt++ {
+		if err := op.runExecute(execContext); err != nil {
+			return err
+		}
 
-	return op.processResult(execContext)
+		err := op.processResult(execContext)
+		if err != nil || len(op.mismatchedHosts) == 0 {
+			return err
+		}
+		if attempt == maxConfigTransferAttempts {
+			return fmt.Errorf("[%s] the config file on hosts %v does not match the source after %d attempts",
+				op.name, op.mismatchedHosts, attempt)
+		}
+		op.logger.PrintWarning("[%s] the config file on hosts %v does not match the source, uploading it again",
+			op.name, op.mismatchedHosts)
+		// only send the requests of the hosts that need another attempt, the
+		// requests keep the certificates that were loaded in them
+		for host := range op.clusterHTTPRequest.RequestCollection {
+			if !util.StringInArray(host, op.mismatchedHosts) {
+				delete(op.clusterHTTPRequest.RequestCollection, host)
+			}
+		}
+	}
 }
 
 func (op *nmaUploadConfigOp) finalize(_ *opEngineExecContext) error {
 	return nil
 }
 
+// processResult checks the size and the checksum of the written file on each
+// host, and records the hosts on which they do not match in op.mismatchedHosts
 func (op *nmaUploadConfigOp) processResult(_ *opEngineExecContext) error {
 	var allErrs error
+	op.mismatchedHosts = []string{}
+	expectedSize := len(*op.fileContent)
+	expectedChecksum := op.expectedChecksum()
 
 	for host, result := range op.clusterHTTPRequest.ResultCollection {
 		op.logResponse(host, result)
-		if result.isPassing() {
-			// the response object will be a dictionary including the destination of the config file, e.g.,:
-			// {"destination":"/data/vcluster_test_db/v_vcluster_test_db_node0003_catalog/vertica.conf"}
-			responseObj, err := op.parseAndCheckMapResponse(host, result.content)
-			if err != nil {
-				err = fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err)
-				allErrs = errors.Join(allErrs, err)
-				continue
-			}
-			_, ok := responseObj["destination"]
-			if !ok {
-				err = fmt.Errorf(`[%s] response does not contain field "destination"`, op.name)
-				allErrs = errors.Join(allErrs, err)
-			}
-		} else {
+		if !result.isPassing() {
 			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// the response object will be a dictionary including the destination of the config file,
+		// and the size and checksum of the written file, e.g.,:
+		// {"destination":"/data/vcluster_test_db/v_vcluster_test_db_node0003_catalog/vertica.conf",
+		//  "size": 1024, "checksum": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}
+		response := uploadConfigResponse{}
+		err := op.parseAndCheckResponse(host, result.content, &response)
+		if err != nil {
+			err = fmt.Errorf("[%s] fail to parse result on host %s, details: %w", op.name, host, err)
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		if response.Checksum == "" || response.Size == nil {
+			op.logger.Info("the NMA does not return the checksum of the config file, skipping its verification",
+				"host", host, "destination", response.Destination)
+			continue
+		}
+		if response.Checksum != expectedChecksum || *response.Size != expectedSize {
+			op.logger.Info("config file verification failed", "host", host, "destination", response.Destination,
+				"expected size", expectedSize, "size", *response.Size,
+				"expected checksum", expectedChecksum, "checksum", response.Checksum)
+			op.mismatchedHosts = append(op.mismatchedHosts, host)
+			continue
 		}
+		op.logger.Info("config file verified", "host", host, "destination", response.Destination,
+			"size", *response.Size, "checksum", response.Checksum)
 	}
+	sort.Strings(op.mismatchedHosts)
 
 	return allErrs
 }
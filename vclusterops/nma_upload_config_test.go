@@ -0,0 +1,80 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestUploadConfigVerification(t *testing.T) {
+	content := "test"
+	checksum := computeChecksum([]byte(content))
+	hosts := []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"}
+	op := makeNMAUploadConfigOp("NMAUploadVerticaConfigOp", []string{"192.0.2.1"}, hosts, verticaConf, &content, nil, nil)
+	op.logger = vlog.Printer{}
+
+	makeResponse := func(size int, checksum string) string {
+		return fmt.Sprintf(`{"destination": "/data/test_db/v_test_db_node0001_catalog/vertica.conf", "size": %d, "checksum": %q}`,
+			size, checksum)
+	}
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: makeResponse(4, checksum)},
+		// wrong checksum
+		"192.0.2.2": {host: "192.0.2.2", status: SUCCESS, statusCode: SuccessCode, content: makeResponse(4, "bad")},
+		// truncated file
+		"192.0.2.3": {host: "192.0.2.3", status: SUCCESS, statusCode: SuccessCode, content: makeResponse(2, checksum)},
+		// an older NMA does not return the size and checksum
+		"192.0.2.4": {host: "192.0.2.4", status: SUCCESS, statusCode: SuccessCode,
+			content: `{"destination": "/data/test_db/v_test_db_node0004_catalog/vertica.conf"}`},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.3"}, op.mismatchedHosts)
+
+	// a response without the destination is still rejected
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: `{"size": 4}`},
+	}
+	assert.Error(t, op.processResult(nil))
+	assert.Empty(t, op.mismatchedHosts)
+}
+
+func TestUploadConfigSourceChecksum(t *testing.T) {
+	// the uploaded copies are verified against the checksum of the source
+	// host, not against the checksum of the content that was downloaded
+	content := "test"
+	sourceChecksum := computeChecksum([]byte("source"))
+	hosts := []string{"192.0.2.1", "192.0.2.2"}
+	op := makeNMAUploadConfigOp("NMAUploadVerticaConfigOp", []string{"192.0.2.1"}, hosts, verticaConf,
+		&content, &sourceChecksum, nil)
+	op.logger = vlog.Printer{}
+	assert.Equal(t, sourceChecksum, op.expectedChecksum())
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.2": {host: "192.0.2.2", status: SUCCESS, statusCode: SuccessCode,
+			content: fmt.Sprintf(`{"destination": "vertica.conf", "size": 4, "checksum": %q}`, computeChecksum([]byte(content)))},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Equal(t, []string{"192.0.2.2"}, op.mismatchedHosts)
+
+	// the checksum of the content is used if the source NMA did not return one
+	sourceChecksum = ""
+	assert.NoError(t, op.processResult(nil))
+	assert.Empty(t, op.mismatchedHosts)
+}
@@ -0,0 +1,178 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// nmaUploadFileOp writes a file to the same path on every host with the NMA,
+// and checks that the checksum of the written file matches the checksum of
+// the content that was sent. It is the counterpart of nmaDownloadFileOp, for
+// files of the hosts, e.g., a license or the library of a UDx, or for files
+// on communal storage, e.g., the hibernation marker of a database.
+type nmaUploadFileOp struct {
+	opBase
+	destinationFilePath string
+	fileContent         []byte
+	fileMode            string
+	overwrite           bool
+	checksum            string
+	// optional, the configuration parameters to access communal storage
+	configurationParameters map[string]string
+	hostRequestBodyMap      map[string]string
+	// host -> checksum of the file written by the NMA, for the hosts on
+	// which the upload succeeded
+	hostChecksums map[string]string
+}
+
+type uploadFileRequestData struct {
+	DestinationFilePath string `json:"destination_file_path"`
+	// the content is base64 encoded so that binary files can be uploaded
+	FileContent string            `json:"file_content"`
+	FileMode    string            `json:"file_mode,omitempty"`
+	Overwrite   bool              `json:"overwrite"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+}
+
+type uploadFileResponse struct {
+	DestinationFilePath string `json:"destination_file_path"`
+	Size                int64  `json:"size"`
+	Checksum            string `json:"checksum"`
+}
+
+// makeNMAUploadFileOp will create an op that uploads fileContent to
+// destinationFilePath on the hosts. fileMode is an octal permission, e.g.,
+// "0644"; the NMA default applies if it is empty. An existing file is only
+// replaced when overwrite is set.
+func makeNMAUploadFileOp(hosts []string, destinationFilePath string, fileContent []byte,
+	fileMode string, overwrite bool) nmaUploadFileOp {
+	op := nmaUploadFileOp{}
+	op.name = "NMAUploadFileOp"
+	op.description = fmt.Sprintf("Upload %s to hosts", filepath.Base(destinationFilePath))
+	op.hosts = hosts
+	op.destinationFilePath = destinationFilePath
+	op.fileContent = fileContent
+	op.fileMode = fileMode
+	op.overwrite = overwrite
+	op.checksum = computeChecksum(fileContent)
+	op.hostChecksums = make(map[string]string)
+	return op
+}
+
+// makeNMAUploadCommunalFileOp will create an op that uploads a small file to
+// communal storage, e.g., the hibernation marker of a database, with the NMA
+// of one of the hosts. An existing file is replaced.
+func makeNMAUploadCommunalFileOp(hosts []string, destinationFilePath, fileContent string,
+	configurationParameters map[string]string) nmaUploadFileOp {
+	op := makeNMAUploadFileOp([]string{getInitiator(hosts)}, destinationFilePath, []byte(fileContent),
+		"" /*fileMode*/, true /*overwrite*/)
+	op.name = "NMAUploadCommunalFileOp"
+	op.configurationParameters = configurationParameters
+	return op
+}
+
+func (op *nmaUploadFileOp) setupRequestBody() error {
+	op.hostRequestBodyMap = make(map[string]string)
+
+	requestData := uploadFileRequestData{
+		DestinationFilePath: op.destinationFilePath,
+		FileContent:         base64.StdEncoding.EncodeToString(op.fileContent),
+		FileMode:            op.fileMode,
+		Overwrite:           op.overwrite,
+		Parameters:          op.configurationParameters,
+	}
+	dataBytes, err := json.Marshal(requestData)
+	if err != nil {
+		return fmt.Errorf("[%s] fail to marshal request data to JSON string, detail %w", op.name, err)
+	}
+	for _, host := range op.hosts {
+		op.hostRequestBodyMap[host] = string(dataBytes)
+	}
+
+	return nil
+}
+
+func (op *nmaUploadFileOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = PostMethod
+		httpRequest.buildNMAEndpoint("vertica/upload-file")
+		httpRequest.Compressible = true
+		httpRequest.RequestData = op.hostRequestBodyMap[host]
+
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaUploadFileOp) prepare(execContext *opEngineExecContext) error {
+	err := op.setupRequestBody()
+	if err != nil {
+		return err
+	}
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaUploadFileOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaUploadFileOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaUploadFileOp) processResult(_ *opEngineExecContext) error {
+	var allErrs error
+
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		// a successful response looks like:
+		// {"destination_file_path": "/opt/vertica/config/license.key", "size": 1024,
+		//  "checksum": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"}
+		response := uploadFileResponse{}
+		err := op.parseAndCheckResponse(host, result.content, &response)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		if response.Checksum != op.checksum {
+			allErrs = errors.Join(allErrs, fmt.Errorf("[%s] checksum mismatch for %s on host %s, expected %s but got %s",
+				op.name, op.destinationFilePath, host, op.checksum, response.Checksum))
+			continue
+		}
+		op.hostChecksums[host] = response.Checksum
+	}
+
+	return allErrs
+}
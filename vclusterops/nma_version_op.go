@@ -0,0 +1,190 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/maps"
+)
+
+// nmaCompatibilityMatrix maps each NMA endpoint version prefix used by
+// vclusterops to the lowest NMA version that serves it
+var nmaCompatibilityMatrix = map[string]string{
+	NMAVersion1: "12.0.4",
+}
+
+type nmaVersionOp struct {
+	opBase
+	hostToNMAVersion map[string]string
+}
+
+type nmaVersionResponse struct {
+	NMAVersion string `json:"nma_version"`
+	// endpoint version prefixes served by the NMA, e.g., ["v1"]
+	APIVersions []string `json:"api_versions"`
+}
+
+// makeNMAVersionOp will create an op that reads the build version of the NMA
+// on each host, which is independent of the Vertica server version, and checks
+// that vclusterops can talk to it. An NMA that is too old to serve its version
+// is only reported in the log.
+func makeNMAVersionOp(hosts []string) nmaVersionOp {
+	op := nmaVersionOp{}
+	op.name = "NMAVersionOp"
+	op.description = "Check NMA version compatibility"
+	op.hosts = hosts
+	op.hostToNMAVersion = make(map[string]string)
+	return op
+}
+
+func (op *nmaVersionOp) setupClusterHTTPRequest(hosts []string) error {
+	for _, host := range hosts {
+		httpRequest := hostHTTPRequest{}
+		httpRequest.Method = GetMethod
+		httpRequest.buildNMAEndpoint("version")
+		op.clusterHTTPRequest.RequestCollection[host] = httpRequest
+	}
+
+	return nil
+}
+
+func (op *nmaVersionOp) prepare(execContext *opEngineExecContext) error {
+	execContext.dispatcher.setup(op.hosts)
+
+	return op.setupClusterHTTPRequest(op.hosts)
+}
+
+func (op *nmaVersionOp) execute(execContext *opEngineExecContext) error {
+	if err := op.runExecute(execContext); err != nil {
+		return err
+	}
+
+	return op.processResult(execContext)
+}
+
+func (op *nmaVersionOp) finalize(_ *opEngineExecContext) error {
+	return nil
+}
+
+func (op *nmaVersionOp) processResult(execContext *opEngineExecContext) error {
+	var allErrs error
+	for host, result := range op.clusterHTTPRequest.ResultCollection {
+		op.logResponse(host, result)
+
+		if result.isNotFound() {
+			op.logger.Info("the NMA does not serve its version, skip the compatibility check", "host", host)
+			continue
+		}
+		if !result.isPassing() {
+			allErrs = errors.Join(allErrs, result.err)
+			continue
+		}
+
+		var resp nmaVersionResponse
+		err := op.parseAndCheckResponse(host, result.content, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		err = op.checkCompatibility(host, &resp)
+		if err != nil {
+			allErrs = errors.Join(allErrs, err)
+			continue
+		}
+		op.hostToNMAVersion[host] = resp.NMAVersion
+	}
+	if allErrs != nil {
+		return allErrs
+	}
+
+	return op.checkSameVersion(execContext)
+}
+
+// checkCompatibility checks that the NMA of a host serves the endpoint version
+// used by vclusterops, and is recent enough for it
+func (op *nmaVersionOp) checkCompatibility(host string, resp *nmaVersionResponse) error {
+	apiVersion := strings.TrimSuffix(NMACurVersion, "/")
+	found := false
+	for _, v := range resp.APIVersions {
+		if strings.TrimSuffix(v, "/") == apiVersion {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("[%s] the NMA on host %s serves the endpoint versions %v, but vcluster requires %s",
+			op.name, host, resp.APIVersions, apiVersion)
+	}
+
+	minVersion, ok := nmaCompatibilityMatrix[NMACurVersion]
+	if !ok {
+		return nil
+	}
+	nmaVer, err := parseServerVersion(resp.NMAVersion)
+	if err != nil {
+		return fmt.Errorf("[%s] fail to parse the NMA version on host %s: %w", op.name, host, err)
+	}
+	minVer := semVer{Ver: minVersion}
+	if cmp, _ := nmaVer.compareCore(&minVer); cmp < 0 {
+		return fmt.Errorf("[%s] the NMA version %s on host %s is not supported, it must be %s or higher",
+			op.name, resp.NMAVersion, host, minVersion)
+	}
+	return nil
+}
+
+// checkSameVersion checks that all the NMAs have the same version, which is
+// then stored in the execContext
+func (op *nmaVersionOp) checkSameVersion(execContext *opEngineExecContext) error {
+	versions := make(map[string]struct{})
+	for _, version := range op.hostToNMAVersion {
+		versions[version] = struct{}{}
+	}
+	switch len(versions) {
+	case 0:
+		return nil
+	case 1:
+		execContext.nmaVersion = maps.Keys(versions)[0]
+		return nil
+	}
+	versionList := maps.Keys(versions)
+	sort.Strings(versionList)
+	return &VersionMismatchError{
+		Detail:   fmt.Sprintf("[%s] Found mismatched NMA versions: %v", op.name, versionList),
+		Versions: versionList,
+	}
+}
+
+// checkNMAServerCompatibility returns an error if the NMA and the Vertica server
+// come from different releases, i.e., do not have the same major and minor versions
+func checkNMAServerCompatibility(nmaVersion, serverVersion string) error {
+	nmaVer, err := parseServerVersion(nmaVersion)
+	if err != nil {
+		return err
+	}
+	serverVer, err := parseServerVersion(serverVersion)
+	if err != nil {
+		return err
+	}
+	if nmaVer.Major != serverVer.Major || nmaVer.Minor != serverVer.Minor {
+		return fmt.Errorf("the NMA version %s is not compatible with the Vertica version %s, "+
+			"they must come from the same release", nmaVersion, serverVersion)
+	}
+	return nil
+}
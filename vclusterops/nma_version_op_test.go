@@ -0,0 +1,51 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNMAVersionCompatibility(t *testing.T) {
+	op := makeNMAVersionOp([]string{"192.0.2.1", "192.0.2.2"})
+	execContext := makeOpEngineExecContext(op.logger)
+
+	// the NMA must serve the endpoint version used by vcluster
+	err := op.checkCompatibility("192.0.2.1", &nmaVersionResponse{NMAVersion: "24.3.0", APIVersions: []string{"v2"}})
+	assert.ErrorContains(t, err, "serves the endpoint versions [v2], but vcluster requires v1")
+	// and be recent enough
+	err = op.checkCompatibility("192.0.2.1", &nmaVersionResponse{NMAVersion: "12.0.3", APIVersions: []string{"v1"}})
+	assert.ErrorContains(t, err, "the NMA version 12.0.3 on host 192.0.2.1 is not supported")
+	err = op.checkCompatibility("192.0.2.1", &nmaVersionResponse{NMAVersion: "v24.3.0-1", APIVersions: []string{"v1/", "v2/"}})
+	assert.NoError(t, err)
+
+	// all the NMAs must have the same version
+	op.hostToNMAVersion = map[string]string{"192.0.2.1": "24.3.0", "192.0.2.2": "24.2.0"}
+	err = op.checkSameVersion(&execContext)
+	versionErr := &VersionMismatchError{}
+	assert.ErrorAs(t, err, &versionErr)
+	assert.Equal(t, []string{"24.2.0", "24.3.0"}, versionErr.Versions)
+	op.hostToNMAVersion = map[string]string{"192.0.2.1": "24.3.0", "192.0.2.2": "24.3.0"}
+	assert.NoError(t, op.checkSameVersion(&execContext))
+	assert.Equal(t, "24.3.0", execContext.nmaVersion)
+
+	// the NMA and the server must come from the same release
+	assert.NoError(t, checkNMAServerCompatibility("24.3.0", "Vertica Analytic Database v24.3.0-2"))
+	err = checkNMAServerCompatibility("24.2.0", "Vertica Analytic Database v24.3.0")
+	assert.ErrorContains(t, err, "the NMA version 24.2.0 is not compatible with the Vertica version")
+}
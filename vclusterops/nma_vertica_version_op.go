@@ -293,10 +293,14 @@ func (op *nmaVerticaVersionOp) logCheckVersionMatch() error {
 				// first time seeing a valid version, set it as the versionStr
 				versionStr = version
 			} else if version != versionStr && op.RequireSameVersion {
+				mismatchErr := &VersionMismatchError{
+					Detail:   fmt.Sprintf("[%s] Found mismatched versions: [%s] and [%s]", op.name, versionStr, version),
+					Versions: []string{versionStr, version},
+				}
 				if op.IsEon && op.HasIncomingSCNames {
-					return fmt.Errorf("[%s] Found mismatched versions: [%s] and [%s] in subcluster [%s]", op.name, versionStr, version, sc)
+					mismatchErr.Detail += fmt.Sprintf(" in subcluster [%s]", sc)
 				}
-				return fmt.Errorf("[%s] Found mismatched versions: [%s] and [%s]", op.name, versionStr, version)
+				return mismatchErr
 			}
 		}
 		// no version collected at all
@@ -310,9 +314,17 @@ func (op *nmaVerticaVersionOp) logCheckVersionMatch() error {
 	return nil
 }
 
-func (op *nmaVerticaVersionOp) processResult(_ *opEngineExecContext) error {
+func (op *nmaVerticaVersionOp) processResult(execContext *opEngineExecContext) error {
 	if op.readOnly {
-		return op.readVersion()
+		err := op.readVersion()
+		if err != nil {
+			return err
+		}
+		var versions []string
+		for _, vnode := range op.vdb.HostNodeMap {
+			versions = append(versions, vnode.Version)
+		}
+		return op.setServerVersion(execContext, versions)
 	}
 
 	err := op.logResponseCollectVersions()
@@ -320,7 +332,59 @@ func (op *nmaVerticaVersionOp) processResult(_ *opEngineExecContext) error {
 		return err
 	}
 
-	return op.logCheckVersionMatch()
+	err = op.logCheckVersionMatch()
+	if err != nil {
+		return err
+	}
+	var versions []string
+	for _, hostVersionMap := range op.SCToHostVersionMap {
+		for _, version := range hostVersionMap {
+			versions = append(versions, version)
+		}
+	}
+	return op.setServerVersion(execContext, versions)
+}
+
+// setServerVersion stores the lowest of the collected versions in the execContext,
+// so that the next ops can check that the server supports them. If the NMA
+// version is known, it also checks that the NMA is compatible with the server.
+func (op *nmaVerticaVersionOp) setServerVersion(execContext *opEngineExecContext, versions []string) error {
+	serverVersion := lowestServerVersion(versions)
+	if serverVersion == "" {
+		op.logger.Info("no valid server version collected, the next ops will not check it")
+		return nil
+	}
+	op.logger.Info("server version", "version", serverVersion)
+	execContext.serverVersion = serverVersion
+
+	if execContext.nmaVersion != "" {
+		if err := checkNMAServerCompatibility(execContext.nmaVersion, serverVersion); err != nil {
+			return fmt.Errorf("[%s] %w", op.name, err)
+		}
+	}
+	return nil
+}
+
+// lowestServerVersion returns the lowest of the given server versions. As for
+// the minimum versions of the ops, the hotfix numbers are ignored. The versions
+// that cannot be parsed are ignored.
+func lowestServerVersion(versions []string) string {
+	var lowest string
+	var lowestVer semVer
+	for _, version := range versions {
+		ver, err := parseServerVersion(version)
+		if err != nil {
+			continue
+		}
+		if lowest != "" {
+			if cmp, _ := ver.compareCore(&lowestVer); cmp >= 0 {
+				continue
+			}
+		}
+		lowest = version
+		lowestVer = ver
+	}
+	return lowest
 }
 
 func (op *nmaVerticaVersionOp) readVersion() error {
@@ -369,7 +433,10 @@ func (op *nmaVerticaVersionOp) prepareHostNodeMap(execContext *opEngineExecConte
 			if ok {
 				targetSCs = append(targetSCs, sc)
 			} else {
-				return hostNodeMap, fmt.Errorf("[%s] host %s does not exist in the database", op.name, host)
+				return hostNodeMap, &NodeNotFoundError{
+					Detail: fmt.Sprintf("[%s] host %s does not exist in the database", op.name, host),
+					Nodes:  []string{host},
+				}
 			}
 		}
 		// find all hosts that in target subclusters
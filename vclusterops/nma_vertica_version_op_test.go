@@ -123,3 +123,13 @@ func TestLogCheckVersionMatch(t *testing.T) {
 	err = op.logCheckVersionMatch()
 	assert.ErrorContains(t, err, "No version collected for all hosts in subcluster [sc1]")
 }
+
+func TestLowestServerVersion(t *testing.T) {
+	assert.Equal(t, "Vertica Analytic Database v24.2.0", lowestServerVersion([]string{
+		"Vertica Analytic Database v24.3.0",
+		"Vertica Analytic Database v24.2.0",
+		"",
+		"Vertica Analytic Database v24.2.0-1",
+	}))
+	assert.Equal(t, "", lowestServerVersion([]string{"", "unknown"}))
+}
@@ -27,6 +27,8 @@ type NodeInfo struct {
 	Sandbox     string `json:"sandbox"`
 	IsPrimary   bool   `json:"is_primary"`
 	Version     string `json:"version"`
+	// the catalog version of the node, only returned by the /nodes/<host> endpoint
+	CatalogVersion string `json:"catalog_version,omitempty"`
 }
 
 // NodeInfo does not contain Eon specific information
@@ -0,0 +1,95 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"sync"
+	"time"
+)
+
+// NodesDetailsCache keeps the result of VFetchNodesDetails for each host, so
+// that callers polling the nodes' details every few seconds, like an operator
+// reconciliation loop, do not send requests to every host on each call.
+// An entry is reused until its TTL expires, and only if it was fetched for the
+// same catalog version. It is safe for concurrent use.
+type NodesDetailsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[nodesDetailsCacheKey]nodesDetailsCacheEntry
+	// now returns the current time, it can be replaced in unit tests
+	now func() time.Time
+}
+
+type nodesDetailsCacheKey struct {
+	host           string
+	catalogVersion string
+}
+
+type nodesDetailsCacheEntry struct {
+	details   NodeDetails
+	fetchedAt time.Time
+}
+
+// MakeNodesDetailsCache creates a cache whose entries expire after ttl.
+// Set it in VClusterCommands.NodesDetailsCache to turn on the caching.
+func MakeNodesDetailsCache(ttl time.Duration) *NodesDetailsCache {
+	return &NodesDetailsCache{
+		ttl:     ttl,
+		entries: make(map[nodesDetailsCacheKey]nodesDetailsCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached details of a host if they have not expired
+func (c *NodesDetailsCache) get(host, catalogVersion string) (NodeDetails, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := nodesDetailsCacheKey{host: host, catalogVersion: catalogVersion}
+	entry, ok := c.entries[key]
+	if !ok {
+		return NodeDetails{}, false
+	}
+	if c.now().Sub(entry.fetchedAt) >= c.ttl {
+		delete(c.entries, key)
+		return NodeDetails{}, false
+	}
+	return entry.details, true
+}
+
+// put stores the details of a host. Entries of older catalog versions
+// for this host are dropped.
+func (c *NodesDetailsCache) put(host, catalogVersion string, details *NodeDetails) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.host == host {
+			delete(c.entries, key)
+		}
+	}
+	key := nodesDetailsCacheKey{host: host, catalogVersion: catalogVersion}
+	c.entries[key] = nodesDetailsCacheEntry{details: *details, fetchedAt: c.now()}
+}
+
+// Invalidate drops all of the cached entries. It should be called after an
+// operation that changes the nodes, e.g., a node was stopped or removed.
+func (c *NodesDetailsCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[nodesDetailsCacheKey]nodesDetailsCacheEntry)
+}
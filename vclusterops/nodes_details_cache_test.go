@@ -0,0 +1,73 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesDetailsCache(t *testing.T) {
+	cache := MakeNodesDetailsCache(10 * time.Second)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	details := NodeDetails{NodeState: NodeState{Name: "v_db_node0001", Address: "192.0.2.1", State: "UP"}}
+	cache.put("192.0.2.1", "100", &details)
+
+	// hit with the same host and catalog version
+	cached, ok := cache.get("192.0.2.1", "100")
+	assert.True(t, ok)
+	assert.Equal(t, details, cached)
+
+	// miss with another host or another catalog version
+	_, ok = cache.get("192.0.2.2", "100")
+	assert.False(t, ok)
+	_, ok = cache.get("192.0.2.1", "101")
+	assert.False(t, ok)
+
+	// a newer catalog version replaces the older entry
+	cache.put("192.0.2.1", "101", &details)
+	_, ok = cache.get("192.0.2.1", "100")
+	assert.False(t, ok)
+
+	// the entry expires after the TTL
+	now = now.Add(10 * time.Second)
+	_, ok = cache.get("192.0.2.1", "101")
+	assert.False(t, ok)
+
+	// invalidate drops everything
+	cache.put("192.0.2.1", "101", &details)
+	cache.Invalidate()
+	_, ok = cache.get("192.0.2.1", "101")
+	assert.False(t, ok)
+}
+
+func TestFetchNodesDetailsFromCache(t *testing.T) {
+	vcc := VClusterCommands{NodesDetailsCache: MakeNodesDetailsCache(time.Minute)}
+	details := NodeDetails{NodeState: NodeState{Name: "v_db_node0001", Address: "192.0.2.1"}}
+	vcc.NodesDetailsCache.put("192.0.2.1", "", &details)
+
+	// all hosts are cached so no request is sent
+	options := VFetchNodesDetailsOptionsFactory()
+	options.DBName = "testDB"
+	options.RawHosts = []string{"192.0.2.1"}
+	nodesDetails, err := vcc.VFetchNodesDetails(&options)
+	assert.NoError(t, err)
+	assert.Equal(t, NodesDetails{details}, nodesDetails)
+}
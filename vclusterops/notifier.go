@@ -0,0 +1,163 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the timeout of a request to a webhook
+const notificationWebhookTimeout = 10 * time.Second
+
+// OperationSummary is the payload sent to the subscribers of a Notifier when
+// a V* operation finishes
+type OperationSummary struct {
+	Command   string    `json:"command"`
+	DBName    string    `json:"db_name,omitempty"`
+	Hosts     []string  `json:"hosts"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	Duration  string    `json:"duration"`
+	// a one-line description of the outcome, so that the payload can be
+	// posted as is to chat webhooks, e.g., the Slack incoming webhooks
+	Text string `json:"text"`
+}
+
+// NotificationCallback is called with the summary of every finished operation
+type NotificationCallback func(summary OperationSummary)
+
+// Notifier invokes callbacks and webhooks with a summary of every V* operation
+// that finishes, whether it succeeded or failed, to integrate vcluster with
+// chat or paging tools. Set it in VClusterCommands.Notifier to turn on the
+// notifications. It is safe for concurrent use, and can be shared by several
+// VClusterCommands.
+type Notifier struct {
+	mu          sync.Mutex
+	callbacks   []NotificationCallback
+	webhookURLs []string
+	client      *http.Client
+}
+
+// MakeNotifier creates a notifier without subscribers
+func MakeNotifier() *Notifier {
+	return &Notifier{client: &http.Client{Timeout: notificationWebhookTimeout}}
+}
+
+// Subscribe registers a callback. The callbacks are called synchronously,
+// in the order of registration, when an operation finishes.
+func (n *Notifier) Subscribe(callback NotificationCallback) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.callbacks = append(n.callbacks, callback)
+}
+
+// AddWebhook registers a URL to which the summaries are posted in JSON
+func (n *Notifier) AddWebhook(webhookURL string) error {
+	parsedURL, err := url.ParseRequestURI(webhookURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q, it must be an absolute http or https URL", webhookURL)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.webhookURLs = append(n.webhookURLs, webhookURL)
+	return nil
+}
+
+// notify sends the summary to all the subscribers. A failure to notify is
+// logged, but does not fail the operation.
+func (n *Notifier) notify(logger vlog.Printer, summary *OperationSummary) {
+	n.mu.Lock()
+	callbacks := append([]NotificationCallback{}, n.callbacks...)
+	webhookURLs := append([]string{}, n.webhookURLs...)
+	n.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(*summary)
+	}
+	if len(webhookURLs) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		logger.PrintWarning("fail to marshal the notification, details: %s", err)
+		return
+	}
+	for _, webhookURL := range webhookURLs {
+		if err := n.postToWebhook(webhookURL, payload); err != nil {
+			logger.PrintWarning("fail to send the notification to the webhook, details: %s", err)
+		}
+	}
+}
+
+func (n *Notifier) postToWebhook(webhookURL string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the webhook returned status %s", resp.Status)
+	}
+	return nil
+}
+
+// makeOperationSummary summarizes the outcome of a command
+func makeOperationSummary(command string, options *DatabaseOptions, start time.Time, err error) OperationSummary {
+	summary := OperationSummary{
+		Command:   command,
+		DBName:    options.DBName,
+		Hosts:     options.Hosts,
+		Result:    SuccessResult,
+		StartTime: start.UTC(),
+		Duration:  time.Since(start).Round(time.Millisecond).String(),
+	}
+	if len(summary.Hosts) == 0 {
+		summary.Hosts = options.RawHosts
+	}
+	if err != nil {
+		summary.Result = FailureResult
+		summary.Error = err.Error()
+	}
+
+	target := ""
+	if summary.DBName != "" {
+		target = fmt.Sprintf(" on database %s", summary.DBName)
+	}
+	if err != nil {
+		summary.Text = fmt.Sprintf("vcluster %s%s failed after %s: %s", command, target, summary.Duration, summary.Error)
+	} else {
+		summary.Text = fmt.Sprintf("vcluster %s%s succeeded in %s", command, target, summary.Duration)
+	}
+	return summary
+}
@@ -0,0 +1,78 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestNotifier(t *testing.T) {
+	var posted []OperationSummary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var summary OperationSummary
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&summary))
+		posted = append(posted, summary)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := MakeNotifier()
+	var received []OperationSummary
+	notifier.Subscribe(func(summary OperationSummary) {
+		received = append(received, summary)
+	})
+	assert.NoError(t, notifier.AddWebhook(server.URL+"/hooks/vcluster"))
+	assert.ErrorContains(t, notifier.AddWebhook("hooks.example.com/vcluster"), "invalid webhook URL")
+	assert.ErrorContains(t, notifier.AddWebhook("ftp://hooks.example.com"), "invalid webhook URL")
+
+	options := VStopDatabaseOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"vnode1"}
+	vcc := VClusterCommands{VClusterCommandsLogger: VClusterCommandsLogger{Log: vlog.Printer{}}, Notifier: notifier}
+	vcc.audit(commandStopDB, &options.DatabaseOptions, &options, time.Now(), nil)
+	runErr := errors.New("fail to stop the database")
+	vcc.audit(commandStopDB, &options.DatabaseOptions, &options, time.Now(), &runErr)
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, received, posted)
+	assert.Equal(t, commandStopDB, received[0].Command)
+	assert.Equal(t, []string{"vnode1"}, received[0].Hosts)
+	assert.Equal(t, SuccessResult, received[0].Result)
+	assert.Contains(t, received[0].Text, "vcluster stop_db on database test_db succeeded")
+	assert.Equal(t, FailureResult, received[1].Result)
+	assert.Equal(t, "fail to stop the database", received[1].Error)
+	assert.Contains(t, received[1].Text, "fail to stop the database")
+}
+
+func TestNotifierWebhookFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := MakeNotifier()
+	err := notifier.postToWebhook(server.URL, []byte(`{}`))
+	assert.ErrorContains(t, err, "500")
+}
@@ -0,0 +1,179 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ResumeOptions are the options of the commands that can be resumed after
+// they were interrupted
+type ResumeOptions struct {
+	// path of the file in which the progress of the command is recorded,
+	// no progress is recorded if empty
+	JournalPath string
+	// whether to resume the run recorded in JournalPath, instead of starting over
+	Resume bool
+}
+
+func (options *ResumeOptions) validateResumeOptions() error {
+	if options.Resume && options.JournalPath == "" {
+		return fmt.Errorf("must specify the journal of the run to resume")
+	}
+	return nil
+}
+
+// makeJournal returns the journal of the command, or nil if no journal path is set
+func (options *ResumeOptions) makeJournal(command, dbName string) (*opJournal, error) {
+	if options.JournalPath == "" {
+		return nil, nil
+	}
+	return makeOpJournal(options.JournalPath, command, dbName, options.Resume)
+}
+
+// opJournal records the checkpointed ops of a command that completed, so
+// that a command that was interrupted, e.g., by a crash of the admin host,
+// can be resumed from the first op that did not complete.
+// All the methods of opJournal can be called on a nil journal.
+type opJournal struct {
+	path         string
+	Command      string           `json:"command"`
+	DBName       string           `json:"db_name"`
+	CompletedOps []opJournalEntry `json:"completed_ops"`
+}
+
+type opJournalEntry struct {
+	// position of the op in the instructions of the engine
+	Index       int      `json:"index"`
+	Name        string   `json:"name"`
+	Hosts       []string `json:"hosts"`
+	CompletedAt string   `json:"completed_at"`
+}
+
+const journalFilePermissions = 0600
+
+// makeOpJournal creates the journal of a command in path. If resume is true,
+// the journal that a previous run of the same command left in path is loaded.
+// Otherwise, path must not exist, so that the progress of an interrupted run
+// is not lost by mistake.
+func makeOpJournal(path, command, dbName string, resume bool) (*opJournal, error) {
+	journal := &opJournal{path: path, Command: command, DBName: dbName}
+	if !resume {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("journal %s of a previous run exists, resume the previous run or remove the journal",
+				path)
+		}
+		return journal, journal.write()
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read journal %s to resume %s: %w", path, command, err)
+	}
+	loaded := opJournal{}
+	err = json.Unmarshal(content, &loaded)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse journal %s: %w", path, err)
+	}
+	if loaded.Command != command || loaded.DBName != dbName {
+		return nil, fmt.Errorf("journal %s was written by %s of database %s, cannot resume %s of database %s",
+			path, loaded.Command, loaded.DBName, command, dbName)
+	}
+	journal.CompletedOps = loaded.CompletedOps
+	return journal, nil
+}
+
+// isCompleted returns true if op, at position index in the instructions,
+// is checkpointed and completed in a previous run
+func (journal *opJournal) isCompleted(index int, op clusterOp) bool {
+	if !op.isCheckpointed() {
+		return false
+	}
+	return journal.isStepCompleted(index, op.getName())
+}
+
+// isStepCompleted returns true if the step with the given position and name
+// completed in a previous run. A step is an op, or a group of ops of
+// a command that runs several engines, e.g., the upgrade of a subcluster.
+func (journal *opJournal) isStepCompleted(index int, name string) bool {
+	if journal == nil {
+		return false
+	}
+	for _, entry := range journal.CompletedOps {
+		if entry.Index == index && entry.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// recordCompleted writes the completion of op, at position index in
+// the instructions, to the journal if the op is checkpointed
+func (journal *opJournal) recordCompleted(index int, op clusterOp) error {
+	if !op.isCheckpointed() {
+		return nil
+	}
+	return journal.recordStepCompleted(index, op.getName(), op.getHosts())
+}
+
+// recordStepCompleted writes the completion of a step to the journal
+func (journal *opJournal) recordStepCompleted(index int, name string, hosts []string) error {
+	if journal == nil {
+		return nil
+	}
+	journal.CompletedOps = append(journal.CompletedOps, opJournalEntry{
+		Index:       index,
+		Name:        name,
+		Hosts:       hosts,
+		CompletedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	return journal.write()
+}
+
+// write replaces the journal file in one step, so that a crash
+// while writing does not leave a partial journal
+func (journal *opJournal) write() error {
+	content, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("fail to marshal journal %s: %w", journal.path, err)
+	}
+	tmpPath := journal.path + ".tmp"
+	err = os.WriteFile(tmpPath, content, journalFilePermissions)
+	if err != nil {
+		return fmt.Errorf("fail to write journal %s: %w", journal.path, err)
+	}
+	err = os.Rename(tmpPath, journal.path)
+	if err != nil {
+		return fmt.Errorf("fail to write journal %s: %w", journal.path, err)
+	}
+	return nil
+}
+
+// remove deletes the journal once the command has completed
+func (journal *opJournal) remove() error {
+	if journal == nil {
+		return nil
+	}
+	err := os.Remove(journal.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("fail to remove journal %s: %w", journal.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	hosts := []string{"192.168.1.101", "192.168.1.102"}
+	checkpointedOp := makeNMAHealthOp(hosts)
+	checkpointedOp.setCheckpointed()
+	healthOp := makeNMAHealthOp(hosts)
+
+	// a resume needs a journal
+	_, err := makeOpJournal(path, commandCreateDB, "test_db", true)
+	assert.Error(t, err)
+
+	journal, err := makeOpJournal(path, commandCreateDB, "test_db", false)
+	assert.NoError(t, err)
+	assert.NoError(t, journal.recordCompleted(0, &healthOp))
+	assert.NoError(t, journal.recordCompleted(1, &checkpointedOp))
+	// only the checkpointed ops are recorded
+	assert.Len(t, journal.CompletedOps, 1)
+
+	// a new run cannot overwrite the journal of an interrupted run
+	_, err = makeOpJournal(path, commandCreateDB, "test_db", false)
+	assert.ErrorContains(t, err, "resume the previous run")
+
+	// the journal of another command cannot be resumed
+	_, err = makeOpJournal(path, commandReviveDB, "test_db", true)
+	assert.Error(t, err)
+
+	resumed, err := makeOpJournal(path, commandCreateDB, "test_db", true)
+	assert.NoError(t, err)
+	assert.True(t, resumed.isCompleted(1, &checkpointedOp))
+	assert.False(t, resumed.isCompleted(2, &checkpointedOp))
+	assert.False(t, resumed.isCompleted(0, &healthOp))
+	assert.Equal(t, hosts, resumed.CompletedOps[0].Hosts)
+
+	assert.NoError(t, resumed.remove())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	// a nil journal records nothing
+	var noJournal *opJournal
+	assert.False(t, noJournal.isCompleted(1, &checkpointedOp))
+	assert.NoError(t, noJournal.recordCompleted(1, &checkpointedOp))
+	assert.NoError(t, noJournal.remove())
+}
@@ -0,0 +1,186 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// VPauseSubclusterOptions represents the available options when you pause
+// a secondary subcluster, i.e., scale it down to zero nodes.
+type VPauseSubclusterOptions struct {
+	DatabaseOptions
+	SCName       string // subcluster to pause
+	DrainSeconds int    // time in seconds to wait for the users of the subcluster to disconnect
+	ForceDelete  bool   // whether force delete directories
+}
+
+func VPauseSubclusterOptionsFactory() VPauseSubclusterOptions {
+	options := VPauseSubclusterOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VPauseSubclusterOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.DrainSeconds = util.DefaultDrainSeconds
+	options.ForceDelete = true
+}
+
+func (options *VPauseSubclusterOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandPauseSubcluster, logger)
+	if err != nil {
+		return err
+	}
+
+	if !options.IsEon {
+		return fmt.Errorf("cannot pause subcluster in an enterprise database '%s'", options.DBName)
+	}
+
+	if options.SCName == "" {
+		return fmt.Errorf("must specify a subcluster name")
+	}
+	err = util.ValidateScName(options.SCName)
+	if err != nil {
+		return err
+	}
+
+	if options.DrainSeconds < 0 {
+		return fmt.Errorf("drain seconds cannot be negative")
+	}
+
+	if options.DepotPrefix != "" {
+		return util.ValidateRequiredAbsPath(options.DepotPrefix, "depot path")
+	}
+	return nil
+}
+
+func (options *VPauseSubclusterOptions) analyzeOptions() (err error) {
+	// we analyze host names when it is set in user input, otherwise we use hosts in yaml config
+	if len(options.RawHosts) > 0 {
+		// resolve RawHosts to be IP addresses
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+		options.normalizePaths()
+	}
+	return nil
+}
+
+func (options *VPauseSubclusterOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	err := options.analyzeOptions()
+	if err != nil {
+		return err
+	}
+	return options.setUsePassword(logger)
+}
+
+// VPauseSubcluster scales a secondary subcluster down to zero nodes. Unlike
+// VRemoveSubcluster, the subcluster itself is kept in the catalog with its
+// name, type and settings, so that compute can be restored later by adding
+// nodes to it with VAddNode, which rebalances its shards again.
+// VPauseSubcluster has three major phases:
+//  1. Pre-check: check that the subcluster exists, is a secondary subcluster
+//     and is not sandboxed, and get its nodes.
+//  2. Stop the subcluster: wait for its users to disconnect and stop its
+//     nodes that are up.
+//  3. Remove the nodes: run VRemoveNode on all the nodes of the subcluster.
+//
+// It returns the updated database catalog information. Pausing a subcluster
+// that has no node is a no-op.
+func (vcc VClusterCommands) VPauseSubcluster(options *VPauseSubclusterOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandPauseSubcluster, &options.DatabaseOptions, options, time.Now(), &err)
+	vdb := makeVCoordinationDatabase()
+
+	// validate and analyze options
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return vdb, err
+	}
+
+	vcc.PrintInfo("Performing pause_subcluster pre-checks")
+	hostsToRemove, err := vcc.pauseSubclusterPreCheck(&vdb, options)
+	if err != nil {
+		return vdb, err
+	}
+	if len(hostsToRemove) == 0 {
+		vcc.PrintInfo("Subcluster %s has no node, it is already paused", options.SCName)
+		return vdb, nil
+	}
+
+	if vdb.hasUpNodes(hostsToRemove) {
+		vcc.PrintInfo("Stopping subcluster %s", options.SCName)
+		stopScOpt := VStopSubclusterOptionsFactory()
+		stopScOpt.DatabaseOptions = options.DatabaseOptions
+		stopScOpt.SCName = options.SCName
+		stopScOpt.DrainSeconds = options.DrainSeconds
+		err = vcc.VStopSubcluster(&stopScOpt)
+		if err != nil {
+			return vdb, fmt.Errorf("fail to stop subcluster %s, details: %w", options.SCName, err)
+		}
+	}
+
+	removeNodeOpt := VRemoveNodeOptionsFactory()
+	removeNodeOpt.DatabaseOptions = options.DatabaseOptions
+	removeNodeOpt.HostsToRemove = hostsToRemove
+	removeNodeOpt.ForceDelete = options.ForceDelete
+	removeNodeOpt.IsSubcluster = true
+
+	vcc.PrintInfo("Removing nodes %q from subcluster %s", hostsToRemove, options.SCName)
+	return vcc.VRemoveNode(&removeNodeOpt)
+}
+
+// pauseSubclusterPreCheck checks that the subcluster can be paused and returns
+// the hosts of its nodes. It reuses the remove_subcluster pre-checks, which
+// reject unknown, sandboxed and default subclusters.
+func (vcc VClusterCommands) pauseSubclusterPreCheck(vdb *VCoordinationDatabase,
+	options *VPauseSubclusterOptions) ([]string, error) {
+	removeScOpt := VRemoveScOptionsFactory()
+	removeScOpt.DatabaseOptions = options.DatabaseOptions
+	removeScOpt.SCName = options.SCName
+
+	hosts, err := vcc.removeScPreCheck(vdb, &removeScOpt)
+	if err != nil {
+		return hosts, err
+	}
+	return hosts, checkPauseSubclusterNodes(vdb, options.SCName, hosts)
+}
+
+// checkPauseSubclusterNodes returns an error if any node of the subcluster
+// is primary, as removing primary nodes would change the quorum of the
+// database and could not be undone by adding nodes back.
+func checkPauseSubclusterNodes(vdb *VCoordinationDatabase, scName string, hosts []string) error {
+	for _, host := range hosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			return fmt.Errorf("cannot find host %s in the database", host)
+		}
+		if vnode.IsPrimary {
+			return fmt.Errorf("cannot pause subcluster %s because it is a primary subcluster", scName)
+		}
+	}
+	return nil
+}
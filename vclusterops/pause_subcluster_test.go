@@ -0,0 +1,66 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestPauseSubclusterOptions(t *testing.T) {
+	options := VPauseSubclusterOptionsFactory()
+	options.RawHosts = []string{"vnode1", "vnode2"}
+	options.Password = new(string)
+	options.DBName = dbName
+	options.IsEon = true
+
+	err := options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify a subcluster name")
+
+	options.SCName = "sc1"
+	options.IsEon = false
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "cannot pause subcluster in an enterprise database")
+	options.IsEon = true
+
+	options.DrainSeconds = -1
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "drain seconds cannot be negative")
+	options.DrainSeconds = 0
+
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.NoError(t, err)
+}
+
+func TestCheckPauseSubclusterNodes(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.168.1.101": &VCoordinationNode{Name: "node1", Subcluster: "default_subcluster", IsPrimary: true},
+		"192.168.1.102": &VCoordinationNode{Name: "node2", Subcluster: "sc1", State: "UP"},
+		"192.168.1.103": &VCoordinationNode{Name: "node3", Subcluster: "sc1", State: "DOWN"},
+	}
+
+	assert.NoError(t, checkPauseSubclusterNodes(&vdb, "sc1", []string{"192.168.1.102", "192.168.1.103"}))
+	assert.ErrorContains(t, checkPauseSubclusterNodes(&vdb, "default_subcluster", []string{"192.168.1.101"}),
+		"it is a primary subcluster")
+	assert.ErrorContains(t, checkPauseSubclusterNodes(&vdb, "sc1", []string{"192.168.1.104"}),
+		"cannot find host 192.168.1.104")
+
+	assert.True(t, vdb.hasUpNodes([]string{"192.168.1.102", "192.168.1.103"}))
+	assert.False(t, vdb.hasUpNodes([]string{"192.168.1.103"}))
+}
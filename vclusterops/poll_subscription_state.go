@@ -0,0 +1,185 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VPollSubscriptionStateOptions struct {
+	DatabaseOptions
+	// Name of the subcluster whose shard subscriptions are polled
+	SCName string
+	// Number of seconds to wait for the subscriptions to be ACTIVE
+	TimeoutSeconds int
+}
+
+// ShardSubscription is the subscription of a node to a shard
+type ShardSubscription struct {
+	NodeName  string `json:"node_name"`
+	ShardName string `json:"shard_name"`
+	State     string `json:"state"`
+}
+
+// VSubscriptionState describes the shard subscriptions of the up nodes of
+// a subcluster when the polling stopped
+type VSubscriptionState struct {
+	SCName      string `json:"subcluster"`
+	ActiveCount int    `json:"active_count"`
+	TotalCount  int    `json:"total_count"`
+	// the subscriptions that are not ACTIVE, sorted by node and shard.
+	// It is empty once all the subscriptions are ACTIVE.
+	Pending []ShardSubscription `json:"pending"`
+}
+
+func VPollSubscriptionStateOptionsFactory() VPollSubscriptionStateOptions {
+	options := VPollSubscriptionStateOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VPollSubscriptionStateOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.TimeoutSeconds = StartupPollingTimeout
+}
+
+func (options *VPollSubscriptionStateOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandPollSubscriptionState, logger)
+	if err != nil {
+		return err
+	}
+	if options.SCName == "" {
+		return fmt.Errorf("must specify a subcluster name")
+	}
+	err = util.ValidateScName(options.SCName)
+	if err != nil {
+		return err
+	}
+	if options.TimeoutSeconds <= 0 {
+		return fmt.Errorf("timeout must be a positive number of seconds, got %d", options.TimeoutSeconds)
+	}
+	return nil
+}
+
+func (options *VPollSubscriptionStateOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VPollSubscriptionStateOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VPollSubscriptionState waits for all the shard subscriptions of the up
+// nodes of a subcluster to be ACTIVE, e.g., after add_node or
+// rebalance_shards, and reports the progress while it waits. The state of
+// the subscriptions when the polling stopped is returned, also on timeout.
+func (vcc VClusterCommands) VPollSubscriptionState(options *VPollSubscriptionStateOptions) (state VSubscriptionState, err error) {
+	defer vcc.audit(commandPollSubscriptionState, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return state, err
+	}
+	state.SCName = options.SCName
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return state, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if !vdb.IsEon {
+		return state, fmt.Errorf("database %s is not an Eon database, shard subscriptions only exist in Eon mode", options.DBName)
+	}
+
+	_, nodesToPoll, err := getSubclustersToRebalance(options.SCName, &vdb)
+	if err != nil {
+		return state, err
+	}
+	if len(nodesToPoll) == 0 {
+		return state, fmt.Errorf("subcluster %s does not have any up node", options.SCName)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return state, err
+	}
+
+	httpsPollSubscriptionStateOp, err := makeHTTPSPollSubscriptionStateOp([]string{initiator},
+		options.usePassword, options.UserName, options.Password, &nodesToPoll)
+	if err != nil {
+		return state, err
+	}
+	httpsPollSubscriptionStateOp.timeout = options.TimeoutSeconds
+	instructions := []clusterOp{&httpsPollSubscriptionStateOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	runErr := clusterOpEngine.run(vcc.Log)
+	state.setSubscriptions(httpsPollSubscriptionStateOp.lastSubscriptions)
+	if runErr != nil {
+		return state, fmt.Errorf("fail to wait for the subscriptions of subcluster %s, %d of %d are ACTIVE: %w",
+			options.SCName, state.ActiveCount, state.TotalCount, runErr)
+	}
+	return state, nil
+}
+
+// setSubscriptions counts the subscriptions and records the pending ones
+func (state *VSubscriptionState) setSubscriptions(subs []subscriptionInfo) {
+	state.TotalCount = len(subs)
+	state.ActiveCount = 0
+	state.Pending = []ShardSubscription{}
+	for _, s := range subs {
+		if s.SubscriptionState == "ACTIVE" {
+			state.ActiveCount++
+			continue
+		}
+		state.Pending = append(state.Pending, ShardSubscription{
+			NodeName:  s.Nodename,
+			ShardName: s.ShardName,
+			State:     s.SubscriptionState,
+		})
+	}
+	sort.Slice(state.Pending, func(i, j int) bool {
+		if state.Pending[i].NodeName != state.Pending[j].NodeName {
+			return state.Pending[i].NodeName < state.Pending[j].NodeName
+		}
+		return state.Pending[i].ShardName < state.Pending[j].ShardName
+	})
+}
@@ -0,0 +1,67 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidatePollSubscriptionStateOptions(t *testing.T) {
+	options := VPollSubscriptionStateOptionsFactory()
+	options.DBName = dbName
+	options.RawHosts = []string{"192.0.2.1"}
+	options.IsEon = true
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "must specify a subcluster name")
+
+	options.SCName = "sc1"
+	assert.Equal(t, StartupPollingTimeout, options.TimeoutSeconds)
+	assert.NoError(t, options.validateAnalyzeOptions(vlog.Printer{}))
+
+	options.TimeoutSeconds = 0
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "timeout must be a positive number")
+}
+
+func TestPollSubscriptionStateProgress(t *testing.T) {
+	nodesToPoll := []string{"v_test_db_node0002", "v_test_db_node0003"}
+	password := "testPwd"
+	op, err := makeHTTPSPollSubscriptionStateOp([]string{"192.0.2.1"}, true, "testUser", &password, &nodesToPoll)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: `{"subscription_list": [
+			{"node_name": "v_test_db_node0001", "shard_name": "segment0001", "subscription_state": "PENDING"},
+			{"node_name": "v_test_db_node0002", "shard_name": "segment0002", "subscription_state": "PASSIVE"},
+			{"node_name": "v_test_db_node0002", "shard_name": "segment0001", "subscription_state": "ACTIVE"},
+			{"node_name": "v_test_db_node0003", "shard_name": "segment0001", "subscription_state": "PENDING"}]}`},
+	}
+	stop, err := op.shouldStopPolling()
+	assert.NoError(t, err)
+	assert.False(t, stop)
+
+	// only the subscriptions of the polled nodes are reported
+	state := VSubscriptionState{SCName: "sc1"}
+	state.setSubscriptions(op.lastSubscriptions)
+	assert.Equal(t, 1, state.ActiveCount)
+	assert.Equal(t, 3, state.TotalCount)
+	assert.Equal(t, []ShardSubscription{
+		{NodeName: "v_test_db_node0002", ShardName: "segment0002", State: "PASSIVE"},
+		{NodeName: "v_test_db_node0003", ShardName: "segment0001", State: "PENDING"},
+	}, state.Pending)
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -109,11 +110,12 @@ func (options *VReIPOptions) validateAnalyzeOptions(logger vlog.Printer) error {
 		return errors.New("the re-ip list is not provided")
 	}
 
-	// address check
-	ipv6 := options.IPv6
+	// address check: the nodes can mix IPv4 and IPv6 addresses, but the
+	// control addresses of a node have the family of its new address
 	nodeAddresses := make(map[string]struct{})
 	for _, info := range options.ReIPList {
 		// the addresses must be valid IPs
+		ipv6 := options.isIPv6Address(info.TargetAddress)
 		if err := util.AddressCheck(info.TargetAddress, ipv6); err != nil {
 			return err
 		}
@@ -141,16 +143,27 @@ func (options *VReIPOptions) validateAnalyzeOptions(logger vlog.Printer) error {
 	return nil
 }
 
+// isIPv6Address returns the family of an address, detected per address as the
+// nodes can mix IPv4 and IPv6. An invalid address is checked against the
+// family of the IPv6 option, so that the error names the expected family.
+func (options *VReIPOptions) isIPv6Address(address string) bool {
+	if util.IsIPv4(address) {
+		return false
+	}
+	return util.IsIPv6(address) || options.IPv6
+}
+
 // VReIP changes the node address, control address, and control broadcast for a node.
 // It returns any error encountered.
-func (vcc VClusterCommands) VReIP(options *VReIPOptions) error {
+func (vcc VClusterCommands) VReIP(options *VReIPOptions) (err error) {
+	defer vcc.audit(commandReIP, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
 	 *   - Give the instructions to the VClusterOpEngine to run
 	 */
 
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -184,8 +197,8 @@ func (vcc VClusterCommands) VReIP(options *VReIPOptions) error {
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -318,11 +331,11 @@ func (options *VReIPOptions) ReadReIPFile(path string) error {
 		return nil
 	}
 
-	ipv6 := options.IPv6
 	for _, row := range reIPRows {
 		var info ReIPInfo
 		info.NodeAddress = row.CurrentAddress
-		if e := addressCheck(row.CurrentAddress, ipv6); e != nil {
+		// the family is detected per address, as the nodes can mix IPv4 and IPv6
+		if e := addressCheck(row.CurrentAddress, options.isIPv6Address(row.CurrentAddress)); e != nil {
 			return e
 		}
 
@@ -44,6 +44,21 @@ func TestReIPOptions(t *testing.T) {
 	opt.ReIPList = append(opt.ReIPList, info)
 	err = opt.validateAnalyzeOptions(vlog.Printer{})
 	assert.NoError(t, err)
+
+	// the new addresses can mix IPv4 and IPv6
+	info.NodeAddress = "192.168.1.101"
+	info.TargetAddress = "2001:db8::1"
+	opt.ReIPList = append(opt.ReIPList, info)
+	err = opt.validateAnalyzeOptions(vlog.Printer{})
+	assert.NoError(t, err)
+
+	// but the control address of a node has the family of its new address
+	info.NodeAddress = "192.168.1.102"
+	info.TargetAddress = "2001:db8::2"
+	info.TargetControlAddress = "10.0.0.2"
+	opt.ReIPList = append(opt.ReIPList, info)
+	err = opt.validateAnalyzeOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "10.0.0.2 in the re-ip file is not a valid IPv6 address")
 }
 
 func TestReadReIPFile(t *testing.T) {
@@ -67,6 +82,12 @@ func TestReadReIPFile(t *testing.T) {
 	// ipv6 negative
 	err = opt.ReadReIPFile(currentDir + "/test_data/re_ip_v6_wrong.json")
 	assert.ErrorContains(t, err, "0:0:0:0:0:ffff:c0a8:016-6 in the re-ip file is not a valid IPv6 address")
+
+	// the nodes can mix IPv4 and IPv6 addresses
+	opt.ReIPList = nil
+	err = opt.ReadReIPFile(currentDir + "/test_data/re_ip_mixed.json")
+	assert.NoError(t, err)
+	assert.Len(t, opt.ReIPList, 2)
 }
 
 func TestTrimReIPList(t *testing.T) {
@@ -108,3 +129,30 @@ func TestTrimReIPList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, len(op.reIPList), 3)
 }
+
+func TestBuildStaleNodesReIPList(t *testing.T) {
+	nodesVDB := makeVCoordinationDatabase()
+	nodesVDB.HostNodeMap = makeVHostNodeMap()
+	nodesVDB.HostNodeMap["192.168.1.201"] = &VCoordinationNode{Name: "v_test_db_node0001"}
+	nodesVDB.HostNodeMap["192.168.1.102"] = &VCoordinationNode{Name: "v_test_db_node0002"}
+	nodesVDB.HostNodeMap["192.168.1.203"] = &VCoordinationNode{Name: "v_test_db_node0003"}
+	nodesVDB.HostNodeMap["192.168.1.204"] = &VCoordinationNode{Name: "v_test_db_node0004"}
+
+	catalogVDB := nmaVDatabase{Nodes: []nmaVNode{
+		{Name: "v_test_db_node0001", Address: "192.168.1.101"},
+		{Name: "v_test_db_node0002", Address: "192.168.1.102"},
+		{Name: "v_test_db_node0003", Address: "192.168.1.103"},
+	}}
+
+	// node0002 has not moved, and node0004 is not in the catalog
+	reIPList := buildStaleNodesReIPList(&nodesVDB, &catalogVDB)
+	assert.Equal(t, []ReIPInfo{
+		{NodeName: "v_test_db_node0001", NodeAddress: "192.168.1.101", TargetAddress: "192.168.1.201"},
+		{NodeName: "v_test_db_node0003", NodeAddress: "192.168.1.103", TargetAddress: "192.168.1.203"},
+	}, reIPList)
+
+	// nothing to re-ip when the catalog is up to date
+	catalogVDB.Nodes[0].Address = "192.168.1.201"
+	catalogVDB.Nodes[2].Address = "192.168.1.203"
+	assert.Empty(t, buildStaleNodesReIPList(&nodesVDB, &catalogVDB))
+}
@@ -0,0 +1,113 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VRealignControlNodesOptions struct {
+	DatabaseOptions
+	// the number of control nodes of the database, in [1, 120], or
+	// util.DefaultControlSetSize to keep the current number
+	ControlSetSize int
+}
+
+func VRealignControlNodesOptionsFactory() VRealignControlNodesOptions {
+	options := VRealignControlNodesOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+	options.ControlSetSize = util.DefaultControlSetSize
+
+	return options
+}
+
+func (options *VRealignControlNodesOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRealignControlNodes, logger)
+	if err != nil {
+		return err
+	}
+	if options.ControlSetSize != util.DefaultControlSetSize &&
+		(options.ControlSetSize < ControlSetSizeLowerBound || options.ControlSetSize > ControlSetSizeUpperBound) {
+		return fmt.Errorf("control set size is out of bounds: valid values are %d or [%d to %d]",
+			util.DefaultControlSetSize, ControlSetSizeLowerBound, ControlSetSizeUpperBound)
+	}
+	return nil
+}
+
+// isLargeCluster returns true if some of the given hosts of the database are
+// not control nodes, in which case control nodes are assigned to them
+func (vdb *VCoordinationDatabase) isLargeCluster(hosts []string) bool {
+	for _, host := range hosts {
+		if vnode, ok := vdb.HostNodeMap[host]; ok && !vnode.IsControlNode {
+			return true
+		}
+	}
+	return false
+}
+
+// VRealignControlNodes re-balances the control nodes of a running database
+// across its nodes and fault groups, e.g., after nodes were added or removed.
+// It returns the name of the control node of each node. The nodes use their
+// new control nodes once they are restarted.
+func (vcc VClusterCommands) VRealignControlNodes(options *VRealignControlNodesOptions) (_ map[string]string, err error) {
+	defer vcc.audit(commandRealignControlNodes, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	httpsRealignControlNodesOp, err := makeHTTPSRealignControlNodesOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, options.ControlSetSize)
+	if err != nil {
+		return nil, err
+	}
+	httpsReloadSpreadOp, err := makeHTTPSReloadSpreadOpWithInitiator([]string{initiator}, options.usePassword,
+		options.UserName, options.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := []clusterOp{&httpsRealignControlNodesOp, &httpsReloadSpreadOp}
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to realign the control nodes: %w", err)
+	}
+
+	vcc.Log.PrintInfo("Realigned the control nodes of database %s, restart the nodes to use them", options.DBName)
+	return httpsRealignControlNodesOp.controlNodes, nil
+}
@@ -0,0 +1,62 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateRealignControlNodesOptions(t *testing.T) {
+	logger := vlog.Printer{}
+	options := VRealignControlNodesOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.0.2.1"}
+	assert.NoError(t, options.validateParseOptions(logger))
+
+	options.ControlSetSize = 0
+	assert.ErrorContains(t, options.validateParseOptions(logger), "out of bounds")
+	options.ControlSetSize = 5
+	assert.NoError(t, options.validateParseOptions(logger))
+}
+
+func TestIsLargeCluster(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Address: "192.0.2.1", IsControlNode: true},
+		"192.0.2.2": {Address: "192.0.2.2", IsControlNode: true},
+		"192.0.2.3": {Address: "192.0.2.3"},
+	}
+	assert.False(t, vdb.isLargeCluster([]string{"192.0.2.1", "192.0.2.2"}))
+	assert.True(t, vdb.isLargeCluster([]string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}))
+}
+
+func TestRealignControlNodesRequest(t *testing.T) {
+	password := "password"
+	op, err := makeHTTPSRealignControlNodesOp([]string{"192.0.2.1"}, true, "dbadmin", &password,
+		util.DefaultControlSetSize)
+	assert.NoError(t, err)
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	assert.Empty(t, op.clusterHTTPRequest.RequestCollection["192.0.2.1"].QueryParams)
+
+	op.controlSetSize = 5
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	assert.Equal(t, "5", op.clusterHTTPRequest.RequestCollection["192.0.2.1"].QueryParams["control-set-size"])
+}
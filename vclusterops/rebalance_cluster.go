@@ -0,0 +1,168 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VRebalanceClusterOptions struct {
+	DatabaseOptions
+	// interval, in seconds, between two fetches of the rebalance progress
+	ProgressIntervalSeconds int
+	// if set, called with the rebalance progress each time it is fetched
+	ProgressCallback func(progress RebalanceProgress) `json:"-"`
+}
+
+func VRebalanceClusterOptionsFactory() VRebalanceClusterOptions {
+	options := VRebalanceClusterOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VRebalanceClusterOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.ProgressIntervalSeconds = PollingInterval
+}
+
+func (options *VRebalanceClusterOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRebalanceCluster, logger)
+	if err != nil {
+		return err
+	}
+	if options.ProgressIntervalSeconds <= 0 {
+		return fmt.Errorf("the rebalance progress interval must be positive, got %d", options.ProgressIntervalSeconds)
+	}
+	return nil
+}
+
+func (options *VRebalanceClusterOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VRebalanceClusterOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VRebalanceCluster rebalances the data of an Enterprise database across its
+// nodes. While the rebalance runs, its progress is fetched at regular
+// intervals, printed, and passed to options.ProgressCallback.
+func (vcc VClusterCommands) VRebalanceCluster(options *VRebalanceClusterOptions) (err error) {
+	defer vcc.audit(commandRebalanceCluster, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Rebalance the cluster, while fetching the progress in the background
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if vdb.IsEon {
+		return fmt.Errorf("database %s is an Eon database, use rebalance shards instead", options.DBName)
+	}
+
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return err
+	}
+	httpsRebalanceClusterOp, err := makeHTTPSRebalanceClusterOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password)
+	if err != nil {
+		return err
+	}
+	instructions := []clusterOp{&httpsRebalanceClusterOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		vcc.reportRebalanceProgress(options, initiator, done)
+	}()
+	err = clusterOpEngine.run(vcc.Log)
+	close(done)
+	wg.Wait()
+	if err != nil {
+		return fmt.Errorf("fail to rebalance the cluster: %w", err)
+	}
+	return nil
+}
+
+// reportRebalanceProgress fetches the rebalance progress from the initiator
+// until done is closed. A failure to get the progress does not stop the
+// rebalance: it is only logged.
+func (vcc VClusterCommands) reportRebalanceProgress(options *VRebalanceClusterOptions,
+	initiator string, done <-chan struct{}) {
+	ticker := time.NewTicker(time.Duration(options.ProgressIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	certs := options.buildHTTPSCerts()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		var progress RebalanceProgress
+		op, err := makeHTTPSGetRebalanceProgressOp([]string{initiator}, options.usePassword,
+			options.UserName, options.Password, &progress)
+		if err != nil {
+			vcc.Log.Error(err, "fail to make the rebalance progress op")
+			return
+		}
+		clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&op}, &certs)
+		err = clusterOpEngine.run(vcc.Log)
+		if err != nil {
+			vcc.Log.Info("fail to get the rebalance progress", "error", err.Error())
+			continue
+		}
+		vcc.Log.PrintInfo("Rebalance progress: %.1f%% (%d of %d projections)",
+			progress.PercentComplete, progress.RebalancedProjections, progress.TotalProjections)
+		if options.ProgressCallback != nil {
+			options.ProgressCallback(progress)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateRebalanceClusterOptions(t *testing.T) {
+	options := VRebalanceClusterOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+	assert.Equal(t, PollingInterval, options.ProgressIntervalSeconds)
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	options.ProgressIntervalSeconds = 0
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+}
+
+func TestAuditRebalanceClusterOptions(t *testing.T) {
+	// the progress callback must not prevent the options from being audited
+	options := VRebalanceClusterOptionsFactory()
+	options.DBName = "test_db"
+	options.ProgressCallback = func(_ RebalanceProgress) {}
+	optionsMap := maskAuditOptions(&options)
+	assert.NotNil(t, optionsMap)
+	assert.NotContains(t, optionsMap, "ProgressCallback")
+}
@@ -0,0 +1,183 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VRebalanceShardsOptions struct {
+	DatabaseOptions
+	// Name of the subcluster whose shards are rebalanced. If empty, the shards
+	// of every subcluster of the main cluster are rebalanced.
+	SCName string
+}
+
+func VRebalanceShardsOptionsFactory() VRebalanceShardsOptions {
+	options := VRebalanceShardsOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VRebalanceShardsOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VRebalanceShardsOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRebalanceShards, logger)
+	if err != nil {
+		return err
+	}
+	if options.SCName != "" {
+		return util.ValidateScName(options.SCName)
+	}
+	return nil
+}
+
+func (options *VRebalanceShardsOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VRebalanceShardsOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VRebalanceShards rebalances the shards of a subcluster, or of every
+// subcluster of the main cluster, in an Eon database. It waits for all the
+// shard subscriptions of the rebalanced subclusters to be active.
+func (vcc VClusterCommands) VRebalanceShards(options *VRebalanceShardsOptions) (err error) {
+	defer vcc.audit(commandRebalanceShards, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if !vdb.IsEon {
+		return fmt.Errorf("database %s is not an Eon database, shards can only be rebalanced in Eon mode", options.DBName)
+	}
+
+	instructions, err := vcc.produceRebalanceShardsInstructions(options, &vdb)
+	if err != nil {
+		return fmt.Errorf("fail to produce instructions, %w", err)
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to rebalance shards: %w", err)
+	}
+	return nil
+}
+
+// produceRebalanceShardsInstructions will build a list of instructions to execute for
+// the rebalance shards operation.
+//
+// The generated instructions will later perform the following operations:
+//   - Rebalance the shards of each target subcluster
+//   - Poll the shard subscriptions of the up nodes of the target subclusters until they are active
+func (vcc VClusterCommands) produceRebalanceShardsInstructions(options *VRebalanceShardsOptions,
+	vdb *VCoordinationDatabase) ([]clusterOp, error) {
+	var instructions []clusterOp
+
+	scNames, nodesToPoll, err := getSubclustersToRebalance(options.SCName, vdb)
+	if err != nil {
+		return instructions, err
+	}
+
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return instructions, err
+	}
+	initiatorHost := []string{initiator}
+
+	err = vcc.produceRebalanceSubclusterShardsOps(&instructions, initiatorHost, scNames,
+		options.usePassword, options.UserName, options.Password)
+	if err != nil {
+		return instructions, err
+	}
+
+	httpsPollSubscriptionStateOp, err := makeHTTPSPollSubscriptionStateOp(initiatorHost,
+		options.usePassword, options.UserName, options.Password, &nodesToPoll)
+	if err != nil {
+		return instructions, err
+	}
+	instructions = append(instructions, &httpsPollSubscriptionStateOp)
+
+	return instructions, nil
+}
+
+// getSubclustersToRebalance returns the sorted names of the subclusters of
+// the main cluster to rebalance, and the names of their up nodes
+func getSubclustersToRebalance(scName string, vdb *VCoordinationDatabase) (scNames, upNodes []string, err error) {
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != util.MainClusterSandbox {
+			continue
+		}
+		if scName != "" && vnode.Subcluster != scName {
+			continue
+		}
+		if !util.StringInArray(vnode.Subcluster, scNames) {
+			scNames = append(scNames, vnode.Subcluster)
+		}
+		if vnode.State == util.NodeUpState {
+			upNodes = append(upNodes, vnode.Name)
+		}
+	}
+	if len(scNames) == 0 {
+		if scName == "" {
+			return nil, nil, fmt.Errorf("cannot find any subcluster in the main cluster")
+		}
+		return nil, nil, fmt.Errorf("cannot find subcluster %s in the main cluster", scName)
+	}
+	if len(upNodes) == 0 {
+		return nil, nil, fmt.Errorf("cannot find any up node in subclusters %v", scNames)
+	}
+	sort.Strings(scNames)
+	sort.Strings(upNodes)
+	return scNames, upNodes, nil
+}
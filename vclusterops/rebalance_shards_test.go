@@ -0,0 +1,71 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestGetSubclustersToRebalance(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	nodes := []VCoordinationNode{
+		{Name: "v_db_node0001", Address: "192.168.1.101", Subcluster: "sc1", State: util.NodeUpState},
+		{Name: "v_db_node0002", Address: "192.168.1.102", Subcluster: "sc2", State: util.NodeUpState},
+		{Name: "v_db_node0003", Address: "192.168.1.103", Subcluster: "sc2", State: util.NodeDownState},
+		{Name: "v_db_node0004", Address: "192.168.1.104", Subcluster: "sc3", Sandbox: "sand1",
+			State: util.NodeUpState},
+	}
+	for i := range nodes {
+		vdb.HostNodeMap[nodes[i].Address] = &nodes[i]
+	}
+
+	// all the subclusters of the main cluster
+	scNames, upNodes, err := getSubclustersToRebalance("", &vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sc1", "sc2"}, scNames)
+	assert.Equal(t, []string{"v_db_node0001", "v_db_node0002"}, upNodes)
+
+	// a single subcluster
+	scNames, upNodes, err = getSubclustersToRebalance("sc2", &vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sc2"}, scNames)
+	assert.Equal(t, []string{"v_db_node0002"}, upNodes)
+
+	// a sandboxed subcluster cannot be rebalanced
+	_, _, err = getSubclustersToRebalance("sc3", &vdb)
+	assert.ErrorContains(t, err, "cannot find subcluster sc3 in the main cluster")
+
+	// a subcluster without up nodes
+	nodes[1].State = util.NodeDownState
+	_, _, err = getSubclustersToRebalance("sc2", &vdb)
+	assert.ErrorContains(t, err, "cannot find any up node")
+}
+
+func TestCountActiveSubs(t *testing.T) {
+	subs := subscriptionList{SubscriptionList: []subscriptionInfo{
+		{Nodename: "v_db_node0001", ShardName: "replica", SubscriptionState: "ACTIVE"},
+		{Nodename: "v_db_node0001", ShardName: "segment0001", SubscriptionState: "PENDING"},
+		{Nodename: "v_db_node0002", ShardName: "segment0001", SubscriptionState: "ACTIVE"},
+	}}
+	nodesToPoll := []string{"v_db_node0001"}
+	activeCount, totalCount := countActiveSubs(&subs, &nodesToPoll)
+	assert.Equal(t, 1, activeCount)
+	assert.Equal(t, 2, totalCount)
+}
@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -31,6 +32,12 @@ type VRemoveNodeOptions struct {
 	Initiator     string   // A primary up host that will be used to execute remove_node operations.
 	ForceDelete   bool     // whether force delete directories
 	IsSubcluster  bool     // is removing all nodes for a subcluster
+	// whether to check that the data of the nodes to remove was rebalanced
+	// to other nodes before dropping them
+	VerifyRebalance bool
+	// close the client sessions of the nodes to remove before they are
+	// removed, instead of only reporting them
+	CloseSessions bool
 }
 
 func VRemoveNodeOptionsFactory() VRemoveNodeOptions {
@@ -46,6 +53,7 @@ func (options *VRemoveNodeOptions) setDefaultValues() {
 
 	options.ForceDelete = true
 	options.IsSubcluster = false
+	options.VerifyRebalance = false
 }
 
 func (options *VRemoveNodeOptions) validateRequiredOptions(logger vlog.Printer) error {
@@ -107,11 +115,12 @@ func (options *VRemoveNodeOptions) validateAnalyzeOptions(log vlog.Printer) erro
 	return options.setUsePassword(log)
 }
 
-func (vcc VClusterCommands) VRemoveNode(options *VRemoveNodeOptions) (VCoordinationDatabase, error) {
+func (vcc VClusterCommands) VRemoveNode(options *VRemoveNodeOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandRemoveNode, &options.DatabaseOptions, options, time.Now(), &err)
 	vdb := makeVCoordinationDatabase()
 
 	// validate and analyze options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return vdb, err
 	}
@@ -163,6 +172,16 @@ func (vcc VClusterCommands) removeNodesInCatalog(options *VRemoveNodeOptions, vd
 		return *vdb, err
 	}
 
+	// the client sessions of the nodes to remove are dropped with the nodes
+	filter := SessionFilter{}
+	for _, host := range options.HostsToRemove {
+		filter.NodeNames = append(filter.NodeNames, vdb.HostNodeMap[host].Name)
+	}
+	err = vcc.drainSessions(&options.DatabaseOptions, vdb, &filter, options.CloseSessions)
+	if err != nil {
+		return *vdb, err
+	}
+
 	instructions, err := vcc.produceRemoveNodeInstructions(vdb, options)
 	if err != nil {
 		return *vdb, fmt.Errorf("fail to produce remove node instructions, %w", err)
@@ -170,8 +189,8 @@ func (vcc VClusterCommands) removeNodesInCatalog(options *VRemoveNodeOptions, vd
 
 	remainingHosts := util.SliceDiff(vdb.HostList, options.HostsToRemove)
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	if runError := clusterOpEngine.run(vcc.Log); runError != nil {
 		// If the machines of the to-be-removed nodes crashed or get killed,
 		// the run error may be ignored.
@@ -201,8 +220,8 @@ func (vcc VClusterCommands) handleRemoveNodeForHostsNotInCatalog(vdb *VCoordinat
 	nmaGetNodesInfoOp := makeNMAGetNodesInfoOp(missingHosts, options.DBName, options.CatalogPrefix,
 		false /* report all errors */, vdb)
 	instructions := []clusterOp{&nmaGetNodesInfoOp}
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	opEng := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	opEng := vcc.makeClusterOpEngine(instructions, &certs)
 	err := opEng.run(vcc.Log)
 	if err != nil {
 		return *vdb, fmt.Errorf("failed to get node info for missing hosts: %w", err)
@@ -223,7 +242,7 @@ func (vcc VClusterCommands) handleRemoveNodeForHostsNotInCatalog(vdb *VCoordinat
 		return *vdb, err
 	}
 	instructions = []clusterOp{&nmaDeleteDirectoriesOp}
-	opEng = makeClusterOpEngine(instructions, &certs)
+	opEng = vcc.makeClusterOpEngine(instructions, &certs)
 	err = opEng.run(vcc.Log)
 	if err != nil {
 		return *vdb, fmt.Errorf("failed to delete directories for missing hosts: %w", err)
@@ -297,7 +316,7 @@ func getMainClusterNodes(vdb *VCoordinationDatabase, options *VRemoveNodeOptions
 func getSortedHosts(hostsToRemove []string, hostNodeMap vHostNodeMap) []string {
 	var sortedHosts []string
 	for _, host := range hostsToRemove {
-		if hostNodeMap[host].IsControlNode {
+		if vnode, ok := hostNodeMap[host]; ok && vnode.IsControlNode {
 			sortedHosts = append(sortedHosts, host)
 		} else {
 			sortedHosts = append([]string{host}, sortedHosts...)
@@ -315,6 +334,7 @@ func getSortedHosts(hostsToRemove []string, hostNodeMap vHostNodeMap) []string {
 //   - Mark nodes to remove as ephemeral
 //   - Rebalance cluster for Enterprise mode, rebalance shards for Eon mode
 //   - Poll subscription state, wait for all subscrptions ACTIVE for Eon mode
+//   - Verify the data was moved away from the nodes to remove (optional)
 //   - Remove secondary nodes from spread
 //   - Drop Nodes
 //   - Reload spread
@@ -378,6 +398,20 @@ func (vcc VClusterCommands) produceRemoveNodeInstructions(vdb *VCoordinationData
 		instructions = append(instructions, &httpsRBCOp)
 	}
 
+	// fail before any node is dropped if the data is still on the nodes to remove
+	if options.VerifyRebalance {
+		nodesToRemove, e := vdb.HostNodeMap.getNodeNames(options.HostsToRemove)
+		if e != nil {
+			return instructions, e
+		}
+		httpsVerifyRebalanceOp, e := makeHTTPSVerifyRebalanceOp(initiatorHost, usePassword, username,
+			password, vdb.IsEon, nodesToRemove)
+		if e != nil {
+			return instructions, e
+		}
+		instructions = append(instructions, &httpsVerifyRebalanceOp)
+	}
+
 	// only remove secondary nodes from spread
 	err = vcc.produceSpreadRemoveNodeOp(&instructions, options.HostsToRemove,
 		usePassword, username, password,
@@ -418,12 +452,16 @@ func (vcc VClusterCommands) produceRemoveNodeInstructions(vdb *VCoordinationData
 }
 
 // produceMarkEphemeralNodeOps gets a slice of target hosts and for each of them
-// produces an HTTPSMarkEphemeralNodeOp.
+// produces an HTTPSAlterNodeTypeOp that marks the node as ephemeral.
 func (vcc VClusterCommands) produceMarkEphemeralNodeOps(instructions *[]clusterOp, targetHosts, hosts []string,
 	useHTTPPassword bool, userName string, httpsPassword *string,
 	hostNodeMap vHostNodeMap) error {
 	for _, host := range targetHosts {
-		httpsMarkEphemeralNodeOp, err := makeHTTPSMarkEphemeralNodeOp(hostNodeMap[host].Name, hosts,
+		vnode, err := hostNodeMap.getNode(host)
+		if err != nil {
+			return err
+		}
+		httpsMarkEphemeralNodeOp, err := makeHTTPSMarkEphemeralNodeOp(vnode.Name, hosts,
 			useHTTPPassword, userName, httpsPassword)
 		if err != nil {
 			return err
@@ -455,9 +493,13 @@ func (vcc VClusterCommands) produceDropNodeOps(instructions *[]clusterOp, target
 	useHTTPPassword bool, userName string, httpsPassword *string,
 	hostNodeMap vHostNodeMap, isEon bool, isSubcluster bool) error {
 	for _, host := range targetHosts {
-		httpsDropNodeOp, err := makeHTTPSDropNodeOp(hostNodeMap[host].Name, hosts,
+		vnode, err := hostNodeMap.getNode(host)
+		if err != nil {
+			return err
+		}
+		httpsDropNodeOp, err := makeHTTPSDropNodeOp(vnode.Name, hosts,
 			useHTTPPassword, userName, httpsPassword,
-			isSubcluster || (isEon && hostNodeMap[host].State == util.NodeDownState))
+			isSubcluster || (isEon && vnode.State == util.NodeDownState))
 		if err != nil {
 			return err
 		}
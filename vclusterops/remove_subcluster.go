@@ -16,8 +16,9 @@
 package vclusterops
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/rfc7807"
 	"github.com/vertica/vcluster/vclusterops/util"
@@ -132,11 +133,12 @@ func (options *VRemoveScOptions) validateAnalyzeOptions(logger vlog.Printer) err
 //  1. Pre-check: check the subcluster name and get nodes for the subcluster.
 //  2. Removes nodes: Optional. If there are any nodes still associated with the subcluster, runs VRemoveNode.
 //  3. Drop the subcluster: Remove the subcluster name from the database catalog.
-func (vcc VClusterCommands) VRemoveSubcluster(removeScOpt *VRemoveScOptions) (VCoordinationDatabase, error) {
+func (vcc VClusterCommands) VRemoveSubcluster(removeScOpt *VRemoveScOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandRemoveSubcluster, &removeScOpt.DatabaseOptions, removeScOpt, time.Now(), &err)
 	vdb := makeVCoordinationDatabase()
 
 	// validate and analyze options
-	err := removeScOpt.validateAnalyzeOptions(vcc.Log)
+	err = removeScOpt.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return vdb, err
 	}
@@ -239,14 +241,13 @@ func (vcc VClusterCommands) removeScPreCheck(vdb *VCoordinationDatabase, options
 		&httpsFindSubclusterOp,
 	)
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
-		// VER-88585 will improve this rfc error flow
-		if strings.Contains(err.Error(), "does not exist in the database") {
+		rfcErr := &rfc7807.VProblem{}
+		if errors.As(err, &rfcErr) && rfcErr.IsInstanceOf(rfc7807.SubclusterNotFound) {
 			vcc.Log.PrintError("fail to get subclusters' information %s, %v", preCheckErrMsg, err)
-			rfcErr := rfc7807.New(rfc7807.SubclusterNotFound).WithHost(options.Hosts[0])
 			return hostsToRemove, rfcErr
 		}
 		return hostsToRemove, err
@@ -306,8 +307,8 @@ func (vcc VClusterCommands) dropSubcluster(vdb *VCoordinationDatabase, options *
 	var instructions []clusterOp
 	instructions = append(instructions, &httpsDropScOp)
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
 		vcc.Log.Error(err, "fail to drop subcluster, details: %v", dropScErrMsg)
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -56,7 +57,7 @@ func (options *VRenameSubclusterOptions) validateParseOptions(logger vlog.Printe
 	}
 
 	// need to provide a password or certs
-	if options.Password == nil && (options.Cert == "" || options.Key == "") {
+	if options.Password == nil && !options.hasHTTPSCredentials() {
 		return fmt.Errorf("must provide a password or certs")
 	}
 
@@ -101,7 +102,8 @@ func (options *VRenameSubclusterOptions) validateAnalyzeOptions(logger vlog.Prin
 }
 
 // VRenameSubcluster alter the name of the specified subcluster
-func (vcc VClusterCommands) VRenameSubcluster(options *VRenameSubclusterOptions) error {
+func (vcc VClusterCommands) VRenameSubcluster(options *VRenameSubclusterOptions) (err error) {
+	defer vcc.audit(commandRenameSc, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -109,7 +111,7 @@ func (vcc VClusterCommands) VRenameSubcluster(options *VRenameSubclusterOptions)
 	 */
 
 	// validate and analyze options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -128,8 +130,8 @@ func (vcc VClusterCommands) VRenameSubcluster(options *VRenameSubclusterOptions)
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
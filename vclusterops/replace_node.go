@@ -0,0 +1,228 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// VReplaceNodeOptions represents the available options for VReplaceNode.
+type VReplaceNodeOptions struct {
+	DatabaseOptions
+	// Host of the node to replace, usually a failed host
+	HostToReplace string
+	// Host that replaces it, in the same subcluster
+	NewHost string
+	// Depot size of the new node, e.g., 10G
+	DepotSize string
+	// whether force delete directories of the replaced node
+	ForceDelete bool
+}
+
+func VReplaceNodeOptionsFactory() VReplaceNodeOptions {
+	options := VReplaceNodeOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VReplaceNodeOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+
+	options.ForceDelete = true
+}
+
+func (options *VReplaceNodeOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandReplaceNode, logger)
+	if err != nil {
+		return err
+	}
+	if options.HostToReplace == "" || options.NewHost == "" {
+		return fmt.Errorf("must specify both the host to replace and the new host")
+	}
+	if options.DataPrefix != "" {
+		return util.ValidateRequiredAbsPath(options.DataPrefix, "data path")
+	}
+	return nil
+}
+
+func (options *VReplaceNodeOptions) analyzeOptions() (err error) {
+	hosts, err := util.ResolveRawHostsToAddresses([]string{options.HostToReplace, options.NewHost}, options.IPv6)
+	if err != nil {
+		return err
+	}
+	options.HostToReplace, options.NewHost = hosts[0], hosts[1]
+	if options.HostToReplace == options.NewHost {
+		return fmt.Errorf("the new host must be different from the host to replace %s", options.HostToReplace)
+	}
+
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+		options.normalizePaths()
+	}
+	return nil
+}
+
+func (options *VReplaceNodeOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	if err := options.analyzeOptions(); err != nil {
+		return err
+	}
+	return options.setUsePassword(logger)
+}
+
+// VReplaceNode replaces a node, usually on a failed host, by a new node on a
+// new host in the same subcluster. The new node is added first and, in Eon
+// mode, its shard subscriptions must be active before the replaced node is
+// removed, so the database never has fewer nodes than before. If the new node
+// cannot be added, it is cleaned up and the replaced node is kept.
+// It returns a VCoordinationDatabase that contains catalog information and any error encountered.
+func (vcc VClusterCommands) VReplaceNode(options *VReplaceNodeOptions) (_ VCoordinationDatabase, err error) {
+	defer vcc.audit(commandReplaceNode, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Check that the node to replace can be removed
+	 *   - Add the new node to the subcluster of the replaced node, clean it up on failure
+	 *   - Wait for the shard subscriptions of the new node to be active (Eon only)
+	 *   - Remove the replaced node
+	 */
+	vdb := makeVCoordinationDatabase()
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return vdb, err
+	}
+
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return vdb, err
+	}
+	oldNode, err := checkReplaceNodeRequirements(&vdb, options)
+	if err != nil {
+		return vdb, err
+	}
+
+	vcc.Log.PrintInfo("Adding host %s to subcluster %s to replace node %s", options.NewHost,
+		oldNode.Subcluster, oldNode.Name)
+	addNodeOptions := VAddNodeOptionsFactory()
+	addNodeOptions.DatabaseOptions = options.DatabaseOptions
+	addNodeOptions.NewHosts = []string{options.NewHost}
+	addNodeOptions.SCName = oldNode.Subcluster
+	addNodeOptions.DepotSize = options.DepotSize
+	addNodeOptions.ForceCleanupOnFailure = true
+	vdb, err = vcc.VAddNode(&addNodeOptions)
+	if err != nil {
+		return vdb, fmt.Errorf("fail to add host %s, node %s is not replaced: %w", options.NewHost, oldNode.Name, err)
+	}
+
+	if vdb.IsEon {
+		err = vcc.waitForNewNodeSubscriptions(options, &vdb)
+		if err != nil {
+			return vdb, errors.Join(err, vcc.rollbackReplaceNode(options))
+		}
+	}
+
+	vcc.Log.PrintInfo("Removing node %s on host %s", oldNode.Name, options.HostToReplace)
+	removeNodeOptions := VRemoveNodeOptionsFactory()
+	removeNodeOptions.DatabaseOptions = options.DatabaseOptions
+	removeNodeOptions.HostsToRemove = []string{options.HostToReplace}
+	removeNodeOptions.ForceDelete = options.ForceDelete
+	vdb, err = vcc.VRemoveNode(&removeNodeOptions)
+	if err != nil {
+		return vdb, fmt.Errorf("host %s was added but fail to remove node %s: %w", options.NewHost, oldNode.Name, err)
+	}
+	return vdb, nil
+}
+
+// checkReplaceNodeRequirements returns the node to replace, or an error if it
+// cannot be replaced
+func checkReplaceNodeRequirements(vdb *VCoordinationDatabase, options *VReplaceNodeOptions) (*VCoordinationNode, error) {
+	oldNode, ok := vdb.HostNodeMap[options.HostToReplace]
+	if !ok {
+		return nil, fmt.Errorf("host %s is not in database %s", options.HostToReplace, options.DBName)
+	}
+	if _, ok := vdb.HostNodeMap[options.NewHost]; ok {
+		return nil, fmt.Errorf("host %s is already in database %s", options.NewHost, options.DBName)
+	}
+	// fail before adding the new node if the old one cannot be removed
+	removeNodeOptions := VRemoveNodeOptions{HostsToRemove: []string{options.HostToReplace}}
+	if vdb.IsEon {
+		err := checkRemoveNodeRequirements(vdb, &removeNodeOptions)
+		return oldNode, err
+	}
+	// in Enterprise mode, all the nodes but the one to replace must be up
+	for host, vnode := range vdb.HostNodeMap {
+		if host != options.HostToReplace && vnode.State == util.NodeDownState {
+			return nil, fmt.Errorf("node %s is down, all the nodes but the one to replace must be up", vnode.Name)
+		}
+	}
+	if oldNode.State == util.NodeDownState {
+		return nil, fmt.Errorf("node %s is down, it must be up to be removed from an Enterprise database", oldNode.Name)
+	}
+	return oldNode, nil
+}
+
+// waitForNewNodeSubscriptions waits for the shard subscriptions of the new
+// node to be active
+func (vcc VClusterCommands) waitForNewNodeSubscriptions(options *VReplaceNodeOptions, vdb *VCoordinationDatabase) error {
+	newNode, ok := vdb.HostNodeMap[options.NewHost]
+	if !ok {
+		return fmt.Errorf("cannot find the new host %s in the database", options.NewHost)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{options.HostToReplace})
+	if err != nil {
+		return err
+	}
+	nodesToPoll := []string{newNode.Name}
+	httpsPollSubscriptionStateOp, err := makeHTTPSPollSubscriptionStateOp([]string{initiator},
+		options.usePassword, options.UserName, options.Password, &nodesToPoll)
+	if err != nil {
+		return err
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&httpsPollSubscriptionStateOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("the shard subscriptions of the new node %s are not active: %w", newNode.Name, err)
+	}
+	return nil
+}
+
+// rollbackReplaceNode removes the new node after it was added
+func (vcc VClusterCommands) rollbackReplaceNode(options *VReplaceNodeOptions) error {
+	vcc.Log.PrintWarning("Removing the new host %s, node on host %s is not replaced", options.NewHost, options.HostToReplace)
+	removeNodeOptions := VRemoveNodeOptionsFactory()
+	removeNodeOptions.DatabaseOptions = options.DatabaseOptions
+	removeNodeOptions.HostsToRemove = []string{options.NewHost}
+	_, err := vcc.VRemoveNode(&removeNodeOptions)
+	if err != nil {
+		return fmt.Errorf("fail to remove the new host %s: %w", options.NewHost, err)
+	}
+	return nil
+}
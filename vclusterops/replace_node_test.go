@@ -0,0 +1,74 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestCheckReplaceNodeRequirements(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	nodes := []VCoordinationNode{
+		{Name: "v_db_node0001", Address: "192.168.1.101", Subcluster: "sc1", State: util.NodeUpState},
+		{Name: "v_db_node0002", Address: "192.168.1.102", Subcluster: "sc1", State: util.NodeDownState},
+		{Name: "v_db_node0003", Address: "192.168.1.103", Subcluster: "sc2", Sandbox: "sand1",
+			State: util.NodeUpState},
+	}
+	for i := range nodes {
+		vdb.HostNodeMap[nodes[i].Address] = &nodes[i]
+	}
+	options := VReplaceNodeOptionsFactory()
+	options.DBName = "test_db"
+	options.HostToReplace = "192.168.1.102"
+	options.NewHost = "192.168.1.104"
+
+	// Eon: a down node can be replaced
+	vdb.IsEon = true
+	oldNode, err := checkReplaceNodeRequirements(&vdb, &options)
+	assert.NoError(t, err)
+	assert.Equal(t, "v_db_node0002", oldNode.Name)
+
+	// the new host cannot be in the database
+	options.NewHost = "192.168.1.101"
+	_, err = checkReplaceNodeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "is already in database")
+	options.NewHost = "192.168.1.104"
+
+	// the host to replace must be in the database
+	options.HostToReplace = "192.168.1.105"
+	_, err = checkReplaceNodeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "is not in database")
+
+	// a sandboxed node cannot be replaced
+	options.HostToReplace = "192.168.1.103"
+	_, err = checkReplaceNodeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "sandboxed")
+
+	// Enterprise: the node to replace must be up to be removed
+	vdb.IsEon = false
+	options.HostToReplace = "192.168.1.102"
+	_, err = checkReplaceNodeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "must be up to be removed")
+
+	// Enterprise: the other nodes must be up
+	options.HostToReplace = "192.168.1.101"
+	_, err = checkReplaceNodeRequirements(&vdb, &options)
+	assert.ErrorContains(t, err, "all the nodes but the one to replace must be up")
+}
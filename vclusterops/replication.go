@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -34,6 +35,8 @@ type VReplicationDatabaseOptions struct {
 	TargetPassword  *string
 	SourceTLSConfig string
 	SandboxName     string
+	// Namespace of the tables to replicate, empty for the default namespace
+	Namespace string
 }
 
 func VReplicationDatabaseFactory() VReplicationDatabaseOptions {
@@ -73,7 +76,7 @@ func (options *VReplicationDatabaseOptions) validateExtraOptions() error {
 	}
 
 	// need to provide a password or certs in source database
-	if options.Password == nil && (options.Cert == "" || options.Key == "") {
+	if options.Password == nil && !options.hasHTTPSCredentials() {
 		return fmt.Errorf("must provide a password or certs")
 	}
 
@@ -91,6 +94,13 @@ func (options *VReplicationDatabaseOptions) validateExtraOptions() error {
 		}
 	}
 
+	if options.Namespace != "" {
+		err = util.ValidateNamespaceName(options.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -144,7 +154,8 @@ func (options *VReplicationDatabaseOptions) validateAnalyzeOptions(logger vlog.P
 }
 
 // VReplicateDatabase can copy all table data and metadata from this cluster to another
-func (vcc VClusterCommands) VReplicateDatabase(options *VReplicationDatabaseOptions) error {
+func (vcc VClusterCommands) VReplicateDatabase(options *VReplicationDatabaseOptions) (err error) {
+	defer vcc.audit(commandReplicationStart, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -152,7 +163,7 @@ func (vcc VClusterCommands) VReplicateDatabase(options *VReplicationDatabaseOpti
 	 */
 
 	// validate and analyze options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -164,8 +175,8 @@ func (vcc VClusterCommands) VReplicateDatabase(options *VReplicationDatabaseOpti
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -224,7 +235,7 @@ func (vcc VClusterCommands) produceDBReplicationInstructions(options *VReplicati
 	initiatorTargetHost := getInitiator(options.TargetHosts)
 	httpsStartReplicationOp, err := makeHTTPSStartReplicationOp(options.DBName, options.Hosts, options.usePassword,
 		options.UserName, options.Password, targetUsePassword, options.TargetDB, options.TargetUserName, initiatorTargetHost,
-		options.TargetPassword, options.SourceTLSConfig, options.SandboxName)
+		options.TargetPassword, options.SourceTLSConfig, options.SandboxName, options.Namespace)
 	if err != nil {
 		return instructions, err
 	}
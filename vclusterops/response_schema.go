@@ -0,0 +1,149 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jsonType is the type of a field in a JSON response
+type jsonType string
+
+const (
+	jsonAny    jsonType = "any"
+	jsonString jsonType = "string"
+	jsonNumber jsonType = "number"
+	jsonBool   jsonType = "boolean"
+	jsonObject jsonType = "object"
+	jsonArray  jsonType = "array"
+)
+
+// responseField declares a field of a JSON response. The path of the field
+// is made of the names of the nested objects separated by dots. A name that
+// ends with "[]" is an array, whose elements all have the rest of the path,
+// e.g., "node_list[].address".
+type responseField struct {
+	path     string
+	typ      jsonType
+	optional bool
+}
+
+// responseSchema declares the fields that an op expects in the responses of
+// the hosts, so that parseAndCheckResponse rejects a response with a missing
+// or mistyped field, instead of each op checking its fields
+type responseSchema []responseField
+
+// validate returns a *ResponseSchemaError for the first field of the schema
+// that is missing from the response or has a wrong type
+func (schema responseSchema) validate(host, content string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	var response any
+	if err := json.Unmarshal([]byte(content), &response); err != nil {
+		return fmt.Errorf("fail to parse response %q from host %s as JSON: %w", content, host, err)
+	}
+	for _, field := range schema {
+		if err := field.check(host, "", strings.Split(field.path, "."), response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check looks for the remaining names of the path in value, which is at the
+// given parent path in the response
+func (field *responseField) check(host, parent string, names []string, value any) error {
+	if len(names) == 0 {
+		return field.checkType(host, parent, value)
+	}
+	name, isArray := strings.CutSuffix(names[0], "[]")
+	path := name
+	if parent != "" {
+		path = parent + "." + name
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return field.makeError(host, parent, jsonObject, value)
+	}
+	child, found := object[name]
+	if !found {
+		if field.optional {
+			return nil
+		}
+		return &ResponseSchemaError{Host: host, Field: path, Expected: string(field.expectedType(names)), Actual: responseFieldMissing}
+	}
+	if !isArray {
+		return field.check(host, path, names[1:], child)
+	}
+
+	elements, ok := child.([]any)
+	if !ok {
+		return field.makeError(host, path, jsonArray, child)
+	}
+	for i, element := range elements {
+		if err := field.check(host, fmt.Sprintf("%s[%d]", path, i), names[1:], element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expectedType returns the type of the value at the first name of the path
+func (field *responseField) expectedType(names []string) jsonType {
+	switch {
+	case strings.HasSuffix(names[0], "[]"):
+		return jsonArray
+	case len(names) > 1:
+		return jsonObject
+	default:
+		return field.typ
+	}
+}
+
+func (field *responseField) checkType(host, path string, value any) error {
+	if field.typ == jsonAny || getJSONType(value) == field.typ {
+		return nil
+	}
+	return field.makeError(host, path, field.typ, value)
+}
+
+func (field *responseField) makeError(host, path string, expected jsonType, value any) error {
+	if path == "" {
+		path = "(root)"
+	}
+	return &ResponseSchemaError{Host: host, Field: path, Expected: string(expected), Actual: string(getJSONType(value))}
+}
+
+func getJSONType(value any) jsonType {
+	switch value.(type) {
+	case string:
+		return jsonString
+	case float64:
+		return jsonNumber
+	case bool:
+		return jsonBool
+	case map[string]any:
+		return jsonObject
+	case []any:
+		return jsonArray
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseSchema(t *testing.T) {
+	schema := responseSchema{
+		{path: "node_list[].address", typ: jsonString},
+		{path: "node_list[].is_primary", typ: jsonBool},
+		{path: "cluster.shards", typ: jsonNumber},
+		{path: "sandbox", typ: jsonString, optional: true},
+	}
+	host := "192.0.2.1"
+
+	assert.NoError(t, schema.validate(host,
+		`{"node_list": [{"address": "192.0.2.1", "is_primary": true}], "cluster": {"shards": 6}}`))
+
+	err := schema.validate(host,
+		`{"node_list": [{"address": "192.0.2.1", "is_primary": true}, {"is_primary": false}], "cluster": {"shards": 6}}`)
+	var schemaErr *ResponseSchemaError
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.Equal(t, host, schemaErr.Host)
+	assert.Equal(t, "node_list[1].address", schemaErr.Field)
+	assert.ErrorContains(t, err, `does not contain the field "node_list[1].address"`)
+
+	err = schema.validate(host, `{"node_list": [], "cluster": {"shards": "6"}}`)
+	assert.ErrorContains(t, err, `the field "cluster.shards" in the response of host 192.0.2.1 is a(n) string, expected a(n) number`)
+
+	err = schema.validate(host, `{"node_list": {}, "cluster": {"shards": 6}}`)
+	assert.ErrorContains(t, err, `the field "node_list" in the response of host 192.0.2.1 is a(n) object, expected a(n) array`)
+
+	err = schema.validate(host, `{"node_list": [], "cluster": {"shards": 6}, "sandbox": 1}`)
+	assert.ErrorContains(t, err, `"sandbox"`)
+
+	err = schema.validate(host, `[]`)
+	assert.ErrorContains(t, err, `the field "(root)"`)
+}
+
+func TestParseAndCheckResponseWithSchema(t *testing.T) {
+	realignOp, err := makeHTTPSRealignControlNodesOp([]string{"192.0.2.1"}, false, "", nil, -1)
+	assert.NoError(t, err)
+
+	resp := realignControlNodesRsp{}
+	err = realignOp.parseAndCheckResponse("192.0.2.1", `{"detail": "done"}`, &resp)
+	assert.ErrorContains(t, err, `[HTTPSRealignControlNodesOp] the response of host 192.0.2.1 does not contain the field "control_nodes"`)
+
+	err = realignOp.parseAndCheckResponse("192.0.2.1", `{"control_nodes": {"v_db_node0001": "v_db_node0001"}}`, &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "v_db_node0001", resp.ControlNodes["v_db_node0001"])
+}
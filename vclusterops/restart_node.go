@@ -0,0 +1,190 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// VRestartNodeOptions represents the available options when you restart
+// some down nodes of a running database with VRestartNode.
+type VRestartNodeOptions struct {
+	// basic db info
+	DatabaseOptions
+	// The addresses, as stored in the catalog, of the down nodes to restart
+	RestartHosts []string
+	// Map from node name to the new address of the node, for down nodes that
+	// came back with a different address. These nodes are re-ip'ed and
+	// started in the same call. The map has the same keys as
+	// VStartNodesOptions.Nodes and the --restart option of restart_node.
+	NewAddresses map[string]string
+	// timeout for polling the state of the restarted nodes
+	StatePollingTimeout int
+	// If the path is set, the NMA will store the Vertica start command at the path
+	// instead of executing it. See VStartNodesOptions.StartUpConf.
+	StartUpConf string
+	// whether to print the new lines of vertica.log of the restarting nodes.
+	// See VStartNodesOptions.FollowLogs.
+	FollowLogs bool
+}
+
+func VRestartNodeOptionsFactory() VRestartNodeOptions {
+	options := VRestartNodeOptions{}
+
+	// set default values to the params
+	options.setDefaultValues()
+	return options
+}
+
+func (options *VRestartNodeOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.StatePollingTimeout = util.DefaultStatePollingTimeout
+	options.NewAddresses = make(map[string]string)
+}
+
+func (options *VRestartNodeOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRestartNode, logger)
+	if err != nil {
+		return err
+	}
+	if len(options.RestartHosts) == 0 && len(options.NewAddresses) == 0 {
+		return fmt.Errorf("must specify at least one host or node to restart")
+	}
+	return nil
+}
+
+func (options *VRestartNodeOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	options.RestartHosts, err = util.ResolveRawHostsToAddresses(options.RestartHosts, options.IPv6)
+	if err != nil {
+		return err
+	}
+	for nodeName, newHost := range options.NewAddresses {
+		newIP, err := util.ResolveToOneIP(newHost, options.IPv6)
+		if err != nil {
+			return err
+		}
+		options.NewAddresses[nodeName] = newIP
+	}
+	return nil
+}
+
+func (options *VRestartNodeOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VRestartNode restarts the given down nodes of a running database. Unlike
+// VStartNodes, the nodes can be given by their catalog addresses: the node names
+// are found in the catalog of an up node. A node is re-ip'ed before it is
+// started if a new address is given for it. Nodes that are already up are skipped.
+func (vcc VClusterCommands) VRestartNode(options *VRestartNodeOptions) (err error) {
+	defer vcc.audit(commandRestartNode, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Map the hosts to restart to node names
+	 *   - Start the down nodes with VStartNodes
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := options.buildNodesToRestart(&vdb, vcc.Log)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		vcc.Log.PrintInfo("All the given nodes are already up. There is nothing to restart.")
+		return nil
+	}
+
+	startNodesOptions := VStartNodesOptionsFactory()
+	startNodesOptions.DatabaseOptions = options.DatabaseOptions
+	startNodesOptions.Nodes = nodes
+	startNodesOptions.StatePollingTimeout = options.StatePollingTimeout
+	startNodesOptions.StartUpConf = options.StartUpConf
+	startNodesOptions.FollowLogs = options.FollowLogs
+	startNodesOptions.vdb = &vdb
+	return vcc.VStartNodes(&startNodesOptions)
+}
+
+// buildNodesToRestart maps the down nodes of RestartHosts and NewAddresses to
+// the address they must be started with. It fails if any host or node is not
+// in the catalog.
+func (options *VRestartNodeOptions) buildNodesToRestart(vdb *VCoordinationDatabase,
+	logger vlog.Printer) (map[string]string, error) {
+	nodeNameToNode := make(map[string]*VCoordinationNode, len(vdb.HostNodeMap))
+	for _, vnode := range vdb.HostNodeMap {
+		nodeNameToNode[vnode.Name] = vnode
+	}
+
+	nodes := make(map[string]string)
+	var missing []string
+	for _, host := range options.RestartHosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			missing = append(missing, host)
+			continue
+		}
+		nodes[vnode.Name] = host
+	}
+	// a new address takes precedence over the catalog address
+	for nodeName, newHost := range options.NewAddresses {
+		if _, ok := nodeNameToNode[nodeName]; !ok {
+			missing = append(missing, nodeName)
+			continue
+		}
+		nodes[nodeName] = newHost
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, &NodeNotFoundError{
+			Detail: fmt.Sprintf("hosts or nodes %v are not in the catalog of database %s", missing, options.DBName),
+			Nodes:  missing,
+		}
+	}
+
+	for nodeName := range nodes {
+		vnode := nodeNameToNode[nodeName]
+		if vnode.State == util.NodeUpState {
+			logger.Info("skipping restart of node that is already up", "nodename", nodeName, "host", vnode.Address)
+			delete(nodes, nodeName)
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,56 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestBuildNodesToRestart(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.0.2.1"] = &VCoordinationNode{Name: "v_test_db_node0001", Address: "192.0.2.1",
+		State: util.NodeUpState}
+	vdb.HostNodeMap["192.0.2.2"] = &VCoordinationNode{Name: "v_test_db_node0002", Address: "192.0.2.2",
+		State: util.NodeDownState}
+	vdb.HostNodeMap["192.0.2.3"] = &VCoordinationNode{Name: "v_test_db_node0003", Address: "192.0.2.3",
+		State: util.NodeDownState}
+
+	// up nodes are skipped, and down nodes are started with their new address if any
+	options := VRestartNodeOptionsFactory()
+	options.DBName = "test_db"
+	options.RestartHosts = []string{"192.0.2.1", "192.0.2.2"}
+	options.NewAddresses["v_test_db_node0003"] = "192.0.2.13"
+	nodes, err := options.buildNodesToRestart(&vdb, vlog.Printer{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"v_test_db_node0002": "192.0.2.2",
+		"v_test_db_node0003": "192.0.2.13",
+	}, nodes)
+
+	// hosts and nodes that are not in the catalog are reported
+	options.RestartHosts = []string{"192.0.2.2", "192.0.2.5", "192.0.2.4"}
+	options.NewAddresses["v_test_db_node0009"] = "192.0.2.19"
+	_, err = options.buildNodesToRestart(&vdb, vlog.Printer{})
+	var notFoundErr *NodeNotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, []string{"192.0.2.4", "192.0.2.5", "v_test_db_node0009"}, notFoundErr.Nodes)
+}
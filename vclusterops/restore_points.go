@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -120,6 +121,13 @@ func (options *VShowRestorePointsOptions) validateExtraOptions() error {
 		return err
 	}
 
+	if options.FilterOptions.Namespace != "" {
+		err = util.ValidateNamespaceName(options.FilterOptions.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -161,6 +169,7 @@ func (options *VShowRestorePointsOptions) validateAnalyzeOptions(logger vlog.Pri
 
 // VShowRestorePoints can query the restore points from an archive
 func (vcc VClusterCommands) VShowRestorePoints(options *VShowRestorePointsOptions) (restorePoints []RestorePoint, err error) {
+	defer vcc.audit(commandShowRestorePoints, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -180,8 +189,8 @@ func (vcc VClusterCommands) VShowRestorePoints(options *VShowRestorePointsOption
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -210,7 +219,7 @@ func (vcc VClusterCommands) produceShowRestorePointsInstructions(options *VShowR
 	nmaVerticaVersionOp := makeNMACheckVerticaVersionOp(hosts, true, true /*IsEon*/)
 
 	nmaShowRestorePointOp := makeNMAShowRestorePointsOpWithFilterOptions(vcc.Log, bootstrapHost, options.DBName,
-		options.CommunalStorageLocation, options.ConfigurationParameters, &options.FilterOptions)
+		options.CommunalStorageLocation, options.getCommunalStorageParameters(), &options.FilterOptions)
 
 	instructions = append(instructions,
 		&nmaHealthOp,
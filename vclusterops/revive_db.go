@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 )
@@ -39,6 +40,20 @@ type VReviveDatabaseOptions struct {
 	IgnoreClusterLease bool
 	// the restore policy
 	RestorePoint RestorePointPolicy
+	// whether to revive the database on fewer hosts than it has nodes. Only the
+	// primary nodes, then the secondary nodes, that the hosts can hold are revived,
+	// and the shards of the other nodes are remapped onto them.
+	AllowNodeCountChange bool
+	// where to record the progress of revive_db, to resume it if interrupted
+	ResumeOptions
+
+	/* hidden options (which cache information only) */
+
+	// the nodes that are not revived as fewer hosts than nodes were given
+	nodesNotRevived []string
+	// the segment shards that each revived node subscribes to, by node name,
+	// when some nodes are not revived
+	shardAssignments map[string][]string
 }
 
 type RestorePointPolicy struct {
@@ -48,6 +63,8 @@ type RestorePointPolicy struct {
 	Index int
 	// The identifier of the restore point in the restore archive to restore from
 	ID string
+	// Namespace of the restore archive, empty for the default namespace
+	Namespace string
 }
 
 func (options *VReviveDatabaseOptions) isRestoreEnabled() bool {
@@ -151,7 +168,14 @@ func (options *VReviveDatabaseOptions) validateExtraOptions() error {
 			"not both or none")
 	}
 
-	return nil
+	if options.RestorePoint.Namespace != "" {
+		err := util.ValidateNamespaceName(options.RestorePoint.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	return options.validateResumeOptions()
 }
 
 func (options *VReviveDatabaseOptions) validateParseOptions() error {
@@ -197,6 +221,7 @@ func (options *VReviveDatabaseOptions) validateAnalyzeOptions() error {
 // VReviveDatabase revives a database that was terminated but whose communal storage data still exists.
 // It returns the database information retrieved from communal storage and any error encountered.
 func (vcc VClusterCommands) VReviveDatabase(options *VReviveDatabaseOptions) (dbInfo string, vdbPtr *VCoordinationDatabase, err error) {
+	defer vcc.audit(commandReviveDB, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Validate options
 	 *   - Run VClusterOpEngine to get terminated database info
@@ -218,9 +243,9 @@ func (vcc VClusterCommands) VReviveDatabase(options *VReviveDatabaseOptions) (db
 	}
 
 	// generate clusterOpEngine certs
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
+	certs := options.buildHTTPSCerts()
 	// feed the pre-revive db instructions to the VClusterOpEngine
-	clusterOpEngine := makeClusterOpEngine(preReviveDBInstructions, &certs)
+	clusterOpEngine := vcc.makeClusterOpEngine(preReviveDBInstructions, &certs)
 	err = clusterOpEngine.run(vcc.GetLog())
 	if err != nil {
 		return dbInfo, nil, fmt.Errorf("fail to collect the information of database in revive_db %w", err)
@@ -238,7 +263,7 @@ func (vcc VClusterCommands) VReviveDatabase(options *VReviveDatabaseOptions) (db
 		}
 
 		// feed the restore db specific instructions to the VClusterOpEngine
-		clusterOpEngine = makeClusterOpEngine(restoreDBSpecificInstructions, &certs)
+		clusterOpEngine = vcc.makeClusterOpEngine(restoreDBSpecificInstructions, &certs)
 		runErr := clusterOpEngine.run(vcc.GetLog())
 		if runErr != nil {
 			return dbInfo, &vdb, fmt.Errorf("fail to collect the restore-specific information of database in revive_db %w", runErr)
@@ -257,12 +282,21 @@ func (vcc VClusterCommands) VReviveDatabase(options *VReviveDatabaseOptions) (db
 	}
 
 	// feed revive db instructions to the VClusterOpEngine
-	clusterOpEngine = makeClusterOpEngine(reviveDBInstructions, &certs)
+	clusterOpEngine = vcc.makeClusterOpEngine(reviveDBInstructions, &certs)
+	clusterOpEngine.journal, err = options.makeJournal(commandReviveDB, options.DBName)
+	if err != nil {
+		return dbInfo, &vdb, err
+	}
 	err = clusterOpEngine.run(vcc.GetLog())
 	if err != nil {
 		return dbInfo, &vdb, fmt.Errorf("fail to revive database %w", err)
 	}
 
+	if len(options.nodesNotRevived) > 0 {
+		vcc.Log.PrintWarning("Nodes %v were not revived as fewer hosts than nodes were given, "+
+			"their shards were remapped onto the revived nodes", options.nodesNotRevived)
+	}
+
 	// fill vdb with VReviveDatabaseOptions information
 	vdb.Name = options.DBName
 	vdb.IsEon = true
@@ -272,6 +306,20 @@ func (vcc VClusterCommands) VReviveDatabase(options *VReviveDatabaseOptions) (db
 	return dbInfo, &vdb, nil
 }
 
+// VDescribeCommunalDatabase downloads the description of the database on
+// communal storage, or of its restore point if options has one, and returns
+// it parsed, without modifying anything. The description tells the nodes,
+// shards, and storage locations that a revive would restore.
+func (vcc VClusterCommands) VDescribeCommunalDatabase(options *VReviveDatabaseOptions) (*DatabaseDescription, error) {
+	describeOptions := *options
+	describeOptions.DisplayOnly = true
+	dbInfo, _, err := vcc.VReviveDatabase(&describeOptions)
+	if err != nil {
+		return nil, err
+	}
+	return parseDatabaseDescription(dbInfo)
+}
+
 // revive db instructions are split into two parts:
 // 1. get terminated database info
 // 2. revive database using the info we got from step 1
@@ -307,10 +355,11 @@ func (vcc VClusterCommands) producePreReviveDBInstructions(options *VReviveDatab
 		// perform revive, either display-only or not
 		nmaDownloadFileOpForRevive, err := makeNMADownloadFileOpForRevive(options.Hosts,
 			currConfigFileSrcPath, currConfigFileDestPath, catalogPath,
-			options.ConfigurationParameters, vdb, options.DisplayOnly, options.IgnoreClusterLease)
+			options.getCommunalStorageParameters(), vdb, options.DisplayOnly, options.IgnoreClusterLease)
 		if err != nil {
 			return instructions, err
 		}
+		nmaDownloadFileOpForRevive.allowFewerNodes = options.AllowNodeCountChange
 		instructions = append(instructions,
 			&nmaDownloadFileOpForRevive,
 		)
@@ -320,7 +369,7 @@ func (vcc VClusterCommands) producePreReviveDBInstructions(options *VReviveDatab
 			// if not display-only, do a lease check first using current cluster config
 			nmaDownloadFileOpForRestoreLeaseCheck, err := makeNMADownloadFileOpForRestoreLeaseCheck(options.Hosts,
 				currConfigFileSrcPath, currConfigFileDestPath, catalogPath,
-				options.ConfigurationParameters, vdb, options.IgnoreClusterLease)
+				options.getCommunalStorageParameters(), vdb, options.IgnoreClusterLease)
 			if err != nil {
 				return instructions, err
 			}
@@ -334,6 +383,7 @@ func (vcc VClusterCommands) producePreReviveDBInstructions(options *VReviveDatab
 		bootstrapHost := []string{initiator}
 		filterOptions := ShowRestorePointFilterOptions{}
 		filterOptions.ArchiveName = options.RestorePoint.Archive
+		filterOptions.Namespace = options.RestorePoint.Namespace
 		if options.hasValidRestorePointID() {
 			filterOptions.ArchiveID = options.RestorePoint.ID
 		} else {
@@ -341,7 +391,7 @@ func (vcc VClusterCommands) producePreReviveDBInstructions(options *VReviveDatab
 			filterOptions.ArchiveIndex = indexStr
 		}
 		nmaShowRestorePointsOp := makeNMAShowRestorePointsOpWithFilterOptions(vcc.GetLog(), bootstrapHost, options.DBName,
-			options.CommunalStorageLocation, options.ConfigurationParameters, &filterOptions)
+			options.CommunalStorageLocation, options.getCommunalStorageParameters(), &filterOptions)
 		instructions = append(instructions,
 			&nmaShowRestorePointsOp,
 		)
@@ -361,11 +411,12 @@ func (vcc VClusterCommands) produceRestoreDBSpecificInstructions(options *VReviv
 
 	nmaDownLoadFileOp, err := makeNMADownloadFileOpForRestore(options.Hosts,
 		restorePointConfigFileSrcPath, restorePointConfigFileDestPath, catalogPath,
-		options.ConfigurationParameters, vdb, options.DisplayOnly)
+		options.getCommunalStorageParameters(), vdb, options.DisplayOnly)
 
 	if err != nil {
 		return instructions, err
 	}
+	nmaDownLoadFileOp.allowFewerNodes = options.AllowNodeCountChange
 
 	instructions = append(instructions,
 		&nmaDownLoadFileOp,
@@ -415,9 +466,14 @@ func (vcc VClusterCommands) produceReviveDBInstructions(options *VReviveDatabase
 
 	nmaNetworkProfileOp := makeNMANetworkProfileOp(options.Hosts)
 
-	nmaLoadRemoteCatalogOp := makeNMALoadRemoteCatalogOp(oldHosts, options.ConfigurationParameters,
+	nmaLoadRemoteCatalogOp := makeNMALoadRemoteCatalogOp(oldHosts, options.getCommunalStorageParameters(),
 		&newVDB, options.LoadCatalogTimeout, &options.RestorePoint)
+	nmaLoadRemoteCatalogOp.droppedNodes = options.nodesNotRevived
+	nmaLoadRemoteCatalogOp.shardAssignments = options.shardAssignments
 
+	// a resumed run skips the ops that completed in a previous run
+	nmaPrepareDirectoriesOp.setCheckpointed()
+	nmaLoadRemoteCatalogOp.setCheckpointed()
 	instructions = append(instructions,
 		&nmaPrepareDirectoriesOp,
 		&nmaNetworkProfileOp,
@@ -462,8 +518,17 @@ func (options *VReviveDatabaseOptions) generateReviveVDB(vdb *VCoordinationDatab
 	})
 
 	newVDB.HostNodeMap = makeVHostNodeMap()
+	options.nodesNotRevived = nil
+	options.shardAssignments = nil
 	if len(newVDB.HostList) != len(vNodes) {
-		return newVDB, oldHosts, fmt.Errorf("the number of new hosts does not match the number of nodes in original database")
+		if !options.AllowNodeCountChange {
+			return newVDB, oldHosts, fmt.Errorf("the number of new hosts does not match the number of nodes in original database")
+		}
+		vNodes, err = options.selectNodesToRevive(vNodes)
+		if err != nil {
+			return newVDB, oldHosts, err
+		}
+		options.shardAssignments = assignShardsToNodes(vNodes, vdb.ShardNames)
 	}
 	for index, newHost := range newVDB.HostList {
 		// recreate the old host list with new hosts' order
@@ -474,3 +539,79 @@ func (options *VReviveDatabaseOptions) generateReviveVDB(vdb *VCoordinationDatab
 
 	return newVDB, oldHosts, nil
 }
+
+// selectNodesToRevive returns the nodes, sorted by name, to revive on the new
+// hosts when there are fewer hosts than nodes. The primary nodes are revived
+// first, and enough of them must be revived for the database to hold quorum.
+func (options *VReviveDatabaseOptions) selectNodesToRevive(vNodes []*VCoordinationNode) ([]*VCoordinationNode, error) {
+	hostCount := len(options.Hosts)
+	if hostCount > len(vNodes) {
+		return nil, fmt.Errorf("cannot revive the database on more hosts (%d) than it has nodes (%d), "+
+			"add the extra hosts with add_node once the database is started", hostCount, len(vNodes))
+	}
+
+	sortedNodes := make([]*VCoordinationNode, len(vNodes))
+	copy(sortedNodes, vNodes)
+	sort.SliceStable(sortedNodes, func(i, j int) bool {
+		return sortedNodes[i].IsPrimary && !sortedNodes[j].IsPrimary
+	})
+
+	primaryCount, revivedPrimaryCount := 0, 0
+	for i, vnode := range sortedNodes {
+		if !vnode.IsPrimary {
+			continue
+		}
+		primaryCount++
+		if i < hostCount {
+			revivedPrimaryCount++
+		}
+	}
+	if revivedPrimaryCount*2 <= primaryCount {
+		return nil, &NoQuorumError{Detail: fmt.Sprintf("%d hosts can only revive %d of the %d primary nodes, "+
+			"which does not hold quorum", hostCount, revivedPrimaryCount, primaryCount)}
+	}
+
+	nodesToRevive := sortedNodes[:hostCount]
+	sort.Slice(nodesToRevive, func(i, j int) bool {
+		return nodesToRevive[i].Name < nodesToRevive[j].Name
+	})
+	for _, vnode := range sortedNodes[hostCount:] {
+		options.nodesNotRevived = append(options.nodesNotRevived, vnode.Name)
+	}
+	sort.Strings(options.nodesNotRevived)
+	return nodesToRevive, nil
+}
+
+// assignShardsToNodes remaps the segment shards onto the revived nodes, sorted
+// by name. The shards are dealt round-robin to the primary nodes, so that each
+// shard has a primary subscriber, and then to the secondary nodes. A node gets
+// at least one shard when there are more nodes than shards.
+func assignShardsToNodes(vNodes []*VCoordinationNode, shardNames []string) map[string][]string {
+	shardAssignments := make(map[string][]string)
+	if len(shardNames) == 0 {
+		return shardAssignments
+	}
+
+	var primaryNodes, secondaryNodes []string
+	for _, vnode := range vNodes {
+		if vnode.IsPrimary {
+			primaryNodes = append(primaryNodes, vnode.Name)
+		} else {
+			secondaryNodes = append(secondaryNodes, vnode.Name)
+		}
+	}
+	for _, nodeNames := range [][]string{primaryNodes, secondaryNodes} {
+		if len(nodeNames) == 0 {
+			continue
+		}
+		count := len(shardNames)
+		if len(nodeNames) > count {
+			count = len(nodeNames)
+		}
+		for i := 0; i < count; i++ {
+			nodeName := nodeNames[i%len(nodeNames)]
+			shardAssignments[nodeName] = append(shardAssignments[nodeName], shardNames[i%len(shardNames)])
+		}
+	}
+	return shardAssignments
+}
@@ -1,10 +1,12 @@
 package vclusterops
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
 )
 
 func TestFindSpecifiedRestorePoint(t *testing.T) {
@@ -54,3 +56,122 @@ func TestFindSpecifiedRestorePoint(t *testing.T) {
 	expectedErr = &ReviveDBRestorePointNotFoundError{Archive: "archive3", InvalidID: "id3"}
 	assert.EqualError(t, err, expectedErr.Error())
 }
+
+func TestGenerateReviveVDBWithFewerHosts(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	for i, isPrimary := range []bool{true, true, true, false} {
+		vnode := makeVCoordinationNode()
+		vnode.Name = fmt.Sprintf("v_test_db_node000%d", i+1)
+		vnode.Address = fmt.Sprintf("192.168.1.10%d", i+1)
+		vnode.IsPrimary = isPrimary
+		err := vdb.addNode(&vnode)
+		assert.NoError(t, err)
+	}
+	vdb.ShardNames = []string{"segment0001", "segment0002", "segment0003"}
+
+	options := VReviveDBOptionsFactory()
+	options.DBName = "test_db"
+	options.Hosts = []string{"10.1.10.1", "10.1.10.2"}
+
+	// a different node count is rejected by default
+	_, _, err := options.generateReviveVDB(&vdb)
+	assert.ErrorContains(t, err, "does not match")
+
+	// the primary nodes are revived first
+	options.AllowNodeCountChange = true
+	newVDB, oldHosts, err := options.generateReviveVDB(&vdb)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.101", "192.168.1.102"}, oldHosts)
+	assert.Equal(t, "v_test_db_node0001", newVDB.HostNodeMap["10.1.10.1"].Name)
+	assert.Equal(t, "v_test_db_node0002", newVDB.HostNodeMap["10.1.10.2"].Name)
+	assert.Equal(t, []string{"v_test_db_node0003", "v_test_db_node0004"}, options.nodesNotRevived)
+	// the shards of the nodes that are not revived are remapped
+	assert.Equal(t, map[string][]string{
+		"v_test_db_node0001": {"segment0001", "segment0003"},
+		"v_test_db_node0002": {"segment0002"},
+	}, options.shardAssignments)
+
+	// one host per node needs no remapping
+	options.Hosts = []string{"10.1.10.1", "10.1.10.2", "10.1.10.3", "10.1.10.4"}
+	_, _, err = options.generateReviveVDB(&vdb)
+	assert.NoError(t, err)
+	assert.Empty(t, options.nodesNotRevived)
+	assert.Nil(t, options.shardAssignments)
+
+	// quorum of the primary nodes must be revived
+	options.Hosts = []string{"10.1.10.1"}
+	_, _, err = options.generateReviveVDB(&vdb)
+	var quorumErr *NoQuorumError
+	assert.ErrorAs(t, err, &quorumErr)
+
+	// more hosts than nodes are not supported
+	options.Hosts = []string{"10.1.10.1", "10.1.10.2", "10.1.10.3", "10.1.10.4", "10.1.10.5"}
+	_, _, err = options.generateReviveVDB(&vdb)
+	assert.ErrorContains(t, err, "more hosts")
+}
+
+func TestAssignShardsToNodes(t *testing.T) {
+	var vNodes []*VCoordinationNode
+	for i, isPrimary := range []bool{true, true, true, false} {
+		vnode := makeVCoordinationNode()
+		vnode.Name = fmt.Sprintf("v_test_db_node000%d", i+1)
+		vnode.IsPrimary = isPrimary
+		vNodes = append(vNodes, &vnode)
+	}
+
+	// every primary node gets a shard when there are more nodes than shards,
+	// and every shard has a primary subscriber
+	shardAssignments := assignShardsToNodes(vNodes, []string{"segment0001", "segment0002"})
+	assert.Equal(t, map[string][]string{
+		"v_test_db_node0001": {"segment0001"},
+		"v_test_db_node0002": {"segment0002"},
+		"v_test_db_node0003": {"segment0001"},
+		"v_test_db_node0004": {"segment0001", "segment0002"},
+	}, shardAssignments)
+
+	// no shards to remap
+	assert.Empty(t, assignShardsToNodes(vNodes, nil))
+}
+
+func TestLoadRemoteCatalogWithDroppedNodes(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = dbName
+	vdb.HostList = []string{"10.1.10.1", "10.1.10.2"}
+	vdb.HostNodeMap = makeVHostNodeMap()
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	execContext.networkProfiles = make(map[string]networkProfile)
+	for i, host := range vdb.HostList {
+		vnode := makeVCoordinationNode()
+		vnode.Name = fmt.Sprintf("v_test_db_node000%d", i+1)
+		vnode.Address = host
+		vnode.IsPrimary = true
+		vdb.HostNodeMap[host] = &vnode
+		execContext.networkProfiles[host] = networkProfile{Address: host}
+	}
+
+	op := makeNMALoadRemoteCatalogOp([]string{"192.168.1.101", "192.168.1.102"}, nil, &vdb, 0, nil)
+	err := op.setupRequestBody(&execContext)
+	assert.NoError(t, err)
+	requestData := loadRemoteCatalogRequestData{}
+	err = json.Unmarshal([]byte(op.hostRequestBodyMap["10.1.10.1"]), &requestData)
+	assert.NoError(t, err)
+	assert.Empty(t, requestData.DroppedNodes)
+	assert.Empty(t, requestData.ShardAssignments)
+
+	// the dropped nodes and the shard remapping are sent to every host
+	op.droppedNodes = []string{"v_test_db_node0003"}
+	op.shardAssignments = map[string][]string{
+		"v_test_db_node0001": {"segment0001", "segment0003"},
+		"v_test_db_node0002": {"segment0002"},
+	}
+	err = op.setupRequestBody(&execContext)
+	assert.NoError(t, err)
+	for _, host := range vdb.HostList {
+		requestData = loadRemoteCatalogRequestData{}
+		err = json.Unmarshal([]byte(op.hostRequestBodyMap[host]), &requestData)
+		assert.NoError(t, err)
+		assert.Equal(t, op.droppedNodes, requestData.DroppedNodes)
+		assert.Equal(t, op.shardAssignments, requestData.ShardAssignments)
+	}
+}
@@ -0,0 +1,384 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VRollingUpgradeOptions struct {
+	DatabaseOptions
+	ResumeOptions
+	// subclusters to upgrade, in order. If empty, all the subclusters of
+	// the main cluster are upgraded, the secondary subclusters first.
+	SCNames []string
+	// time in seconds to wait for the users of a subcluster to disconnect
+	// before it is stopped
+	DrainSeconds int
+	// timeout in seconds for the nodes of a subcluster to come up
+	StatePollingTimeout int
+	// version that the nodes must run once upgraded, e.g., "v24.3.0". If
+	// empty, the nodes of a subcluster only need to run the same version.
+	TargetVersion string
+	// called once a subcluster is stopped to install the new Vertica binary
+	// on its hosts. It must return once the hosts are ready to be started.
+	UpgradeHook func(scName string, hosts []string) error `json:"-"`
+}
+
+func VRollingUpgradeOptionsFactory() VRollingUpgradeOptions {
+	options := VRollingUpgradeOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VRollingUpgradeOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.DrainSeconds = util.DefaultDrainSeconds
+	options.StatePollingTimeout = util.DefaultStatePollingTimeout
+}
+
+func (options *VRollingUpgradeOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRollingUpgrade, logger)
+	if err != nil {
+		return err
+	}
+	if !options.IsEon {
+		return fmt.Errorf("rolling upgrade is only supported in Eon mode")
+	}
+	for _, scName := range options.SCNames {
+		err = util.ValidateScName(scName)
+		if err != nil {
+			return err
+		}
+	}
+	if options.DrainSeconds < 0 {
+		return fmt.Errorf("drain seconds cannot be negative")
+	}
+	if options.StatePollingTimeout <= 0 {
+		return fmt.Errorf("the state polling timeout must be positive, got %d", options.StatePollingTimeout)
+	}
+	if options.TargetVersion != "" {
+		if _, err = parseServerVersion(options.TargetVersion); err != nil {
+			return fmt.Errorf("invalid target version: %w", err)
+		}
+	}
+	if options.UpgradeHook == nil {
+		return fmt.Errorf("must provide the hook that installs the new binary on the hosts")
+	}
+	return options.validateResumeOptions()
+}
+
+func (options *VRollingUpgradeOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VRollingUpgradeOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	err := options.analyzeOptions()
+	if err != nil {
+		return err
+	}
+	return options.setUsePassword(logger)
+}
+
+// VRollingUpgrade upgrades the Vertica binary of an Eon database one
+// subcluster at a time, so that the other subclusters keep serving clients.
+// For each subcluster, it:
+//  1. pauses the new client connections of the subcluster,
+//  2. stops the subcluster once its users are disconnected,
+//  3. calls options.UpgradeHook to install the new binary on its hosts,
+//  4. starts the subcluster and checks the version of its nodes,
+//  5. resumes the client connections of the subcluster.
+//
+// The upgraded subclusters are recorded in the journal, if one is set, so
+// that an interrupted upgrade can be resumed from the subcluster that did not
+// complete. The steps of a subcluster are safe to run again.
+func (vcc VClusterCommands) VRollingUpgrade(options *VRollingUpgradeOptions) (err error) {
+	defer vcc.audit(commandRollingUpgrade, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	scNames, err := getSubclustersToUpgrade(&vdb, options.SCNames)
+	if err != nil {
+		return err
+	}
+
+	journal, err := options.makeJournal(commandRollingUpgrade, options.DBName)
+	if err != nil {
+		return err
+	}
+
+	for i, scName := range scNames {
+		if journal.isStepCompleted(i, scName) {
+			vcc.PrintInfo("Subcluster %s was upgraded in a previous run, skipping it", scName)
+			continue
+		}
+		hosts, err := vcc.upgradeSubcluster(options, scName)
+		if err != nil {
+			return fmt.Errorf("fail to upgrade subcluster %s, %d of %d subclusters are upgraded: %w",
+				scName, i, len(scNames), err)
+		}
+		err = journal.recordStepCompleted(i, scName, hosts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return journal.remove()
+}
+
+// getSubclustersToUpgrade returns the subclusters to upgrade in order. If
+// no subcluster is given, all the subclusters of the main cluster are
+// returned, the secondary ones first, as stopping them does not affect the
+// quorum of the database. It fails if stopping any of the primary subclusters
+// would lose the quorum, before any subcluster is touched.
+func getSubclustersToUpgrade(vdb *VCoordinationDatabase, scNames []string) ([]string, error) {
+	isPrimary := make(map[string]bool)
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != "" {
+			continue
+		}
+		isPrimary[vnode.Subcluster] = vnode.IsPrimary
+	}
+
+	if len(scNames) > 0 {
+		for _, scName := range scNames {
+			if _, ok := isPrimary[scName]; !ok {
+				return nil, fmt.Errorf("subcluster %s does not exist in the main cluster of database %s",
+					scName, vdb.Name)
+			}
+			if err := checkPrimaryQuorum(vdb, scName); err != nil {
+				return nil, err
+			}
+		}
+		return scNames, nil
+	}
+
+	allSCNames := make([]string, 0, len(isPrimary))
+	for scName := range isPrimary {
+		allSCNames = append(allSCNames, scName)
+	}
+	sort.Slice(allSCNames, func(i, j int) bool {
+		if isPrimary[allSCNames[i]] != isPrimary[allSCNames[j]] {
+			return !isPrimary[allSCNames[i]]
+		}
+		return allSCNames[i] < allSCNames[j]
+	})
+	for _, scName := range allSCNames {
+		if err := checkPrimaryQuorum(vdb, scName); err != nil {
+			return nil, err
+		}
+	}
+	return allSCNames, nil
+}
+
+// checkPrimaryQuorum returns an error if scName is a primary subcluster and
+// stopping it would leave no more than half of the primary nodes of the main
+// cluster up, as the database would then lose its quorum and shut down. A
+// database whose primary nodes are all in one subcluster cannot be upgraded
+// one subcluster at a time.
+func checkPrimaryQuorum(vdb *VCoordinationDatabase, scName string) error {
+	primaryCount := 0
+	upPrimaryCountAfterStop := 0
+	scIsPrimary := false
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != "" || !vnode.IsPrimary {
+			continue
+		}
+		primaryCount++
+		if vnode.Subcluster == scName {
+			scIsPrimary = true
+			continue
+		}
+		if vnode.State == util.NodeUpState {
+			upPrimaryCountAfterStop++
+		}
+	}
+	if !scIsPrimary || upPrimaryCountAfterStop*2 > primaryCount {
+		return nil
+	}
+	return fmt.Errorf("cannot stop primary subcluster %s without losing the quorum of database %s: "+
+		"only %d of its %d primary nodes would remain up, the database must be upgraded offline "+
+		"or have more primary nodes up in other subclusters", scName, vdb.Name, upPrimaryCountAfterStop, primaryCount)
+}
+
+// upgradeSubcluster runs the upgrade steps of a subcluster and returns its
+// hosts. The steps that are already done, e.g., stopping a subcluster whose
+// nodes are down, are skipped. If a step fails once the client connections
+// are paused, they are resumed before returning.
+func (vcc VClusterCommands) upgradeSubcluster(options *VRollingUpgradeOptions, scName string) (hosts []string, err error) {
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	for host, vnode := range vdb.HostNodeMap {
+		if vnode.Subcluster == scName && vnode.Sandbox == "" {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	// the other subclusters may have gone down since the upgrade started
+	err = checkPrimaryQuorum(&vdb, scName)
+	if err != nil {
+		return hosts, err
+	}
+
+	vcc.PrintInfo("Pausing the client connections of subcluster %s", scName)
+	err = vcc.manageSubclusterConnections(options, scName, ActionPause)
+	if err != nil {
+		return hosts, err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		vcc.PrintInfo("Resuming the client connections of subcluster %s after the failure", scName)
+		resumeErr := vcc.manageSubclusterConnections(options, scName, ActionResume)
+		if resumeErr != nil {
+			vcc.PrintWarning("fail to resume the client connections of subcluster %s, details: %s",
+				scName, resumeErr)
+		}
+	}()
+
+	if vdb.hasUpNodes(hosts) {
+		vcc.PrintInfo("Stopping subcluster %s", scName)
+		stopScOpt := VStopSubclusterOptionsFactory()
+		stopScOpt.DatabaseOptions = options.DatabaseOptions
+		stopScOpt.SCName = scName
+		stopScOpt.DrainSeconds = options.DrainSeconds
+		err = vcc.VStopSubcluster(&stopScOpt)
+		if err != nil {
+			return hosts, err
+		}
+	}
+
+	vcc.PrintInfo("Installing the new binary on the hosts %v of subcluster %s", hosts, scName)
+	err = options.UpgradeHook(scName, hosts)
+	if err != nil {
+		return hosts, fmt.Errorf("fail to install the new binary: %w", err)
+	}
+
+	vcc.PrintInfo("Starting subcluster %s", scName)
+	startScOpt := VStartScOptionsFactory()
+	startScOpt.DatabaseOptions = options.DatabaseOptions
+	startScOpt.SCName = scName
+	startScOpt.StatePollingTimeout = options.StatePollingTimeout
+	err = vcc.VStartSubcluster(&startScOpt)
+	if err != nil {
+		return hosts, err
+	}
+
+	version, err := vcc.checkUpgradedVersion(options, &vdb, hosts)
+	if err != nil {
+		return hosts, err
+	}
+
+	vcc.PrintInfo("Resuming the client connections of subcluster %s", scName)
+	err = vcc.manageSubclusterConnections(options, scName, ActionResume)
+	if err != nil {
+		return hosts, err
+	}
+	vcc.PrintInfo("Upgraded subcluster %s to version %s", scName, version)
+	return hosts, nil
+}
+
+func (vcc VClusterCommands) manageSubclusterConnections(options *VRollingUpgradeOptions,
+	scName string, action ConnectionDrainingAction) error {
+	manageConnOpt := VManageConnectionDrainingOptionsFactory()
+	manageConnOpt.DatabaseOptions = options.DatabaseOptions
+	manageConnOpt.SCName = scName
+	manageConnOpt.Action = action
+	return vcc.VManageConnectionDraining(&manageConnOpt)
+}
+
+// checkUpgradedVersion reads the Vertica version of the hosts from the NMA,
+// and returns it if all the hosts run the same version, which matches the
+// target version of the options if one is given
+func (vcc VClusterCommands) checkUpgradedVersion(options *VRollingUpgradeOptions,
+	vdb *VCoordinationDatabase, hosts []string) (string, error) {
+	scVDB := vdb.copy(hosts)
+	nmaReadVerticaVersionOp := makeNMAReadVerticaVersionOp(&scVDB)
+	instructions := []clusterOp{&nmaReadVerticaVersionOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err := clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return "", fmt.Errorf("fail to read the Vertica version of hosts %v: %w", hosts, err)
+	}
+	return checkHostVersions(&scVDB, hosts, options.TargetVersion)
+}
+
+// checkHostVersions returns the version of the nodes of the hosts, or an
+// error if they do not all run the same version or the target version
+func checkHostVersions(vdb *VCoordinationDatabase, hosts []string, targetVersion string) (string, error) {
+	var version string
+	for _, host := range hosts {
+		vnode, ok := vdb.HostNodeMap[host]
+		if !ok {
+			return "", fmt.Errorf("cannot find host %s in the database", host)
+		}
+		if version == "" {
+			version = vnode.Version
+		} else if vnode.Version != version {
+			return "", fmt.Errorf("hosts run different versions after the upgrade: %s and %s",
+				version, vnode.Version)
+		}
+	}
+	if targetVersion == "" {
+		return version, nil
+	}
+
+	target, err := parseServerVersion(targetVersion)
+	if err != nil {
+		return "", err
+	}
+	ver, err := parseServerVersion(version)
+	if err != nil {
+		return "", err
+	}
+	if cmp, err := ver.compareCore(&target); err != nil || cmp != 0 {
+		return "", fmt.Errorf("hosts run version %s after the upgrade, expected %s", version, targetVersion)
+	}
+	return version, nil
+}
@@ -0,0 +1,143 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateRollingUpgradeOptions(t *testing.T) {
+	options := VRollingUpgradeOptionsFactory()
+	options.DBName = dbName
+	options.RawHosts = []string{"192.0.2.1"}
+	options.IsEon = true
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must provide the hook")
+
+	options.UpgradeHook = func(string, []string) error { return nil }
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	options.TargetVersion = "latest"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "invalid target version")
+
+	options.TargetVersion = "v24.3.0"
+	options.Resume = true
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must specify the journal")
+
+	options.Resume = false
+	options.IsEon = false
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "only supported in Eon mode")
+}
+
+func TestGetSubclustersToUpgrade(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = dbName
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Name: "v_test_db_node0001", Subcluster: "default_subcluster", IsPrimary: true, State: "UP"},
+		"192.0.2.2": {Name: "v_test_db_node0002", Subcluster: "sc2", State: "UP"},
+		"192.0.2.3": {Name: "v_test_db_node0003", Subcluster: "sc1", State: "UP"},
+		"192.0.2.4": {Name: "v_test_db_node0004", Subcluster: "sand_sc", Sandbox: "sand", State: "UP"},
+		"192.0.2.5": {Name: "v_test_db_node0005", Subcluster: "primary_sc", IsPrimary: true, State: "UP"},
+		"192.0.2.6": {Name: "v_test_db_node0006", Subcluster: "primary_sc2", IsPrimary: true, State: "UP"},
+	}
+
+	// the secondary subclusters are upgraded first, sandboxes are skipped
+	scNames, err := getSubclustersToUpgrade(&vdb, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sc1", "sc2", "default_subcluster", "primary_sc", "primary_sc2"}, scNames)
+
+	scNames, err = getSubclustersToUpgrade(&vdb, []string{"default_subcluster", "sc1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default_subcluster", "sc1"}, scNames)
+
+	_, err = getSubclustersToUpgrade(&vdb, []string{"sand_sc"})
+	assert.ErrorContains(t, err, "subcluster sand_sc does not exist")
+
+	// once a primary node is down, stopping primary_sc would leave 1 of the
+	// 3 primary nodes up
+	vdb.HostNodeMap["192.0.2.6"].State = "DOWN"
+	_, err = getSubclustersToUpgrade(&vdb, []string{"sc1", "primary_sc"})
+	assert.ErrorContains(t, err, "cannot stop primary subcluster primary_sc without losing the quorum")
+	_, err = getSubclustersToUpgrade(&vdb, nil)
+	assert.ErrorContains(t, err, "only 1 of its 3 primary nodes would remain up")
+}
+
+func TestCheckPrimaryQuorum(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = dbName
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Subcluster: "sc1", IsPrimary: true, State: "UP"},
+		"192.0.2.2": {Subcluster: "sc2", IsPrimary: true, State: "UP"},
+		"192.0.2.3": {Subcluster: "sc2", IsPrimary: true, State: "UP"},
+		"192.0.2.4": {Subcluster: "sc3", State: "UP"},
+	}
+	// the secondary subclusters never affect the quorum
+	assert.NoError(t, checkPrimaryQuorum(&vdb, "sc3"))
+	assert.NoError(t, checkPrimaryQuorum(&vdb, "sc1"))
+	assert.Error(t, checkPrimaryQuorum(&vdb, "sc2"))
+
+	// a down primary node in another subcluster does not count
+	vdb.HostNodeMap["192.0.2.3"].State = "DOWN"
+	assert.Error(t, checkPrimaryQuorum(&vdb, "sc1"))
+
+	// a database with a single primary subcluster cannot be upgraded online
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Subcluster: "default_subcluster", IsPrimary: true, State: "UP"},
+		"192.0.2.2": {Subcluster: "default_subcluster", IsPrimary: true, State: "UP"},
+		"192.0.2.3": {Subcluster: "sc1", State: "UP"},
+	}
+	assert.Error(t, checkPrimaryQuorum(&vdb, "default_subcluster"))
+}
+
+func TestCheckHostVersions(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Version: "v24.3.0-1"},
+		"192.0.2.2": {Version: "v24.3.0-1"},
+		"192.0.2.3": {Version: "v24.2.0-0"},
+	}
+
+	version, err := checkHostVersions(&vdb, []string{"192.0.2.1", "192.0.2.2"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "v24.3.0-1", version)
+
+	// the hotfix of the target version is not compared
+	_, err = checkHostVersions(&vdb, []string{"192.0.2.1", "192.0.2.2"}, "v24.3.0")
+	assert.NoError(t, err)
+	_, err = checkHostVersions(&vdb, []string{"192.0.2.1", "192.0.2.2"}, "v24.4.0")
+	assert.ErrorContains(t, err, "expected v24.4.0")
+
+	_, err = checkHostVersions(&vdb, []string{"192.0.2.1", "192.0.2.3"}, "")
+	assert.ErrorContains(t, err, "hosts run different versions")
+}
+
+func TestRollingUpgradeJournalSteps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal, err := makeOpJournal(path, commandRollingUpgrade, dbName, false)
+	assert.NoError(t, err)
+	assert.NoError(t, journal.recordStepCompleted(0, "sc1", []string{"192.0.2.3"}))
+
+	resumed, err := makeOpJournal(path, commandRollingUpgrade, dbName, true)
+	assert.NoError(t, err)
+	assert.True(t, resumed.isStepCompleted(0, "sc1"))
+	// a step is identified by its position and name
+	assert.False(t, resumed.isStepCompleted(1, "sc1"))
+	assert.False(t, resumed.isStepCompleted(0, "sc2"))
+	assert.NoError(t, resumed.remove())
+}
@@ -0,0 +1,102 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VRotateDBPasswordOptions struct {
+	DatabaseOptions
+	// the password that replaces the current one of the database user
+	NewPassword *string
+}
+
+func VRotateDBPasswordOptionsFactory() VRotateDBPasswordOptions {
+	options := VRotateDBPasswordOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VRotateDBPasswordOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRotateDBPassword, logger)
+	if err != nil {
+		return err
+	}
+	// the password of the current OS user is rotated if no user is given
+	err = options.validateUserName(logger)
+	if err != nil {
+		return err
+	}
+	if options.NewPassword == nil || *options.NewPassword == "" {
+		return errors.New("must specify a non-empty new password")
+	}
+	if options.Password != nil && *options.Password == *options.NewPassword {
+		return errors.New("the new password must be different from the current one")
+	}
+	return nil
+}
+
+// VRotateDBPassword changes the database password of the user in the options,
+// the database superuser by default, on a running database. On success, the
+// password in the options is replaced by the new one, so that the options can
+// be reused by the next calls to vclusterops.
+func (vcc VClusterCommands) VRotateDBPassword(options *VRotateDBPasswordOptions) (err error) {
+	defer vcc.audit(commandRotateDBPassword, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return err
+	}
+
+	httpsChangeDBPasswordOp, err := makeHTTPSChangeDBPasswordOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, options.NewPassword)
+	if err != nil {
+		return err
+	}
+	err = vcc.runSingleOp(&httpsChangeDBPasswordOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to rotate the password of user %s", options.UserName))
+	if err != nil {
+		return err
+	}
+
+	// the current password no longer works
+	if options.usePassword {
+		options.Password = options.NewPassword
+	}
+	vcc.Log.PrintInfo("Rotated the password of user %s in database %s", options.UserName, options.DBName)
+	return nil
+}
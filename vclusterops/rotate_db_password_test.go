@@ -0,0 +1,60 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateRotateDBPasswordOptions(t *testing.T) {
+	logger := vlog.Printer{}
+	currentPassword := "old"
+	newPassword := "new"
+
+	options := VRotateDBPasswordOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"vnode1"}
+	options.UserName = "dbadmin"
+	options.Password = &currentPassword
+	assert.ErrorContains(t, options.validateParseOptions(logger), "must specify a non-empty new password")
+
+	options.NewPassword = &currentPassword
+	assert.ErrorContains(t, options.validateParseOptions(logger), "must be different from the current one")
+
+	options.NewPassword = &newPassword
+	assert.NoError(t, options.validateParseOptions(logger))
+}
+
+func TestChangeDBPasswordRequest(t *testing.T) {
+	currentPassword := "old"
+	newPassword := "new"
+
+	_, err := makeHTTPSChangeDBPasswordOp([]string{"vnode1"}, true, "dbadmin", &currentPassword, nil)
+	assert.ErrorContains(t, err, "new password is not set")
+
+	op, err := makeHTTPSChangeDBPasswordOp([]string{"vnode1"}, true, "dbadmin", &currentPassword, &newPassword)
+	assert.NoError(t, err)
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	request := op.clusterHTTPRequest.RequestCollection["vnode1"]
+	assert.Equal(t, PutMethod, request.Method)
+	assert.Contains(t, request.Endpoint, "users/dbadmin/password")
+	assert.Equal(t, `{"password":"new"}`, request.RequestData)
+	assert.Equal(t, &currentPassword, request.Password)
+}
@@ -0,0 +1,290 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// RoutingAction is the change made to a load balance group or a routing rule
+type RoutingAction string
+
+const (
+	RoutingCreate RoutingAction = "create"
+	RoutingAlter  RoutingAction = "alter"
+	RoutingDrop   RoutingAction = "drop"
+)
+
+// the policies a load balance group can use to pick a node
+var loadBalancePolicies = []string{"ROUNDROBIN", "RANDOM", "NONE"}
+
+type VLoadBalanceGroupOptions struct {
+	DatabaseOptions
+	Action RoutingAction
+	// Name of the load balance group
+	GroupName string
+	// Subclusters whose nodes the connections are balanced across.
+	// Required to create a group.
+	Subclusters []string
+	// CIDR of the node addresses the connections can be redirected to,
+	// all addresses if empty
+	Filter string
+	// One of ROUNDROBIN, RANDOM or NONE, the server default if empty
+	Policy string
+}
+
+type VRoutingRuleOptions struct {
+	DatabaseOptions
+	Action RoutingAction
+	// Name of the routing rule
+	RuleName string
+	// CIDR of the client addresses the rule applies to.
+	// Required to create a rule.
+	SourceAddress string
+	// The load balance group the clients are routed to.
+	// Required to create a rule.
+	GroupName string
+}
+
+func VLoadBalanceGroupOptionsFactory() VLoadBalanceGroupOptions {
+	options := VLoadBalanceGroupOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func VRoutingRuleOptionsFactory() VRoutingRuleOptions {
+	options := VRoutingRuleOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func validateCIDR(cidr, name string) error {
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return fmt.Errorf("invalid %s %q, must be a CIDR like 192.168.1.0/24: %w", name, cidr, err)
+	}
+	return nil
+}
+
+func (options *VLoadBalanceGroupOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandLoadBalanceGroup, logger)
+	if err != nil {
+		return err
+	}
+	if options.GroupName == "" {
+		return fmt.Errorf("must specify a load balance group name")
+	}
+	err = util.ValidateName(options.GroupName, "load balance group")
+	if err != nil {
+		return err
+	}
+
+	hasSettings := len(options.Subclusters) > 0 || options.Filter != "" || options.Policy != ""
+	switch options.Action {
+	case RoutingCreate:
+		if len(options.Subclusters) == 0 {
+			return fmt.Errorf("must specify at least one subcluster to create a load balance group")
+		}
+	case RoutingAlter:
+		if !hasSettings {
+			return fmt.Errorf("must specify the subclusters, the filter or the policy to alter a load balance group")
+		}
+	case RoutingDrop:
+		if hasSettings {
+			return fmt.Errorf("the subclusters, the filter and the policy cannot be specified to drop a load balance group")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid routing action %q", options.Action)
+	}
+
+	for _, scName := range options.Subclusters {
+		err = util.ValidateScName(scName)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Filter != "" {
+		err = validateCIDR(options.Filter, "load balance group filter")
+		if err != nil {
+			return err
+		}
+	}
+	if options.Policy != "" {
+		options.Policy = strings.ToUpper(options.Policy)
+		if !util.StringInArray(options.Policy, loadBalancePolicies) {
+			return fmt.Errorf("invalid load balance policy %q, must be one of %v", options.Policy, loadBalancePolicies)
+		}
+	}
+	return nil
+}
+
+func (options *VRoutingRuleOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandRoutingRule, logger)
+	if err != nil {
+		return err
+	}
+	if options.RuleName == "" {
+		return fmt.Errorf("must specify a routing rule name")
+	}
+	err = util.ValidateName(options.RuleName, "routing rule")
+	if err != nil {
+		return err
+	}
+
+	switch options.Action {
+	case RoutingCreate:
+		if options.SourceAddress == "" || options.GroupName == "" {
+			return fmt.Errorf("must specify the source address and the load balance group to create a routing rule")
+		}
+	case RoutingAlter:
+		if options.SourceAddress == "" && options.GroupName == "" {
+			return fmt.Errorf("must specify the source address or the load balance group to alter a routing rule")
+		}
+	case RoutingDrop:
+		if options.SourceAddress != "" || options.GroupName != "" {
+			return fmt.Errorf("the source address and the load balance group cannot be specified to drop a routing rule")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid routing action %q", options.Action)
+	}
+
+	if options.SourceAddress != "" {
+		err = validateCIDR(options.SourceAddress, "routing rule source address")
+		if err != nil {
+			return err
+		}
+	}
+	if options.GroupName != "" {
+		return util.ValidateName(options.GroupName, "load balance group")
+	}
+	return nil
+}
+
+// getQueryParams returns the settings of the load balance group to send to the server
+func (options *VLoadBalanceGroupOptions) getQueryParams() map[string]string {
+	queryParams := make(map[string]string)
+	if len(options.Subclusters) > 0 {
+		queryParams["subclusters"] = strings.Join(options.Subclusters, ",")
+	}
+	if options.Filter != "" {
+		queryParams["filter"] = options.Filter
+	}
+	if options.Policy != "" {
+		queryParams["policy"] = options.Policy
+	}
+	return queryParams
+}
+
+// getQueryParams returns the settings of the routing rule to send to the server
+func (options *VRoutingRuleOptions) getQueryParams() map[string]string {
+	queryParams := make(map[string]string)
+	if options.SourceAddress != "" {
+		queryParams["source"] = options.SourceAddress
+	}
+	if options.GroupName != "" {
+		queryParams["group"] = options.GroupName
+	}
+	return queryParams
+}
+
+// VAlterLoadBalanceGroup creates, alters or drops a connection load balance
+// group made of subclusters of an Eon database. Clients connecting to the
+// database can then be redirected to the nodes of the group.
+func (vcc VClusterCommands) VAlterLoadBalanceGroup(options *VLoadBalanceGroupOptions) (err error) {
+	defer vcc.audit(commandLoadBalanceGroup, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	if !vdb.IsEon {
+		return fmt.Errorf("database %s is not an Eon database, subcluster load balance groups only exist in Eon mode",
+			options.DBName)
+	}
+	scNames := vdb.getSCNames()
+	for _, scName := range options.Subclusters {
+		if !util.StringInArray(scName, scNames) {
+			return fmt.Errorf("subcluster %s does not exist in database %s", scName, options.DBName)
+		}
+	}
+
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return err
+	}
+	httpsRoutingOp, err := makeHTTPSRoutingOp([]string{initiator}, options.usePassword, options.UserName,
+		options.Password, options.Action, loadBalanceGroupEndpoint+options.GroupName, options.getQueryParams())
+	if err != nil {
+		return err
+	}
+
+	return vcc.runSingleOp(&httpsRoutingOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to %s load balance group %s", options.Action, options.GroupName))
+}
+
+// VAlterRoutingRule creates, alters or drops a routing rule, which sends the
+// clients connecting from the given addresses to a load balance group
+func (vcc VClusterCommands) VAlterRoutingRule(options *VRoutingRuleOptions) (err error) {
+	defer vcc.audit(commandRoutingRule, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return err
+	}
+	httpsRoutingOp, err := makeHTTPSRoutingOp([]string{initiator}, options.usePassword, options.UserName,
+		options.Password, options.Action, routingRuleEndpoint+options.RuleName, options.getQueryParams())
+	if err != nil {
+		return err
+	}
+
+	return vcc.runSingleOp(&httpsRoutingOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to %s routing rule %s", options.Action, options.RuleName))
+}
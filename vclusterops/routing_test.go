@@ -0,0 +1,82 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateLoadBalanceGroupOptions(t *testing.T) {
+	options := VLoadBalanceGroupOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+	options.Action = RoutingCreate
+
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must specify a load balance group name")
+	options.GroupName = "group1"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "at least one subcluster")
+	options.Subclusters = []string{"sc1", "sc2"}
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	options.Filter = "192.168.1.0"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must be a CIDR")
+	options.Filter = "192.168.1.0/24"
+	options.Policy = "roundrobin"
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+	assert.Equal(t, "ROUNDROBIN", options.Policy)
+	assert.Equal(t, map[string]string{"subclusters": "sc1,sc2", "filter": "192.168.1.0/24", "policy": "ROUNDROBIN"},
+		options.getQueryParams())
+	options.Policy = "fastest"
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "invalid load balance policy")
+
+	// a group is dropped by name only
+	options.Action = RoutingDrop
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+	options.Subclusters = nil
+	options.Filter = ""
+	options.Policy = ""
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	// something must change to alter a group
+	options.Action = RoutingAlter
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+}
+
+func TestValidateRoutingRuleOptions(t *testing.T) {
+	options := VRoutingRuleOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+	options.Action = RoutingCreate
+	options.RuleName = "rule1"
+
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "must specify the source address")
+	options.SourceAddress = "10.0.0.0/8"
+	options.GroupName = "group1"
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+	assert.Equal(t, map[string]string{"source": "10.0.0.0/8", "group": "group1"}, options.getQueryParams())
+
+	options.Action = RoutingAlter
+	options.SourceAddress = ""
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+
+	options.Action = RoutingDrop
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+	options.GroupName = ""
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+}
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -28,6 +29,13 @@ type VSandboxOptions struct {
 	SCName      string
 	SCHosts     []string
 	SCRawHosts  []string
+	// configuration parameters that are set in the sandbox only,
+	// e.g., to test a version upgrade in the sandbox
+	ConfigParamOverrides map[string]string
+	// whether the sandbox creates its own depot at SandboxDepotPath,
+	// instead of re-using the depot locations of the subcluster
+	CreateSeparateDepot bool
+	SandboxDepotPath    string
 }
 
 func VSandboxOptionsFactory() VSandboxOptions {
@@ -38,6 +46,7 @@ func VSandboxOptionsFactory() VSandboxOptions {
 
 func (options *VSandboxOptions) setDefaultValues() {
 	options.DatabaseOptions.setDefaultValues()
+	options.ConfigParamOverrides = make(map[string]string)
 }
 
 func (options *VSandboxOptions) validateRequiredOptions(logger vlog.Printer) error {
@@ -63,6 +72,23 @@ func (options *VSandboxOptions) validateRequiredOptions(logger vlog.Printer) err
 	if err != nil {
 		return err
 	}
+	return options.validateSandboxOverrides()
+}
+
+// validateSandboxOverrides validates the configuration parameters and
+// the depot that the sandbox does not share with the main cluster
+func (options *VSandboxOptions) validateSandboxOverrides() error {
+	for name := range options.ConfigParamOverrides {
+		if name == "" {
+			return fmt.Errorf("must specify the name of each configuration parameter to override in the sandbox")
+		}
+	}
+	if options.CreateSeparateDepot {
+		return util.ValidateRequiredAbsPath(options.SandboxDepotPath, "sandbox depot path")
+	}
+	if options.SandboxDepotPath != "" {
+		return fmt.Errorf("the sandbox depot path can only be given when the sandbox creates a separate depot")
+	}
 	return nil
 }
 
@@ -152,6 +178,10 @@ func (vcc *VClusterCommands) produceSandboxSubclusterInstructions(options *VSand
 	if err != nil {
 		return instructions, err
 	}
+	httpsSandboxSubclusterOp.configParamOverrides = options.ConfigParamOverrides
+	if options.CreateSeparateDepot {
+		httpsSandboxSubclusterOp.depotPath = options.SandboxDepotPath
+	}
 
 	// Poll for sandboxed nodes to be up
 	httpsPollSubclusterNodeOp, err := makeHTTPSPollSubclusterNodeStateUpOp(options.SCName,
@@ -170,7 +200,8 @@ func (vcc *VClusterCommands) produceSandboxSubclusterInstructions(options *VSand
 	return instructions, nil
 }
 
-func (vcc VClusterCommands) VSandbox(options *VSandboxOptions) error {
+func (vcc VClusterCommands) VSandbox(options *VSandboxOptions) (err error) {
+	defer vcc.audit(commandSandboxSC, &options.DatabaseOptions, options, time.Now(), &err)
 	vcc.Log.V(0).Info("VSandbox method called", "options", options)
 	return runSandboxCmd(vcc, options)
 }
@@ -191,8 +222,8 @@ func (options *VSandboxOptions) runCommand(vcc VClusterCommands) error {
 	}
 
 	// add certs and instructions to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// run the engine
 	runError := clusterOpEngine.run(vcc.Log)
@@ -0,0 +1,60 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateSandboxOverrides(t *testing.T) {
+	options := VSandboxOptionsFactory()
+	assert.NoError(t, options.validateSandboxOverrides())
+
+	options.ConfigParamOverrides["MaxClientSessions"] = "100"
+	assert.NoError(t, options.validateSandboxOverrides())
+
+	// a separate depot needs an absolute path
+	options.CreateSeparateDepot = true
+	assert.Error(t, options.validateSandboxOverrides())
+	options.SandboxDepotPath = "sandbox/depot"
+	assert.Error(t, options.validateSandboxOverrides())
+	options.SandboxDepotPath = "/sandbox/depot"
+	assert.NoError(t, options.validateSandboxOverrides())
+
+	// a depot path without a separate depot is rejected
+	options.CreateSeparateDepot = false
+	assert.Error(t, options.validateSandboxOverrides())
+}
+
+func TestSandboxRequestData(t *testing.T) {
+	op, err := makeHTTPSandboxingOp(vlog.Printer{}, "sc1", "sand", false, "", nil)
+	assert.NoError(t, err)
+
+	// no body is sent without overrides
+	requestData, err := op.setupRequestData()
+	assert.NoError(t, err)
+	assert.Empty(t, requestData)
+
+	op.configParamOverrides = map[string]string{"MaxClientSessions": "100"}
+	op.depotPath = "/sandbox/depot"
+	requestData, err = op.setupRequestData()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"config_parameters": {"MaxClientSessions": "100"}, "depot_path": "/sandbox/depot"}`,
+		requestData)
+}
@@ -0,0 +1,323 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+	"golang.org/x/exp/slices"
+)
+
+// the actions and statuses of the steps of a scale_subcluster plan
+const (
+	ScaleActionAddNode    = "add_node"
+	ScaleActionRemoveNode = "remove_node"
+
+	ScaleStepPending   = "pending"
+	ScaleStepSucceeded = "succeeded"
+	ScaleStepFailed    = "failed"
+)
+
+// VScaleSubclusterOptions represents the available options when you scale a
+// subcluster to a target number of nodes.
+type VScaleSubclusterOptions struct {
+	DatabaseOptions
+	// subcluster to scale
+	SCName string
+	// number of nodes the subcluster must have
+	TargetNodeCount int
+	// Hosts that can be added to the subcluster, in order of preference. The
+	// hosts that are already in the database are not added. When the
+	// subcluster is scaled down, its nodes that are not in the pool are
+	// removed first.
+	HostPool []string
+	// Depot size of the new nodes, e.g., 10G
+	DepotSize string
+	// whether force delete directories of the removed nodes
+	ForceDelete bool
+	// only compute the plan without executing it
+	DryRun bool
+}
+
+// VScaleSubclusterPlan is the list of hosts to add to or remove from a
+// subcluster so that it has the target number of nodes
+type VScaleSubclusterPlan struct {
+	SCName        string   `json:"subcluster"`
+	CurrentHosts  []string `json:"current_hosts"`
+	HostsToAdd    []string `json:"hosts_to_add"`
+	HostsToRemove []string `json:"hosts_to_remove"`
+}
+
+// IsEmpty returns true if the subcluster already has the target number of nodes
+func (plan *VScaleSubclusterPlan) IsEmpty() bool {
+	return len(plan.HostsToAdd) == 0 && len(plan.HostsToRemove) == 0
+}
+
+// VScaleSubclusterStep is a step of a scale_subcluster plan and its result
+type VScaleSubclusterStep struct {
+	Action string   `json:"action"`
+	Hosts  []string `json:"hosts"`
+	Status string   `json:"status"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// VScaleSubclusterResult is what VScaleSubcluster did, or would do in dry run
+type VScaleSubclusterResult struct {
+	Plan  VScaleSubclusterPlan   `json:"plan"`
+	Steps []VScaleSubclusterStep `json:"steps"`
+	// the database after the steps that ran, nil if no step ran
+	Database *VCoordinationDatabase `json:"-"`
+}
+
+func VScaleSubclusterOptionsFactory() VScaleSubclusterOptions {
+	options := VScaleSubclusterOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VScaleSubclusterOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.ForceDelete = true
+}
+
+func (options *VScaleSubclusterOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandScaleSubcluster, logger)
+	if err != nil {
+		return err
+	}
+
+	if !options.IsEon {
+		return fmt.Errorf("cannot scale subcluster in an enterprise database '%s'", options.DBName)
+	}
+
+	if options.SCName == "" {
+		return fmt.Errorf("must specify a subcluster name")
+	}
+	err = util.ValidateScName(options.SCName)
+	if err != nil {
+		return err
+	}
+
+	if options.TargetNodeCount < 0 {
+		return fmt.Errorf("the target node count cannot be negative")
+	}
+	return nil
+}
+
+func (options *VScaleSubclusterOptions) analyzeOptions() (err error) {
+	// we analyze host names when it is set in user input, otherwise we use hosts in yaml config
+	if len(options.RawHosts) > 0 {
+		// resolve RawHosts to be IP addresses
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+		options.normalizePaths()
+	}
+	if len(options.HostPool) > 0 {
+		options.HostPool, err = util.ResolveRawHostsToAddresses(options.HostPool, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (options *VScaleSubclusterOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	err := options.analyzeOptions()
+	if err != nil {
+		return err
+	}
+	return options.setUsePassword(logger)
+}
+
+// VScaleSubcluster makes a subcluster have the target number of nodes. It
+// computes the hosts to add from the host pool or the nodes to remove, and
+// runs VAddNode or VRemoveNode accordingly. Running it again with the same
+// options is a no-op once the subcluster has converged, so it can be called
+// periodically by an operator or an autoscaler.
+//
+// The returned result has the plan and the result of every step, even when
+// a step fails. With DryRun, only the plan is computed.
+func (vcc VClusterCommands) VScaleSubcluster(options *VScaleSubclusterOptions) (result VScaleSubclusterResult, err error) {
+	defer vcc.audit(commandScaleSubcluster, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return result, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.scaleSubclusterPreCheck(&vdb, options)
+	if err != nil {
+		return result, err
+	}
+
+	result.Plan, err = computeScaleSubclusterPlan(&vdb, options)
+	if err != nil {
+		return result, err
+	}
+	result.Steps = result.Plan.makeSteps()
+	if result.Plan.IsEmpty() {
+		vcc.PrintInfo("Subcluster %s already has %d nodes", options.SCName, options.TargetNodeCount)
+		return result, nil
+	}
+	if options.DryRun {
+		return result, nil
+	}
+
+	for i := range result.Steps {
+		step := &result.Steps[i]
+		vcc.PrintInfo("Running %s on hosts %v for subcluster %s", step.Action, step.Hosts, options.SCName)
+		vdb, err = vcc.runScaleSubclusterStep(step, options)
+		if err != nil {
+			step.Status = ScaleStepFailed
+			step.Error = err.Error()
+			return result, fmt.Errorf("fail to %s for subcluster %s: %w", step.Action, options.SCName, err)
+		}
+		step.Status = ScaleStepSucceeded
+		result.Database = &vdb
+	}
+	return result, nil
+}
+
+// scaleSubclusterPreCheck gets the running database and checks that the
+// subcluster exists and is not sandboxed
+func (vcc VClusterCommands) scaleSubclusterPreCheck(vdb *VCoordinationDatabase, options *VScaleSubclusterOptions) error {
+	err := vcc.getVDBFromRunningDB(vdb, &options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+	if !vdb.IsEon {
+		return fmt.Errorf("cannot scale subcluster in an enterprise database '%s'", options.DBName)
+	}
+
+	httpsFindSubclusterOp, err := makeHTTPSFindSubclusterOp(options.Hosts,
+		options.usePassword, options.UserName, options.Password,
+		options.SCName, false /*do not ignore not found*/, ScaleSubclusterCmd)
+	if err != nil {
+		return err
+	}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&httpsFindSubclusterOp}, &certs)
+	return clusterOpEngine.run(vcc.Log)
+}
+
+// computeScaleSubclusterPlan returns the hosts to add or remove so that the
+// subcluster has the target number of nodes. The hosts to add are taken from
+// the host pool, in order, skipping those that are already in the database.
+// The nodes to remove are chosen in the following order: down nodes, nodes
+// that are not in the host pool, then the most recent nodes.
+func computeScaleSubclusterPlan(vdb *VCoordinationDatabase, options *VScaleSubclusterOptions) (VScaleSubclusterPlan, error) {
+	plan := VScaleSubclusterPlan{SCName: options.SCName}
+	var currentNodes []*VCoordinationNode
+	isPrimary := false
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Subcluster == options.SCName {
+			currentNodes = append(currentNodes, vnode)
+			isPrimary = isPrimary || vnode.IsPrimary
+		}
+	}
+	sort.Slice(currentNodes, func(i, j int) bool { return currentNodes[i].Name < currentNodes[j].Name })
+	for _, vnode := range currentNodes {
+		plan.CurrentHosts = append(plan.CurrentHosts, vnode.Address)
+	}
+
+	diff := options.TargetNodeCount - len(currentNodes)
+	switch {
+	case diff > 0:
+		for _, host := range options.HostPool {
+			if len(plan.HostsToAdd) == diff {
+				break
+			}
+			if _, inDB := vdb.HostNodeMap[host]; !inDB && !slices.Contains(plan.HostsToAdd, host) {
+				plan.HostsToAdd = append(plan.HostsToAdd, host)
+			}
+		}
+		if len(plan.HostsToAdd) < diff {
+			return plan, fmt.Errorf("the host pool has %d available hosts, but %d hosts are needed to scale subcluster %s to %d nodes",
+				len(plan.HostsToAdd), diff, options.SCName, options.TargetNodeCount)
+		}
+	case diff < 0:
+		if isPrimary && options.TargetNodeCount == 0 {
+			return plan, fmt.Errorf("cannot scale primary subcluster %s to 0 nodes", options.SCName)
+		}
+		sort.Slice(currentNodes, func(i, j int) bool { return currentNodes[i].Name > currentNodes[j].Name })
+		sort.SliceStable(currentNodes, func(i, j int) bool {
+			return removalRank(currentNodes[i], options.HostPool) < removalRank(currentNodes[j], options.HostPool)
+		})
+		for _, vnode := range currentNodes[:-diff] {
+			plan.HostsToRemove = append(plan.HostsToRemove, vnode.Address)
+		}
+	}
+	return plan, nil
+}
+
+// removalRank returns the order in which the nodes of a subcluster are
+// removed, lower first. Among nodes of the same rank, the most recent nodes,
+// i.e., the ones with the highest names, are removed first.
+func removalRank(vnode *VCoordinationNode, hostPool []string) int {
+	switch {
+	case vnode.State == util.NodeDownState:
+		return 0
+	case len(hostPool) > 0 && !slices.Contains(hostPool, vnode.Address):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (plan *VScaleSubclusterPlan) makeSteps() []VScaleSubclusterStep {
+	steps := []VScaleSubclusterStep{}
+	if len(plan.HostsToAdd) > 0 {
+		steps = append(steps, VScaleSubclusterStep{Action: ScaleActionAddNode, Hosts: plan.HostsToAdd, Status: ScaleStepPending})
+	}
+	if len(plan.HostsToRemove) > 0 {
+		steps = append(steps, VScaleSubclusterStep{Action: ScaleActionRemoveNode, Hosts: plan.HostsToRemove, Status: ScaleStepPending})
+	}
+	return steps
+}
+
+func (vcc VClusterCommands) runScaleSubclusterStep(step *VScaleSubclusterStep,
+	options *VScaleSubclusterOptions) (VCoordinationDatabase, error) {
+	if step.Action == ScaleActionAddNode {
+		addNodeOpt := VAddNodeOptionsFactory()
+		addNodeOpt.DatabaseOptions = options.DatabaseOptions
+		addNodeOpt.NewHosts = step.Hosts
+		addNodeOpt.SCName = options.SCName
+		addNodeOpt.DepotSize = options.DepotSize
+		// a new attempt after a failure reuses the nodes that were added
+		addNodeOpt.Idempotent = true
+		return vcc.VAddNode(&addNodeOpt)
+	}
+
+	removeNodeOpt := VRemoveNodeOptionsFactory()
+	removeNodeOpt.DatabaseOptions = options.DatabaseOptions
+	removeNodeOpt.HostsToRemove = step.Hosts
+	removeNodeOpt.ForceDelete = options.ForceDelete
+	removeNodeOpt.IsSubcluster = options.TargetNodeCount == 0
+	return vcc.VRemoveNode(&removeNodeOpt)
+}
@@ -0,0 +1,104 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestScaleSubclusterOptions(t *testing.T) {
+	options := VScaleSubclusterOptionsFactory()
+	options.RawHosts = []string{"vnode1"}
+	options.Password = new(string)
+	options.DBName = dbName
+	options.IsEon = true
+
+	err := options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "must specify a subcluster name")
+
+	options.SCName = "sc1"
+	options.TargetNodeCount = -1
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.ErrorContains(t, err, "the target node count cannot be negative")
+
+	options.TargetNodeCount = 3
+	err = options.validateParseOptions(vlog.Printer{})
+	assert.NoError(t, err)
+}
+
+func TestComputeScaleSubclusterPlan(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.168.1.101": &VCoordinationNode{Name: "v_test_db_node0001", Address: "192.168.1.101",
+			Subcluster: "default_subcluster", IsPrimary: true, State: "UP"},
+		"192.168.1.102": &VCoordinationNode{Name: "v_test_db_node0002", Address: "192.168.1.102",
+			Subcluster: "sc1", State: "UP"},
+		"192.168.1.103": &VCoordinationNode{Name: "v_test_db_node0003", Address: "192.168.1.103",
+			Subcluster: "sc1", State: "UP"},
+		"192.168.1.104": &VCoordinationNode{Name: "v_test_db_node0004", Address: "192.168.1.104",
+			Subcluster: "sc1", State: "UP"},
+	}
+	options := VScaleSubclusterOptionsFactory()
+	options.SCName = "sc1"
+
+	// scale up with hosts from the pool that are not in the database
+	options.TargetNodeCount = 5
+	options.HostPool = []string{"192.168.1.101", "192.168.1.105", "192.168.1.106", "192.168.1.107"}
+	plan, err := computeScaleSubclusterPlan(&vdb, &options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.102", "192.168.1.103", "192.168.1.104"}, plan.CurrentHosts)
+	assert.Equal(t, []string{"192.168.1.105", "192.168.1.106"}, plan.HostsToAdd)
+	assert.Empty(t, plan.HostsToRemove)
+	steps := plan.makeSteps()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, ScaleActionAddNode, steps[0].Action)
+	assert.Equal(t, ScaleStepPending, steps[0].Status)
+
+	// not enough hosts in the pool
+	options.TargetNodeCount = 7
+	_, err = computeScaleSubclusterPlan(&vdb, &options)
+	assert.ErrorContains(t, err, "the host pool has 3 available hosts, but 4 hosts are needed")
+
+	// already converged
+	options.TargetNodeCount = 3
+	plan, err = computeScaleSubclusterPlan(&vdb, &options)
+	assert.NoError(t, err)
+	assert.True(t, plan.IsEmpty())
+	assert.Empty(t, plan.makeSteps())
+
+	// scale down removes the most recent nodes first
+	options.TargetNodeCount = 1
+	options.HostPool = nil
+	plan, err = computeScaleSubclusterPlan(&vdb, &options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.104", "192.168.1.103"}, plan.HostsToRemove)
+
+	// down nodes and nodes out of the pool are removed first
+	vdb.HostNodeMap["192.168.1.102"].State = "DOWN"
+	options.HostPool = []string{"192.168.1.102", "192.168.1.104"}
+	plan, err = computeScaleSubclusterPlan(&vdb, &options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.102", "192.168.1.103"}, plan.HostsToRemove)
+
+	// a primary subcluster cannot be scaled to zero
+	options.SCName = "default_subcluster"
+	options.TargetNodeCount = 0
+	_, err = computeScaleSubclusterPlan(&vdb, &options)
+	assert.ErrorContains(t, err, "cannot scale primary subcluster default_subcluster to 0 nodes")
+}
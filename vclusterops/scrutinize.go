@@ -92,37 +92,51 @@ func generateScrutinizeID() string {
 }
 
 func (options *VScrutinizeOptions) setLogAgeRange(logger vlog.Printer) (err error) {
+	options.logAgeMaxHours, options.logAgeMinHours, err = getLogAgeRange(options.LogAgeOldestTime,
+		options.LogAgeNewestTime, options.LogAgeHours, options.timeFormats, logger)
+	return err
+}
+
+// getLogAgeRange calculates the maximum and minimum ages in hours of the
+// archived logs to collect. The oldest time, if set, overrides the max age.
+func getLogAgeRange(oldestTime, newestTime string, maxAgeHours int, timeFormats []util.TimeFormat,
+	logger vlog.Printer) (logAgeMaxHours, logAgeMinHours int, err error) {
 	// calculate maximum allowed age for archived logs
-	options.logAgeMaxHours = options.LogAgeHours
-	if options.LogAgeOldestTime != "" {
-		options.logAgeMaxHours, err = options.getHoursAgo(options.LogAgeOldestTime, "LogAgeOldestTime", logger)
+	logAgeMaxHours = maxAgeHours
+	if oldestTime != "" {
+		logAgeMaxHours, err = getHoursAgo(oldestTime, "LogAgeOldestTime", timeFormats, logger)
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 	}
 
 	// calculate minimum allowed age for archived logs (default 0)
-	if options.LogAgeNewestTime != "" {
-		options.logAgeMinHours, err = options.getHoursAgo(options.LogAgeNewestTime, "LogAgeNewestTime", logger)
+	if newestTime != "" {
+		logAgeMinHours, err = getHoursAgo(newestTime, "LogAgeNewestTime", timeFormats, logger)
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 	}
 
 	// sanity check values
-	if options.logAgeMaxHours < options.logAgeMinHours {
+	if logAgeMaxHours < logAgeMinHours {
 		err = fmt.Errorf("invalid time range: max log age cannot be less than min log age")
-		logger.Error(err, "invalid log age range", "MaxHours", options.logAgeMaxHours, "MinHours", options.logAgeMinHours)
-		return err
+		logger.Error(err, "invalid log age range", "MaxHours", logAgeMaxHours, "MinHours", logAgeMinHours)
+		return 0, 0, err
 	}
 
-	logger.Info("Archived log time range set", "MaxHours", options.logAgeMaxHours, "MinHours", options.logAgeMinHours)
-	return nil
+	logger.Info("Archived log time range set", "MaxHours", logAgeMaxHours, "MinHours", logAgeMinHours)
+	return logAgeMaxHours, logAgeMinHours, nil
 }
 
 // getHoursAgo converts time strings into hour durations according to the options' allowed formats
 func (options *VScrutinizeOptions) getHoursAgo(timeString, timeVarName string, logger vlog.Printer) (int, error) {
-	t, err := util.ParseTime(timeString, options.timeFormats)
+	return getHoursAgo(timeString, timeVarName, options.timeFormats, logger)
+}
+
+// getHoursAgo converts time strings into hour durations according to the given formats
+func getHoursAgo(timeString, timeVarName string, timeFormats []util.TimeFormat, logger vlog.Printer) (int, error) {
+	t, err := util.ParseTime(timeString, timeFormats)
 	if err != nil {
 		logger.Log.Error(err, "Failed to parse time input", timeVarName, timeString)
 		return 0, fmt.Errorf("unable to parse time '%s' according to allowed format %s", timeString, ScrutinizeHelpTimeFormatDesc)
@@ -135,7 +149,7 @@ func (options *VScrutinizeOptions) getHoursAgo(timeString, timeVarName string, l
 	}
 	if hours < 0 {
 		logger.Log.Info("Provided time is or rounds to a future time.  Using 0 instead.",
-			"Field", timeVarName, "HoursAgo", options.logAgeMaxHours)
+			"Field", timeVarName, "HoursAgo", hours)
 		hours = 0
 	}
 
@@ -191,9 +205,10 @@ func (options *VScrutinizeOptions) ValidateAnalyzeOptions(logger vlog.Printer) e
 	return options.analyzeOptions(logger)
 }
 
-func (vcc VClusterCommands) VScrutinize(options *VScrutinizeOptions) error {
+func (vcc VClusterCommands) VScrutinize(options *VScrutinizeOptions) (err error) {
+	defer vcc.audit(VScrutinizeTypeName, &options.DatabaseOptions, options, time.Now(), &err)
 	// check required options (including those that can come from cluster config)
-	err := options.ValidateAnalyzeOptions(vcc.Log)
+	err = options.ValidateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		vcc.Log.Error(err, "validation of scrutinize arguments failed")
 		return err
@@ -203,7 +218,7 @@ func (vcc VClusterCommands) VScrutinize(options *VScrutinizeOptions) error {
 	// 1. slice of nodes with NMA running
 	// 2. host -> node info map
 	vdb := makeVCoordinationDatabase()
-	err = options.getVDBForScrutinize(vcc.Log, &vdb)
+	err = vcc.getVDBForScrutinize(&options.DatabaseOptions, &vdb)
 	if err != nil {
 		vcc.Log.Error(err, "failed to retrieve cluster info for scrutinize")
 		return err
@@ -217,7 +232,7 @@ func (vcc VClusterCommands) VScrutinize(options *VScrutinizeOptions) error {
 		vcc.Log.Error(err, "failed to produce instructions for scrutinize")
 		return err
 	}
-	err = options.runClusterOpEngine(vcc.Log, instructions)
+	err = vcc.runClusterOpEngine(&options.DatabaseOptions, instructions)
 	if err != nil {
 		vcc.Log.Error(err, "failed to run scrutinize operations")
 		return err
@@ -266,7 +281,7 @@ func tarAndRemoveDirectory(tarballName, id string, log vlog.Printer) (err error)
 	if err = cmd.Run(); err != nil {
 		return
 	}
-	log.PrintInfo("Scrutinize final result at %s", tarballPath)
+	log.PrintInfo("Final result at %s", tarballPath)
 
 	intermediateDirectoryPath := "/tmp/scrutinize/remote/" + id
 	if err = os.RemoveAll(intermediateDirectoryPath); err != nil {
@@ -278,11 +293,11 @@ func tarAndRemoveDirectory(tarballName, id string, log vlog.Printer) (err error)
 
 // getVDBForScrutinize populates an empty coordinator database with the minimum
 // required information for further scrutinize operations.
-func (options *VScrutinizeOptions) getVDBForScrutinize(logger vlog.Printer,
+func (vcc VClusterCommands) getVDBForScrutinize(options *DatabaseOptions,
 	vdb *VCoordinationDatabase) error {
 	// get nodes where NMA is running and only use those for NMA ops
 	getHealthyNodesOp := makeNMAGetHealthyNodesOp(options.Hosts, vdb)
-	err := options.runClusterOpEngine(logger, []clusterOp{&getHealthyNodesOp})
+	err := vcc.runClusterOpEngine(options, []clusterOp{&getHealthyNodesOp})
 	if err != nil {
 		return err
 	}
@@ -290,7 +305,7 @@ func (options *VScrutinizeOptions) getVDBForScrutinize(logger vlog.Printer,
 	// get map of host to node name and fully qualified catalog path
 	getNodesInfoOp := makeNMAGetNodesInfoOp(vdb.HostList, options.DBName,
 		options.CatalogPrefix, true /* ignore internal errors */, vdb)
-	err = options.runClusterOpEngine(logger, []clusterOp{&getNodesInfoOp})
+	err = vcc.runClusterOpEngine(options, []clusterOp{&getNodesInfoOp})
 	if err != nil {
 		return err
 	}
@@ -319,6 +334,7 @@ func (options *VScrutinizeOptions) getVDBForScrutinize(logger vlog.Printer,
 //   - Stage vertica logs on all nodes
 //   - Stage files on all nodes
 //   - Stage DC tables on all nodes
+//   - Stage startup.log on all nodes
 //   - Tar and retrieve vertica logs and DC tables from all nodes (batch normal)
 //   - Tar and retrieve error report from all nodes (batch context)
 //   - (If applicable) Poll for system table staging completion on task node
@@ -364,6 +380,14 @@ func (vcc VClusterCommands) produceScrutinizeInstructions(options *VScrutinizeOp
 	}
 	instructions = append(instructions, &stageDCTablesOp)
 
+	// stage startup.log
+	stageStartupLogOp, err := makeNMAStageStartupLogOp(options.ID, scrutinizeBatchNormal, options.Hosts,
+		hostNodeNameMap, hostCatPathMap, scrutinizeFileLimitBytes)
+	if err != nil {
+		return nil, err
+	}
+	instructions = append(instructions, &stageStartupLogOp)
+
 	// stage 'normal' batch files -- see NMA for what files are collected
 	stageVerticaNormalFilesOp, err := makeNMAStageFilesOp(options.ID, scrutinizeBatchNormal,
 		options.Hosts, hostNodeNameMap, hostCatPathMap, scrutinizeFileLimitBytes)
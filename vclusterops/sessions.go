@@ -0,0 +1,393 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// SessionFilter selects client sessions of a database. The criteria are
+// combined, e.g., the sessions of a user on a subcluster. An empty filter
+// selects all the sessions.
+type SessionFilter struct {
+	// only select the sessions connected to these nodes
+	NodeNames []string
+	// only select the sessions connected to the nodes of this subcluster
+	SCName string
+	// only select the sessions of this database user
+	SessionUser string
+}
+
+// SessionInfo describes a client session of a database
+type SessionInfo struct {
+	SessionID        string `json:"session_id"`
+	NodeName         string `json:"node_name"`
+	Subcluster       string `json:"subcluster"`
+	UserName         string `json:"user_name"`
+	ClientHostname   string `json:"client_hostname"`
+	LoginTimestamp   string `json:"login_timestamp"`
+	StatementStart   string `json:"statement_start"`
+	CurrentStatement string `json:"current_statement"`
+}
+
+type VListSessionsOptions struct {
+	DatabaseOptions
+	SessionFilter
+}
+
+type VCloseSessionsOptions struct {
+	DatabaseOptions
+	SessionFilter
+	// only close the sessions with these IDs among the selected ones
+	SessionIDs []string
+}
+
+func VListSessionsOptionsFactory() VListSessionsOptions {
+	options := VListSessionsOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func VCloseSessionsOptionsFactory() VCloseSessionsOptions {
+	options := VCloseSessionsOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (filter *SessionFilter) validate() error {
+	for _, nodeName := range filter.NodeNames {
+		if nodeName == "" {
+			return fmt.Errorf("node names must not be empty")
+		}
+	}
+	if filter.SCName != "" {
+		return util.ValidateScName(filter.SCName)
+	}
+	return nil
+}
+
+func (filter *SessionFilter) isEmpty() bool {
+	return len(filter.NodeNames) == 0 && filter.SCName == "" && filter.SessionUser == ""
+}
+
+func (options *VListSessionsOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandListSessions, logger)
+	if err != nil {
+		return err
+	}
+	return options.SessionFilter.validate()
+}
+
+func (options *VListSessionsOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VListSessionsOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+func (options *VCloseSessionsOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandCloseSessions, logger)
+	if err != nil {
+		return err
+	}
+	err = options.SessionFilter.validate()
+	if err != nil {
+		return err
+	}
+	// closing all the sessions of the database must not happen by mistake
+	if options.SessionFilter.isEmpty() && len(options.SessionIDs) == 0 {
+		return fmt.Errorf("must select the sessions to close by node, subcluster, user, or session ID")
+	}
+	return nil
+}
+
+func (options *VCloseSessionsOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VCloseSessionsOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VListSessions lists the client sessions of a running database that match
+// a filter, e.g., to find the sessions that keep a subcluster from draining
+// before it is stopped or removed. The sessions are sorted by node and ID.
+func (vcc VClusterCommands) VListSessions(options *VListSessionsOptions) (sessions []SessionInfo, err error) {
+	defer vcc.audit(commandListSessions, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Get the sessions from an up node and filter them
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, _, err = vcc.getFilteredSessions(&options.DatabaseOptions, &options.SessionFilter)
+	return sessions, err
+}
+
+// VCloseSessions forcibly closes the client sessions of a running database
+// that match a filter, e.g., the stragglers that keep a subcluster from
+// draining. The sessions that were closed are returned. A session that ended
+// on its own before it could be closed is not an error.
+func (vcc VClusterCommands) VCloseSessions(options *VCloseSessionsOptions) (closed []SessionInfo, err error) {
+	defer vcc.audit(commandCloseSessions, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the sessions to close
+	 *   - Produce Instructions
+	 *   - Create a VClusterOpEngine
+	 *   - Give the instructions to the VClusterOpEngine to run
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, initiator, err := vcc.getFilteredSessions(&options.DatabaseOptions, &options.SessionFilter)
+	if err != nil {
+		return nil, err
+	}
+	sessions = filterSessionsByID(sessions, options.SessionIDs)
+	if len(sessions) == 0 {
+		vcc.Log.PrintInfo("No session to close")
+		return []SessionInfo{}, nil
+	}
+
+	return vcc.closeSessions(&options.DatabaseOptions, initiator, sessions)
+}
+
+// closeSessions closes the given sessions through the initiator, and
+// returns the ones that were closed
+func (vcc VClusterCommands) closeSessions(options *DatabaseOptions, initiator string,
+	sessions []SessionInfo) ([]SessionInfo, error) {
+	sessionIDs := make([]string, len(sessions))
+	for i := range sessions {
+		sessionIDs[i] = sessions[i].SessionID
+	}
+	var closedIDs []string
+	httpsCloseSessionsOp, err := makeHTTPSCloseSessionsOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, sessionIDs, &closedIDs)
+	if err != nil {
+		return nil, err
+	}
+	instructions := []clusterOp{&httpsCloseSessionsOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to close %d sessions: %w", len(sessionIDs), err)
+	}
+
+	return filterSessionsByID(sessions, closedIDs), nil
+}
+
+// getFilteredSessions gets the sessions of the database from an up node,
+// and returns the ones that match the filter along with that up node
+func (vcc VClusterCommands) getFilteredSessions(options *DatabaseOptions,
+	filter *SessionFilter) (sessions []SessionInfo, initiator string, err error) {
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, options)
+	if err != nil {
+		return nil, "", fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	return vcc.getFilteredSessionsOfVDB(options, &vdb, filter)
+}
+
+// getFilteredSessionsOfVDB is getFilteredSessions for a database whose nodes
+// are already known
+func (vcc VClusterCommands) getFilteredSessionsOfVDB(options *DatabaseOptions, vdb *VCoordinationDatabase,
+	filter *SessionFilter) (sessions []SessionInfo, initiator string, err error) {
+	nodeNames, err := getFilteredNodeNames(vdb, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	initiator, err = getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return nil, "", err
+	}
+
+	httpsGetSessionsOp, err := makeHTTPSGetSessionsOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, &sessions)
+	if err != nil {
+		return nil, "", err
+	}
+	instructions := []clusterOp{&httpsGetSessionsOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, "", fmt.Errorf("fail to get the sessions of database %s: %w", options.DBName, err)
+	}
+
+	return filterSessions(sessions, vdb, nodeNames, filter.SessionUser), initiator, nil
+}
+
+// drainSessions reports the client sessions selected by filter, which keep
+// their nodes from draining, and closes them if closeSessions is set. The
+// sessions are only reported on a best-effort basis, so a failure to get
+// them is a warning unless they must be closed.
+func (vcc VClusterCommands) drainSessions(options *DatabaseOptions, vdb *VCoordinationDatabase,
+	filter *SessionFilter, closeSessions bool) error {
+	sessions, initiator, err := vcc.getFilteredSessionsOfVDB(options, vdb, filter)
+	if err != nil {
+		if closeSessions {
+			return fmt.Errorf("fail to get the sessions to close: %w", err)
+		}
+		vcc.Log.PrintWarning("Fail to get the client sessions that may block the drain: %v", err)
+		return nil
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	if !closeSessions {
+		vcc.Log.PrintWarning("%d client sessions are connected to the nodes being drained: %s",
+			len(sessions), formatSessions(sessions))
+		return nil
+	}
+	closed, err := vcc.closeSessions(options, initiator, sessions)
+	if err != nil {
+		return err
+	}
+	vcc.Log.PrintInfo("Closed %d client sessions: %s", len(closed), formatSessions(closed))
+	return nil
+}
+
+// formatSessions describes the sessions for a log message, e.g.,
+// "v_test_db_node0001-12345:0x3b (dbadmin on v_test_db_node0001)"
+func formatSessions(sessions []SessionInfo) string {
+	descriptions := make([]string, len(sessions))
+	for i := range sessions {
+		descriptions[i] = fmt.Sprintf("%s (%s on %s)", sessions[i].SessionID,
+			sessions[i].UserName, sessions[i].NodeName)
+	}
+	return util.ArrayToString(descriptions, ", ")
+}
+
+// getFilteredNodeNames returns the names of the nodes selected by the node
+// and subcluster criteria of the filter, or nil if all the nodes are selected
+func getFilteredNodeNames(vdb *VCoordinationDatabase, filter *SessionFilter) (mapset.Set[string], error) {
+	if len(filter.NodeNames) == 0 && filter.SCName == "" {
+		return nil, nil
+	}
+
+	nodeNames := mapset.NewSet[string]()
+	scFound := false
+	for _, vnode := range vdb.HostNodeMap {
+		if filter.SCName != "" && vnode.Subcluster != filter.SCName {
+			continue
+		}
+		scFound = true
+		if len(filter.NodeNames) == 0 || util.StringInArray(vnode.Name, filter.NodeNames) {
+			nodeNames.Add(vnode.Name)
+		}
+	}
+	if filter.SCName != "" && !scFound {
+		return nil, fmt.Errorf("subcluster %s does not exist in database %s", filter.SCName, vdb.Name)
+	}
+	nodeNameToHost := vdb.genNodeNameToHostMap()
+	for _, nodeName := range filter.NodeNames {
+		if _, exists := nodeNameToHost[nodeName]; !exists {
+			return nil, fmt.Errorf("node %s does not exist in database %s", nodeName, vdb.Name)
+		}
+	}
+	return nodeNames, nil
+}
+
+// filterSessions returns the sessions connected to the selected nodes and
+// owned by the selected user, with their subcluster filled in. A nil set of
+// nodes or an empty user selects all.
+func filterSessions(sessions []SessionInfo, vdb *VCoordinationDatabase,
+	nodeNames mapset.Set[string], sessionUser string) []SessionInfo {
+	nodeSubclusters := make(map[string]string)
+	for _, vnode := range vdb.HostNodeMap {
+		nodeSubclusters[vnode.Name] = vnode.Subcluster
+	}
+
+	filtered := []SessionInfo{}
+	for _, session := range sessions {
+		if nodeNames != nil && !nodeNames.Contains(session.NodeName) {
+			continue
+		}
+		if sessionUser != "" && session.UserName != sessionUser {
+			continue
+		}
+		session.Subcluster = nodeSubclusters[session.NodeName]
+		filtered = append(filtered, session)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].NodeName != filtered[j].NodeName {
+			return filtered[i].NodeName < filtered[j].NodeName
+		}
+		return filtered[i].SessionID < filtered[j].SessionID
+	})
+	return filtered
+}
+
+// filterSessionsByID returns the sessions with the given IDs, or all the
+// sessions if no ID is given
+func filterSessionsByID(sessions []SessionInfo, sessionIDs []string) []SessionInfo {
+	if len(sessionIDs) == 0 {
+		return sessions
+	}
+	filtered := []SessionInfo{}
+	for _, session := range sessions {
+		if util.StringInArray(session.SessionID, sessionIDs) {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,132 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func makeSessionsTestVDB() VCoordinationDatabase {
+	vdb := makeVCoordinationDatabase()
+	vdb.Name = dbName
+	vdb.HostNodeMap = vHostNodeMap{
+		"192.0.2.1": {Name: "v_test_db_node0001", Subcluster: "sc1"},
+		"192.0.2.2": {Name: "v_test_db_node0002", Subcluster: "sc2"},
+		"192.0.2.3": {Name: "v_test_db_node0003", Subcluster: "sc2"},
+	}
+	return vdb
+}
+
+func TestValidateCloseSessionsOptions(t *testing.T) {
+	options := VCloseSessionsOptionsFactory()
+	options.DBName = dbName
+	options.RawHosts = []string{"192.0.2.1"}
+	// closing all the sessions requires a criterion
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "must select the sessions to close")
+
+	options.SessionUser = "app_user"
+	assert.NoError(t, options.validateAnalyzeOptions(vlog.Printer{}))
+
+	options.NodeNames = []string{""}
+	assert.ErrorContains(t, options.validateAnalyzeOptions(vlog.Printer{}), "node names must not be empty")
+}
+
+func TestFilterSessions(t *testing.T) {
+	vdb := makeSessionsTestVDB()
+	sessions := []SessionInfo{
+		{SessionID: "s3", NodeName: "v_test_db_node0003", UserName: "app_user"},
+		{SessionID: "s2", NodeName: "v_test_db_node0002", UserName: "dbadmin"},
+		{SessionID: "s1", NodeName: "v_test_db_node0001", UserName: "app_user"},
+		{SessionID: "s0", NodeName: "v_test_db_node0002", UserName: "app_user"},
+	}
+
+	// an empty filter selects all the sessions, sorted by node and ID
+	nodeNames, err := getFilteredNodeNames(&vdb, &SessionFilter{})
+	assert.NoError(t, err)
+	assert.Nil(t, nodeNames)
+	filtered := filterSessions(sessions, &vdb, nodeNames, "")
+	assert.Len(t, filtered, 4)
+	assert.Equal(t, "s1", filtered[0].SessionID)
+	assert.Equal(t, "sc1", filtered[0].Subcluster)
+	assert.Equal(t, "s0", filtered[1].SessionID)
+
+	// the criteria are combined
+	nodeNames, err = getFilteredNodeNames(&vdb, &SessionFilter{SCName: "sc2"})
+	assert.NoError(t, err)
+	filtered = filterSessions(sessions, &vdb, nodeNames, "app_user")
+	assert.Equal(t, []SessionInfo{
+		{SessionID: "s0", NodeName: "v_test_db_node0002", Subcluster: "sc2", UserName: "app_user"},
+		{SessionID: "s3", NodeName: "v_test_db_node0003", Subcluster: "sc2", UserName: "app_user"},
+	}, filtered)
+
+	nodeNames, err = getFilteredNodeNames(&vdb, &SessionFilter{SCName: "sc2", NodeNames: []string{"v_test_db_node0003"}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"v_test_db_node0003"}, nodeNames.ToSlice())
+
+	_, err = getFilteredNodeNames(&vdb, &SessionFilter{SCName: "sc3"})
+	assert.ErrorContains(t, err, "subcluster sc3 does not exist")
+	_, err = getFilteredNodeNames(&vdb, &SessionFilter{NodeNames: []string{"v_test_db_node0004"}})
+	assert.ErrorContains(t, err, "node v_test_db_node0004 does not exist")
+
+	assert.Equal(t, sessions, filterSessionsByID(sessions, nil))
+	assert.Equal(t, []SessionInfo{sessions[1]}, filterSessionsByID(sessions, []string{"s2", "s9"}))
+}
+
+func TestCloseSessionsOp(t *testing.T) {
+	var closedIDs []string
+	op, err := makeHTTPSCloseSessionsOp([]string{"192.0.2.1"}, false, "", nil, []string{"s1", "s2"}, &closedIDs)
+	assert.NoError(t, err)
+	op.setLogger(vlog.Printer{})
+
+	op.clusterHTTPRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	assert.NoError(t, op.setupClusterHTTPRequest(op.hosts))
+	assert.JSONEq(t, `{"session_ids": ["s1", "s2"]}`, op.clusterHTTPRequest.RequestCollection["192.0.2.1"].RequestData)
+
+	// s2 ended before it could be closed
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: `{"closed_sessions": ["s1"]}`},
+	}
+	assert.NoError(t, op.processResult(nil))
+	assert.Equal(t, []string{"s1"}, closedIDs)
+
+	op.clusterHTTPRequest.ResultCollection = map[string]hostHTTPResult{
+		"192.0.2.1": {host: "192.0.2.1", status: SUCCESS, statusCode: SuccessCode, content: `{"detail": "closed"}`},
+	}
+	assert.Error(t, op.processResult(nil))
+}
+
+func TestFormatSessions(t *testing.T) {
+	sessions := []SessionInfo{
+		{SessionID: "s1", UserName: "dbadmin", NodeName: "v_test_db_node0001"},
+		{SessionID: "s2", UserName: "app_user", NodeName: "v_test_db_node0002"},
+	}
+	assert.Equal(t, "s1 (dbadmin on v_test_db_node0001), s2 (app_user on v_test_db_node0002)",
+		formatSessions(sessions))
+}
+
+func TestValidateStopSubclusterCloseSessions(t *testing.T) {
+	options := VStopSubclusterOptionsFactory()
+	options.IsEon = true
+	options.CloseSessions = true
+	assert.NoError(t, options.validateEonOptions(vlog.Printer{}))
+
+	// a forcibly stopped subcluster does not drain
+	options.Force = true
+	assert.ErrorContains(t, options.validateEonOptions(vlog.Printer{}), "cannot close the sessions")
+}
@@ -0,0 +1,205 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the highest design k-safety supported by vertica
+const maxKSafety = 2
+
+type VSetKSafetyOptions struct {
+	DatabaseOptions
+	// the design k-safety to set, between 0 and 2
+	KSafety int
+}
+
+type VGetKSafetyOptions struct {
+	DatabaseOptions
+}
+
+// KSafetyInfo is the k-safety of a running database
+type KSafetyInfo struct {
+	// the k-safety the physical design was marked with
+	DesignedKSafety int `json:"designed_ksafety"`
+	// the number of node failures the database can currently tolerate
+	CurrentFaultTolerance int `json:"current_fault_tolerance"`
+}
+
+func VSetKSafetyOptionsFactory() VSetKSafetyOptions {
+	options := VSetKSafetyOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func VGetKSafetyOptionsFactory() VGetKSafetyOptions {
+	options := VGetKSafetyOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VSetKSafetyOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandSetKSafety, logger)
+	if err != nil {
+		return err
+	}
+	if options.KSafety < 0 || options.KSafety > maxKSafety {
+		return fmt.Errorf("k-safety must be between 0 and %d, got %d", maxKSafety, options.KSafety)
+	}
+	return nil
+}
+
+func (options *VSetKSafetyOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VSetKSafetyOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+func (options *VGetKSafetyOptions) validateAnalyzeOptions(logger vlog.Printer) (err error) {
+	err = options.validateBaseOptions(commandGetKSafety, logger)
+	if err != nil {
+		return err
+	}
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateKSafetyAgainstNodes checks that the database has enough nodes for
+// the k-safety: 2k+1 nodes, or 2k+1 primary nodes in an Eon database.
+func validateKSafetyAgainstNodes(ksafety int, vdb *VCoordinationDatabase) error {
+	nodeCount := 0
+	for _, vnode := range vdb.HostNodeMap {
+		if vdb.IsEon && !vnode.IsPrimary {
+			continue
+		}
+		nodeCount++
+	}
+	requiredNodeCount := 2*ksafety + 1
+	if nodeCount < requiredNodeCount {
+		nodeType := "nodes"
+		if vdb.IsEon {
+			nodeType = "primary nodes"
+		}
+		return fmt.Errorf("k-safety %d requires at least %d %s, but the database has %d",
+			ksafety, requiredNodeCount, nodeType, nodeCount)
+	}
+	return nil
+}
+
+// VSetKSafety marks the physical design of a running database with the given
+// k-safety, after checking that the database has enough nodes for it.
+func (vcc VClusterCommands) VSetKSafety(options *VSetKSafetyOptions) (err error) {
+	defer vcc.audit(commandSetKSafety, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Get the nodes from the running database
+	 *   - Check the node count against the k-safety
+	 *   - Mark the design k-safe
+	 */
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	err = validateKSafetyAgainstNodes(options.KSafety, &vdb)
+	if err != nil {
+		return err
+	}
+
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return err
+	}
+	httpsMarkDesignKSafeOp, err := makeHTTPSMarkDesignKSafeOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, options.KSafety)
+	if err != nil {
+		return err
+	}
+	instructions := []clusterOp{&httpsMarkDesignKSafeOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to set the k-safety of database %s: %w", options.DBName, err)
+	}
+	return nil
+}
+
+// VGetKSafety returns the design k-safety and the current fault tolerance
+// of a running database
+func (vcc VClusterCommands) VGetKSafety(options *VGetKSafetyOptions) (ksafety KSafetyInfo, err error) {
+	defer vcc.audit(commandGetKSafety, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return ksafety, err
+	}
+	err = options.setUsePassword(vcc.Log)
+	if err != nil {
+		return ksafety, err
+	}
+
+	httpsGetKSafetyOp, err := makeHTTPSGetKSafetyOp(options.Hosts, options.usePassword,
+		options.UserName, options.Password, &ksafety)
+	if err != nil {
+		return ksafety, err
+	}
+	instructions := []clusterOp{&httpsGetKSafetyOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return ksafety, fmt.Errorf("fail to get the k-safety of database %s: %w", options.DBName, err)
+	}
+	return ksafety, nil
+}
@@ -0,0 +1,60 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateKSafetyAgainstNodes(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	for i := 1; i <= 4; i++ {
+		host := fmt.Sprintf("192.168.1.10%d", i)
+		// the fourth node is a secondary node
+		vdb.HostNodeMap[host] = &VCoordinationNode{Address: host, IsPrimary: i < 4}
+	}
+
+	// Enterprise: all the nodes are counted
+	assert.NoError(t, validateKSafetyAgainstNodes(0, &vdb))
+	assert.NoError(t, validateKSafetyAgainstNodes(1, &vdb))
+	err := validateKSafetyAgainstNodes(2, &vdb)
+	assert.ErrorContains(t, err, "requires at least 5 nodes, but the database has 4")
+
+	// Eon: only the primary nodes are counted
+	vdb.IsEon = true
+	assert.NoError(t, validateKSafetyAgainstNodes(1, &vdb))
+	delete(vdb.HostNodeMap, "192.168.1.103")
+	err = validateKSafetyAgainstNodes(1, &vdb)
+	assert.ErrorContains(t, err, "requires at least 3 primary nodes, but the database has 2")
+}
+
+func TestValidateSetKSafetyOptions(t *testing.T) {
+	options := VSetKSafetyOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"192.168.1.101"}
+
+	options.KSafety = 2
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+	options.KSafety = 3
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+	options.KSafety = -1
+	assert.Error(t, options.validateParseOptions(vlog.Printer{}))
+}
@@ -0,0 +1,140 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VShowSandboxesOptions struct {
+	DatabaseOptions
+}
+
+// SandboxNode describes a node that belongs to a sandbox
+type SandboxNode struct {
+	Name           string `json:"name"`
+	Address        string `json:"address"`
+	Subcluster     string `json:"subcluster"`
+	State          string `json:"state"`
+	CatalogVersion string `json:"catalog_version"`
+}
+
+// SandboxInfo describes a sandbox, its subclusters and its nodes
+type SandboxInfo struct {
+	Name        string        `json:"name"`
+	Subclusters []string      `json:"subclusters"`
+	Nodes       []SandboxNode `json:"nodes"`
+}
+
+func VShowSandboxesOptionsFactory() VShowSandboxesOptions {
+	options := VShowSandboxesOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VShowSandboxesOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VShowSandboxesOptions) validateParseOptions(logger vlog.Printer) error {
+	return options.validateBaseOptions(commandShowSandboxes, logger)
+}
+
+func (options *VShowSandboxesOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (options *VShowSandboxesOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VShowSandboxes returns the sandboxes of the database, with their subclusters,
+// and the state and catalog version of their nodes. The main cluster is not
+// part of the result.
+func (vcc VClusterCommands) VShowSandboxes(options *VShowSandboxesOptions) (sandboxes []SandboxInfo, err error) {
+	defer vcc.audit(commandShowSandboxes, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	// the node states of the sandboxed nodes are fetched from the nodes themselves
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the sandboxes of database %s: %w", options.DBName, err)
+	}
+
+	return buildSandboxInfoList(&vdb), nil
+}
+
+// buildSandboxInfoList groups the sandboxed nodes of the vdb by sandbox.
+// The sandboxes, their subclusters and their nodes are sorted by name.
+func buildSandboxInfoList(vdb *VCoordinationDatabase) []SandboxInfo {
+	sandboxMap := make(map[string]*SandboxInfo)
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox == util.MainClusterSandbox {
+			continue
+		}
+		sandbox, ok := sandboxMap[vnode.Sandbox]
+		if !ok {
+			sandbox = &SandboxInfo{Name: vnode.Sandbox}
+			sandboxMap[vnode.Sandbox] = sandbox
+		}
+		if !util.StringInArray(vnode.Subcluster, sandbox.Subclusters) {
+			sandbox.Subclusters = append(sandbox.Subclusters, vnode.Subcluster)
+		}
+		sandbox.Nodes = append(sandbox.Nodes, SandboxNode{
+			Name:           vnode.Name,
+			Address:        vnode.Address,
+			Subcluster:     vnode.Subcluster,
+			State:          vnode.State,
+			CatalogVersion: vnode.CatalogVersion,
+		})
+	}
+
+	sandboxes := make([]SandboxInfo, 0, len(sandboxMap))
+	for _, sandbox := range sandboxMap {
+		sort.Strings(sandbox.Subclusters)
+		sort.Slice(sandbox.Nodes, func(i, j int) bool {
+			return sandbox.Nodes[i].Name < sandbox.Nodes[j].Name
+		})
+		sandboxes = append(sandboxes, *sandbox)
+	}
+	sort.Slice(sandboxes, func(i, j int) bool {
+		return sandboxes[i].Name < sandboxes[j].Name
+	})
+	return sandboxes
+}
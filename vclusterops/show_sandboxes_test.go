@@ -0,0 +1,56 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+)
+
+func TestBuildSandboxInfoList(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	nodes := []VCoordinationNode{
+		{Name: "v_db_node0001", Address: "192.168.1.101", Subcluster: "default_subcluster", State: util.NodeUpState},
+		{Name: "v_db_node0003", Address: "192.168.1.103", Subcluster: "sc2", Sandbox: "sand1",
+			State: util.NodeUpState, CatalogVersion: "52"},
+		{Name: "v_db_node0002", Address: "192.168.1.102", Subcluster: "sc1", Sandbox: "sand1",
+			State: util.NodeDownState},
+		{Name: "v_db_node0004", Address: "192.168.1.104", Subcluster: "sc3", Sandbox: "sand0",
+			State: util.NodeUpState, CatalogVersion: "40"},
+	}
+	for i := range nodes {
+		vdb.HostNodeMap[nodes[i].Address] = &nodes[i]
+	}
+
+	sandboxes := buildSandboxInfoList(&vdb)
+	// the main cluster is not a sandbox
+	assert.Len(t, sandboxes, 2)
+	assert.Equal(t, "sand0", sandboxes[0].Name)
+	assert.Equal(t, []string{"sc3"}, sandboxes[0].Subclusters)
+	assert.Equal(t, "sand1", sandboxes[1].Name)
+	assert.Equal(t, []string{"sc1", "sc2"}, sandboxes[1].Subclusters)
+	assert.Len(t, sandboxes[1].Nodes, 2)
+	assert.Equal(t, "v_db_node0002", sandboxes[1].Nodes[0].Name)
+	assert.Equal(t, util.NodeDownState, sandboxes[1].Nodes[0].State)
+	assert.Equal(t, "52", sandboxes[1].Nodes[1].CatalogVersion)
+
+	// no sandbox
+	vdb.HostNodeMap = makeVHostNodeMap()
+	assert.Empty(t, buildSandboxInfoList(&vdb))
+}
@@ -0,0 +1,232 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// the configuration parameter that enables spread encryption
+const encryptSpreadCommConfigName = "EncryptSpreadComm"
+
+// SpreadEncryptionAction is the change VAlterSpreadEncryption makes to spread encryption
+type SpreadEncryptionAction string
+
+const (
+	SpreadEncryptionEnable  SpreadEncryptionAction = "enable"
+	SpreadEncryptionDisable SpreadEncryptionAction = "disable"
+	SpreadEncryptionRotate  SpreadEncryptionAction = "rotate"
+)
+
+type VSpreadEncryptionOptions struct {
+	DatabaseOptions
+	Action SpreadEncryptionAction
+	// The type of the spread key, used to enable spread encryption.
+	// Only "vertica" is supported.
+	KeyType string
+	// timeout for polling the states of all nodes when the database restarts
+	StatePollingTimeout int
+}
+
+func VSpreadEncryptionOptionsFactory() VSpreadEncryptionOptions {
+	options := VSpreadEncryptionOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VSpreadEncryptionOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+	options.KeyType = spreadKeyTypeVertica
+	options.StatePollingTimeout = util.DefaultStatePollingTimeout
+}
+
+func (options *VSpreadEncryptionOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandSpreadEncryption, logger)
+	if err != nil {
+		return err
+	}
+	switch options.Action {
+	case SpreadEncryptionEnable, SpreadEncryptionDisable, SpreadEncryptionRotate:
+	default:
+		return fmt.Errorf("invalid spread encryption action %q: must be '%s', '%s' or '%s'", options.Action,
+			SpreadEncryptionEnable, SpreadEncryptionDisable, SpreadEncryptionRotate)
+	}
+	if options.KeyType != spreadKeyTypeVertica {
+		return fmt.Errorf("unsupported spread key type %q, only %q is supported", options.KeyType, spreadKeyTypeVertica)
+	}
+	if options.StatePollingTimeout < 0 {
+		return fmt.Errorf("the state polling timeout cannot be negative, got %d", options.StatePollingTimeout)
+	}
+	return nil
+}
+
+// getSpreadKeyType returns the key type spread must use once the action is
+// done, empty if spread encryption is disabled. currentKeyType is the value of
+// EncryptSpreadComm in the catalog, empty if spread encryption is disabled.
+func (options *VSpreadEncryptionOptions) getSpreadKeyType(currentKeyType string) (string, error) {
+	switch options.Action {
+	case SpreadEncryptionEnable:
+		if currentKeyType != "" {
+			return "", fmt.Errorf("spread encryption is already enabled with key type %q, rotate the key instead",
+				currentKeyType)
+		}
+		return options.KeyType, nil
+	case SpreadEncryptionDisable:
+		if currentKeyType == "" {
+			return "", errors.New("spread encryption is already disabled")
+		}
+		return "", nil
+	case SpreadEncryptionRotate:
+		if currentKeyType == "" {
+			return "", errors.New("spread encryption is disabled, there is no key to rotate")
+		}
+		return currentKeyType, nil
+	}
+	return "", fmt.Errorf("invalid spread encryption action %q", options.Action)
+}
+
+// VAlterSpreadEncryption enables or disables the encryption of the spread
+// communications of a running database, or rotates its key. The spread daemons
+// of all the nodes must share the same key, so the change is only applied
+// when the database restarts:
+//   - EncryptSpreadComm is set or cleared in the catalog
+//   - the database is stopped
+//   - a new key is written to the catalog of a primary node (enable and rotate)
+//   - the database is started, spread.conf being distributed with the new key
+func (vcc VClusterCommands) VAlterSpreadEncryption(options *VSpreadEncryptionOptions) (err error) {
+	defer vcc.audit(commandSpreadEncryption, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+	}
+	err = checkSpreadEncryptionRequirements(&vdb)
+	if err != nil {
+		return err
+	}
+
+	keyType, err := vcc.changeSpreadEncryptionInCatalog(options, &vdb)
+	if err != nil {
+		return err
+	}
+
+	return vcc.restartDBWithSpreadKey(options, &vdb, keyType)
+}
+
+// checkSpreadEncryptionRequirements checks all nodes are up, so that they
+// all get the new spread configuration, and no node is sandboxed, as a
+// sandbox has its own spread
+func checkSpreadEncryptionRequirements(vdb *VCoordinationDatabase) error {
+	for _, vnode := range vdb.HostNodeMap {
+		if vnode.Sandbox != "" {
+			return fmt.Errorf("node %s is in sandbox %s, unsandbox the subclusters before changing spread encryption",
+				vnode.Name, vnode.Sandbox)
+		}
+		if vnode.State != util.NodeUpState {
+			return fmt.Errorf("node %s is %s, all nodes must be up to change spread encryption", vnode.Name, vnode.State)
+		}
+	}
+	return nil
+}
+
+// changeSpreadEncryptionInCatalog sets EncryptSpreadComm in the catalog
+// according to the action. It returns the key type spread must use once the
+// database restarts, empty if spread encryption is disabled.
+func (vcc VClusterCommands) changeSpreadEncryptionInCatalog(options *VSpreadEncryptionOptions,
+	vdb *VCoordinationDatabase) (string, error) {
+	initiator, err := getInitiatorHost(vdb.PrimaryUpNodes, []string{})
+	if err != nil {
+		return "", err
+	}
+	info := ConfigParameterInfo{}
+	httpsGetConfigParameterOp, err := makeHTTPSGetConfigParameterOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, encryptSpreadCommConfigName, ConfigLevelDatabase, "", &info)
+	if err != nil {
+		return "", err
+	}
+	err = vcc.runSingleOp(&httpsGetConfigParameterOp, &options.DatabaseOptions,
+		"fail to get the spread encryption setting")
+	if err != nil {
+		return "", err
+	}
+
+	keyType, err := options.getSpreadKeyType(info.CurrentValue)
+	if err != nil {
+		return "", err
+	}
+	if options.Action == SpreadEncryptionRotate {
+		// the catalog is already right, only the key changes
+		return keyType, nil
+	}
+
+	httpsSetConfigParameterOp, err := makeHTTPSSetConfigParameterOp([]string{initiator}, options.usePassword,
+		options.UserName, options.Password, encryptSpreadCommConfigName, keyType,
+		options.Action == SpreadEncryptionDisable, ConfigLevelDatabase, "")
+	if err != nil {
+		return "", err
+	}
+	err = vcc.runSingleOp(&httpsSetConfigParameterOp, &options.DatabaseOptions,
+		fmt.Sprintf("fail to %s spread encryption in the catalog", options.Action))
+	return keyType, err
+}
+
+// restartDBWithSpreadKey stops the database and starts it again. When keyType
+// is not empty, start_db writes a new spread key before spread is started.
+func (vcc VClusterCommands) restartDBWithSpreadKey(options *VSpreadEncryptionOptions,
+	vdb *VCoordinationDatabase, keyType string) error {
+	vcc.Log.PrintInfo("Restarting database %s to %s spread encryption", options.DBName, options.Action)
+
+	stopDBOptions := VStopDatabaseOptionsFactory()
+	stopDBOptions.DatabaseOptions = options.DatabaseOptions
+	stopDBOptions.RawHosts = vdb.HostList
+	err := vcc.VStopDatabase(&stopDBOptions)
+	if err != nil {
+		return fmt.Errorf("fail to stop database %s to apply the spread encryption change: %w", options.DBName, err)
+	}
+
+	startDBOptions := VStartDatabaseOptionsFactory()
+	startDBOptions.DatabaseOptions = options.DatabaseOptions
+	startDBOptions.RawHosts = vdb.HostList
+	startDBOptions.StatePollingTimeout = options.StatePollingTimeout
+	// only the spread key parameter is given to start_db, so that it sets
+	// the new key, the other ones are already in the catalog
+	startDBOptions.ConfigurationParameters = make(map[string]string)
+	if keyType != "" {
+		startDBOptions.ConfigurationParameters[encryptSpreadCommConfigName] = keyType
+	}
+	_, err = vcc.VStartDatabase(&startDBOptions)
+	if err != nil {
+		return fmt.Errorf("fail to start database %s with the new spread configuration: %w", options.DBName, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestValidateSpreadEncryptionOptions(t *testing.T) {
+	logger := vlog.Printer{}
+	options := VSpreadEncryptionOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"vnode1"}
+	options.Action = SpreadEncryptionRotate
+	assert.NoError(t, options.validateParseOptions(logger))
+
+	options.Action = "renew"
+	assert.ErrorContains(t, options.validateParseOptions(logger), "invalid spread encryption action")
+
+	options.Action = SpreadEncryptionEnable
+	options.KeyType = "aes"
+	assert.ErrorContains(t, options.validateParseOptions(logger), "unsupported spread key type")
+}
+
+func TestGetSpreadKeyType(t *testing.T) {
+	options := VSpreadEncryptionOptionsFactory()
+
+	options.Action = SpreadEncryptionEnable
+	keyType, err := options.getSpreadKeyType("")
+	assert.NoError(t, err)
+	assert.Equal(t, spreadKeyTypeVertica, keyType)
+	_, err = options.getSpreadKeyType(spreadKeyTypeVertica)
+	assert.ErrorContains(t, err, "already enabled")
+
+	options.Action = SpreadEncryptionDisable
+	keyType, err = options.getSpreadKeyType(spreadKeyTypeVertica)
+	assert.NoError(t, err)
+	assert.Empty(t, keyType)
+	_, err = options.getSpreadKeyType("")
+	assert.ErrorContains(t, err, "already disabled")
+
+	options.Action = SpreadEncryptionRotate
+	keyType, err = options.getSpreadKeyType(spreadKeyTypeVertica)
+	assert.NoError(t, err)
+	assert.Equal(t, spreadKeyTypeVertica, keyType)
+	_, err = options.getSpreadKeyType("")
+	assert.ErrorContains(t, err, "no key to rotate")
+}
+
+func TestCheckSpreadEncryptionRequirements(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["vnode1"] = &VCoordinationNode{Name: "v_db_node0001", State: util.NodeUpState}
+	vdb.HostNodeMap["vnode2"] = &VCoordinationNode{Name: "v_db_node0002", State: util.NodeUpState}
+	assert.NoError(t, checkSpreadEncryptionRequirements(&vdb))
+
+	vdb.HostNodeMap["vnode2"].State = util.NodeDownState
+	assert.ErrorContains(t, checkSpreadEncryptionRequirements(&vdb), "all nodes must be up")
+
+	vdb.HostNodeMap["vnode2"].State = util.NodeUpState
+	vdb.HostNodeMap["vnode2"].Sandbox = "sand"
+	assert.ErrorContains(t, checkSpreadEncryptionRequirements(&vdb), "is in sandbox sand")
+}
@@ -17,9 +17,12 @@ package vclusterops
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
+	"golang.org/x/exp/maps"
 )
 
 // VStartDatabaseOptions represents the available options when you start a database
@@ -40,13 +43,24 @@ type VStartDatabaseOptions struct {
 	// If the path is set, the NMA will store the Vertica start command at the path
 	// instead of executing it. This is useful in containerized environments where
 	// you may not want to have both the NMA and Vertica server in the same container.
-	// This feature requires version 24.2.0+.
+	// This feature requires version 24.2.0+, older servers start the nodes without it.
 	StartUpConf string
 	// whether the provided hosts are in a sandbox
 	HostsInSandbox bool
 
 	// whether the first time to start the database after revive
 	FirstStartAfterRevive bool
+	// whether skip the clock skew check across hosts
+	SkipClockCheck bool
+	// whether to re-ip the nodes whose addresses in the catalog do not match
+	// the given hosts before starting the database, instead of failing
+	AutoReIP bool
+	// whether to only start the DOWN nodes if the database is partially up,
+	// so that the command can be run again after some nodes failed to start
+	RestartOnlyFailed bool
+	// whether to print the new lines of vertica.log of the starting nodes,
+	// read from the NMA, while waiting for them to come up
+	FollowLogs bool
 }
 
 func VStartDatabaseOptionsFactory() VStartDatabaseOptions {
@@ -90,6 +104,9 @@ func (options *VStartDatabaseOptions) validateParseOptions(logger vlog.Printer)
 	if err != nil {
 		return err
 	}
+	if options.RestartOnlyFailed && options.HostsInSandbox {
+		return fmt.Errorf("only the failed nodes of the main cluster can be restarted, not the ones of a sandbox")
+	}
 	return nil
 }
 
@@ -113,6 +130,7 @@ func (options *VStartDatabaseOptions) validateAnalyzeOptions(logger vlog.Printer
 }
 
 func (vcc VClusterCommands) VStartDatabase(options *VStartDatabaseOptions) (vdbPtr *VCoordinationDatabase, err error) {
+	defer vcc.audit(commandStartDB, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create VClusterOpEngine
@@ -125,6 +143,23 @@ func (vcc VClusterCommands) VStartDatabase(options *VStartDatabaseOptions) (vdbP
 		return nil, err
 	}
 
+	// if the database is partially up, only start its DOWN nodes
+	if options.RestartOnlyFailed {
+		var started bool
+		vdbPtr, started, err = vcc.startOnlyDownNodes(options)
+		if err != nil || started {
+			return vdbPtr, err
+		}
+	}
+
+	// repair the stale node addresses in the catalog before using it
+	if options.AutoReIP {
+		err = vcc.reIPStaleNodes(options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// VER-93369 may improve this if the CLI knows which nodes are primary
 	// from the config file
 	var vdb VCoordinationDatabase
@@ -164,8 +199,8 @@ func (vcc VClusterCommands) VStartDatabase(options *VStartDatabaseOptions) (vdbP
 	}
 
 	// create a VClusterOpEngine for start_db instructions, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -191,8 +226,8 @@ func (vcc VClusterCommands) runStartDBPrecheck(options *VStartDatabaseOptions, v
 	}
 
 	// create a VClusterOpEngine for pre-check, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(preInstructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(preInstructions, &certs)
 	runError := clusterOpEngine.run(vcc.Log)
 	if runError != nil {
 		return fmt.Errorf("fail to start database pre-checks: %w", runError)
@@ -208,6 +243,131 @@ func (vcc VClusterCommands) runStartDBPrecheck(options *VStartDatabaseOptions, v
 	return nil
 }
 
+// startOnlyDownNodes starts the DOWN nodes of the database, like restart_node,
+// if some of its nodes are UP. It returns false if no node is UP, in which case
+// the whole database must be started.
+func (vcc VClusterCommands) startOnlyDownNodes(options *VStartDatabaseOptions) (*VCoordinationDatabase, bool, error) {
+	err := options.setUsePassword(vcc.Log)
+	if err != nil {
+		return nil, false, err
+	}
+	httpsGetUpNodesOp, err := makeHTTPSGetUpNodesOp(options.DBName, options.Hosts,
+		options.usePassword, options.UserName, options.Password, StartNodeCommand)
+	if err != nil {
+		return nil, false, err
+	}
+	httpsGetUpNodesOp.allowNoUpHosts()
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&httpsGetUpNodesOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, false, fmt.Errorf("fail to check for up nodes: %w", err)
+	}
+	if len(clusterOpEngine.execContext.upHosts) == 0 {
+		vcc.Log.Info("no up node found, starting the whole database")
+		return nil, false, nil
+	}
+
+	var vdb VCoordinationDatabase
+	err = vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		return nil, false, err
+	}
+	nodesToStart := vdb.getDownNodes(options.Hosts)
+	if len(nodesToStart) == 0 {
+		vcc.Log.PrintInfo("All nodes of database %s are already up", options.DBName)
+		return &vdb, true, nil
+	}
+
+	nodeNames := maps.Keys(nodesToStart)
+	sort.Strings(nodeNames)
+	vcc.Log.PrintInfo("Database %s is partially up, starting its down nodes %v", options.DBName, nodeNames)
+	startNodesOptions := VStartNodesOptionsFactory()
+	startNodesOptions.DatabaseOptions = options.DatabaseOptions
+	startNodesOptions.Nodes = nodesToStart
+	startNodesOptions.StatePollingTimeout = options.StatePollingTimeout
+	startNodesOptions.StartUpConf = options.StartUpConf
+	startNodesOptions.FollowLogs = options.FollowLogs
+	err = vcc.VStartNodes(&startNodesOptions)
+	if err != nil {
+		return nil, true, fmt.Errorf("fail to start the down nodes: %w", err)
+	}
+
+	var updatedVDB VCoordinationDatabase
+	err = vcc.getVDBFromRunningDB(&updatedVDB, &options.DatabaseOptions)
+	if err != nil {
+		return nil, true, err
+	}
+	return &updatedVDB, true, nil
+}
+
+// reIPStaleNodes finds the node on each host through the NMA /nodes endpoint,
+// and re-ips the nodes whose address in the latest catalog is not the one of
+// their host, e.g., after the hosts got new addresses.
+func (vcc VClusterCommands) reIPStaleNodes(options *VStartDatabaseOptions) error {
+	nodesVDB := makeVCoordinationDatabase()
+	nmaHealthOp := makeNMAHealthOp(options.Hosts)
+	nmaGetNodesInfoOp := makeNMAGetNodesInfoOp(options.Hosts, options.DBName, options.CatalogPrefix,
+		false /* report all errors */, &nodesVDB)
+	nmaReadCatalogEditorOp, err := makeNMAReadCatalogEditorOp(&nodesVDB)
+	if err != nil {
+		return err
+	}
+	instructions := []clusterOp{&nmaHealthOp, &nmaGetNodesInfoOp, &nmaReadCatalogEditorOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to check the node addresses in the catalog: %w", err)
+	}
+
+	reIPList := buildStaleNodesReIPList(&nodesVDB, &clusterOpEngine.execContext.nmaVDatabase)
+	if len(reIPList) == 0 {
+		vcc.Log.Info("the node addresses in the catalog match the hosts, no need to re-ip")
+		return nil
+	}
+	for _, info := range reIPList {
+		vcc.Log.PrintInfo("Node %s will be re-ipped from %s to %s", info.NodeName, info.NodeAddress, info.TargetAddress)
+	}
+
+	reIPOptions := VReIPFactory()
+	reIPOptions.DatabaseOptions = options.DatabaseOptions
+	reIPOptions.ReIPList = reIPList
+	reIPInstructions, err := vcc.produceReIPInstructions(&reIPOptions, nil)
+	if err != nil {
+		return fmt.Errorf("fail to produce re-ip instructions: %w", err)
+	}
+	clusterOpEngine = vcc.makeClusterOpEngine(reIPInstructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to re-ip the nodes with stale addresses: %w", err)
+	}
+	return nil
+}
+
+// buildStaleNodesReIPList returns the re-ip info of the nodes whose address
+// in the catalog is not the host on which the NMA /nodes endpoint found them
+func buildStaleNodesReIPList(nodesVDB *VCoordinationDatabase, catalogVDB *nmaVDatabase) []ReIPInfo {
+	catalogAddresses := make(map[string]string)
+	for i := range catalogVDB.Nodes {
+		catalogAddresses[catalogVDB.Nodes[i].Name] = catalogVDB.Nodes[i].Address
+	}
+
+	var reIPList []ReIPInfo
+	for host, vnode := range nodesVDB.HostNodeMap {
+		catalogAddress, ok := catalogAddresses[vnode.Name]
+		if !ok || catalogAddress == host {
+			continue
+		}
+		reIPList = append(reIPList, ReIPInfo{NodeName: vnode.Name, NodeAddress: catalogAddress, TargetAddress: host})
+	}
+	sort.Slice(reIPList, func(i, j int) bool {
+		return reIPList[i].NodeName < reIPList[j].NodeName
+	})
+	return reIPList
+}
+
 func (vcc VClusterCommands) removeHostsNotInCatalog(vdb *nmaVDatabase, hosts []string) []string {
 	var trimmedHostList []string
 	var extraHosts []string
@@ -235,7 +395,10 @@ func (vcc VClusterCommands) removeHostsNotInCatalog(vdb *nmaVDatabase, hosts []s
 // The generated instructions will later perform the following operations necessary
 // for a successful start_db:
 //   - Check NMA connectivity
+//   - Check NMA version compatibility
+//   - Check clock skew between hosts
 //   - Check to see if any dbs run
+//   - Check to see if any vertica process runs
 //   - Get nodes' information by calling the NMA /nodes endpoint
 //   - Find latest catalog to use for removal of nodes not in the catalog
 func (vcc VClusterCommands) produceStartDBPreCheck(options *VStartDatabaseOptions, vdb *VCoordinationDatabase,
@@ -254,10 +417,21 @@ func (vcc VClusterCommands) produceStartDBPreCheck(options *VStartDatabaseOption
 	if err != nil {
 		return instructions, err
 	}
-	instructions = append(instructions,
-		&nmaHealthOp,
-		&checkDBRunningOp,
-	)
+	nmaVersionOp := makeNMAVersionOp(options.Hosts)
+	instructions = append(instructions, &nmaHealthOp, &nmaVersionOp)
+
+	// the database may still start with a clock skew,
+	// so we only warn the user about it
+	if !options.SkipClockCheck {
+		nmaClockSkewOp := makeNMAClockSkewOp(options.Hosts, util.DefaultMaxClockSkewSeconds, false /*failOnSkew*/)
+		instructions = append(instructions, &nmaClockSkewOp)
+	}
+
+	// refuse to start over a vertica process that is already running,
+	// even if its https service is not up yet
+	nmaCheckProcessesOp := makeNMACheckProcessesOp(options.Hosts, true, /*failIfVerticaRunning*/
+		true /*ignoreNotFound*/, make(map[string]*HostProcesses))
+	instructions = append(instructions, &checkDBRunningOp, &nmaCheckProcessesOp)
 
 	// when we cannot get db info from cluster_config.json, we will fetch it from NMA /nodes endpoint.
 	if len(vdb.HostNodeMap) == 0 {
@@ -285,6 +459,7 @@ func (vcc VClusterCommands) produceStartDBPreCheck(options *VStartDatabaseOption
 // for a successful start_db:
 //   - Use NMA /catalog/database to get the best source node for spread.conf and vertica.conf
 //   - Check Vertica versions
+//   - Check that the hosts run the same Vertica binary and package
 //   - Sync the confs to the rest of nodes who have lower catalog version (results from the previous step)
 //   - Start all nodes of the database
 //   - Poll node startup
@@ -299,9 +474,12 @@ func (vcc VClusterCommands) produceStartDBInstructions(options *VStartDatabaseOp
 	}
 	// require to have the same vertica version
 	nmaVerticaVersionOp := makeNMAVerticaVersionOpWithTargetHosts(true, options.Hosts)
+	// require to have the same vertica binary, e.g., after an upgrade of the package
+	nmaCheckPackageInfoOp := makeNMACheckPackageInfoOp(options.Hosts)
 	instructions = append(instructions,
 		&nmaReadCatalogEditorOp,
 		&nmaVerticaVersionOp,
+		&nmaCheckPackageInfoOp,
 	)
 
 	if enabled, keyType := options.isSpreadEncryptionEnabled(); enabled {
@@ -326,6 +504,9 @@ func (vcc VClusterCommands) produceStartDBInstructions(options *VStartDatabaseOp
 	if err != nil {
 		return instructions, err
 	}
+	if options.FollowLogs {
+		httpsPollNodeStateOp.followVerticaLogs(vdb)
+	}
 
 	instructions = append(instructions,
 		&nmaStartNewNodesOp,
@@ -0,0 +1,49 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestGetDownNodes(t *testing.T) {
+	vdb := makeVCoordinationDatabase()
+	vdb.HostNodeMap = makeVHostNodeMap()
+	vdb.HostNodeMap["192.168.1.101"] = &VCoordinationNode{Name: "v_test_db_node0001", State: util.NodeUpState}
+	vdb.HostNodeMap["192.168.1.102"] = &VCoordinationNode{Name: "v_test_db_node0002", State: util.NodeDownState}
+	vdb.HostNodeMap["192.168.1.103"] = &VCoordinationNode{Name: "v_test_db_node0003", State: util.NodeDownState}
+
+	downNodes := vdb.getDownNodes([]string{"192.168.1.101", "192.168.1.102", "192.168.1.103", "192.168.1.104"})
+	assert.Equal(t, map[string]string{"v_test_db_node0002": "192.168.1.102", "v_test_db_node0003": "192.168.1.103"},
+		downNodes)
+	assert.Empty(t, vdb.getDownNodes([]string{"192.168.1.101"}))
+}
+
+func TestRestartOnlyFailedOptions(t *testing.T) {
+	opt := VStartDatabaseOptionsFactory()
+	opt.DBName = "test_db"
+	opt.RawHosts = []string{"192.168.1.101"}
+	opt.CatalogPrefix = "/data"
+	opt.RestartOnlyFailed = true
+	assert.NoError(t, opt.validateParseOptions(vlog.Printer{}))
+
+	opt.HostsInSandbox = true
+	assert.ErrorContains(t, opt.validateParseOptions(vlog.Printer{}), "not the ones of a sandbox")
+}
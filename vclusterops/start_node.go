@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -35,8 +36,14 @@ type VStartNodesOptions struct {
 	// If the path is set, the NMA will store the Vertica start command at the path
 	// instead of executing it. This is useful in containerized environments where
 	// you may not want to have both the NMA and Vertica server in the same container.
-	// This feature requires version 24.2.0+.
+	// This feature requires version 24.2.0+, older servers start the nodes without it.
 	StartUpConf string
+	// Number of up hosts on which spread can fail to reload, after the nodes
+	// to start were re-ip'ed, without failing the operation
+	MaxHostFailures int
+	// whether to print the new lines of vertica.log of the starting nodes,
+	// read from the NMA, while waiting for them to come up
+	FollowLogs bool
 
 	vdb *VCoordinationDatabase
 }
@@ -154,7 +161,8 @@ func (vcc VClusterCommands) startNodePreCheck(vdb *VCoordinationDatabase, option
 // node's IP in the Vertica catalog. If cluster quorum is already lost, use
 // VStartDatabase. It will skip any nodes given that no longer exist in the
 // catalog.
-func (vcc VClusterCommands) VStartNodes(options *VStartNodesOptions) error {
+func (vcc VClusterCommands) VStartNodes(options *VStartNodesOptions) (err error) {
+	defer vcc.audit(commandStartNode, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -162,7 +170,7 @@ func (vcc VClusterCommands) VStartNodes(options *VStartNodesOptions) error {
 	 */
 
 	// validate and analyze options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -235,8 +243,8 @@ func (vcc VClusterCommands) VStartNodes(options *VStartNodesOptions) error {
 	}
 
 	// create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	err = clusterOpEngine.run(vcc.Log)
@@ -301,6 +309,7 @@ func (vcc VClusterCommands) produceStartNodesInstructions(startNodeInfo *VStartN
 		if e != nil {
 			return instructions, e
 		}
+		httpsReloadSpreadOp.maxHostFailures = options.MaxHostFailures
 		// update new vdb information after re-ip
 		httpsGetNodesInfoOp, e := makeHTTPSGetNodesInfoOp(options.DBName, options.Hosts,
 			options.usePassword, options.UserName, options.Password, vdb, true, startNodeInfo.Sandbox)
@@ -341,6 +350,9 @@ func (vcc VClusterCommands) produceStartNodesInstructions(startNodeInfo *VStartN
 	if err != nil {
 		return instructions, err
 	}
+	if options.FollowLogs {
+		httpsPollNodeStateOp.followVerticaLogs(vdb)
+	}
 
 	instructions = append(instructions,
 		&httpsRestartUpCommandOp,
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -112,8 +113,9 @@ func (options *VStartScOptions) validateAnalyzeOptions(logger vlog.Printer) erro
 // VStartSubcluster has two major phases:
 //  1. Pre-check: check the subcluster name and get nodes for the subcluster.
 //  2. Start nodes: Optional. If there are any down nodes in the subcluster, runs VStartNodes.
-func (vcc VClusterCommands) VStartSubcluster(options *VStartScOptions) error {
-	err := options.validateAnalyzeOptions(vcc.Log)
+func (vcc VClusterCommands) VStartSubcluster(options *VStartScOptions) (err error) {
+	defer vcc.audit(commandStartSubcluster, &options.DatabaseOptions, options, time.Now(), &err)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
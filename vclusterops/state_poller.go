@@ -17,6 +17,8 @@ package vclusterops
 
 import (
 	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 )
 
@@ -29,14 +31,160 @@ const (
 	PollingInterval          = 3 * OneSecond
 )
 
+// PollingPolicy controls how often the polling ops, e.g., the ops that wait
+// for the nodes to be up, send their requests, so that several controllers
+// polling the same hosts do not overload their HTTPS service
+type PollingPolicy struct {
+	// wait between the first two polls. It is PollingInterval seconds if not set.
+	InitialInterval time.Duration
+	// the wait is multiplied by this factor after each poll, up to
+	// MaxInterval. A factor of 1 keeps a fixed interval.
+	BackoffMultiplier float64
+	// maximum wait between two polls
+	MaxInterval time.Duration
+	// fraction of the wait, between 0 and 1, that is randomly added or
+	// removed, so that several controllers do not poll in lockstep
+	JitterFraction float64
+	// maximum number of polls sent at the same time to a host by all the
+	// commands of this process, 0 for no limit
+	MaxConcurrentPollsPerHost int
+}
+
+// DefaultPollingPolicy returns the policy of the polling ops when
+// VClusterCommands does not set one
+func DefaultPollingPolicy() PollingPolicy {
+	return PollingPolicy{
+		InitialInterval:           PollingInterval * time.Second,
+		BackoffMultiplier:         1.5,
+		MaxInterval:               10 * time.Second,
+		JitterFraction:            0.1,
+		MaxConcurrentPollsPerHost: 2,
+	}
+}
+
+// withDefaults returns a copy of the policy in which the unset or invalid
+// fields have their default values
+func (policy PollingPolicy) withDefaults() PollingPolicy {
+	defaults := DefaultPollingPolicy()
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = defaults.InitialInterval
+	}
+	if policy.BackoffMultiplier < 1 {
+		policy.BackoffMultiplier = 1
+	}
+	if policy.MaxInterval < policy.InitialInterval {
+		policy.MaxInterval = policy.InitialInterval
+	}
+	if policy.JitterFraction < 0 || policy.JitterFraction > 1 {
+		policy.JitterFraction = defaults.JitterFraction
+	}
+	if policy.MaxConcurrentPollsPerHost < 0 {
+		policy.MaxConcurrentPollsPerHost = 0
+	}
+	return policy
+}
+
+// this variable is for unit test, be careful to modify it.
+// It returns a random number in [0.0, 1.0) to compute the jitter.
+var pollJitterFn = rand.Float64 //nolint:gosec // the jitter does not need a secure random number
+
+// nextInterval returns the wait that follows the given wait, without jitter
+func (policy *PollingPolicy) nextInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * policy.BackoffMultiplier)
+	if next > policy.MaxInterval {
+		return policy.MaxInterval
+	}
+	return next
+}
+
+// addJitter randomly adds or removes up to JitterFraction of the wait
+func (policy *PollingPolicy) addJitter(interval time.Duration) time.Duration {
+	jitter := (2*pollJitterFn() - 1) * policy.JitterFraction * float64(interval)
+	return interval + time.Duration(jitter)
+}
+
+// hostPollLimiter limits the number of polls that are sent at the same
+// time to each host by all the ops of this process
+type hostPollLimiter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	inFlight map[string]int
+}
+
+var pollLimiter = makeHostPollLimiter()
+
+func makeHostPollLimiter() *hostPollLimiter {
+	limiter := &hostPollLimiter{inFlight: make(map[string]int)}
+	limiter.cond = sync.NewCond(&limiter.mu)
+	return limiter
+}
+
+// acquire waits until every host has less than limit polls in flight, then
+// counts a poll for all of them. Waiting for all the hosts at once avoids a
+// deadlock between two ops that poll the same hosts.
+func (limiter *hostPollLimiter) acquire(hosts []string, limit int) {
+	if limit <= 0 {
+		return
+	}
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	for !limiter.canAcquire(hosts, limit) {
+		limiter.cond.Wait()
+	}
+	for _, host := range hosts {
+		limiter.inFlight[host]++
+	}
+}
+
+func (limiter *hostPollLimiter) canAcquire(hosts []string, limit int) bool {
+	for _, host := range hosts {
+		if limiter.inFlight[host] >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+// release removes the polls counted by acquire
+func (limiter *hostPollLimiter) release(hosts []string, limit int) {
+	if limit <= 0 {
+		return
+	}
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	for _, host := range hosts {
+		limiter.inFlight[host]--
+		if limiter.inFlight[host] <= 0 {
+			delete(limiter.inFlight, host)
+		}
+	}
+	limiter.cond.Broadcast()
+}
+
 type statePoller interface {
 	getPollingTimeout() int
 	shouldStopPolling() (bool, error)
 	runExecute(execContext *opEngineExecContext) error
+	getHosts() []string
+}
+
+// watchingPoller is implemented by the pollers whose requests can wait on
+// the server for the state to change, e.g., a long poll
+type watchingPoller interface {
+	// isWatching returns true if the server already waited during the
+	// last poll, in which case the next poll is sent without waiting
+	isWatching() bool
+}
+
+func isWatching(poller statePoller) bool {
+	watcher, ok := poller.(watchingPoller)
+	return ok && watcher.isWatching()
 }
 
 // pollState is a helper function to poll state for all ops that implement the StatePoller interface.
 // If poller.getPollingTimeout() returns a value < 0, pollState will poll forever.
+// The wait between two polls follows the polling policy of the execContext,
+// and is skipped when the poller watches the state on the server.
 func pollState(poller statePoller, execContext *opEngineExecContext) error {
 	startTime := time.Now()
 	timeout := poller.getPollingTimeout()
@@ -46,14 +194,17 @@ func pollState(poller statePoller, execContext *opEngineExecContext) error {
 	if timeout < 0 {
 		needTimeout = false
 	}
+	policy := execContext.pollingPolicy
+	interval := policy.InitialInterval
 
 	for endTime := startTime.Add(duration); ; {
 		if needTimeout && time.Now().After(endTime) {
 			break
 		}
 
-		if count > 0 {
-			time.Sleep(PollingInterval * time.Second)
+		if count > 0 && !isWatching(poller) {
+			time.Sleep(policy.addJitter(interval))
+			interval = policy.nextInterval(interval)
 		}
 
 		shouldStopPoll, err := poller.shouldStopPolling()
@@ -65,7 +216,11 @@ func pollState(poller statePoller, execContext *opEngineExecContext) error {
 			return nil
 		}
 
-		if err := poller.runExecute(execContext); err != nil {
+		hosts := poller.getHosts()
+		pollLimiter.acquire(hosts, policy.MaxConcurrentPollsPerHost)
+		err = poller.runExecute(execContext)
+		pollLimiter.release(hosts, policy.MaxConcurrentPollsPerHost)
+		if err != nil {
 			return err
 		}
 
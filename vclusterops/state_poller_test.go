@@ -0,0 +1,118 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestPollingPolicyBackoff(t *testing.T) {
+	policy := PollingPolicy{BackoffMultiplier: 2, MaxInterval: 10 * time.Second}.withDefaults()
+	assert.Equal(t, PollingInterval*time.Second, policy.InitialInterval)
+
+	interval := policy.InitialInterval
+	var intervals []time.Duration
+	for i := 0; i < 4; i++ {
+		intervals = append(intervals, interval)
+		interval = policy.nextInterval(interval)
+	}
+	assert.Equal(t, []time.Duration{3 * time.Second, 6 * time.Second, 10 * time.Second, 10 * time.Second}, intervals)
+
+	// a multiplier below 1 keeps a fixed interval
+	policy = PollingPolicy{BackoffMultiplier: 0.5}.withDefaults()
+	assert.Equal(t, policy.InitialInterval, policy.nextInterval(policy.InitialInterval))
+}
+
+func TestPollingPolicyJitter(t *testing.T) {
+	originalFn := pollJitterFn
+	defer func() { pollJitterFn = originalFn }()
+
+	policy := PollingPolicy{JitterFraction: 0.1}.withDefaults()
+	pollJitterFn = func() float64 { return 0 }
+	assert.Equal(t, 9*time.Second, policy.addJitter(10*time.Second))
+	pollJitterFn = func() float64 { return 0.5 }
+	assert.Equal(t, 10*time.Second, policy.addJitter(10*time.Second))
+	pollJitterFn = func() float64 { return 0.75 }
+	assert.Equal(t, 10500*time.Millisecond, policy.addJitter(10*time.Second))
+}
+
+func TestHostPollLimiter(t *testing.T) {
+	limiter := makeHostPollLimiter()
+	limiter.acquire([]string{"host1", "host2"}, 1)
+
+	// a poll of host2 waits for the previous poll of host2
+	acquired := make(chan struct{})
+	go func() {
+		limiter.acquire([]string{"host2", "host3"}, 1)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "the second poll must wait for the first one")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.release([]string{"host1", "host2"}, 1)
+	<-acquired
+	limiter.release([]string{"host2", "host3"}, 1)
+	assert.Empty(t, limiter.inFlight)
+
+	// no limit
+	limiter.acquire([]string{"host1"}, 0)
+	assert.Empty(t, limiter.inFlight)
+}
+
+type mockStatePoller struct {
+	mu       sync.Mutex
+	polls    int
+	maxPolls int
+}
+
+func (p *mockStatePoller) getPollingTimeout() int { return OneMinute }
+
+func (p *mockStatePoller) shouldStopPolling() (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.polls >= p.maxPolls, nil
+}
+
+func (p *mockStatePoller) runExecute(_ *opEngineExecContext) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.polls++
+	return nil
+}
+
+func (p *mockStatePoller) getHosts() []string { return []string{"host1"} }
+
+func TestPollStateWithPolicy(t *testing.T) {
+	execContext := makeOpEngineExecContext(vlog.Printer{})
+	execContext.pollingPolicy = PollingPolicy{
+		InitialInterval:   time.Millisecond,
+		BackoffMultiplier: 2,
+		MaxInterval:       4 * time.Millisecond,
+	}.withDefaults()
+
+	poller := mockStatePoller{maxPolls: 5}
+	assert.NoError(t, pollState(&poller, &execContext))
+	assert.Equal(t, 5, poller.polls)
+	assert.Empty(t, pollLimiter.inFlight)
+}
@@ -16,7 +16,9 @@
 package vclusterops
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -33,6 +35,15 @@ type VStopDatabaseOptions struct {
 	/* part 3: hidden info */
 	CheckUserConn bool // whether check user connection
 	ForceKill     bool // whether force kill connections
+	/* part 4: shutdown escalation */
+	// time in seconds to wait for all nodes to be down after the graceful shutdown,
+	// StopDBTimeout is used if not set
+	ShutdownTimeout int
+	// if some nodes are still up after ShutdownTimeout, force them to shut down
+	// instead of failing
+	EscalateToForce bool
+	// set by VStopDatabase: the up hosts that had to be stopped with a forced shutdown
+	EscalatedHosts []string
 }
 
 func VStopDatabaseOptionsFactory() VStopDatabaseOptions {
@@ -83,6 +94,9 @@ func (options *VStopDatabaseOptions) validateExtraOptions() error {
 			return err
 		}
 	}
+	if options.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown timeout cannot be negative")
+	}
 	return nil
 }
 
@@ -125,7 +139,8 @@ func (options *VStopDatabaseOptions) validateAnalyzeOptions(log vlog.Printer) er
 	return options.analyzeOptions()
 }
 
-func (vcc VClusterCommands) VStopDatabase(options *VStopDatabaseOptions) error {
+func (vcc VClusterCommands) VStopDatabase(options *VStopDatabaseOptions) (err error) {
+	defer vcc.audit(commandStopDB, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Produce Instructions
 	 *   - Create a VClusterOpEngine
@@ -133,7 +148,7 @@ func (vcc VClusterCommands) VStopDatabase(options *VStopDatabaseOptions) error {
 	 */
 
 	// validate and analyze all options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -157,18 +172,43 @@ func (vcc VClusterCommands) VStopDatabase(options *VStopDatabaseOptions) error {
 	}
 
 	// Create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
 	if runError != nil {
+		// some nodes are still up after the graceful shutdown
+		var dbIsRunningErr *DBIsRunningError
+		if options.EscalateToForce && errors.As(runError, &dbIsRunningErr) {
+			return vcc.forceStopDatabase(options, &certs)
+		}
 		return fmt.Errorf("fail to stop database: %w", runError)
 	}
 
 	return nil
 }
 
+// forceStopDatabase is the second phase of stop_db. It forces the nodes that
+// are still up after the graceful shutdown to shut down.
+func (vcc VClusterCommands) forceStopDatabase(options *VStopDatabaseOptions, certs *httpsCerts) error {
+	vcc.Log.PrintWarning("The database is still up after the graceful shutdown, forcing it to shut down")
+
+	instructions, err := vcc.produceForceStopDBInstructions(options)
+	if err != nil {
+		return fmt.Errorf("fail to production instructions: %w", err)
+	}
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, certs)
+	runError := clusterOpEngine.run(vcc.Log)
+	options.EscalatedHosts = clusterOpEngine.execContext.upHosts
+	if runError != nil {
+		return fmt.Errorf("fail to force stop database on hosts %v: %w", options.EscalatedHosts, runError)
+	}
+
+	vcc.Log.PrintWarning("Forced the shutdown of hosts %v", options.EscalatedHosts)
+	return nil
+}
+
 // produceStopDBInstructions will build a list of instructions to execute for
 // the stop db operation.
 //
@@ -178,6 +218,9 @@ func (vcc VClusterCommands) VStopDatabase(options *VStopDatabaseOptions) error {
 //   - Sync catalog through the first up node
 //   - Stop db through the first up node
 //   - Check there is not any database running
+//
+// If EscalateToForce is set and the database is still running at the end,
+// VStopDatabase runs the instructions of produceForceStopDBInstructions.
 func (vcc *VClusterCommands) produceStopDBInstructions(options *VStopDatabaseOptions) ([]clusterOp, error) {
 	var instructions []clusterOp
 
@@ -214,6 +257,45 @@ func (vcc *VClusterCommands) produceStopDBInstructions(options *VStopDatabaseOpt
 		return instructions, err
 	}
 
+	httpsCheckDBRunningOp, err := makeHTTPSCheckRunningDBWithSandboxOp(options.Hosts,
+		usePassword, options.UserName, options.SandboxName, options.MainCluster, options.Password, StopDB)
+	if err != nil {
+		return instructions, err
+	}
+	httpsCheckDBRunningOp.pollingTimeout = options.ShutdownTimeout
+
+	instructions = append(instructions,
+		&httpsStopDBOp,
+		&httpsCheckDBRunningOp,
+	)
+
+	return instructions, nil
+}
+
+// produceForceStopDBInstructions will build a list of instructions to execute for
+// the forced shutdown of the nodes that are still up after a graceful shutdown.
+//
+// The generated instructions will later perform the following operations:
+//   - Get up nodes through https call
+//   - Force stop db through the first up node, without draining
+//   - Check there is not any database running
+func (vcc *VClusterCommands) produceForceStopDBInstructions(options *VStopDatabaseOptions) ([]clusterOp, error) {
+	var instructions []clusterOp
+
+	usePassword := options.Password != nil
+	httpsGetUpNodesOp, err := makeHTTPSGetUpNodesWithSandboxOp(options.DBName, options.Hosts,
+		usePassword, options.UserName, options.Password, StopDBCmd, options.SandboxName, options.MainCluster)
+	if err != nil {
+		return instructions, err
+	}
+
+	httpsStopDBOp, err := makeHTTPSStopDBOp(usePassword, options.UserName, options.Password, nil, /*no draining*/
+		options.SandboxName, options.MainCluster)
+	if err != nil {
+		return instructions, err
+	}
+	httpsStopDBOp.RequestParams["force"] = "true"
+
 	httpsCheckDBRunningOp, err := makeHTTPSCheckRunningDBWithSandboxOp(options.Hosts,
 		usePassword, options.UserName, options.SandboxName, options.MainCluster, options.Password, StopDB)
 	if err != nil {
@@ -221,6 +303,7 @@ func (vcc *VClusterCommands) produceStopDBInstructions(options *VStopDatabaseOpt
 	}
 
 	instructions = append(instructions,
+		&httpsGetUpNodesOp,
 		&httpsStopDBOp,
 		&httpsCheckDBRunningOp,
 	)
@@ -18,6 +18,7 @@ package vclusterops
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -90,10 +91,11 @@ func (options *VStopNodeOptions) validateAnalyzeOptions(logger vlog.Printer) err
 
 // VStopNode stops a host in an existing database.
 // It returns any error encountered.
-func (vcc VClusterCommands) VStopNode(options *VStopNodeOptions) error {
+func (vcc VClusterCommands) VStopNode(options *VStopNodeOptions) (err error) {
+	defer vcc.audit(commandStopNode, &options.DatabaseOptions, options, time.Now(), &err)
 	vdb := makeVCoordinationDatabase()
 
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
@@ -117,8 +119,8 @@ func (vcc VClusterCommands) VStopNode(options *VStopNodeOptions) error {
 		return fmt.Errorf("fail to produce stop node instructions, %w", err)
 	}
 
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 	if runError := clusterOpEngine.run(vcc.Log); runError != nil {
 		return fmt.Errorf("fail to complete stop node operation, %w", runError)
 	}
@@ -130,7 +132,10 @@ func (vcc VClusterCommands) VStopNode(options *VStopNodeOptions) error {
 func checkStopNodeRequirements(vdb *VCoordinationDatabase, hostsToStop []string) error {
 	// the host to be stopped should be a part of the database.
 	if nodes, _ := vdb.containNodes(hostsToStop); len(nodes) == 0 {
-		return fmt.Errorf("%s do not exist in the database", strings.Join(nodes, ","))
+		return &NodeNotFoundError{
+			Detail: fmt.Sprintf("%s do not exist in the database", strings.Join(hostsToStop, ",")),
+			Nodes:  hostsToStop,
+		}
 	}
 
 	return nil
@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/vclusterops/util"
 	"github.com/vertica/vcluster/vclusterops/vlog"
@@ -30,6 +31,9 @@ type VStopSubclusterOptions struct {
 	DrainSeconds int    // time in seconds to wait for subcluster users' disconnection, its default value is 60
 	SCName       string // subcluster name
 	Force        bool   // force the subcluster to shutdown immediately even if users are connected
+	// close the client sessions of the subcluster before it is stopped,
+	// instead of waiting for them to end during the drain
+	CloseSessions bool
 }
 
 func VStopSubclusterOptionsFactory() VStopSubclusterOptions {
@@ -69,6 +73,9 @@ func (options *VStopSubclusterOptions) validateEonOptions(log vlog.Printer) erro
 	if options.Force {
 		// this log is for vclusterops user since they probably set both DrainSeconds and Force
 		log.Info("The subcluster will be forcibly shutdown so provided drain seconds will be ignored")
+		if options.CloseSessions {
+			return fmt.Errorf("cannot close the sessions of a subcluster that is forcibly shutdown")
+		}
 	}
 
 	return nil
@@ -118,7 +125,8 @@ func (options *VStopSubclusterOptions) validateAnalyzeOptions(log vlog.Printer)
 }
 
 //nolint:dupl
-func (vcc VClusterCommands) VStopSubcluster(options *VStopSubclusterOptions) error {
+func (vcc VClusterCommands) VStopSubcluster(options *VStopSubclusterOptions) (err error) {
+	defer vcc.audit(commandStopSubcluster, &options.DatabaseOptions, options, time.Now(), &err)
 	/*
 	 *   - Validate Options
 	 *   - Produce Instructions
@@ -127,19 +135,28 @@ func (vcc VClusterCommands) VStopSubcluster(options *VStopSubclusterOptions) err
 	 */
 
 	// validate and analyze all options
-	err := options.validateAnalyzeOptions(vcc.Log)
+	err = options.validateAnalyzeOptions(vcc.Log)
 	if err != nil {
 		return err
 	}
 
+	// the sessions are closed when the subcluster is forcibly shutdown,
+	// otherwise they block the drain
+	if !options.Force {
+		err = vcc.drainSubclusterSessions(options)
+		if err != nil {
+			return err
+		}
+	}
+
 	instructions, err := vcc.produceStopSCInstructions(options)
 	if err != nil {
 		return fmt.Errorf("fail to production instructions: %w", err)
 	}
 
 	// Create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
 	runError := clusterOpEngine.run(vcc.Log)
@@ -150,6 +167,22 @@ func (vcc VClusterCommands) VStopSubcluster(options *VStopSubclusterOptions) err
 	return nil
 }
 
+// drainSubclusterSessions reports the client sessions of the subcluster, which
+// keep it from draining, or closes them if requested
+func (vcc VClusterCommands) drainSubclusterSessions(options *VStopSubclusterOptions) error {
+	vdb := makeVCoordinationDatabase()
+	err := vcc.getVDBFromRunningDB(&vdb, &options.DatabaseOptions)
+	if err != nil {
+		if options.CloseSessions {
+			return fmt.Errorf("fail to get the nodes of database %s: %w", options.DBName, err)
+		}
+		vcc.Log.PrintWarning("Fail to get the client sessions that may block the drain: %v", err)
+		return nil
+	}
+	filter := SessionFilter{SCName: options.SCName}
+	return vcc.drainSessions(&options.DatabaseOptions, &vdb, &filter, options.CloseSessions)
+}
+
 // produceStopSCInstructions will build a list of instructions to execute for
 // the stop subcluster operation.
 //
@@ -0,0 +1,102 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"net"
+)
+
+func (opt *DatabaseOptions) validateSubnet() error {
+	if opt.Subnet == "" {
+		return nil
+	}
+	_, _, err := net.ParseCIDR(opt.Subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %q, it must be in CIDR notation, e.g., 10.20.0.0/16", opt.Subnet)
+	}
+	return nil
+}
+
+// selectSubnetAddresses returns the address of each host in options.Subnet,
+// as reported by the NMA of the host, in the order of hosts. The hosts are
+// returned unchanged if no subnet is given.
+func (vcc VClusterCommands) selectSubnetAddresses(options *DatabaseOptions, hosts []string) ([]string, error) {
+	if options.Subnet == "" || len(hosts) == 0 {
+		return hosts, nil
+	}
+	_, subnet, err := net.ParseCIDR(options.Subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	nmaNetworkProfileOp := makeNMANetworkProfileOpForSubnet(hosts, subnet)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine([]clusterOp{&nmaNetworkProfileOp}, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return nil, fmt.Errorf("fail to get the addresses of the hosts in subnet %s: %w", subnet, err)
+	}
+
+	addresses := make([]string, 0, len(hosts))
+	hostOfAddress := make(map[string]string)
+	for _, host := range hosts {
+		profile, ok := clusterOpEngine.execContext.networkProfiles[host]
+		if !ok {
+			return nil, fmt.Errorf("unable to find network profile for host %s", host)
+		}
+		if otherHost, found := hostOfAddress[profile.Address]; found {
+			return nil, fmt.Errorf("hosts %s and %s have the same address %s in subnet %s",
+				otherHost, host, profile.Address, subnet)
+		}
+		hostOfAddress[profile.Address] = host
+		if profile.Address != host {
+			vcc.Log.PrintInfo("Using address %s of host %s in subnet %s", profile.Address, host, subnet)
+		}
+		addresses = append(addresses, profile.Address)
+	}
+	return addresses, nil
+}
+
+// useSubnetAddresses replaces the hosts of the create db options, and the
+// hosts of their node paths, by their addresses in options.Subnet
+func (vcc VClusterCommands) useSubnetAddresses(options *VCreateDatabaseOptions) error {
+	if options.Subnet == "" {
+		return nil
+	}
+	err := options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return err
+	}
+	addresses, err := vcc.selectSubnetAddresses(&options.DatabaseOptions, options.Hosts)
+	if err != nil {
+		return err
+	}
+
+	if len(options.NodePaths) > 0 {
+		nodePaths := make(map[string]VNodePaths, len(options.NodePaths))
+		for i, host := range options.Hosts {
+			if paths, ok := options.NodePaths[host]; ok {
+				nodePaths[addresses[i]] = paths
+			}
+		}
+		options.NodePaths = nodePaths
+	}
+	// the addresses are resolved again, to themselves, in the analysis of the options
+	options.RawHosts = addresses
+	options.Hosts = addresses
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+)
+
+// TLSVerifyMode tells how the certificates of the servers are verified
+type TLSVerifyMode string
+
+const (
+	// the certificates of the servers are not verified
+	TLSInsecureSkipVerify TLSVerifyMode = "insecure-skip-verify"
+	// the certificates of the servers must be signed by the CA
+	TLSVerifyCA TLSVerifyMode = "verify-ca"
+	// the certificates of the servers must be signed by the CA, and
+	// their SANs must match the host name or IP address of the server
+	TLSVerifyFull TLSVerifyMode = "verify-full"
+)
+
+// tlsVerifyConfig tells how the adapter verifies the certificates of the servers
+type tlsVerifyConfig struct {
+	mode TLSVerifyMode
+	// optional, with verify-full, the name the SANs must match
+	// instead of the host name or IP address of the server
+	serverName string
+}
+
+func (config tlsVerifyConfig) verifiesServer() bool {
+	return config.mode == TLSVerifyCA || config.mode == TLSVerifyFull
+}
+
+// validateTLSVerify checks the TLS verification mode and the server name
+func (opt *DatabaseOptions) validateTLSVerify() error {
+	switch opt.TLSVerifyMode {
+	case "", TLSInsecureSkipVerify, TLSVerifyCA, TLSVerifyFull:
+	default:
+		return fmt.Errorf("invalid TLS verification mode %q: must be '%s', '%s' or '%s'", opt.TLSVerifyMode,
+			TLSInsecureSkipVerify, TLSVerifyCA, TLSVerifyFull)
+	}
+	if opt.TLSServerName != "" && opt.TLSVerifyMode != TLSVerifyFull {
+		return fmt.Errorf("a TLS server name can only be given with the %s mode", TLSVerifyFull)
+	}
+	return nil
+}
+
+// setupTLSVerification sets how the certificate of the server is verified,
+// the roots of the TLS configuration being the CAs the certificate must be
+// signed by
+func setupTLSVerification(tlsConfig *tls.Config, config tlsVerifyConfig) {
+	switch config.mode {
+	case TLSVerifyFull:
+		// the standard verification checks the chain, and that the DNS or IP SANs
+		// match the server name, which is the host of the request URL if not set
+		tlsConfig.ServerName = config.serverName
+	case TLSVerifyCA:
+		// only the chain is checked, as the standard verification
+		// cannot skip the SAN check
+		//nolint:gosec
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyCertificateChain(state.PeerCertificates, tlsConfig.RootCAs)
+		}
+	default:
+		// for both http and nma, we have to use `InsecureSkipVerify: true` here
+		// because the certs are self signed by default
+		//nolint:gosec
+		tlsConfig.InsecureSkipVerify = true
+	}
+}
+
+// verifyCertificateChain checks the certificate of the server is signed by one
+// of the roots, the system roots being used if roots is nil
+func verifyCertificateChain(peerCertificates []*x509.Certificate, roots *x509.CertPool) error {
+	if len(peerCertificates) == 0 {
+		return errors.New("the server did not send any certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range peerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := peerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return fmt.Errorf("fail to verify the certificate of the server: %w", err)
+	}
+	return nil
+}
+
+// buildCAPool returns the CAs the certificates of the servers are verified
+// against when no client certificate is used: the CA in the options, or the
+// default CA file. It returns nil to use the system roots if there is none.
+func buildCAPool(caCert string) (*x509.CertPool, error) {
+	if caCert == "" {
+		caFile := path.Join(certPathBase, "rootca.pem")
+		caBytes, err := os.ReadFile(caFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("fail to load HTTPS CA certificates, details %w", err)
+		}
+		caCert = string(caBytes)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, fmt.Errorf("fail to load HTTPS CA certificates")
+	}
+	return caCertPool, nil
+}
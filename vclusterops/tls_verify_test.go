@@ -0,0 +1,73 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTLSVerify(t *testing.T) {
+	opt := DatabaseOptions{}
+	assert.NoError(t, opt.validateTLSVerify())
+
+	opt.TLSVerifyMode = "verify"
+	assert.ErrorContains(t, opt.validateTLSVerify(), "invalid TLS verification mode")
+
+	opt.TLSVerifyMode = TLSVerifyCA
+	opt.TLSServerName = "vertica.example.com"
+	assert.ErrorContains(t, opt.validateTLSVerify(), "can only be given with the verify-full mode")
+
+	opt.TLSVerifyMode = TLSVerifyFull
+	assert.NoError(t, opt.validateTLSVerify())
+}
+
+func TestTLSVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverCAs := x509.NewCertPool()
+	serverCAs.AddCert(server.Certificate())
+
+	get := func(config tlsVerifyConfig, roots *x509.CertPool) error {
+		tlsConfig := &tls.Config{RootCAs: roots}
+		setupTLSVerification(tlsConfig, config)
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// the certificate of the test server is self signed
+	assert.NoError(t, get(tlsVerifyConfig{mode: TLSInsecureSkipVerify}, nil))
+	assert.Error(t, get(tlsVerifyConfig{mode: TLSVerifyCA}, x509.NewCertPool()))
+	assert.NoError(t, get(tlsVerifyConfig{mode: TLSVerifyCA}, serverCAs))
+
+	// the certificate of the test server has the 127.0.0.1 IP SAN and the example.com and *.example.com DNS SANs
+	assert.NoError(t, get(tlsVerifyConfig{mode: TLSVerifyFull}, serverCAs))
+	assert.NoError(t, get(tlsVerifyConfig{mode: TLSVerifyFull, serverName: "example.com"}, serverCAs))
+	assert.Error(t, get(tlsVerifyConfig{mode: TLSVerifyFull, serverName: "vertica.test"}, serverCAs))
+	// verify-ca does not check the SANs
+	assert.NoError(t, get(tlsVerifyConfig{mode: TLSVerifyCA, serverName: "vertica.test"}, serverCAs))
+}
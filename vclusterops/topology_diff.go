@@ -0,0 +1,204 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// VTopologyNode is the placement of a node in the topology of a database
+type VTopologyNode struct {
+	Name       string `json:"name"`
+	Address    string `json:"address"`
+	Subcluster string `json:"subcluster,omitempty"`
+	// empty string if the node is not in a sandbox
+	Sandbox string `json:"sandbox,omitempty"`
+}
+
+// VTopologyChange is a property of a node whose live value differs from
+// the expected one, e.g., its address
+type VTopologyChange struct {
+	NodeName string `json:"node_name"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// VTopologyDiff is the difference between an expected topology and the live
+// topology of a database. The nodes are sorted by name.
+type VTopologyDiff struct {
+	// nodes in the live catalog that are not expected
+	AddedNodes []VTopologyNode `json:"added_nodes"`
+	// expected nodes that are not in the live catalog
+	RemovedNodes []VTopologyNode `json:"removed_nodes"`
+	// nodes whose addresses changed
+	ReIPedNodes []VTopologyChange `json:"reiped_nodes"`
+	// nodes that moved to another subcluster
+	SubclusterChanges []VTopologyChange `json:"subcluster_changes"`
+	// nodes that were sandboxed or unsandboxed
+	SandboxChanges []VTopologyChange `json:"sandbox_changes"`
+}
+
+// IsEmpty returns true if the live topology matches the expected one
+func (diff *VTopologyDiff) IsEmpty() bool {
+	return len(diff.AddedNodes) == 0 && len(diff.RemovedNodes) == 0 && len(diff.ReIPedNodes) == 0 &&
+		len(diff.SubclusterChanges) == 0 && len(diff.SandboxChanges) == 0
+}
+
+type VDiffTopologyOptions struct {
+	DatabaseOptions
+	// the expected topology, e.g., the nodes of the config file
+	ExpectedNodes []VTopologyNode
+	// path to a cluster_config.json on the local host, to use as the expected
+	// topology instead of ExpectedNodes. It does not tell the subclusters and
+	// sandboxes of the nodes, so only added, removed and re-ip'ed nodes are
+	// reported.
+	DescriptionFilePath string
+}
+
+func VDiffTopologyOptionsFactory() VDiffTopologyOptions {
+	options := VDiffTopologyOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VDiffTopologyOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandDiffTopology, logger)
+	if err != nil {
+		return err
+	}
+	if (len(options.ExpectedNodes) == 0) == (options.DescriptionFilePath == "") {
+		return fmt.Errorf("must specify exactly one of the expected nodes and the description file")
+	}
+	return nil
+}
+
+// VDiffTopology compares an expected topology, e.g., the one of the config
+// file or of a cluster_config.json, with the live topology of a running
+// database, including its sandboxes. The nodes are matched by name.
+func (vcc VClusterCommands) VDiffTopology(options *VDiffTopologyOptions) (diff VTopologyDiff, err error) {
+	defer vcc.audit(commandDiffTopology, &options.DatabaseOptions, options, time.Now(), &err)
+
+	err = options.validateParseOptions(vcc.Log)
+	if err != nil {
+		return diff, err
+	}
+	err = resolveRawHosts(&options.DatabaseOptions)
+	if err != nil {
+		return diff, err
+	}
+
+	expectedNodes := options.ExpectedNodes
+	compareMembership := true
+	if options.DescriptionFilePath != "" {
+		expectedNodes, err = readTopologyFromDescriptionFile(options.DescriptionFilePath)
+		if err != nil {
+			return diff, err
+		}
+		compareMembership = false
+	}
+
+	vdb := makeVCoordinationDatabase()
+	err = vcc.getVDBFromRunningDBIncludeSandbox(&vdb, &options.DatabaseOptions, AnySandbox)
+	if err != nil {
+		return diff, fmt.Errorf("fail to get the live topology of database %s: %w", options.DBName, err)
+	}
+	liveNodes := make([]VTopologyNode, 0, len(vdb.HostNodeMap))
+	for _, vnode := range vdb.HostNodeMap {
+		liveNodes = append(liveNodes, VTopologyNode{Name: vnode.Name, Address: vnode.Address,
+			Subcluster: vnode.Subcluster, Sandbox: vnode.Sandbox})
+	}
+
+	return diffTopology(expectedNodes, liveNodes, compareMembership), nil
+}
+
+// readTopologyFromDescriptionFile reads the nodes of a local cluster_config.json
+func readTopologyFromDescriptionFile(path string) ([]VTopologyNode, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read the description file %s: %w", path, err)
+	}
+	desc, err := parseDatabaseDescription(string(content))
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]VTopologyNode, 0, len(desc.Nodes))
+	for _, node := range desc.Nodes {
+		nodes = append(nodes, VTopologyNode{Name: node.Name, Address: node.Address})
+	}
+	return nodes, nil
+}
+
+// diffTopology matches the expected and live nodes by name. The subclusters
+// and sandboxes are only compared if compareMembership is true.
+func diffTopology(expectedNodes, liveNodes []VTopologyNode, compareMembership bool) VTopologyDiff {
+	diff := VTopologyDiff{}
+	liveNodeMap := make(map[string]VTopologyNode, len(liveNodes))
+	for _, node := range liveNodes {
+		liveNodeMap[node.Name] = node
+	}
+	expectedNodeMap := make(map[string]VTopologyNode, len(expectedNodes))
+	for _, node := range expectedNodes {
+		expectedNodeMap[node.Name] = node
+	}
+
+	for _, expected := range expectedNodes {
+		live, ok := liveNodeMap[expected.Name]
+		if !ok {
+			diff.RemovedNodes = append(diff.RemovedNodes, expected)
+			continue
+		}
+		if expected.Address != live.Address {
+			diff.ReIPedNodes = append(diff.ReIPedNodes,
+				VTopologyChange{NodeName: expected.Name, Expected: expected.Address, Actual: live.Address})
+		}
+		if !compareMembership {
+			continue
+		}
+		if expected.Subcluster != live.Subcluster {
+			diff.SubclusterChanges = append(diff.SubclusterChanges,
+				VTopologyChange{NodeName: expected.Name, Expected: expected.Subcluster, Actual: live.Subcluster})
+		}
+		if expected.Sandbox != live.Sandbox {
+			diff.SandboxChanges = append(diff.SandboxChanges,
+				VTopologyChange{NodeName: expected.Name, Expected: expected.Sandbox, Actual: live.Sandbox})
+		}
+	}
+	for _, live := range liveNodes {
+		if _, ok := expectedNodeMap[live.Name]; !ok {
+			diff.AddedNodes = append(diff.AddedNodes, live)
+		}
+	}
+
+	sortNodes := func(nodes []VTopologyNode) {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	}
+	sortChanges := func(changes []VTopologyChange) {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].NodeName < changes[j].NodeName })
+	}
+	sortNodes(diff.AddedNodes)
+	sortNodes(diff.RemovedNodes)
+	sortChanges(diff.ReIPedNodes)
+	sortChanges(diff.SubclusterChanges)
+	sortChanges(diff.SandboxChanges)
+	return diff
+}
@@ -0,0 +1,78 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestDiffTopology(t *testing.T) {
+	expected := []VTopologyNode{
+		{Name: "v_test_db_node0001", Address: "10.20.30.40", Subcluster: "sc1"},
+		{Name: "v_test_db_node0002", Address: "10.20.30.41", Subcluster: "sc1"},
+		{Name: "v_test_db_node0003", Address: "10.20.30.42", Subcluster: "sc2"},
+		{Name: "v_test_db_node0004", Address: "10.20.30.43", Subcluster: "sc2"},
+	}
+	live := []VTopologyNode{
+		{Name: "v_test_db_node0005", Address: "10.20.30.44", Subcluster: "sc3"},
+		{Name: "v_test_db_node0004", Address: "10.20.30.43", Subcluster: "sc2", Sandbox: "sand1"},
+		{Name: "v_test_db_node0002", Address: "10.20.30.51", Subcluster: "sc2"},
+		{Name: "v_test_db_node0001", Address: "10.20.30.40", Subcluster: "sc1"},
+	}
+
+	diff := diffTopology(expected, live, true)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []VTopologyNode{live[0]}, diff.AddedNodes)
+	assert.Equal(t, []VTopologyNode{expected[2]}, diff.RemovedNodes)
+	assert.Equal(t, []VTopologyChange{{NodeName: "v_test_db_node0002", Expected: "10.20.30.41", Actual: "10.20.30.51"}},
+		diff.ReIPedNodes)
+	assert.Equal(t, []VTopologyChange{{NodeName: "v_test_db_node0002", Expected: "sc1", Actual: "sc2"}},
+		diff.SubclusterChanges)
+	assert.Equal(t, []VTopologyChange{{NodeName: "v_test_db_node0004", Expected: "", Actual: "sand1"}},
+		diff.SandboxChanges)
+
+	// without the membership, only the addresses are compared
+	diff = diffTopology(expected, live, false)
+	assert.Len(t, diff.ReIPedNodes, 1)
+	assert.Empty(t, diff.SubclusterChanges)
+	assert.Empty(t, diff.SandboxChanges)
+
+	diff = diffTopology(expected, expected, true)
+	assert.True(t, diff.IsEmpty())
+}
+
+func TestReadTopologyFromDescriptionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cluster_config.json")
+	content := `{"Node": [{"name": "v_test_db_node0001", "address": "10.20.30.40",
+		"catalogPath": "/data/test_db/v_test_db_node0001_catalog", "isPrimary": true}]}`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	nodes, err := readTopologyFromDescriptionFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []VTopologyNode{{Name: "v_test_db_node0001", Address: "10.20.30.40"}}, nodes)
+
+	options := VDiffTopologyOptionsFactory()
+	options.DBName = "test_db"
+	options.RawHosts = []string{"10.20.30.40"}
+	assert.ErrorContains(t, options.validateParseOptions(vlog.Printer{}), "exactly one")
+	options.DescriptionFilePath = path
+	assert.NoError(t, options.validateParseOptions(vlog.Printer{}))
+}
@@ -0,0 +1,75 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"context"
+)
+
+// Tracer is an optional hook to trace the operations run by vclusterops. A
+// span is started for each op, and, as its children, a span for each request
+// that the op sends to a host. Tracer is meant to be implemented on top of a
+// tracing library, e.g., by an adapter around an OpenTelemetry TracerProvider:
+//
+//	func (t otelTracer) StartSpan(ctx context.Context, name string,
+//		attributes map[string]string) (context.Context, vclusterops.Span) {
+//		ctx, span := t.provider.Tracer("vclusterops").Start(ctx, name)
+//		for k, v := range attributes {
+//			span.SetAttributes(attribute.String(k, v))
+//		}
+//		return ctx, otelSpan{span}
+//	}
+//
+// The spans of the ops have no parent in ctx, so the implementation can attach
+// them to a span of the caller, e.g., the span of the request that called add_node.
+type Tracer interface {
+	// StartSpan starts a span with the given name and attributes, as a child
+	// of the span in ctx if any. It returns a context that carries the new span.
+	StartSpan(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// RecordError marks the span as failed with the given error
+	RecordError(err error)
+	// End completes the span
+	End()
+}
+
+// startSpan starts a span with the given tracer, which can be nil if
+// tracing is disabled
+func startSpan(ctx context.Context, tracer Tracer, name string,
+	attributes map[string]string) (context.Context, Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return tracer.StartSpan(ctx, name, attributes)
+}
+
+// endSpan records err, if any, in the span and ends it
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// noopSpan is the span used when tracing is disabled
+type noopSpan struct{}
+
+func (noopSpan) RecordError(_ error) {}
+
+func (noopSpan) End() {}
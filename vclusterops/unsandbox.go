@@ -17,6 +17,7 @@ package vclusterops
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/vertica/vcluster/rfc7807"
 	"github.com/vertica/vcluster/vclusterops/util"
@@ -30,6 +31,10 @@ type VUnsandboxOptions struct {
 	SCRawHosts []string
 	// if restart the subcluster after unsandboxing it, the default value of it is true
 	RestartSC bool
+	// if delete the sandbox catalog directories of the subcluster hosts after
+	// unsandboxing it, so that the next sandbox does not leave the previous
+	// catalog on disk. The default value of it is true.
+	CleanupCatalog bool
 	// if any node in the target subcluster is up. This is for internal use only.
 	hasUpNodeInSC bool
 }
@@ -43,6 +48,7 @@ func VUnsandboxOptionsFactory() VUnsandboxOptions {
 func (options *VUnsandboxOptions) setDefaultValues() {
 	options.DatabaseOptions.setDefaultValues()
 	options.RestartSC = true
+	options.CleanupCatalog = true
 }
 
 func (options *VUnsandboxOptions) validateRequiredOptions(logger vlog.Printer) error {
@@ -179,7 +185,7 @@ func (vcc *VClusterCommands) unsandboxPreCheck(vdb *VCoordinationDatabase, optio
 //     1. Stop the up subcluster hosts
 //     2. Poll for stopped hosts to be down
 //   - Run unsandboxing for the user provided subcluster using the selected initiator host(s).
-//   - Remove catalog dirs from unsandboxed hosts
+//   - Remove catalog dirs from unsandboxed hosts, unless the catalog cleanup is disabled
 //   - VCluster CLI will restart the unsandboxed hosts using below instructions, but k8s operator will skip the restart process
 //     1. Check Vertica versions
 //     2. get start commands from UP main cluster node
@@ -236,16 +242,16 @@ func (vcc *VClusterCommands) produceUnsandboxSCInstructions(options *VUnsandboxO
 		return instructions, err
 	}
 
-	// Clean catalog dirs
-	nmaDeleteDirsOp, err := makeNMADeleteDirsSandboxOp(true, true /* sandbox */)
-	if err != nil {
-		return instructions, err
-	}
+	instructions = append(instructions, &httpsUnsandboxSubclusterOp)
 
-	instructions = append(instructions,
-		&httpsUnsandboxSubclusterOp,
-		&nmaDeleteDirsOp,
-	)
+	if options.CleanupCatalog {
+		// Clean catalog dirs
+		nmaDeleteDirsOp, e := makeNMADeleteDirsSandboxOp(true, true /* sandbox */)
+		if e != nil {
+			return instructions, e
+		}
+		instructions = append(instructions, &nmaDeleteDirsOp)
+	}
 
 	if options.RestartSC {
 		// NMA check vertica versions before restart
@@ -278,7 +284,8 @@ func (vcc *VClusterCommands) produceUnsandboxSCInstructions(options *VUnsandboxO
 	return instructions, nil
 }
 
-func (vcc VClusterCommands) VUnsandbox(options *VUnsandboxOptions) error {
+func (vcc VClusterCommands) VUnsandbox(options *VUnsandboxOptions) (err error) {
+	defer vcc.audit(commandUnsandboxSC, &options.DatabaseOptions, options, time.Now(), &err)
 	vcc.Log.V(0).Info("VUnsandbox method called", "options", options)
 	return runSandboxCmd(vcc, options)
 }
@@ -297,8 +304,8 @@ func (options *VUnsandboxOptions) runCommand(vcc VClusterCommands) error {
 	}
 
 	// add certs and instructions to the engine
-	certs := httpsCerts{key: options.Key, cert: options.Cert, caCert: options.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// run the engine
 	runError := clusterOpEngine.run(vcc.Log)
@@ -0,0 +1,52 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+func TestUnsandboxCleanupCatalog(t *testing.T) {
+	vcc := VClusterCommands{}
+	vcc.Log = vlog.Printer{}
+	options := VUnsandboxOptionsFactory()
+	options.DBName = "test_db"
+	options.SCName = "sc1"
+	options.Hosts = []string{"192.168.1.101"}
+	options.RestartSC = false
+
+	hasDeleteDirsOp := func(instructions []clusterOp) bool {
+		for _, op := range instructions {
+			if op.getName() == delDirOpName {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the catalog is cleaned up by default
+	instructions, err := vcc.produceUnsandboxSCInstructions(&options)
+	assert.NoError(t, err)
+	assert.True(t, hasDeleteDirsOp(instructions))
+
+	options.CleanupCatalog = false
+	instructions, err = vcc.produceUnsandboxSCInstructions(&options)
+	assert.NoError(t, err)
+	assert.False(t, hasDeleteDirsOp(instructions))
+}
@@ -0,0 +1,146 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// the schemes of the remote communal storage locations
+const (
+	S3Scheme      = "s3"
+	GCSScheme     = "gs"
+	AzureScheme   = "azb"
+	HDFSScheme    = "hdfs"
+	WebHDFSScheme = "webhdfs"
+)
+
+const schemeSeparator = "://"
+
+// bucket names of S3, including the S3-compatible stores like MinIO, and GCS
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.\-_]{1,61}[a-z0-9]$`)
+
+// CommunalLocation is a communal storage location split into its parts.
+// For example, "s3://bucket/db/loc" has the scheme "s3", the host "bucket",
+// and the path "db/loc".
+type CommunalLocation struct {
+	// empty for a local path
+	Scheme string
+	// the bucket of S3 and GCS, the account of Azure,
+	// or the name node (host[:port]) of HDFS
+	Host string
+	// an absolute path for a local location, or the path
+	// under the host, without leading or trailing slash
+	Path string
+}
+
+// ParseCommunalStorageLocation parses and normalizes a communal storage
+// location: the scheme is lowercased, and the duplicate and trailing
+// slashes are removed. An error tells what is wrong with the location.
+func ParseCommunalStorageLocation(location string) (CommunalLocation, error) {
+	loc := CommunalLocation{}
+	if location == "" {
+		return loc, fmt.Errorf("must specify a communal storage location")
+	}
+
+	scheme, rest, isRemote := strings.Cut(location, schemeSeparator)
+	if !isRemote {
+		if !IsAbsPath(location) {
+			return loc, fmt.Errorf("communal storage path is invalid: use an absolute local path or a correct remote url path")
+		}
+		loc.Path = path.Clean(location)
+		return loc, nil
+	}
+
+	loc.Scheme = strings.ToLower(scheme)
+	host, remotePath, _ := strings.Cut(rest, "/")
+	if host == "" {
+		return loc, fmt.Errorf("communal storage location %q is missing the host or bucket after %s",
+			location, schemeSeparator)
+	}
+	loc.Host = host
+	loc.Path = strings.Trim(path.Clean("/"+remotePath), "/")
+
+	switch loc.Scheme {
+	case S3Scheme, GCSScheme:
+		if !bucketNameRegexp.MatchString(host) {
+			return loc, fmt.Errorf("communal storage location %q has an invalid bucket name %q: "+
+				"use 3 to 63 lowercase letters, digits, dots, hyphens, or underscores", location, host)
+		}
+	case AzureScheme:
+		// azb://account/container/path
+		if loc.Path == "" {
+			return loc, fmt.Errorf("communal storage location %q is missing the container: "+
+				"use %s://account/container/path", location, AzureScheme)
+		}
+	case HDFSScheme, WebHDFSScheme:
+		err := validateNameNode(host)
+		if err != nil {
+			return loc, fmt.Errorf("communal storage location %q has an invalid name node: %w", location, err)
+		}
+	default:
+		return loc, fmt.Errorf("communal storage location %q has an unsupported scheme %q, use one of %s",
+			location, scheme, strings.Join([]string{S3Scheme, GCSScheme, AzureScheme, HDFSScheme, WebHDFSScheme}, ", "))
+	}
+	return loc, nil
+}
+
+// validateNameNode checks a name node in the host[:port] format. The port
+// can be omitted, e.g., for a name service of an HA HDFS cluster.
+func validateNameNode(nameNode string) error {
+	host, port, hasPort := strings.Cut(nameNode, ":")
+	if host == "" {
+		return fmt.Errorf("the host is empty")
+	}
+	if !hasPort {
+		return nil
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil || portNum < 1 || portNum > 65535 {
+		return fmt.Errorf("the port %q is not a number between 1 and 65535", port)
+	}
+	return nil
+}
+
+// IsRemote returns true if the location is not a local path
+func (loc CommunalLocation) IsRemote() bool {
+	return loc.Scheme != ""
+}
+
+// String returns the normalized location
+func (loc CommunalLocation) String() string {
+	if !loc.IsRemote() {
+		return loc.Path
+	}
+	if loc.Path == "" {
+		return loc.Scheme + schemeSeparator + loc.Host
+	}
+	return loc.Scheme + schemeSeparator + loc.Host + "/" + loc.Path
+}
+
+// Join returns the normalized location of the given elements under the location
+func (loc CommunalLocation) Join(elems ...string) string {
+	joined := loc
+	joined.Path = path.Join(append([]string{loc.Path}, elems...)...)
+	if loc.IsRemote() {
+		joined.Path = strings.Trim(joined.Path, "/")
+	}
+	return joined.String()
+}
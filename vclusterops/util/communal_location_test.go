@@ -0,0 +1,73 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommunalStorageLocation(t *testing.T) {
+	validLocations := map[string]string{
+		"/communal/db":                           "/communal/db",
+		"//communal//db/":                        "/communal/db",
+		"s3://vertica-fleeting/k8s/revive_eon_5": "s3://vertica-fleeting/k8s/revive_eon_5",
+		"S3://vertica-fleeting/k8s/":             "s3://vertica-fleeting/k8s",
+		"s3://minio-bucket":                      "s3://minio-bucket",
+		"gs://vertica-fleeting//k8s":             "gs://vertica-fleeting/k8s",
+		"azb://account/container/db":             "azb://account/container/db",
+		"hdfs://nameservice1/vertica/db":         "hdfs://nameservice1/vertica/db",
+		"webhdfs://namenode:9870/vertica/db/":    "webhdfs://namenode:9870/vertica/db",
+	}
+	for location, normalized := range validLocations {
+		loc, err := ParseCommunalStorageLocation(location)
+		assert.NoError(t, err, location)
+		assert.Equal(t, normalized, loc.String(), location)
+	}
+
+	invalidLocations := map[string]string{
+		"":                            "must specify",
+		"communal/db":                 "absolute local path",
+		"s3:///vertica-fleeting/k8s":  "missing the host",
+		"s3://Vertica_Fleeting/k8s":   "invalid bucket name",
+		"s3://ab/k8s":                 "invalid bucket name",
+		"azb://account":               "missing the container",
+		"webhdfs://namenode:http/db":  "invalid name node",
+		"webhdfs://namenode:70000/db": "invalid name node",
+		"hdfs://:8020/db":             "invalid name node",
+		"ftp://server/db":             "unsupported scheme",
+	}
+	for location, errMsg := range invalidLocations {
+		_, err := ParseCommunalStorageLocation(location)
+		assert.ErrorContains(t, err, errMsg, location)
+	}
+}
+
+func TestJoinCommunalLocation(t *testing.T) {
+	loc, err := ParseCommunalStorageLocation("s3://vertica-fleeting/k8s/")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://vertica-fleeting/k8s/metadata/db/cluster_config.json",
+		loc.Join("metadata", "db", "cluster_config.json"))
+
+	loc, err = ParseCommunalStorageLocation("s3://vertica-fleeting")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3://vertica-fleeting/metadata", loc.Join("metadata"))
+
+	loc, err = ParseCommunalStorageLocation("/communal/")
+	assert.NoError(t, err)
+	assert.Equal(t, "/communal/metadata/db", loc.Join("metadata", "db"))
+}
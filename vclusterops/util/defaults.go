@@ -18,6 +18,7 @@ package util
 // this file defines basic default values
 const (
 	DefaultClientPort                = 5433
+	DefaultSpreadPort                = 4803
 	DefaultHTTPPortOffset            = 3010
 	DefaultHTTPPort                  = DefaultClientPort + DefaultHTTPPortOffset
 	DefaultControlAddressFamily      = "ipv4"
@@ -39,6 +40,7 @@ const (
 	MaxDepotSize                     = 100
 	DefaultDrainSeconds              = 60
 	DefaultControlSetSize            = -1
+	DefaultMaxClockSkewSeconds       = 5
 	NodeUpState                      = "UP"
 	NodeDownState                    = "DOWN"
 	NodeUnknownState                 = "UNKNOWN" // this is for sandbox only
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -49,7 +50,10 @@ const (
 	ipv4Str          = "IPv4"
 	ipv6Str          = "IPv6"
 	AWSAuthKey       = "awsauth"
-	kubernetesPort   = "KUBERNETES_PORT"
+	// the parameter that makes the server access the communal storage with
+	// the IAM role of the hosts, e.g., an instance profile or IRSA
+	AWSUseInstanceProfileKey = "awsuseinstanceprofile"
+	kubernetesPort           = "KUBERNETES_PORT"
 
 	// Environment variable names storing name of k8s secret that has NMA cert
 	secretNameSpaceEnvVar = "NMA_SECRET_NAMESPACE"
@@ -233,6 +237,51 @@ func IsIPv6(ip string) bool {
 	return strings.Contains(ip, ":") && net.ParseIP(ip).To16() != nil
 }
 
+// TrimIPv6Brackets removes the brackets around an IPv6 address, e.g., [fd00::1]
+func TrimIPv6Brackets(host string) string {
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		return host[1 : len(host)-1]
+	}
+	return host
+}
+
+// JoinHostPort returns host:port, with brackets around the host if it is an
+// IPv6 address, e.g., [fd00::1]:8443, so that it can be used in a URL
+func JoinHostPort(host string, port int) string {
+	return net.JoinHostPort(TrimIPv6Brackets(host), strconv.Itoa(port))
+}
+
+// GetControlAddressFamily returns the control address family of a node
+// from the family of its address
+func GetControlAddressFamily(address string) string {
+	if IsIPv6(address) {
+		return IPv6ControlAddressFamily
+	}
+	return DefaultControlAddressFamily
+}
+
+// IsLocalHost returns true if the IP address belongs to one of
+// the network interfaces of the host vcluster runs on
+func IsLocalHost(address string) (bool, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false, fmt.Errorf("%s is not a valid IP address", address)
+	}
+	if ip.IsLoopback() {
+		return true, nil
+	}
+	interfaceAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false, fmt.Errorf("fail to get the addresses of the network interfaces: %w", err)
+	}
+	for _, interfaceAddr := range interfaceAddrs {
+		if ipNet, ok := interfaceAddr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func AddressCheck(address string, ipv6 bool) error {
 	checkPassed := false
 	if ipv6 {
@@ -278,13 +327,14 @@ func ResolveToIPAddrs(hostname string, ipv6 bool) ([]string, error) {
 	return v4Addrs, nil
 }
 
+// ResolveToOneIP returns the IP address of a host. An IPv4 or IPv6 address is
+// returned as is, whatever ipv6 is, so that a host list can mix both families.
+// A host name is resolved to an address of the preferred family, IPv6 if ipv6
+// is true, or of the other family if the host name has none.
 func ResolveToOneIP(hostname string, ipv6 bool) (string, error) {
+	hostname = TrimIPv6Brackets(hostname)
 	// already an IPv4 or IPv6 address
-	if !ipv6 && IsIPv4(hostname) {
-		return hostname, nil
-	}
-	// IPv6
-	if ipv6 && IsIPv6(hostname) {
+	if IsIPv4(hostname) || IsIPv6(hostname) {
 		return hostname, nil
 	}
 
@@ -294,13 +344,15 @@ func ResolveToOneIP(hostname string, ipv6 bool) (string, error) {
 	if err != nil {
 		return "", err
 	}
-
-	ipVersion := ipv4Str
-	if ipv6 {
-		ipVersion = ipv6Str
+	if len(addrs) == 0 {
+		// the host name only has addresses of the other family
+		addrs, err = ResolveToIPAddrs(hostname, !ipv6)
+		if err != nil {
+			return "", err
+		}
 	}
 	if len(addrs) == 0 {
-		return "", fmt.Errorf("cannot resolve %s as %s address", hostname, ipVersion)
+		return "", fmt.Errorf("cannot resolve %s as %s or %s address", hostname, ipv4Str, ipv6Str)
 	}
 
 	if len(addrs) > 1 {
@@ -347,13 +399,28 @@ func AbsPathCheck(dirPath string) error {
 	return nil
 }
 
-// ParseHostList will trim spaces and convert all chars to lowercase in the hosts
+// ParseHostList will trim spaces and convert all chars to lowercase in the hosts.
+// It also expands the host ranges, e.g., node[01-32].example.com. A host
+// expanded from a range is skipped if it is already in the list, so that
+// overlapping ranges are accepted. The hosts given without range are kept
+// as is, even if they are repeated.
 func ParseHostList(hosts *[]string) error {
 	var parsedHosts []string
+	seenHosts := mapset.NewSet[string]()
 	for _, host := range *hosts {
-		parsedHost := strings.TrimSpace(strings.ToLower(host))
-		if parsedHost != "" {
-			parsedHosts = append(parsedHosts, parsedHost)
+		parsedHost := TrimIPv6Brackets(strings.TrimSpace(strings.ToLower(host)))
+		if parsedHost == "" {
+			continue
+		}
+		expandedHosts, err := expandHostRange(parsedHost)
+		if err != nil {
+			return err
+		}
+		isRange := len(expandedHosts) != 1 || expandedHosts[0] != parsedHost
+		for _, expandedHost := range expandedHosts {
+			if seenHosts.Add(expandedHost) || !isRange {
+				parsedHosts = append(parsedHosts, expandedHost)
+			}
 		}
 	}
 	if len(parsedHosts) == 0 {
@@ -364,6 +431,75 @@ func ParseHostList(hosts *[]string) error {
 	return nil
 }
 
+// a range of numbers in a host, e.g., [01-32] in node[01-32].example.com
+var hostRangeRegexp = regexp.MustCompile(`\[(\d+)-(\d+)\]`)
+
+// maxExpandedHosts is the maximum number of hosts that a host with ranges
+// can expand to, to catch typos like node[1-10000]
+const maxExpandedHosts = 4096
+
+// maxHostRangeDigits is the maximum number of digits in the start or the
+// end of a host range, so that the bounds cannot overflow
+const maxHostRangeDigits = 6
+
+// expandHostRange returns the hosts that the ranges in host expand to, e.g.,
+// node[01-03] expands to node01, node02 and node03, and 10.1.1.[9-10] to
+// 10.1.1.9 and 10.1.1.10. A range whose start has a leading zero is padded
+// to the width of its start. A host without range is returned as is.
+func expandHostRange(host string) ([]string, error) {
+	loc := hostRangeRegexp.FindStringSubmatchIndex(host)
+	if loc == nil {
+		if strings.ContainsAny(host, "[]") {
+			return nil, fmt.Errorf("invalid host range in %q, a range must look like [01-32]", host)
+		}
+		return []string{host}, nil
+	}
+
+	prefix, startStr, endStr, suffix := host[:loc[0]], host[loc[2]:loc[3]], host[loc[4]:loc[5]], host[loc[1]:]
+	if strings.ContainsAny(prefix, "[]") {
+		return nil, fmt.Errorf("invalid host range in %q, a range must look like [01-32]", host)
+	}
+	if len(startStr) > maxHostRangeDigits || len(endStr) > maxHostRangeDigits {
+		return nil, fmt.Errorf("invalid host range in %q, the start and the end can have at most %d digits",
+			host, maxHostRangeDigits)
+	}
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start of host range in %q: %w", host, err)
+	}
+	end, err := strconv.Atoi(endStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end of host range in %q: %w", host, err)
+	}
+	if start > end {
+		return nil, fmt.Errorf("invalid host range in %q, the start %d is greater than the end %d", host, start, end)
+	}
+	// check the size of the range before it is multiplied, so that it cannot overflow
+	if end-start >= maxExpandedHosts {
+		return nil, fmt.Errorf("host range %q expands to more than %d hosts", host, maxExpandedHosts)
+	}
+	width := 0
+	if strings.HasPrefix(startStr, "0") {
+		width = len(startStr)
+	}
+
+	// the rest of the host may have more ranges
+	suffixes, err := expandHostRange(suffix)
+	if err != nil {
+		return nil, err
+	}
+	if (end-start+1)*len(suffixes) > maxExpandedHosts {
+		return nil, fmt.Errorf("host range %q expands to more than %d hosts", host, maxExpandedHosts)
+	}
+	var hosts []string
+	for i := start; i <= end; i++ {
+		for _, s := range suffixes {
+			hosts = append(hosts, fmt.Sprintf("%s%0*d%s", prefix, width, i, s))
+		}
+	}
+	return hosts, nil
+}
+
 // get env var with a fallback value
 func GetEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
@@ -494,6 +630,41 @@ func ValidateSandboxName(dbName string) error {
 	return ValidateName(dbName, "sandbox")
 }
 
+// MaxNamespaceNameLength is the maximum length of a namespace name,
+// the same as any other identifier of the database
+const MaxNamespaceNameLength = 128
+
+func ValidateNamespaceName(namespace string) error {
+	if len(namespace) > MaxNamespaceNameLength {
+		return fmt.Errorf("namespace name %q is longer than %d characters", namespace, MaxNamespaceNameLength)
+	}
+	return ValidateName(namespace, "namespace")
+}
+
+// the keys of node and subcluster labels, e.g., topology.kubernetes.io/zone
+var labelKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]{0,251}[A-Za-z0-9])?$`)
+
+// MaxLabelValueLength is the maximum length of the value of a label
+const MaxLabelValueLength = 256
+
+// ValidateLabels checks the keys and values of node or subcluster labels.
+// A key starts and ends with an alphanumeric character and can contain
+// '.', '_', '/' and '-'. A value is a single line.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !labelKeyRegexp.MatchString(key) {
+			return fmt.Errorf("invalid label key %q", key)
+		}
+		if len(value) > MaxLabelValueLength {
+			return fmt.Errorf("the value of label %q is longer than %d characters", key, MaxLabelValueLength)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("the value of label %q must be a single line", key)
+		}
+	}
+	return nil
+}
+
 // suppress help message for hidden options
 func SetParserUsage(parser *flag.FlagSet, op string) {
 	fmt.Printf("Usage of %s:\n", op)
@@ -588,7 +759,9 @@ func ValidateCommunalStorageLocation(location string) error {
 		return fmt.Errorf("communal storage path is invalid: use an absolute local path or a correct remote url path")
 	}
 
-	return nil
+	// check the rules of the scheme of a remote location
+	_, err := ParseCommunalStorageLocation(location)
+	return err
 }
 
 // Max works on all sane types, not just float64 like the math package funcs.
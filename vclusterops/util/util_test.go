@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -109,12 +110,54 @@ func TestResolveToOneIP(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, res, "")
 
-	// given IP does not match its IP version
-	_, err = ResolveToOneIP("192.168.1.101", true)
-	assert.ErrorContains(t, err, "cannot resolve 192.168.1.101 as IPv6 address")
+	// an IP address is used as is, whatever the preferred IP version
+	res, err = ResolveToOneIP("192.168.1.101", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.1.101", res)
+
+	res, err = ResolveToOneIP("2001:db8::8:800:200c:417a", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::8:800:200c:417a", res)
 
-	_, err = ResolveToOneIP("2001:db8::8:800:200c:417a", false)
-	assert.ErrorContains(t, err, "cannot resolve 2001:db8::8:800:200c:417a as IPv4 address")
+	res, err = ResolveToOneIP("[2001:db8::1]", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", res)
+}
+
+func TestMixedHostList(t *testing.T) {
+	hosts := []string{" 192.168.1.101", "[2001:DB8::1] "}
+	assert.NoError(t, ParseHostList(&hosts))
+	assert.Equal(t, []string{"192.168.1.101", "2001:db8::1"}, hosts)
+
+	addresses, err := ResolveRawHostsToAddresses(hosts, false)
+	assert.NoError(t, err)
+	assert.Equal(t, hosts, addresses)
+	assert.Equal(t, DefaultControlAddressFamily, GetControlAddressFamily(addresses[0]))
+	assert.Equal(t, IPv6ControlAddressFamily, GetControlAddressFamily(addresses[1]))
+
+	assert.Equal(t, "192.168.1.101:8443", JoinHostPort("192.168.1.101", 8443))
+	assert.Equal(t, "[2001:db8::1]:8443", JoinHostPort("2001:db8::1", 8443))
+	assert.Equal(t, "[2001:db8::1]:8443", JoinHostPort("[2001:db8::1]", 8443))
+	assert.Equal(t, "vnode1:5554", JoinHostPort("vnode1", 5554))
+
+	// the preferred family does not change the addresses of a mixed list
+	addresses, err = ResolveRawHostsToAddresses(hosts, true)
+	assert.NoError(t, err)
+	assert.Equal(t, hosts, addresses)
+}
+
+func TestIsLocalHost(t *testing.T) {
+	local, err := IsLocalHost("127.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, local)
+
+	// a documentation address cannot be local
+	local, err = IsLocalHost("192.0.2.1")
+	assert.NoError(t, err)
+	assert.False(t, local)
+
+	_, err = IsLocalHost("randomIP")
+	assert.ErrorContains(t, err, "not a valid IP address")
 }
 
 func TestGetCleanPath(t *testing.T) {
@@ -161,6 +204,44 @@ func TestParseHostList(t *testing.T) {
 	err = ParseHostList(&hosts)
 	assert.NotNil(t, err)
 	assert.Equal(t, err.Error(), "must specify a host or host list")
+
+	// ranges are expanded and the hosts of overlapping ranges are only kept once
+	hosts = []string{"Node[08-10].example.com", "10.1.1.[9-10]", "node[09-11].example.com", "[2001:db8::1]"}
+	assert.NoError(t, ParseHostList(&hosts))
+	assert.Equal(t, []string{"node08.example.com", "node09.example.com", "node10.example.com",
+		"10.1.1.9", "10.1.1.10", "node11.example.com", "2001:db8::1"}, hosts)
+
+	// the hosts given without range are not deduplicated
+	hosts = []string{"vnode1", " VNODE1", "vnode2"}
+	assert.NoError(t, ParseHostList(&hosts))
+	assert.Equal(t, []string{"vnode1", "vnode1", "vnode2"}, hosts)
+}
+
+func TestExpandHostRange(t *testing.T) {
+	hosts, err := expandHostRange("rack[1-2]-node[01-02]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rack1-node01", "rack1-node02", "rack2-node01", "rack2-node02"}, hosts)
+
+	hosts, err = expandHostRange("vnode1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"vnode1"}, hosts)
+
+	_, err = expandHostRange("node[32-01]")
+	assert.ErrorContains(t, err, "the start 32 is greater than the end 1")
+	_, err = expandHostRange("node[1-]")
+	assert.ErrorContains(t, err, "a range must look like [01-32]")
+	_, err = expandHostRange("node[a]-[1-2]")
+	assert.ErrorContains(t, err, "a range must look like [01-32]")
+	_, err = expandHostRange("node[1-10000]")
+	assert.ErrorContains(t, err, "expands to more than 4096 hosts")
+	_, err = expandHostRange("rack[1-100]-node[1-100]")
+	assert.ErrorContains(t, err, "expands to more than 4096 hosts")
+	// the bounds are checked before the range is expanded, so a huge range
+	// is rejected right away instead of overflowing
+	_, err = expandHostRange("node[0-9223372036854775807]")
+	assert.ErrorContains(t, err, "can have at most 6 digits")
+	_, err = expandHostRange("rack[0-999999]-node[0-999999]")
+	assert.ErrorContains(t, err, "expands to more than 4096 hosts")
 }
 
 type testStruct struct {
@@ -279,6 +360,36 @@ func TestValidateName(t *testing.T) {
 	assert.ErrorContains(t, err, "invalid character in "+obj+" name: -")
 }
 
+func TestValidateNamespaceName(t *testing.T) {
+	assert.NoError(t, ValidateNamespaceName("default_namespace"))
+	assert.NoError(t, ValidateNamespaceName(strings.Repeat("n", MaxNamespaceNameLength)))
+
+	err := ValidateNamespaceName("ns.one")
+	assert.ErrorContains(t, err, "invalid character in namespace name: .")
+	err = ValidateNamespaceName(strings.Repeat("n", MaxNamespaceNameLength+1))
+	assert.ErrorContains(t, err, "is longer than 128 characters")
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels(nil))
+	assert.NoError(t, ValidateLabels(map[string]string{
+		"topology.kubernetes.io/zone": "us-east-1a",
+		"rack":                        "r12",
+		"instance-type":               "",
+	}))
+
+	err := ValidateLabels(map[string]string{"": "value"})
+	assert.ErrorContains(t, err, `invalid label key ""`)
+	err = ValidateLabels(map[string]string{"-zone": "us-east-1a"})
+	assert.ErrorContains(t, err, `invalid label key "-zone"`)
+	err = ValidateLabels(map[string]string{"zone id": "1"})
+	assert.ErrorContains(t, err, `invalid label key "zone id"`)
+	err = ValidateLabels(map[string]string{"note": "line1\nline2"})
+	assert.ErrorContains(t, err, `the value of label "note" must be a single line`)
+	err = ValidateLabels(map[string]string{"note": strings.Repeat("v", MaxLabelValueLength+1)})
+	assert.ErrorContains(t, err, "is longer than 256 characters")
+}
+
 func TestSetEonFlagHelpMsg(t *testing.T) {
 	msg := "Path to depot directory"
 	finalMsg := "[Eon only] Path to depot directory"
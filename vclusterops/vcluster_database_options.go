@@ -16,6 +16,8 @@
 package vclusterops
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -34,8 +36,13 @@ type DatabaseOptions struct {
 	RawHosts []string
 	// expected to be IP addresses resolved from RawHosts
 	Hosts []string
-	// whether using IPv6 for host addresses
+	// whether host names are resolved to IPv6 addresses rather than IPv4 ones.
+	// IP addresses in RawHosts are used as is, so IPv4 and IPv6 can be mixed.
 	IPv6 bool
+	// optional, the CIDR of a network, e.g., 10.20.0.0/16. On hosts with
+	// several network interfaces, the addresses of the new nodes are the
+	// addresses of the hosts in that network instead of the given ones.
+	Subnet string
 	// path of catalog directory
 	CatalogPrefix string
 	// path of data directory
@@ -53,6 +60,9 @@ type DatabaseOptions struct {
 	CommunalStorageLocation string
 	// database configuration parameters
 	ConfigurationParameters map[string]string
+	// whether to access the communal storage with the IAM role of the hosts,
+	// e.g., an instance profile or IRSA, instead of AWS keys
+	UseInstanceProfile bool
 
 	/* part 3: authentication info */
 
@@ -66,11 +76,49 @@ type DatabaseOptions struct {
 	Cert string
 	// TLS CA Certificate
 	CaCert string
+	// optional, the parsed TLS certificate and its key, used instead of
+	// Key and Cert, e.g., when the certificate is held by the caller
+	TLSCertificate *tls.Certificate `json:"-"`
+	// optional, the CAs, used instead of CaCert
+	CACertPool *x509.CertPool `json:"-"`
+	// optional, how the certificates of the servers are verified,
+	// TLSInsecureSkipVerify by default
+	TLSVerifyMode TLSVerifyMode
+	// optional, with TLSVerifyFull, the name the SANs of the certificates
+	// must match instead of the host names or IP addresses of the servers
+	TLSServerName string
+	// optional, an access token from an identity provider, sent as a bearer
+	// token to the HTTPS endpoints instead of the TLS certificates if no
+	// password is given
+	AccessToken string
+	// optional, path of a file to read the access token from
+	TokenFile string
+	// optional, called to get a new access token when the HTTPS endpoints
+	// reject the current one
+	AccessTokenRefresher AccessTokenRefresher `json:"-"`
+	// optional, the name of the Kerberos service of the HTTPS endpoints,
+	// e.g., vertica. When set, the HTTPS endpoints are called with Kerberos
+	// authentication through SPNEGO, instead of the TLS certificates, if
+	// no password is given.
+	KerberosServiceName string
+	// path of the keytab of the Kerberos client principal
+	KerberosKeytab string
+	// generates the SPNEGO tokens, required with Kerberos authentication
+	KerberosTokenGenerator SPNEGOTokenGenerator `json:"-"`
 
 	/* part 4: other info */
 
 	// path of the log file
 	LogPath string
+	// optional, path of the file to which a record of each V* API invocation is
+	// appended, for compliance tracking. Set it to AuditLogSyslog to send the
+	// records to syslog instead.
+	AuditLogPath string
+	// whether to resolve the host names in RawHosts again before each op,
+	// and when a host is unreachable, instead of only once at the start of
+	// the command, so that it survives a host name that starts pointing at
+	// another address, e.g., after a DNS-based failover
+	ReresolveHosts bool
 	// whether use password
 	usePassword bool
 }
@@ -85,29 +133,75 @@ const (
 )
 
 const (
-	commandCreateDB            = "create_db"
-	commandDropDB              = "drop_db"
-	commandStopDB              = "stop_db"
-	commandStartDB             = "start_db"
-	commandAddNode             = "add_node"
-	commandRemoveNode          = "remove_node"
-	commandStopNode            = "stop_node"
-	commandRestartNode         = "restart_node"
-	commandAddSubcluster       = "add_subcluster"
-	commandRemoveSubcluster    = "remove_subcluster"
-	commandStopSubcluster      = "stop_subcluster"
-	commandStartSubcluster     = "start_subcluster"
-	commandSandboxSC           = "sandbox_subcluster"
-	commandUnsandboxSC         = "unsandbox_subcluster"
-	commandShowRestorePoints   = "show_restore_points"
-	commandInstallPackages     = "install_packages"
-	commandConfigRecover       = "manage_config_recover"
-	commandManageConnections   = "manage_connections"
-	commandReplicationStart    = "replication_start"
-	commandFetchNodesDetails   = "fetch_nodes_details"
-	commandAlterSubclusterType = "alter_subcluster_type"
-	commandRenameSc            = "rename_subcluster"
-	commandReIP                = "re_ip"
+	commandCreateDB                    = "create_db"
+	commandDropDB                      = "drop_db"
+	commandStopDB                      = "stop_db"
+	commandStartDB                     = "start_db"
+	commandAddNode                     = "add_node"
+	commandRemoveNode                  = "remove_node"
+	commandStopNode                    = "stop_node"
+	commandRestartNode                 = "restart_node"
+	commandAddSubcluster               = "add_subcluster"
+	commandRemoveSubcluster            = "remove_subcluster"
+	commandStopSubcluster              = "stop_subcluster"
+	commandStartSubcluster             = "start_subcluster"
+	commandSandboxSC                   = "sandbox_subcluster"
+	commandUnsandboxSC                 = "unsandbox_subcluster"
+	commandShowRestorePoints           = "show_restore_points"
+	commandInstallPackages             = "install_packages"
+	commandConfigRecover               = "manage_config_recover"
+	commandConfigSync                  = "manage_config_sync"
+	commandConfigRecoverFromCommunal   = "manage_config_recover_from_communal"
+	commandHibernateDB                 = "hibernate_db"
+	commandWakeDB                      = "wake_db"
+	commandPauseSubcluster             = "pause_subcluster"
+	commandScaleSubcluster             = "scale_subcluster"
+	commandFetchDatabaseInfo           = "fetch_database_info"
+	commandDistributeFile              = "distribute_file"
+	commandPollSubscriptionState       = "poll_subscription_state"
+	commandListSessions                = "list_sessions"
+	commandCloseSessions               = "close_sessions"
+	commandRollingUpgrade              = "rolling_upgrade"
+	commandCheckPrerequisites          = "check_prerequisites"
+	commandCheckLicenseCompliance      = "check_license_compliance"
+	commandManageConnections           = "manage_connections"
+	commandReplicationStart            = "replication_start"
+	commandFetchNodesDetails           = "fetch_nodes_details"
+	commandAlterSubclusterType         = "alter_subcluster_type"
+	commandRenameSc                    = "rename_subcluster"
+	commandReIP                        = "re_ip"
+	commandCheckProcesses              = "check_processes"
+	commandKillVertica                 = "kill_vertica"
+	commandVerifyCatalog               = "verify_catalog"
+	commandAlterDepotSize              = "alter_depot_size"
+	commandStorageLocation             = "storage_location"
+	commandReviveDB                    = "revive_db"
+	commandFetchNodeState              = "fetch_node_state"
+	commandStartNode                   = "start_node"
+	commandShowSandboxes               = "show_sandboxes"
+	commandListSubclusters             = "list_subclusters"
+	commandSetKSafety                  = "set_ksafety"
+	commandGetKSafety                  = "get_ksafety"
+	commandRebalanceShards             = "rebalance_shards"
+	commandRebalanceCluster            = "rebalance_cluster"
+	commandCreateArchive               = "create_archive"
+	commandRemoveArchive               = "remove_archive"
+	commandReplaceNode                 = "replace_node"
+	commandAlterNodeType               = "alter_node_type"
+	commandLoadBalanceGroup            = "load_balance_group"
+	commandRoutingRule                 = "routing_rule"
+	commandSetConfigParameter          = "set_config"
+	commandGetConfigParameter          = "get_config"
+	commandSpreadEncryption            = "spread_encryption"
+	commandRotateDBPassword            = "rotate_db_password"
+	commandManageNMA                   = "manage_nma"
+	commandRealignControlNodes         = "realign_control_nodes"
+	commandCustomRequest               = "custom_request"
+	commandForceRestartDB              = "force_restart_db"
+	commandDiffTopology                = "diff_topology"
+	commandCollectLogs                 = "collect_logs"
+	commandSyncCatalog                 = "sync_catalog"
+	commandGetCatalogTruncationVersion = "get_catalog_truncation_version"
 )
 
 func DatabaseOptionsFactory() DatabaseOptions {
@@ -146,6 +240,32 @@ func (opt *DatabaseOptions) validateBaseOptions(commandName string, log vlog.Pri
 		return err
 	}
 
+	// communal storage credentials
+	err = opt.validateCommunalAuth()
+	if err != nil {
+		return err
+	}
+
+	// TLS verification, access token and Kerberos authentication
+	err = opt.validateTLSVerify()
+	if err != nil {
+		return err
+	}
+	err = opt.validateAccessToken()
+	if err != nil {
+		return err
+	}
+	err = opt.validateKerberos()
+	if err != nil {
+		return err
+	}
+
+	// network of the node addresses
+	err = opt.validateSubnet()
+	if err != nil {
+		return err
+	}
+
 	// config directory
 	// VER-91801: remove this condition once re_ip supports the config file
 	if !slices.Contains([]string{commandReIP}, commandName) {
@@ -217,6 +337,41 @@ func (opt *DatabaseOptions) validatePaths(commandName string) error {
 	return nil
 }
 
+// validateCommunalAuth checks that no AWS keys are given when the communal
+// storage is accessed with the IAM role of the hosts
+func (opt *DatabaseOptions) validateCommunalAuth() error {
+	if !opt.UseInstanceProfile {
+		return nil
+	}
+	if opt.CommunalStorageLocation != "" &&
+		!strings.HasPrefix(strings.ToLower(opt.CommunalStorageLocation), "s3://") {
+		return fmt.Errorf("the instance profile can only be used with an S3 communal storage location")
+	}
+	for key := range opt.ConfigurationParameters {
+		if strings.EqualFold(key, util.AWSAuthKey) {
+			return fmt.Errorf("cannot set the %s configuration parameter when the instance profile is used",
+				util.AWSAuthKey)
+		}
+	}
+	return nil
+}
+
+// getCommunalStorageParameters returns the configuration parameters that the
+// server ops use to access the communal storage. With the instance profile,
+// they tell the server to use the IAM role of the hosts, since no AWS key
+// is given.
+func (opt *DatabaseOptions) getCommunalStorageParameters() map[string]string {
+	if !opt.UseInstanceProfile {
+		return opt.ConfigurationParameters
+	}
+	parameters := make(map[string]string, len(opt.ConfigurationParameters)+1)
+	for key, value := range opt.ConfigurationParameters {
+		parameters[key] = value
+	}
+	parameters[util.AWSUseInstanceProfileKey] = "1"
+	return parameters
+}
+
 func (opt *DatabaseOptions) validateCatalogPath() error {
 	// catalog prefix path
 	return util.ValidateRequiredAbsPath(opt.CatalogPrefix, "catalog path")
@@ -320,8 +475,8 @@ func (opt *DatabaseOptions) getVDBWhenDBIsDown(vcc VClusterCommands) (vdb VCoord
 		&nmaGetNodesInfoOp,
 	)
 
-	certs := httpsCerts{key: opt.Key, cert: opt.Cert, caCert: opt.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions1, &certs)
+	certs := opt.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions1, &certs)
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
 		vcc.Log.PrintError("fail to retrieve node names from NMA /nodes: %v", err)
@@ -339,7 +494,7 @@ func (opt *DatabaseOptions) getVDBWhenDBIsDown(vcc VClusterCommands) (vdb VCoord
 	}
 	instructions2 = append(instructions2, &nmaDownLoadFileOp)
 
-	clusterOpEngine = makeClusterOpEngine(instructions2, &certs)
+	clusterOpEngine = vcc.makeClusterOpEngine(instructions2, &certs)
 	err = clusterOpEngine.run(vcc.Log)
 	if err != nil {
 		vcc.Log.PrintError("fail to retrieve node details from %s: %v", descriptionFileName, err)
@@ -376,12 +531,21 @@ func (opt *DatabaseOptions) getVDBWhenDBIsDown(vcc VClusterCommands) (vdb VCoord
 func (opt *DatabaseOptions) getCurrConfigFilePath() string {
 	// description file will be in the location: {communal_storage_location}/metadata/{db_name}/cluster_config.json
 	// an example: s3://tfminio/test_loc/metadata/test_db/cluster_config.json
-	descriptionFilePath := filepath.Join(opt.CommunalStorageLocation, descriptionFileMetadataFolder, opt.DBName, descriptionFileName)
+	return opt.joinCommunalStorageLocation(descriptionFileMetadataFolder, opt.DBName, descriptionFileName)
+}
+
+// joinCommunalStorageLocation returns the normalized path of the given
+// elements under the communal storage location
+func (opt *DatabaseOptions) joinCommunalStorageLocation(elems ...string) string {
+	location, err := util.ParseCommunalStorageLocation(opt.CommunalStorageLocation)
+	if err == nil {
+		return location.Join(elems...)
+	}
+	// the location was not validated, join it as is
+	joinedPath := filepath.Join(append([]string{opt.CommunalStorageLocation}, elems...)...)
 	// filepath.Join() will change "://" of the remote communal storage path to ":/"
 	// as a result, we need to change the separator back to url format
-	descriptionFilePath = strings.Replace(descriptionFilePath, ":/", "://", 1)
-
-	return descriptionFilePath
+	return strings.Replace(joinedPath, ":/", "://", 1)
 }
 
 // getRestorePointConfigFilePath can make the restore point description file path using db name, archive name, restore point id,
@@ -393,31 +557,29 @@ func (options *VReviveDatabaseOptions) getRestorePointConfigFilePath(validatedRe
 	// description file will be in the location:
 	// {communal_storage_location}/metadata/{db_name}/archives/{archive_name}/{restore_point_id}/cluster_config.json
 	// an example: s3://tfminio/test_loc/metadata/test_db/archives/test_archive_name/2251e5cc-3e16-4fb1-8cd0-e4b8651f5779/cluster_config.json
-	descriptionFilePath := filepath.Join(options.CommunalStorageLocation, descriptionFileMetadataFolder,
+	descriptionFilePath := options.joinCommunalStorageLocation(descriptionFileMetadataFolder,
 		options.DBName, archivesFolder, options.RestorePoint.Archive, validatedRestorePointID, descriptionFileName)
-	// filepath.Join() will change "://" of the remote communal storage path to ":/"
-	// as a result, we need to change the separator back to url format
-	descriptionFilePath = strings.Replace(descriptionFilePath, ":/", "://", 1)
 
 	return descriptionFilePath
 }
 
 func (opt *DatabaseOptions) isSpreadEncryptionEnabled() (enabled bool, encryptionType string) {
-	const EncryptSpreadCommConfigName = "encryptspreadcomm"
 	// We cannot use the map lookup because the key name is case insensitive.
 	for key, val := range opt.ConfigurationParameters {
-		if strings.EqualFold(key, EncryptSpreadCommConfigName) {
+		if strings.EqualFold(key, encryptSpreadCommConfigName) {
 			return true, val
 		}
 	}
 	return false, ""
 }
 
-func (opt *DatabaseOptions) runClusterOpEngine(log vlog.Printer, instructions []clusterOp) error {
+// runClusterOpEngine runs the instructions in an engine of vcc, so that they
+// are traced and measured like the instructions of the other commands
+func (vcc VClusterCommands) runClusterOpEngine(opt *DatabaseOptions, instructions []clusterOp) error {
 	// Create a VClusterOpEngine, and add certs to the engine
-	certs := httpsCerts{key: opt.Key, cert: opt.Cert, caCert: opt.CaCert}
-	clusterOpEngine := makeClusterOpEngine(instructions, &certs)
+	certs := opt.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
 
 	// Give the instructions to the VClusterOpEngine to run
-	return clusterOpEngine.run(log)
+	return clusterOpEngine.run(vcc.Log)
 }
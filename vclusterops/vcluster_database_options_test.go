@@ -16,9 +16,11 @@
 package vclusterops
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops/util"
 )
 
 func TestGetDescriptionFilePath(t *testing.T) {
@@ -65,3 +67,58 @@ func TestGetDescriptionFilePath(t *testing.T) {
 	path = opt.getCurrConfigFilePath()
 	assert.Equal(t, targetGCPPath, path)
 }
+
+func TestValidateCommunalAuth(t *testing.T) {
+	opt := DatabaseOptionsFactory()
+	opt.CommunalStorageLocation = "s3://vertica-fleeting/k8s/revive_eon_5"
+	opt.ConfigurationParameters = map[string]string{"AWSAuth": "id:secret", "AWSRegion": "us-east-1"}
+
+	// AWS keys are allowed without the instance profile
+	assert.NoError(t, opt.validateCommunalAuth())
+
+	// AWS keys are rejected with the instance profile
+	opt.UseInstanceProfile = true
+	assert.ErrorContains(t, opt.validateCommunalAuth(), "awsauth")
+
+	// the keys can be omitted with the instance profile
+	delete(opt.ConfigurationParameters, "AWSAuth")
+	assert.NoError(t, opt.validateCommunalAuth())
+
+	// the instance profile is only for S3
+	opt.CommunalStorageLocation = "gs://vertica-fleeting/k8s/revive_eon_5"
+	assert.Error(t, opt.validateCommunalAuth())
+}
+
+func TestCommunalStorageParametersWithInstanceProfile(t *testing.T) {
+	opt := DatabaseOptionsFactory()
+	opt.CommunalStorageLocation = "s3://vertica-fleeting/k8s/revive_eon_5"
+	opt.ConfigurationParameters = map[string]string{"awsregion": "us-east-1"}
+	hosts := []string{"192.168.1.101"}
+	vdb := makeVCoordinationDatabase()
+
+	// without the instance profile, the parameters are sent as is
+	op, err := makeNMADownloadFileOp(hosts, "s3://vertica-fleeting/k8s/revive_eon_5/metadata/test_db/cluster_config.json",
+		"/tmp/desc.json", "/data", opt.getCommunalStorageParameters(), &vdb)
+	assert.NoError(t, err)
+	data := downloadFileRequestData{}
+	assert.NoError(t, json.Unmarshal([]byte(op.hostRequestBodyMap[hosts[0]]), &data))
+	assert.Equal(t, map[string]string{"awsregion": "us-east-1"}, data.Parameters)
+
+	// with the instance profile, the ops tell the server to use the IAM role
+	// of the hosts, and the options are not changed
+	opt.UseInstanceProfile = true
+	op, err = makeNMADownloadFileOp(hosts, "s3://vertica-fleeting/k8s/revive_eon_5/metadata/test_db/cluster_config.json",
+		"/tmp/desc.json", "/data", opt.getCommunalStorageParameters(), &vdb)
+	assert.NoError(t, err)
+	data = downloadFileRequestData{}
+	assert.NoError(t, json.Unmarshal([]byte(op.hostRequestBodyMap[hosts[0]]), &data))
+	assert.Equal(t, map[string]string{"awsregion": "us-east-1", util.AWSUseInstanceProfileKey: "1"}, data.Parameters)
+	assert.Equal(t, map[string]string{"awsregion": "us-east-1"}, opt.ConfigurationParameters)
+
+	uploadOp := makeNMAUploadCommunalFileOp(hosts, "s3://vertica-fleeting/k8s/revive_eon_5/hibernation.json", "{}",
+		opt.getCommunalStorageParameters())
+	assert.NoError(t, uploadOp.setupRequestBody())
+	uploadData := uploadFileRequestData{}
+	assert.NoError(t, json.Unmarshal([]byte(uploadOp.hostRequestBodyMap[hosts[0]]), &uploadData))
+	assert.Equal(t, "1", uploadData.Parameters[util.AWSUseInstanceProfileKey])
+}
@@ -27,6 +27,11 @@ type semVer struct {
 	Major string `json:"-"`
 	Minor string `json:"-"`
 	Patch string `json:"-"`
+	// optional pre-release tag, e.g., "0" in 24.3.0-0
+	PreRelease string `json:"-"`
+	// optional build metadata, e.g., "20240501" in 24.3.0+20240501. It is
+	// ignored when comparing versions.
+	Build string `json:"-"`
 }
 
 type VclusterOpVersion struct {
@@ -34,19 +39,34 @@ type VclusterOpVersion struct {
 	SemVer semVer
 }
 
+var semVerRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+([0-9A-Za-z.-]+))?$`)
+
 func (semVersion *semVer) parseComponentsIfNecessary() error {
 	cleanSize := strings.TrimSpace(semVersion.Ver)
-	r := regexp.MustCompile(`^(\d+)\.(\d+).(\d+)$`)
-	matches := r.FindAllStringSubmatch(cleanSize, -1)
+	matches := semVerRegexp.FindAllStringSubmatch(cleanSize, -1)
 	if len(matches) != 1 {
 		return fmt.Errorf("parse error for version %s: It is not a valid version", semVersion.Ver)
 	}
 	semVersion.Major = matches[0][1]
 	semVersion.Minor = matches[0][2]
 	semVersion.Patch = matches[0][3]
+	semVersion.PreRelease = matches[0][4]
+	semVersion.Build = matches[0][5]
 	return nil
 }
 
+// parseServerVersion builds a semVer from a Vertica version as returned by the
+// server, e.g., "Vertica Analytic Database v24.3.0-0" or "v24.2.0-<revision>"
+func parseServerVersion(versionStr string) (semVer, error) {
+	fields := strings.Fields(versionStr)
+	if len(fields) == 0 {
+		return semVer{}, fmt.Errorf("parse error for version %q: It is empty", versionStr)
+	}
+	ver := semVer{Ver: strings.TrimPrefix(fields[len(fields)-1], "v")}
+	err := ver.parseComponentsIfNecessary()
+	return ver, err
+}
+
 func (semVersion *semVer) incompatibleVersion(otherVer *semVer) (bool, error) {
 	err := semVersion.parseComponentsIfNecessary()
 	if err != nil {
@@ -65,6 +85,120 @@ func (semVersion *semVer) equalVersion(otherVer *semVer) bool {
 	return otherVer.Ver == semVersion.Ver
 }
 
+// compare returns -1, 0, or 1 if semVersion is lower than, equal to, or higher
+// than otherVer, following the SemVer precedence rules: a version with a
+// pre-release tag is lower than the same version without it, and the build
+// metadata is ignored.
+func (semVersion *semVer) compare(otherVer *semVer) (int, error) {
+	cmp, err := semVersion.compareCore(otherVer)
+	if err != nil || cmp != 0 {
+		return cmp, err
+	}
+	return comparePreRelease(semVersion.PreRelease, otherVer.PreRelease), nil
+}
+
+// compareCore is the same as compare, but only compares the major, minor, and
+// patch versions. Vertica hotfix builds, e.g., 24.3.0-1, are then considered
+// equal to their release.
+func (semVersion *semVer) compareCore(otherVer *semVer) (int, error) {
+	if err := semVersion.parseComponentsIfNecessary(); err != nil {
+		return 0, err
+	}
+	if err := otherVer.parseComponentsIfNecessary(); err != nil {
+		return 0, err
+	}
+	components := [][2]string{
+		{semVersion.Major, otherVer.Major},
+		{semVersion.Minor, otherVer.Minor},
+		{semVersion.Patch, otherVer.Patch},
+	}
+	for _, c := range components {
+		if cmp := compareNumericIdentifiers(c[0], c[1]); cmp != 0 {
+			return cmp, nil
+		}
+	}
+	return 0, nil
+}
+
+// less returns true if semVersion is lower than otherVer
+func (semVersion *semVer) less(otherVer *semVer) (bool, error) {
+	cmp, err := semVersion.compare(otherVer)
+	return cmp < 0, err
+}
+
+// greaterOrEqual returns true if semVersion is higher than or equal to otherVer
+func (semVersion *semVer) greaterOrEqual(otherVer *semVer) (bool, error) {
+	cmp, err := semVersion.compare(otherVer)
+	return cmp >= 0, err
+}
+
+// comparePreRelease compares two pre-release tags: the dot-separated
+// identifiers are compared one by one, numerically if they are both numeric,
+// and lexically otherwise. An empty tag, i.e., a release, is the highest.
+func comparePreRelease(pre, otherPre string) int {
+	if pre == otherPre {
+		return 0
+	}
+	if pre == "" {
+		return 1
+	}
+	if otherPre == "" {
+		return -1
+	}
+	ids := strings.Split(pre, ".")
+	otherIDs := strings.Split(otherPre, ".")
+	for i := 0; i < len(ids) && i < len(otherIDs); i++ {
+		id, otherID := ids[i], otherIDs[i]
+		isNum, otherIsNum := isNumericIdentifier(id), isNumericIdentifier(otherID)
+		var cmp int
+		switch {
+		case isNum && otherIsNum:
+			cmp = compareNumericIdentifiers(id, otherID)
+		case isNum:
+			// numeric identifiers are lower than alphanumeric ones
+			cmp = -1
+		case otherIsNum:
+			cmp = 1
+		default:
+			cmp = strings.Compare(id, otherID)
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	// a longer tag is higher if all the preceding identifiers are equal
+	switch {
+	case len(ids) < len(otherIDs):
+		return -1
+	case len(ids) > len(otherIDs):
+		return 1
+	}
+	return 0
+}
+
+func isNumericIdentifier(id string) bool {
+	for _, c := range id {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return id != ""
+}
+
+// compareNumericIdentifiers compares two strings of digits without converting
+// them, so that they cannot overflow
+func compareNumericIdentifiers(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
 func (opVersion *VclusterOpVersion) equalVclusterVersion(otherVer *VclusterOpVersion) bool {
 	return opVersion.Origin == otherVer.Origin && opVersion.SemVer.equalVersion(&otherVer.SemVer)
 }
@@ -91,3 +91,55 @@ func TestForVclusterVersionDict(t *testing.T) {
 	_, err := vclusterVersionFromDict(VclusterVersionDict)
 	assert.Error(t, err)
 }
+
+func TestSemVerComparison(t *testing.T) {
+	compare := func(a, b string) int {
+		v1 := &semVer{Ver: a}
+		v2 := &semVer{Ver: b}
+		cmp, err := v1.compare(v2)
+		assert.NoError(t, err)
+		return cmp
+	}
+	assert.Equal(t, 0, compare("24.3.0", "24.3.0"))
+	assert.Equal(t, -1, compare("24.2.1", "24.3.0"))
+	assert.Equal(t, 1, compare("24.10.0", "24.9.0"))
+	assert.Equal(t, 1, compare("25.0.0", "24.9.9"))
+
+	// pre-release tags are lower than the release
+	assert.Equal(t, -1, compare("24.3.0-0", "24.3.0"))
+	assert.Equal(t, -1, compare("24.3.0-1", "24.3.0-2"))
+	assert.Equal(t, -1, compare("24.3.0-2", "24.3.0-10"))
+	assert.Equal(t, -1, compare("1.0.0-alpha", "1.0.0-alpha.1"))
+	assert.Equal(t, -1, compare("1.0.0-alpha.1", "1.0.0-alpha.beta"))
+	assert.Equal(t, -1, compare("1.0.0-beta.11", "1.0.0-rc.1"))
+	// build metadata is ignored
+	assert.Equal(t, 0, compare("24.3.0+20240501", "24.3.0+20240601"))
+
+	v1 := &semVer{Ver: "24.2.0"}
+	v2 := &semVer{Ver: "24.3.0-0"}
+	less, err := v1.less(v2)
+	assert.NoError(t, err)
+	assert.True(t, less)
+	greaterOrEqual, err := v1.greaterOrEqual(v2)
+	assert.NoError(t, err)
+	assert.False(t, greaterOrEqual)
+
+	_, err = v1.compare(&semVer{Ver: "24.3"})
+	assert.Error(t, err)
+}
+
+func TestMinServerVersion(t *testing.T) {
+	op := opBase{name: "TestOp"}
+	// no minimum version
+	assert.NoError(t, op.checkMinServerVersion("Vertica Analytic Database v12.0.4"))
+
+	op.minServerVersion = "24.2.0"
+	assert.NoError(t, op.checkMinServerVersion("Vertica Analytic Database v24.2.0"))
+	// a hotfix of the minimum version is enough
+	assert.NoError(t, op.checkMinServerVersion("v24.2.0-1"))
+	assert.NoError(t, op.checkMinServerVersion("v24.3.0-a0efe9ba3abb08d9e6472ffc29c8e0949b5998d2"))
+	err := op.checkMinServerVersion("Vertica Analytic Database v24.1.0")
+	assert.ErrorContains(t, err, "requires Vertica version 24.2.0 or higher")
+	err = op.checkMinServerVersion("unknown")
+	assert.Error(t, err)
+}
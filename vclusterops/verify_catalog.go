@@ -0,0 +1,241 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vertica/vcluster/vclusterops/util"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+type VVerifyCatalogOptions struct {
+	DatabaseOptions
+	// Whether to sync vertica.conf and spread.conf from a host with the
+	// latest catalog to the hosts with stale catalogs. The database must be down.
+	Repair bool
+}
+
+// StaleCatalogNode describes a node whose catalog is behind the latest catalog
+type StaleCatalogNode struct {
+	Host          string `json:"host"`
+	NodeName      string `json:"node_name"`
+	GlobalVersion int64  `json:"global_version"`
+	SpreadVersion int64  `json:"spread_version"`
+}
+
+// CatalogVerificationReport is the result of VVerifyCatalog
+type CatalogVerificationReport struct {
+	LatestGlobalVersion    int64              `json:"latest_global_version"`
+	LatestSpreadVersion    int64              `json:"latest_spread_version"`
+	HostsWithLatestCatalog []string           `json:"hosts_with_latest_catalog"`
+	StaleNodes             []StaleCatalogNode `json:"stale_nodes"`
+	// whether the stale catalogs were repaired
+	Repaired bool `json:"repaired"`
+}
+
+func VVerifyCatalogOptionsFactory() VVerifyCatalogOptions {
+	options := VVerifyCatalogOptions{}
+	// set default values to the params
+	options.setDefaultValues()
+
+	return options
+}
+
+func (options *VVerifyCatalogOptions) setDefaultValues() {
+	options.DatabaseOptions.setDefaultValues()
+}
+
+func (options *VVerifyCatalogOptions) validateParseOptions(logger vlog.Printer) error {
+	err := options.validateBaseOptions(commandVerifyCatalog, logger)
+	if err != nil {
+		return err
+	}
+	// catalog prefix is needed to find the catalog of each node
+	return options.validateCatalogPath()
+}
+
+func (options *VVerifyCatalogOptions) analyzeOptions() (err error) {
+	// resolve RawHosts to be IP addresses
+	if len(options.RawHosts) > 0 {
+		options.Hosts, err = util.ResolveRawHostsToAddresses(options.RawHosts, options.IPv6)
+		if err != nil {
+			return err
+		}
+		options.normalizePaths()
+	}
+
+	return nil
+}
+
+func (options *VVerifyCatalogOptions) validateAnalyzeOptions(logger vlog.Printer) error {
+	if err := options.validateParseOptions(logger); err != nil {
+		return err
+	}
+	return options.analyzeOptions()
+}
+
+// VVerifyCatalog reads the catalog of every node through the NMA and reports
+// the nodes whose global or spread catalog version is behind the latest one.
+// If options.Repair is set, the config files of the latest catalog are synced
+// to the stale nodes.
+func (vcc VClusterCommands) VVerifyCatalog(options *VVerifyCatalogOptions) (_ CatalogVerificationReport, err error) {
+	defer vcc.audit(commandVerifyCatalog, &options.DatabaseOptions, options, time.Now(), &err)
+	/*
+	 *   - Validate Options
+	 *   - Read the catalog editor of every node
+	 *   - Find the stale catalogs
+	 *   - Optionally, sync the config files to the stale nodes
+	 */
+
+	var report CatalogVerificationReport
+	err = options.validateAnalyzeOptions(vcc.Log)
+	if err != nil {
+		return report, err
+	}
+
+	vdb := makeVCoordinationDatabase()
+	hostToVersions := make(map[string]nmaVersions)
+	nmaHealthOp := makeNMAHealthOp(options.Hosts)
+	nmaGetNodesInfoOp := makeNMAGetNodesInfoOp(options.Hosts, options.DBName, options.CatalogPrefix,
+		true /* ignore internal errors */, &vdb)
+	nmaReadCatalogEditorOp, err := makeNMAReadCatalogEditorOp(&vdb)
+	if err != nil {
+		return report, err
+	}
+	nmaReadCatalogEditorOp.hostToVersions = hostToVersions
+	instructions := []clusterOp{&nmaHealthOp, &nmaGetNodesInfoOp, &nmaReadCatalogEditorOp}
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return report, fmt.Errorf("fail to read the catalogs: %w", err)
+	}
+
+	hostToNodeName := make(map[string]string)
+	for host, vnode := range vdb.HostNodeMap {
+		hostToNodeName[host] = vnode.Name
+	}
+	report, err = buildCatalogVerificationReport(hostToVersions, hostToNodeName)
+	if err != nil {
+		return report, err
+	}
+	if len(report.StaleNodes) == 0 {
+		vcc.Log.PrintInfo("All nodes have the latest catalog")
+		return report, nil
+	}
+	vcc.Log.PrintWarning("Found %d node(s) with a stale catalog", len(report.StaleNodes))
+
+	if options.Repair {
+		err = vcc.repairStaleCatalogs(options, &vdb)
+		if err != nil {
+			return report, err
+		}
+		report.Repaired = true
+	}
+
+	return report, nil
+}
+
+// repairStaleCatalogs syncs vertica.conf and spread.conf from a host with the
+// latest catalog to the other hosts, as start_db does
+func (vcc VClusterCommands) repairStaleCatalogs(options *VVerifyCatalogOptions, vdb *VCoordinationDatabase) error {
+	err := options.setUsePassword(vcc.Log)
+	if err != nil {
+		return err
+	}
+	checkDBRunningOp, err := makeHTTPSCheckRunningDBOp(options.Hosts,
+		options.usePassword, options.UserName, options.Password, RepairCatalog)
+	if err != nil {
+		return err
+	}
+	nmaReadCatalogEditorOp, err := makeNMAReadCatalogEditorOp(vdb)
+	if err != nil {
+		return err
+	}
+	instructions := []clusterOp{&checkDBRunningOp, &nmaReadCatalogEditorOp}
+	produceTransferConfigOps(
+		&instructions,
+		nil, /*source hosts for transferring configuration files*/
+		options.Hosts,
+		nil /*db configurations retrieved from a running db*/)
+
+	certs := options.buildHTTPSCerts()
+	clusterOpEngine := vcc.makeClusterOpEngine(instructions, &certs)
+	err = clusterOpEngine.run(vcc.Log)
+	if err != nil {
+		return fmt.Errorf("fail to repair the stale catalogs: %w", err)
+	}
+	return nil
+}
+
+// buildCatalogVerificationReport finds the latest catalog and the hosts whose
+// catalog is behind it. As when start_db picks the catalog to start from, the
+// latest catalog is the one with the highest global version; the spread
+// version only decides between the catalogs with that global version. So a
+// node whose spread version is ahead of the latest catalog, but whose global
+// version is behind it, is stale.
+func buildCatalogVerificationReport(hostToVersions map[string]nmaVersions,
+	hostToNodeName map[string]string) (CatalogVerificationReport, error) {
+	report := CatalogVerificationReport{}
+	hostToNode := make(map[string]StaleCatalogNode, len(hostToVersions))
+	for host, versions := range hostToVersions {
+		globalVersion, err := versions.Global.Int64()
+		if err != nil {
+			return report, fmt.Errorf("fail to convert global version %q of host %s to integer: %w",
+				versions.Global, host, err)
+		}
+		spreadVersion, err := versions.Spread.Int64()
+		if err != nil {
+			return report, fmt.Errorf("fail to convert spread version %q of host %s to integer: %w",
+				versions.Spread, host, err)
+		}
+		hostToNode[host] = StaleCatalogNode{
+			Host:          host,
+			NodeName:      hostToNodeName[host],
+			GlobalVersion: globalVersion,
+			SpreadVersion: spreadVersion,
+		}
+		if globalVersion > report.LatestGlobalVersion {
+			report.LatestGlobalVersion = globalVersion
+		}
+	}
+	for _, node := range hostToNode {
+		if node.GlobalVersion == report.LatestGlobalVersion && node.SpreadVersion > report.LatestSpreadVersion {
+			report.LatestSpreadVersion = node.SpreadVersion
+		}
+	}
+
+	for host, node := range hostToNode {
+		if node.GlobalVersion < report.LatestGlobalVersion ||
+			(node.GlobalVersion == report.LatestGlobalVersion && node.SpreadVersion < report.LatestSpreadVersion) {
+			report.StaleNodes = append(report.StaleNodes, node)
+		} else {
+			report.HostsWithLatestCatalog = append(report.HostsWithLatestCatalog, host)
+		}
+	}
+	if len(report.HostsWithLatestCatalog) == 0 {
+		return report, fmt.Errorf("cannot find any host with the latest catalog")
+	}
+	sort.Strings(report.HostsWithLatestCatalog)
+	sort.Slice(report.StaleNodes, func(i, j int) bool {
+		return report.StaleNodes[i].Host < report.StaleNodes[j].Host
+	})
+	return report, nil
+}
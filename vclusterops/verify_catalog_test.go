@@ -0,0 +1,73 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCatalogVerificationReport(t *testing.T) {
+	hostToNodeName := map[string]string{
+		"192.168.1.101": "v_db_node0001",
+		"192.168.1.102": "v_db_node0002",
+		"192.168.1.103": "v_db_node0003",
+	}
+	hostToVersions := map[string]nmaVersions{
+		"192.168.1.101": {Global: "120", Spread: "8"},
+		"192.168.1.102": {Global: "120", Spread: "8"},
+		"192.168.1.103": {Global: "120", Spread: "8"},
+	}
+
+	// all nodes are up to date
+	report, err := buildCatalogVerificationReport(hostToVersions, hostToNodeName)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), report.LatestGlobalVersion)
+	assert.Equal(t, int64(8), report.LatestSpreadVersion)
+	assert.Equal(t, []string{"192.168.1.101", "192.168.1.102", "192.168.1.103"}, report.HostsWithLatestCatalog)
+	assert.Empty(t, report.StaleNodes)
+
+	// a node behind on the global version and another behind on the spread version
+	hostToVersions["192.168.1.102"] = nmaVersions{Global: "118", Spread: "8"}
+	hostToVersions["192.168.1.103"] = nmaVersions{Global: "120", Spread: "7"}
+	report, err = buildCatalogVerificationReport(hostToVersions, hostToNodeName)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.168.1.101"}, report.HostsWithLatestCatalog)
+	assert.Equal(t, []StaleCatalogNode{
+		{Host: "192.168.1.102", NodeName: "v_db_node0002", GlobalVersion: 118, SpreadVersion: 8},
+		{Host: "192.168.1.103", NodeName: "v_db_node0003", GlobalVersion: 120, SpreadVersion: 7},
+	}, report.StaleNodes)
+
+	// the catalog versions are skewed: the node with the highest global
+	// version has the latest catalog, even if its spread version is behind
+	hostToVersions["192.168.1.102"] = nmaVersions{Global: "118", Spread: "9"}
+	report, err = buildCatalogVerificationReport(hostToVersions, hostToNodeName)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), report.LatestGlobalVersion)
+	assert.Equal(t, int64(8), report.LatestSpreadVersion)
+	assert.Equal(t, []string{"192.168.1.101"}, report.HostsWithLatestCatalog)
+	assert.Len(t, report.StaleNodes, 2)
+
+	// no catalog was read
+	_, err = buildCatalogVerificationReport(map[string]nmaVersions{}, hostToNodeName)
+	assert.ErrorContains(t, err, "cannot find any host with the latest catalog")
+
+	// invalid version
+	hostToVersions["192.168.1.103"] = nmaVersions{Global: "abc", Spread: "8"}
+	_, err = buildCatalogVerificationReport(hostToVersions, hostToNodeName)
+	assert.ErrorContains(t, err, "fail to convert global version")
+}
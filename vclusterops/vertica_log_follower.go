@@ -0,0 +1,111 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vclusterops
+
+import (
+	"fmt"
+	"sort"
+)
+
+// the maximum number of bytes of vertica.log read from a node at once
+const verticaLogTailMaxBytes = 64 * 1024
+
+// verticaLogFollower prints the lines appended to vertica.log of starting
+// nodes, read from the NMA, so that startup failures can be diagnosed
+// without logging in to the hosts
+type verticaLogFollower struct {
+	// the database is read when following the logs, as the nodes can
+	// be re-ip'ed after the follower is created
+	vdb *VCoordinationDatabase
+	// host -> offset in vertica.log of the next line to read
+	offsets map[string]int64
+}
+
+type verticaLogTailResponse struct {
+	// offset after the last returned line
+	Offset int64    `json:"offset"`
+	Lines  []string `json:"lines"`
+}
+
+func makeVerticaLogFollower(vdb *VCoordinationDatabase) *verticaLogFollower {
+	return &verticaLogFollower{vdb: vdb, offsets: make(map[string]int64)}
+}
+
+// follow reads the new lines of vertica.log of the given hosts and prints them,
+// prefixed with the node names. The first read of a host returns the tail of
+// its log. Following the logs never fails the op: the errors are only logged.
+func (follower *verticaLogFollower) follow(op *opBase, execContext *opEngineExecContext, hosts []string) {
+	logRequest := clusterHTTPRequest{Name: op.name, SemVar: op.clusterHTTPRequest.SemVar}
+	logRequest.RequestCollection = make(map[string]hostHTTPRequest)
+	for _, host := range hosts {
+		node, ok := follower.vdb.HostNodeMap[host]
+		if !ok || node.CatalogPath == "" {
+			continue
+		}
+		// reuse the certificates loaded in the request of the op
+		opRequest := op.clusterHTTPRequest.RequestCollection[host]
+		httpRequest := hostHTTPRequest{
+			Method:            GetMethod,
+			UseCertsInOptions: opRequest.UseCertsInOptions,
+			Certs:             opRequest.Certs,
+			TLSVerify:         opRequest.TLSVerify,
+		}
+		httpRequest.buildNMAEndpoint("logs/vertica/tail")
+		httpRequest.QueryParams = map[string]string{
+			"catalog_path": node.CatalogPath,
+			"max_bytes":    fmt.Sprint(verticaLogTailMaxBytes),
+		}
+		if offset, found := follower.offsets[host]; found {
+			httpRequest.QueryParams["offset"] = fmt.Sprint(offset)
+		}
+		logRequest.RequestCollection[host] = httpRequest
+	}
+	if len(logRequest.RequestCollection) == 0 {
+		return
+	}
+
+	if err := execContext.dispatcher.sendRequest(&logRequest, nil); err != nil {
+		op.logger.Info("fail to read vertica.log", "details", err)
+		return
+	}
+	follower.printResults(op, logRequest.ResultCollection)
+}
+
+func (follower *verticaLogFollower) printResults(op *opBase, results map[string]hostHTTPResult) {
+	hosts := make([]string, 0, len(results))
+	for host := range results {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		result := results[host]
+		if !result.isPassing() {
+			op.logger.Info("fail to read vertica.log", "host", host, "details", result.err)
+			continue
+		}
+		var resp verticaLogTailResponse
+		if err := op.parseAndCheckResponse(host, result.content, &resp); err != nil {
+			op.logger.Info("fail to parse the lines of vertica.log", "host", host, "details", err)
+			continue
+		}
+		follower.offsets[host] = resp.Offset
+		nodeName := follower.vdb.HostNodeMap[host].Name
+		for _, line := range resp.Lines {
+			op.logger.PrintInfo("[%s] %s", nodeName, line)
+		}
+	}
+}
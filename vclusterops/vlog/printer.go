@@ -24,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 const (
@@ -54,6 +55,17 @@ func (p *Printer) WithName(logName string) Printer {
 	}
 }
 
+// WithValues will construct a new printer with the logger set with additional
+// key/value pairs, which are added to every message it logs. The new printer
+// inherits state from the current Printer.
+func (p *Printer) WithValues(keysAndValues ...any) Printer {
+	return Printer{
+		Log:           p.Log.WithValues(keysAndValues...),
+		LogToFileOnly: p.LogToFileOnly,
+		ForCli:        p.ForCli,
+	}
+}
+
 // Reimplement the logr APIs that we use. These are simple pass through functions to the logr object.
 
 // V sets the logging level. Can be daisy-chained to produce a log message for
@@ -150,7 +162,8 @@ func logMaskedArgParseHelper(inputArgv []string) (maskedPairs []string) {
 	}
 	// some params have simple value format v
 	targetMaskedSimpleArg := map[string]bool{
-		"--password": true,
+		"--password":     true,
+		"--access-token": true,
 	}
 
 	for i := 0; i < len(inputArgv); i++ {
@@ -182,15 +195,129 @@ func logMaskedArgParseHelper(inputArgv []string) (maskedPairs []string) {
 	return maskedPairs
 }
 
-// setupOrDie will setup the logging for vcluster CLI. On exit, p.Log will
+// Log formats
+const (
+	ConsoleFormat = "console"
+	JSONFormat    = "json"
+)
+
+// Log levels
+const (
+	DebugLevel   = "debug"
+	InfoLevel    = "info"
+	WarningLevel = "warn"
+	ErrorLevel   = "error"
+)
+
+// Log outputs, used when a log file is given
+const (
+	FileOutput   = "file"
+	StdoutOutput = "stdout"
+	BothOutput   = "both"
+)
+
+// LogOptions configures the logger built by Setup
+type LogOptions struct {
+	// Path of the log file. If empty, the logs are written to stderr.
+	LogFile string
+	// Encoding of the log entries: console (default) or json. The json
+	// format can be ingested by log collectors like Loki or Splunk.
+	Format string
+	// Minimum level of the logged messages: debug, info (default), warn or error
+	Level string
+	// Where the logs go when a log file is given: file (default), stdout or both
+	Output string
+}
+
+// SetupOrDie will setup the logging for vcluster CLI. On exit, p.Log will
 // be set.
 func (p *Printer) SetupOrDie(logFile string) {
+	p.SetupWithOptionsOrDie(LogOptions{LogFile: logFile})
+}
+
+// SetupWithOptionsOrDie is the same as SetupOrDie, but lets the caller
+// configure the format, level, and output of the logs
+func (p *Printer) SetupWithOptionsOrDie(opts LogOptions) {
+	err := p.Setup(opts)
+	if err != nil {
+		fmt.Printf("Failed to setup the logger: %s", err.Error())
+		os.Exit(1)
+	}
+}
+
+// Setup will setup the logging with the given options. On success, p.Log will
+// be set.
+func (p *Printer) Setup(opts LogOptions) error {
+	cfg, err := buildZapConfig(opts)
+	if err != nil {
+		return err
+	}
+	// messages printed to the console are not repeated in the log
+	// if the log is already written to the console
+	p.LogToFileOnly = opts.LogFile != "" && (opts.Output == "" || opts.Output == FileOutput)
+
 	// The vcluster library uses logr as the logging API. We use Uber's zap
 	// package to implement the logging API.
-	EncoderConfigWithoutCaller := zap.NewDevelopmentEncoderConfig()
-	EncoderConfigWithoutCaller.EncodeCaller = nil // Set EncodeCaller to nil to exclude caller information
+	zapLg, err := cfg.Build()
+	if err != nil {
+		return err
+	}
+	p.Log = zapr.NewLogger(zapLg)
+	p.Log.Info("Successfully started logger", "logFile", opts.LogFile,
+		"format", cfg.Encoding, "level", cfg.Level.String())
+	return nil
+}
+
+func buildZapConfig(opts LogOptions) (*zap.Config, error) {
+	var encoderConfig zapcore.EncoderConfig
+	switch opts.Format {
+	case "", ConsoleFormat:
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeCaller = nil // Set EncodeCaller to nil to exclude caller information
+		opts.Format = ConsoleFormat
+	case JSONFormat:
+		encoderConfig = zap.NewProductionEncoderConfig()
+		encoderConfig.CallerKey = zapcore.OmitKey // exclude caller information
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.EncodeDuration = zapcore.StringDurationEncoder
+	default:
+		return nil, fmt.Errorf("invalid log format %q, must be one of %s, %s",
+			opts.Format, ConsoleFormat, JSONFormat)
+	}
+
+	var level zapcore.Level
+	switch opts.Level {
+	case "", InfoLevel:
+		level = zap.InfoLevel
+	case DebugLevel:
+		level = zap.DebugLevel
+	case WarningLevel:
+		level = zap.WarnLevel
+	case ErrorLevel:
+		level = zap.ErrorLevel
+	default:
+		return nil, fmt.Errorf("invalid log level %q, must be one of %s, %s, %s, %s",
+			opts.Level, DebugLevel, InfoLevel, WarningLevel, ErrorLevel)
+	}
+
+	// If no log file is given, we just log to standard error
+	outputPaths := []string{"stderr"}
+	if opts.LogFile != "" {
+		switch opts.Output {
+		case "", FileOutput:
+			outputPaths = []string{opts.LogFile}
+		case StdoutOutput:
+			outputPaths = []string{"stdout"}
+		case BothOutput:
+			outputPaths = []string{opts.LogFile, "stdout"}
+		default:
+			return nil, fmt.Errorf("invalid log output %q, must be one of %s, %s, %s",
+				opts.Output, FileOutput, StdoutOutput, BothOutput)
+		}
+	}
+
 	cfg := zap.Config{
-		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		Level:       zap.NewAtomicLevelAt(level),
 		Development: false,
 		// Sampling is enabled at 100:100, meaning that after the first 100 log
 		// entries with the same level and message in the same second, it will
@@ -199,23 +326,12 @@ func (p *Printer) SetupOrDie(logFile string) {
 			Initial:    100,
 			Thereafter: 100,
 		},
-		Encoding:         "console",
-		EncoderConfig:    EncoderConfigWithoutCaller,
-		OutputPaths:      []string{"stderr"},
+		Encoding:         opts.Format,
+		EncoderConfig:    encoderConfig,
+		OutputPaths:      outputPaths,
 		ErrorOutputPaths: []string{"stderr"},
 	}
-	// If no log file is given, we just log to standard output
-	if logFile != "" {
-		p.LogToFileOnly = true
-		cfg.OutputPaths = []string{logFile}
-	}
-	zapLg, err := cfg.Build()
-	if err != nil {
-		fmt.Printf("Failed to setup the logger: %s", err.Error())
-		os.Exit(1)
-	}
-	p.Log = zapr.NewLogger(zapLg)
-	p.Log.Info("Successfully started logger", "logFile", logFile)
+	return &cfg, nil
 }
 
 func isVerboseOutputEnabled() bool {
@@ -16,8 +16,11 @@
 package vlog
 
 import (
+	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -46,9 +49,50 @@ func TestPasswordRedaction(t *testing.T) {
 	assert.Len(t, maskedArgs, 2)
 	assert.NotEqual(t, pw, maskedArgs[1])
 
+	// test access token redaction
+	maskedArgs = logMaskedArgParseHelper([]string{"--access-token", pw})
+	assert.Len(t, maskedArgs, 2)
+	assert.NotEqual(t, pw, maskedArgs[1])
+
 	// test non-sensitive is not redacted
 	argv := []string{"--nothing-secret", pw}
 	unmaskedArgs := logMaskedArgParseHelper(argv)
 	assert.Len(t, unmaskedArgs, 2)
 	assert.Equal(t, pw, unmaskedArgs[1])
 }
+
+func TestSetupWithOptions(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "vcluster.log")
+	p := Printer{}
+	err := p.Setup(LogOptions{LogFile: logFile, Format: JSONFormat, Level: DebugLevel})
+	assert.NoError(t, err)
+	assert.True(t, p.LogToFileOnly)
+
+	// the op name and the host are structured fields of the json log
+	namedLogger := p.WithName("NMAHealthOp")
+	opLogger := namedLogger.WithValues("op", "NMAHealthOp")
+	opLogger.Info("Request succeeded", "host", "192.0.2.1")
+	opLogger.V(1).Info("debug message")
+	content, err := os.ReadFile(logFile)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 3)
+	entry := map[string]any{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "Request succeeded", entry["msg"])
+	assert.Equal(t, "NMAHealthOp", entry["op"])
+	assert.Equal(t, "192.0.2.1", entry["host"])
+
+	// logs written to stdout are not repeated in the console
+	err = p.Setup(LogOptions{LogFile: logFile, Output: BothOutput})
+	assert.NoError(t, err)
+	assert.False(t, p.LogToFileOnly)
+
+	// invalid options
+	err = p.Setup(LogOptions{Format: "xml"})
+	assert.ErrorContains(t, err, `invalid log format "xml"`)
+	err = p.Setup(LogOptions{Level: "trace"})
+	assert.ErrorContains(t, err, `invalid log level "trace"`)
+	err = p.Setup(LogOptions{LogFile: logFile, Output: "syslog"})
+	assert.ErrorContains(t, err, `invalid log output "syslog"`)
+}
@@ -0,0 +1,800 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+// Package vmock provides a mock of the vclusterops commands, so that the
+// callers of vclusterops can unit test their code without a real cluster.
+// Set the function of a command, e.g., VAddNodeFn, to choose its result. The
+// commands without a function succeed and return zero values.
+package vmock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vertica/vcluster/vclusterops"
+	"github.com/vertica/vcluster/vclusterops/vlog"
+)
+
+// Call is a call to a command of the mock
+type Call struct {
+	Method  string
+	Options any
+}
+
+// ClusterCommands is a mock of vclusterops.VClusterCommands. It is safe
+// for concurrent use.
+type ClusterCommands struct {
+	vclusterops.VClusterCommandsLogger
+
+	mu    sync.Mutex
+	calls []Call
+	// the context given to WithContext
+	Ctx context.Context
+
+	VAddNodeFn                          func(options *vclusterops.VAddNodeOptions) (vclusterops.VCoordinationDatabase, error)
+	VStopNodeFn                         func(options *vclusterops.VStopNodeOptions) error
+	VAddSubclusterFn                    func(options *vclusterops.VAddSubclusterOptions) error
+	VCreateDatabaseFn                   func(options *vclusterops.VCreateDatabaseOptions) (vclusterops.VCoordinationDatabase, error)
+	VDropDatabaseFn                     func(options *vclusterops.VDropDatabaseOptions) error
+	VFetchNodeStateFn                   func(options *vclusterops.VFetchNodeStateOptions) ([]vclusterops.NodeInfo, error)
+	VInstallPackagesFn                  func(options *vclusterops.VInstallPackagesOptions) (*vclusterops.InstallPackageStatus, error)
+	VReIPFn                             func(options *vclusterops.VReIPOptions) error
+	VRemoveNodeFn                       func(options *vclusterops.VRemoveNodeOptions) (vclusterops.VCoordinationDatabase, error)
+	VReplaceNodeFn                      func(options *vclusterops.VReplaceNodeOptions) (vclusterops.VCoordinationDatabase, error)
+	VRemoveSubclusterFn                 func(options *vclusterops.VRemoveScOptions) (vclusterops.VCoordinationDatabase, error)
+	VReviveDatabaseFn                   func(options *vclusterops.VReviveDatabaseOptions) (string, *vclusterops.VCoordinationDatabase, error)
+	VDescribeCommunalDatabaseFn         func(options *vclusterops.VReviveDatabaseOptions) (*vclusterops.DatabaseDescription, error)
+	VSandboxFn                          func(options *vclusterops.VSandboxOptions) error
+	VShowSandboxesFn                    func(options *vclusterops.VShowSandboxesOptions) ([]vclusterops.SandboxInfo, error)
+	VScrutinizeFn                       func(options *vclusterops.VScrutinizeOptions) error
+	VShowRestorePointsFn                func(options *vclusterops.VShowRestorePointsOptions) ([]vclusterops.RestorePoint, error)
+	VCreateArchiveFn                    func(options *vclusterops.VCreateArchiveOptions) error
+	VRemoveArchiveFn                    func(options *vclusterops.VRemoveArchiveOptions) error
+	VStartDatabaseFn                    func(options *vclusterops.VStartDatabaseOptions) (*vclusterops.VCoordinationDatabase, error)
+	VStartNodesFn                       func(options *vclusterops.VStartNodesOptions) error
+	VRestartNodeFn                      func(options *vclusterops.VRestartNodeOptions) error
+	VStartSubclusterFn                  func(options *vclusterops.VStartScOptions) error
+	VStopDatabaseFn                     func(options *vclusterops.VStopDatabaseOptions) error
+	VReplicateDatabaseFn                func(options *vclusterops.VReplicationDatabaseOptions) error
+	VFetchCoordinationDatabaseFn        func(options *vclusterops.VFetchCoordinationDatabaseOptions) (vclusterops.VCoordinationDatabase, error)
+	VUnsandboxFn                        func(options *vclusterops.VUnsandboxOptions) error
+	VStopSubclusterFn                   func(options *vclusterops.VStopSubclusterOptions) error
+	VAlterSubclusterTypeFn              func(options *vclusterops.VAlterSubclusterTypeOptions) error
+	VAlterNodeTypeFn                    func(options *vclusterops.VAlterNodeTypeOptions) error
+	VAlterLoadBalanceGroupFn            func(options *vclusterops.VLoadBalanceGroupOptions) error
+	VAlterRoutingRuleFn                 func(options *vclusterops.VRoutingRuleOptions) error
+	VGetConfigurationParameterFn        func(options *vclusterops.VGetConfigurationParameterOptions) (vclusterops.ConfigParameterInfo, error)
+	VSetConfigurationParameterFn        func(options *vclusterops.VSetConfigurationParameterOptions) (vclusterops.ConfigParameterChange, error)
+	VAlterSpreadEncryptionFn            func(options *vclusterops.VSpreadEncryptionOptions) error
+	VRotateDBPasswordFn                 func(options *vclusterops.VRotateDBPasswordOptions) error
+	VManageNMAFn                        func(options *vclusterops.VManageNMAOptions) error
+	VRealignControlNodesFn              func(options *vclusterops.VRealignControlNodesOptions) (map[string]string, error)
+	VSendCustomRequestFn                func(options *vclusterops.VCustomRequestOptions) (map[string]vclusterops.CustomRequestResult, error)
+	VForceRestartDatabaseFn             func(options *vclusterops.VForceRestartDatabaseOptions) ([]string, error)
+	VSyncCatalogFn                      func(options *vclusterops.VCatalogTruncationVersionOptions) (int64, error)
+	VGetCatalogTruncationVersionFn      func(options *vclusterops.VCatalogTruncationVersionOptions) (int64, error)
+	VCollectLogsFn                      func(options *vclusterops.VCollectLogsOptions) (string, error)
+	VDiffTopologyFn                     func(options *vclusterops.VDiffTopologyOptions) (vclusterops.VTopologyDiff, error)
+	VFetchRunningCoordinationDatabaseFn func(options *vclusterops.VFetchCoordinationDatabaseOptions) (vclusterops.VCoordinationDatabase, error)
+	VHibernateDatabaseFn                func(options *vclusterops.VHibernateDatabaseOptions) error
+	VWakeDatabaseFn                     func(options *vclusterops.VWakeDatabaseOptions) (*vclusterops.VCoordinationDatabase, error)
+	VPauseSubclusterFn                  func(options *vclusterops.VPauseSubclusterOptions) (vclusterops.VCoordinationDatabase, error)
+	VScaleSubclusterFn                  func(options *vclusterops.VScaleSubclusterOptions) (vclusterops.VScaleSubclusterResult, error)
+	VFetchDatabaseInfoFn                func(options *vclusterops.VFetchDatabaseInfoOptions) (vclusterops.VDatabaseInfo, error)
+	VDistributeFileFn                   func(options *vclusterops.VDistributeFileOptions) (vclusterops.VDistributeFileResult, error)
+	VPollSubscriptionStateFn            func(options *vclusterops.VPollSubscriptionStateOptions) (vclusterops.VSubscriptionState, error)
+	VListSessionsFn                     func(options *vclusterops.VListSessionsOptions) ([]vclusterops.SessionInfo, error)
+	VCloseSessionsFn                    func(options *vclusterops.VCloseSessionsOptions) ([]vclusterops.SessionInfo, error)
+	VRollingUpgradeFn                   func(options *vclusterops.VRollingUpgradeOptions) error
+	VCheckPrerequisitesFn               func(options *vclusterops.VCheckPrerequisitesOptions) (vclusterops.PrerequisiteReport, error)
+	VCheckLicenseComplianceFn           func(options *vclusterops.VCheckLicenseComplianceOptions) (vclusterops.LicenseComplianceReport, error)
+	VListSubclustersFn                  func(options *vclusterops.VListSubclustersOptions) ([]vclusterops.SubclusterDetails, error)
+	VRenameSubclusterFn                 func(options *vclusterops.VRenameSubclusterOptions) error
+	VFetchNodesDetailsFn                func(options *vclusterops.VFetchNodesDetailsOptions) (vclusterops.NodesDetails, error)
+	VCheckVClusterServerPidFn           func(options *vclusterops.VCheckVClusterServerPidOptions) ([]vclusterops.HostProcesses, error)
+	VKillVerticaFn                      func(options *vclusterops.VKillVerticaOptions) error
+	VVerifyCatalogFn                    func(options *vclusterops.VVerifyCatalogOptions) (vclusterops.CatalogVerificationReport, error)
+	VAlterDepotSizeFn                   func(options *vclusterops.VAlterDepotSizeOptions) (map[string]string, error)
+	VAlterStorageLocationFn             func(options *vclusterops.VAlterStorageLocationOptions) error
+	VSetKSafetyFn                       func(options *vclusterops.VSetKSafetyOptions) error
+	VGetKSafetyFn                       func(options *vclusterops.VGetKSafetyOptions) (vclusterops.KSafetyInfo, error)
+	VRebalanceShardsFn                  func(options *vclusterops.VRebalanceShardsOptions) error
+	VRebalanceClusterFn                 func(options *vclusterops.VRebalanceClusterOptions) error
+	VManageConnectionDrainingFn         func(options *vclusterops.VManageConnectionDrainingOptions) error
+}
+
+// check at compile time that the mock implements all the versions
+var _ vclusterops.ClusterCommandsV1 = &ClusterCommands{}
+var _ vclusterops.ClusterCommandsV2 = &ClusterCommands{}
+
+// MakeClusterCommands creates a mock whose logs are discarded
+func MakeClusterCommands() *ClusterCommands {
+	return &ClusterCommands{VClusterCommandsLogger: vclusterops.VClusterCommandsLogger{Log: vlog.Printer{}}}
+}
+
+func (m *ClusterCommands) recordCall(method string, options any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Options: options})
+}
+
+// Calls returns the calls to the commands, in order
+func (m *ClusterCommands) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call{}, m.calls...)
+}
+
+// CallsOf returns the options of the calls to a command, in order
+func (m *ClusterCommands) CallsOf(method string) []any {
+	var options []any
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			options = append(options, call.Options)
+		}
+	}
+	return options
+}
+
+// APIVersion returns the version of the interface that the mock implements
+func (m *ClusterCommands) APIVersion() int {
+	return vclusterops.ClusterCommandsAPIVersion
+}
+
+// WithContext stores ctx in the mock and returns the mock itself
+func (m *ClusterCommands) WithContext(ctx context.Context) vclusterops.ClusterCommandsV1 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Ctx = ctx
+	return m
+}
+
+// VAddNode records the call and calls VAddNodeFn if it is set
+func (m *ClusterCommands) VAddNode(options *vclusterops.VAddNodeOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VAddNode", options)
+	if m.VAddNodeFn != nil {
+		return m.VAddNodeFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VStopNode records the call and calls VStopNodeFn if it is set
+func (m *ClusterCommands) VStopNode(options *vclusterops.VStopNodeOptions) error {
+	m.recordCall("VStopNode", options)
+	if m.VStopNodeFn != nil {
+		return m.VStopNodeFn(options)
+	}
+	return nil
+}
+
+// VAddSubcluster records the call and calls VAddSubclusterFn if it is set
+func (m *ClusterCommands) VAddSubcluster(options *vclusterops.VAddSubclusterOptions) error {
+	m.recordCall("VAddSubcluster", options)
+	if m.VAddSubclusterFn != nil {
+		return m.VAddSubclusterFn(options)
+	}
+	return nil
+}
+
+// VCreateDatabase records the call and calls VCreateDatabaseFn if it is set
+func (m *ClusterCommands) VCreateDatabase(options *vclusterops.VCreateDatabaseOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VCreateDatabase", options)
+	if m.VCreateDatabaseFn != nil {
+		return m.VCreateDatabaseFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VDropDatabase records the call and calls VDropDatabaseFn if it is set
+func (m *ClusterCommands) VDropDatabase(options *vclusterops.VDropDatabaseOptions) error {
+	m.recordCall("VDropDatabase", options)
+	if m.VDropDatabaseFn != nil {
+		return m.VDropDatabaseFn(options)
+	}
+	return nil
+}
+
+// VFetchNodeState records the call and calls VFetchNodeStateFn if it is set
+func (m *ClusterCommands) VFetchNodeState(options *vclusterops.VFetchNodeStateOptions) ([]vclusterops.NodeInfo, error) {
+	m.recordCall("VFetchNodeState", options)
+	if m.VFetchNodeStateFn != nil {
+		return m.VFetchNodeStateFn(options)
+	}
+	return nil, nil
+}
+
+// VInstallPackages records the call and calls VInstallPackagesFn if it is set
+func (m *ClusterCommands) VInstallPackages(options *vclusterops.VInstallPackagesOptions) (*vclusterops.InstallPackageStatus, error) {
+	m.recordCall("VInstallPackages", options)
+	if m.VInstallPackagesFn != nil {
+		return m.VInstallPackagesFn(options)
+	}
+	return nil, nil
+}
+
+// VReIP records the call and calls VReIPFn if it is set
+func (m *ClusterCommands) VReIP(options *vclusterops.VReIPOptions) error {
+	m.recordCall("VReIP", options)
+	if m.VReIPFn != nil {
+		return m.VReIPFn(options)
+	}
+	return nil
+}
+
+// VRemoveNode records the call and calls VRemoveNodeFn if it is set
+func (m *ClusterCommands) VRemoveNode(options *vclusterops.VRemoveNodeOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VRemoveNode", options)
+	if m.VRemoveNodeFn != nil {
+		return m.VRemoveNodeFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VReplaceNode records the call and calls VReplaceNodeFn if it is set
+func (m *ClusterCommands) VReplaceNode(options *vclusterops.VReplaceNodeOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VReplaceNode", options)
+	if m.VReplaceNodeFn != nil {
+		return m.VReplaceNodeFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VRemoveSubcluster records the call and calls VRemoveSubclusterFn if it is set
+func (m *ClusterCommands) VRemoveSubcluster(options *vclusterops.VRemoveScOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VRemoveSubcluster", options)
+	if m.VRemoveSubclusterFn != nil {
+		return m.VRemoveSubclusterFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VReviveDatabase records the call and calls VReviveDatabaseFn if it is set
+func (m *ClusterCommands) VReviveDatabase(options *vclusterops.VReviveDatabaseOptions) (string, *vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VReviveDatabase", options)
+	if m.VReviveDatabaseFn != nil {
+		return m.VReviveDatabaseFn(options)
+	}
+	return "", nil, nil
+}
+
+// VDescribeCommunalDatabase records the call and calls VDescribeCommunalDatabaseFn if it is set
+func (m *ClusterCommands) VDescribeCommunalDatabase(options *vclusterops.VReviveDatabaseOptions) (*vclusterops.DatabaseDescription, error) {
+	m.recordCall("VDescribeCommunalDatabase", options)
+	if m.VDescribeCommunalDatabaseFn != nil {
+		return m.VDescribeCommunalDatabaseFn(options)
+	}
+	return nil, nil
+}
+
+// VSandbox records the call and calls VSandboxFn if it is set
+func (m *ClusterCommands) VSandbox(options *vclusterops.VSandboxOptions) error {
+	m.recordCall("VSandbox", options)
+	if m.VSandboxFn != nil {
+		return m.VSandboxFn(options)
+	}
+	return nil
+}
+
+// VShowSandboxes records the call and calls VShowSandboxesFn if it is set
+func (m *ClusterCommands) VShowSandboxes(options *vclusterops.VShowSandboxesOptions) ([]vclusterops.SandboxInfo, error) {
+	m.recordCall("VShowSandboxes", options)
+	if m.VShowSandboxesFn != nil {
+		return m.VShowSandboxesFn(options)
+	}
+	return nil, nil
+}
+
+// VScrutinize records the call and calls VScrutinizeFn if it is set
+func (m *ClusterCommands) VScrutinize(options *vclusterops.VScrutinizeOptions) error {
+	m.recordCall("VScrutinize", options)
+	if m.VScrutinizeFn != nil {
+		return m.VScrutinizeFn(options)
+	}
+	return nil
+}
+
+// VShowRestorePoints records the call and calls VShowRestorePointsFn if it is set
+func (m *ClusterCommands) VShowRestorePoints(options *vclusterops.VShowRestorePointsOptions) ([]vclusterops.RestorePoint, error) {
+	m.recordCall("VShowRestorePoints", options)
+	if m.VShowRestorePointsFn != nil {
+		return m.VShowRestorePointsFn(options)
+	}
+	return nil, nil
+}
+
+// VCreateArchive records the call and calls VCreateArchiveFn if it is set
+func (m *ClusterCommands) VCreateArchive(options *vclusterops.VCreateArchiveOptions) error {
+	m.recordCall("VCreateArchive", options)
+	if m.VCreateArchiveFn != nil {
+		return m.VCreateArchiveFn(options)
+	}
+	return nil
+}
+
+// VRemoveArchive records the call and calls VRemoveArchiveFn if it is set
+func (m *ClusterCommands) VRemoveArchive(options *vclusterops.VRemoveArchiveOptions) error {
+	m.recordCall("VRemoveArchive", options)
+	if m.VRemoveArchiveFn != nil {
+		return m.VRemoveArchiveFn(options)
+	}
+	return nil
+}
+
+// VStartDatabase records the call and calls VStartDatabaseFn if it is set
+func (m *ClusterCommands) VStartDatabase(options *vclusterops.VStartDatabaseOptions) (*vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VStartDatabase", options)
+	if m.VStartDatabaseFn != nil {
+		return m.VStartDatabaseFn(options)
+	}
+	return nil, nil
+}
+
+// VStartNodes records the call and calls VStartNodesFn if it is set
+func (m *ClusterCommands) VStartNodes(options *vclusterops.VStartNodesOptions) error {
+	m.recordCall("VStartNodes", options)
+	if m.VStartNodesFn != nil {
+		return m.VStartNodesFn(options)
+	}
+	return nil
+}
+
+// VRestartNode records the call and calls VRestartNodeFn if it is set
+func (m *ClusterCommands) VRestartNode(options *vclusterops.VRestartNodeOptions) error {
+	m.recordCall("VRestartNode", options)
+	if m.VRestartNodeFn != nil {
+		return m.VRestartNodeFn(options)
+	}
+	return nil
+}
+
+// VStartSubcluster records the call and calls VStartSubclusterFn if it is set
+func (m *ClusterCommands) VStartSubcluster(options *vclusterops.VStartScOptions) error {
+	m.recordCall("VStartSubcluster", options)
+	if m.VStartSubclusterFn != nil {
+		return m.VStartSubclusterFn(options)
+	}
+	return nil
+}
+
+// VStopDatabase records the call and calls VStopDatabaseFn if it is set
+func (m *ClusterCommands) VStopDatabase(options *vclusterops.VStopDatabaseOptions) error {
+	m.recordCall("VStopDatabase", options)
+	if m.VStopDatabaseFn != nil {
+		return m.VStopDatabaseFn(options)
+	}
+	return nil
+}
+
+// VReplicateDatabase records the call and calls VReplicateDatabaseFn if it is set
+func (m *ClusterCommands) VReplicateDatabase(options *vclusterops.VReplicationDatabaseOptions) error {
+	m.recordCall("VReplicateDatabase", options)
+	if m.VReplicateDatabaseFn != nil {
+		return m.VReplicateDatabaseFn(options)
+	}
+	return nil
+}
+
+// VFetchCoordinationDatabase records the call and calls VFetchCoordinationDatabaseFn if it is set
+func (m *ClusterCommands) VFetchCoordinationDatabase(
+	options *vclusterops.VFetchCoordinationDatabaseOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VFetchCoordinationDatabase", options)
+	if m.VFetchCoordinationDatabaseFn != nil {
+		return m.VFetchCoordinationDatabaseFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VUnsandbox records the call and calls VUnsandboxFn if it is set
+func (m *ClusterCommands) VUnsandbox(options *vclusterops.VUnsandboxOptions) error {
+	m.recordCall("VUnsandbox", options)
+	if m.VUnsandboxFn != nil {
+		return m.VUnsandboxFn(options)
+	}
+	return nil
+}
+
+// VStopSubcluster records the call and calls VStopSubclusterFn if it is set
+func (m *ClusterCommands) VStopSubcluster(options *vclusterops.VStopSubclusterOptions) error {
+	m.recordCall("VStopSubcluster", options)
+	if m.VStopSubclusterFn != nil {
+		return m.VStopSubclusterFn(options)
+	}
+	return nil
+}
+
+// VAlterSubclusterType records the call and calls VAlterSubclusterTypeFn if it is set
+func (m *ClusterCommands) VAlterSubclusterType(options *vclusterops.VAlterSubclusterTypeOptions) error {
+	m.recordCall("VAlterSubclusterType", options)
+	if m.VAlterSubclusterTypeFn != nil {
+		return m.VAlterSubclusterTypeFn(options)
+	}
+	return nil
+}
+
+// VAlterNodeType records the call and calls VAlterNodeTypeFn if it is set
+func (m *ClusterCommands) VAlterNodeType(options *vclusterops.VAlterNodeTypeOptions) error {
+	m.recordCall("VAlterNodeType", options)
+	if m.VAlterNodeTypeFn != nil {
+		return m.VAlterNodeTypeFn(options)
+	}
+	return nil
+}
+
+// VAlterLoadBalanceGroup records the call and calls VAlterLoadBalanceGroupFn if it is set
+func (m *ClusterCommands) VAlterLoadBalanceGroup(options *vclusterops.VLoadBalanceGroupOptions) error {
+	m.recordCall("VAlterLoadBalanceGroup", options)
+	if m.VAlterLoadBalanceGroupFn != nil {
+		return m.VAlterLoadBalanceGroupFn(options)
+	}
+	return nil
+}
+
+// VAlterRoutingRule records the call and calls VAlterRoutingRuleFn if it is set
+func (m *ClusterCommands) VAlterRoutingRule(options *vclusterops.VRoutingRuleOptions) error {
+	m.recordCall("VAlterRoutingRule", options)
+	if m.VAlterRoutingRuleFn != nil {
+		return m.VAlterRoutingRuleFn(options)
+	}
+	return nil
+}
+
+// VGetConfigurationParameter records the call and calls VGetConfigurationParameterFn if it is set
+func (m *ClusterCommands) VGetConfigurationParameter(
+	options *vclusterops.VGetConfigurationParameterOptions) (vclusterops.ConfigParameterInfo, error) {
+	m.recordCall("VGetConfigurationParameter", options)
+	if m.VGetConfigurationParameterFn != nil {
+		return m.VGetConfigurationParameterFn(options)
+	}
+	return vclusterops.ConfigParameterInfo{}, nil
+}
+
+// VSetConfigurationParameter records the call and calls VSetConfigurationParameterFn if it is set
+func (m *ClusterCommands) VSetConfigurationParameter(
+	options *vclusterops.VSetConfigurationParameterOptions) (vclusterops.ConfigParameterChange, error) {
+	m.recordCall("VSetConfigurationParameter", options)
+	if m.VSetConfigurationParameterFn != nil {
+		return m.VSetConfigurationParameterFn(options)
+	}
+	return vclusterops.ConfigParameterChange{}, nil
+}
+
+// VAlterSpreadEncryption records the call and calls VAlterSpreadEncryptionFn if it is set
+func (m *ClusterCommands) VAlterSpreadEncryption(options *vclusterops.VSpreadEncryptionOptions) error {
+	m.recordCall("VAlterSpreadEncryption", options)
+	if m.VAlterSpreadEncryptionFn != nil {
+		return m.VAlterSpreadEncryptionFn(options)
+	}
+	return nil
+}
+
+// VRotateDBPassword records the call and calls VRotateDBPasswordFn if it is set
+func (m *ClusterCommands) VRotateDBPassword(options *vclusterops.VRotateDBPasswordOptions) error {
+	m.recordCall("VRotateDBPassword", options)
+	if m.VRotateDBPasswordFn != nil {
+		return m.VRotateDBPasswordFn(options)
+	}
+	return nil
+}
+
+// VManageNMA records the call and calls VManageNMAFn if it is set
+func (m *ClusterCommands) VManageNMA(options *vclusterops.VManageNMAOptions) error {
+	m.recordCall("VManageNMA", options)
+	if m.VManageNMAFn != nil {
+		return m.VManageNMAFn(options)
+	}
+	return nil
+}
+
+// VRealignControlNodes records the call and calls VRealignControlNodesFn if it is set
+func (m *ClusterCommands) VRealignControlNodes(options *vclusterops.VRealignControlNodesOptions) (map[string]string, error) {
+	m.recordCall("VRealignControlNodes", options)
+	if m.VRealignControlNodesFn != nil {
+		return m.VRealignControlNodesFn(options)
+	}
+	return nil, nil
+}
+
+// VSendCustomRequest records the call and calls VSendCustomRequestFn if it is set
+func (m *ClusterCommands) VSendCustomRequest(
+	options *vclusterops.VCustomRequestOptions) (map[string]vclusterops.CustomRequestResult, error) {
+	m.recordCall("VSendCustomRequest", options)
+	if m.VSendCustomRequestFn != nil {
+		return m.VSendCustomRequestFn(options)
+	}
+	return nil, nil
+}
+
+// VForceRestartDatabase records the call and calls VForceRestartDatabaseFn if it is set
+func (m *ClusterCommands) VForceRestartDatabase(options *vclusterops.VForceRestartDatabaseOptions) ([]string, error) {
+	m.recordCall("VForceRestartDatabase", options)
+	if m.VForceRestartDatabaseFn != nil {
+		return m.VForceRestartDatabaseFn(options)
+	}
+	return nil, nil
+}
+
+// VSyncCatalog records the call and calls VSyncCatalogFn if it is set
+func (m *ClusterCommands) VSyncCatalog(options *vclusterops.VCatalogTruncationVersionOptions) (int64, error) {
+	m.recordCall("VSyncCatalog", options)
+	if m.VSyncCatalogFn != nil {
+		return m.VSyncCatalogFn(options)
+	}
+	return 0, nil
+}
+
+// VGetCatalogTruncationVersion records the call and calls VGetCatalogTruncationVersionFn if it is set
+func (m *ClusterCommands) VGetCatalogTruncationVersion(options *vclusterops.VCatalogTruncationVersionOptions) (int64, error) {
+	m.recordCall("VGetCatalogTruncationVersion", options)
+	if m.VGetCatalogTruncationVersionFn != nil {
+		return m.VGetCatalogTruncationVersionFn(options)
+	}
+	return 0, nil
+}
+
+// VCollectLogs records the call and calls VCollectLogsFn if it is set
+func (m *ClusterCommands) VCollectLogs(options *vclusterops.VCollectLogsOptions) (string, error) {
+	m.recordCall("VCollectLogs", options)
+	if m.VCollectLogsFn != nil {
+		return m.VCollectLogsFn(options)
+	}
+	return "", nil
+}
+
+// VDiffTopology records the call and calls VDiffTopologyFn if it is set
+func (m *ClusterCommands) VDiffTopology(options *vclusterops.VDiffTopologyOptions) (vclusterops.VTopologyDiff, error) {
+	m.recordCall("VDiffTopology", options)
+	if m.VDiffTopologyFn != nil {
+		return m.VDiffTopologyFn(options)
+	}
+	return vclusterops.VTopologyDiff{}, nil
+}
+
+// VFetchRunningCoordinationDatabase records the call and calls VFetchRunningCoordinationDatabaseFn if it is set
+func (m *ClusterCommands) VFetchRunningCoordinationDatabase(
+	options *vclusterops.VFetchCoordinationDatabaseOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VFetchRunningCoordinationDatabase", options)
+	if m.VFetchRunningCoordinationDatabaseFn != nil {
+		return m.VFetchRunningCoordinationDatabaseFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VHibernateDatabase records the call and calls VHibernateDatabaseFn if it is set
+func (m *ClusterCommands) VHibernateDatabase(options *vclusterops.VHibernateDatabaseOptions) error {
+	m.recordCall("VHibernateDatabase", options)
+	if m.VHibernateDatabaseFn != nil {
+		return m.VHibernateDatabaseFn(options)
+	}
+	return nil
+}
+
+// VWakeDatabase records the call and calls VWakeDatabaseFn if it is set
+func (m *ClusterCommands) VWakeDatabase(options *vclusterops.VWakeDatabaseOptions) (*vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VWakeDatabase", options)
+	if m.VWakeDatabaseFn != nil {
+		return m.VWakeDatabaseFn(options)
+	}
+	return nil, nil
+}
+
+// VPauseSubcluster records the call and calls VPauseSubclusterFn if it is set
+func (m *ClusterCommands) VPauseSubcluster(options *vclusterops.VPauseSubclusterOptions) (vclusterops.VCoordinationDatabase, error) {
+	m.recordCall("VPauseSubcluster", options)
+	if m.VPauseSubclusterFn != nil {
+		return m.VPauseSubclusterFn(options)
+	}
+	return vclusterops.VCoordinationDatabase{}, nil
+}
+
+// VScaleSubcluster records the call and calls VScaleSubclusterFn if it is set
+func (m *ClusterCommands) VScaleSubcluster(options *vclusterops.VScaleSubclusterOptions) (vclusterops.VScaleSubclusterResult, error) {
+	m.recordCall("VScaleSubcluster", options)
+	if m.VScaleSubclusterFn != nil {
+		return m.VScaleSubclusterFn(options)
+	}
+	return vclusterops.VScaleSubclusterResult{}, nil
+}
+
+// VFetchDatabaseInfo records the call and calls VFetchDatabaseInfoFn if it is set
+func (m *ClusterCommands) VFetchDatabaseInfo(options *vclusterops.VFetchDatabaseInfoOptions) (vclusterops.VDatabaseInfo, error) {
+	m.recordCall("VFetchDatabaseInfo", options)
+	if m.VFetchDatabaseInfoFn != nil {
+		return m.VFetchDatabaseInfoFn(options)
+	}
+	return vclusterops.VDatabaseInfo{}, nil
+}
+
+// VDistributeFile records the call and calls VDistributeFileFn if it is set
+func (m *ClusterCommands) VDistributeFile(options *vclusterops.VDistributeFileOptions) (vclusterops.VDistributeFileResult, error) {
+	m.recordCall("VDistributeFile", options)
+	if m.VDistributeFileFn != nil {
+		return m.VDistributeFileFn(options)
+	}
+	return vclusterops.VDistributeFileResult{}, nil
+}
+
+// VPollSubscriptionState records the call and calls VPollSubscriptionStateFn if it is set
+func (m *ClusterCommands) VPollSubscriptionState(
+	options *vclusterops.VPollSubscriptionStateOptions) (vclusterops.VSubscriptionState, error) {
+	m.recordCall("VPollSubscriptionState", options)
+	if m.VPollSubscriptionStateFn != nil {
+		return m.VPollSubscriptionStateFn(options)
+	}
+	return vclusterops.VSubscriptionState{}, nil
+}
+
+// VListSessions records the call and calls VListSessionsFn if it is set
+func (m *ClusterCommands) VListSessions(options *vclusterops.VListSessionsOptions) ([]vclusterops.SessionInfo, error) {
+	m.recordCall("VListSessions", options)
+	if m.VListSessionsFn != nil {
+		return m.VListSessionsFn(options)
+	}
+	return nil, nil
+}
+
+// VCloseSessions records the call and calls VCloseSessionsFn if it is set
+func (m *ClusterCommands) VCloseSessions(options *vclusterops.VCloseSessionsOptions) ([]vclusterops.SessionInfo, error) {
+	m.recordCall("VCloseSessions", options)
+	if m.VCloseSessionsFn != nil {
+		return m.VCloseSessionsFn(options)
+	}
+	return nil, nil
+}
+
+// VRollingUpgrade records the call and calls VRollingUpgradeFn if it is set
+func (m *ClusterCommands) VRollingUpgrade(options *vclusterops.VRollingUpgradeOptions) error {
+	m.recordCall("VRollingUpgrade", options)
+	if m.VRollingUpgradeFn != nil {
+		return m.VRollingUpgradeFn(options)
+	}
+	return nil
+}
+
+// VCheckPrerequisites records the call and calls VCheckPrerequisitesFn if it is set
+func (m *ClusterCommands) VCheckPrerequisites(options *vclusterops.VCheckPrerequisitesOptions) (vclusterops.PrerequisiteReport, error) {
+	m.recordCall("VCheckPrerequisites", options)
+	if m.VCheckPrerequisitesFn != nil {
+		return m.VCheckPrerequisitesFn(options)
+	}
+	return vclusterops.PrerequisiteReport{}, nil
+}
+
+// VCheckLicenseCompliance records the call and calls VCheckLicenseComplianceFn if it is set
+func (m *ClusterCommands) VCheckLicenseCompliance(
+	options *vclusterops.VCheckLicenseComplianceOptions) (vclusterops.LicenseComplianceReport, error) {
+	m.recordCall("VCheckLicenseCompliance", options)
+	if m.VCheckLicenseComplianceFn != nil {
+		return m.VCheckLicenseComplianceFn(options)
+	}
+	return vclusterops.LicenseComplianceReport{}, nil
+}
+
+// VListSubclusters records the call and calls VListSubclustersFn if it is set
+func (m *ClusterCommands) VListSubclusters(options *vclusterops.VListSubclustersOptions) ([]vclusterops.SubclusterDetails, error) {
+	m.recordCall("VListSubclusters", options)
+	if m.VListSubclustersFn != nil {
+		return m.VListSubclustersFn(options)
+	}
+	return nil, nil
+}
+
+// VRenameSubcluster records the call and calls VRenameSubclusterFn if it is set
+func (m *ClusterCommands) VRenameSubcluster(options *vclusterops.VRenameSubclusterOptions) error {
+	m.recordCall("VRenameSubcluster", options)
+	if m.VRenameSubclusterFn != nil {
+		return m.VRenameSubclusterFn(options)
+	}
+	return nil
+}
+
+// VFetchNodesDetails records the call and calls VFetchNodesDetailsFn if it is set
+func (m *ClusterCommands) VFetchNodesDetails(options *vclusterops.VFetchNodesDetailsOptions) (vclusterops.NodesDetails, error) {
+	m.recordCall("VFetchNodesDetails", options)
+	if m.VFetchNodesDetailsFn != nil {
+		return m.VFetchNodesDetailsFn(options)
+	}
+	return nil, nil
+}
+
+// VCheckVClusterServerPid records the call and calls VCheckVClusterServerPidFn if it is set
+func (m *ClusterCommands) VCheckVClusterServerPid(
+	options *vclusterops.VCheckVClusterServerPidOptions) ([]vclusterops.HostProcesses, error) {
+	m.recordCall("VCheckVClusterServerPid", options)
+	if m.VCheckVClusterServerPidFn != nil {
+		return m.VCheckVClusterServerPidFn(options)
+	}
+	return nil, nil
+}
+
+// VKillVertica records the call and calls VKillVerticaFn if it is set
+func (m *ClusterCommands) VKillVertica(options *vclusterops.VKillVerticaOptions) error {
+	m.recordCall("VKillVertica", options)
+	if m.VKillVerticaFn != nil {
+		return m.VKillVerticaFn(options)
+	}
+	return nil
+}
+
+// VVerifyCatalog records the call and calls VVerifyCatalogFn if it is set
+func (m *ClusterCommands) VVerifyCatalog(options *vclusterops.VVerifyCatalogOptions) (vclusterops.CatalogVerificationReport, error) {
+	m.recordCall("VVerifyCatalog", options)
+	if m.VVerifyCatalogFn != nil {
+		return m.VVerifyCatalogFn(options)
+	}
+	return vclusterops.CatalogVerificationReport{}, nil
+}
+
+// VAlterDepotSize records the call and calls VAlterDepotSizeFn if it is set
+func (m *ClusterCommands) VAlterDepotSize(options *vclusterops.VAlterDepotSizeOptions) (map[string]string, error) {
+	m.recordCall("VAlterDepotSize", options)
+	if m.VAlterDepotSizeFn != nil {
+		return m.VAlterDepotSizeFn(options)
+	}
+	return nil, nil
+}
+
+// VAlterStorageLocation records the call and calls VAlterStorageLocationFn if it is set
+func (m *ClusterCommands) VAlterStorageLocation(options *vclusterops.VAlterStorageLocationOptions) error {
+	m.recordCall("VAlterStorageLocation", options)
+	if m.VAlterStorageLocationFn != nil {
+		return m.VAlterStorageLocationFn(options)
+	}
+	return nil
+}
+
+// VSetKSafety records the call and calls VSetKSafetyFn if it is set
+func (m *ClusterCommands) VSetKSafety(options *vclusterops.VSetKSafetyOptions) error {
+	m.recordCall("VSetKSafety", options)
+	if m.VSetKSafetyFn != nil {
+		return m.VSetKSafetyFn(options)
+	}
+	return nil
+}
+
+// VGetKSafety records the call and calls VGetKSafetyFn if it is set
+func (m *ClusterCommands) VGetKSafety(options *vclusterops.VGetKSafetyOptions) (vclusterops.KSafetyInfo, error) {
+	m.recordCall("VGetKSafety", options)
+	if m.VGetKSafetyFn != nil {
+		return m.VGetKSafetyFn(options)
+	}
+	return vclusterops.KSafetyInfo{}, nil
+}
+
+// VRebalanceShards records the call and calls VRebalanceShardsFn if it is set
+func (m *ClusterCommands) VRebalanceShards(options *vclusterops.VRebalanceShardsOptions) error {
+	m.recordCall("VRebalanceShards", options)
+	if m.VRebalanceShardsFn != nil {
+		return m.VRebalanceShardsFn(options)
+	}
+	return nil
+}
+
+// VRebalanceCluster records the call and calls VRebalanceClusterFn if it is set
+func (m *ClusterCommands) VRebalanceCluster(options *vclusterops.VRebalanceClusterOptions) error {
+	m.recordCall("VRebalanceCluster", options)
+	if m.VRebalanceClusterFn != nil {
+		return m.VRebalanceClusterFn(options)
+	}
+	return nil
+}
+
+// VManageConnectionDraining records the call and calls VManageConnectionDrainingFn if it is set
+func (m *ClusterCommands) VManageConnectionDraining(options *vclusterops.VManageConnectionDrainingOptions) error {
+	m.recordCall("VManageConnectionDraining", options)
+	if m.VManageConnectionDrainingFn != nil {
+		return m.VManageConnectionDrainingFn(options)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ (c) Copyright [2023-2024] Open Text.
+ Licensed under the Apache License, Version 2.0 (the "License");
+ You may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package vmock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vertica/vcluster/vclusterops"
+)
+
+// every command of the interface must have a function field in the mock
+func TestMockCompleteness(t *testing.T) {
+	iface := reflect.TypeOf((*vclusterops.ClusterCommands)(nil)).Elem()
+	mockType := reflect.TypeOf(ClusterCommands{})
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		if !strings.HasPrefix(method.Name, "V") || method.Name == "V" {
+			continue
+		}
+		field, ok := mockType.FieldByName(method.Name + "Fn")
+		if assert.True(t, ok, "field %sFn is missing in the mock", method.Name) {
+			assert.Equal(t, method.Type, field.Type, "field %sFn has a wrong type", method.Name)
+		}
+	}
+}
+
+func TestMockCalls(t *testing.T) {
+	m := MakeClusterCommands()
+	var vcc vclusterops.ClusterCommandsV1 = m
+	assert.Equal(t, vclusterops.ClusterCommandsAPIVersion, vcc.APIVersion())
+
+	// a command without a function succeeds with zero values
+	vdb, err := vcc.VAddNode(&vclusterops.VAddNodeOptions{SCName: "sc1"})
+	assert.NoError(t, err)
+	assert.Empty(t, vdb.HostList)
+
+	// a command with a function returns its results
+	m.VStopDatabaseFn = func(options *vclusterops.VStopDatabaseOptions) error {
+		return errors.New("database " + options.DBName + " is down")
+	}
+	stopOptions := vclusterops.VStopDatabaseOptionsFactory()
+	stopOptions.DBName = "test_db"
+	err = vcc.VStopDatabase(&stopOptions)
+	assert.EqualError(t, err, "database test_db is down")
+
+	calls := m.Calls()
+	assert.Len(t, calls, 2)
+	assert.Equal(t, "VAddNode", calls[0].Method)
+	assert.Equal(t, []any{&stopOptions}, m.CallsOf("VStopDatabase"))
+	assert.Empty(t, m.CallsOf("VStartDatabase"))
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	assert.Same(t, m, vcc.WithContext(ctx))
+	assert.Equal(t, ctx, m.Ctx)
+}